@@ -3,14 +3,18 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/pdiddy/research-engine/internal/acquire"
+	"github.com/pdiddy/research-engine/internal/search"
 	"github.com/pdiddy/research-engine/pkg/types"
 )
 
@@ -23,25 +27,357 @@ const (
 var acquireCmd = &cobra.Command{
 	Use:   "acquire [identifiers...]",
 	Short: "Download papers from URLs, DOIs, or arXiv IDs",
-	Long: `Acquire resolves paper identifiers (arXiv IDs, DOIs, direct PDF URLs)
-to PDF files, downloads them, and creates metadata records. Existing papers
-are skipped.`,
+	Long: `Acquire resolves paper identifiers (arXiv IDs, DOIs, direct PDF URLs,
+and US, EP, WO, JP, CN, or KR patent numbers) to PDF files, downloads them,
+and creates metadata records. Existing papers are skipped. A download that
+is not a PDF (for example an empty or truncated file) is deleted and
+reported as a failure with the content type received; --verify-xref also
+rejects truncated PDFs.
+
+Non-US patents are downloaded from Espacenet and their metadata comes from
+EPO Open Patent Services, which needs a consumer key and secret in
+.secrets/epo-ops-key and .secrets/epo-ops-secret; without them the PDF is
+still downloaded.
+
+Use --from-query to acquire the results of a saved search query file. By
+default every result is acquired; --status keep restricts the batch to the
+shortlist marked with search annotate, and --top N to the N best-ranked
+selected results.
+
+Each identifier's status is recorded in papers/acquisition-manifest.yaml as
+it completes. After an interrupted run or transient failures (network
+errors, HTTP 429 or 5xx), rerun with --resume: identifiers not yet attempted
+and transient failures are processed again, while completed identifiers and
+permanent failures are skipped. Identifiers given with --resume that the
+manifest does not list are added to the batch.
+
+Use --metadata-only to record metadata (from arXiv, Crossref, or
+PatentsView) without downloading PDFs, for paywalled works the knowledge
+base should still know about. Such records have status metadata_only;
+acquiring the paper again without the flag downloads its PDF.
+
+A download that returns an HTML page instead of a PDF, typically a
+publisher paywall or landing page, is not saved: the paper's metadata is
+recorded with status paywalled. Acquiring it again looks for an open-access
+copy anew; acquire recheck-oa also asks Unpaywall.
+
+Use --dry-run to vet a large batch first: identifiers are classified and
+resolved (querying OpenAlex, NCBI, and Crossref as a real run would), and
+each PDF that would be downloaded is listed with its source, URL, and size
+from a HEAD request where the server answers one. Nothing is written, not
+even the manifest.
+
+Use --json to print the batch result as JSON on stdout: the counts and, for
+each identifier, its status (downloaded, skipped, failed, no_pdf,
+metadata_only, paywalled, or not_attempted), paper ID, PDF and metadata paths, and error,
+with transient failures marked. Progress lines go to stderr instead. With
+--dry-run, --json prints the plan.
+
+Use --max-file-size to abort downloads larger than a limit (checked
+against Content-Length and again while reading, since servers may not send
+it) and --max-total-size to cap the papers directory: a download that would
+take the directory past the quota is aborted and the batch stops, leaving
+it and the remaining identifiers pending in the manifest for --resume once
+the quota is raised. Sizes take KB, MB, GB, or TB suffixes (powers of
+1024); the defaults come from acquire.max_file_size and
+acquire.max_total_size in the config file.
+
+Use --force to download papers again even when their PDF exists, replacing
+the PDF and metadata; the old PDF is kept if the new download fails. To fix
+a papers directory in place, run acquire repair.
+
+Users with institutional access can download paywalled PDFs through their
+library: --proxy prepends an EZproxy prefix (for example
+https://login.ezproxy.example.edu/login?url=) to publisher download URLs,
+--header adds HTTP headers to PDF downloads, and --cookies loads the session
+cookies of a browser login from a cookies.txt file. The same settings can be
+kept under acquire.proxy, acquire.headers, and acquire.cookie_file in the
+config file. Open-access copies, arXiv, PubMed Central, and patents are
+downloaded directly.
+
+Use --from-zotero to import a collection (given by name or key) from a
+Zotero library through the Zotero Web API: each item's stored PDF
+attachment is downloaded into papers/raw and its Zotero metadata written to
+papers/metadata. Items are stored under the ID their DOI, arXiv ID, or ISBN
+gives (zotero-<key> otherwise), and each record keeps its Zotero item key;
+items without a PDF attachment are recorded as metadata only. The library is
+--zotero-library (users/<id> or groups/<id>, default from
+acquire.zotero_library) and the API key is read from
+.secrets/zotero-api-key.
+
+Requests are paced per host: metadata APIs use their published rate limits
+and other hosts get one request per --delay. Use --rate-limit host=rate to
+override a host.`,
 	RunE: runAcquire,
 }
 
+var acquireRepairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Re-acquire corrupted or missing PDFs and rebuild missing metadata",
+	Long: `Repair scans papers/raw/ for files that are not usable PDFs (zero bytes,
+HTML login or error pages, or with --verify-xref truncated files) and
+downloads them again, rebuilds the metadata record of PDFs that have none,
+and re-acquires papers whose metadata record names a PDF that is missing.
+
+Identifiers are recovered from the metadata record, the acquisition
+manifest, or the paper ID. A paper whose identifier cannot be recovered (for
+example a DOI known only by its slug) is reported; acquire it again with
+acquire --force <identifier>. Use --dry-run to list the problems without
+changing anything.`,
+	Args: cobra.NoArgs,
+	RunE: runAcquireRepair,
+}
+
+var acquireRecheckOACmd = &cobra.Command{
+	Use:   "recheck-oa",
+	Short: "Download open-access copies of papers recorded as paywalled",
+	Long: `Recheck-oa asks OpenAlex and Unpaywall again for an open-access copy of
+every paper recorded with status paywalled, since publisher embargoes
+commonly lapse while a survey is being written, and downloads the copies
+found. A downloaded paper's record loses its paywalled status and names the
+open-access source; papers still without a copy are left as they are.
+
+Unpaywall requires a contact email: --email, acquire.email in the config
+file, or .secrets/openalex-email. Without one only OpenAlex is asked.
+Paywalled records without a DOI cannot be looked up and are reported. Use
+--dry-run to list the open-access copies without downloading them.`,
+	Args: cobra.NoArgs,
+	RunE: runAcquireRecheckOA,
+}
+
 func init() {
 	acquireCmd.Flags().Duration("timeout", 0, "HTTP request timeout (default 60s)")
-	acquireCmd.Flags().Duration("delay", 0, "delay between consecutive downloads (default 1s)")
+	acquireCmd.Flags().Duration("delay", 0, "minimum interval between requests to a host without a built-in rate limit (default 1s)")
 	acquireCmd.Flags().String("papers-dir", "papers", "base directory for papers")
+	addFailOnFlag(acquireCmd)
+	acquireCmd.Flags().String("from-query", "", "also acquire the results of this search query file")
+	acquireCmd.Flags().Int("top", 0, "with --from-query, acquire only the N best-ranked selected results")
+	acquireCmd.Flags().String("status", "", "with --from-query, acquire only results with this triage status: keep, reject, or untriaged")
+	acquireCmd.Flags().Bool("verify-xref", false, "also reject PDFs whose cross-reference trailer is missing or broken (truncated downloads)")
+	acquireCmd.Flags().Bool("metadata-only", false, "write metadata records without downloading PDFs")
+	acquireCmd.Flags().Bool("resume", false, "continue the batch recorded in the acquisition manifest, retrying transient failures only")
+	acquireCmd.Flags().Bool("force", false, "download papers again even if their PDF exists, replacing the PDF and metadata")
+	acquireCmd.Flags().Bool("dry-run", false, "classify and resolve the identifiers and print what would be downloaded, with sizes, without writing anything")
+	acquireCmd.Flags().Bool("json", false, "print the batch result (or the --dry-run plan) as JSON on stdout; progress goes to stderr")
+	acquireCmd.Flags().String("from-zotero", "", "also import the PDFs and metadata of this Zotero collection (name or key)")
+	acquireCmd.Flags().String("zotero-library", "", "Zotero library for --from-zotero: users/<id> or groups/<id> (default from acquire.zotero_library)")
+	addRateLimitFlag(acquireCmd)
+	addInstitutionalAccessFlags(acquireCmd)
+	addSizeLimitFlags(acquireCmd)
 
+	acquireRepairCmd.Flags().Duration("timeout", 0, "HTTP request timeout (default 60s)")
+	acquireRepairCmd.Flags().Duration("delay", 0, "minimum interval between requests to a host without a built-in rate limit (default 1s)")
+	acquireRepairCmd.Flags().String("papers-dir", "papers", "base directory for papers")
+	acquireRepairCmd.Flags().Bool("verify-xref", false, "also treat PDFs whose cross-reference trailer is missing or broken as corrupt")
+	acquireRepairCmd.Flags().Bool("dry-run", false, "report problems without changing files")
+	addRateLimitFlag(acquireRepairCmd)
+	addInstitutionalAccessFlags(acquireRepairCmd)
+	addSizeLimitFlags(acquireRepairCmd)
+
+	acquireRecheckOACmd.Flags().Duration("timeout", 0, "HTTP request timeout (default 60s)")
+	acquireRecheckOACmd.Flags().Duration("delay", 0, "minimum interval between requests to a host without a built-in rate limit (default 1s)")
+	acquireRecheckOACmd.Flags().String("papers-dir", "papers", "base directory for papers")
+	acquireRecheckOACmd.Flags().Bool("verify-xref", false, "also reject PDFs whose cross-reference trailer is missing or broken (truncated downloads)")
+	acquireRecheckOACmd.Flags().Bool("dry-run", false, "list the open-access copies found without downloading them")
+	acquireRecheckOACmd.Flags().String("email", "", "contact email for Unpaywall (default from acquire.email or .secrets/openalex-email)")
+	addRateLimitFlag(acquireRecheckOACmd)
+	addSizeLimitFlags(acquireRecheckOACmd)
+
+	acquireCmd.AddCommand(acquireRepairCmd)
+	acquireCmd.AddCommand(acquireRecheckOACmd)
 	rootCmd.AddCommand(acquireCmd)
 }
 
 func runAcquire(cmd *cobra.Command, args []string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("provide one or more paper identifiers (arXiv IDs, DOIs, or URLs)")
+	fromQuery, _ := cmd.Flags().GetString("from-query")
+	top, _ := cmd.Flags().GetInt("top")
+	statusFlag, _ := cmd.Flags().GetString("status")
+	if fromQuery == "" && (top != 0 || statusFlag != "") {
+		return fmt.Errorf("--top and --status require --from-query")
+	}
+	if fromQuery != "" {
+		ids, err := queryFileIDs(fromQuery, statusFlag, top)
+		if err != nil {
+			return err
+		}
+		args = append(args, ids...)
+	}
+	papersDir, _ := cmd.Flags().GetString("papers-dir")
+	if resume, _ := cmd.Flags().GetBool("resume"); resume {
+		manifest, err := acquire.LoadManifest(acquire.ManifestPath(papersDir))
+		if err != nil {
+			return err
+		}
+		args = manifest.Resume(args)
+		if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, "nothing to resume: every identifier in the manifest is done or failed permanently")
+			return nil
+		}
+		fmt.Fprintf(os.Stderr, "resuming %d identifiers\n", len(args))
+	}
+	fromZotero, _ := cmd.Flags().GetString("from-zotero")
+	if len(args) == 0 && fromZotero == "" {
+		return fmt.Errorf("provide one or more paper identifiers (arXiv IDs, DOIs, or URLs), --from-query, --from-zotero, or --resume")
+	}
+	var zotero acquire.ZoteroLibrary
+	if fromZotero != "" {
+		var err error
+		if zotero, err = zoteroLibrary(cmd); err != nil {
+			return err
+		}
+	}
+
+	policy, err := failPolicyFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := acquisitionConfig(cmd, papersDir)
+	if err != nil {
+		return err
+	}
+	cfg.MetadataOnly, _ = cmd.Flags().GetBool("metadata-only")
+	cfg.Force, _ = cmd.Flags().GetBool("force")
+
+	footer := newRunFooter()
+	defer footer.print(os.Stderr)
+	client, err := acquisitionClient(footer, cfg)
+	if err != nil {
+		return err
+	}
+
+	// With --json the progress lines go to stderr and stdout carries only
+	// the JSON document.
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	var out io.Writer = os.Stdout
+	if jsonOutput {
+		out = os.Stderr
+	}
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		if fromZotero != "" {
+			return fmt.Errorf("--dry-run does not support --from-zotero")
+		}
+		plan := acquire.PlanBatch(client, args, cfg, out)
+		if jsonOutput {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(plan); err != nil {
+				return err
+			}
+		}
+		return policy.check("acquisition", plan.Failed, len(plan.Items))
 	}
 
+	var result acquire.BatchResult
+	if len(args) > 0 {
+		result = acquire.AcquireBatch(client, args, cfg, out)
+	}
+	var zoteroErr error
+	if fromZotero != "" {
+		var imported acquire.BatchResult
+		imported, zoteroErr = acquire.ImportZotero(client, zotero, fromZotero, cfg, out)
+		result.Merge(imported)
+	}
+	footer.cache(result.Skipped, result.Total())
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	}
+	if zoteroErr != nil {
+		return zoteroErr
+	}
+	if result.Stopped != "" {
+		return fmt.Errorf("acquisition stopped: %s", result.Stopped)
+	}
+	return policy.check("acquisition", result.Failed, result.Total())
+}
+
+// zoteroLibrary returns the library --from-zotero reads, from
+// --zotero-library or acquire.zotero_library, with the API key from
+// .secrets/zotero-api-key.
+func zoteroLibrary(cmd *cobra.Command) (acquire.ZoteroLibrary, error) {
+	library, _ := cmd.Flags().GetString("zotero-library")
+	if library == "" {
+		library = viper.GetString("acquire.zotero_library")
+	}
+	if library == "" {
+		return acquire.ZoteroLibrary{}, fmt.Errorf("--from-zotero needs a library: set --zotero-library or acquire.zotero_library to users/<id> or groups/<id>")
+	}
+	path, err := acquire.ParseZoteroLibrary(library)
+	if err != nil {
+		return acquire.ZoteroLibrary{}, err
+	}
+	key := secretDefault("zotero-api-key", "")
+	if key == "" {
+		return acquire.ZoteroLibrary{}, fmt.Errorf("--from-zotero needs a Zotero API key in .secrets/zotero-api-key")
+	}
+	return acquire.ZoteroLibrary{Path: path, APIKey: key}, nil
+}
+
+func runAcquireRepair(cmd *cobra.Command, args []string) error {
+	papersDir, _ := cmd.Flags().GetString("papers-dir")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	cfg, err := acquisitionConfig(cmd, papersDir)
+	if err != nil {
+		return err
+	}
+
+	footer := newRunFooter()
+	defer footer.print(os.Stderr)
+	client, err := acquisitionClient(footer, cfg)
+	if err != nil {
+		return err
+	}
+
+	summary, err := acquire.Repair(client, cfg, dryRun, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d paper(s) could not be repaired", summary.Failed)
+	}
+	return nil
+}
+
+func runAcquireRecheckOA(cmd *cobra.Command, args []string) error {
+	papersDir, _ := cmd.Flags().GetString("papers-dir")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	cfg, err := acquisitionConfig(cmd, papersDir)
+	if err != nil {
+		return err
+	}
+	cfg.Email, _ = cmd.Flags().GetString("email")
+	if cfg.Email == "" {
+		cfg.Email = viper.GetString("acquire.email")
+	}
+	if cfg.Email == "" {
+		cfg.Email = secretDefault("openalex-email", "")
+	}
+
+	footer := newRunFooter()
+	defer footer.print(os.Stderr)
+	client, err := acquisitionClient(footer, cfg)
+	if err != nil {
+		return err
+	}
+
+	summary, err := acquire.RecheckOA(client, cfg, dryRun, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d open-access download(s) failed", summary.Failed)
+	}
+	return nil
+}
+
+// acquisitionConfig builds the acquisition settings shared by acquire and
+// its subcommands from the command's flags.
+func acquisitionConfig(cmd *cobra.Command, papersDir string) (types.AcquisitionConfig, error) {
 	timeout, _ := cmd.Flags().GetDuration("timeout")
 	if timeout == 0 {
 		timeout = defaultTimeout
@@ -50,24 +386,115 @@ func runAcquire(cmd *cobra.Command, args []string) error {
 	if delay == 0 {
 		delay = defaultDelay
 	}
-	papersDir, _ := cmd.Flags().GetString("papers-dir")
-
+	verifyXref, _ := cmd.Flags().GetBool("verify-xref")
+	rateLimits, err := rateLimitsFromFlags(cmd)
+	if err != nil {
+		return types.AcquisitionConfig{}, err
+	}
 	cfg := types.AcquisitionConfig{
 		HTTPConfig: types.HTTPConfig{
-			Timeout:   timeout,
-			UserAgent: defaultUserAgent,
+			Timeout:    timeout,
+			UserAgent:  defaultUserAgent,
+			RateLimits: rateLimits,
 		},
 		DownloadDelay: delay,
 		PapersDir:     papersDir,
+		VerifyXref:    verifyXref,
+		OPSKey:        secretDefault("epo-ops-key", ""),
+		OPSSecret:     secretDefault("epo-ops-secret", ""),
 	}
 
-	client := &http.Client{
-		Timeout: cfg.Timeout,
+	cfg.ProxyPrefix, _ = cmd.Flags().GetString("proxy")
+	if cfg.ProxyPrefix == "" {
+		cfg.ProxyPrefix = viper.GetString("acquire.proxy")
+	}
+	cfg.CookieFile, _ = cmd.Flags().GetString("cookies")
+	if cfg.CookieFile == "" {
+		cfg.CookieFile = viper.GetString("acquire.cookie_file")
+	}
+	cfg.Headers = viper.GetStringMapString("acquire.headers")
+	headers, _ := cmd.Flags().GetStringArray("header")
+	for _, h := range headers {
+		name, value, err := acquire.ParseHeader(h)
+		if err != nil {
+			return types.AcquisitionConfig{}, err
+		}
+		if cfg.Headers == nil {
+			cfg.Headers = make(map[string]string)
+		}
+		cfg.Headers[name] = value
 	}
 
-	result := acquire.AcquireBatch(client, args, cfg, os.Stdout)
-	if result.HasFailures() {
-		return fmt.Errorf("%d paper(s) failed acquisition", result.Failed)
+	for _, limit := range []struct {
+		flag, key string
+		dst       *int64
+	}{
+		{"max-file-size", "acquire.max_file_size", &cfg.MaxFileSize},
+		{"max-total-size", "acquire.max_total_size", &cfg.MaxTotalBytes},
+	} {
+		v, _ := cmd.Flags().GetString(limit.flag)
+		if v == "" {
+			v = viper.GetString(limit.key)
+		}
+		n, err := acquire.ParseSize(v)
+		if err != nil {
+			return types.AcquisitionConfig{}, fmt.Errorf("--%s: %w", limit.flag, err)
+		}
+		*limit.dst = n
 	}
-	return nil
+	return cfg, nil
+}
+
+// addInstitutionalAccessFlags registers the proxy, header, and cookie
+// flags for downloading through a library subscription.
+func addInstitutionalAccessFlags(cmd *cobra.Command) {
+	cmd.Flags().String("proxy", "", "institutional proxy prefix for publisher downloads, e.g. an EZproxy login URL ending in ?url= (default from acquire.proxy)")
+	cmd.Flags().StringArray("header", nil, `extra HTTP header for PDF downloads as "Name: value" (repeatable; adds to acquire.headers)`)
+	cmd.Flags().String("cookies", "", "cookies.txt file (Netscape format) with login session cookies (default from acquire.cookie_file)")
+}
+
+// addSizeLimitFlags registers the per-file size limit and the papers
+// directory quota.
+func addSizeLimitFlags(cmd *cobra.Command) {
+	cmd.Flags().String("max-file-size", "", "abort downloads larger than this, e.g. 50MB (default from acquire.max_file_size; none when unset)")
+	cmd.Flags().String("max-total-size", "", "stop when the papers directory would grow past this, e.g. 5GB (default from acquire.max_total_size; none when unset)")
+}
+
+// acquisitionClient returns the rate-limited HTTP client for acquisition,
+// carrying the cookies of cfg.CookieFile when set.
+func acquisitionClient(footer *runFooter, cfg types.AcquisitionConfig) (*http.Client, error) {
+	client := footer.client(cfg.Timeout, acquire.NewRateLimiter(cfg))
+	if cfg.CookieFile != "" {
+		jar, err := acquire.LoadCookieJar(cfg.CookieFile)
+		if err != nil {
+			return nil, err
+		}
+		client.Jar = jar
+	}
+	return client, nil
+}
+
+// queryFileIDs returns the acquisition IDs selected from a query file by
+// triage status and rank.
+func queryFileIDs(path, statusFlag string, top int) ([]string, error) {
+	if top < 0 {
+		return nil, fmt.Errorf("invalid --top %d: must be positive", top)
+	}
+	status, err := search.ParseTriageStatus(statusFlag, true)
+	if err != nil {
+		return nil, err
+	}
+	qf, err := search.ReadQueryFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ids := qf.AcquisitionIDs(status, top)
+	if len(ids) == 0 {
+		if status != "" {
+			return nil, fmt.Errorf("no results with status %s in %s", status, path)
+		}
+		return nil, fmt.Errorf("no results in %s", path)
+	}
+	fmt.Fprintf(os.Stderr, "acquiring %d results from %s\n", len(ids), path)
+	return ids, nil
 }
@@ -0,0 +1,295 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/pdiddy/research-engine/internal/audit"
+	"github.com/pdiddy/research-engine/internal/usage"
+)
+
+// replayEnv carries the ID of the run being replayed to the replaying
+// process, which records it in its audit entry.
+const replayEnv = "RESEARCH_ENGINE_REPLAY_OF"
+
+// auditedCommands are the commands that change the corpus: papers,
+// conversions, extractions, the knowledge base, notes, or query files.
+var auditedCommands = map[*cobra.Command]bool{}
+
+func init() {
+	viper.SetDefault("audit_log", true)
+
+	for _, c := range []*cobra.Command{
+		searchCmd, searchAnnotateCmd,
+		screenDecideCmd, screenResolveCmd,
+		acquireCmd, acquireRepairCmd, acquireRecheckOACmd,
+		convertCmd,
+		extractCmd, extractRedoAllCmd,
+		idMigrateDOIsCmd,
+		knowledgeStoreCmd, knowledgeRebuildCmd, knowledgeNoteAbsenceCmd,
+		knowledgeItemAddCmd, knowledgeItemEditCmd, knowledgeItemDeleteCmd,
+		knowledgeVerifyCmd, knowledgeDisputeCmd,
+	} {
+		auditedCommands[c] = true
+	}
+
+	replayCmd.Flags().Bool("show", false, "print the recorded run and the command it would run, without running it")
+	replayCmd.Flags().Bool("strict", false, "refuse to replay when the config file, directory, or version differs from the recorded run")
+	rootCmd.AddCommand(replayCmd)
+
+	reportAuditCmd.Flags().Int("last", 20, "show only the N most recent runs (0 = all)")
+	reportAuditCmd.Flags().Bool("json", false, "output the runs as JSON")
+	reportCmd.AddCommand(reportAuditCmd)
+}
+
+// mutates reports whether the run of cmd changes the corpus. Dry runs and
+// checks do not, nor does a search without a query file to write or a
+// knowledge verify without items to mark.
+func mutates(cmd *cobra.Command) bool {
+	if !auditedCommands[cmd] {
+		return false
+	}
+	for _, name := range []string{"dry-run", "check"} {
+		if v, err := cmd.Flags().GetBool(name); err == nil && v {
+			return false
+		}
+	}
+	if cmd == searchCmd {
+		return flagOrDefault(cmd, "query-file", "") != ""
+	}
+	if cmd == knowledgeVerifyCmd {
+		// Verify changes the corpus only when it marks items.
+		return cmd.Flags().NArg() > 0
+	}
+	return true
+}
+
+// secretFlag reports whether a flag's value must not be written to the
+// audit log: API keys, secrets, tokens, passwords, and request headers.
+func secretFlag(name string) bool {
+	for _, suffix := range []string{"key", "secret", "token", "password"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return name == "header"
+}
+
+// recordAudit appends the run of cmd to the audit log when it changes the
+// corpus. Failures to write the log are reported but never change the
+// command's outcome.
+func recordAudit(cmd *cobra.Command, start time.Time, runErr error) {
+	if cmd == nil || !viper.GetBool("audit_log") || !mutates(cmd) {
+		return
+	}
+	if help, _ := cmd.Flags().GetBool("help"); help {
+		return
+	}
+
+	entry := audit.Entry{
+		ID:       audit.NewID(start),
+		Time:     start,
+		Command:  strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" "),
+		Args:     audit.RedactArgs(os.Args[1:], secretFlag),
+		Version:  version,
+		Duration: time.Since(start),
+		OK:       runErr == nil,
+		ReplayOf: os.Getenv(replayEnv),
+	}
+	if runErr != nil {
+		entry.Error = runErr.Error()
+	}
+	entry.Dir, _ = os.Getwd()
+	if cfg := viper.ConfigFileUsed(); cfg != "" {
+		entry.ConfigFile = cfg
+		hash, err := audit.HashConfig(cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: recording audit: %v\n", err)
+		}
+		entry.ConfigHash = hash
+	}
+	if f := activeFooter; f != nil {
+		entry.Results.Items, entry.Results.Skipped = f.cacheLookups, f.cacheHits
+		for _, c := range f.calls.Counts() {
+			entry.Results.APICalls = append(entry.Results.APICalls, audit.APICalls{Host: c.Host, Calls: c.Calls})
+		}
+		entry.Results.InputTokens, entry.Results.OutputTokens = f.inputTokens, f.outputTokens
+	}
+	entry.Results.Corpus = usage.CountCorpus(flagOrDefault(cmd, "papers-dir", "papers"), flagOrDefault(cmd, "knowledge-dir", "knowledge"))
+
+	if err := audit.Append(audit.DefaultPath, entry); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: recording audit: %v\n", err)
+	}
+}
+
+// --- replay command ---
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <run-id>",
+	Short: "Re-run a command from the audit log with the same arguments",
+	Long: `Replay re-executes a run recorded in the audit log
+(.research-engine/audit.log) with the arguments it was given, so that how
+the corpus was built can be reproduced. A unique prefix of the run ID is
+enough; list runs with "report audit".
+
+Secret flag values (API keys, secrets, tokens, headers) are not recorded, so
+replay leaves those flags out and the keys come from .secrets/ and the
+config as for any run. Replay warns when the config file's contents, the
+working directory, or the research-engine version differ from the recorded
+run; --strict refuses to run instead. The replayed run is recorded in the
+audit log with a reference to the original.
+
+Use --show to print the recorded run and the command without running it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReplay,
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	show, _ := cmd.Flags().GetBool("show")
+	strict, _ := cmd.Flags().GetBool("strict")
+
+	entries, err := audit.Read(audit.DefaultPath)
+	if err != nil {
+		return err
+	}
+	entry, err := audit.Find(entries, args[0])
+	if err != nil {
+		return err
+	}
+	replayArgs, dropped := audit.ReplayArgs(entry)
+
+	status := "ok"
+	if !entry.OK {
+		status = "failed: " + entry.Error
+	}
+	fmt.Fprintf(os.Stdout, "Run %s (%s, %s)\n", entry.ID, entry.Time.Local().Format("2006-01-02 15:04:05"), status)
+	fmt.Fprintf(os.Stdout, "  %s %s\n", rootCmd.Name(), strings.Join(quoteArgs(replayArgs), " "))
+	for _, name := range dropped {
+		fmt.Fprintf(os.Stderr, "note: --%s was not recorded; using the value from .secrets/ or the config\n", name)
+	}
+
+	differences := replayDifferences(entry)
+	for _, d := range differences {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", d)
+	}
+	if show {
+		return nil
+	}
+	if strict && len(differences) > 0 {
+		return fmt.Errorf("not replaying %s: the environment differs from the recorded run", entry.ID)
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating the research-engine binary: %w", err)
+	}
+	run := exec.Command(self, replayArgs...)
+	run.Stdin, run.Stdout, run.Stderr = os.Stdin, os.Stdout, os.Stderr
+	run.Env = append(os.Environ(), replayEnv+"="+entry.ID)
+	if err := run.Run(); err != nil {
+		return fmt.Errorf("replaying %s: %w", entry.ID, err)
+	}
+	return nil
+}
+
+// replayDifferences lists how the current environment differs from the
+// one entry was recorded in.
+func replayDifferences(entry audit.Entry) []string {
+	var diffs []string
+	if dir, err := os.Getwd(); err == nil && entry.Dir != "" && dir != entry.Dir {
+		diffs = append(diffs, fmt.Sprintf("recorded in %s, replaying in %s", entry.Dir, dir))
+	}
+	if entry.Version != version {
+		diffs = append(diffs, fmt.Sprintf("recorded with version %s, replaying with %s", entry.Version, version))
+	}
+	cfg := viper.ConfigFileUsed()
+	switch {
+	case entry.ConfigFile == "" && cfg != "":
+		diffs = append(diffs, fmt.Sprintf("recorded without a config file, now using %s", cfg))
+	case entry.ConfigFile != "" && cfg == "":
+		diffs = append(diffs, fmt.Sprintf("recorded with config %s, now no config file is found", entry.ConfigFile))
+	case entry.ConfigFile != "":
+		if hash, err := audit.HashConfig(cfg); err != nil || hash != entry.ConfigHash {
+			diffs = append(diffs, fmt.Sprintf("config %s has changed since the recorded run", cfg))
+		}
+	}
+	return diffs
+}
+
+// quoteArgs quotes arguments that a shell would split.
+func quoteArgs(args []string) []string {
+	out := make([]string, len(args))
+	for i, a := range args {
+		if a == "" || strings.ContainsAny(a, " \t\"'$\\") {
+			a = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+		}
+		out[i] = a
+	}
+	return out
+}
+
+// --- report audit subcommand ---
+
+var reportAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "List the runs recorded in the audit log",
+	Long: `Audit lists the runs that changed the corpus, most recent last, from the
+audit log (.research-engine/audit.log in the project directory): run ID,
+time, outcome, items processed and skipped, and the arguments. Replay a run
+with "replay <run-id>".
+
+Every run of search (with --query-file), search annotate, screen decide and
+resolve, acquire (except --dry-run), acquire repair and recheck-oa, convert,
+extract, extract redo-all, id migrate-dois (except --dry-run), knowledge
+store, knowledge rebuild, knowledge item add, edit, and delete, knowledge
+verify with item IDs, knowledge dispute, and knowledge note absence is
+recorded, with its arguments (secret values removed), a hash of the config file, and a summary of its results.
+The log never leaves this machine. Set audit_log: false in the config file
+or RESEARCH_ENGINE_AUDIT_LOG=false to stop recording.`,
+	Args: cobra.NoArgs,
+	RunE: runReportAudit,
+}
+
+func runReportAudit(cmd *cobra.Command, args []string) error {
+	last, _ := cmd.Flags().GetInt("last")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	entries, err := audit.Read(audit.DefaultPath)
+	if err != nil {
+		return err
+	}
+	if last > 0 && len(entries) > last {
+		entries = entries[len(entries)-last:]
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(os.Stdout, "No runs recorded.")
+		return nil
+	}
+	fmt.Fprintf(os.Stdout, "%-20s  %-16s  %-6s  %5s  %7s  %s\n", "Run", "Time", "Status", "Items", "Skipped", "Command")
+	fmt.Fprintln(os.Stdout, strings.Repeat("-", 90))
+	for _, e := range entries {
+		status := "ok"
+		if !e.OK {
+			status = "failed"
+		}
+		fmt.Fprintf(os.Stdout, "%-20s  %-16s  %-6s  %5d  %7d  %s\n",
+			e.ID, e.Time.Local().Format("2006-01-02 15:04"), status, e.Results.Items, e.Results.Skipped,
+			strings.Join(quoteArgs(e.Args), " "))
+	}
+	return nil
+}
@@ -3,29 +3,109 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
+	"github.com/pdiddy/research-engine/internal/acquire"
 	"github.com/pdiddy/research-engine/internal/container"
 	"github.com/pdiddy/research-engine/internal/convert"
+	"github.com/pdiddy/research-engine/internal/extract"
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+const (
+	// defaultGROBIDTimeout bounds one GROBID conversion; full-text processing
+	// of a long paper can take minutes.
+	defaultGROBIDTimeout = 5 * time.Minute
+	// defaultSourceTimeout bounds one arXiv e-print download for the latex
+	// backend.
+	defaultSourceTimeout = 2 * time.Minute
+	// translateMaxTokens bounds the reply to one translation request of up
+	// to 8000 characters.
+	translateMaxTokens = 8192
 )
 
 var convertCmd = &cobra.Command{
 	Use:   "convert [papers...]",
 	Short: "Convert PDF files to structured Markdown",
 	Long: `Convert transforms PDF files into structured Markdown that preserves
-section hierarchy, paragraphs, and reference lists. Supports GROBID,
-pdftotext, and markitdown (container-based) backends.`,
+section hierarchy, paragraphs, and reference lists. Supports markitdown
+(container-based), GROBID, LaTeX source, and native backends.
+
+The grobid backend posts each PDF to a GROBID server (--grobid-url, default
+from convert.grobid_url or http://localhost:8070; start one with
+"docker run -p 8070:8070 grobid/grobid:0.8.1") and renders its TEI output
+with numbered section headings, <!-- page N --> markers, figure and table
+captions, and a numbered references list with authors, year, title, venue,
+and DOI. Headings and bibliographies are far more faithful than with
+markitdown. Large PDFs can take GROBID a minute or more; raise --timeout if
+conversions time out.
+
+The latex backend converts arXiv papers from their LaTeX source (the
+e-print), found from the arxiv_id in the paper's metadata or an arXiv ID
+file name, instead of the PDF. Display equations stay LaTeX in $$ blocks
+tagged <!-- equation N --> with the paper's equation numbers, and \ref,
+\eqref, and \cite resolve to the numbers the PDF shows. Papers without
+arXiv source are converted with --pdf-backend (default markitdown).
+
+The native backend extracts the text in Go, with no container, server, or
+external binary: plain text with <!-- page N --> markers and paragraph
+breaks, but no headings or tables, and scanned pages only through OCR.
+When the selected backend is unavailable (no container runtime, no
+markitdown image, or no GROBID server), convert warns and falls back to it;
+--no-fallback fails instead.
+
+Pages with no extractable text (older scanned papers, many patents) are
+rendered with pdftoppm and recognised with tesseract, and marked with
+<!-- ocr --> after their page marker. --ocr auto (the default, or
+convert.ocr) uses OCR when both binaries are on PATH, on requires them, and
+off disables it; --ocr-lang picks the Tesseract language (default eng,
+e.g. deu+eng).
+
+Patents (papers whose metadata source is patentsview or epo-ops) are
+restructured into Abstract, Drawings, Description, and Claims sections.
+Drawing sheets become <!-- drawing sheet N --> markers with their figure
+labels, running headers and line numbers are dropped, and each claim is
+led by <!-- claim N --> (or <!-- claim N depends on M -->) so extraction
+can target claims.
+
+HTML (.html, .htm, .xhtml) and DOCX files in papers/raw/ are converted in
+Go whatever the backend, to the same Markdown layout: headings, lists,
+pipe tables, and MathML display equations as $$ blocks with equation
+markers. They have no pages, so synthetic <!-- page N --> markers are
+inserted at the page breaks a DOCX recorded, or every 500 words.
+
+Each paper's language is detected and recorded in its frontmatter and
+metadata. --translate claude or --translate deepl (or convert.translate)
+translates non-English papers into English before they are written,
+section by section, keeping markers, math, tables, and references as they
+are. claude uses --translate-model (default convert.translate_model or
+extraction.model) and the Anthropic API key extraction uses; deepl reads
+its key from convert.deepl_api_key or .secrets/deepl-api-key and posts to
+convert.deepl_url (default the DeepL Free endpoint).`,
 	RunE: runConvert,
 }
 
 func init() {
-	convertCmd.Flags().String("backend", "markitdown", "conversion backend: grobid, pdftotext, or markitdown")
+	convertCmd.Flags().String("backend", "markitdown", "conversion backend: markitdown, grobid, latex, or native")
 	convertCmd.Flags().String("papers-dir", "papers", "base directory for papers")
 	convertCmd.Flags().Bool("batch", false, "process all unconverted papers in papers-dir")
+	convertCmd.Flags().String("grobid-url", "", "GROBID server for the grobid backend (default from convert.grobid_url or "+convert.DefaultGROBIDURL+")")
+	convertCmd.Flags().Duration("timeout", 0, "HTTP request timeout for the grobid backend (default 5m) and latex source downloads (default 2m)")
+	convertCmd.Flags().String("pdf-backend", "markitdown", "backend for papers without arXiv LaTeX source when --backend is latex: markitdown, grobid, or native")
+	convertCmd.Flags().Bool("no-fallback", false, "fail when the backend is unavailable instead of falling back to the native text extractor")
+	convertCmd.Flags().String("ocr", "", "OCR for pages with no extractable text: auto, on, or off (default from convert.ocr or auto)")
+	convertCmd.Flags().String("ocr-lang", "", "Tesseract language for OCR (default from convert.ocr_lang or "+convert.DefaultOCRLang+")")
+	convertCmd.Flags().String("translate", "", "translate non-English papers into English: claude, deepl, or off (default from convert.translate or off)")
+	convertCmd.Flags().String("translate-model", "", "Claude model for --translate claude (default from convert.translate_model or extraction.model)")
+	addFailOnFlag(convertCmd)
 
 	rootCmd.AddCommand(convertCmd)
 }
@@ -34,11 +114,27 @@ func runConvert(cmd *cobra.Command, args []string) error {
 	backend, _ := cmd.Flags().GetString("backend")
 	papersDir, _ := cmd.Flags().GetString("papers-dir")
 	batch, _ := cmd.Flags().GetBool("batch")
+	policy, err := failPolicyFromFlags(cmd)
+	if err != nil {
+		return err
+	}
 
-	converter, err := newConverter(backend)
+	footer := newRunFooter()
+	defer footer.print(os.Stderr)
+
+	converter, err := newConverter(cmd, backend, footer)
 	if err != nil {
 		return err
 	}
+	if converter, err = withOCR(cmd, converter); err != nil {
+		return err
+	}
+	converter = convert.NewPatentConverter(convert.NewDocumentConverter(converter))
+	converter, usage, err := withTranslation(cmd, converter, footer)
+	if err != nil {
+		return err
+	}
+	defer func() { footer.tokens(usage()) }()
 
 	var pdfPaths []string
 	if batch {
@@ -48,12 +144,12 @@ func runConvert(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("reading %s: %w", rawDir, err)
 		}
 		for _, e := range entries {
-			if !e.IsDir() && filepath.Ext(e.Name()) == ".pdf" {
+			if !e.IsDir() && convert.IsDocument(e.Name()) {
 				pdfPaths = append(pdfPaths, filepath.Join(rawDir, e.Name()))
 			}
 		}
 		if len(pdfPaths) == 0 {
-			fmt.Fprintln(os.Stdout, "No PDF files found in", rawDir)
+			fmt.Fprintln(os.Stdout, "No PDF, HTML, or DOCX files found in", rawDir)
 			return nil
 		}
 	} else {
@@ -64,14 +160,72 @@ func runConvert(cmd *cobra.Command, args []string) error {
 	}
 
 	result := convert.ConvertPaths(converter, pdfPaths, papersDir, os.Stdout)
-	if result.HasFailures() {
-		return fmt.Errorf("%d paper(s) failed conversion", result.Failed)
+	footer.cache(result.Skipped, result.Total())
+	return policy.check("conversion", result.Failed, result.Total())
+}
+
+// newConverter returns the converter for backend. When the backend's tool
+// is unavailable it falls back to the native extractor with a warning,
+// unless --no-fallback is set.
+func newConverter(cmd *cobra.Command, backend string, footer *runFooter) (convert.Converter, error) {
+	c, err := backendConverter(cmd, backend, footer)
+	var unsupported *unsupportedBackendError
+	if err == nil || errors.As(err, &unsupported) {
+		return c, err
+	}
+	if noFallback, _ := cmd.Flags().GetBool("no-fallback"); noFallback {
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "warning: %v; falling back to the native text extractor (no headings or tables)\n", err)
+	return convert.NewNativeConverter(), nil
+}
+
+// unsupportedBackendError reports a backend name convert does not know, or
+// a --pdf-backend that cannot convert PDFs.
+type unsupportedBackendError struct {
+	backend string
+	pdf     bool
+}
+
+func (e *unsupportedBackendError) Error() string {
+	if e.pdf {
+		return fmt.Sprintf("unsupported --pdf-backend: %s (available: markitdown, grobid, native)", e.backend)
 	}
-	return nil
+	return fmt.Sprintf("unsupported backend: %s (available: markitdown, grobid, latex, native)", e.backend)
 }
 
-func newConverter(backend string) (convert.Converter, error) {
+func backendConverter(cmd *cobra.Command, backend string, footer *runFooter) (convert.Converter, error) {
 	switch backend {
+	case "native":
+		return convert.NewNativeConverter(), nil
+	case "latex":
+		pdfBackend, _ := cmd.Flags().GetString("pdf-backend")
+		if pdfBackend == "latex" {
+			return nil, &unsupportedBackendError{backend: pdfBackend, pdf: true}
+		}
+		fallback, err := newConverter(cmd, pdfBackend, footer)
+		if err != nil {
+			return nil, err
+		}
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		if timeout == 0 {
+			timeout = defaultSourceTimeout
+		}
+		limiter := acquire.NewRateLimiter(types.AcquisitionConfig{DownloadDelay: defaultDelay})
+		return convert.NewLaTeXConverter(footer.client(timeout, limiter), convert.DefaultArxivSourceURL, fallback), nil
+	case "grobid":
+		url, _ := cmd.Flags().GetString("grobid-url")
+		if url == "" {
+			url = viper.GetString("convert.grobid_url")
+		}
+		if url == "" {
+			url = convert.DefaultGROBIDURL
+		}
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		if timeout == 0 {
+			timeout = defaultGROBIDTimeout
+		}
+		return convert.NewGROBIDConverter(footer.client(timeout, nil), url)
 	case "markitdown":
 		rt, err := container.DetectRuntime()
 		if err != nil {
@@ -79,6 +233,90 @@ func newConverter(backend string) (convert.Converter, error) {
 		}
 		return convert.NewMarkitdownConverter(rt)
 	default:
-		return nil, fmt.Errorf("unsupported backend: %s (available: markitdown)", backend)
+		return nil, &unsupportedBackendError{backend: backend}
+	}
+}
+
+// withOCR attaches OCR to converter according to --ocr: the native
+// extractor recognises its empty pages, and other backends fall back to it
+// for PDFs they find no text in. In auto mode missing OCR binaries quietly
+// disable OCR.
+func withOCR(cmd *cobra.Command, c convert.Converter) (convert.Converter, error) {
+	mode, _ := cmd.Flags().GetString("ocr")
+	if mode == "" {
+		mode = viper.GetString("convert.ocr")
+	}
+	if mode == "" {
+		mode = "auto"
+	}
+	lang, _ := cmd.Flags().GetString("ocr-lang")
+	if lang == "" {
+		lang = viper.GetString("convert.ocr_lang")
+	}
+
+	switch mode {
+	case "off":
+		return c, nil
+	case "auto", "on":
+	default:
+		return nil, fmt.Errorf("invalid --ocr %q (want auto, on, or off)", mode)
+	}
+	ocr, err := convert.NewTesseractOCR(lang)
+	if err != nil {
+		if mode == "on" {
+			return nil, err
+		}
+		return c, nil
+	}
+	if native, ok := c.(*convert.NativeConverter); ok {
+		return native.WithOCR(ocr), nil
+	}
+	return convert.NewOCRFallback(c, ocr), nil
+}
+
+// withTranslation wraps converter to translate non-English papers when
+// --translate names a translation backend. usage reports the AI tokens
+// the translations spent.
+func withTranslation(cmd *cobra.Command, c convert.Converter, footer *runFooter) (_ convert.Converter, usage func() (int64, int64), err error) {
+	usage = func() (int64, int64) { return 0, 0 }
+	mode, _ := cmd.Flags().GetString("translate")
+	if mode == "" {
+		mode = viper.GetString("convert.translate")
+	}
+
+	switch mode {
+	case "", "off":
+		return c, usage, nil
+	case "claude":
+		model, _ := cmd.Flags().GetString("translate-model")
+		if model == "" {
+			model = viper.GetString("convert.translate_model")
+		}
+		if model == "" {
+			model = viper.GetString("extraction.model")
+		}
+		if model == "" {
+			return nil, nil, fmt.Errorf("--translate claude needs a model: use --translate-model or set convert.translate_model or extraction.model in config")
+		}
+		backend := &extract.ClaudeBackend{
+			APIKey: secretDefault("anthropic-api-key", viper.GetString("extraction.api_key")),
+			Model:  model,
+			Client: footer.client(0, nil),
+		}
+		return convert.NewTranslatingConverter(c, convert.NewModelTranslator(func(prompt string) (string, error) {
+			return backend.Complete(context.Background(), prompt, translateMaxTokens)
+		})), backend.Usage, nil
+	case "deepl":
+		key := secretDefault("deepl-api-key", viper.GetString("convert.deepl_api_key"))
+		if key == "" {
+			return nil, nil, fmt.Errorf("--translate deepl needs a DeepL API key in .secrets/deepl-api-key or convert.deepl_api_key")
+		}
+		url := viper.GetString("convert.deepl_url")
+		if url == "" {
+			url = convert.DefaultDeepLURL
+		}
+		return convert.NewTranslatingConverter(c, convert.NewDeepLTranslator(footer.client(0, nil), url, key)), usage, nil
+	default:
+		return nil, nil, fmt.Errorf("invalid --translate %q (want claude, deepl, or off)", mode)
 	}
 }
@@ -0,0 +1,270 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pdiddy/research-engine/internal/draft"
+	"github.com/pdiddy/research-engine/internal/knowledge"
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+var draftCmd = &cobra.Command{
+	Use:   "draft",
+	Short: "Work with paper projects in output/papers/ (init, evidence, compile)",
+}
+
+var draftInitCmd = &cobra.Command{
+	Use:   "init <output/papers/slug>",
+	Short: "Create a paper project seeded with references from the knowledge base",
+	Long: `Init creates a paper project: 00-title-page.md, a numbered stub per
+section, outline.yaml with every section at status outline, and a
+references.yaml listing the papers whose knowledge items match the topic
+(or --query), with AuthorYear citation keys. The papers are ranked by their
+matching items and the best --max-papers are kept.
+
+The directory must not exist or be empty; an existing project is never
+overwritten. Without --sections the project gets Introduction, Background,
+Related Work, Discussion, and Conclusion.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDraftInit,
+}
+
+var draftEvidenceCmd = &cobra.Command{
+	Use:   "evidence <section-file>",
+	Short: "Suggest knowledge items and citations for a section, as a comment block in it",
+	Long: `Evidence searches the knowledge base for items relevant to a section file:
+the words of its headings and its most frequent other words. It writes an
+Evidence comment block at the end of the file listing each item's suggested
+citation key, ID, type, and content; running it again replaces the block.
+
+Citation keys come from the references.yaml of the section's paper project
+(--project, default: the file's directory); papers not listed there get an
+AuthorYear key and are marked "not in references.yaml". The block is an
+HTML comment, so it never reaches compiled output and its keys are not
+checked as citations. Use --print to see the block without changing the file.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDraftEvidence,
+}
+
+var draftCompileCmd = &cobra.Command{
+	Use:   "compile <output/papers/slug>",
+	Short: "Compile a paper project to PDF, LaTeX, Word, or HTML with pandoc",
+	Long: `Compile joins the project's metadata and numbered sections and renders
+them with pandoc, which must be installed, to <slug>.pdf, <slug>.tex,
+<slug>.docx, or <slug>.html in the project (--format, several allowed:
+--format pdf,docx). PDF is typeset through LaTeX, and tex is that LaTeX
+source for submission. Word output takes its styles from --reference-doc,
+or the project's reference.docx; HTML is a standalone page with images and
+styles embedded, to send as one file.
+
+--template lays PDF and LaTeX output out in a venue's format: ieee
+(IEEEtran conference), acm (acmart sigconf), or arxiv (preprint article),
+a template of that name in templates/ of the working directory, or a
+pandoc LaTeX template file. --columns 1 or 2 overrides the format's column
+layout. The title, authors with affiliations and emails, abstract, and
+keywords come from the project's paper.yaml, or the title page frontmatter
+when there is none.
+Inline citations such as [Vaswani2017; Brown2020] of keys in references.yaml
+are resolved against a bibliography generated from it: <slug>.json in
+CSL-JSON, which pandoc reads, and <slug>.bib in BibTeX for LaTeX
+submissions. Each reference's type (article, inproceedings, patent, misc)
+picks its entry type.
+
+--csl formats citations and the bibliography with a Citation Style Language
+style, for example ieee.csl or apa.csl from the Zotero style repository, to
+match the target venue; the default is Chicago author-date.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDraftCompile,
+}
+
+func init() {
+	// Init flags.
+	draftInitCmd.Flags().String("topic", "", "what the paper is about (required)")
+	draftInitCmd.Flags().String("title", "", "paper title (default: the topic)")
+	draftInitCmd.Flags().String("type", "survey", "paper type: survey, literature-review, original-research, position-paper")
+	draftInitCmd.Flags().StringSlice("sections", nil, "section titles in order (default: Introduction, Background, Related Work, Discussion, Conclusion)")
+	draftInitCmd.Flags().StringSlice("keywords", nil, "title page keywords (default: the topic)")
+	draftInitCmd.Flags().String("query", "", "full-text query selecting the papers for references.yaml (default: the topic)")
+	draftInitCmd.Flags().Int("max-papers", 30, "at most this many papers in references.yaml; 0 for every match")
+	draftInitCmd.Flags().Bool("no-references", false, "leave references.yaml empty instead of querying the knowledge base")
+	draftInitCmd.Flags().String("knowledge-dir", "knowledge", "base directory for knowledge (contains index/)")
+	draftInitCmd.Flags().String("papers-dir", "papers", "base directory for papers (contains metadata/, markdown/)")
+	draftInitCmd.MarkFlagRequired("topic")
+
+	// Evidence flags.
+	draftEvidenceCmd.Flags().Int("max-results", 8, "at most this many items")
+	draftEvidenceCmd.Flags().String("type", "", "filter by item type: claim, method, definition, result")
+	draftEvidenceCmd.Flags().String("project", "", "paper project whose references.yaml supplies citation keys (default: the section file's directory)")
+	draftEvidenceCmd.Flags().Bool("print", false, "print the evidence block instead of writing it to the section file")
+	draftEvidenceCmd.Flags().String("knowledge-dir", "knowledge", "base directory for knowledge (contains index/)")
+	draftEvidenceCmd.Flags().String("papers-dir", "papers", "base directory for papers (contains metadata/, markdown/)")
+
+	// Compile flags.
+	draftCompileCmd.Flags().StringSlice("format", []string{"pdf"}, "output formats: pdf, tex, docx, html")
+	draftCompileCmd.Flags().String("template", "", "LaTeX template for pdf and tex: "+strings.Join(draft.Templates(), ", ")+", a file in templates/, or a path (default: pandoc's)")
+	draftCompileCmd.Flags().Int("columns", 0, "1 or 2 columns for pdf and tex (default: the template's)")
+	draftCompileCmd.Flags().String("reference-doc", "", "Word document whose styles docx output takes (default: the project's reference.docx)")
+	draftCompileCmd.Flags().String("csl", "", "Citation Style Language file for citations and bibliography (default: Chicago author-date)")
+
+	draftCmd.AddCommand(draftInitCmd)
+	draftCmd.AddCommand(draftEvidenceCmd)
+	draftCmd.AddCommand(draftCompileCmd)
+	rootCmd.AddCommand(draftCmd)
+}
+
+func runDraftInit(cmd *cobra.Command, args []string) error {
+	topic, _ := cmd.Flags().GetString("topic")
+	title, _ := cmd.Flags().GetString("title")
+	paperType, _ := cmd.Flags().GetString("type")
+	sections, _ := cmd.Flags().GetStringSlice("sections")
+	keywords, _ := cmd.Flags().GetStringSlice("keywords")
+	query, _ := cmd.Flags().GetString("query")
+	maxPapers, _ := cmd.Flags().GetInt("max-papers")
+	noReferences, _ := cmd.Flags().GetBool("no-references")
+	if query == "" {
+		query = topic
+	}
+
+	var refs []types.ReferenceEntry
+	if !noReferences {
+		cfg, papersDir := knowledgeConfig(cmd)
+		store, err := knowledge.NewStore(cfg, papersDir)
+		if err != nil {
+			return err
+		}
+		refs, err = store.References(context.Background(), knowledge.PaperListOptions{Query: query, MaxResults: maxPapers})
+		store.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	files, err := draft.Init(args[0], draft.InitOptions{
+		Topic:      topic,
+		Title:      title,
+		Type:       paperType,
+		Keywords:   keywords,
+		Sections:   sections,
+		References: refs,
+	})
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		fmt.Fprintf(os.Stdout, "Wrote %s\n", f)
+	}
+	if !noReferences && len(refs) == 0 {
+		fmt.Fprintf(os.Stderr, "Warning: no papers in the knowledge base match %q; references.yaml is empty\n", query)
+	}
+	fmt.Fprintf(os.Stdout, "Created paper project %s: %d sections, %d references\n", args[0], len(files)-3, len(refs))
+	return nil
+}
+
+func runDraftEvidence(cmd *cobra.Command, args []string) error {
+	itemType, _ := cmd.Flags().GetString("type")
+	maxResults, _ := cmd.Flags().GetInt("max-results")
+	project, _ := cmd.Flags().GetString("project")
+	printOnly, _ := cmd.Flags().GetBool("print")
+	path := args[0]
+	if project == "" {
+		project = filepath.Dir(path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading section: %w", err)
+	}
+	query := draft.EvidenceQuery(string(data))
+	if query == "" {
+		return fmt.Errorf("%s has no words to search the knowledge base with", path)
+	}
+	known := make(map[string]string)
+	refs, err := draft.LoadReferences(project)
+	switch {
+	case err == nil:
+		for _, r := range refs.Papers {
+			known[r.PaperID] = r.CitationKey
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+
+	cfg, papersDir := knowledgeConfig(cmd)
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	answer, err := store.Ask(ctx, query, knowledge.QueryOptions{
+		Type:       types.KnowledgeItemType(itemType),
+		MaxResults: maxResults,
+	})
+	if err != nil {
+		return err
+	}
+	paperIDs := make([]string, len(answer.Results))
+	for i, r := range answer.Results {
+		paperIDs[i] = r.PaperID
+		if r.CanonicalID != "" {
+			paperIDs[i] = r.CanonicalID
+		}
+	}
+	keys, err := store.CiteKeys(ctx, paperIDs, known)
+	if err != nil {
+		return err
+	}
+	evidence := make([]draft.Evidence, len(answer.Results))
+	for i, r := range answer.Results {
+		_, listed := known[paperIDs[i]]
+		evidence[i] = draft.Evidence{
+			ItemID:  r.ID,
+			Type:    string(r.Type),
+			Content: r.Content,
+			PaperID: paperIDs[i],
+			CiteKey: keys[paperIDs[i]],
+			Listed:  listed,
+		}
+	}
+
+	block := draft.RenderEvidence(query, evidence)
+	if printOnly {
+		fmt.Fprint(os.Stdout, block)
+		return nil
+	}
+	if err := draft.WriteEvidence(path, block); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Wrote %d evidence items to %s\n", len(evidence), path)
+	return nil
+}
+
+func runDraftCompile(cmd *cobra.Command, args []string) error {
+	formats, _ := cmd.Flags().GetStringSlice("format")
+	referenceDoc, _ := cmd.Flags().GetString("reference-doc")
+	template, _ := cmd.Flags().GetString("template")
+	columns, _ := cmd.Flags().GetInt("columns")
+	csl, _ := cmd.Flags().GetString("csl")
+	outputs, err := draft.Compile(args[0], draft.CompileOptions{
+		Formats:      formats,
+		Template:     template,
+		Columns:      columns,
+		CSL:          csl,
+		ReferenceDoc: referenceDoc,
+	})
+	for _, out := range outputs {
+		fmt.Fprintf(os.Stdout, "Compiled %s\n", out)
+	}
+	return err
+}
@@ -6,16 +6,20 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
-	"path/filepath"
+	"os/signal"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"go.yaml.in/yaml/v3"
 
 	"github.com/pdiddy/research-engine/internal/extract"
+	"github.com/pdiddy/research-engine/internal/httputil"
+	"github.com/pdiddy/research-engine/internal/knowledge"
 	"github.com/pdiddy/research-engine/pkg/types"
 )
 
@@ -27,28 +31,294 @@ var extractCmd = &cobra.Command{
 the source paper, section, and page.
 
 Provide paper IDs as positional arguments to extract specific papers,
-or use --batch to process all papers in papers/markdown/.`,
+or use --batch to process all papers in papers/markdown/.
+
+Sections are extracted one AI call at a time by default. --concurrency N
+(or extraction.max_concurrent_calls) runs up to N calls at once, across
+sections of a paper and across papers, while --requests-per-minute paces
+them all together. Items keep section order and the log keeps paper
+order whatever the concurrency.
+
+Each section's AI response is cached in knowledge/cache/ under the model,
+the prompt version, and the SHA-256 of the section text, so rerunning
+after a crash or after editing one section pays only for the sections
+that changed. --no-cache sends every section; delete knowledge/cache/ to
+reclaim its space.
+
+The prompt sent for each section is a template. --prompt (extraction.prompt)
+selects a template file, or one in knowledge/prompts/ by name: --prompt
+extract-v3 reads knowledge/prompts/extract-v3.tmpl. Its name is recorded
+as prompt_version in each result, and papers extracted with a prompt of
+another name are re-extracted even when their Markdown is unchanged. The
+built-in prompt is ` + extract.DefaultPromptName + `; "extract prompt" prints it.
+
+Converted patents, whose claims carry <!-- claim N --> markers, are sent
+with the patent prompt instead: --patent-prompt (extraction.patent_prompt)
+selects it the same way, and the built-in one is ` + extract.DefaultPatentPromptName + `
+("extract prompt --patent" prints it). Each claim becomes a claim item
+with its claim number and, for a dependent claim, the claim it depends
+on; "knowledge retrieve --paper ID --claim 1" then finds claim 1.
+
+--replay DIR (extraction.replay_dir) records every AI response in DIR,
+one YAML file per call keyed by the prompt text and the section, and
+replays it on later runs instead of calling the API, bypassing the cache.
+Commit the directory with a set of papers and rerun with --backend replay
+--replay DIR to check a parsing change without an API key: a call without
+a recording fails, and a prompt change shows up as missing recordings.
+
+One failed or invalid section fails its paper. With --allow-partial
+(extraction.allow_partial) the other sections' items are written, the
+failed sections are listed under errors in the result, and the paper is
+marked partial; the next run retries it, and the cache means only the
+failed sections are sent again.
+
+--summarize (extraction.summarize) adds one AI call per paper, after its
+sections, for a 3-5 sentence summary in three parts (problem, approach,
+findings) written from the paper's abstract and extracted items. It is
+stored as summary in the result and indexed with the paper. Summaries
+are cached like sections; use redo-all to add them to papers already
+extracted.
+
+Reference lists, acknowledgments, and funding and competing-interest
+statements are not sent to the AI backend; funding and disclosures are
+still read from them without it. --sections "Methods,Results" sends only
+sections whose heading, or the ## heading above it, contains one of the
+names (case-insensitive), and --skip-sections "Related Work" leaves such
+sections out. Naming a back-matter section in --sections extracts it.
+Papers already extracted are skipped as unchanged, so use redo-all to
+apply new section choices to them.
+
+Sections estimated above --max-section-tokens (extraction.max_section_tokens,
+default 6000; half of extraction.ollama_num_ctx for --backend ollama) are
+split at paragraphs, then sentences, into parts that overlap by about 200
+tokens. Each part is its own AI call, its items stay under the section's
+heading, and items read twice from an overlap are kept once.
+
+Extraction calls the Claude API by default. --backend openai (or
+extraction.backend) speaks the OpenAI chat-completions protocol instead,
+for OpenAI, Azure OpenAI, or a local server such as Ollama or vLLM, at
+--base-url (extraction.base_url, default ` + extract.DefaultOpenAIBaseURL + `).
+For Azure give the deployment URL with its api-version query. The key
+comes from --api-key, extraction.api_key, or .secrets/openai-api-key, and
+local servers can run without one.
+
+--backend ollama runs extraction offline against a local Ollama server
+(--base-url, default ` + extract.DefaultOllamaURL + `) with the model
+pulled there, e.g. --model qwen2.5:14b. Replies are constrained to the item
+schema, and because small models rate nearly everything as certain, their
+confidence is scaled by 0.8 and items below
+extraction.ollama_min_confidence (default 0.3) are dropped.
+extraction.ollama_num_ctx (default 8192) sets the context window.`,
 	RunE: runExtract,
 }
 
+var extractRedoAllCmd = &cobra.Command{
+	Use:   "redo-all",
+	Short: "Re-extract every paper with a new model and swap the results in",
+	Long: `Redo-all re-extracts every paper in papers/markdown/ with --model, writing
+results to knowledge/redo/extracted/ so the live knowledge base is untouched
+while the run is in progress. A manifest in knowledge/redo/ checkpoints each
+paper: rerun the same command to resume after an interruption, a failure, or
+a --max-tokens or --max-papers stop. Failed papers are retried on resume.
+
+When every paper has been re-extracted, the staged results replace
+knowledge/extracted/ (the old results move to knowledge/redo/previous/) and
+the knowledge base is re-indexed. Use --no-ingest to skip re-indexing.
+
+API requests are paced to --requests-per-minute. Papers are re-extracted
+one at a time; --concurrency runs each paper's sections in parallel. To
+abandon a run, remove knowledge/redo/.`,
+	Args: cobra.NoArgs,
+	RunE: runExtractRedoAll,
+}
+
+var extractPromptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Print the built-in extraction prompt template",
+	Long: `Prompt prints the built-in extraction prompt template (` + extract.DefaultPromptName + `) as a
+starting point for your own. Save it under knowledge/prompts/ with a new
+version in its name, edit it, and select it with --prompt:
+
+  research-engine extract prompt > knowledge/prompts/extract-v3.tmpl
+  research-engine extract --batch --prompt extract-v3
+
+With --patent it prints the built-in patent prompt (` + extract.DefaultPatentPromptName + `),
+which --patent-prompt replaces.
+
+The template is a Go text/template and must include {{.Section}}, the
+section of Markdown to extract from.`,
+	Args: cobra.NoArgs,
+	RunE: runExtractPrompt,
+}
+
+var extractValidateCmd = &cobra.Command{
+	Use:   "validate [papers...]",
+	Short: "Report the quality of extraction results",
+	Long: `Validate reads the extraction results in knowledge/extracted/ (or only the
+given papers) and reports, per paper, the items by type, the mean item
+confidence, items without tags, inline citations that match no
+bibliography entry, and sections of the paper's Markdown that yielded no
+items, followed by a confidence histogram of the whole corpus. No AI
+backend is called.
+
+A paper is flagged when it has no items, is partial, has a mean confidence
+below --min-confidence, or has untagged items, unlinked citations, or
+sections of about 100 tokens or more without items. Sections are chosen
+as extraction chooses them, so pass the --sections and --skip-sections
+used to extract. Flagged papers are listed with their problems; re-extract
+them (extract PAPER-ID, perhaps with another model) before running
+knowledge store. With --strict the command fails when any paper is
+flagged. --json prints the full report, including per-paper histograms.`,
+	RunE: runExtractValidate,
+}
+
+func runExtractValidate(cmd *cobra.Command, args []string) error {
+	minConfidence, _ := cmd.Flags().GetFloat64("min-confidence")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	strict, _ := cmd.Flags().GetBool("strict")
+
+	report, err := extract.ValidateExtractions(extractionConfig(cmd), args, minConfidence)
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		formatValidationReport(report)
+	}
+
+	if flagged := len(report.Flagged()); strict && flagged > 0 {
+		return fmt.Errorf("%d of %d paper(s) flagged", flagged, len(report.Papers))
+	}
+	return nil
+}
+
+func formatValidationReport(report *extract.ValidationReport) {
+	if len(report.Papers) == 0 {
+		fmt.Fprintln(os.Stdout, "No extraction results found. Run extract first.")
+		return
+	}
+	fmt.Fprintf(os.Stdout, "%-30s  %5s  %5s  %6s  %5s  %6s  %4s  %8s  %8s  %7s\n",
+		"Paper", "Items", "Claim", "Method", "Def", "Result", "Conf", "Untagged", "Unlinked", "Missing")
+	fmt.Fprintln(os.Stdout, strings.Repeat("-", 104))
+	for _, p := range report.Papers {
+		id := p.PaperID
+		if len(id) > 30 {
+			id = id[:27] + "..."
+		}
+		fmt.Fprintf(os.Stdout, "%-30s  %5d  %5d  %6d  %5d  %6d  %4.2f  %8d  %8d  %7d\n",
+			id, p.Items, p.ItemsByType[string(types.ItemClaim)], p.ItemsByType[string(types.ItemMethod)],
+			p.ItemsByType[string(types.ItemDefinition)], p.ItemsByType[string(types.ItemResult)],
+			p.MeanConfidence, p.Untagged, p.UnlinkedCitations, len(p.MissingSections))
+	}
+
+	fmt.Fprintf(os.Stdout, "\nConfidence (%d items)\n", report.Items)
+	peak := slices.Max(report.Confidence[:])
+	for i, n := range report.Confidence {
+		bar := 0
+		if peak > 0 {
+			bar = (n*40 + peak - 1) / peak
+		}
+		line := fmt.Sprintf("  %.1f-%.1f  %6d  %s", float64(i)/extract.ConfidenceBins, float64(i+1)/extract.ConfidenceBins, n, strings.Repeat("#", bar))
+		fmt.Fprintln(os.Stdout, strings.TrimRight(line, " "))
+	}
+
+	flagged := report.Flagged()
+	if len(flagged) == 0 {
+		fmt.Fprintf(os.Stdout, "\nNo papers flagged (%d checked).\n", len(report.Papers))
+		return
+	}
+	fmt.Fprintf(os.Stdout, "\nFlagged papers (%d of %d):\n", len(flagged), len(report.Papers))
+	for _, p := range flagged {
+		fmt.Fprintf(os.Stdout, "  %s: %s\n", p.PaperID, strings.Join(p.Problems, "; "))
+	}
+}
+
+func runExtractPrompt(cmd *cobra.Command, _ []string) error {
+	text := extract.DefaultPromptText()
+	if patent, _ := cmd.Flags().GetBool("patent"); patent {
+		text = extract.DefaultPatentPromptText()
+	}
+	_, err := fmt.Fprint(cmd.OutOrStdout(), text)
+	return err
+}
+
 func init() {
-	extractCmd.Flags().String("model", "", "AI model identifier for extraction")
-	extractCmd.Flags().String("api-key", "", "API key for the AI backend (or set RESEARCH_ENGINE_EXTRACTION_API_KEY)")
-	extractCmd.Flags().String("papers-dir", "papers", "base directory for papers (contains markdown/)")
-	extractCmd.Flags().String("knowledge-dir", "knowledge", "base directory for knowledge output (contains extracted/)")
+	viper.SetDefault("extraction.ollama_num_ctx", extract.DefaultOllamaContext)
+	viper.SetDefault("extraction.ollama_min_confidence", extract.DefaultOllamaMinConfidence)
+
+	addExtractionFlags(extractCmd)
 	extractCmd.Flags().Bool("batch", false, "process all unconverted papers in papers-dir")
+	addFailOnFlag(extractCmd)
+
+	extractValidateCmd.Flags().String("papers-dir", "papers", "base directory for papers (contains markdown/)")
+	extractValidateCmd.Flags().String("knowledge-dir", "knowledge", "base directory for knowledge output (contains extracted/)")
+	extractValidateCmd.Flags().StringSlice("sections", nil, "sections extraction was restricted to (default from extraction.sections)")
+	extractValidateCmd.Flags().StringSlice("skip-sections", nil, "sections extraction left out (default from extraction.skip_sections)")
+	extractValidateCmd.Flags().Float64("min-confidence", extract.DefaultMinMeanConfidence, "flag papers whose mean item confidence is below this")
+	extractValidateCmd.Flags().Bool("json", false, "print the report as JSON")
+	extractValidateCmd.Flags().Bool("strict", false, "fail when any paper is flagged")
+
+	addExtractionFlags(extractRedoAllCmd)
+	extractRedoAllCmd.Flags().Int64("max-tokens", 0, "stop before the next paper once this many tokens were used (0 = unlimited)")
+	extractRedoAllCmd.Flags().Int("max-papers", 0, "stop after extracting this many papers (0 = unlimited)")
+	extractRedoAllCmd.Flags().Bool("no-ingest", false, "do not re-index the knowledge base after swapping results in")
+
+	extractPromptCmd.Flags().Bool("patent", false, "print the built-in patent prompt")
 
+	extractCmd.AddCommand(extractRedoAllCmd)
+	extractCmd.AddCommand(extractPromptCmd)
+	extractCmd.AddCommand(extractValidateCmd)
 	rootCmd.AddCommand(extractCmd)
 }
 
+// addExtractionFlags registers the flags read by extractionConfig.
+func addExtractionFlags(cmd *cobra.Command) {
+	cmd.Flags().String("backend", "", "AI API: claude, openai for an OpenAI-compatible chat-completions API, ollama for a local Ollama server, or replay to answer only from --replay recordings (default from extraction.backend or claude)")
+	cmd.Flags().String("base-url", "", "API base URL for the openai backend (default from extraction.base_url or "+extract.DefaultOpenAIBaseURL+") or the ollama server (default "+extract.DefaultOllamaURL+")")
+	cmd.Flags().String("model", "", "AI model identifier for extraction")
+	cmd.Flags().String("api-key", "", "API key for the AI backend (or set RESEARCH_ENGINE_EXTRACTION_API_KEY)")
+	cmd.Flags().String("papers-dir", "papers", "base directory for papers (contains markdown/)")
+	cmd.Flags().String("knowledge-dir", "knowledge", "base directory for knowledge output (contains extracted/)")
+	cmd.Flags().Float64("min-quality", 0, "skip papers whose conversion quality score is below this (0-1; 0 = extract all)")
+	cmd.Flags().Int("concurrency", 0, "maximum AI API calls in flight across papers and sections (default from extraction.max_concurrent_calls or 1)")
+	cmd.Flags().Float64("requests-per-minute", 50, "maximum AI API requests per minute across all concurrent calls (0 = unlimited)")
+	cmd.Flags().String("prompt", "", "extraction prompt template: a file, or a name in knowledge-dir/prompts/ such as extract-v3 (default from extraction.prompt or the built-in "+extract.DefaultPromptName+")")
+	cmd.Flags().String("patent-prompt", "", "extraction prompt template for converted patents, selected like --prompt (default from extraction.patent_prompt or the built-in "+extract.DefaultPatentPromptName+")")
+	cmd.Flags().StringSlice("sections", nil, "only extract sections whose heading contains one of these names, e.g. \"Methods,Results\" (default from extraction.sections: all but references and acknowledgments)")
+	cmd.Flags().StringSlice("skip-sections", nil, "do not extract sections whose heading contains one of these names (default from extraction.skip_sections)")
+	cmd.Flags().Int("max-section-tokens", 0, "split sections estimated above this many tokens into overlapping parts (default from extraction.max_section_tokens or 6000)")
+	cmd.Flags().Bool("allow-partial", false, "write the items of a paper's other sections when some sections fail, marking the paper partial for a later retry")
+	cmd.Flags().String("replay", "", "replay AI responses recorded in this directory and record the others there, bypassing the cache (default from extraction.replay_dir); with --backend replay, fail on calls without a recording")
+	cmd.Flags().Bool("no-cache", false, "send every section to the AI backend instead of reusing responses cached in knowledge-dir/cache/")
+	cmd.Flags().Bool("summarize", false, "ask the AI backend for a problem, approach, and findings summary of each paper (default from extraction.summarize)")
+}
+
+// extractionClient returns the HTTP client for AI calls, paced to
+// --requests-per-minute however many calls run concurrently.
+func extractionClient(cmd *cobra.Command, footer *runFooter) *http.Client {
+	rpm, _ := cmd.Flags().GetFloat64("requests-per-minute")
+	var limiter *httputil.RateLimiter
+	if rpm > 0 {
+		limiter = httputil.NewRateLimiter(httputil.HostLimit{Rate: rpm / 60, Burst: 1}, nil)
+	}
+	return footer.client(0, limiter)
+}
+
 func runExtract(cmd *cobra.Command, args []string) error {
 	cfg := extractionConfig(cmd)
 
-	if cfg.APIKey == "" {
-		return fmt.Errorf("API key required: use --api-key or set RESEARCH_ENGINE_EXTRACTION_API_KEY")
+	if err := checkExtractionConfig(cfg); err != nil {
+		return err
 	}
-	if cfg.Model == "" {
-		return fmt.Errorf("model required: use --model or set extraction.model in config")
+
+	policy, err := failPolicyFromFlags(cmd)
+	if err != nil {
+		return err
 	}
 
 	batch, _ := cmd.Flags().GetBool("batch")
@@ -56,97 +326,144 @@ func runExtract(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("provide paper IDs as arguments or use --batch")
 	}
 
-	backend := &extract.ClaudeBackend{
-		APIKey: cfg.APIKey,
-		Model:  cfg.Model,
-		Client: &http.Client{},
+	footer := newRunFooter()
+	defer footer.print(os.Stderr)
+
+	backend, err := newExtractionBackend(cfg, extractionClient(cmd, footer))
+	if err != nil {
+		return err
 	}
+	defer footerUsage(footer, backend)
 
-	ctx := context.Background()
+	// Ctrl-C stops the batch after the calls in flight, keeping its
+	// checkpoint for the next run.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
 	var summary extract.BatchSummary
 	if batch {
-		var err error
 		summary, err = extract.ExtractAll(ctx, backend, cfg, os.Stdout)
 		if err != nil {
 			return err
 		}
 	} else {
-		summary = extractPapers(ctx, backend, args, cfg)
+		summary, err = extract.ExtractPapers(ctx, backend, cfg, args, os.Stdout)
+		if err != nil {
+			return err
+		}
 	}
 
-	fmt.Fprintf(os.Stdout, "\n%d extracted, %d skipped, %d failed (%d total)\n",
-		summary.Extracted, summary.Skipped, summary.Failed, summary.Total())
-
-	if summary.HasFailures() {
-		return fmt.Errorf("%d paper(s) failed extraction", summary.Failed)
+	if summary.Partial > 0 {
+		fmt.Fprintf(os.Stdout, "\n%d extracted (%d partial), %d skipped, %d failed (%d total)\n",
+			summary.Extracted, summary.Partial, summary.Skipped, summary.Failed, summary.Total())
+	} else {
+		fmt.Fprintf(os.Stdout, "\n%d extracted, %d skipped, %d failed (%d total)\n",
+			summary.Extracted, summary.Skipped, summary.Failed, summary.Total())
 	}
-	return nil
+	footer.cache(summary.Skipped, summary.Total())
+
+	return policy.check("extraction", summary.Failed, summary.Total())
 }
 
-// extractPapers processes specific paper IDs rather than scanning the full
-// markdown directory. It follows the same status output format as ExtractAll.
-func extractPapers(ctx context.Context, backend extract.AIBackend, paperIDs []string, cfg types.ExtractionConfig) extract.BatchSummary {
-	outDir := filepath.Join(cfg.KnowledgeDir, "extracted")
-	if err := os.MkdirAll(outDir, 0o755); err != nil {
-		fmt.Fprintf(os.Stdout, "failed  creating output directory: %v\n", err)
-		return extract.BatchSummary{Failed: len(paperIDs)}
+func runExtractRedoAll(cmd *cobra.Command, _ []string) error {
+	cfg := extractionConfig(cmd)
+	if err := checkExtractionConfig(cfg); err != nil {
+		return err
 	}
 
-	var summary extract.BatchSummary
-	for _, paperID := range paperIDs {
-		mdPath := filepath.Join(cfg.PapersDir, "markdown", paperID+".md")
-		outPath := filepath.Join(outDir, paperID+"-items.yaml")
-
-		if _, err := os.Stat(mdPath); err != nil {
-			fmt.Fprintf(os.Stdout, "failed  %s: %v\n", paperID, err)
-			summary.Failed++
-			continue
-		}
+	maxTokens, _ := cmd.Flags().GetInt64("max-tokens")
+	maxPapers, _ := cmd.Flags().GetInt("max-papers")
+	noIngest, _ := cmd.Flags().GetBool("no-ingest")
 
-		fmt.Fprintf(os.Stdout, "extracting %s\n", paperID)
+	footer := newRunFooter()
+	defer footer.print(os.Stderr)
 
-		result, err := extract.ExtractPaper(ctx, backend, paperID, mdPath, cfg)
-		if err != nil {
-			fmt.Fprintf(os.Stdout, "failed  %s: %v\n", paperID, err)
-			summary.Failed++
-			continue
-		}
+	backend, err := newExtractionBackend(cfg, extractionClient(cmd, footer))
+	if err != nil {
+		return err
+	}
+	defer footerUsage(footer, backend)
 
-		data, err := yaml.Marshal(result)
-		if err != nil {
-			fmt.Fprintf(os.Stdout, "failed  %s: marshaling result: %v\n", paperID, err)
-			summary.Failed++
-			continue
+	ctx := context.Background()
+	opts := extract.RedoOptions{MaxTokens: maxTokens, MaxPapers: maxPapers}
+	summary, err := extract.RedoAll(ctx, backend, cfg, opts, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "\n%d extracted, %d resumed, %d failed, %d remaining\n",
+		summary.Extracted, summary.Resumed, summary.Failed, summary.Remaining)
+	footer.cache(summary.Resumed, summary.Resumed+summary.Extracted+summary.Failed)
+
+	if !summary.Swapped {
+		if summary.Stopped != "" {
+			fmt.Fprintf(os.Stdout, "stopped: %s; rerun the same command to resume\n", summary.Stopped)
 		}
-		if err := os.WriteFile(outPath, data, 0o644); err != nil {
-			fmt.Fprintf(os.Stdout, "failed  %s: writing result: %v\n", paperID, err)
-			summary.Failed++
-			continue
+		if summary.Failed > 0 {
+			return fmt.Errorf("re-extraction incomplete: %d papers failed; rerun to retry them", summary.Failed)
 		}
-
-		fmt.Fprintf(os.Stdout, "extracted %s (%d items)\n", paperID, len(result.Items))
-		summary.Extracted++
+		return nil
+	}
+	if noIngest {
+		return nil
 	}
 
-	return summary
+	store, err := knowledge.NewStore(types.KnowledgeBaseConfig{KnowledgeDir: cfg.KnowledgeDir}, cfg.PapersDir)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	ingest, err := store.Ingest(ctx, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if ingest.Failed > 0 {
+		return fmt.Errorf("re-indexing failed for %d papers", ingest.Failed)
+	}
+	return nil
 }
 
 // extractionConfig builds ExtractionConfig from CLI flags and Viper config.
 // CLI flags take precedence over config file and environment variables.
 func extractionConfig(cmd *cobra.Command) types.ExtractionConfig {
+	backend, _ := cmd.Flags().GetString("backend")
+	baseURL, _ := cmd.Flags().GetString("base-url")
 	model, _ := cmd.Flags().GetString("model")
 	apiKey, _ := cmd.Flags().GetString("api-key")
 	papersDir, _ := cmd.Flags().GetString("papers-dir")
 	knowledgeDir, _ := cmd.Flags().GetString("knowledge-dir")
+	minQuality, _ := cmd.Flags().GetFloat64("min-quality")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	prompt, _ := cmd.Flags().GetString("prompt")
+	patentPrompt, _ := cmd.Flags().GetString("patent-prompt")
+	sections, _ := cmd.Flags().GetStringSlice("sections")
+	skipSections, _ := cmd.Flags().GetStringSlice("skip-sections")
+	maxSectionTokens, _ := cmd.Flags().GetInt("max-section-tokens")
+	allowPartial, _ := cmd.Flags().GetBool("allow-partial")
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+	summarize, _ := cmd.Flags().GetBool("summarize")
+	replayDir, _ := cmd.Flags().GetString("replay")
 
+	if backend == "" {
+		backend = viper.GetString("extraction.backend")
+	}
+	if backend == "" {
+		backend = "claude"
+	}
+	if baseURL == "" {
+		baseURL = viper.GetString("extraction.base_url")
+	}
 	if model == "" {
 		model = viper.GetString("extraction.model")
 	}
 	if apiKey == "" {
 		apiKey = viper.GetString("extraction.api_key")
 	}
-	apiKey = secretDefault("anthropic-api-key", apiKey)
+	if backend == "openai" {
+		apiKey = secretDefault("openai-api-key", apiKey)
+	} else {
+		apiKey = secretDefault("anthropic-api-key", apiKey)
+	}
 	if papersDir == "papers" {
 		if v := viper.GetString("extraction.papers_dir"); v != "" {
 			papersDir = v
@@ -158,6 +475,53 @@ func extractionConfig(cmd *cobra.Command) types.ExtractionConfig {
 		}
 	}
 
+	if !cmd.Flags().Changed("min-quality") {
+		minQuality = viper.GetFloat64("extraction.min_quality")
+	}
+
+	if concurrency <= 0 {
+		concurrency = viper.GetInt("extraction.max_concurrent_calls")
+	}
+
+	if prompt == "" {
+		prompt = viper.GetString("extraction.prompt")
+	}
+	if patentPrompt == "" {
+		patentPrompt = viper.GetString("extraction.patent_prompt")
+	}
+
+	if !cmd.Flags().Changed("sections") {
+		sections = viper.GetStringSlice("extraction.sections")
+	}
+	if !cmd.Flags().Changed("skip-sections") {
+		skipSections = viper.GetStringSlice("extraction.skip_sections")
+	}
+
+	if maxSectionTokens <= 0 {
+		maxSectionTokens = viper.GetInt("extraction.max_section_tokens")
+	}
+	if maxSectionTokens <= 0 && backend == "ollama" {
+		// Leave the rest of a local model's window for the prompt and reply.
+		maxSectionTokens = viper.GetInt("extraction.ollama_num_ctx") / 2
+	}
+
+	if !cmd.Flags().Changed("allow-partial") {
+		allowPartial = viper.GetBool("extraction.allow_partial")
+	}
+
+	if !cmd.Flags().Changed("summarize") {
+		summarize = viper.GetBool("extraction.summarize")
+	}
+
+	// Recording needs every call to reach the replay backend, so the
+	// response cache is bypassed.
+	if replayDir == "" {
+		replayDir = viper.GetString("extraction.replay_dir")
+	}
+	if replayDir != "" {
+		noCache = true
+	}
+
 	maxRetries := viper.GetInt("extraction.max_retries")
 	if maxRetries <= 0 {
 		maxRetries = 3
@@ -165,11 +529,107 @@ func extractionConfig(cmd *cobra.Command) types.ExtractionConfig {
 
 	return types.ExtractionConfig{
 		AIConfig: types.AIConfig{
+			Backend:    backend,
+			BaseURL:    baseURL,
 			Model:      model,
 			APIKey:     apiKey,
 			MaxRetries: maxRetries,
 		},
-		PapersDir:    papersDir,
-		KnowledgeDir: knowledgeDir,
+		PapersDir:          papersDir,
+		KnowledgeDir:       knowledgeDir,
+		MinQuality:         minQuality,
+		MaxConcurrentCalls: concurrency,
+		Prompt:             prompt,
+		PatentPrompt:       patentPrompt,
+		Sections:           sections,
+		SkipSections:       skipSections,
+		MaxSectionTokens:   maxSectionTokens,
+		AllowPartial:       allowPartial,
+		NoCache:            noCache,
+		Summarize:          summarize,
+		ReplayDir:          replayDir,
+	}
+}
+
+// checkExtractionConfig reports settings extraction cannot run without. The
+// ollama backend needs no key, nor does the openai backend for a local
+// server at --base-url. The replay backend needs only --replay.
+func checkExtractionConfig(cfg types.ExtractionConfig) error {
+	switch cfg.Backend {
+	case "claude", "openai", "ollama":
+	case "replay":
+		if cfg.ReplayDir == "" {
+			return fmt.Errorf("the replay backend needs recorded responses: use --replay or set extraction.replay_dir")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported --backend: %s (available: claude, openai, ollama, replay)", cfg.Backend)
+	}
+	if cfg.APIKey == "" && (cfg.Backend == "claude" || cfg.Backend == "openai" && cfg.BaseURL == "") {
+		return fmt.Errorf("API key required: use --api-key or set RESEARCH_ENGINE_EXTRACTION_API_KEY")
+	}
+	if cfg.Model == "" {
+		return fmt.Errorf("model required: use --model or set extraction.model in config")
+	}
+	return nil
+}
+
+// extractionBackend is an AI backend that counts the tokens it spends.
+type extractionBackend interface {
+	extract.AIBackend
+	Usage() (input, output int64)
+}
+
+// footerUsage records the tokens backend spent, and the responses it
+// replayed and recorded, in footer.
+func footerUsage(footer *runFooter, backend extractionBackend) {
+	footer.tokens(backend.Usage())
+	if r, ok := backend.(*extract.ReplayBackend); ok {
+		footer.replay(r.Counts())
+	}
+}
+
+// newExtractionBackend returns the AI backend cfg.Backend selects, sending
+// its requests through client. With cfg.ReplayDir it is wrapped in a
+// replay backend, or with the replay backend answers only from recordings.
+func newExtractionBackend(cfg types.ExtractionConfig, client *http.Client) (extractionBackend, error) {
+	backend, err := newAIBackend(cfg, client)
+	if err != nil || cfg.ReplayDir == "" {
+		return backend, err
+	}
+	if cfg.Backend == "replay" {
+		return backend, nil
+	}
+	return &extract.ReplayBackend{Dir: cfg.ReplayDir, Backend: backend}, nil
+}
+
+// newAIBackend returns the AI backend cfg.Backend selects.
+func newAIBackend(cfg types.ExtractionConfig, client *http.Client) (extractionBackend, error) {
+	prompt, err := extract.LoadPrompt(cfg.KnowledgeDir, cfg.Prompt)
+	if err != nil {
+		return nil, err
+	}
+	patentPrompt := extract.DefaultPatentPrompt()
+	if cfg.PatentPrompt != "" {
+		if patentPrompt, err = extract.LoadPrompt(cfg.KnowledgeDir, cfg.PatentPrompt); err != nil {
+			return nil, err
+		}
+	}
+	switch cfg.Backend {
+	case "replay":
+		return &extract.ReplayBackend{Dir: cfg.ReplayDir, Prompt: prompt, PatentPrompt: patentPrompt}, nil
+	case "openai":
+		return &extract.OpenAIBackend{BaseURL: cfg.BaseURL, APIKey: cfg.APIKey, Model: cfg.Model, Client: client, Prompt: prompt, PatentPrompt: patentPrompt}, nil
+	case "ollama":
+		return &extract.OllamaBackend{
+			URL:           cfg.BaseURL,
+			Model:         cfg.Model,
+			Client:        client,
+			Prompt:        prompt,
+			PatentPrompt:  patentPrompt,
+			ContextSize:   viper.GetInt("extraction.ollama_num_ctx"),
+			MinConfidence: viper.GetFloat64("extraction.ollama_min_confidence"),
+		}, nil
 	}
+	return &extract.ClaudeBackend{APIKey: cfg.APIKey, Model: cfg.Model, Client: client, Prompt: prompt, PatentPrompt: patentPrompt}, nil
 }
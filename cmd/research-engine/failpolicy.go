@@ -0,0 +1,65 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// failPolicy decides whether per-paper failures in a batch command make the
+// command exit non-zero. Every batch command processes all its inputs
+// first; the policy is applied to the final counts.
+type failPolicy string
+
+const (
+	// failOnAny exits non-zero when at least one paper failed.
+	failOnAny failPolicy = "any"
+
+	// failOnPartial tolerates partial failure and exits non-zero only
+	// when every paper failed. "all" is accepted as an alias.
+	failOnPartial failPolicy = "partial"
+
+	// failOnNone always exits zero after per-paper failures; they are
+	// still reported in the output.
+	failOnNone failPolicy = "none"
+)
+
+// addFailOnFlag registers --fail-on on a batch command.
+func addFailOnFlag(cmd *cobra.Command) {
+	cmd.Flags().String("fail-on", string(failOnAny),
+		"when per-paper failures fail the command: any (one failed), partial (only if every paper failed), or none")
+}
+
+// failPolicyFromFlags reads and validates --fail-on.
+func failPolicyFromFlags(cmd *cobra.Command) (failPolicy, error) {
+	v, _ := cmd.Flags().GetString("fail-on")
+	switch p := failPolicy(v); p {
+	case failOnAny, failOnPartial, failOnNone:
+		return p, nil
+	case "all":
+		return failOnPartial, nil
+	case "":
+		return failOnAny, nil
+	default:
+		return "", fmt.Errorf("invalid --fail-on %q: use any, partial, or none", v)
+	}
+}
+
+// check returns an error describing the failures when the policy says the
+// run failed. Skipped papers count as successes: their output is current.
+func (p failPolicy) check(stage string, failed, total int) error {
+	if failed == 0 {
+		return nil
+	}
+	switch p {
+	case failOnNone:
+		return nil
+	case failOnPartial:
+		if failed < total {
+			return nil
+		}
+	}
+	return fmt.Errorf("%d paper(s) failed %s", failed, stage)
+}
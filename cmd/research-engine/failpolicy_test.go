@@ -0,0 +1,78 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailPolicyFromFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		set     bool
+		want    failPolicy
+		wantErr bool
+	}{
+		{name: "default", want: failOnAny},
+		{name: "any", value: "any", set: true, want: failOnAny},
+		{name: "partial", value: "partial", set: true, want: failOnPartial},
+		{name: "all alias", value: "all", set: true, want: failOnPartial},
+		{name: "none", value: "none", set: true, want: failOnNone},
+		{name: "empty", value: "", set: true, want: failOnAny},
+		{name: "invalid", value: "some", set: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			addFailOnFlag(cmd)
+			if tt.set {
+				require.NoError(t, cmd.Flags().Set("fail-on", tt.value))
+			}
+
+			got, err := failPolicyFromFlags(cmd)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "use any, partial, or none")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFailPolicyCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  failPolicy
+		failed  int
+		total   int
+		wantErr bool
+	}{
+		{name: "any, none failed", policy: failOnAny, failed: 0, total: 3},
+		{name: "any, some failed", policy: failOnAny, failed: 1, total: 3, wantErr: true},
+		{name: "any, all failed", policy: failOnAny, failed: 3, total: 3, wantErr: true},
+		{name: "partial, none failed", policy: failOnPartial, failed: 0, total: 3},
+		{name: "partial, some failed", policy: failOnPartial, failed: 1, total: 3},
+		{name: "partial, all failed", policy: failOnPartial, failed: 3, total: 3, wantErr: true},
+		{name: "none, none failed", policy: failOnNone, failed: 0, total: 3},
+		{name: "none, some failed", policy: failOnNone, failed: 1, total: 3},
+		{name: "none, all failed", policy: failOnNone, failed: 3, total: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.check("extraction", tt.failed, tt.total)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "paper(s) failed extraction")
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pdiddy/research-engine/internal/httputil"
+)
+
+// runFooter collects the numbers printed at the end of a batch command:
+// wall time, API calls per host, cache hits (work skipped because its
+// output was already up to date), AI tokens spent, and AI responses
+// replayed from or recorded to a replay directory.
+type runFooter struct {
+	start        time.Time
+	calls        *httputil.CallStats
+	cacheHits    int
+	cacheLookups int
+	inputTokens  int64
+	outputTokens int64
+	replayed     int64
+	recorded     int64
+}
+
+// activeFooter is the footer of the running command, if it made one; the
+// audit log reads its counts after the command returns.
+var activeFooter *runFooter
+
+// newRunFooter starts the wall clock for a command.
+func newRunFooter() *runFooter {
+	activeFooter = &runFooter{start: time.Now(), calls: httputil.NewCallStats()}
+	return activeFooter
+}
+
+// client returns an HTTP client whose requests are counted in the footer.
+// A non-nil limiter paces the requests per host before they are sent.
+func (f *runFooter) client(timeout time.Duration, limiter *httputil.RateLimiter) *http.Client {
+	var base http.RoundTripper
+	if limiter != nil {
+		base = limiter.Transport(nil)
+	}
+	return &http.Client{Timeout: timeout, Transport: f.calls.Transport(base)}
+}
+
+// cache records hits out of lookups; commands pass their skipped and
+// total counts.
+func (f *runFooter) cache(hits, lookups int) {
+	f.cacheHits += hits
+	f.cacheLookups += lookups
+}
+
+// tokens records AI tokens spent.
+func (f *runFooter) tokens(input, output int64) {
+	f.inputTokens += input
+	f.outputTokens += output
+}
+
+// replay records AI responses replayed from and recorded to a replay
+// directory.
+func (f *runFooter) replay(replayed, recorded int64) {
+	f.replayed += replayed
+	f.recorded += recorded
+}
+
+// print writes the one-line footer to w. Sections with nothing to report
+// are left out.
+func (f *runFooter) print(w io.Writer) {
+	parts := []string{fmt.Sprintf("time %s", time.Since(f.start).Round(time.Millisecond))}
+
+	if counts := f.calls.Counts(); len(counts) > 0 {
+		hosts := make([]string, len(counts))
+		for i, c := range counts {
+			hosts[i] = fmt.Sprintf("%s=%d", c.Host, c.Calls)
+		}
+		parts = append(parts, "api "+strings.Join(hosts, " "))
+	} else {
+		parts = append(parts, "api none")
+	}
+
+	if f.cacheLookups > 0 {
+		parts = append(parts, fmt.Sprintf("cache %d/%d (%.0f%%)",
+			f.cacheHits, f.cacheLookups, 100*float64(f.cacheHits)/float64(f.cacheLookups)))
+	}
+
+	if f.inputTokens > 0 || f.outputTokens > 0 {
+		parts = append(parts, fmt.Sprintf("tokens %d in / %d out", f.inputTokens, f.outputTokens))
+	}
+
+	if f.replayed > 0 || f.recorded > 0 {
+		parts = append(parts, fmt.Sprintf("replay %d replayed / %d recorded", f.replayed, f.recorded))
+	}
+
+	fmt.Fprintf(w, "-- %s\n", strings.Join(parts, " | "))
+}
@@ -0,0 +1,104 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pdiddy/research-engine/internal/acquire"
+)
+
+var idCmd = &cobra.Command{
+	Use:   "id",
+	Short: "Inspect and normalize paper identifiers",
+	Long: `Id exposes the identifier rules the pipeline uses, so scripts and skills
+can validate identifiers before invoking batch commands.`,
+}
+
+var idClassifyCmd = &cobra.Command{
+	Use:   "classify [identifiers...]",
+	Short: "Classify identifiers and print their normalized forms",
+	Long: `Classify reports, for each identifier, its type (arxiv, doi, patent, url,
+or unknown), the normalized form acquisition uses, the base form without
+arXiv version or patent kind code, the file slug, and the PDF URL.
+
+Exits non-zero when any identifier is unknown, after printing all of them.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runIDClassify,
+}
+
+var idMigrateDOIsCmd = &cobra.Command{
+	Use:   "migrate-dois",
+	Short: "Rename papers stored under mixed-case DOI slugs",
+	Long: `Migrate-dois lowercases the slugs of papers acquired before DOIs were
+normalized, renaming their PDF, metadata, Markdown, and extraction files and
+rewriting the paper ID inside the records. Case-variant duplicates are
+reported and left in place for manual review.
+
+Run knowledge store afterwards to index the renamed papers; rebuild the index
+(delete knowledge/index/research.db) to drop entries under the old IDs.`,
+	RunE: runIDMigrateDOIs,
+}
+
+func init() {
+	idClassifyCmd.Flags().Bool("json", false, "output results as JSON")
+
+	idMigrateDOIsCmd.Flags().String("papers-dir", "papers", "base directory for papers")
+	idMigrateDOIsCmd.Flags().String("knowledge-dir", "knowledge", "base directory for knowledge (contains extracted/)")
+	idMigrateDOIsCmd.Flags().Bool("dry-run", false, "report renames without changing files")
+
+	idCmd.AddCommand(idClassifyCmd)
+	idCmd.AddCommand(idMigrateDOIsCmd)
+	rootCmd.AddCommand(idCmd)
+}
+
+func runIDClassify(cmd *cobra.Command, args []string) error {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	infos := make([]acquire.IdentifierInfo, len(args))
+	unknown := 0
+	for i, arg := range args {
+		infos[i] = acquire.Describe(arg)
+		if infos[i].Type == "unknown" {
+			unknown++
+		}
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(infos); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(os.Stdout, "%-8s  %-30s  %-30s  %s\n", "Type", "Normalized", "Base", "PDF URL")
+		for _, info := range infos {
+			fmt.Fprintf(os.Stdout, "%-8s  %-30s  %-30s  %s\n",
+				info.Type, info.Normalized, info.Base, info.PDFURL)
+		}
+	}
+
+	if unknown > 0 {
+		return fmt.Errorf("%d identifier(s) not recognized", unknown)
+	}
+	return nil
+}
+
+func runIDMigrateDOIs(cmd *cobra.Command, args []string) error {
+	papersDir, _ := cmd.Flags().GetString("papers-dir")
+	knowledgeDir, _ := cmd.Flags().GetString("knowledge-dir")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	summary, err := acquire.MigrateDOISlugs(papersDir, knowledgeDir, dryRun, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d paper(s) failed migration", summary.Failed)
+	}
+	return nil
+}
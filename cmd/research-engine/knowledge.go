@@ -6,18 +6,27 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
+	"github.com/pdiddy/research-engine/internal/draft"
 	"github.com/pdiddy/research-engine/internal/knowledge"
+	"github.com/pdiddy/research-engine/internal/search"
 	"github.com/pdiddy/research-engine/pkg/types"
 )
 
 var knowledgeCmd = &cobra.Command{
 	Use:   "knowledge",
-	Short: "Manage the knowledge base (store, retrieve, export)",
+	Short: "Manage the knowledge base of extracted items",
 	Long: `Knowledge manages a local SQLite knowledge base built from extracted
 knowledge items. Use subcommands to index items, query them, or export.`,
 }
@@ -29,27 +38,169 @@ var knowledgeStoreCmd = &cobra.Command{
 	Short: "Ingest extracted knowledge items into the knowledge base",
 	Long: `Store reads extraction YAML files from knowledge/extracted/, ingests
 them into a SQLite database with FTS5 indexing, and writes an export file.
-Unchanged papers are skipped on subsequent runs.`,
+Unchanged papers are skipped on subsequent runs.
+
+Each paper's venue (journal, conference, workshop, or preprint server) is
+recorded from its metadata. Use --venue-rankings, or knowledge.venue_rankings
+in the config file, to rank venues from a CORE conference CSV export or a
+Scimago journal list.
+
+With --embedding-backend (knowledge.embedding_backend) set to openai or
+ollama, store also embeds every new or changed item for semantic search
+("knowledge retrieve --semantic"). Items already embedded with the same
+model are not sent again; changing --embedding-model embeds them all.`,
 	RunE: runKnowledgeStore,
 }
 
 func runKnowledgeStore(cmd *cobra.Command, args []string) error {
-	cfg, papersDir := knowledgeConfig(cmd)
+	policy, err := failPolicyFromFlags(cmd)
+	if err != nil {
+		return err
+	}
 
+	cfg, papersDir := knowledgeConfig(cmd)
+	cfg.VenueRankings, _ = cmd.Flags().GetStringSlice("venue-rankings")
+	if len(cfg.VenueRankings) == 0 {
+		cfg.VenueRankings = viper.GetStringSlice("knowledge.venue_rankings")
+	}
 	store, err := knowledge.NewStore(cfg, papersDir)
 	if err != nil {
 		return err
 	}
 	defer store.Close()
 
+	footer := newRunFooter()
+	defer footer.print(os.Stderr)
+
 	summary, err := store.Ingest(context.Background(), os.Stdout)
+	footer.cache(summary.Skipped, summary.Total())
 	if err != nil {
 		return err
 	}
-	if summary.Failed > 0 {
-		return fmt.Errorf("%d paper(s) failed indexing", summary.Failed)
+
+	embedder, err := knowledgeEmbedder(cfg, footer)
+	if err != nil {
+		return err
 	}
-	return nil
+	if embedder != nil {
+		n, err := store.EmbedItems(context.Background(), embedder, os.Stdout)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			fmt.Fprintf(os.Stdout, "embedded %d item(s)\n", n)
+		}
+	}
+	return policy.check("indexing", summary.Failed, summary.Total())
+}
+
+// --- rebuild subcommand ---
+
+var knowledgeRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Recreate the knowledge base database from the extraction files",
+	Long: `Rebuild creates a new database from knowledge/extracted/ and
+papers/metadata/ and replaces the current one with it, for recovery from
+a damaged database or after schema changes. The current database is only
+read for its collections, snapshots, and ingest history, which are carried
+over, so rebuild works when other knowledge commands fail; it is kept as
+research.db.bak. A progress bar shows on a terminal, followed by a
+summary; failed papers and warnings are listed after the bar.
+
+Rebuild accepts the store flags. With an embedding backend configured,
+every item is embedded again. Run no other knowledge command while
+rebuilding.`,
+	Args: cobra.NoArgs,
+	RunE: runKnowledgeRebuild,
+}
+
+func runKnowledgeRebuild(cmd *cobra.Command, args []string) error {
+	policy, err := failPolicyFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, papersDir := knowledgeConfig(cmd)
+	cfg.VenueRankings, _ = cmd.Flags().GetStringSlice("venue-rankings")
+	if len(cfg.VenueRankings) == 0 {
+		cfg.VenueRankings = viper.GetStringSlice("knowledge.venue_rankings")
+	}
+
+	footer := newRunFooter()
+	defer footer.print(os.Stderr)
+
+	// On a terminal the bar replaces the per-paper lines, and the lines
+	// worth reading are printed after it.
+	var out io.Writer = os.Stdout
+	var log strings.Builder
+	var progress func(done, total int)
+	if isTerminal(os.Stderr) {
+		out = &log
+		progress = func(done, total int) { drawProgress(os.Stderr, done, total) }
+	}
+	summary, err := knowledge.Rebuild(context.Background(), cfg, papersDir, out, progress)
+	if progress != nil {
+		for _, line := range strings.Split(strings.TrimSpace(log.String()), "\n") {
+			if line != "" && !strings.HasPrefix(line, "indexing ") {
+				fmt.Fprintln(os.Stdout, line)
+			}
+		}
+	}
+	footer.cache(0, summary.Total())
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "\nrebuilt knowledge base: %d papers, %d items\n", summary.Papers, summary.Items)
+	if summary.Collections > 0 {
+		fmt.Fprintf(os.Stdout, "carried over %d collection(s)\n", summary.Collections)
+	}
+	if summary.Snapshots > 0 {
+		fmt.Fprintf(os.Stdout, "carried over %d snapshot(s) and the ingest history\n", summary.Snapshots)
+	}
+	if summary.Previous != "" {
+		fmt.Fprintf(os.Stdout, "previous database kept as %s\n", summary.Previous)
+	}
+
+	embedder, err := knowledgeEmbedder(cfg, footer)
+	if err != nil {
+		return err
+	}
+	if embedder != nil {
+		store, err := knowledge.NewStore(cfg, papersDir)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		n, err := store.EmbedItems(context.Background(), embedder, os.Stdout)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "embedded %d item(s)\n", n)
+	}
+	return policy.check("indexing", summary.Failed, summary.Total())
+}
+
+// progressWidth is the number of cells in a progress bar.
+const progressWidth = 30
+
+// drawProgress redraws a progress bar of done out of total papers on the
+// current line of w, ending the line when done reaches total.
+func drawProgress(w io.Writer, done, total int) {
+	filled := progressWidth
+	if total > 0 {
+		filled = progressWidth * done / total
+	}
+	fmt.Fprintf(w, "\r[%s%s] %d/%d papers", strings.Repeat("#", filled), strings.Repeat(".", progressWidth-filled), done, total)
+	if done == total {
+		fmt.Fprintln(w)
+	}
+}
+
+// isTerminal reports whether f is a terminal rather than a file or pipe.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
 }
 
 // --- retrieve subcommand ---
@@ -61,6 +212,34 @@ var knowledgeRetrieveCmd = &cobra.Command{
 structured filters (type, tag, paper), or a combination of both.
 Results include provenance links to the source paper and section.
 
+The query supports quoted phrases ("linear attention"), prefixes
+(atten*), AND, OR, and NOT in upper case (terms without an operator must
+all match), parentheses, NEAR(a b, 5) for terms within five words of each
+other, and column filters (section:methods, tags:softmax). Words with
+punctuation such as self-attention are searched as phrases. An invalid
+query is reported with the position of the problem.
+
+--metric ranks result items by the number they report, best first, and
+--dataset restricts them to a benchmark: --metric accuracy --dataset GLUE
+lists the best reported GLUE accuracies. Both match a case-insensitive
+substring. Values rank descending, or ascending for metrics where lower
+is better (error, loss, perplexity, latency, WER, FID, ...) or with
+--lower-is-better. Items extracted before structured results carry no
+metric; re-extract them with "extract redo-all".
+
+--semantic ranks items by the meaning of a question rather than its words,
+so paraphrases match: --semantic "how do they reduce attention cost" finds
+items about linear attention that share none of its words. It needs items
+embedded by "knowledge store" with an embedding backend. A positional
+query alongside --semantic, or --hybrid for the question's own words,
+fuses the full-text and semantic rankings. The score column shows the
+similarity, or the fused score.
+
+--claim selects a patent claim by number: --paper US1234567B2 --claim 1
+shows what claim 1 covers. Claims list in claim order with their numbers
+in the type column; --json shows the claim each dependent claim depends
+on.
+
 Use --trace with an item ID to view the surrounding source context.`,
 	RunE: runKnowledgeRetrieve,
 }
@@ -77,28 +256,91 @@ func runKnowledgeRetrieve(cmd *cobra.Command, args []string) error {
 
 	// Trace mode: show source context for a specific item.
 	if traceID != "" {
-		text, err := store.Trace(context.Background(), traceID)
+		trace, err := store.TraceItem(context.Background(), traceID)
 		if err != nil {
 			return err
 		}
-		fmt.Println(text)
+		switch trace.Match {
+		case knowledge.TraceHeading:
+			fmt.Fprintf(os.Stderr, "Section %q not found; showing heading %q\n", trace.Section, trace.Heading)
+		case knowledge.TraceContent:
+			fmt.Fprintf(os.Stderr, "Section %q not found; showing the paragraph matching the item's content under %q (similarity %.2f)\n",
+				trace.Section, trace.Heading, trace.Similarity)
+		}
+		fmt.Println(trace.Context)
 		return nil
 	}
 
-	opts := queryOptsFromFlags(cmd, args)
-	if opts.IsEmpty() {
-		return fmt.Errorf("query or filter required: provide a search query, --type, --tag, or --paper")
+	opts, err := queryOptsFromFlags(cmd, args)
+	if err != nil {
+		return err
+	}
+	semantic, _ := cmd.Flags().GetString("semantic")
+	if opts.IsEmpty() && semantic == "" {
+		return fmt.Errorf("query or filter required: provide a search query, --semantic, --type, --tag, --paper, --metric, --claim, --min-confidence, --pages, --from, or --to")
 	}
 
-	results, err := store.Retrieve(context.Background(), opts)
+	var results []knowledge.QueryResult
+	if semantic != "" {
+		embedder, err := knowledgeEmbedder(cfg, nil)
+		if err != nil {
+			return err
+		}
+		if embedder == nil {
+			return fmt.Errorf("--semantic needs an embedding backend: use --embedding-backend or set knowledge.embedding_backend")
+		}
+		if hybrid, _ := cmd.Flags().GetBool("hybrid"); hybrid && opts.Query == "" {
+			opts.Query = knowledge.FullTextTerms(semantic)
+		}
+		results, err = store.RetrieveSemantic(context.Background(), embedder, semantic, opts)
+	} else {
+		results, err = store.Retrieve(context.Background(), opts)
+	}
 	if err != nil {
 		return err
 	}
 
 	jsonOutput, _ := cmd.Flags().GetBool("json")
+	if opts.Metric != "" && !jsonOutput {
+		formatMetricResults(results)
+		return nil
+	}
 	return formatRetrieveOutput(results, jsonOutput)
 }
 
+// formatMetricResults prints result items ranked by their metric value.
+func formatMetricResults(results []knowledge.QueryResult) {
+	if len(results) == 0 {
+		fmt.Println("No results found.")
+		return
+	}
+
+	fmt.Fprintf(os.Stdout, "%-4s  %-12s  %-20s  %-25s  %-20s  %s\n",
+		"Rank", "Value", "Metric", "Dataset", "Paper", "Baseline")
+	fmt.Fprintln(os.Stdout, strings.Repeat("-", 110))
+
+	for i, r := range results {
+		m := r.Metric
+		value := strconv.FormatFloat(m.Value, 'f', -1, 64) + m.Unit
+		baseline := m.Baseline
+		if m.BaselineValue != nil {
+			baseline = strings.TrimSpace(fmt.Sprintf("%s %s%s", baseline, strconv.FormatFloat(*m.BaselineValue, 'f', -1, 64), m.Unit))
+		}
+		fmt.Fprintf(os.Stdout, "%-4d  %-12s  %-20s  %-25s  %-20s  %s\n",
+			i+1, value, truncateColumn(m.Name, 20), truncateColumn(m.Dataset, 25), truncateColumn(r.PaperID, 20), baseline)
+	}
+
+	fmt.Fprintf(os.Stdout, "\n%d results\n", len(results))
+}
+
+// truncateColumn shortens s to max bytes for a table column.
+func truncateColumn(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max-3] + "..."
+}
+
 func formatRetrieveOutput(results []knowledge.QueryResult, jsonOutput bool) error {
 	if jsonOutput {
 		enc := json.NewEncoder(os.Stdout)
@@ -111,9 +353,18 @@ func formatRetrieveOutput(results []knowledge.QueryResult, jsonOutput bool) erro
 		return nil
 	}
 
+	// Full-text, semantic, and hybrid results carry a score.
+	scored := results[0].Score != 0
+	if scored {
+		fmt.Fprintf(os.Stdout, "%-6s  ", "Score")
+	}
 	fmt.Fprintf(os.Stdout, "%-4s  %-8s  %-50s  %-20s  %-10s  %s\n",
 		"Rank", "Type", "Content", "Paper", "Section", "Page")
-	fmt.Fprintln(os.Stdout, strings.Repeat("-", 110))
+	width := 110
+	if scored {
+		width += 8
+	}
+	fmt.Fprintln(os.Stdout, strings.Repeat("-", width))
 
 	for i, r := range results {
 		content := r.Content
@@ -121,15 +372,32 @@ func formatRetrieveOutput(results []knowledge.QueryResult, jsonOutput bool) erro
 			content = content[:47] + "..."
 		}
 		paper := r.PaperID
-		if len(paper) > 20 {
-			paper = paper[:17] + "..."
+		// A collapsed result counts the other papers stating it (R14.2).
+		more := ""
+		if n := len(r.SupportingPapers) - 1; n > 0 {
+			more = fmt.Sprintf(" +%d", n)
+		}
+		if len(paper)+len(more) > 20 {
+			paper = paper[:17-len(more)] + "..."
 		}
+		paper += more
 		section := r.Section
 		if len(section) > 10 {
 			section = section[:7] + "..."
 		}
+		itemType := string(r.Type)
+		if r.PatentClaim != nil {
+			itemType = fmt.Sprintf("claim %d", r.PatentClaim.Number)
+		}
+		if scored {
+			fmt.Fprintf(os.Stdout, "%-6.3f  ", r.Score)
+		}
 		fmt.Fprintf(os.Stdout, "%-4d  %-8s  %-50s  %-20s  %-10s  %d\n",
-			i+1, r.Type, content, paper, section, r.Page)
+			i+1, itemType, content, paper, section, r.Page)
+		// Full-text results show the match in context (R2.7).
+		if r.Snippet != "" {
+			fmt.Fprintf(os.Stdout, "      %s\n", strings.Join(strings.Fields(r.Snippet), " "))
+		}
 	}
 
 	fmt.Fprintf(os.Stdout, "\n%d results\n", len(results))
@@ -140,10 +408,30 @@ func formatRetrieveOutput(results []knowledge.QueryResult, jsonOutput bool) erro
 
 var knowledgeExportCmd = &cobra.Command{
 	Use:   "export",
-	Short: "Export the knowledge base to YAML or JSON",
+	Short: "Export the knowledge base to YAML, JSON, CSV, Parquet, Anki, GraphML, or DOT",
 	Long: `Export writes the full knowledge base (or a filtered subset) to
-knowledge/index/export.yaml or export.json. Supports the same filter
-flags as retrieve for partial exports.`,
+knowledge/index/export.yaml, export.json, export.csv, or export.parquet.
+Supports the same filter flags as retrieve for partial exports.
+
+CSV and Parquet have one row per item with flat columns for loading into
+pandas, DuckDB, or a spreadsheet: paper metadata and metric fields get
+their own columns, and list fields (tags, authors, versions) are JSON.
+
+Anki writes knowledge/index/export-anki.txt, a flashcard deck to import
+with File > Import: one card per claim or definition item, with the
+defined term or a question about the claim in front and the statement
+with its paper, section, and page on the back. Cards are keyed by item
+ID, so importing a later export updates them.
+
+GraphML (export.graphml, for Gephi) and DOT (export.dot, for Graphviz)
+write a graph of the selected items, their papers and tags, and the
+papers' citations from the bibliographies. Use the filter flags, --nodes
+to leave out node kinds, and --min-cited to drop rarely cited works, to
+keep the graph readable.
+
+Entries are sorted by paper, section, page, and item ID so exports kept
+in git or used as prompt context diff cleanly. With --query, --order score
+keeps the best matches first; --limit then exports the top matches.`,
 	RunE: runKnowledgeExport,
 }
 
@@ -157,7 +445,12 @@ func runKnowledgeExport(cmd *cobra.Command, args []string) error {
 	}
 	defer store.Close()
 
-	opts := queryOptsFromFlags(cmd, args)
+	opts, err := queryOptsFromFlags(cmd, args)
+	if err != nil {
+		return err
+	}
+	order, _ := cmd.Flags().GetString("order")
+	opts.Order = knowledge.ExportOrder(order)
 
 	switch format {
 	case "yaml", "":
@@ -170,83 +463,2010 @@ func runKnowledgeExport(cmd *cobra.Command, args []string) error {
 			return err
 		}
 		fmt.Println("Exported to knowledge/index/export.json")
+	case "csv":
+		if err := store.ExportCSV(context.Background(), opts); err != nil {
+			return err
+		}
+		fmt.Println("Exported to knowledge/index/export.csv")
+	case "parquet":
+		if err := store.ExportParquet(context.Background(), opts); err != nil {
+			return err
+		}
+		fmt.Println("Exported to knowledge/index/export.parquet")
+	case "anki":
+		if err := store.ExportAnki(context.Background(), opts); err != nil {
+			return err
+		}
+		fmt.Println("Exported to knowledge/index/export-anki.txt")
+	case "graphml", "dot":
+		nodes, _ := cmd.Flags().GetStringSlice("nodes")
+		minCited, _ := cmd.Flags().GetInt("min-cited")
+		gopts := knowledge.GraphExportOptions{Query: opts, Nodes: nodes, MinCited: minCited}
+		export := store.ExportGraphML
+		if format == "dot" {
+			export = store.ExportDOT
+		}
+		if err := export(context.Background(), gopts); err != nil {
+			return err
+		}
+		fmt.Printf("Exported to knowledge/index/export.%s\n", format)
 	default:
-		return fmt.Errorf("unsupported format %q: use yaml or json", format)
+		return fmt.Errorf("unsupported format %q: use yaml, json, csv, parquet, anki, graphml, or dot", format)
 	}
 
 	return nil
 }
 
-// --- shared helpers ---
+// --- ask subcommand ---
 
-func knowledgeConfig(cmd *cobra.Command) (types.KnowledgeBaseConfig, string) {
-	knowledgeDir, _ := cmd.Flags().GetString("knowledge-dir")
-	if knowledgeDir == "" {
-		knowledgeDir = "knowledge"
+var knowledgeAskCmd = &cobra.Command{
+	Use:   "ask [question]",
+	Short: "Answer a question from the knowledge base with footnoted references",
+	Long: `Ask retrieves the knowledge items most relevant to a natural-language
+question and renders them as a Markdown answer. Each statement carries a
+numbered footnote with the paper title, section, page, and item ID so the
+answer can be pasted into a draft with verifiable provenance.
+
+Use --out to write the answer to a file instead of stdout.`,
+	RunE: runKnowledgeAsk,
+}
+
+func runKnowledgeAsk(cmd *cobra.Command, args []string) error {
+	outPath, _ := cmd.Flags().GetString("out")
+
+	opts, err := queryOptsFromFlags(cmd, args)
+	if err != nil {
+		return err
 	}
-	papersDir, _ := cmd.Flags().GetString("papers-dir")
-	if papersDir == "" {
-		papersDir = "papers"
+	question := opts.Query
+	if question == "" {
+		return fmt.Errorf("question required: provide it as arguments")
 	}
-	maxResults, _ := cmd.Flags().GetInt("max-results")
 
-	cfg := types.KnowledgeBaseConfig{
-		KnowledgeDir: knowledgeDir,
-		MaxResults:   maxResults,
+	cfg, papersDir := knowledgeConfig(cmd)
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
 	}
-	return cfg, papersDir
+	defer store.Close()
+
+	answer, err := store.Ask(context.Background(), question, opts)
+	if err != nil {
+		return err
+	}
+
+	if outPath == "" {
+		return answer.RenderMarkdown(os.Stdout)
+	}
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", outPath, err)
+	}
+	if err := answer.RenderMarkdown(f); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", outPath, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing %s: %w", outPath, err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote answer with %d references to %s\n", len(answer.Results), outPath)
+	return nil
 }
 
-func queryOptsFromFlags(cmd *cobra.Command, args []string) knowledge.QueryOptions {
-	queryText, _ := cmd.Flags().GetString("query")
-	if queryText == "" && len(args) > 0 {
-		queryText = strings.Join(args, " ")
+// --- versions subcommand ---
+
+var knowledgeVersionsCmd = &cobra.Command{
+	Use:   "versions [paper-id]",
+	Short: "List the linked preprint/published versions of a paper",
+	Long: `Versions lists every paper record linked to the given paper because
+they share an arXiv ID or DOI, canonical version first. Retrieval and
+export cite the canonical version; the other versions keep their own
+PDFs and items.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runKnowledgeVersions,
+}
+
+func runKnowledgeVersions(cmd *cobra.Command, args []string) error {
+	cfg, papersDir := knowledgeConfig(cmd)
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	versions, err := store.Versions(context.Background(), args[0])
+	if err != nil {
+		return err
 	}
 
-	itemType, _ := cmd.Flags().GetString("type")
-	tag, _ := cmd.Flags().GetString("tag")
-	paperID, _ := cmd.Flags().GetString("paper")
+	for _, v := range versions {
+		marker := " "
+		if v.Canonical {
+			marker = "*"
+		}
+		kind := "published"
+		if v.Preprint {
+			kind = "preprint"
+		}
+		fmt.Fprintf(os.Stdout, "%s %-30s  %-9s  %s\n", marker, v.ID, kind, v.PDFPath)
+	}
+	return nil
+}
+
+// --- papers subcommands ---
+
+var knowledgePapersCmd = &cobra.Command{
+	Use:   "papers",
+	Short: "List the papers in the knowledge base with their item counts and tags",
+	Long: `Papers lists the papers in the knowledge base, newest first, with their
+date, title, authors, number of knowledge items, and most frequent tags.
+Papers registered without a PDF are listed with no items.
+
+Use --query to list only papers with items matching a full-text query,
+in the syntax of retrieve, ranked by their number of matching items. Use
+knowledge paper <id> for the detailed view of one paper.`,
+	Args: cobra.NoArgs,
+	RunE: runKnowledgePapers,
+}
+
+func runKnowledgePapers(cmd *cobra.Command, args []string) error {
+	query, _ := cmd.Flags().GetString("query")
 	limit, _ := cmd.Flags().GetInt("limit")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
 
-	opts := knowledge.QueryOptions{
-		Query:      queryText,
-		Type:       types.KnowledgeItemType(itemType),
-		PaperID:    paperID,
-		MaxResults: limit,
+	cfg, papersDir := knowledgeConfig(cmd)
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
 	}
-	if tag != "" {
-		opts.Tags = []string{tag}
+	defer store.Close()
+
+	papers, err := store.Papers(context.Background(), knowledge.PaperListOptions{Query: query, MaxResults: limit})
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(papers)
+	}
+	if len(papers) == 0 {
+		fmt.Println("No papers found.")
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "%-25s  %-10s  %5s  %-40s  %-20s  %s\n", "Paper", "Date", "Items", "Title", "Authors", "Tags")
+	fmt.Fprintln(os.Stdout, strings.Repeat("-", 120))
+	for _, p := range papers {
+		items := strconv.Itoa(p.Items)
+		if query != "" {
+			items = fmt.Sprintf("%d/%d", p.Matches, p.Items)
+		}
+		var tags []string
+		for _, t := range p.Tags {
+			tags = append(tags, t.Value)
+		}
+		fmt.Fprintf(os.Stdout, "%-25s  %-10s  %5s  %-40s  %-20s  %s\n",
+			truncateColumn(p.ID, 25), dateOnly(p.Date), items, truncateColumn(p.Title, 40),
+			truncateColumn(authorsShort(p.Authors), 20), strings.Join(tags, ", "))
+	}
+	if query != "" {
+		fmt.Fprintln(os.Stdout, "\nItems column: matching/total items.")
 	}
-	return opts
+	return nil
 }
 
-func init() {
-	// Shared flags on the parent command, inherited by subcommands.
-	knowledgeCmd.PersistentFlags().String("knowledge-dir", "knowledge", "base directory for knowledge (contains extracted/, index/)")
-	knowledgeCmd.PersistentFlags().String("papers-dir", "papers", "base directory for papers (contains metadata/, markdown/)")
-	knowledgeCmd.PersistentFlags().Int("max-results", 20, "maximum number of query results")
+var knowledgePaperCmd = &cobra.Command{
+	Use:   "paper <paper-id>",
+	Short: "Show one paper: metadata, venue, versions, item counts, and items",
+	Long: `Paper shows the detailed view of one paper in the knowledge base: its
+metadata, venue and rank, author institutions, linked preprint/published
+versions, item counts by type, section, and tag, and its knowledge items
+by section and page.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runKnowledgePaper,
+}
 
-	// Retrieve flags.
-	knowledgeRetrieveCmd.Flags().String("query", "", "full-text search query")
-	knowledgeRetrieveCmd.Flags().String("type", "", "filter by item type: claim, method, definition, result")
-	knowledgeRetrieveCmd.Flags().String("tag", "", "filter by tag")
-	knowledgeRetrieveCmd.Flags().String("paper", "", "filter by paper ID")
-	knowledgeRetrieveCmd.Flags().Int("limit", 0, "maximum results (0 = use default)")
-	knowledgeRetrieveCmd.Flags().String("trace", "", "show source context for an item ID")
-	knowledgeRetrieveCmd.Flags().Bool("json", false, "output results as JSON")
+func runKnowledgePaper(cmd *cobra.Command, args []string) error {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
 
-	// Export flags.
-	knowledgeExportCmd.Flags().String("format", "yaml", "export format: yaml or json")
-	knowledgeExportCmd.Flags().String("query", "", "full-text search filter for partial export")
-	knowledgeExportCmd.Flags().String("type", "", "filter by item type for partial export")
-	knowledgeExportCmd.Flags().String("tag", "", "filter by tag for partial export")
-	knowledgeExportCmd.Flags().String("paper", "", "filter by paper ID for partial export")
-	knowledgeExportCmd.Flags().Int("limit", 0, "maximum items to export (0 = all)")
+	cfg, papersDir := knowledgeConfig(cmd)
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
 
-	// Wire subcommands.
-	knowledgeCmd.AddCommand(knowledgeStoreCmd)
-	knowledgeCmd.AddCommand(knowledgeRetrieveCmd)
-	knowledgeCmd.AddCommand(knowledgeExportCmd)
+	d, err := store.Paper(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	}
+	formatPaperDetail(d)
+	return nil
+}
 
-	rootCmd.AddCommand(knowledgeCmd)
+func formatPaperDetail(d knowledge.PaperDetail) {
+	title := d.Title
+	if title == "" {
+		title = "(untitled)"
+	}
+	fmt.Fprintln(os.Stdout, title)
+	fields := []struct{ label, value string }{
+		{"ID", d.ID},
+		{"Authors", strings.Join(d.Authors, "; ")},
+		{"Date", dateOnly(d.Date)},
+		{"DOI", d.DOI},
+		{"arXiv", d.ArxivID},
+		{"Venue", strings.TrimSpace(d.Venue + " " + d.VenueRank)},
+		{"Institutions", strings.Join(d.Institutions, "; ")},
+		{"Status", d.Status},
+		{"Source", d.Source},
+		{"URL", d.SourceURL},
+		{"PDF", d.PDFPath},
+	}
+	for _, f := range fields {
+		if f.value != "" {
+			fmt.Fprintf(os.Stdout, "%-14s %s\n", f.label+":", f.value)
+		}
+	}
+	if len(d.Versions) > 0 {
+		fmt.Fprintln(os.Stdout, "\nVersions:")
+		for _, v := range d.Versions {
+			marker := " "
+			if v.Canonical {
+				marker = "*"
+			}
+			kind := "published"
+			if v.Preprint {
+				kind = "preprint"
+			}
+			fmt.Fprintf(os.Stdout, "  %s %-30s  %s\n", marker, v.ID, kind)
+		}
+	}
+	if d.Abstract != "" {
+		fmt.Fprintf(os.Stdout, "\nAbstract:\n  %s\n", d.Abstract)
+	}
+
+	fmt.Fprintf(os.Stdout, "\nItems: %d\n", d.Items)
+	if d.Items == 0 {
+		return
+	}
+	itemTypes := make([]string, 0, len(d.ItemsByType))
+	for t := range d.ItemsByType {
+		itemTypes = append(itemTypes, t)
+	}
+	sort.Strings(itemTypes)
+	for _, t := range itemTypes {
+		fmt.Fprintf(os.Stdout, "  %-12s %d\n", t, d.ItemsByType[t])
+	}
+	for _, f := range []struct {
+		title  string
+		counts []knowledge.FacetCount
+	}{
+		{"Sections", d.Sections},
+		{"Tags", d.Tags},
+	} {
+		if len(f.counts) == 0 {
+			continue
+		}
+		var parts []string
+		for _, c := range f.counts {
+			parts = append(parts, fmt.Sprintf("%s (%d)", c.Value, c.Items))
+		}
+		fmt.Fprintf(os.Stdout, "%s: %s\n", f.title, strings.Join(parts, ", "))
+	}
+
+	fmt.Fprintf(os.Stdout, "\n%-30s  %-10s  %-15s  %4s  %s\n", "Item", "Type", "Section", "Page", "Content")
+	fmt.Fprintln(os.Stdout, strings.Repeat("-", 120))
+	for _, it := range d.KnowledgeItems {
+		fmt.Fprintf(os.Stdout, "%-30s  %-10s  %-15s  %4d  %s\n",
+			truncateColumn(it.ID, 30), it.Type, truncateColumn(it.Section, 15), it.Page, truncateColumn(it.Content, 50))
+	}
+}
+
+// dateOnly returns the YYYY-MM-DD part of a stored RFC 3339 date.
+func dateOnly(date string) string {
+	if len(date) > 10 {
+		return date[:10]
+	}
+	return date
+}
+
+// authorsShort returns the first author, with "et al." when there are
+// more.
+func authorsShort(authors []string) string {
+	switch len(authors) {
+	case 0:
+		return ""
+	case 1:
+		return authors[0]
+	default:
+		return authors[0] + " et al."
+	}
+}
+
+// --- collection subcommands ---
+
+var knowledgeCollectionCmd = &cobra.Command{
+	Use:   "collection",
+	Short: "Group papers and items into named reading lists (create, add, remove, list, delete)",
+	Long: `Collection manages named sets of papers and knowledge items, such as the
+reading list of one survey or thesis chapter. Retrieve and export take
+--collection to search or export only a collection's members; a paper in
+a collection brings all its items.
+
+Collections live in the knowledge base database and are carried over by
+knowledge rebuild.`,
+}
+
+var knowledgeCollectionCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an empty collection",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runKnowledgeCollectionCreate,
+}
+
+var knowledgeCollectionAddCmd = &cobra.Command{
+	Use:   "add <name> <paper-or-item-id>...",
+	Short: "Add papers and items to a collection",
+	Long: `Add adds papers and knowledge items, named by ID, to a collection. Each ID
+is looked up as a paper first, then as an item; if any ID is unknown,
+nothing is added.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runKnowledgeCollectionAdd,
+}
+
+var knowledgeCollectionRemoveCmd = &cobra.Command{
+	Use:   "remove <name> <paper-or-item-id>...",
+	Short: "Remove papers and items from a collection",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runKnowledgeCollectionRemove,
+}
+
+var knowledgeCollectionListCmd = &cobra.Command{
+	Use:   "list [name]",
+	Short: "List the collections, or the members of one",
+	Long: `List prints every collection with its paper and item counts, or, given a
+name, the collection's members. Members no longer in the knowledge base
+are marked missing.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runKnowledgeCollectionList,
+}
+
+var knowledgeCollectionDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a collection; its papers and items stay in the knowledge base",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runKnowledgeCollectionDelete,
+}
+
+// collectionStore opens the knowledge base for a collection or snapshot
+// subcommand.
+func collectionStore(cmd *cobra.Command) (*knowledge.Store, error) {
+	cfg, papersDir := knowledgeConfig(cmd)
+	return knowledge.NewStore(cfg, papersDir)
+}
+
+func runKnowledgeCollectionCreate(cmd *cobra.Command, args []string) error {
+	description, _ := cmd.Flags().GetString("description")
+	store, err := collectionStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if err := store.CreateCollection(context.Background(), args[0], description); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Created collection %q\n", args[0])
+	return nil
+}
+
+func runKnowledgeCollectionAdd(cmd *cobra.Command, args []string) error {
+	store, err := collectionStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	n, err := store.AddToCollection(context.Background(), args[0], args[1:])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Added %d to %q", n, args[0])
+	if skipped := len(args) - 1 - n; skipped > 0 {
+		fmt.Fprintf(os.Stdout, " (%d already members)", skipped)
+	}
+	fmt.Fprintln(os.Stdout)
+	return nil
+}
+
+func runKnowledgeCollectionRemove(cmd *cobra.Command, args []string) error {
+	store, err := collectionStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	n, err := store.RemoveFromCollection(context.Background(), args[0], args[1:])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Removed %d of %d from %q\n", n, len(args)-1, args[0])
+	return nil
+}
+
+func runKnowledgeCollectionList(cmd *cobra.Command, args []string) error {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	store, err := collectionStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if len(args) == 1 {
+		members, err := store.CollectionMembers(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			return enc.Encode(members)
+		}
+		if len(members) == 0 {
+			fmt.Fprintf(os.Stdout, "Collection %q is empty.\n", args[0])
+			return nil
+		}
+		for _, m := range members {
+			label := truncateColumn(m.Label, 70)
+			if m.Missing {
+				label = "(missing from the knowledge base)"
+			}
+			fmt.Fprintf(os.Stdout, "%-5s  %-30s  %s\n", m.Kind, m.ID, label)
+		}
+		return nil
+	}
+
+	collections, err := store.Collections(ctx)
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		return enc.Encode(collections)
+	}
+	if len(collections) == 0 {
+		fmt.Fprintln(os.Stdout, "No collections.")
+		return nil
+	}
+	for _, c := range collections {
+		fmt.Fprintf(os.Stdout, "%-24s  %3d papers  %3d items  %s\n", c.Name, c.Papers, c.Items, c.Description)
+	}
+	return nil
+}
+
+func runKnowledgeCollectionDelete(cmd *cobra.Command, args []string) error {
+	store, err := collectionStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if err := store.DeleteCollection(context.Background(), args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Deleted collection %q\n", args[0])
+	return nil
+}
+
+// --- snapshot subcommands ---
+
+var knowledgeSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Record and compare the knowledge base over time (create, list, diff, delete)",
+	Long: `Snapshot records the knowledge base's items under a name, so a long-running
+review can later see what changed in the corpus: papers added or removed,
+items added, removed, edited, or reviewed, and the papers stored in
+between. Every paper knowledge store indexes is also recorded in an ingest
+history, which diff lists.
+
+Snapshots and the ingest history live in the knowledge base database and
+are carried over by knowledge rebuild.`,
+}
+
+var knowledgeSnapshotCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Record the current items under a name",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runKnowledgeSnapshotCreate,
+}
+
+var knowledgeSnapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the snapshots, oldest first",
+	Args:  cobra.NoArgs,
+	RunE:  runKnowledgeSnapshotList,
+}
+
+var knowledgeSnapshotDiffCmd = &cobra.Command{
+	Use:   "diff <a> [b]",
+	Short: "Show what changed between two snapshots, or since one",
+	Long: `Diff compares snapshot a with snapshot b, or with the current knowledge
+base when b is omitted. It lists papers added and removed, items added and
+removed outside those papers, items whose type, content, or review status
+changed, and the papers stored between the two snapshots.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runKnowledgeSnapshotDiff,
+}
+
+var knowledgeSnapshotDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a snapshot; the ingest history stays",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runKnowledgeSnapshotDelete,
+}
+
+func runKnowledgeSnapshotCreate(cmd *cobra.Command, args []string) error {
+	description, _ := cmd.Flags().GetString("description")
+	store, err := collectionStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	sn, err := store.CreateSnapshot(context.Background(), args[0], description)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Created snapshot %q: %d papers, %d items\n", sn.Name, sn.Papers, sn.Items)
+	return nil
+}
+
+func runKnowledgeSnapshotList(cmd *cobra.Command, args []string) error {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	store, err := collectionStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	snapshots, err := store.Snapshots(context.Background())
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(snapshots)
+	}
+	if len(snapshots) == 0 {
+		fmt.Fprintln(os.Stdout, "No snapshots.")
+		return nil
+	}
+	for _, sn := range snapshots {
+		fmt.Fprintf(os.Stdout, "%-24s  %s  %4d papers  %5d items  %s\n", sn.Name, sn.CreatedAt, sn.Papers, sn.Items, sn.Description)
+	}
+	return nil
+}
+
+func runKnowledgeSnapshotDiff(cmd *cobra.Command, args []string) error {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	store, err := collectionStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	to := ""
+	if len(args) == 2 {
+		to = args[1]
+	}
+	diff, err := store.DiffSnapshots(context.Background(), args[0], to)
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diff)
+	}
+	printSnapshotDiff(diff)
+	return nil
+}
+
+// printSnapshotDiff prints a snapshot diff. Papers added or removed are
+// listed with their item counts rather than item by item.
+func printSnapshotDiff(diff knowledge.SnapshotDiff) {
+	to := "the current knowledge base"
+	if diff.To != "" {
+		to = fmt.Sprintf("%q", diff.To)
+	}
+	fmt.Fprintf(os.Stdout, "Changes from %q to %s: %d papers added, %d removed; %d items added, %d removed, %d changed\n",
+		diff.From, to, len(diff.AddedPapers), len(diff.RemovedPapers),
+		len(diff.AddedItems), len(diff.RemovedItems), len(diff.ChangedItems))
+
+	countByPaper := func(items []knowledge.SnapshotItem) map[string]int {
+		counts := make(map[string]int)
+		for _, it := range items {
+			counts[it.PaperID]++
+		}
+		return counts
+	}
+	added, removed := countByPaper(diff.AddedItems), countByPaper(diff.RemovedItems)
+	printItems := func(title, mark string, items []knowledge.SnapshotItem, papers []string) {
+		whole := make(map[string]bool)
+		for _, p := range papers {
+			whole[p] = true
+		}
+		var lines []string
+		for _, it := range items {
+			if !whole[it.PaperID] {
+				lines = append(lines, fmt.Sprintf("  %s %-30s  [%s] %s", mark, it.ID, it.Type, truncateColumn(it.Content, 70)))
+			}
+		}
+		if len(lines) > 0 {
+			fmt.Fprintf(os.Stdout, "\n%s:\n%s\n", title, strings.Join(lines, "\n"))
+		}
+	}
+
+	if len(diff.AddedPapers) > 0 {
+		fmt.Fprintln(os.Stdout, "\nPapers added:")
+		for _, p := range diff.AddedPapers {
+			fmt.Fprintf(os.Stdout, "  + %s (%d items)\n", p, added[p])
+		}
+	}
+	if len(diff.RemovedPapers) > 0 {
+		fmt.Fprintln(os.Stdout, "\nPapers removed:")
+		for _, p := range diff.RemovedPapers {
+			fmt.Fprintf(os.Stdout, "  - %s (%d items)\n", p, removed[p])
+		}
+	}
+	printItems("Items added", "+", diff.AddedItems, diff.AddedPapers)
+	printItems("Items removed", "-", diff.RemovedItems, diff.RemovedPapers)
+	if len(diff.ChangedItems) > 0 {
+		fmt.Fprintln(os.Stdout, "\nItems changed:")
+		for _, c := range diff.ChangedItems {
+			fmt.Fprintf(os.Stdout, "  ~ %s\n", c.After.ID)
+			if c.Before.Type != c.After.Type {
+				fmt.Fprintf(os.Stdout, "      type: %s -> %s\n", c.Before.Type, c.After.Type)
+			}
+			if c.Before.Content != c.After.Content {
+				fmt.Fprintf(os.Stdout, "      was: %s\n      now: %s\n", truncateColumn(c.Before.Content, 70), truncateColumn(c.After.Content, 70))
+			}
+			if c.Before.Status != c.After.Status {
+				fmt.Fprintf(os.Stdout, "      status: %s -> %s\n", c.Before.Status, c.After.Status)
+			}
+		}
+	}
+	if len(diff.Ingests) > 0 {
+		fmt.Fprintln(os.Stdout, "\nStored in between:")
+		for _, e := range diff.Ingests {
+			fmt.Fprintf(os.Stdout, "  %s  %-7s  %s (%d items)\n", e.IngestedAt, e.Action, e.PaperID, e.Items)
+		}
+	}
+}
+
+func runKnowledgeSnapshotDelete(cmd *cobra.Command, args []string) error {
+	store, err := collectionStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if err := store.DeleteSnapshot(context.Background(), args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Deleted snapshot %q\n", args[0])
+	return nil
+}
+
+// --- item subcommands ---
+
+var knowledgeItemCmd = &cobra.Command{
+	Use:   "item",
+	Short: "Curate knowledge items by hand (add, edit, delete)",
+	Long: `Item adds, edits, and deletes knowledge items by hand: notes from a talk,
+corrections of extraction mistakes, or removal of noise. The changes are
+recorded in knowledge/curated/<paper-id>-curated.yaml and applied over the
+paper's extraction whenever it is stored, so they survive re-extraction
+and rebuild. Curated items are marked curated in JSON output.`,
+}
+
+var knowledgeItemAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a hand-written item to a paper",
+	Long: `Add stores a hand-written item for --paper, which need not have an
+extraction file. The item gets an ID from its paper, section, and
+content, as extracted items do, and a confidence of 1 unless
+--confidence is given.`,
+	Args: cobra.NoArgs,
+	RunE: runKnowledgeItemAdd,
+}
+
+var knowledgeItemEditCmd = &cobra.Command{
+	Use:   "edit <item-id>",
+	Short: "Change an item's type, content, section, page, confidence, or tags",
+	Long: `Edit changes the fields given by flags and keeps the others. The item
+keeps its ID, so collections and drafts citing it stay valid.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runKnowledgeItemEdit,
+}
+
+var knowledgeItemDeleteCmd = &cobra.Command{
+	Use:   "delete <item-id>...",
+	Short: "Remove items; extracted items stay removed when their paper is stored again",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runKnowledgeItemDelete,
+}
+
+func runKnowledgeItemAdd(cmd *cobra.Command, args []string) error {
+	paperID, _ := cmd.Flags().GetString("paper")
+	itemType, _ := cmd.Flags().GetString("type")
+	content, _ := cmd.Flags().GetString("content")
+	section, _ := cmd.Flags().GetString("section")
+	page, _ := cmd.Flags().GetInt("page")
+	confidence, _ := cmd.Flags().GetFloat64("confidence")
+	tags, _ := cmd.Flags().GetStringSlice("tags")
+	if paperID == "" || itemType == "" || content == "" {
+		return fmt.Errorf("--paper, --type, and --content are required")
+	}
+
+	cfg, papersDir := knowledgeConfig(cmd)
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	item, err := store.AddItem(context.Background(), types.KnowledgeItem{
+		Type:       types.KnowledgeItemType(itemType),
+		Content:    content,
+		PaperID:    paperID,
+		Section:    section,
+		Page:       page,
+		Confidence: confidence,
+		Tags:       tags,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Added %s %s to %s\n", item.Type, item.ID, item.PaperID)
+	return nil
+}
+
+func runKnowledgeItemEdit(cmd *cobra.Command, args []string) error {
+	var edit knowledge.ItemEdit
+	flags := cmd.Flags()
+	if flags.Changed("type") {
+		v, _ := flags.GetString("type")
+		t := types.KnowledgeItemType(v)
+		edit.Type = &t
+	}
+	if flags.Changed("content") {
+		v, _ := flags.GetString("content")
+		edit.Content = &v
+	}
+	if flags.Changed("section") {
+		v, _ := flags.GetString("section")
+		edit.Section = &v
+	}
+	if flags.Changed("page") {
+		v, _ := flags.GetInt("page")
+		edit.Page = &v
+	}
+	if flags.Changed("confidence") {
+		v, _ := flags.GetFloat64("confidence")
+		edit.Confidence = &v
+	}
+	if flags.Changed("tags") {
+		v, _ := flags.GetStringSlice("tags")
+		edit.Tags = &v
+	}
+	if edit == (knowledge.ItemEdit{}) {
+		return fmt.Errorf("nothing to change: give --type, --content, --section, --page, --confidence, or --tags")
+	}
+
+	cfg, papersDir := knowledgeConfig(cmd)
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	item, err := store.EditItem(context.Background(), args[0], edit)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Edited %s %s: %s\n", item.Type, item.ID, truncateColumn(item.Content, 70))
+	return nil
+}
+
+func runKnowledgeItemDelete(cmd *cobra.Command, args []string) error {
+	cfg, papersDir := knowledgeConfig(cmd)
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	for _, id := range args {
+		if err := store.DeleteItem(context.Background(), id); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "Deleted %s\n", id)
+	}
+	return nil
+}
+
+// --- migrate subcommand ---
+
+var knowledgeMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Bring the knowledge base schema up to date",
+	Long: `Migrate applies the schema migrations the knowledge base database lacks
+and records them in its schema_version table. Every knowledge command
+applies pending migrations when it opens the database, so running migrate
+is only needed to upgrade ahead of time. Use --dry-run to list the pending
+migrations without changing the database.
+
+A database from a newer build of research-engine is refused rather than
+changed.`,
+	Args: cobra.NoArgs,
+	RunE: runKnowledgeMigrate,
+}
+
+func runKnowledgeMigrate(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	cfg, papersDir := knowledgeConfig(cmd)
+
+	state, err := knowledge.CheckSchema(cfg.KnowledgeDir)
+	if err != nil {
+		return err
+	}
+	if state.Current > state.Latest {
+		return fmt.Errorf("database schema version %d is newer than this build supports (%d): upgrade research-engine", state.Current, state.Latest)
+	}
+	if len(state.Pending) == 0 {
+		fmt.Fprintf(os.Stdout, "schema is up to date (version %d)\n", state.Current)
+		return nil
+	}
+
+	verb := "applied"
+	if dryRun {
+		verb = "would apply"
+	}
+	if !state.Exists {
+		fmt.Fprintf(os.Stdout, "no database yet; it will be created at schema version %d\n", state.Latest)
+		if dryRun {
+			return nil
+		}
+	}
+	if !dryRun {
+		store, err := knowledge.NewStore(cfg, papersDir)
+		if err != nil {
+			return err
+		}
+		store.Close()
+	}
+	for _, m := range state.Pending {
+		fmt.Fprintf(os.Stdout, "%s %3d  %s\n", verb, m.Version, m.Name)
+	}
+	fmt.Fprintf(os.Stdout, "\nschema version %d -> %d\n", state.Current, state.Latest)
+	return nil
+}
+
+// --- backup, restore, and verify subcommands ---
+
+var knowledgeBackupCmd = &cobra.Command{
+	Use:   "backup <path>",
+	Short: "Write a consistent copy of the knowledge base database",
+	Long: `Backup writes a compacted copy of knowledge/index/research.db to the
+given path, which must not exist. The copy holds every committed change,
+including those still in the write-ahead log, and can be taken while
+other commands read the database.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runKnowledgeBackup,
+}
+
+func runKnowledgeBackup(cmd *cobra.Command, args []string) error {
+	cfg, papersDir := knowledgeConfig(cmd)
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if err := store.Backup(context.Background(), args[0]); err != nil {
+		return err
+	}
+	info, err := os.Stat(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "backed up knowledge base to %s (%d bytes)\n", args[0], info.Size())
+	return nil
+}
+
+var knowledgeRestoreCmd = &cobra.Command{
+	Use:   "restore <path>",
+	Short: "Replace the knowledge base database with a backup",
+	Long: `Restore replaces knowledge/index/research.db with a backup written by
+"knowledge backup". The backup must pass SQLite's integrity check and
+have a schema this build knows; an older schema is migrated. The replaced
+database is kept as research.db.bak, replacing any earlier one. Run no
+other knowledge command while restoring.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runKnowledgeRestore,
+}
+
+func runKnowledgeRestore(cmd *cobra.Command, args []string) error {
+	cfg, papersDir := knowledgeConfig(cmd)
+	previous, err := knowledge.Restore(cfg.KnowledgeDir, args[0])
+	if err != nil {
+		return err
+	}
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
+	}
+	store.Close()
+
+	fmt.Fprintf(os.Stdout, "restored knowledge base from %s\n", args[0])
+	if previous != "" {
+		fmt.Fprintf(os.Stdout, "previous database kept as %s\n", previous)
+	}
+	return nil
+}
+
+var knowledgeVerifyCmd = &cobra.Command{
+	Use:   "verify [item-id...]",
+	Short: "Check the knowledge base database, or mark items verified",
+	Long: `Without arguments, verify runs SQLite's integrity check on the database
+and its full-text index, then compares the stored papers and items with the
+extraction files in knowledge/extracted/: papers extracted but never
+stored, papers stored from an extraction file that is gone, and items
+missing, extra, or changed. It exits with an error when it finds a problem.
+
+Differences from the extraction files usually mean "knowledge store" has
+not run since extraction; run it to catch up. An integrity failure means
+the database is damaged; restore a backup or delete the database and run
+"knowledge store" to rebuild it.
+
+With item IDs, verify instead records that the researcher checked those
+items against their papers and marks them verified, with an optional
+--note; --reset marks them unverified again. Reviews are kept in
+knowledge/curated/ and survive storing the papers again.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runKnowledgeVerify,
+}
+
+var knowledgeDisputeCmd = &cobra.Command{
+	Use:   "dispute <item-id>...",
+	Short: "Mark items disputed, with a note on what is wrong",
+	Long: `Dispute records that the researcher found items wrong or unsupported by
+their papers, such as a misread number or an overstated claim. --note
+says why. Disputed items stay in the knowledge base; retrieve --status
+disputed lists them, and exports carry the status and note.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runKnowledgeDispute,
+}
+
+// verifyListMax is the number of IDs verify lists for each problem.
+const verifyListMax = 10
+
+func runKnowledgeVerify(cmd *cobra.Command, args []string) error {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	if len(args) > 0 {
+		if jsonOutput {
+			return fmt.Errorf("--json applies to the integrity check and cannot be used with item IDs")
+		}
+		status := types.StatusVerified
+		if reset, _ := cmd.Flags().GetBool("reset"); reset {
+			status = types.StatusUnverified
+		}
+		return reviewItems(cmd, args, status)
+	}
+	if cmd.Flags().Changed("note") || cmd.Flags().Changed("reset") {
+		return fmt.Errorf("--note and --reset require item IDs")
+	}
+
+	cfg, papersDir := knowledgeConfig(cmd)
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	report, err := store.Verify(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		integrity := "ok"
+		if len(report.Integrity) > 0 {
+			integrity = strings.Join(report.Integrity, "; ")
+		}
+		fullText := "ok"
+		if report.FullText != "" {
+			fullText = report.FullText
+		}
+		fmt.Fprintf(os.Stdout, "integrity:        %s\n", integrity)
+		fmt.Fprintf(os.Stdout, "full-text index:  %s\n", fullText)
+		fmt.Fprintf(os.Stdout, "extraction files: %d papers, %d items\n", report.Papers, report.Items)
+		for _, p := range []struct {
+			label string
+			ids   []string
+		}{
+			{"unreadable extraction files", report.Unreadable},
+			{"papers not stored", report.NotIndexed},
+			{"papers without extraction file", report.Orphaned},
+			{"items missing", report.MissingItems},
+			{"items not in any extraction file", report.ExtraItems},
+			{"items changed since storing", report.ChangedItems},
+		} {
+			if len(p.ids) == 0 {
+				continue
+			}
+			ids := p.ids
+			more := ""
+			if len(ids) > verifyListMax {
+				more = fmt.Sprintf(", and %d more", len(ids)-verifyListMax)
+				ids = ids[:verifyListMax]
+			}
+			fmt.Fprintf(os.Stdout, "%s (%d): %s%s\n", p.label, len(p.ids), strings.Join(ids, ", "), more)
+		}
+	}
+
+	if n := report.Problems(); n > 0 {
+		return fmt.Errorf("verification found %d problem(s)", n)
+	}
+	if !jsonOutput {
+		fmt.Fprintln(os.Stdout, "\nknowledge base verified")
+	}
+	return nil
+}
+
+func runKnowledgeDispute(cmd *cobra.Command, args []string) error {
+	if note, _ := cmd.Flags().GetString("note"); strings.TrimSpace(note) == "" {
+		return fmt.Errorf("--note is required: say what is wrong with the items")
+	}
+	return reviewItems(cmd, args, types.StatusDisputed)
+}
+
+// reviewItems sets the review status of the items named by ids, with the
+// command's --note.
+func reviewItems(cmd *cobra.Command, ids []string, status types.ItemStatus) error {
+	note, _ := cmd.Flags().GetString("note")
+	cfg, papersDir := knowledgeConfig(cmd)
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if err := store.ReviewItems(context.Background(), ids, status, note); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Marked %d item(s) %s\n", len(ids), status)
+	return nil
+}
+
+// --- stats subcommand ---
+
+var knowledgeStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize the knowledge base: papers, items, authors, institutions, venues",
+	Long: `Stats counts the papers, knowledge items (by type), authors, and
+institutions in the knowledge base, and breaks the items down by paper,
+tag, and section with a histogram of their extraction confidence, for an
+overview of corpus coverage before drafting. Text output lists the ten
+largest of each breakdown (--top N to change); --json includes them all. Authors are merged by ORCID, and by
+normalized name when no ORCID is known; affiliations come from OpenAlex at
+acquisition time.
+
+Use --by-institution to count papers and authors per institution for
+landscape analyses, and --institution on retrieve or export to slice the
+corpus by affiliation. Use --by-venue to count papers per venue with its
+type and rank, for venue-coverage claims in surveys.`,
+	Args: cobra.NoArgs,
+	RunE: runKnowledgeStats,
+}
+
+func runKnowledgeStats(cmd *cobra.Command, args []string) error {
+	byInstitution, _ := cmd.Flags().GetBool("by-institution")
+	byVenue, _ := cmd.Flags().GetBool("by-venue")
+	if byInstitution && byVenue {
+		return fmt.Errorf("use either --by-institution or --by-venue")
+	}
+	top, _ := cmd.Flags().GetInt("top")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	cfg, papersDir := knowledgeConfig(cmd)
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	var out any
+	switch {
+	case byVenue:
+		stats, err := store.VenueStats(ctx)
+		if err != nil {
+			return err
+		}
+		if top > 0 && len(stats) > top {
+			stats = stats[:top]
+		}
+		out = stats
+		if !jsonOutput {
+			formatVenueStats(stats)
+			return nil
+		}
+	case byInstitution:
+		stats, err := store.InstitutionStats(ctx)
+		if err != nil {
+			return err
+		}
+		if top > 0 && len(stats) > top {
+			stats = stats[:top]
+		}
+		out = stats
+		if !jsonOutput {
+			formatInstitutionStats(stats)
+			return nil
+		}
+	default:
+		stats, err := store.Stats(ctx)
+		if err != nil {
+			return err
+		}
+		out = stats
+		if !jsonOutput {
+			if top <= 0 {
+				top = defaultStatsTop
+			}
+			formatKnowledgeStats(stats, top)
+			return nil
+		}
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// defaultStatsTop is the number of papers, tags, and sections the stats
+// text output lists.
+const defaultStatsTop = 10
+
+func formatKnowledgeStats(st knowledge.CorpusStats, top int) {
+	fmt.Fprintf(os.Stdout, "Papers:        %d (%d with knowledge items)\n", st.Papers, st.PapersWithItems)
+	fmt.Fprintf(os.Stdout, "Items:         %d\n", st.Items)
+	itemTypes := make([]string, 0, len(st.ItemsByType))
+	for t := range st.ItemsByType {
+		itemTypes = append(itemTypes, t)
+	}
+	sort.Strings(itemTypes)
+	for _, t := range itemTypes {
+		fmt.Fprintf(os.Stdout, "  %-12s %d\n", t, st.ItemsByType[t])
+	}
+	fmt.Fprintf(os.Stdout, "Authors:       %d (%d with ORCID)\n", st.Authors, st.AuthorsWithORCID)
+	fmt.Fprintf(os.Stdout, "Institutions:  %d\n", st.Institutions)
+	fmt.Fprintf(os.Stdout, "Venues:        %d\n", st.Venues)
+	venueTypes := make([]string, 0, len(st.PapersByVenueType))
+	for t := range st.PapersByVenueType {
+		venueTypes = append(venueTypes, t)
+	}
+	sort.Strings(venueTypes)
+	for _, t := range venueTypes {
+		fmt.Fprintf(os.Stdout, "  %-12s %d papers\n", t, st.PapersByVenueType[t])
+	}
+	if st.Items == 0 {
+		return
+	}
+
+	facets := []struct {
+		title  string
+		counts map[string]int
+	}{
+		{"Items by paper", st.ItemsByPaper},
+		{"Top tags", st.ItemsByTag},
+		{"Items by section", st.ItemsBySection},
+	}
+	for _, f := range facets {
+		fmt.Fprintf(os.Stdout, "\n%s (%d):\n", f.title, len(f.counts))
+		for _, c := range knowledge.TopCounts(f.counts, top) {
+			value := c.Value
+			if len(value) > 40 {
+				value = value[:37] + "..."
+			}
+			fmt.Fprintf(os.Stdout, "  %-40s %d\n", value, c.Items)
+		}
+	}
+
+	fmt.Fprintln(os.Stdout, "\nConfidence:")
+	most := 0
+	for _, b := range st.Confidence {
+		most = max(most, b.Items)
+	}
+	for _, b := range st.Confidence {
+		bar := 0
+		if most > 0 {
+			bar = (b.Items*40 + most - 1) / most
+		}
+		line := fmt.Sprintf("  %.1f-%.1f  %6d  %s", b.Min, b.Max, b.Items, strings.Repeat("#", bar))
+		fmt.Fprintln(os.Stdout, strings.TrimRight(line, " "))
+	}
+}
+
+func formatVenueStats(stats []knowledge.VenueStat) {
+	if len(stats) == 0 {
+		fmt.Fprintln(os.Stdout, "No venues recorded. Acquire papers by DOI or arXiv ID so their venue is known, then run knowledge store.")
+		return
+	}
+	fmt.Fprintf(os.Stdout, "%-50s  %-10s  %-16s  %6s\n", "Venue", "Type", "Rank", "Papers")
+	fmt.Fprintln(os.Stdout, strings.Repeat("-", 88))
+	for _, st := range stats {
+		name := st.Venue
+		if len(name) > 50 {
+			name = name[:47] + "..."
+		}
+		rank := st.Rank
+		if rank != "" && st.RankSource != "" {
+			rank += " (" + st.RankSource + ")"
+		}
+		fmt.Fprintf(os.Stdout, "%-50s  %-10s  %-16s  %6d\n", name, st.Type, rank, st.Papers)
+	}
+}
+
+func formatInstitutionStats(stats []knowledge.InstitutionStat) {
+	if len(stats) == 0 {
+		fmt.Fprintln(os.Stdout, "No affiliations recorded. Acquire papers by DOI so OpenAlex supplies them, then run knowledge store.")
+		return
+	}
+	fmt.Fprintf(os.Stdout, "%-50s  %-7s  %6s  %7s\n", "Institution", "Country", "Papers", "Authors")
+	fmt.Fprintln(os.Stdout, strings.Repeat("-", 76))
+	for _, st := range stats {
+		name := st.Institution
+		if len(name) > 50 {
+			name = name[:47] + "..."
+		}
+		fmt.Fprintf(os.Stdout, "%-50s  %-7s  %6d  %7d\n", name, st.Country, st.Papers, st.Authors)
+	}
+}
+
+// --- note subcommands ---
+
+var knowledgeNoteCmd = &cobra.Command{
+	Use:   "note",
+	Short: "Record notes about the research process (absence, list)",
+	Long: `Note records findings about the research process that are not knowledge
+items from a paper, such as a planned survey topic that searches found
+nothing for.`,
+}
+
+var knowledgeNoteAbsenceCmd = &cobra.Command{
+	Use:   "absence",
+	Short: "Record that searches for a survey topic found nothing",
+	Long: `Absence records a negative finding for a planned survey topic in
+knowledge/notes/absence-<topic>.yaml, so the survey can state honestly what
+was searched and not found.
+
+Each --queries entry that names a file is read as a saved search query
+file: its query, run time, result count, results kept in triage, and failed
+backends are recorded. Any other entry is run as a full-text retrieval
+against the knowledge base and recorded with the number of items it
+returned. Recording the same topic again adds the new queries to its note
+and updates those already listed.`,
+	Args: cobra.NoArgs,
+	RunE: runKnowledgeNoteAbsence,
+}
+
+var knowledgeNoteListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the recorded absence notes",
+	Long: `List prints each recorded absence note with the searches behind it.
+Use --markdown to render them as a "Searched and not found" section for a
+survey draft.`,
+	Args: cobra.NoArgs,
+	RunE: runKnowledgeNoteList,
+}
+
+func runKnowledgeNoteAbsence(cmd *cobra.Command, args []string) error {
+	topic, _ := cmd.Flags().GetString("topic")
+	queries, _ := cmd.Flags().GetStringArray("queries")
+	comment, _ := cmd.Flags().GetString("note")
+	if strings.TrimSpace(topic) == "" {
+		return fmt.Errorf("--topic is required")
+	}
+	if len(queries) == 0 {
+		return fmt.Errorf("provide the searches that found nothing with --queries (query files or retrieval queries)")
+	}
+
+	cfg, papersDir := knowledgeConfig(cmd)
+	note := types.AbsenceNote{Topic: strings.TrimSpace(topic), Note: comment}
+	var store *knowledge.Store
+	for _, q := range queries {
+		if info, err := os.Stat(q); err == nil && !info.IsDir() {
+			aq, err := absenceSearchQuery(q)
+			if err != nil {
+				return err
+			}
+			if aq.Kept > 0 {
+				fmt.Fprintf(os.Stderr, "warning: %s has %d results triaged as keep\n", q, aq.Kept)
+			}
+			note.Queries = append(note.Queries, aq)
+			continue
+		}
+		if store == nil {
+			var err error
+			if store, err = knowledge.NewStore(cfg, papersDir); err != nil {
+				return err
+			}
+			defer store.Close()
+		}
+		aq, err := store.CheckRetrieval(context.Background(), q)
+		if err != nil {
+			return fmt.Errorf("retrieving %q: %w", q, err)
+		}
+		if aq.Results > 0 {
+			fmt.Fprintf(os.Stderr, "warning: retrieval %q returned %d items\n", q, aq.Results)
+		}
+		note.Queries = append(note.Queries, aq)
+	}
+
+	path, err := knowledge.RecordAbsence(cfg.KnowledgeDir, note)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Recorded absence of %q in %s (queries added or updated: %d)\n", note.Topic, path, len(note.Queries))
+	return nil
+}
+
+// absenceSearchQuery describes a saved search query file for an absence
+// note.
+func absenceSearchQuery(path string) (types.AbsenceQuery, error) {
+	qf, err := search.ReadQueryFile(path)
+	if err != nil {
+		return types.AbsenceQuery{}, fmt.Errorf("%s: %w", path, err)
+	}
+	text := qf.Query.FreeText
+	if text == "" {
+		text = strings.Join(qf.Query.Keywords, " ")
+	}
+	if qf.Query.Author != "" {
+		text = strings.TrimSpace(text + " author:" + qf.Query.Author)
+	}
+	if qf.Query.DateFrom != "" || qf.Query.DateTo != "" {
+		text = strings.TrimSpace(fmt.Sprintf("%s %s..%s", text, qf.Query.DateFrom, qf.Query.DateTo))
+	}
+	kept := 0
+	for _, r := range qf.Results {
+		if r.Triage != nil && r.Triage.Status == search.TriageKeep {
+			kept++
+		}
+	}
+	return types.AbsenceQuery{
+		Kind:          knowledge.AbsenceSearch,
+		Query:         text,
+		File:          path,
+		Run:           qf.Summary.Timestamp,
+		Results:       len(qf.Results),
+		Kept:          kept,
+		BackendErrors: qf.Summary.BackendErrors,
+	}, nil
+}
+
+func runKnowledgeNoteList(cmd *cobra.Command, args []string) error {
+	cfg, _ := knowledgeConfig(cmd)
+	notes, err := knowledge.LoadAbsences(cfg.KnowledgeDir)
+	if err != nil {
+		return err
+	}
+	if markdown, _ := cmd.Flags().GetBool("markdown"); markdown {
+		return knowledge.RenderAbsences(os.Stdout, notes)
+	}
+	if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(notes)
+	}
+	if len(notes) == 0 {
+		fmt.Fprintln(os.Stdout, "No absence notes recorded.")
+		return nil
+	}
+	for _, n := range notes {
+		fmt.Fprintf(os.Stdout, "%s  (%d queries, recorded %s)\n", n.Topic, len(n.Queries), n.Recorded.Format("2006-01-02"))
+		for _, q := range n.Queries {
+			fmt.Fprintf(os.Stdout, "  %-8s  %-40s  %d results\n", q.Kind, q.Query, q.Results)
+		}
+	}
+	return nil
+}
+
+// --- matrix subcommand ---
+
+var knowledgeMatrixCmd = &cobra.Command{
+	Use:   "matrix",
+	Short: "Compare papers across tags or item types in a table",
+	Long: `Matrix builds a comparison table with one row per paper and one column
+per --columns entry: a tag, or type:<item type> such as type:result. Each
+cell holds the paper's highest-confidence matching item, shortened to
+--max-chars, followed by a citation of the paper.
+
+Citation keys come from the references.yaml of the paper project given
+with --project; other papers get an AuthorYear key from their metadata.
+--format markdown cites as [Key]; --format latex writes a booktabs tabular
+with \cite{Key} in each cell (see --cite-command), ready to paste into a
+LaTeX paper that loads the booktabs package.`,
+	Args: cobra.NoArgs,
+	RunE: runKnowledgeMatrix,
+}
+
+func runKnowledgeMatrix(cmd *cobra.Command, args []string) error {
+	columns, _ := cmd.Flags().GetStringSlice("columns")
+	format, _ := cmd.Flags().GetString("format")
+	papers, _ := cmd.Flags().GetStringSlice("paper")
+	project, _ := cmd.Flags().GetString("project")
+	citeCommand, _ := cmd.Flags().GetString("cite-command")
+	maxChars, _ := cmd.Flags().GetInt("max-chars")
+	outPath, _ := cmd.Flags().GetString("out")
+	if len(columns) == 0 {
+		return fmt.Errorf("--columns is required: tags, or type:<item type>")
+	}
+
+	opts := knowledge.MatrixOptions{Columns: columns, PaperIDs: papers, MaxChars: maxChars}
+	if project != "" {
+		refs, err := draft.LoadReferences(project)
+		if err != nil {
+			return err
+		}
+		opts.CiteKeys = make(map[string]string, len(refs.Papers))
+		for _, r := range refs.Papers {
+			opts.CiteKeys[r.PaperID] = r.CitationKey
+		}
+	}
+
+	cfg, papersDir := knowledgeConfig(cmd)
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	m, err := store.Matrix(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+
+	var render func(io.Writer) error
+	switch format {
+	case "markdown":
+		render = func(w io.Writer) error { return knowledge.RenderMatrixMarkdown(w, m) }
+	case "latex":
+		render = func(w io.Writer) error { return knowledge.RenderMatrixLaTeX(w, m, citeCommand) }
+	case "json":
+		render = func(w io.Writer) error {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(m)
+		}
+	default:
+		return fmt.Errorf("unsupported format %q: use markdown, latex, or json", format)
+	}
+
+	if outPath == "" {
+		return render(os.Stdout)
+	}
+	f, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", outPath, err)
+	}
+	if err := render(f); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", outPath, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing %s: %w", outPath, err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %d papers by %d columns to %s\n", len(m.Rows), len(m.Columns), outPath)
+	return nil
+}
+
+// --- graph subcommand ---
+
+var knowledgeGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Work with the corpus citation graph (build)",
+	Long: `Graph connects the papers of the corpus through their bibliographies, to
+each other and to the works outside the corpus they cite.`,
+}
+
+var knowledgeGraphBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build the citation graph from extracted bibliographies",
+	Long: `Build reads the bibliography of every paper in knowledge/extracted/ and
+links each entry to the acquired paper it names, matched by the paper ID
+recorded at extraction, its DOI, arXiv ID, or title, or else to an
+external work identified by its DOI or arXiv ID. Entries with neither are
+counted as unresolved. Each edge lists the citing paper's bibliography keys
+and the knowledge items that cite the work inline.
+
+The graph is written as JSON to knowledge/index/citation-graph.json (see
+--out) for analysis and visualization.`,
+	Args: cobra.NoArgs,
+	RunE: runKnowledgeGraphBuild,
+}
+
+func runKnowledgeGraphBuild(cmd *cobra.Command, args []string) error {
+	cfg, papersDir := knowledgeConfig(cmd)
+	outPath, _ := cmd.Flags().GetString("out")
+	if outPath == "" {
+		outPath = knowledge.CitationGraphPath(cfg.KnowledgeDir)
+	}
+
+	g, err := knowledge.BuildCitationGraph(cfg.KnowledgeDir, papersDir)
+	if err != nil {
+		return err
+	}
+	if err := knowledge.WriteCitationGraph(outPath, g); err != nil {
+		return err
+	}
+	papers, external := g.Counts()
+	fmt.Fprintf(os.Stdout, "Wrote %d papers, %d external works, and %d citations to %s (%d references unresolved)\n",
+		papers, external, len(g.Edges), outPath, g.Unresolved)
+	return nil
+}
+
+// --- shared helpers ---
+
+func knowledgeConfig(cmd *cobra.Command) (types.KnowledgeBaseConfig, string) {
+	knowledgeDir, _ := cmd.Flags().GetString("knowledge-dir")
+	if knowledgeDir == "" {
+		knowledgeDir = "knowledge"
+	}
+	papersDir, _ := cmd.Flags().GetString("papers-dir")
+	if papersDir == "" {
+		papersDir = "papers"
+	}
+	maxResults, _ := cmd.Flags().GetInt("max-results")
+	versionPolicy, _ := cmd.Flags().GetString("version-policy")
+
+	embeddingBackend, _ := cmd.Flags().GetString("embedding-backend")
+	embeddingModel, _ := cmd.Flags().GetString("embedding-model")
+	embeddingURL, _ := cmd.Flags().GetString("embedding-url")
+	if embeddingBackend == "" {
+		embeddingBackend = viper.GetString("knowledge.embedding_backend")
+	}
+	if embeddingModel == "" {
+		embeddingModel = viper.GetString("knowledge.embedding_model")
+	}
+	if embeddingURL == "" {
+		embeddingURL = viper.GetString("knowledge.embedding_base_url")
+	}
+
+	cfg := types.KnowledgeBaseConfig{
+		KnowledgeDir:     knowledgeDir,
+		MaxResults:       maxResults,
+		VersionPolicy:    versionPolicy,
+		EmbeddingBackend: embeddingBackend,
+		EmbeddingModel:   embeddingModel,
+		EmbeddingBaseURL: embeddingURL,
+	}
+	return cfg, papersDir
+}
+
+// knowledgeEmbedder returns the embedder cfg selects, or nil when no
+// embedding backend is configured. The openai backend's key comes from
+// knowledge.embedding_api_key or the openai-api-key secret. A non-nil
+// footer counts its requests.
+// --- compare subcommand ---
+
+var knowledgeCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Find where papers agree and contradict each other on a topic",
+	Long: `Compare finds the claim and result items on a topic, by full-text
+search or, with --semantic, by meaning, and asks the AI backend whether
+each pair from different papers agrees, contradicts, or is orthogonal.
+The report lists the contradictions and agreements with both statements
+and their provenance, and is written to knowledge/notes/compare-<topic>.md
+(--out to change; --json prints the comparison instead).
+
+The number of AI calls grows with the square of --limit: 12 items from
+different papers make up to 66 pairs, judged ten to a call.`,
+	Args: cobra.NoArgs,
+	RunE: runKnowledgeCompare,
+}
+
+func runKnowledgeCompare(cmd *cobra.Command, args []string) error {
+	topic, _ := cmd.Flags().GetString("topic")
+	limit, _ := cmd.Flags().GetInt("limit")
+	semantic, _ := cmd.Flags().GetBool("semantic")
+	outPath, _ := cmd.Flags().GetString("out")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	if topic == "" {
+		return fmt.Errorf("--topic is required")
+	}
+	opts, err := queryOptsFromFlags(cmd, nil)
+	if err != nil {
+		return err
+	}
+
+	aiCfg := extractionConfig(cmd)
+	if err := checkExtractionConfig(aiCfg); err != nil {
+		return err
+	}
+	cfg, papersDir := knowledgeConfig(cmd)
+	footer := newRunFooter()
+	defer footer.print(os.Stderr)
+
+	backend, err := newAIBackend(aiCfg, footer.client(0, nil))
+	if err != nil {
+		return err
+	}
+	defer footer.tokens(backend.Usage())
+	completer, ok := backend.(knowledge.Completer)
+	if !ok {
+		return fmt.Errorf("the %s backend cannot compare items: use claude, openai, or ollama", aiCfg.Backend)
+	}
+	var embedder knowledge.Embedder
+	if semantic {
+		if embedder, err = knowledgeEmbedder(cfg, footer); err != nil {
+			return err
+		}
+		if embedder == nil {
+			return fmt.Errorf("--semantic needs an embedding backend: use --embedding-backend or set knowledge.embedding_backend")
+		}
+	}
+
+	store, err := knowledge.NewStore(cfg, papersDir)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	items, err := store.CompareCandidates(ctx, embedder, topic, opts, limit)
+	if err != nil {
+		return err
+	}
+	if len(items) < 2 {
+		return fmt.Errorf("found %d claim or result items on %q: nothing to compare", len(items), topic)
+	}
+	cmp, err := knowledge.Compare(ctx, completer, topic, items)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(cmp)
+	}
+	if outPath == "" {
+		if outPath, err = knowledge.ComparisonPath(cfg.KnowledgeDir, topic); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", outPath, err)
+	}
+	if err := knowledge.WriteComparisonReport(f, cmp); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Compared %d pairs of %d items: %d contradict, %d agree, %d orthogonal\n",
+		len(cmp.Pairs), len(items), cmp.Count(knowledge.RelationContradict), cmp.Count(knowledge.RelationAgree), cmp.Count(knowledge.RelationOrthogonal))
+	fmt.Fprintf(os.Stdout, "Report written to %s\n", outPath)
+	return nil
+}
+
+func knowledgeEmbedder(cfg types.KnowledgeBaseConfig, footer *runFooter) (knowledge.Embedder, error) {
+	apiKey := secretDefault("openai-api-key", viper.GetString("knowledge.embedding_api_key"))
+	var client *http.Client
+	if footer != nil {
+		client = footer.client(0, nil)
+	}
+	return knowledge.NewEmbedder(cfg, apiKey, client)
+}
+
+func queryOptsFromFlags(cmd *cobra.Command, args []string) (knowledge.QueryOptions, error) {
+	queryText, _ := cmd.Flags().GetString("query")
+	if queryText == "" && len(args) > 0 {
+		queryText = strings.Join(args, " ")
+	}
+
+	itemType, _ := cmd.Flags().GetString("type")
+	tag, _ := cmd.Flags().GetString("tag")
+	paperID, _ := cmd.Flags().GetString("paper")
+	institution, _ := cmd.Flags().GetString("institution")
+	metric, _ := cmd.Flags().GetString("metric")
+	dataset, _ := cmd.Flags().GetString("dataset")
+	lowerIsBetter, _ := cmd.Flags().GetBool("lower-is-better")
+	claim, _ := cmd.Flags().GetInt("claim")
+	minConfidence, _ := cmd.Flags().GetFloat64("min-confidence")
+	pages, _ := cmd.Flags().GetString("pages")
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	limit, _ := cmd.Flags().GetInt("limit")
+	collapse, _ := cmd.Flags().GetBool("collapse-duplicates")
+	collection, _ := cmd.Flags().GetString("collection")
+	status, _ := cmd.Flags().GetString("status")
+
+	opts := knowledge.QueryOptions{
+		Query:         queryText,
+		Type:          types.KnowledgeItemType(itemType),
+		PaperID:       paperID,
+		Institution:   institution,
+		Metric:        metric,
+		Dataset:       dataset,
+		LowerIsBetter: lowerIsBetter,
+		Claim:         claim,
+		MinConfidence: minConfidence,
+		MaxResults:    limit,
+		Collection:    collection,
+		Status:        types.ItemStatus(status),
+
+		CollapseDuplicates: collapse,
+	}
+	if tag != "" {
+		opts.Tags = []string{tag}
+	}
+	if minConfidence < 0 || minConfidence > 1 {
+		return opts, fmt.Errorf("invalid --min-confidence %v: use a value from 0 to 1", minConfidence)
+	}
+	if pages != "" {
+		r, err := knowledge.ParsePageRange(pages)
+		if err != nil {
+			return opts, err
+		}
+		opts.Pages = r
+	}
+	var err error
+	if opts.DateFrom, err = parseDateBound(from, false); err != nil {
+		return opts, fmt.Errorf("invalid --from date %q: use YYYY, YYYY-MM, or YYYY-MM-DD", from)
+	}
+	if opts.DateTo, err = parseDateBound(to, true); err != nil {
+		return opts, fmt.Errorf("invalid --to date %q: use YYYY, YYYY-MM, or YYYY-MM-DD", to)
+	}
+	return opts, nil
+}
+
+// parseDateBound parses a year, month, or day as the first day of the
+// period, or with end as its last day, so --to 2022 includes December.
+func parseDateBound(s string, end bool) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []struct {
+		format string
+		years  int
+		months int
+	}{{"2006", 1, 0}, {"2006-01", 0, 1}, {time.DateOnly, 0, 0}} {
+		t, err := time.Parse(layout.format, s)
+		if err != nil {
+			continue
+		}
+		if end && (layout.years > 0 || layout.months > 0) {
+			t = t.AddDate(layout.years, layout.months, -1)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+func init() {
+	// Shared flags on the parent command, inherited by subcommands.
+	knowledgeCmd.PersistentFlags().String("knowledge-dir", "knowledge", "base directory for knowledge (contains extracted/, index/)")
+	knowledgeCmd.PersistentFlags().String("papers-dir", "papers", "base directory for papers (contains metadata/, markdown/)")
+	knowledgeCmd.PersistentFlags().Int("max-results", 20, "maximum number of query results")
+	knowledgeCmd.PersistentFlags().String("version-policy", "published", "canonical version of linked preprint/published pairs: published or preprint")
+
+	// Store flags.
+	addFailOnFlag(knowledgeStoreCmd)
+	addEmbeddingFlags(knowledgeStoreCmd)
+	knowledgeStoreCmd.Flags().StringSlice("venue-rankings", nil, "venue ranking files: CORE conference CSV exports or Scimago journal lists (default from knowledge.venue_rankings)")
+
+	// Rebuild flags.
+	addFailOnFlag(knowledgeRebuildCmd)
+	addEmbeddingFlags(knowledgeRebuildCmd)
+	knowledgeRebuildCmd.Flags().StringSlice("venue-rankings", nil, "venue ranking files: CORE conference CSV exports or Scimago journal lists (default from knowledge.venue_rankings)")
+
+	// Retrieve flags.
+	knowledgeRetrieveCmd.Flags().String("query", "", "full-text search query")
+	knowledgeRetrieveCmd.Flags().String("type", "", "filter by item type: claim, method, definition, result")
+	knowledgeRetrieveCmd.Flags().String("tag", "", "filter by tag")
+	knowledgeRetrieveCmd.Flags().String("paper", "", "filter by paper ID")
+	knowledgeRetrieveCmd.Flags().String("institution", "", "filter by author affiliation (institution name substring or ROR ID)")
+	knowledgeRetrieveCmd.Flags().String("collection", "", "search only the papers and items of this collection")
+	knowledgeRetrieveCmd.Flags().String("status", "", "filter by review status: unverified, verified, or disputed")
+	knowledgeRetrieveCmd.Flags().String("metric", "", "rank result items reporting this metric by value, best first")
+	knowledgeRetrieveCmd.Flags().String("dataset", "", "filter result items by the dataset their metric was measured on")
+	knowledgeRetrieveCmd.Flags().Bool("lower-is-better", false, "with --metric, rank the smallest values first")
+	knowledgeRetrieveCmd.Flags().String("semantic", "", "rank items by semantic similarity to this question (needs embedded items)")
+	knowledgeRetrieveCmd.Flags().Bool("hybrid", false, "with --semantic, fuse the ranking with a full-text search of the question's words")
+	addEmbeddingFlags(knowledgeRetrieveCmd)
+	knowledgeRetrieveCmd.Flags().Int("claim", 0, "select the patent claim with this number (use with --paper)")
+	knowledgeRetrieveCmd.Flags().Float64("min-confidence", 0, "keep items extracted with at least this confidence (0 to 1)")
+	knowledgeRetrieveCmd.Flags().String("pages", "", "keep items from these pages: N, N-M, N-, or -M")
+	knowledgeRetrieveCmd.Flags().String("from", "", "keep items from papers dated on or after this date (YYYY, YYYY-MM, or YYYY-MM-DD)")
+	knowledgeRetrieveCmd.Flags().String("to", "", "keep items from papers dated on or before this date (YYYY, YYYY-MM, or YYYY-MM-DD)")
+	knowledgeRetrieveCmd.Flags().Bool("collapse-duplicates", false, "show one result per cluster of near-duplicate items across papers")
+	knowledgeRetrieveCmd.Flags().Int("limit", 0, "maximum results (0 = use default)")
+	knowledgeRetrieveCmd.Flags().String("trace", "", "show source context for an item ID")
+	knowledgeRetrieveCmd.Flags().Bool("json", false, "output results as JSON")
+
+	// Export flags.
+	knowledgeExportCmd.Flags().String("format", "yaml", "export format: yaml, json, csv, parquet, anki, graphml, or dot")
+	knowledgeExportCmd.Flags().String("query", "", "full-text search filter for partial export")
+	knowledgeExportCmd.Flags().String("type", "", "filter by item type for partial export")
+	knowledgeExportCmd.Flags().String("tag", "", "filter by tag for partial export")
+	knowledgeExportCmd.Flags().String("paper", "", "filter by paper ID for partial export")
+	knowledgeExportCmd.Flags().String("institution", "", "filter by author affiliation for partial export")
+	knowledgeExportCmd.Flags().String("collection", "", "export only the papers and items of this collection")
+	knowledgeExportCmd.Flags().String("status", "", "export only items with this review status: unverified, verified, or disputed")
+	knowledgeExportCmd.Flags().String("metric", "", "filter result items by metric name for partial export")
+	knowledgeExportCmd.Flags().String("dataset", "", "filter result items by dataset for partial export")
+	knowledgeExportCmd.Flags().Float64("min-confidence", 0, "export items extracted with at least this confidence (0 to 1)")
+	knowledgeExportCmd.Flags().String("pages", "", "export items from these pages: N, N-M, N-, or -M")
+	knowledgeExportCmd.Flags().String("from", "", "export items from papers dated on or after this date (YYYY, YYYY-MM, or YYYY-MM-DD)")
+	knowledgeExportCmd.Flags().String("to", "", "export items from papers dated on or before this date (YYYY, YYYY-MM, or YYYY-MM-DD)")
+	knowledgeExportCmd.Flags().Int("limit", 0, "maximum items to export (0 = all)")
+	knowledgeExportCmd.Flags().StringSlice("nodes", nil, "graphml and dot node kinds: paper, item, tag, external (default all)")
+	knowledgeExportCmd.Flags().Int("min-cited", 0, "graphml and dot: keep works outside the selection cited by at least this many selected papers")
+	knowledgeExportCmd.Flags().String("order", "document", "entry order: document (paper, section, page, id) or score (requires --query)")
+
+	// Ask flags.
+	knowledgeAskCmd.Flags().String("out", "", "write the Markdown answer to this file (default: stdout)")
+	knowledgeAskCmd.Flags().String("type", "", "restrict supporting items to a type")
+	knowledgeAskCmd.Flags().String("tag", "", "restrict supporting items to a tag")
+	knowledgeAskCmd.Flags().String("paper", "", "restrict supporting items to a paper ID")
+	knowledgeAskCmd.Flags().String("institution", "", "restrict supporting items to papers with an author at this institution")
+	knowledgeAskCmd.Flags().Int("limit", 0, "maximum supporting items (0 = use default)")
+
+	// Stats flags.
+	knowledgeStatsCmd.Flags().Bool("by-institution", false, "count papers and authors per author affiliation")
+	knowledgeStatsCmd.Flags().Bool("by-venue", false, "count papers per venue with its type and rank")
+	knowledgeStatsCmd.Flags().Int("top", 0, "show only the N institutions or venues with most papers, or the N largest item breakdowns (default 10)")
+	knowledgeStatsCmd.Flags().Bool("json", false, "output statistics as JSON")
+
+	// Compare flags.
+	knowledgeCompareCmd.Flags().String("topic", "", "topic whose claims and results to compare (required)")
+	knowledgeCompareCmd.Flags().Int("limit", knowledge.DefaultCompareItems, "maximum claim and result items to compare pairwise")
+	knowledgeCompareCmd.Flags().Bool("semantic", false, "find items by meaning as well as words (needs embedded items)")
+	addEmbeddingFlags(knowledgeCompareCmd)
+	knowledgeCompareCmd.Flags().String("paper", "", "restrict the items to a paper ID")
+	knowledgeCompareCmd.Flags().String("institution", "", "restrict the items to papers with an author at this institution")
+	knowledgeCompareCmd.Flags().Float64("min-confidence", 0, "compare items extracted with at least this confidence (0 to 1)")
+	knowledgeCompareCmd.Flags().String("from", "", "compare items from papers dated on or after this date (YYYY, YYYY-MM, or YYYY-MM-DD)")
+	knowledgeCompareCmd.Flags().String("to", "", "compare items from papers dated on or before this date (YYYY, YYYY-MM, or YYYY-MM-DD)")
+	knowledgeCompareCmd.Flags().String("backend", "", "AI API: claude, openai, or ollama (default from extraction.backend or claude)")
+	knowledgeCompareCmd.Flags().String("base-url", "", "API base URL for the openai or ollama backend (default from extraction.base_url)")
+	knowledgeCompareCmd.Flags().String("model", "", "AI model identifier (default from extraction.model)")
+	knowledgeCompareCmd.Flags().String("api-key", "", "API key for the AI backend (or set RESEARCH_ENGINE_EXTRACTION_API_KEY)")
+	knowledgeCompareCmd.Flags().String("out", "", "write the Markdown report to this file (default knowledge/notes/compare-<topic>.md)")
+	knowledgeCompareCmd.Flags().Bool("json", false, "print the comparison as JSON instead of writing the report")
+
+	// Note flags.
+	knowledgeNoteAbsenceCmd.Flags().String("topic", "", "survey topic that was searched for (required)")
+	knowledgeNoteAbsenceCmd.Flags().StringArray("queries", nil, "search query file or full-text retrieval query that found nothing (repeatable)")
+	knowledgeNoteAbsenceCmd.Flags().String("note", "", "comment on the finding, such as the scope searched")
+	knowledgeNoteListCmd.Flags().Bool("markdown", false, "render the notes as a Markdown section for a survey")
+	knowledgeNoteListCmd.Flags().Bool("json", false, "output the notes as JSON")
+	knowledgeNoteCmd.AddCommand(knowledgeNoteAbsenceCmd)
+	knowledgeNoteCmd.AddCommand(knowledgeNoteListCmd)
+
+	// Matrix flags.
+	knowledgeMatrixCmd.Flags().StringSlice("columns", nil, "columns: tags, or type:<item type> (required)")
+	knowledgeMatrixCmd.Flags().String("format", "markdown", "output format: markdown, latex (booktabs), or json")
+	knowledgeMatrixCmd.Flags().StringSlice("paper", nil, "restrict rows to these paper IDs (default: every paper with a matching item)")
+	knowledgeMatrixCmd.Flags().String("project", "", "paper project directory whose references.yaml supplies citation keys")
+	knowledgeMatrixCmd.Flags().String("cite-command", "cite", "LaTeX citation command for --format latex, such as citep or autocite")
+	knowledgeMatrixCmd.Flags().Int("max-chars", 0, "shorten cell text to this many characters (0 = 80, -1 = full text)")
+	knowledgeMatrixCmd.Flags().String("out", "", "write the table to this file (default: stdout)")
+
+	// Papers flags.
+	knowledgePapersCmd.Flags().String("query", "", "list only papers with items matching this full-text query")
+	knowledgePapersCmd.Flags().Int("limit", 0, "maximum papers to list (0 = all)")
+	knowledgePapersCmd.Flags().Bool("json", false, "output the papers as JSON")
+	knowledgePaperCmd.Flags().Bool("json", false, "output the paper as JSON")
+
+	// Collection flags.
+	knowledgeCollectionCreateCmd.Flags().String("description", "", "what the collection is for")
+	knowledgeCollectionListCmd.Flags().Bool("json", false, "output the collections or members as JSON")
+	knowledgeCollectionCmd.AddCommand(knowledgeCollectionCreateCmd)
+	knowledgeCollectionCmd.AddCommand(knowledgeCollectionAddCmd)
+	knowledgeCollectionCmd.AddCommand(knowledgeCollectionRemoveCmd)
+	knowledgeCollectionCmd.AddCommand(knowledgeCollectionListCmd)
+	knowledgeCollectionCmd.AddCommand(knowledgeCollectionDeleteCmd)
+
+	// Snapshot flags.
+	knowledgeSnapshotCreateCmd.Flags().String("description", "", "what the snapshot marks, such as a review milestone")
+	knowledgeSnapshotListCmd.Flags().Bool("json", false, "output the snapshots as JSON")
+	knowledgeSnapshotDiffCmd.Flags().Bool("json", false, "output the diff as JSON")
+	knowledgeSnapshotCmd.AddCommand(knowledgeSnapshotCreateCmd)
+	knowledgeSnapshotCmd.AddCommand(knowledgeSnapshotListCmd)
+	knowledgeSnapshotCmd.AddCommand(knowledgeSnapshotDiffCmd)
+	knowledgeSnapshotCmd.AddCommand(knowledgeSnapshotDeleteCmd)
+
+	// Item flags.
+	for _, c := range []*cobra.Command{knowledgeItemAddCmd, knowledgeItemEditCmd} {
+		c.Flags().String("type", "", "item type: claim, method, definition, or result")
+		c.Flags().String("content", "", "item text")
+		c.Flags().String("section", "", "section of the paper the item comes from")
+		c.Flags().Int("page", 0, "page the item begins on")
+		c.Flags().Float64("confidence", 0, "confidence from 0 to 1 (default 1 for added items)")
+		c.Flags().StringSlice("tags", nil, "topic tags")
+	}
+	knowledgeItemAddCmd.Flags().String("paper", "", "paper ID the item belongs to (required)")
+	knowledgeItemCmd.AddCommand(knowledgeItemAddCmd)
+	knowledgeItemCmd.AddCommand(knowledgeItemEditCmd)
+	knowledgeItemCmd.AddCommand(knowledgeItemDeleteCmd)
+
+	// Migrate flags.
+	knowledgeMigrateCmd.Flags().Bool("dry-run", false, "list the pending migrations without applying them")
+
+	// Verify and dispute flags.
+	knowledgeVerifyCmd.Flags().Bool("json", false, "output the report as JSON")
+	knowledgeVerifyCmd.Flags().String("note", "", "with item IDs, a note on how the items were checked")
+	knowledgeVerifyCmd.Flags().Bool("reset", false, "with item IDs, mark them unverified again")
+	knowledgeDisputeCmd.Flags().String("note", "", "what is wrong with the items (required)")
+
+	// Graph flags.
+	knowledgeGraphBuildCmd.Flags().String("out", "", "write the graph to this file (default: knowledge-dir/index/citation-graph.json)")
+	knowledgeGraphCmd.AddCommand(knowledgeGraphBuildCmd)
+
+	// Wire subcommands.
+	knowledgeCmd.AddCommand(knowledgeStoreCmd)
+	knowledgeCmd.AddCommand(knowledgeRetrieveCmd)
+	knowledgeCmd.AddCommand(knowledgeExportCmd)
+	knowledgeCmd.AddCommand(knowledgeAskCmd)
+	knowledgeCmd.AddCommand(knowledgeVersionsCmd)
+	knowledgeCmd.AddCommand(knowledgePapersCmd)
+	knowledgeCmd.AddCommand(knowledgePaperCmd)
+	knowledgeCmd.AddCommand(knowledgeStatsCmd)
+	knowledgeCmd.AddCommand(knowledgeNoteCmd)
+	knowledgeCmd.AddCommand(knowledgeMatrixCmd)
+	knowledgeCmd.AddCommand(knowledgeCompareCmd)
+	knowledgeCmd.AddCommand(knowledgeGraphCmd)
+	knowledgeCmd.AddCommand(knowledgeCollectionCmd)
+	knowledgeCmd.AddCommand(knowledgeSnapshotCmd)
+	knowledgeCmd.AddCommand(knowledgeItemCmd)
+	knowledgeCmd.AddCommand(knowledgeMigrateCmd)
+	knowledgeCmd.AddCommand(knowledgeRebuildCmd)
+	knowledgeCmd.AddCommand(knowledgeBackupCmd)
+	knowledgeCmd.AddCommand(knowledgeRestoreCmd)
+	knowledgeCmd.AddCommand(knowledgeVerifyCmd)
+	knowledgeCmd.AddCommand(knowledgeDisputeCmd)
+
+	rootCmd.AddCommand(knowledgeCmd)
+}
+
+// addEmbeddingFlags registers the embedding flags read by knowledgeConfig.
+func addEmbeddingFlags(cmd *cobra.Command) {
+	cmd.Flags().String("embedding-backend", "", "embedding API for semantic search: openai or ollama (default from knowledge.embedding_backend; empty disables embeddings)")
+	cmd.Flags().String("embedding-model", "", "embedding model (default from knowledge.embedding_model, or "+knowledge.DefaultOpenAIEmbeddingModel+" for openai and "+knowledge.DefaultOllamaEmbeddingModel+" for ollama)")
+	cmd.Flags().String("embedding-url", "", "embedding API base URL (default from knowledge.embedding_base_url, or "+knowledge.DefaultOpenAIEmbeddingURL+" for openai and "+knowledge.DefaultOllamaEmbeddingURL+" for ollama)")
 }
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -47,6 +48,9 @@ Each infrastructure stage is a subcommand: search, acquire, convert, extract,
 and knowledge. Claude composes these into research workflows through
 .claude/commands/ skills.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := initConfig(cmd); err != nil {
+			return err
+		}
 		s, err := secrets.Load(".secrets/")
 		if err != nil {
 			return err
@@ -65,13 +69,29 @@ and knowledge. Claude composes these into research workflows through
 }
 
 func init() {
-	cobra.OnInitialize(initConfig)
+	rootCmd.Version = version
 
 	rootCmd.PersistentFlags().String("config", "", "config file (default: ./research-engine.yaml or ~/.config/research-engine/config.yaml)")
+	rootCmd.PersistentFlags().String("workspace", "", "run in this registered workspace's directory, where relative paths given to the command also resolve (default from "+workspaceEnv+")")
 }
 
-func initConfig() {
-	cfgFile, _ := rootCmd.PersistentFlags().GetString("config")
+// initConfig enters the selected workspace, unless cmd manages the
+// workspace registry, and reads the config file.
+func initConfig(cmd *cobra.Command) error {
+	cfgFile, _ := cmd.Flags().GetString("config")
+	// A config file named on the command line is relative to where the
+	// command was run, not to the workspace.
+	if cfgFile != "" {
+		if abs, err := filepath.Abs(cfgFile); err == nil {
+			cfgFile = abs
+		}
+	}
+	if !isWorkspaceCommand(cmd) {
+		if err := enterWorkspace(cmd); err != nil {
+			return err
+		}
+	}
+
 	if cfgFile != "" {
 		viper.SetConfigFile(cfgFile)
 	} else {
@@ -91,10 +111,15 @@ func initConfig() {
 	if err := viper.ReadInConfig(); err == nil {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+	return nil
 }
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	start := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	recordUsage(cmd, start, err)
+	recordAudit(cmd, start, err)
+	if err != nil {
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,155 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/pdiddy/research-engine/internal/acquire"
+	"github.com/pdiddy/research-engine/internal/knowledge"
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// Targets of the open command.
+const (
+	openPDF      = "pdf"
+	openMarkdown = "markdown"
+	openLanding  = "landing"
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open <paper-id|item-id>",
+	Short: "Open a paper's PDF, Markdown, or landing page",
+	Long: `Open shows a paper in the default application: the downloaded PDF, the
+converted Markdown, or the publisher landing page (DOI, arXiv abstract, or
+Google Patents page). The argument is a paper ID or a knowledge item ID,
+which is resolved to its paper through the knowledge base.
+
+The target defaults to open.target in the config file (pdf if unset).
+Landing page URLs come from templates in open.landing, keyed by doi, arxiv,
+and patent, with {id} replaced by the identifier; for example
+  open:
+    landing:
+      doi: https://doi-org.ezproxy.example.edu/{id}
+
+Use --print to print the path or URL instead of opening it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOpen,
+}
+
+func init() {
+	viper.SetDefault("open.target", openPDF)
+
+	openCmd.Flags().String("target", "", "what to open: pdf, markdown, or landing (default from open.target, else pdf)")
+	openCmd.Flags().Bool("print", false, "print the path or URL instead of opening it")
+	openCmd.Flags().String("papers-dir", "papers", "base directory for papers")
+	openCmd.Flags().String("knowledge-dir", "knowledge", "base directory for knowledge (contains index/)")
+	rootCmd.AddCommand(openCmd)
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	target, _ := cmd.Flags().GetString("target")
+	if target == "" {
+		target = viper.GetString("open.target")
+	}
+	printOnly, _ := cmd.Flags().GetBool("print")
+	papersDir, _ := cmd.Flags().GetString("papers-dir")
+	knowledgeDir, _ := cmd.Flags().GetString("knowledge-dir")
+
+	paperID, err := resolveOpenPaper(args[0], papersDir, knowledgeDir)
+	if err != nil {
+		return err
+	}
+
+	var location string
+	switch target {
+	case openPDF:
+		location = filepath.Join(papersDir, "raw", paperID+".pdf")
+	case openMarkdown:
+		location = filepath.Join(papersDir, "markdown", paperID+".md")
+	case openLanding:
+		paper, err := acquire.LoadPaper(papersDir, paperID)
+		if err != nil {
+			return fmt.Errorf("reading metadata for %s: %w", paperID, err)
+		}
+		location = acquire.LandingURL(paper, viper.GetStringMapString("open.landing"))
+		if location == "" {
+			return fmt.Errorf("no landing page known for %s", paperID)
+		}
+	default:
+		return fmt.Errorf("invalid --target %q: use pdf, markdown, or landing", target)
+	}
+	if target != openLanding {
+		if _, err := os.Stat(location); err != nil {
+			return fmt.Errorf("%s has no %s: %w", paperID, target, err)
+		}
+	}
+
+	if printOnly {
+		fmt.Fprintln(os.Stdout, location)
+		return nil
+	}
+	return openInDefaultApp(location)
+}
+
+// resolveOpenPaper returns the paper ID for id: id itself when a paper
+// with that ID was acquired (or the paper it duplicates), otherwise the
+// paper of the knowledge item id.
+func resolveOpenPaper(id, papersDir, knowledgeDir string) (string, error) {
+	if p, err := acquire.LoadPaper(papersDir, id); err == nil && p.DuplicateOf != "" {
+		return p.DuplicateOf, nil
+	}
+	for _, p := range []string{
+		filepath.Join(papersDir, "metadata", id+".yaml"),
+		filepath.Join(papersDir, "raw", id+".pdf"),
+		filepath.Join(papersDir, "markdown", id+".md"),
+	} {
+		if _, err := os.Stat(p); err == nil {
+			return id, nil
+		}
+	}
+
+	dbPath := filepath.Join(knowledgeDir, "index", "research.db")
+	if _, err := os.Stat(dbPath); err != nil {
+		return "", fmt.Errorf("no paper %s in %s and no knowledge base to resolve item IDs", id, papersDir)
+	}
+	store, err := knowledge.NewStore(types.KnowledgeBaseConfig{KnowledgeDir: knowledgeDir}, papersDir)
+	if err != nil {
+		return "", err
+	}
+	defer store.Close()
+	paperID, err := store.ItemPaperID(context.Background(), id)
+	if err != nil {
+		return "", fmt.Errorf("%s is neither a paper nor a knowledge item: %w", id, err)
+	}
+	return paperID, nil
+}
+
+// openInDefaultApp hands a file path or URL to the platform's opener.
+func openInDefaultApp(location string) error {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		c = exec.Command("open", location)
+	case "windows":
+		c = exec.Command("rundll32", "url.dll,FileProtocolHandler", location)
+	default:
+		c = exec.Command("xdg-open", location)
+	}
+	if err := c.Start(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return fmt.Errorf("no opener found (%s); use --print to get the location", c.Path)
+		}
+		return fmt.Errorf("opening %s: %w", location, err)
+	}
+	return c.Process.Release()
+}
@@ -0,0 +1,37 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// addRateLimitFlag registers --rate-limit on a command that calls remote
+// APIs.
+func addRateLimitFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("rate-limit", nil,
+		"override a host's request rate as host=requests-per-second (repeatable; 0 removes the limit)")
+}
+
+// rateLimitsFromFlags parses --rate-limit into per-host rates.
+func rateLimitsFromFlags(cmd *cobra.Command) (map[string]float64, error) {
+	entries, _ := cmd.Flags().GetStringSlice("rate-limit")
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	limits := make(map[string]float64, len(entries))
+	for _, e := range entries {
+		host, value, ok := strings.Cut(e, "=")
+		host = strings.TrimSpace(host)
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if !ok || host == "" || err != nil || rate < 0 {
+			return nil, fmt.Errorf("invalid --rate-limit %q: use host=requests-per-second", e)
+		}
+		limits[host] = rate
+	}
+	return limits, nil
+}
@@ -0,0 +1,112 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/pdiddy/research-engine/internal/usage"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize local records of research activity",
+}
+
+var reportUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Summarize commands run, time spent, and corpus growth",
+	Long: `Usage reads the local usage log (.research-engine/usage.jsonl in the
+project directory) and reports how many times each command ran, how long
+it took in total, and the corpus size (PDFs, Markdown, extractions) at the
+end of each day with activity. Use it for the tooling-effort figures of a
+methodology section.
+
+Every command except help, version, and report appends one line to the log.
+The log never leaves this machine. Set usage_log: false in the config file
+or RESEARCH_ENGINE_USAGE_LOG=false to stop recording.`,
+	Args: cobra.NoArgs,
+	RunE: runReportUsage,
+}
+
+func init() {
+	viper.SetDefault("usage_log", true)
+
+	reportUsageCmd.Flags().String("since", "", "only include runs on or after this date (YYYY-MM-DD)")
+	reportUsageCmd.Flags().Bool("json", false, "output the report as JSON")
+	reportCmd.AddCommand(reportUsageCmd)
+	rootCmd.AddCommand(reportCmd)
+}
+
+func runReportUsage(cmd *cobra.Command, args []string) error {
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	var since time.Time
+	if sinceFlag != "" {
+		t, err := time.ParseInLocation("2006-01-02", sinceFlag, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --since %q: use YYYY-MM-DD", sinceFlag)
+		}
+		since = t
+	}
+
+	entries, err := usage.Read(usage.DefaultPath)
+	if err != nil {
+		return err
+	}
+	report := usage.Summarize(entries, since)
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	usage.FormatReport(report, os.Stdout)
+	return nil
+}
+
+// recordUsage appends the run of cmd to the usage log. Failures to write
+// the log are reported but never change the command's outcome.
+func recordUsage(cmd *cobra.Command, start time.Time, runErr error) {
+	if cmd == nil || cmd == rootCmd || !viper.GetBool("usage_log") {
+		return
+	}
+	path := cmd.CommandPath()
+	name := strings.TrimPrefix(path, rootCmd.Name()+" ")
+	switch strings.Fields(name)[0] {
+	case "help", "completion", "version", "report":
+		return
+	}
+	if help, _ := cmd.Flags().GetBool("help"); help {
+		return
+	}
+
+	papersDir := flagOrDefault(cmd, "papers-dir", "papers")
+	knowledgeDir := flagOrDefault(cmd, "knowledge-dir", "knowledge")
+	entry := usage.Entry{
+		Time:     start,
+		Command:  name,
+		Duration: time.Since(start),
+		OK:       runErr == nil,
+		Corpus:   usage.CountCorpus(papersDir, knowledgeDir),
+	}
+	if err := usage.Append(usage.DefaultPath, entry); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: recording usage: %v\n", err)
+	}
+}
+
+// flagOrDefault returns the value of a string flag if cmd defines it.
+func flagOrDefault(cmd *cobra.Command, name, fallback string) string {
+	if f := cmd.Flags().Lookup(name); f != nil && f.Value.String() != "" {
+		return f.Value.String()
+	}
+	return fallback
+}
@@ -0,0 +1,81 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/pdiddy/research-engine/internal/review"
+	"github.com/pdiddy/research-engine/internal/search"
+)
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Systematic-review accounting",
+}
+
+var reviewPrismaCmd = &cobra.Command{
+	Use:   "prisma [query-file...]",
+	Short: "Report the PRISMA flow counts of a systematic review",
+	Long: `Prisma derives the PRISMA 2020 flow from the review's saved searches and
+the corpus:
+
+  identification  results in the query files, per backend, and duplicates
+                  merged within or across searches
+  screening       title/abstract decisions (search annotate keep/reject)
+  eligibility     kept records whose full text was acquired
+  inclusion       acquired papers with knowledge items extracted
+
+Query files are given as arguments or listed under review.query_files in
+the config file, so a review's searches are declared once. Use --format
+json for the numbers or --format mermaid for a flow diagram.`,
+	RunE: runReviewPrisma,
+}
+
+func init() {
+	reviewPrismaCmd.Flags().String("format", "text", "output format: text, json, or mermaid")
+	reviewPrismaCmd.Flags().String("papers-dir", "papers", "base directory for papers (contains raw/, metadata/)")
+	reviewPrismaCmd.Flags().String("knowledge-dir", "knowledge", "base directory for knowledge (contains extracted/)")
+	reviewCmd.AddCommand(reviewPrismaCmd)
+	rootCmd.AddCommand(reviewCmd)
+}
+
+func runReviewPrisma(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	papersDir, _ := cmd.Flags().GetString("papers-dir")
+	knowledgeDir, _ := cmd.Flags().GetString("knowledge-dir")
+
+	paths := args
+	if len(paths) == 0 {
+		paths = viper.GetStringSlice("review.query_files")
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no query files: pass them as arguments or set review.query_files in the config file")
+	}
+
+	var queryFiles []*search.QueryFile
+	for _, path := range paths {
+		qf, err := search.ReadQueryFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		queryFiles = append(queryFiles, qf)
+	}
+	flow := review.ComputeFlow(queryFiles, papersDir, knowledgeDir)
+
+	switch format {
+	case "text", "":
+		review.FormatText(flow, os.Stdout)
+	case "json":
+		return review.FormatJSON(flow, os.Stdout)
+	case "mermaid":
+		review.FormatMermaid(flow, os.Stdout)
+	default:
+		return fmt.Errorf("unsupported format %q: use text, json, or mermaid", format)
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/pdiddy/research-engine/internal/review"
+	"github.com/pdiddy/research-engine/internal/search"
+)
+
+var screenCmd = &cobra.Command{
+	Use:   "screen",
+	Short: "Title and abstract screening by one or two reviewers",
+	Long: `Screen records include/exclude decisions on the results of a saved query
+file, by reviewer, for systematic reviews. Each reviewer screens the results
+independently (screen next, screen decide). A result's triage status becomes
+keep or reject once the required number of reviewers (--required, default
+from review.reviewers, else 1) agree; while they disagree it stays
+untriaged and appears in screen conflicts until screen resolve settles it.
+
+Kept results form the acquisition queue (acquire --from-query <file>
+--status keep) and the screening counts of review prisma. Screen stats
+reports agreement between two reviewers with Cohen's kappa.`,
+}
+
+var screenNextCmd = &cobra.Command{
+	Use:   "next <query-file>",
+	Short: "Show the results a reviewer has not screened yet",
+	Long: `Next prints the title, authors, year, and abstract of the results the
+reviewer has not decided on, in rank order. Other reviewers' decisions are
+not shown, so dual screening stays independent.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runScreenNext,
+}
+
+var screenDecideCmd = &cobra.Command{
+	Use:   "decide <query-file>",
+	Short: "Record a reviewer's include or exclude decision",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runScreenDecide,
+}
+
+var screenConflictsCmd = &cobra.Command{
+	Use:   "conflicts <query-file>",
+	Short: "List results the reviewers disagree on",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runScreenConflicts,
+}
+
+var screenResolveCmd = &cobra.Command{
+	Use:   "resolve <query-file>",
+	Short: "Record the final decision on a disagreement",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runScreenResolve,
+}
+
+var screenStatsCmd = &cobra.Command{
+	Use:   "stats <query-file>...",
+	Short: "Report inter-reviewer agreement and Cohen's kappa",
+	Long: `Stats compares two reviewers on the results both screened across the
+query files: the include/exclude cross-tabulation, observed agreement, and
+Cohen's kappa. Use --pair to name the reviewers when more than two screened.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runScreenStats,
+}
+
+func init() {
+	viper.SetDefault("review.reviewers", 1)
+
+	screenNextCmd.Flags().String("reviewer", "", "reviewer name (default from review.reviewer)")
+	screenNextCmd.Flags().Int("limit", 10, "show at most N results (0 for all)")
+	screenNextCmd.Flags().Bool("json", false, "output results as JSON")
+
+	screenDecideCmd.Flags().String("reviewer", "", "reviewer name (default from review.reviewer)")
+	screenDecideCmd.Flags().String("id", "", "identifier of the result (required)")
+	screenDecideCmd.Flags().String("decision", "", "include or exclude (required)")
+	screenDecideCmd.Flags().String("note", "", "reason for the decision")
+	screenDecideCmd.Flags().Int("required", 0, "reviewers who must agree before the decision counts (default from review.reviewers, else 1)")
+	screenDecideCmd.MarkFlagRequired("id")
+	screenDecideCmd.MarkFlagRequired("decision")
+
+	screenResolveCmd.Flags().String("id", "", "identifier of the result (required)")
+	screenResolveCmd.Flags().String("decision", "", "include or exclude (required)")
+	screenResolveCmd.Flags().String("note", "", "reason for the decision")
+	screenResolveCmd.MarkFlagRequired("id")
+	screenResolveCmd.MarkFlagRequired("decision")
+
+	screenStatsCmd.Flags().StringSlice("pair", nil, "the two reviewers to compare (default: the only two who screened)")
+	screenStatsCmd.Flags().Bool("json", false, "output the statistics as JSON")
+
+	screenCmd.AddCommand(screenNextCmd)
+	screenCmd.AddCommand(screenDecideCmd)
+	screenCmd.AddCommand(screenConflictsCmd)
+	screenCmd.AddCommand(screenResolveCmd)
+	screenCmd.AddCommand(screenStatsCmd)
+	rootCmd.AddCommand(screenCmd)
+}
+
+// screenReviewer returns the --reviewer flag or review.reviewer.
+func screenReviewer(cmd *cobra.Command) (string, error) {
+	reviewer, _ := cmd.Flags().GetString("reviewer")
+	if reviewer == "" {
+		reviewer = viper.GetString("review.reviewer")
+	}
+	if strings.TrimSpace(reviewer) == "" {
+		return "", fmt.Errorf("no reviewer: pass --reviewer or set review.reviewer in the config file")
+	}
+	return strings.TrimSpace(reviewer), nil
+}
+
+func runScreenNext(cmd *cobra.Command, args []string) error {
+	reviewer, err := screenReviewer(cmd)
+	if err != nil {
+		return err
+	}
+	limit, _ := cmd.Flags().GetInt("limit")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	qf, err := search.ReadQueryFile(args[0])
+	if err != nil {
+		return err
+	}
+	pending := review.Pending(qf, reviewer)
+	remaining := len(pending)
+	if limit > 0 && len(pending) > limit {
+		pending = pending[:limit]
+	}
+
+	if jsonOutput {
+		out := make([]any, len(pending))
+		for i, rr := range pending {
+			r := rr.Result
+			// Hide the other reviewers' decisions.
+			r.Triage = nil
+			out[i] = r
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+	review.FormatScreening(pending, os.Stdout)
+	if remaining > 0 {
+		fmt.Fprintf(os.Stdout, "%d of %d results left for %s\n", remaining, len(qf.Results), reviewer)
+	}
+	return nil
+}
+
+func runScreenDecide(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	reviewer, err := screenReviewer(cmd)
+	if err != nil {
+		return err
+	}
+	id, _ := cmd.Flags().GetString("id")
+	decisionFlag, _ := cmd.Flags().GetString("decision")
+	note, _ := cmd.Flags().GetString("note")
+	required, _ := cmd.Flags().GetInt("required")
+	if required == 0 {
+		required = viper.GetInt("review.reviewers")
+	}
+	if required < 1 || required > 2 {
+		return fmt.Errorf("invalid --required %d: use 1 or 2", required)
+	}
+
+	decision, err := review.ParseDecision(decisionFlag)
+	if err != nil {
+		return err
+	}
+	qf, err := search.ReadQueryFile(path)
+	if err != nil {
+		return err
+	}
+	r, err := review.Decide(qf, id, reviewer, decision, note, required, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := qf.Save(path); err != nil {
+		return err
+	}
+
+	status := r.Triage.Status
+	switch {
+	case r.Triage.Resolved:
+		status += " (resolved)"
+	case review.InConflict(r.Triage):
+		status = "conflict"
+	case status == "":
+		status = "awaiting another reviewer"
+	}
+	fmt.Fprintf(os.Stdout, "%s by %s: %s (%s) -> %s\n", decision, reviewer, r.Identifier, r.Title, status)
+	return nil
+}
+
+func runScreenConflicts(cmd *cobra.Command, args []string) error {
+	qf, err := search.ReadQueryFile(args[0])
+	if err != nil {
+		return err
+	}
+	review.FormatConflicts(review.Conflicts(qf), os.Stdout)
+	return nil
+}
+
+func runScreenResolve(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	id, _ := cmd.Flags().GetString("id")
+	decisionFlag, _ := cmd.Flags().GetString("decision")
+	note, _ := cmd.Flags().GetString("note")
+
+	decision, err := review.ParseDecision(decisionFlag)
+	if err != nil {
+		return err
+	}
+	qf, err := search.ReadQueryFile(path)
+	if err != nil {
+		return err
+	}
+	r, err := review.Resolve(qf, id, decision, note, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := qf.Save(path); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "resolved %s: %s (%s) -> %s\n", decision, r.Identifier, r.Title, r.Triage.Status)
+	return nil
+}
+
+func runScreenStats(cmd *cobra.Command, args []string) error {
+	pair, _ := cmd.Flags().GetStringSlice("pair")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	var queryFiles []*search.QueryFile
+	seen := make(map[string]bool)
+	var reviewers []string
+	for _, path := range args {
+		qf, err := search.ReadQueryFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		queryFiles = append(queryFiles, qf)
+		for _, name := range review.Reviewers(qf) {
+			if !seen[strings.ToLower(name)] {
+				seen[strings.ToLower(name)] = true
+				reviewers = append(reviewers, name)
+			}
+		}
+	}
+
+	if len(pair) == 0 {
+		if len(reviewers) != 2 {
+			return fmt.Errorf("%d reviewers screened these results (%s): name two with --pair", len(reviewers), strings.Join(reviewers, ", "))
+		}
+		pair = reviewers
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("--pair needs exactly two reviewers, got %d", len(pair))
+	}
+
+	ag := review.ComputeAgreement(queryFiles, pair[0], pair[1])
+	if ag.Both == 0 {
+		return fmt.Errorf("%s and %s have not screened any result in common", pair[0], pair[1])
+	}
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(ag)
+	}
+	review.FormatAgreement(ag, os.Stdout)
+	return nil
+}
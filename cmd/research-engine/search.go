@@ -5,7 +5,6 @@ package main
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -27,13 +26,32 @@ or structured query parameters. Results are deduplicated across sources and
 ranked by relevance.
 
 Use --patents to search only PatentsView (disables academic backends).
+Use --lens-api-key, or place the key in .secrets/lens-api-key, to add the
+Lens.org backend, which searches scholarly works and patents together.
 Use --patentsview-api-key to provide a PatentsView API key, or place it in
 .secrets/patentsview-api-key.
 
 Use --query-file to save results to a YAML file for later review. When
 --query-file is provided without a query, the saved results are displayed.
 
-Use --csl to output results in CSL YAML format for Pandoc and reference managers.`,
+Use --csl to output results in CSL YAML format for Pandoc and reference managers.
+
+Use --expand to also search query variants (acronyms swapped with their long
+forms, matching OpenAlex concepts) and merge the results. Each result records
+which variants found it; results found only by a variant are marked in the
+table output.
+
+Use --cluster to group the table output into topical clusters (TF-IDF over
+title and abstract with agglomerative clustering) for triaging large result
+sets. --cluster-threshold sets how similar results must be to share a cluster.
+
+Requests are paced per host so that all backends and query variants share
+each API's rate budget (for example one request per second to Semantic
+Scholar without an API key). Use --rate-limit host=rate to override a host.
+
+Use --keep-raw to retain each backend's raw JSON/XML record alongside every
+result, so fields the unified result drops (venue, citation counts, OA status)
+remain available in the query file and JSON output.`,
 	RunE: runSearch,
 }
 
@@ -49,7 +67,13 @@ func init() {
 	searchCmd.Flags().Bool("recency-bias", false, "boost recently published papers")
 	searchCmd.Flags().String("query-file", "", "YAML file to save/load query and results")
 	searchCmd.Flags().String("patentsview-api-key", "", "PatentsView API key")
+	searchCmd.Flags().String("lens-api-key", "", "Lens.org API key (enables the Lens scholarly and patent backend)")
 	searchCmd.Flags().Bool("patents", false, "search only PatentsView (disables academic backends)")
+	searchCmd.Flags().Bool("expand", false, "also search acronym and OpenAlex concept variants of the query and merge results")
+	searchCmd.Flags().Bool("cluster", false, "group table output into topical clusters")
+	searchCmd.Flags().Float64("cluster-threshold", search.DefaultClusterThreshold, "minimum average similarity (0-1) for results to share a cluster")
+	searchCmd.Flags().Bool("keep-raw", false, "retain each backend's raw response record per result (stored in --query-file and --json output)")
+	addRateLimitFlag(searchCmd)
 
 	rootCmd.AddCommand(searchCmd)
 }
@@ -67,7 +91,20 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	queryFile, _ := cmd.Flags().GetString("query-file")
 	patentsViewAPIKey, _ := cmd.Flags().GetString("patentsview-api-key")
 	patentsViewAPIKey = secretDefault("patentsview-api-key", patentsViewAPIKey)
+	lensAPIKey, _ := cmd.Flags().GetString("lens-api-key")
+	lensAPIKey = secretDefault("lens-api-key", lensAPIKey)
 	patentsOnly, _ := cmd.Flags().GetBool("patents")
+	keepRaw, _ := cmd.Flags().GetBool("keep-raw")
+	expand, _ := cmd.Flags().GetBool("expand")
+	cluster, _ := cmd.Flags().GetBool("cluster")
+	clusterThreshold, _ := cmd.Flags().GetFloat64("cluster-threshold")
+	if !cluster {
+		clusterThreshold = 0
+	}
+	rateLimits, err := rateLimitsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
 
 	// If no --query flag, use positional args as the query.
 	if queryText == "" && len(args) > 0 {
@@ -78,7 +115,7 @@ func runSearch(cmd *cobra.Command, args []string) error {
 
 	// Load from query file when no query is provided (R4.6).
 	if queryFile != "" && !hasQuery {
-		return loadAndDisplayQueryFile(queryFile, jsonOutput, cslOutput)
+		return loadAndDisplayQueryFile(queryFile, jsonOutput, cslOutput, clusterThreshold)
 	}
 
 	query := search.Query{
@@ -111,7 +148,8 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	cfg := types.SearchConfig{
 		HTTPConfig: types.HTTPConfig{
 			Timeout:   defaultSearchTimeout,
-			UserAgent: defaultUserAgent,
+			UserAgent:  defaultUserAgent,
+			RateLimits: rateLimits,
 		},
 		MaxResults:           maxResults,
 		EnableArxiv:          !patentsOnly,
@@ -119,13 +157,17 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		EnableOpenAlex:       !patentsOnly,
 		EnablePatentsView:    patentsOnly || patentsViewAPIKey != "",
 		PatentsViewAPIKey:    patentsViewAPIKey,
+		EnableLens:           lensAPIKey != "",
+		LensAPIKey:           lensAPIKey,
 		SemanticScholarAPIKey: secretDefault("semantic-scholar-api-key", ""),
 		OpenAlexEmail:        secretDefault("openalex-email", ""),
-		InterBackendDelay:    1 * time.Second,
 		RecencyBiasWindow:    2 * 365 * 24 * time.Hour,
+		KeepRaw:              keepRaw,
 	}
 
-	client := &http.Client{Timeout: cfg.Timeout}
+	footer := newRunFooter()
+	defer footer.print(os.Stderr)
+	client := footer.client(cfg.Timeout, search.NewRateLimiter(cfg))
 
 	var backends []search.Backend
 	if cfg.EnableArxiv {
@@ -149,8 +191,24 @@ func runSearch(cmd *cobra.Command, args []string) error {
 			APIKey: cfg.PatentsViewAPIKey,
 		})
 	}
+	if cfg.EnableLens {
+		backends = append(backends, &search.LensBackend{
+			Client: client,
+			APIKey: cfg.LensAPIKey,
+		})
+	}
 
-	out, err := search.Search(context.Background(), query, backends, cfg, recencyBias, os.Stderr)
+	ctx := context.Background()
+	var out search.SearchOutput
+	if expand {
+		expansions := search.ExpandQuery(ctx, client, query, cfg.OpenAlexEmail, cfg.UserAgent, os.Stderr)
+		for _, e := range expansions {
+			fmt.Fprintf(os.Stderr, "expanding: %s -> %q\n", e.Label, e.Query.FreeText)
+		}
+		out, err = search.SearchExpanded(ctx, query, expansions, backends, cfg, recencyBias, os.Stderr)
+	} else {
+		out, err = search.Search(ctx, query, backends, cfg, recencyBias, os.Stderr)
+	}
 	if err != nil {
 		return err
 	}
@@ -163,10 +221,10 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stderr, "Saved query and %d results to %s\n", len(out.Results), queryFile)
 	}
 
-	return formatSearchOutput(out, jsonOutput, cslOutput)
+	return formatSearchOutput(out, jsonOutput, cslOutput, clusterThreshold)
 }
 
-func loadAndDisplayQueryFile(path string, jsonOutput, cslOutput bool) error {
+func loadAndDisplayQueryFile(path string, jsonOutput, cslOutput bool, clusterThreshold float64) error {
 	qf, err := search.ReadQueryFile(path)
 	if err != nil {
 		return err
@@ -178,16 +236,21 @@ func loadAndDisplayQueryFile(path string, jsonOutput, cslOutput bool) error {
 		Results:     qf.Results,
 		DupsRemoved: qf.Summary.DuplicatesRemoved,
 	}
-	return formatSearchOutput(out, jsonOutput, cslOutput)
+	return formatSearchOutput(out, jsonOutput, cslOutput, clusterThreshold)
 }
 
-func formatSearchOutput(out search.SearchOutput, jsonOutput, cslOutput bool) error {
+// formatSearchOutput renders results. A positive clusterThreshold groups
+// the table output into topical clusters.
+func formatSearchOutput(out search.SearchOutput, jsonOutput, cslOutput bool, clusterThreshold float64) error {
 	if cslOutput {
 		return search.FormatCSL(out, os.Stdout)
 	}
 	if jsonOutput {
 		return search.FormatJSON(out, os.Stdout)
 	}
+	if clusterThreshold > 0 {
+		out.Clusters = search.ClusterResults(out.Results, clusterThreshold)
+	}
 	search.FormatTable(out, os.Stdout)
 	return nil
 }
@@ -0,0 +1,105 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pdiddy/research-engine/internal/search"
+)
+
+var searchAnnotateCmd = &cobra.Command{
+	Use:   "annotate <query-file>",
+	Short: "Record a keep or reject decision on a saved search result",
+	Long: `Annotate marks one result in a query file as keep or reject, with an
+optional note, and saves the decision in the file. The result is selected by
+its identifier or preferred acquisition ID (DOIs match in any case).
+
+Decisions survive re-running the search with the same --query-file for
+results that are still returned.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSearchAnnotate,
+}
+
+var searchListCmd = &cobra.Command{
+	Use:   "list <query-file>",
+	Short: "List saved search results by triage status",
+	Long: `List prints the results of a query file with their triage status and
+note. Use --status keep to show the shortlist that acquire --from-query
+--status keep downloads, or --status untriaged to see what is left to review.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSearchList,
+}
+
+func init() {
+	searchAnnotateCmd.Flags().String("id", "", "identifier of the result to annotate (required)")
+	searchAnnotateCmd.Flags().String("status", "", "decision: keep or reject (required)")
+	searchAnnotateCmd.Flags().String("note", "", "reason for the decision")
+	searchAnnotateCmd.MarkFlagRequired("id")
+	searchAnnotateCmd.MarkFlagRequired("status")
+
+	searchListCmd.Flags().String("status", "", "show only results with this status: keep, reject, or untriaged")
+	searchListCmd.Flags().Bool("json", false, "output results as JSON")
+
+	searchCmd.AddCommand(searchAnnotateCmd)
+	searchCmd.AddCommand(searchListCmd)
+}
+
+func runSearchAnnotate(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	id, _ := cmd.Flags().GetString("id")
+	statusFlag, _ := cmd.Flags().GetString("status")
+	note, _ := cmd.Flags().GetString("note")
+
+	status, err := search.ParseTriageStatus(statusFlag, false)
+	if err != nil {
+		return err
+	}
+
+	qf, err := search.ReadQueryFile(path)
+	if err != nil {
+		return err
+	}
+	r, err := qf.Annotate(id, status, note, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := qf.Save(path); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "%s: %s (%s)\n", status, r.Identifier, r.Title)
+	return nil
+}
+
+func runSearchList(cmd *cobra.Command, args []string) error {
+	statusFlag, _ := cmd.Flags().GetString("status")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	status, err := search.ParseTriageStatus(statusFlag, true)
+	if err != nil {
+		return err
+	}
+
+	qf, err := search.ReadQueryFile(args[0])
+	if err != nil {
+		return err
+	}
+	results := qf.Filter(status)
+
+	if jsonOutput {
+		out := make([]any, len(results))
+		for i, rr := range results {
+			out[i] = rr.Result
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+	search.FormatTriageTable(results, os.Stdout)
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pdiddy/research-engine/internal/update"
+)
+
+// updatePublicKey is the base64 Ed25519 key that signs release checksums,
+// set at build time via ldflags by mage release.
+var updatePublicKey = ""
+
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Replace this binary with the latest release",
+	Long: `Update checks the release feed for a newer version, downloads the binary
+for this platform, verifies the signature on the release's SHA256SUMS and
+the binary's checksum against it, and replaces the running binary in place.
+Nothing is replaced if any check fails.
+
+Use --check to report whether an update is available without installing it.
+Builds from source carry no signing key and cannot self-update.`,
+	Args: cobra.NoArgs,
+	RunE: runUpdate,
+}
+
+func init() {
+	updateCmd.Flags().Bool("check", false, "only report whether a newer release exists")
+	updateCmd.Flags().Bool("force", false, "reinstall even if the latest release is not newer")
+	updateCmd.Flags().String("feed", update.DefaultFeedURL, "release feed URL")
+	rootCmd.AddCommand(updateCmd)
+}
+
+func runUpdate(cmd *cobra.Command, args []string) error {
+	checkOnly, _ := cmd.Flags().GetBool("check")
+	force, _ := cmd.Flags().GetBool("force")
+	feed, _ := cmd.Flags().GetString("feed")
+
+	ctx := context.Background()
+	client := &http.Client{Timeout: 5 * time.Minute}
+
+	rel, err := update.Check(ctx, client, feed)
+	if err != nil {
+		return err
+	}
+	newer := update.Newer(rel.Version, version)
+	if !newer && !force {
+		fmt.Fprintf(os.Stdout, "research-engine %s is up to date (latest release %s)\n", version, rel.Version)
+		return nil
+	}
+	if checkOnly {
+		fmt.Fprintf(os.Stdout, "research-engine %s is available (installed %s)\n", rel.Version, version)
+		return nil
+	}
+
+	key, err := update.ParsePublicKey(updatePublicKey)
+	if err != nil {
+		return err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating current binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+
+	fmt.Fprintf(os.Stderr, "downloading research-engine %s for %s/%s\n", rel.Version, runtime.GOOS, runtime.GOARCH)
+	bin, err := update.Download(ctx, client, rel, key, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+	if err := update.Replace(exe, bin); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "updated %s from %s to %s\n", exe, version, rel.Version)
+	return nil
+}
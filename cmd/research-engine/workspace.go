@@ -0,0 +1,205 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pdiddy/research-engine/internal/workspace"
+)
+
+// workspaceEnv selects a workspace when --workspace is not given.
+const workspaceEnv = "RESEARCH_ENGINE_WORKSPACE"
+
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "Register named project directories selected with --workspace (add, list, remove, path)",
+	Long: `Workspace manages the registry of named research workspaces in
+~/.config/research-engine/workspaces.yaml. A workspace is a project
+directory with its own papers/, knowledge/, and output/ directories,
+research-engine.yaml, and .secrets/.
+
+The global --workspace flag (or RESEARCH_ENGINE_WORKSPACE) runs any command
+in a registered workspace's directory, so one machine can drive several
+unrelated projects without changing directory.`,
+}
+
+var workspaceAddCmd = &cobra.Command{
+	Use:   "add <name> [dir]",
+	Short: "Register a directory (default: the current one) as a workspace",
+	Long: `Add registers dir, or the current directory, under name. The directory is
+recorded as an absolute path and created if it does not exist.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runWorkspaceAdd,
+}
+
+var workspaceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the registered workspaces",
+	Args:  cobra.NoArgs,
+	RunE:  runWorkspaceList,
+}
+
+var workspaceRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Unregister a workspace; its directory is left alone",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWorkspaceRemove,
+}
+
+var workspacePathCmd = &cobra.Command{
+	Use:   "path <name>",
+	Short: "Print a workspace's directory, for cd \"$(research-engine workspace path NAME)\"",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWorkspacePath,
+}
+
+func init() {
+	workspaceAddCmd.Flags().String("description", "", "what the project is")
+	workspaceListCmd.Flags().Bool("json", false, "output the workspaces as JSON")
+	workspaceCmd.AddCommand(workspaceAddCmd)
+	workspaceCmd.AddCommand(workspaceListCmd)
+	workspaceCmd.AddCommand(workspaceRemoveCmd)
+	workspaceCmd.AddCommand(workspacePathCmd)
+	rootCmd.AddCommand(workspaceCmd)
+}
+
+// loadWorkspaces reads the workspace registry.
+func loadWorkspaces() (*workspace.Registry, error) {
+	path, err := workspace.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return workspace.Load(path)
+}
+
+// enterWorkspace changes to the directory of the workspace named by
+// --workspace or RESEARCH_ENGINE_WORKSPACE, if any, so that the config
+// file, secrets, logs, and every relative path are the workspace's,
+// including relative paths the user passes as arguments or flags.
+func enterWorkspace(cmd *cobra.Command) error {
+	name, _ := cmd.Flags().GetString("workspace")
+	if name == "" {
+		name = os.Getenv(workspaceEnv)
+	}
+	if name == "" {
+		return nil
+	}
+	registry, err := loadWorkspaces()
+	if err != nil {
+		return err
+	}
+	w, err := registry.Lookup(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(w.Path); err != nil {
+		return fmt.Errorf("entering workspace %q: %w", name, err)
+	}
+	fmt.Fprintf(os.Stderr, "Using workspace %s (%s)\n", w.Name, w.Path)
+	return nil
+}
+
+// isWorkspaceCommand reports whether cmd is workspace or one of its
+// subcommands, which run where they are invoked so a stale
+// RESEARCH_ENGINE_WORKSPACE cannot stop the registry being repaired.
+func isWorkspaceCommand(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == workspaceCmd {
+			return true
+		}
+	}
+	return false
+}
+
+func runWorkspaceAdd(cmd *cobra.Command, args []string) error {
+	description, _ := cmd.Flags().GetString("description")
+	dir := "."
+	if len(args) == 2 {
+		dir = args[1]
+	}
+	registry, err := loadWorkspaces()
+	if err != nil {
+		return err
+	}
+	w, err := registry.Add(args[0], dir, description)
+	if err != nil {
+		return err
+	}
+	if err := registry.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Registered workspace %q at %s\n", w.Name, w.Path)
+	return nil
+}
+
+func runWorkspaceList(cmd *cobra.Command, args []string) error {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	registry, err := loadWorkspaces()
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		workspaces := registry.Workspaces
+		if workspaces == nil {
+			workspaces = []workspace.Workspace{}
+		}
+		return enc.Encode(workspaces)
+	}
+	if len(registry.Workspaces) == 0 {
+		fmt.Fprintln(os.Stdout, "No workspaces. Register one with: research-engine workspace add <name> [dir]")
+		return nil
+	}
+	cwd, _ := os.Getwd()
+	for _, w := range registry.Workspaces {
+		mark := " "
+		if filepath.Clean(cwd) == w.Path {
+			mark = "*"
+		}
+		path := w.Path
+		if _, err := os.Stat(w.Path); err != nil {
+			path += " (missing)"
+		}
+		fmt.Fprintf(os.Stdout, "%s %-20s  %s  %s\n", mark, w.Name, path, w.Description)
+	}
+	return nil
+}
+
+func runWorkspaceRemove(cmd *cobra.Command, args []string) error {
+	registry, err := loadWorkspaces()
+	if err != nil {
+		return err
+	}
+	w, err := registry.Lookup(args[0])
+	if err != nil {
+		return err
+	}
+	if err := registry.Remove(w.Name); err != nil {
+		return err
+	}
+	if err := registry.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Unregistered workspace %q; %s is left in place\n", w.Name, w.Path)
+	return nil
+}
+
+func runWorkspacePath(cmd *cobra.Command, args []string) error {
+	registry, err := loadWorkspaces()
+	if err != nil {
+		return err
+	}
+	w, err := registry.Lookup(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, w.Path)
+	return nil
+}
@@ -7,8 +7,10 @@
 package acquire
 
 import (
+	"bufio"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -29,15 +31,131 @@ const (
 
 // BatchResult holds the outcome of a batch acquisition run.
 type BatchResult struct {
-	Downloaded int
-	Skipped    int
-	Failed     int
-	Papers     []*types.Paper
+	Downloaded int `json:"downloaded"`
+	Skipped    int `json:"skipped"`
+	Failed     int `json:"failed"`
+	// NoPDF counts books and chapters recorded without a PDF (R1.7).
+	NoPDF int `json:"no_pdf"`
+	// MetadataOnly counts papers recorded without a PDF in metadata-only
+	// mode (R1.8).
+	MetadataOnly int `json:"metadata_only"`
+	// Paywalled counts papers whose download returned an HTML page
+	// instead of a PDF (R2.11).
+	Paywalled int `json:"paywalled"`
+	// Transient counts the failures a resumed run retries.
+	Transient int `json:"transient"`
+	// NotAttempted counts the identifiers left when the batch stopped at
+	// the papers directory quota; Stopped gives the reason (R2.13).
+	NotAttempted int    `json:"not_attempted"`
+	Stopped      string `json:"stopped,omitempty"`
+	// Items lists the outcome of each identifier in order (R4.8).
+	Items  []BatchItem    `json:"items"`
+	Papers []*types.Paper `json:"-"`
+}
+
+// Outcomes of one identifier in a batch.
+const (
+	OutcomeDownloaded   = "downloaded"
+	OutcomeSkipped      = "skipped"
+	OutcomeFailed       = "failed"
+	OutcomeNoPDF        = "no_pdf"
+	OutcomeMetadataOnly = "metadata_only"
+	OutcomePaywalled    = "paywalled"
+	OutcomeNotAttempted = "not_attempted"
+)
+
+// BatchItem is the outcome of acquiring one identifier (R4.8).
+type BatchItem struct {
+	Identifier string `json:"identifier"`
+	// PaperID is the paper the identifier is stored under; for an
+	// identifier skipped as a duplicate, the paper with the same PDF.
+	PaperID      string `json:"paper_id,omitempty"`
+	Status       string `json:"status"`
+	PDFPath      string `json:"pdf_path,omitempty"`
+	MetadataPath string `json:"metadata_path,omitempty"`
+	Error        string `json:"error,omitempty"`
+	// Transient marks a failure that a resumed run retries.
+	Transient bool `json:"transient,omitempty"`
 }
 
 // Total returns the total number of identifiers processed.
 func (r BatchResult) Total() int {
-	return r.Downloaded + r.Skipped + r.Failed
+	return r.Downloaded + r.Skipped + r.Failed + r.NoPDF + r.MetadataOnly + r.Paywalled
+}
+
+// MarshalJSON adds the total to the encoded counts.
+func (r BatchResult) MarshalJSON() ([]byte, error) {
+	type counts BatchResult
+	return json.Marshal(struct {
+		counts
+		Total int `json:"total"`
+	}{counts(r), r.Total()})
+}
+
+// add counts the outcome of acquiring identifier, stored under slug.
+func (r *BatchResult) add(identifier, slug string, paper *types.Paper, skipped bool, err error, cfg types.AcquisitionConfig) {
+	item := BatchItem{Identifier: identifier, PaperID: slug}
+	if err != nil {
+		item.Status, item.Error, item.Transient = OutcomeFailed, err.Error(), IsTransient(err)
+		r.Failed++
+		if item.Transient {
+			r.Transient++
+		}
+		r.Items = append(r.Items, item)
+		return
+	}
+	switch {
+	case skipped:
+		item.Status = OutcomeSkipped
+		r.Skipped++
+	case paper.Status == types.AcquisitionNoPDF:
+		item.Status = OutcomeNoPDF
+		r.NoPDF++
+	case paper.Status == types.AcquisitionMetadataOnly:
+		item.Status = OutcomeMetadataOnly
+		r.MetadataOnly++
+	case paper.Status == types.AcquisitionPaywalled:
+		item.Status = OutcomePaywalled
+		r.Paywalled++
+	default:
+		item.Status = OutcomeDownloaded
+		r.Downloaded++
+	}
+	item.PaperID, item.PDFPath = paper.ID, paper.PDFPath
+	item.MetadataPath = filepath.Join(cfg.PapersDir, metadataDir, paper.ID+".yaml")
+	r.Items = append(r.Items, item)
+	r.Papers = append(r.Papers, paper)
+}
+
+// stop records that the batch stopped at err with rest not acquired; the
+// first of rest is the identifier whose download err aborted.
+func (r *BatchResult) stop(err error, rest []string) {
+	r.Stopped = err.Error()
+	r.NotAttempted += len(rest)
+	for i, id := range rest {
+		item := BatchItem{Identifier: id, Status: OutcomeNotAttempted}
+		if i == 0 {
+			item.Error = err.Error()
+		}
+		r.Items = append(r.Items, item)
+	}
+}
+
+// Merge adds the outcomes of o to r.
+func (r *BatchResult) Merge(o BatchResult) {
+	r.Downloaded += o.Downloaded
+	r.Skipped += o.Skipped
+	r.Failed += o.Failed
+	r.NoPDF += o.NoPDF
+	r.MetadataOnly += o.MetadataOnly
+	r.Paywalled += o.Paywalled
+	r.Transient += o.Transient
+	r.NotAttempted += o.NotAttempted
+	if r.Stopped == "" {
+		r.Stopped = o.Stopped
+	}
+	r.Items = append(r.Items, o.Items...)
+	r.Papers = append(r.Papers, o.Papers...)
 }
 
 // HasFailures reports whether any papers failed.
@@ -46,9 +164,12 @@ func (r BatchResult) HasFailures() bool {
 }
 
 // AcquirePaper resolves a single identifier, downloads the PDF, and writes
-// metadata. If the PDF already exists on disk, it skips the download.
-// The skipped return value indicates whether the download was skipped.
+// metadata. If the PDF already exists on disk, it skips the download
+// unless cfg.Force is set. The skipped return value indicates whether the
+// download was skipped. The metadata records every request made as the
+// paper's provenance (R3.10).
 func AcquirePaper(client *http.Client, identifier string, cfg types.AcquisitionConfig, w io.Writer) (paper *types.Paper, skipped bool, err error) {
+	client = withProvenance(client)
 	idType, normalized := Classify(identifier)
 	if idType == TypeUnknown {
 		return nil, false, fmt.Errorf("unrecognized identifier format: %q", identifier)
@@ -58,8 +179,8 @@ func AcquirePaper(client *http.Client, identifier string, cfg types.AcquisitionC
 	pdfPath := filepath.Join(cfg.PapersDir, rawDir, slug+".pdf")
 	metaPath := filepath.Join(cfg.PapersDir, metadataDir, slug+".yaml")
 
-	// Skip if PDF already exists (R2.4).
-	if _, err := os.Stat(pdfPath); err == nil {
+	// Skip if PDF already exists (R2.4), unless forced (R2.9).
+	if _, err := os.Stat(pdfPath); err == nil && !cfg.Force {
 		fmt.Fprintf(w, "skipped: %s (already exists)\n", slug)
 		p, readErr := readMetadata(metaPath)
 		if readErr != nil {
@@ -67,19 +188,80 @@ func AcquirePaper(client *http.Client, identifier string, cfg types.AcquisitionC
 		}
 		return p, true, nil
 	}
-
-	// For DOI identifiers, try OpenAlex first for open-access PDF.
-	var source string
-	pdfURL := PDFURL(idType, normalized)
-	if idType == TypeDOI {
-		if oaURL, err := resolveOpenAlex(client, normalized, cfg); err == nil && oaURL != "" {
-			pdfURL = oaURL
-			source = "openalex"
+	// Skip identifiers already linked to another paper's identical PDF.
+	if p, err := readMetadata(metaPath); err == nil && p.DuplicateOf != "" && !cfg.Force {
+		fmt.Fprintf(w, "skipped: %s (same PDF as %s)\n", slug, p.DuplicateOf)
+		if canonical, err := LoadPaper(cfg.PapersDir, p.DuplicateOf); err == nil {
+			return canonical, true, nil
+		}
+		return p, true, nil
+	}
+	// In metadata-only mode an existing record is enough (R1.8).
+	if cfg.MetadataOnly && !cfg.Force {
+		if p, err := readMetadata(metaPath); err == nil {
+			fmt.Fprintf(w, "skipped: %s (metadata exists)\n", slug)
+			return p, true, nil
 		}
 	}
-	// Patent source is always "patentsview" (prd008 R4.6).
-	if idType == TypePatent {
-		source = "patentsview"
+
+	r, err := resolveIdentifier(client, identifier, idType, normalized, cfg, w)
+	if err != nil {
+		return nil, false, err
+	}
+	// Books and chapters without an open-access PDF are recorded as
+	// metadata-only entries rather than failures (R1.7).
+	isBook := idType == TypeISBN || idType == TypeChapter
+	if isBook && r.pdfURL == "" && !cfg.MetadataOnly {
+		return acquireMetadataOnly(client, slug, r.bookDOI, r.isbn, metaPath, cfg, w)
+	}
+	pdfURL, downloadURL, source := r.pdfURL, r.downloadURL, r.source
+
+	// Build Paper record (R3.1, R3.2).
+	if source == "" {
+		source = idType.String()
+	}
+	p := &types.Paper{
+		ID:               slug,
+		SourceURL:        pdfURL,
+		PDFPath:          pdfPath,
+		Source:           source,
+		ConversionStatus: types.ConversionNone,
+		AuthorDetails:    r.authors,
+		Venue:            r.venue,
+	}
+	switch idType {
+	case TypeArxiv:
+		p.ArxivID = StripArxivVersion(normalized)
+	case TypeDOI:
+		p.DOI = normalized
+		p.ArxivID = r.linkedArxivID
+	case TypeISBN, TypeChapter:
+		p.DOI = r.bookDOI
+		p.ISBN = r.isbn
+	case TypePMID, TypePMCID:
+		p.PMID = string(r.pmc.PMID)
+		p.PMCID = r.pmc.PMCID
+		p.DOI = r.pmc.DOI
+		p.ArxivID = r.linkedArxivID
+	}
+
+	// Metadata-only acquisition records the paper without its PDF (R1.8).
+	if cfg.MetadataOnly {
+		p.PDFPath = ""
+		p.Status = types.AcquisitionMetadataOnly
+		if p.SourceURL == "" && p.DOI != "" {
+			p.SourceURL = doiBase + p.DOI
+		}
+		if err := os.MkdirAll(filepath.Dir(metaPath), 0o755); err != nil {
+			return nil, false, fmt.Errorf("creating directory %s: %w", filepath.Dir(metaPath), err)
+		}
+		fetchMetadata(client, idType, normalized, p, cfg, w)
+		stampProvenance(client, p)
+		if err := writeMetadata(p, metaPath); err != nil {
+			return nil, false, fmt.Errorf("writing metadata for %s: %w", slug, err)
+		}
+		fmt.Fprintf(w, "metadata: %s (PDF not downloaded)\n", slug)
+		return p, false, nil
 	}
 
 	if pdfURL == "" {
@@ -98,34 +280,187 @@ func AcquirePaper(client *http.Client, identifier string, cfg types.AcquisitionC
 
 	fmt.Fprintf(w, "downloading: %s (%s)\n", slug, idType)
 
-	// Download PDF to temp file, rename on success (R2.5).
-	// For patents, fall back to Google Patents HTML URL on failure (prd008 R4.4).
-	if err := downloadFile(client, pdfURL, pdfPath, cfg); err != nil {
+	// Download PDF to temp file, rename on success (R2.5). The download
+	// must be a valid PDF (R2.7).
+	// For patents, fall back to Google Patents HTML URL on failure (prd008 R4.4);
+	// that page is not a PDF, so it is not validated.
+	if err := downloadFile(client, downloadURL, pdfPath, cfg, true); err != nil {
+		if quotaExceeded(err) {
+			return nil, false, fmt.Errorf("downloading %s: %w", slug, err)
+		}
 		if idType == TypePatent {
 			fallbackURL := googlePatentsHTMLBase + normalized + "/en"
 			fmt.Fprintf(w, "  warning: patent PDF download failed (%v), trying fallback: %s\n", err, fallbackURL)
-			if fallbackErr := downloadFile(client, fallbackURL, pdfPath, cfg); fallbackErr != nil {
+			if fallbackErr := downloadFile(client, fallbackURL, pdfPath, cfg, false); fallbackErr != nil {
 				return nil, false, fmt.Errorf("downloading %s: primary: %v, fallback: %w", slug, err, fallbackErr)
 			}
 			pdfURL = fallbackURL
+		} else if isBook && !IsTransient(err) {
+			fmt.Fprintf(w, "  warning: %v\n", err)
+			return acquireMetadataOnly(client, slug, r.bookDOI, r.isbn, metaPath, cfg, w)
 		} else {
+			var invalid *InvalidPDFError
+			var paywall *PaywallError
+			if downloadURL != pdfURL && (errors.As(err, &invalid) || errors.As(err, &paywall)) {
+				// A proxy answers with its login page once the session
+				// has expired.
+				return nil, false, fmt.Errorf("downloading %s through the proxy (is the login session still valid?): %w", slug, err)
+			}
+			if errors.As(err, &paywall) {
+				return recordPaywalled(client, idType, normalized, p, metaPath, cfg, w)
+			}
 			return nil, false, fmt.Errorf("downloading %s: %w", slug, err)
 		}
 	}
 
-	// Build Paper record (R3.1, R3.2).
-	if source == "" {
-		source = idType.String()
+	// The patent fallback may have replaced the URL.
+	p.SourceURL = pdfURL
+
+	// Record the checksum; link instead of storing a second copy when
+	// another identifier already acquired the same file (R2.8).
+	sum, err := fileSHA256(pdfPath)
+	if err != nil {
+		return nil, false, err
 	}
-	p := &types.Paper{
-		ID:               slug,
-		SourceURL:        pdfURL,
-		PDFPath:          pdfPath,
-		Source:           source,
-		ConversionStatus: types.ConversionNone,
+	p.SHA256 = sum
+	if info, err := os.Stat(pdfPath); err == nil {
+		dup, err := findDuplicate(cfg.PapersDir, slug, sum, info.Size())
+		if err != nil {
+			fmt.Fprintf(w, "  warning: duplicate check failed: %v\n", err)
+		} else if dup != "" {
+			stampProvenance(client, p)
+			canonical, err := linkDuplicate(cfg.PapersDir, p, dup)
+			if err != nil {
+				return nil, false, err
+			}
+			fmt.Fprintf(w, "linked:  %s (same PDF as %s)\n", slug, dup)
+			return canonical, true, nil
+		}
 	}
 
 	// Fetch metadata from APIs (R3.3, R3.4, R3.5).
+	fetchMetadata(client, idType, normalized, p, cfg, w)
+
+	// Write metadata YAML (R3.6).
+	stampProvenance(client, p)
+	if err := writeMetadata(p, metaPath); err != nil {
+		return nil, false, fmt.Errorf("writing metadata for %s: %w", slug, err)
+	}
+
+	return p, false, nil
+}
+
+// resolution is where an identifier's PDF comes from, with what the
+// resolvers learned about the paper on the way.
+type resolution struct {
+	// pdfURL is the PDF's location, recorded as the source URL; it is
+	// empty for a book or chapter without an open-access copy.
+	pdfURL string
+	// downloadURL is pdfURL, through the institutional proxy when one
+	// applies (R2.10).
+	downloadURL string
+	// source names the backend that provided the PDF, or "" for the
+	// identifier's own resolver.
+	source        string
+	linkedArxivID string
+	authors       []types.PaperAuthor
+	venue         *types.Venue
+	pmc           pmcRecord
+	bookDOI, isbn string
+}
+
+// resolveIdentifier finds the PDF URL of a classified identifier without
+// downloading anything. For DOIs, and PMIDs and books that map to one,
+// OpenAlex is asked for an open-access copy first; the same record names
+// the arXiv preprint, if any, for version linking, and the authors'
+// ORCIDs and affiliations (R3.8).
+func resolveIdentifier(client *http.Client, identifier string, idType IdentifierType, normalized string, cfg types.AcquisitionConfig, w io.Writer) (resolution, error) {
+	r := resolution{pdfURL: PDFURL(idType, normalized)}
+	openAlex := func(doi string) {
+		oa, err := lookupOpenAlex(client, doi, cfg)
+		if err != nil {
+			return
+		}
+		if oaURL := oa.pdfURL(); oaURL != "" {
+			r.pdfURL = oaURL
+			r.source = "openalex"
+		}
+		if idType != TypeISBN && idType != TypeChapter {
+			r.linkedArxivID = oa.arxivID()
+		}
+		r.authors = oa.authors()
+		r.venue = oa.venue()
+	}
+
+	switch idType {
+	case TypeDOI:
+		openAlex(normalized)
+
+	// PMIDs and PMCIDs are mapped by the NCBI ID converter. The PubMed
+	// Central copy is preferred; a PMID without one falls back to its DOI.
+	case TypePMID, TypePMCID:
+		rec, err := lookupPMC(client, normalized, cfg)
+		switch {
+		case err == nil:
+			r.pmc = rec
+		case idType == TypePMCID:
+			fmt.Fprintf(w, "  warning: NCBI ID lookup failed: %v\n", err)
+			r.pmc.PMCID = normalized
+		default:
+			return r, fmt.Errorf("resolving %s: %w", identifier, err)
+		}
+		switch {
+		case r.pmc.PMCID != "":
+			r.pdfURL = pmcPDFBase + r.pmc.PMCID
+			r.source = "pmc"
+		case r.pmc.DOI != "":
+			r.pdfURL = doiBase + r.pmc.DOI
+			openAlex(r.pmc.DOI)
+		default:
+			return r, fmt.Errorf("%s has no PubMed Central copy or DOI", identifier)
+		}
+
+	// Books and chapters resolve to a DOI (an ISBN through Crossref), then
+	// to an open-access PDF through OpenAlex.
+	case TypeISBN, TypeChapter:
+		r.bookDOI = normalized
+		if idType == TypeISBN {
+			r.isbn = normalized
+			doi, err := lookupISBN(client, r.isbn, cfg)
+			if err != nil {
+				fmt.Fprintf(w, "  warning: ISBN lookup failed: %v\n", err)
+			}
+			r.bookDOI = doi
+		} else {
+			r.isbn = chapterISBN(normalized)
+		}
+		if r.bookDOI != "" {
+			openAlex(r.bookDOI)
+		}
+
+	// US patent metadata comes from PatentsView (prd008 R4.6), other
+	// patents' from EPO Open Patent Services (prd008 R4.7).
+	case TypePatent:
+		r.source = "patentsview"
+		if !isUSPatent(normalized) {
+			r.source = "epo-ops"
+		}
+	}
+
+	// Publisher downloads (DOI resolver, chapter, and direct URLs) go
+	// through the institutional proxy when one is configured; open-access
+	// locations, arXiv, PubMed Central, and patents do not (R2.10).
+	r.downloadURL = r.pdfURL
+	if r.source == "" && idType != TypeArxiv && r.pdfURL != "" {
+		r.downloadURL = proxied(r.pdfURL, cfg)
+	}
+	return r, nil
+}
+
+// fetchMetadata fills p from the metadata API for its identifier type
+// (R3.3, R3.4, R3.5). Failures are reported as warnings; the record keeps
+// the fields it has.
+func fetchMetadata(client *http.Client, idType IdentifierType, normalized string, p *types.Paper, cfg types.AcquisitionConfig, w io.Writer) {
 	switch idType {
 	case TypeArxiv:
 		if err := fetchArxivMetadata(client, normalized, p, cfg); err != nil {
@@ -136,56 +471,157 @@ func AcquirePaper(client *http.Client, identifier string, cfg types.AcquisitionC
 			fmt.Fprintf(w, "  warning: CrossRef metadata fetch failed: %v\n", err)
 		}
 	case TypePatent:
-		if err := fetchPatentMetadata(client, normalized, p, cfg); err != nil {
+		fetch := fetchPatentMetadata
+		if !isUSPatent(normalized) {
+			fetch = fetchOPSMetadata
+		}
+		if err := fetch(client, normalized, p, cfg); err != nil {
 			fmt.Fprintf(w, "  warning: patent metadata fetch failed: %v\n", err)
 		}
+	case TypePMID, TypePMCID, TypeISBN, TypeChapter:
+		if p.DOI != "" {
+			if err := fetchCrossRefMetadata(client, p.DOI, p, cfg); err != nil {
+				fmt.Fprintf(w, "  warning: CrossRef metadata fetch failed: %v\n", err)
+			}
+		}
 	}
-
-	// Write metadata YAML (R3.6).
-	if err := writeMetadata(p, metaPath); err != nil {
-		return nil, false, fmt.Errorf("writing metadata for %s: %w", slug, err)
-	}
-
-	return p, false, nil
 }
 
 // AcquireBatch processes multiple identifiers, printing per-item status
 // and returning a summary. It continues after individual failures (R4.2)
-// and applies a delay between consecutive downloads (R5.1).
+// and relies on the client's transport for rate limiting (R5.1); see
+// NewRateLimiter.
+//
+// The status of each identifier is recorded in the papers directory's
+// manifest (see ManifestFile) as it completes, so an interrupted batch can
+// be resumed with Manifest.Resume. A manifest that cannot be written is
+// reported and does not stop the batch.
+//
+// The batch stops when a download would take the papers directory past
+// cfg.MaxTotalBytes (R2.13); that identifier and the rest stay pending in
+// the manifest, so raising the quota and resuming picks them up.
 func AcquireBatch(client *http.Client, identifiers []string, cfg types.AcquisitionConfig, w io.Writer) BatchResult {
+	manifestPath := ManifestPath(cfg.PapersDir)
+	manifest, err := LoadManifest(manifestPath)
+	if err != nil {
+		fmt.Fprintf(w, "warning: %v; starting a new manifest\n", err)
+		manifest = &Manifest{}
+	}
+	manifest.queue(identifiers)
+	saveManifest := func() {
+		if err := manifest.Save(manifestPath); err != nil {
+			fmt.Fprintf(w, "warning: saving acquisition manifest: %v\n", err)
+		}
+	}
+	saveManifest()
+
 	var result BatchResult
 	for i, id := range identifiers {
-		if i > 0 && cfg.DownloadDelay > 0 {
-			time.Sleep(cfg.DownloadDelay)
+		traced := withProvenance(client)
+		paper, wasSkipped, err := AcquirePaper(traced, id, cfg, w)
+		if quotaExceeded(err) {
+			fmt.Fprintf(w, "stopped: %s (%v)\n", id, err)
+			result.stop(err, identifiers[i:])
+			break
+		}
+		manifest.record(id, err, time.Now())
+		if err != nil {
+			manifest.find(id).Provenance = provenanceSteps(traced)
 		}
-		paper, wasSkipped, err := AcquirePaper(client, id, cfg, w)
+		saveManifest()
 		if err != nil {
 			fmt.Fprintf(w, "failed:  %s (%v)\n", id, err)
-			result.Failed++
-			continue
 		}
-		if wasSkipped {
-			result.Skipped++
-		} else {
-			result.Downloaded++
+		slug := ""
+		if idType, normalized := Classify(id); idType != TypeUnknown {
+			slug = Slug(idType, normalized)
 		}
-		result.Papers = append(result.Papers, paper)
+		result.add(id, slug, paper, wasSkipped, err, cfg)
 	}
 	fmt.Fprintf(w, "\nBatch summary: %d downloaded, %d skipped, %d failed (total: %d)\n",
 		result.Downloaded, result.Skipped, result.Failed, result.Total())
+	if result.MetadataOnly > 0 {
+		fmt.Fprintf(w, "%d papers recorded without PDFs (metadata only)\n", result.MetadataOnly)
+	}
+	if result.NoPDF > 0 {
+		fmt.Fprintf(w, "%d books or chapters had no open-access PDF; metadata recorded with status %s\n", result.NoPDF, types.AcquisitionNoPDF)
+	}
+	if result.Paywalled > 0 {
+		fmt.Fprintf(w, "%d papers are paywalled; metadata recorded with status %s (re-check with acquire recheck-oa, which also asks Unpaywall)\n", result.Paywalled, types.AcquisitionPaywalled)
+	}
+	if result.Transient > 0 {
+		fmt.Fprintf(w, "%d failures look transient; rerun with --resume to retry them\n", result.Transient)
+	}
+	if result.Stopped != "" {
+		fmt.Fprintf(w, "Stopped at the papers directory quota: %d identifiers not acquired (raise the quota and rerun with --resume)\n", result.NotAttempted)
+	}
 	return result
 }
 
+// acquireMetadataOnly records a book or chapter that has no downloadable
+// PDF: its metadata comes from Crossref when the DOI is known, and the
+// record is marked AcquisitionNoPDF (R1.7). Acquiring it again retries the
+// PDF lookup.
+func acquireMetadataOnly(client *http.Client, slug, doi, isbn, metaPath string, cfg types.AcquisitionConfig, w io.Writer) (*types.Paper, bool, error) {
+	if err := os.MkdirAll(filepath.Dir(metaPath), 0o755); err != nil {
+		return nil, false, fmt.Errorf("creating directory %s: %w", filepath.Dir(metaPath), err)
+	}
+	p := &types.Paper{
+		ID:               slug,
+		DOI:              doi,
+		ISBN:             isbn,
+		Status:           types.AcquisitionNoPDF,
+		ConversionStatus: types.ConversionNone,
+	}
+	if doi != "" {
+		p.SourceURL = doiBase + doi
+		if err := fetchCrossRefMetadata(client, doi, p, cfg); err != nil {
+			fmt.Fprintf(w, "  warning: CrossRef metadata fetch failed: %v\n", err)
+		}
+	}
+	stampProvenance(client, p)
+	if err := writeMetadata(p, metaPath); err != nil {
+		return nil, false, fmt.Errorf("writing metadata for %s: %w", slug, err)
+	}
+	fmt.Fprintf(w, "no pdf:  %s (metadata only)\n", slug)
+	return p, false, nil
+}
+
+// recordPaywalled writes the metadata record of a paper whose download
+// returned an HTML page, marked AcquisitionPaywalled and without a PDF
+// (R2.11). The source URL stays the publisher URL, so the record shows
+// where the paywall was.
+func recordPaywalled(client *http.Client, idType IdentifierType, normalized string, p *types.Paper, metaPath string, cfg types.AcquisitionConfig, w io.Writer) (*types.Paper, bool, error) {
+	p.PDFPath = ""
+	p.Status = types.AcquisitionPaywalled
+	fetchMetadata(client, idType, normalized, p, cfg, w)
+	stampProvenance(client, p)
+	if err := writeMetadata(p, metaPath); err != nil {
+		return nil, false, fmt.Errorf("writing metadata for %s: %w", p.ID, err)
+	}
+	fmt.Fprintf(w, "paywalled: %s (HTML page instead of PDF; metadata recorded)\n", p.ID)
+	return p, false, nil
+}
+
 // downloadFile fetches url to destPath using a temporary file (R2.5).
 // It sets User-Agent (R5.2) and requests PDF via Accept header.
-// The HTTP client handles redirect following (R5.3).
-func downloadFile(client *http.Client, url, destPath string, cfg types.AcquisitionConfig) error {
+// The HTTP client handles redirect following (R5.3). With requirePDF, an
+// HTML response is not written and a *PaywallError is returned (R2.11),
+// and a download that fails validatePDF is deleted and an
+// *InvalidPDFError returned (R2.7).
+func downloadFile(client *http.Client, url, destPath string, cfg types.AcquisitionConfig, requirePDF bool) error {
+	limit, err := limitFor(cfg, destPath)
+	if err != nil {
+		return err
+	}
+
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
 	req.Header.Set("User-Agent", cfg.UserAgent)
 	req.Header.Set("Accept", "application/pdf")
+	setDownloadHeaders(req, cfg)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -194,7 +630,18 @@ func downloadFile(client *http.Client, url, destPath string, cfg types.Acquisiti
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP %d from %s", resp.StatusCode, url)
+		return &HTTPStatusError{StatusCode: resp.StatusCode, URL: url}
+	}
+	if limit.bytes > 0 && resp.ContentLength > limit.bytes {
+		return limit.exceeded(cfg, url, resp.ContentLength)
+	}
+
+	body := bufio.NewReader(resp.Body)
+	if requirePDF {
+		head, _ := body.Peek(512)
+		if ct := resp.Header.Get("Content-Type"); isHTML(ct, head) {
+			return &PaywallError{URL: url, ContentType: ct}
+		}
 	}
 
 	tmpFile, err := os.CreateTemp(filepath.Dir(destPath), ".acquire-*.tmp")
@@ -203,21 +650,44 @@ func downloadFile(client *http.Client, url, destPath string, cfg types.Acquisiti
 	}
 	tmpPath := tmpFile.Name()
 
-	_, copyErr := io.Copy(tmpFile, resp.Body)
+	// A server may send more than it announced, or announce nothing, so
+	// the limit is also enforced on the bytes read.
+	var src io.Reader = body
+	if limit.bytes > 0 {
+		src = io.LimitReader(body, limit.bytes+1)
+	}
+	n, copyErr := io.Copy(tmpFile, src)
 	closeErr := tmpFile.Close()
 	if copyErr != nil {
 		os.Remove(tmpPath)
 		return fmt.Errorf("writing download: %w", copyErr)
 	}
+	if limit.bytes > 0 && n > limit.bytes {
+		os.Remove(tmpPath)
+		return limit.exceeded(cfg, url, -1)
+	}
 	if closeErr != nil {
 		os.Remove(tmpPath)
 		return fmt.Errorf("closing temp file: %w", closeErr)
 	}
 
+	if requirePDF {
+		reason, err := validatePDF(tmpPath, cfg.VerifyXref)
+		if err != nil {
+			os.Remove(tmpPath)
+			return fmt.Errorf("validating download: %w", err)
+		}
+		if reason != "" {
+			os.Remove(tmpPath)
+			return &InvalidPDFError{URL: url, ContentType: resp.Header.Get("Content-Type"), Reason: reason}
+		}
+	}
+
 	if err := os.Rename(tmpPath, destPath); err != nil {
 		os.Remove(tmpPath)
 		return fmt.Errorf("renaming temp file: %w", err)
 	}
+	markSaved(client)
 	return nil
 }
 
@@ -231,6 +701,7 @@ type arxivEntry struct {
 	Summary   string        `xml:"summary"`
 	Published string        `xml:"published"`
 	Authors   []arxivAuthor `xml:"author"`
+	DOI       string        `xml:"doi"`
 }
 
 type arxivAuthor struct {
@@ -277,6 +748,13 @@ func fetchArxivMetadata(client *http.Client, arxivID string, paper *types.Paper,
 	if t, parseErr := time.Parse(time.RFC3339, entry.Published); parseErr == nil {
 		paper.Date = t
 	}
+	// arXiv records the DOI of the published version when authors supply it.
+	if doi := strings.TrimSpace(entry.DOI); doi != "" {
+		paper.DOI = NormalizeDOI(doi)
+	}
+	if paper.Venue == nil {
+		paper.Venue = &types.Venue{Name: "arXiv", Type: types.VenuePreprint}
+	}
 	return nil
 }
 
@@ -290,6 +768,17 @@ type crossrefWork struct {
 	Abstract string           `json:"abstract"`
 	Author   []crossrefAuthor `json:"author"`
 	Created  crossrefDate     `json:"created"`
+	Funder   []crossrefFunder `json:"funder"`
+	// ContainerTitle, Type, and ISSN describe the venue (R3.9).
+	ContainerTitle []string `json:"container-title"`
+	Type           string   `json:"type"`
+	ISSN           []string `json:"ISSN"`
+}
+
+type crossrefFunder struct {
+	Name  string   `json:"name"`
+	DOI   string   `json:"DOI"`
+	Award []string `json:"award"`
 }
 
 type crossrefAuthor struct {
@@ -301,7 +790,8 @@ type crossrefDate struct {
 	DateParts [][]int `json:"date-parts"`
 }
 
-// fetchCrossRefMetadata retrieves metadata from the CrossRef API (R3.4).
+// fetchCrossRefMetadata retrieves metadata from the CrossRef API (R3.4),
+// including the funders and award numbers publishers deposit (R3.7).
 func fetchCrossRefMetadata(client *http.Client, doi string, paper *types.Paper, cfg types.AcquisitionConfig) error {
 	apiURL := crossrefAPIBase + doi
 
@@ -340,6 +830,20 @@ func fetchCrossRefMetadata(client *http.Client, doi string, paper *types.Paper,
 		parts := cr.Message.Created.DateParts[0]
 		paper.Date = time.Date(parts[0], time.Month(parts[1]), parts[2], 0, 0, 0, 0, time.UTC)
 	}
+
+	// Funder data (R3.7).
+	paper.Funders = nil
+	for _, f := range cr.Message.Funder {
+		if f.Name == "" {
+			continue
+		}
+		paper.Funders = append(paper.Funders, types.Funder{Name: f.Name, DOI: f.DOI, Awards: f.Award})
+	}
+
+	// OpenAlex's venue, when known, takes precedence (R3.9).
+	if paper.Venue == nil {
+		paper.Venue = cr.Message.venue()
+	}
 	return nil
 }
 
@@ -364,3 +868,9 @@ func readMetadata(path string) (*types.Paper, error) {
 	}
 	return &paper, nil
 }
+
+// LoadPaper reads the metadata record of an acquired paper from
+// papersDir/metadata/.
+func LoadPaper(papersDir, paperID string) (*types.Paper, error) {
+	return readMetadata(filepath.Join(papersDir, metadataDir, paperID+".yaml"))
+}
@@ -4,6 +4,7 @@ package acquire
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -27,6 +28,10 @@ func TestClassify(t *testing.T) {
 		{"arxiv prefixed", "arXiv:2301.07041", TypeArxiv, "2301.07041"},
 		{"arxiv versioned", "2301.07041v2", TypeArxiv, "2301.07041v2"},
 		{"arxiv five digit", "2301.12345", TypeArxiv, "2301.12345"},
+		{"arxiv old style", "hep-th/9901001", TypeArxiv, "hep-th/9901001"},
+		{"arxiv old style subject class", "math.GT/0309136", TypeArxiv, "math/0309136"},
+		{"arxiv old style prefixed versioned", "arXiv:cond-mat/0102536v1", TypeArxiv, "cond-mat/0102536v1"},
+		{"arxiv old style bad number", "hep-th/99010", TypeUnknown, "hep-th/99010"},
 		{"doi simple", "10.1145/1234567.1234568", TypeDOI, "10.1145/1234567.1234568"},
 		{"doi nature", "10.1038/s41586-024-07487-w", TypeDOI, "10.1038/s41586-024-07487-w"},
 		{"url https", "https://example.com/paper.pdf", TypeURL, "https://example.com/paper.pdf"},
@@ -34,6 +39,18 @@ func TestClassify(t *testing.T) {
 		{"unknown bare word", "not-an-id", TypeUnknown, "not-an-id"},
 		{"unknown empty", "", TypeUnknown, ""},
 		{"whitespace trimmed", "  2301.07041  ", TypeArxiv, "2301.07041"},
+		{"pmid prefixed", "PMID:12345678", TypePMID, "12345678"},
+		{"pmid lower case spaced", "pmid 12345678", TypePMID, "12345678"},
+		{"pmcid", "PMC1234567", TypePMCID, "PMC1234567"},
+		{"pmcid prefixed", "PMCID: pmc1234567", TypePMCID, "PMC1234567"},
+		{"bare digits", "12345678", TypeUnknown, "12345678"},
+		{"isbn-13 prefixed", "ISBN 978-3-16-148410-0", TypeISBN, "9783161484100"},
+		{"isbn-10 converted", "isbn:0-306-40615-2", TypeISBN, "9780306406157"},
+		{"isbn-13 bare", "978-3-16-148410-0", TypeISBN, "9783161484100"},
+		{"isbn bad check digit", "ISBN 978-3-16-148410-1", TypeUnknown, "ISBN 978-3-16-148410-1"},
+		{"springer chapter doi", "10.1007/978-3-030-58452-8_13", TypeChapter, "10.1007/978-3-030-58452-8_13"},
+		{"elsevier chapter doi", "10.1016/B978-0-12-809633-8.00001-5", TypeChapter, "10.1016/b978-0-12-809633-8.00001-5"},
+		{"springer article doi", "10.1007/s10994-021-05946-3", TypeDOI, "10.1007/s10994-021-05946-3"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -56,9 +73,14 @@ func TestSlug(t *testing.T) {
 		wantSlug string
 	}{
 		{"arxiv", TypeArxiv, "2301.07041", "2301.07041"},
+		{"arxiv old style", TypeArxiv, "math/0309136v2", "math-0309136v2"},
 		{"doi", TypeDOI, "10.1145/1234567.1234568", "10.1145-1234567.1234568"},
 		{"url with filename", TypeURL, "https://example.com/my-paper.pdf", "my-paper"},
 		{"url no filename", TypeURL, "https://example.com/", "url-" + urlHashSlug("https://example.com/")[4:]},
+		{"pmid", TypePMID, "12345678", "pmid-12345678"},
+		{"pmcid", TypePMCID, "PMC1234567", "PMC1234567"},
+		{"isbn", TypeISBN, "9783161484100", "isbn-9783161484100"},
+		{"chapter", TypeChapter, "10.1007/978-3-030-58452-8_13", "10.1007-978-3-030-58452-8_13"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -78,8 +100,11 @@ func TestPDFURL(t *testing.T) {
 		wantURL string
 	}{
 		{"arxiv", TypeArxiv, "2301.07041", arxivPDFBase + "2301.07041"},
+		{"arxiv old style", TypeArxiv, "hep-th/9901001", arxivPDFBase + "hep-th/9901001"},
 		{"doi", TypeDOI, "10.1145/1234567", doiBase + "10.1145/1234567"},
 		{"url passthrough", TypeURL, "https://example.com/paper.pdf", "https://example.com/paper.pdf"},
+		{"pmcid", TypePMCID, "PMC1234567", pmcPDFBase + "PMC1234567"},
+		{"pmid needs lookup", TypePMID, "12345678", ""},
 		{"unknown empty", TypeUnknown, "foo", ""},
 	}
 	for _, tt := range tests {
@@ -114,7 +139,10 @@ const sampleCrossRefJSON = `{
     ],
     "created": {
       "date-parts": [[2023, 6, 15]]
-    }
+    },
+    "funder": [
+      {"name": "National Science Foundation", "DOI": "10.13039/100000001", "award": ["CCF-1918757"]}
+    ]
   }
 }`
 
@@ -132,7 +160,11 @@ const samplePatentsViewJSON = `{
   "total_patent_count": 1
 }`
 
-const fakePDFContent = "%PDF-1.4 fake"
+// fakePDF returns a minimal well-formed PDF that differs per URL path, so
+// downloads pass validatePDF and distinct papers have distinct checksums.
+func fakePDF(path string) string {
+	return minimalPDF(path)
+}
 
 // newTestServer creates an httptest server that serves fake PDF downloads,
 // arXiv API responses, and CrossRef API responses based on URL path.
@@ -142,7 +174,7 @@ func newTestServer(t *testing.T) *httptest.Server {
 		switch {
 		case strings.HasPrefix(r.URL.Path, "/pdf/"):
 			w.Header().Set("Content-Type", "application/pdf")
-			fmt.Fprint(w, fakePDFContent)
+			fmt.Fprint(w, fakePDF(r.URL.Path))
 		case r.URL.Path == "/api/query":
 			w.Header().Set("Content-Type", "application/xml")
 			fmt.Fprint(w, sampleArxivXML)
@@ -156,16 +188,16 @@ func newTestServer(t *testing.T) *httptest.Server {
 		case strings.HasPrefix(r.URL.Path, "/doi/"):
 			// Simulate DOI redirect to PDF.
 			w.Header().Set("Content-Type", "application/pdf")
-			fmt.Fprint(w, fakePDFContent)
+			fmt.Fprint(w, fakePDF(r.URL.Path))
 		case strings.HasPrefix(r.URL.Path, "/patent-pdf/"):
 			w.Header().Set("Content-Type", "application/pdf")
-			fmt.Fprint(w, fakePDFContent)
+			fmt.Fprint(w, fakePDF(r.URL.Path))
 		case strings.HasPrefix(r.URL.Path, "/patentsview-api/"):
 			w.Header().Set("Content-Type", "application/json")
 			fmt.Fprint(w, samplePatentsViewJSON)
 		case strings.HasPrefix(r.URL.Path, "/google-patents/"):
 			w.Header().Set("Content-Type", "application/pdf")
-			fmt.Fprint(w, fakePDFContent)
+			fmt.Fprint(w, fakePDF(r.URL.Path))
 		default:
 			http.NotFound(w, r)
 		}
@@ -252,8 +284,8 @@ func TestAcquirePaperArxiv(t *testing.T) {
 	if err != nil {
 		t.Fatalf("reading PDF: %v", err)
 	}
-	if string(data) != fakePDFContent {
-		t.Errorf("PDF content = %q, want %q", string(data), fakePDFContent)
+	if want := fakePDF("/pdf/2301.07041"); string(data) != want {
+		t.Errorf("PDF content = %q, want %q", string(data), want)
 	}
 
 	// Verify metadata YAML exists.
@@ -326,6 +358,44 @@ func TestAcquirePaperSkipExisting(t *testing.T) {
 	}
 }
 
+func TestAcquirePaperForceReplacesExisting(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+	restore := overrideBaseURLs(ts.URL)
+	defer restore()
+
+	dir := t.TempDir()
+	cfg := testConfig(dir)
+	cfg.Force = true
+
+	pdfPath := filepath.Join(dir, "raw", "2301.07041.pdf")
+	if err := os.MkdirAll(filepath.Dir(pdfPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pdfPath, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	paper, skipped, err := AcquirePaper(ts.Client(), "2301.07041", cfg, &buf)
+	if err != nil {
+		t.Fatalf("AcquirePaper: %v", err)
+	}
+	if skipped {
+		t.Fatalf("expected download with Force, got skipped\n%s", buf.String())
+	}
+	data, err := os.ReadFile(pdfPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := fakePDF("/pdf/2301.07041"); string(data) != want {
+		t.Errorf("PDF content = %q, want the re-downloaded PDF", data)
+	}
+	if paper.SHA256 == "" {
+		t.Error("paper.SHA256 should be recorded")
+	}
+}
+
 func TestAcquirePaperDOIViaOpenAlex(t *testing.T) {
 	// Use a variable so the handler can reference the server URL after assignment.
 	var tsURL string
@@ -336,7 +406,7 @@ func TestAcquirePaperDOIViaOpenAlex(t *testing.T) {
 			fmt.Fprintf(w, `{"best_oa_location":{"pdf_url":"%s/pdf/oa-paper.pdf","landing_page_url":"https://example.com"}}`, tsURL)
 		case strings.HasPrefix(r.URL.Path, "/pdf/"):
 			w.Header().Set("Content-Type", "application/pdf")
-			fmt.Fprint(w, fakePDFContent)
+			fmt.Fprint(w, fakePDF(r.URL.Path))
 		case strings.HasPrefix(r.URL.Path, "/works/"):
 			w.Header().Set("Content-Type", "application/json")
 			fmt.Fprint(w, sampleCrossRefJSON)
@@ -407,6 +477,81 @@ func TestAcquirePaperDOIFallbackWhenNoOA(t *testing.T) {
 	}
 }
 
+func TestAcquirePaperMetadataOnly(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+	restore := overrideBaseURLs(ts.URL)
+	defer restore()
+
+	dir := t.TempDir()
+	cfg := testConfig(dir)
+	cfg.MetadataOnly = true
+	var buf bytes.Buffer
+
+	paper, skipped, err := AcquirePaper(ts.Client(), "10.1145/1234567.1234568", cfg, &buf)
+	if err != nil {
+		t.Fatalf("AcquirePaper: %v", err)
+	}
+	if skipped {
+		t.Error("expected a new record, got skipped")
+	}
+	if paper.Status != types.AcquisitionMetadataOnly {
+		t.Errorf("Status = %q, want %q", paper.Status, types.AcquisitionMetadataOnly)
+	}
+	if paper.PDFPath != "" {
+		t.Errorf("PDFPath = %q, want empty", paper.PDFPath)
+	}
+	if paper.Title != "CrossRef Paper Title" {
+		t.Errorf("Title = %q, want CrossRef title", paper.Title)
+	}
+	if paper.SourceURL != doiBase+"10.1145/1234567.1234568" {
+		t.Errorf("SourceURL = %q", paper.SourceURL)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "raw", paper.ID+".pdf")); !os.IsNotExist(err) {
+		t.Errorf("PDF written in metadata-only mode (stat err %v)", err)
+	}
+	saved, err := LoadPaper(dir, paper.ID)
+	if err != nil {
+		t.Fatalf("LoadPaper: %v", err)
+	}
+	if saved.Status != types.AcquisitionMetadataOnly {
+		t.Errorf("saved Status = %q", saved.Status)
+	}
+
+	// A second metadata-only run keeps the record.
+	if _, skipped, err := AcquirePaper(ts.Client(), "10.1145/1234567.1234568", cfg, &buf); err != nil || !skipped {
+		t.Errorf("second run: skipped = %v, err = %v; want skipped", skipped, err)
+	}
+
+	// Acquiring without the flag downloads the PDF and clears the status.
+	cfg.MetadataOnly = false
+	paper, skipped, err = AcquirePaper(ts.Client(), "10.1145/1234567.1234568", cfg, &buf)
+	if err != nil || skipped {
+		t.Fatalf("full acquisition: skipped = %v, err = %v", skipped, err)
+	}
+	if paper.Status != "" || paper.PDFPath == "" {
+		t.Errorf("full acquisition: Status = %q, PDFPath = %q", paper.Status, paper.PDFPath)
+	}
+}
+
+func TestAcquireBatchCountsMetadataOnly(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+	restore := overrideBaseURLs(ts.URL)
+	defer restore()
+
+	cfg := testConfig(t.TempDir())
+	cfg.MetadataOnly = true
+	var buf bytes.Buffer
+	result := AcquireBatch(ts.Client(), []string{"10.1145/1234567.1234568", "2301.07041"}, cfg, &buf)
+	if result.MetadataOnly != 2 || result.Downloaded != 0 || result.Total() != 2 {
+		t.Errorf("result = %+v, want 2 metadata-only", result)
+	}
+	if !strings.Contains(buf.String(), "2 papers recorded without PDFs") {
+		t.Errorf("summary missing metadata-only line:\n%s", buf.String())
+	}
+}
+
 func TestAcquirePaperArxivBypassesOpenAlex(t *testing.T) {
 	openAlexCalled := false
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -416,7 +561,7 @@ func TestAcquirePaperArxivBypassesOpenAlex(t *testing.T) {
 			http.NotFound(w, r)
 		case strings.HasPrefix(r.URL.Path, "/pdf/"):
 			w.Header().Set("Content-Type", "application/pdf")
-			fmt.Fprint(w, fakePDFContent)
+			fmt.Fprint(w, fakePDF(r.URL.Path))
 		case r.URL.Path == "/api/query":
 			w.Header().Set("Content-Type", "application/xml")
 			fmt.Fprint(w, sampleArxivXML)
@@ -505,6 +650,56 @@ func TestAcquireBatch(t *testing.T) {
 	}
 }
 
+func TestAcquireBatchJSON(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+	restore := overrideBaseURLs(ts.URL)
+	defer restore()
+
+	dir := t.TempDir()
+	cfg := testConfig(dir)
+	rawPath := filepath.Join(dir, "raw")
+	if err := os.MkdirAll(rawPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rawPath, "2301.00002.pdf"), []byte("existing"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	result := AcquireBatch(ts.Client(), []string{"2301.07041", "bad-identifier", "2301.00002"}, cfg, &buf)
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Downloaded, Skipped, Failed, Total int
+		Items                              []BatchItem
+		Papers                             any
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Downloaded != 1 || got.Skipped != 1 || got.Failed != 1 || got.Total != 3 || got.Papers != nil {
+		t.Fatalf("JSON = %s", data)
+	}
+	want := []BatchItem{
+		{Identifier: "2301.07041", PaperID: "2301.07041", Status: OutcomeDownloaded,
+			PDFPath: filepath.Join(rawPath, "2301.07041.pdf"), MetadataPath: filepath.Join(dir, "metadata", "2301.07041.yaml")},
+		{Identifier: "bad-identifier", Status: OutcomeFailed, Error: `unrecognized identifier format: "bad-identifier"`},
+		{Identifier: "2301.00002", PaperID: "2301.00002", Status: OutcomeSkipped,
+			PDFPath: filepath.Join(rawPath, "2301.00002.pdf"), MetadataPath: filepath.Join(dir, "metadata", "2301.00002.yaml")},
+	}
+	if len(got.Items) != len(want) {
+		t.Fatalf("items = %+v", got.Items)
+	}
+	for i := range want {
+		if got.Items[i] != want[i] {
+			t.Errorf("item %d = %+v, want %+v", i, got.Items[i], want[i])
+		}
+	}
+}
+
 func TestAcquireBatchSkipExisting(t *testing.T) {
 	ts := newTestServer(t)
 	defer ts.Close()
@@ -598,6 +793,10 @@ func TestFetchCrossRefMetadata(t *testing.T) {
 	if !paper.Date.Equal(expectedDate) {
 		t.Errorf("Date = %v, want %v", paper.Date, expectedDate)
 	}
+	if len(paper.Funders) != 1 || paper.Funders[0].Name != "National Science Foundation" ||
+		paper.Funders[0].DOI != "10.13039/100000001" || len(paper.Funders[0].Awards) != 1 {
+		t.Errorf("Funders = %+v", paper.Funders)
+	}
 }
 
 func TestWriteAndReadMetadata(t *testing.T) {
@@ -0,0 +1,113 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// proxied returns the URL to download target from: through the
+// institutional proxy when one is configured (R2.10). An EZproxy prefix
+// such as "https://login.ezproxy.example.edu/login?url=" is prepended to
+// the target URL.
+func proxied(target string, cfg types.AcquisitionConfig) string {
+	if cfg.ProxyPrefix == "" {
+		return target
+	}
+	return cfg.ProxyPrefix + target
+}
+
+// setDownloadHeaders adds the configured custom headers to a PDF download
+// request (R2.10). They are not sent to the metadata APIs.
+func setDownloadHeaders(req *http.Request, cfg types.AcquisitionConfig) {
+	for name, value := range cfg.Headers {
+		req.Header.Set(name, value)
+	}
+}
+
+// ParseHeader splits a "Name: value" header as given on the command line.
+func ParseHeader(header string) (name, value string, err error) {
+	name, value, ok := strings.Cut(header, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("invalid header %q: use \"Name: value\"", header)
+	}
+	return name, strings.TrimSpace(value), nil
+}
+
+// LoadCookieJar reads a cookies.txt file in the Netscape format that
+// browser extensions export, so downloads carry the session cookies of a
+// library login (R2.10). Expired cookies are dropped.
+func LoadCookieJar(path string) (http.CookieJar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening cookie file: %w", err)
+	}
+	defer f.Close()
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		httpOnly := false
+		if rest, ok := strings.CutPrefix(line, "#HttpOnly_"); ok {
+			line, httpOnly = rest, true
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("%s:%d: expected 7 tab-separated fields, got %d", path, lineNo, len(fields))
+		}
+		domain, cookiePath := fields[0], fields[2]
+		secure := strings.EqualFold(fields[3], "TRUE")
+		expires, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid expiry %q", path, lineNo, fields[4])
+		}
+		cookie := &http.Cookie{
+			Name:     fields[5],
+			Value:    fields[6],
+			Path:     cookiePath,
+			Secure:   secure,
+			HttpOnly: httpOnly,
+		}
+		// Expiry 0 marks a session cookie.
+		if expires > 0 {
+			cookie.Expires = time.Unix(expires, 0)
+			if cookie.Expires.Before(now) {
+				continue
+			}
+		}
+		host := strings.TrimPrefix(domain, ".")
+		if strings.EqualFold(fields[1], "TRUE") {
+			cookie.Domain = host
+		}
+		scheme := "http"
+		if secure {
+			scheme = "https"
+		}
+		jar.SetCookies(&url.URL{Scheme: scheme, Host: host, Path: cookiePath}, []*http.Cookie{cookie})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading cookie file: %w", err)
+	}
+	return jar, nil
+}
@@ -0,0 +1,136 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseHeader(t *testing.T) {
+	name, value, err := ParseHeader("X-Api-Key:  abc:123 ")
+	if err != nil || name != "X-Api-Key" || value != "abc:123" {
+		t.Errorf("ParseHeader = %q, %q, %v", name, value, err)
+	}
+	if _, _, err := ParseHeader("no colon"); err == nil {
+		t.Error("expected an error for a header without a colon")
+	}
+}
+
+func TestLoadCookieJar(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cookies.txt")
+	content := strings.Join([]string{
+		"# Netscape HTTP Cookie File",
+		"",
+		".ezproxy.example.edu\tTRUE\t/\tTRUE\t0\tezproxy\tsession123",
+		"#HttpOnly_lib.example.edu\tFALSE\t/\tFALSE\t4102444800\tauth\ttoken",
+		"old.example.edu\tFALSE\t/\tFALSE\t1\texpired\tgone",
+	}, "\n")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	jar, err := LoadCookieJar(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://login.ezproxy.example.edu/login", "ezproxy=session123"},
+		{"http://login.ezproxy.example.edu/login", ""},
+		{"http://lib.example.edu/", "auth=token"},
+		{"http://old.example.edu/", ""},
+	}
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.url)
+		var got []string
+		for _, c := range jar.Cookies(u) {
+			got = append(got, c.Name+"="+c.Value)
+		}
+		if strings.Join(got, "; ") != tt.want {
+			t.Errorf("cookies for %s = %v, want %q", tt.url, got, tt.want)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("bad line"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCookieJar(path); err == nil {
+		t.Error("expected an error for a malformed cookie file")
+	}
+}
+
+func TestAcquirePaperThroughProxy(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+	restore := overrideBaseURLs(ts.URL)
+	defer restore()
+
+	// The proxy serves the publisher PDF to requests that carry the
+	// session cookie and the configured header, and its login page
+	// otherwise.
+	var proxiedTarget string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("ezproxy")
+		if err != nil || cookie.Value != "session123" || r.Header.Get("X-Library") != "yes" {
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, "<html>Library login</html>")
+			return
+		}
+		proxiedTarget = r.URL.Query().Get("url")
+		w.Header().Set("Content-Type", "application/pdf")
+		fmt.Fprint(w, fakePDF(proxiedTarget))
+	}))
+	defer proxy.Close()
+
+	dir := t.TempDir()
+	cookiePath := filepath.Join(dir, "cookies.txt")
+	host := strings.TrimPrefix(proxy.URL, "http://")
+	host, _, _ = strings.Cut(host, ":")
+	cookieLine := host + "\tFALSE\t/\tFALSE\t0\tezproxy\tsession123\n"
+	if err := os.WriteFile(cookiePath, []byte(cookieLine), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := testConfig(dir)
+	cfg.ProxyPrefix = proxy.URL + "/login?url="
+	cfg.Headers = map[string]string{"X-Library": "yes"}
+
+	// Without the login session the proxy's login page is rejected.
+	var buf bytes.Buffer
+	_, _, err := AcquirePaper(proxy.Client(), "10.1234/paywalled", cfg, &buf)
+	if err == nil || !strings.Contains(err.Error(), "proxy") {
+		t.Fatalf("expected a proxy login failure, got %v", err)
+	}
+
+	jar, err := LoadCookieJar(cookiePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := proxy.Client()
+	client.Jar = jar
+	paper, _, err := AcquirePaper(client, "10.1234/paywalled", cfg, &buf)
+	if err != nil {
+		t.Fatalf("AcquirePaper: %v\n%s", err, buf.String())
+	}
+	if want := ts.URL + "/doi/10.1234/paywalled"; proxiedTarget != want {
+		t.Errorf("proxied URL = %q, want %q", proxiedTarget, want)
+	}
+	if paper.SourceURL != ts.URL+"/doi/10.1234/paywalled" {
+		t.Errorf("SourceURL = %q, want the publisher URL without the proxy", paper.SourceURL)
+	}
+
+	// arXiv downloads bypass the proxy.
+	if _, _, err := AcquirePaper(client, "2301.07041", cfg, &buf); err != nil {
+		t.Fatalf("arXiv through proxy config: %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// springerPDFBase serves the PDF of an open-access Springer chapter by DOI.
+// Declared as a var so tests can substitute an httptest server.
+var springerPDFBase = "https://link.springer.com/content/pdf/"
+
+// isbnPattern matches prefixed ISBN-10 and ISBN-13 values with optional
+// hyphens or spaces: "ISBN 978-3-16-148410-0", "isbn:0-306-40615-2".
+var isbnPattern = regexp.MustCompile(`^(?i:isbn(?:-1[03])?):?\s*([\dX][\d\s-]{8,15}[\dXx])$`)
+
+// bareISBNPattern matches an unprefixed ISBN-13. Only the 978 and 979
+// prefixes are accepted, so other long digit strings stay unknown.
+var bareISBNPattern = regexp.MustCompile(`^97[89][\d-]{10,14}$`)
+
+// Chapter DOIs embed the ISBN of the book: Springer writes
+// "10.1007/978-3-030-12345-6_7" and Elsevier "10.1016/B978-0-12-809633-8.00001-5".
+// The patterns apply to normalized (lowercase) DOIs.
+var (
+	springerChapterPattern = regexp.MustCompile(`^10\.1007/(97[89][\d-]+)_\d+$`)
+	elsevierChapterPattern = regexp.MustCompile(`^10\.1016/b(97[89][\d-]+)\.\d+-[\dx]$`)
+)
+
+// normalizeISBN strips separators from an ISBN, validates its check digit,
+// and returns the ISBN-13 form, or "" when s is not a valid ISBN.
+func normalizeISBN(s string) string {
+	digits := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(s))
+	switch len(digits) {
+	case 10:
+		sum := 0
+		for i, c := range digits {
+			var d int
+			switch {
+			case c >= '0' && c <= '9':
+				d = int(c - '0')
+			case c == 'X' && i == 9:
+				d = 10
+			default:
+				return ""
+			}
+			sum += (10 - i) * d
+		}
+		if sum%11 != 0 {
+			return ""
+		}
+		isbn13 := "978" + digits[:9]
+		return isbn13 + isbn13CheckDigit(isbn13)
+	case 13:
+		for _, c := range digits {
+			if c < '0' || c > '9' {
+				return ""
+			}
+		}
+		if isbn13CheckDigit(digits[:12]) != digits[12:] {
+			return ""
+		}
+		return digits
+	}
+	return ""
+}
+
+// isbn13CheckDigit returns the check digit for the first twelve digits of
+// an ISBN-13.
+func isbn13CheckDigit(first12 string) string {
+	sum := 0
+	for i, c := range first12 {
+		d := int(c - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return fmt.Sprint((10 - sum%10) % 10)
+}
+
+// classifyISBN reports the ISBN-13 form of a prefixed ISBN or a bare
+// ISBN-13, or "" when identifier is neither.
+func classifyISBN(identifier string) string {
+	if m := isbnPattern.FindStringSubmatch(identifier); m != nil {
+		return normalizeISBN(m[1])
+	}
+	if bareISBNPattern.MatchString(identifier) {
+		return normalizeISBN(identifier)
+	}
+	return ""
+}
+
+// chapterISBN returns the ISBN-13 of the book a Springer or Elsevier
+// chapter DOI belongs to, or "" when doi is not a chapter DOI.
+func chapterISBN(doi string) string {
+	for _, p := range []*regexp.Regexp{springerChapterPattern, elsevierChapterPattern} {
+		if m := p.FindStringSubmatch(doi); m != nil {
+			return normalizeISBN(m[1])
+		}
+	}
+	return ""
+}
+
+// chapterPDFURL returns the publisher PDF URL acquisition tries for a
+// chapter DOI: Springer serves open-access chapters by DOI. Other
+// publishers have no predictable URL.
+func chapterPDFURL(doi string) string {
+	if springerChapterPattern.MatchString(doi) {
+		return springerPDFBase + doi + ".pdf"
+	}
+	return ""
+}
+
+// bookWorkTypes are the Crossref work types that describe a whole book
+// rather than one of its chapters.
+var bookWorkTypes = map[string]bool{
+	"book":           true,
+	"monograph":      true,
+	"edited-book":    true,
+	"reference-book": true,
+	"book-set":       true,
+}
+
+type crossrefListResponse struct {
+	Message struct {
+		Items []struct {
+			DOI  string `json:"DOI"`
+			Type string `json:"type"`
+		} `json:"items"`
+	} `json:"message"`
+}
+
+// lookupISBN finds the DOI of the book with the given ISBN-13 in Crossref.
+// Chapters carry their book's ISBN too, so only book records are used.
+func lookupISBN(client *http.Client, isbn string, cfg types.AcquisitionConfig) (string, error) {
+	q := url.Values{"filter": {"isbn:" + isbn}, "rows": {"20"}}
+	apiURL := strings.TrimSuffix(crossrefAPIBase, "/") + "?" + q.Encode()
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", cfg.UserAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("CrossRef API request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", &HTTPStatusError{StatusCode: resp.StatusCode, URL: apiURL}
+	}
+
+	var cr crossrefListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&cr); err != nil {
+		return "", fmt.Errorf("parsing CrossRef response: %w", err)
+	}
+	for _, item := range cr.Message.Items {
+		if bookWorkTypes[item.Type] {
+			return NormalizeDOI(item.DOI), nil
+		}
+	}
+	return "", fmt.Errorf("no CrossRef book record for ISBN %s", isbn)
+}
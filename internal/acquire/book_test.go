@@ -0,0 +1,146 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"978-3-16-148410-0", "9783161484100"},
+		{"0-306-40615-2", "9780306406157"},
+		{"080442957X", "9780804429573"},
+		{"0-306-40615-3", ""},
+		{"978316148410", ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeISBN(tt.in); got != tt.want {
+			t.Errorf("normalizeISBN(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestChapterISBN(t *testing.T) {
+	if got := chapterISBN("10.1007/978-3-030-58452-8_13"); got != "9783030584528" {
+		t.Errorf("Springer chapter ISBN = %q", got)
+	}
+	if got := chapterISBN("10.1016/b978-0-12-809633-8.00001-5"); got != "9780128096338" {
+		t.Errorf("Elsevier chapter ISBN = %q", got)
+	}
+	if got := chapterISBN("10.1145/1234567.1234568"); got != "" {
+		t.Errorf("article ISBN = %q, want none", got)
+	}
+}
+
+// newBookTestServer serves Crossref ISBN search and metadata, OpenAlex
+// records with an open-access PDF only for oaDOI, and Springer chapter
+// PDFs.
+func newBookTestServer(t *testing.T, oaDOI string) *httptest.Server {
+	t.Helper()
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/works":
+			if r.URL.Query().Get("filter") != "isbn:9783161484100" {
+				fmt.Fprint(w, `{"message":{"items":[]}}`)
+				return
+			}
+			fmt.Fprint(w, `{"message":{"items":[
+				{"DOI":"10.1007/978-3-16-148410-0_1","type":"book-chapter"},
+				{"DOI":"10.1007/978-3-16-148410-0","type":"monograph"}]}}`)
+		case strings.HasPrefix(r.URL.Path, "/works/"):
+			fmt.Fprint(w, sampleCrossRefJSON)
+		case strings.HasPrefix(r.URL.Path, "/openalex/"):
+			if strings.HasSuffix(r.URL.Path, oaDOI) {
+				fmt.Fprintf(w, `{"best_oa_location":{"pdf_url":"%s/oa/book.pdf"}}`, ts.URL)
+				return
+			}
+			fmt.Fprint(w, `{"best_oa_location": null}`)
+		case strings.HasPrefix(r.URL.Path, "/oa/"):
+			w.Header().Set("Content-Type", "application/pdf")
+			fmt.Fprint(w, fakePDF(r.URL.Path))
+		case strings.HasPrefix(r.URL.Path, "/springer/10.1007/978-3-030-58452-8_13"):
+			w.Header().Set("Content-Type", "application/pdf")
+			fmt.Fprint(w, fakePDF(r.URL.Path))
+		case strings.HasPrefix(r.URL.Path, "/springer/"):
+			// Closed chapters serve an HTML paywall page.
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, "<html>Buy this chapter</html>")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return ts
+}
+
+func overrideBookBaseURLs(tsURL string) func() {
+	restore := overrideBaseURLs(tsURL)
+	orig := springerPDFBase
+	springerPDFBase = tsURL + "/springer/"
+	return func() {
+		restore()
+		springerPDFBase = orig
+	}
+}
+
+func TestAcquireBatchBooks(t *testing.T) {
+	ts := newBookTestServer(t, "10.1007/978-3-16-148410-0")
+	defer ts.Close()
+	defer overrideBookBaseURLs(ts.URL)()
+
+	dir := t.TempDir()
+	cfg := testConfig(dir)
+	ids := []string{
+		"ISBN 978-3-16-148410-0",             // OA book via Crossref and OpenAlex
+		"10.1007/978-3-030-58452-8_13",       // OA Springer chapter
+		"10.1007/978-3-030-58452-8_14",       // closed Springer chapter
+		"10.1016/B978-0-12-809633-8.00001-5", // Elsevier chapter, no OA copy
+		"ISBN 0-306-40615-2",                 // unknown to Crossref
+	}
+
+	var buf bytes.Buffer
+	result := AcquireBatch(ts.Client(), ids, cfg, &buf)
+	if result.Downloaded != 2 || result.NoPDF != 3 || result.Failed != 0 {
+		t.Fatalf("result = %+v\n%s", result, buf.String())
+	}
+
+	book, err := LoadPaper(dir, "isbn-9783161484100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.DOI != "10.1007/978-3-16-148410-0" || book.ISBN != "9783161484100" || book.Status != "" {
+		t.Errorf("book = %+v", book)
+	}
+
+	closed, err := LoadPaper(dir, "10.1007-978-3-030-58452-8_14")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.Status != types.AcquisitionNoPDF || closed.ISBN != "9783030584528" || closed.Title != "CrossRef Paper Title" || closed.PDFPath != "" {
+		t.Errorf("closed chapter = %+v", closed)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "raw", "10.1007-978-3-030-58452-8_14.pdf")); !os.IsNotExist(err) {
+		t.Errorf("closed chapter should have no PDF, stat err = %v", err)
+	}
+
+	unknown, err := LoadPaper(dir, "isbn-9780306406157")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unknown.Status != types.AcquisitionNoPDF || unknown.DOI != "" {
+		t.Errorf("unknown ISBN = %+v", unknown)
+	}
+}
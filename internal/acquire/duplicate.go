@@ -0,0 +1,108 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// fileSHA256 returns the hex SHA-256 checksum of the file at path.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("hashing %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// findDuplicate returns the ID of an acquired paper other than slug whose
+// PDF has checksum sum, or "" if there is none. Papers whose metadata
+// predates checksums are hashed on demand, but only when their PDF has the
+// same size.
+func findDuplicate(papersDir, slug, sum string, size int64) (string, error) {
+	metaDir := filepath.Join(papersDir, metadataDir)
+	entries, err := os.ReadDir(metaDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("reading %s: %w", metaDir, err)
+	}
+	for _, entry := range entries {
+		id := strings.TrimSuffix(entry.Name(), ".yaml")
+		if entry.IsDir() || id == entry.Name() || id == slug {
+			continue
+		}
+		p, err := readMetadata(filepath.Join(metaDir, entry.Name()))
+		if err != nil || p.DuplicateOf != "" {
+			continue
+		}
+		if p.SHA256 != "" {
+			if p.SHA256 == sum {
+				return id, nil
+			}
+			continue
+		}
+		pdfPath := filepath.Join(papersDir, rawDir, id+".pdf")
+		info, err := os.Stat(pdfPath)
+		if err != nil || info.Size() != size {
+			continue
+		}
+		if other, err := fileSHA256(pdfPath); err == nil && other == sum {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+// linkDuplicate records p as an alias of the paper canonicalID: p's PDF is
+// removed, p's metadata is written with DuplicateOf set, and the canonical
+// record gains p as an alias along with any DOI or arXiv ID it lacked. It
+// returns the updated canonical paper.
+func linkDuplicate(papersDir string, p *types.Paper, canonicalID string) (*types.Paper, error) {
+	canonicalPath := filepath.Join(papersDir, metadataDir, canonicalID+".yaml")
+	canonical, err := readMetadata(canonicalPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading metadata for %s: %w", canonicalID, err)
+	}
+
+	if err := os.Remove(p.PDFPath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("removing duplicate PDF: %w", err)
+	}
+	p.PDFPath = ""
+	p.DuplicateOf = canonicalID
+	if err := writeMetadata(p, filepath.Join(papersDir, metadataDir, p.ID+".yaml")); err != nil {
+		return nil, fmt.Errorf("writing metadata for %s: %w", p.ID, err)
+	}
+
+	if !slices.Contains(canonical.Aliases, p.ID) {
+		canonical.Aliases = append(canonical.Aliases, p.ID)
+	}
+	if canonical.DOI == "" {
+		canonical.DOI = p.DOI
+	}
+	if canonical.ArxivID == "" {
+		canonical.ArxivID = p.ArxivID
+	}
+	if canonical.SHA256 == "" {
+		canonical.SHA256 = p.SHA256
+	}
+	if err := writeMetadata(canonical, canonicalPath); err != nil {
+		return nil, fmt.Errorf("writing metadata for %s: %w", canonicalID, err)
+	}
+	return canonical, nil
+}
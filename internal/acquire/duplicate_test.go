@@ -0,0 +1,82 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAcquirePaperLinksDuplicateContent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, fakePDF("same paper"))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	cfg := testConfig(dir)
+	var buf bytes.Buffer
+
+	first, _, err := AcquirePaper(ts.Client(), ts.URL+"/preprint.pdf", cfg, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.SHA256 == "" {
+		t.Error("SHA256 not recorded")
+	}
+
+	paper, skipped, err := AcquirePaper(ts.Client(), ts.URL+"/published.pdf", cfg, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !skipped || paper.ID != "preprint" {
+		t.Errorf("duplicate returned %q (skipped %v), want preprint", paper.ID, skipped)
+	}
+	if len(paper.Aliases) != 1 || paper.Aliases[0] != "published" {
+		t.Errorf("Aliases = %v", paper.Aliases)
+	}
+	if _, err := os.Stat(filepath.Join(dir, rawDir, "published.pdf")); !os.IsNotExist(err) {
+		t.Errorf("duplicate PDF kept: %v", err)
+	}
+	alias, err := LoadPaper(dir, "published")
+	if err != nil || alias.DuplicateOf != "preprint" {
+		t.Fatalf("alias record = %+v, %v", alias, err)
+	}
+
+	// Acquiring the alias again skips without downloading.
+	buf.Reset()
+	paper, skipped, err = AcquirePaper(ts.Client(), ts.URL+"/published.pdf", cfg, &buf)
+	if err != nil || !skipped || paper.ID != "preprint" {
+		t.Errorf("re-acquire = %v, %v, %v", paper, skipped, err)
+	}
+	if strings.Contains(buf.String(), "downloading") {
+		t.Errorf("alias was downloaded again:\n%s", buf.String())
+	}
+}
+
+func TestFindDuplicateHashesLegacyPapers(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{rawDir, metadataDir} {
+		os.MkdirAll(filepath.Join(dir, sub), 0o755)
+	}
+	content := []byte(fakePDF("legacy"))
+	os.WriteFile(filepath.Join(dir, rawDir, "old.pdf"), content, 0o644)
+	os.WriteFile(filepath.Join(dir, metadataDir, "old.yaml"), []byte("id: old\n"), 0o644)
+
+	newPath := filepath.Join(t.TempDir(), "new.pdf")
+	os.WriteFile(newPath, content, 0o644)
+	sum, err := fileSHA256(newPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := findDuplicate(dir, "new", sum, int64(len(content)))
+	if err != nil || got != "old" {
+		t.Errorf("findDuplicate = %q, %v, want old", got, err)
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// EPO endpoints. Declared as vars so tests can substitute an httptest
+// server.
+var (
+	// espacenetImagesBase serves the full-document PDF of a publication
+	// from the Espacenet published-data images service.
+	espacenetImagesBase = "https://worldwide.espacenet.com/3.2/rest-services/published-data/images/"
+
+	// opsAuthURL issues Open Patent Services access tokens.
+	opsAuthURL = "https://ops.epo.org/3.2/auth/accesstoken"
+
+	// opsPublicationBase is the OPS published-data publication service.
+	opsPublicationBase = "https://ops.epo.org/3.2/rest-services/published-data/publication/epodoc/"
+)
+
+// intlPatentPattern matches EP, WO, JP, CN, and KR publication numbers
+// with an optional kind code, as written on the document or by Espacenet:
+// "EP1234567B1", "WO2023/123456A1", "JP 2020-123456 A", "CN112345678A",
+// "KR1020200012345A". It captures the country, the number (which may
+// contain a slash or hyphen), and the kind code.
+var intlPatentPattern = regexp.MustCompile(`^(EP|WO|JP|CN|KR)\s?(\d{4}[/-]\d{6}|\d{5,13})\s?([A-Z]\d?)?$`)
+
+// defaultKindCodes is the kind code of a country's first publication, used
+// for the PDF URL when an identifier has none.
+var defaultKindCodes = map[string]string{
+	"EP": "A1",
+	"WO": "A1",
+	"JP": "A",
+	"CN": "A",
+	"KR": "A",
+}
+
+// classifyIntlPatent returns the normalized form of an EP, WO, JP, CN, or
+// KR publication number ("WO2023123456A1"), or "" when identifier is none.
+func classifyIntlPatent(identifier string) string {
+	m := intlPatentPattern.FindStringSubmatch(identifier)
+	if m == nil {
+		return ""
+	}
+	number := strings.NewReplacer("/", "", "-", "").Replace(m[2])
+	return m[1] + number + m[3]
+}
+
+// patentParts splits a normalized patent identifier into its country
+// code, number, and kind code ("" when absent).
+func patentParts(normalized string) (country, number, kind string) {
+	country, rest := normalized[:2], normalized[2:]
+	number = stripKindCode(rest)
+	return country, number, rest[len(number):]
+}
+
+// isUSPatent reports whether a normalized patent identifier is a US
+// patent, which PatentsView and Google Patents storage cover.
+func isUSPatent(normalized string) bool {
+	return strings.HasPrefix(normalized, "US")
+}
+
+// espacenetPDFURL returns the Espacenet full-document PDF of a non-US
+// publication. Without a kind code the country's first publication is
+// assumed.
+func espacenetPDFURL(normalized string) string {
+	country, number, kind := patentParts(normalized)
+	if kind == "" {
+		kind = defaultKindCodes[country]
+	}
+	return espacenetImagesBase + country + "/" + number + "/" + kind + "/fullimage.pdf"
+}
+
+// opsToken caches an OPS access token until shortly before it expires.
+type opsToken struct {
+	mu      sync.Mutex
+	key     string
+	token   string
+	expires time.Time
+}
+
+var opsTokens opsToken
+
+// get returns a valid access token for the consumer key and secret,
+// requesting a new one when the cached token is missing or expiring.
+func (t *opsToken) get(client *http.Client, cfg types.AcquisitionConfig) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.key == cfg.OPSKey && t.token != "" && time.Now().Before(t.expires) {
+		return t.token, nil
+	}
+
+	body := url.Values{"grant_type": {"client_credentials"}}.Encode()
+	req, err := http.NewRequest(http.MethodPost, opsAuthURL, strings.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("creating OPS token request: %w", err)
+	}
+	req.SetBasicAuth(cfg.OPSKey, cfg.OPSSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", cfg.UserAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("OPS token request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("OPS token request returned HTTP %d (check the consumer key and secret)", resp.StatusCode)
+	}
+	var out struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   string `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("parsing OPS token response: %w", err)
+	}
+	lifetime, err := time.ParseDuration(out.ExpiresIn + "s")
+	if err != nil {
+		lifetime = 20 * time.Minute
+	}
+	t.key, t.token = cfg.OPSKey, out.AccessToken
+	t.expires = time.Now().Add(lifetime - time.Minute)
+	return t.token, nil
+}
+
+// OPS answers in a JSON rendering of its XML: text is under "$", and an
+// element that may repeat is an object when it occurs once and an array
+// otherwise.
+type opsText struct {
+	Value string `json:"$"`
+	Lang  string `json:"@lang"`
+}
+
+// opsList decodes an element that is either one object or an array.
+type opsList[T any] []T
+
+func (l *opsList[T]) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, (*[]T)(l))
+	}
+	var one T
+	if err := json.Unmarshal(data, &one); err != nil {
+		return err
+	}
+	*l = opsList[T]{one}
+	return nil
+}
+
+type opsBiblioResponse struct {
+	WorldPatentData struct {
+		ExchangeDocuments struct {
+			ExchangeDocument opsList[opsExchangeDocument] `json:"exchange-document"`
+		} `json:"exchange-documents"`
+	} `json:"ops:world-patent-data"`
+}
+
+type opsExchangeDocument struct {
+	Kind              string `json:"@kind"`
+	BibliographicData struct {
+		PublicationReference struct {
+			DocumentID opsList[struct {
+				Type string  `json:"@document-id-type"`
+				Date opsText `json:"date"`
+			}] `json:"document-id"`
+		} `json:"publication-reference"`
+		InventionTitle opsList[opsText] `json:"invention-title"`
+		Parties        struct {
+			Inventors struct {
+				Inventor opsList[struct {
+					Format string `json:"@data-format"`
+					Name   struct {
+						Name opsText `json:"name"`
+					} `json:"inventor-name"`
+				}] `json:"inventor"`
+			} `json:"inventors"`
+		} `json:"parties"`
+	} `json:"bibliographic-data"`
+	Abstract opsList[struct {
+		Lang string           `json:"@lang"`
+		P    opsList[opsText] `json:"p"`
+	}] `json:"abstract"`
+}
+
+// fetchOPSMetadata fills paper from the OPS bibliographic record of a
+// non-US publication: English title and abstract when available, inventor
+// names as originally written, and the publication date.
+func fetchOPSMetadata(client *http.Client, patentID string, paper *types.Paper, cfg types.AcquisitionConfig) error {
+	if cfg.OPSKey == "" || cfg.OPSSecret == "" {
+		return fmt.Errorf("EPO OPS credentials not configured (.secrets/epo-ops-key and .secrets/epo-ops-secret)")
+	}
+	token, err := opsTokens.get(client, cfg)
+	if err != nil {
+		return err
+	}
+
+	country, number, _ := patentParts(patentID)
+	apiURL := opsPublicationBase + country + number + "/biblio"
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", cfg.UserAgent)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("OPS request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return &HTTPStatusError{StatusCode: resp.StatusCode, URL: apiURL}
+	}
+
+	var out opsBiblioResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return fmt.Errorf("parsing OPS response: %w", err)
+	}
+	docs := out.WorldPatentData.ExchangeDocuments.ExchangeDocument
+	if len(docs) == 0 {
+		return fmt.Errorf("no OPS record for %s", patentID)
+	}
+	// Prefer the publication with the requested kind code.
+	doc := docs[0]
+	_, _, kind := patentParts(patentID)
+	for _, d := range docs {
+		if kind != "" && d.Kind == kind {
+			doc = d
+			break
+		}
+	}
+	bib := doc.BibliographicData
+
+	paper.Title = englishOr(bib.InventionTitle)
+	for _, inv := range bib.Parties.Inventors.Inventor {
+		if inv.Format == "original" && inv.Name.Name.Value != "" {
+			paper.Authors = append(paper.Authors, strings.TrimRight(inv.Name.Name.Value, ", "))
+		}
+	}
+	for _, id := range bib.PublicationReference.DocumentID {
+		if t, err := time.Parse("20060102", id.Date.Value); err == nil {
+			paper.Date = t
+			break
+		}
+	}
+	for _, a := range doc.Abstract {
+		var parts []string
+		for _, p := range a.P {
+			parts = append(parts, p.Value)
+		}
+		paper.Abstract = strings.Join(parts, "\n\n")
+		if a.Lang == "en" {
+			break
+		}
+	}
+	return nil
+}
+
+// englishOr returns the English text of a multilingual element, or the
+// first text when there is no English one.
+func englishOr(texts opsList[opsText]) string {
+	for _, t := range texts {
+		if t.Lang == "en" {
+			return t.Value
+		}
+	}
+	if len(texts) > 0 {
+		return texts[0].Value
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sampleOPSBiblio has two publications of the same family member, with a
+// single inventor rendered as an object rather than an array.
+const sampleOPSBiblio = `{"ops:world-patent-data": {"exchange-documents": {"exchange-document": [
+  {"@kind": "A1", "bibliographic-data": {"invention-title": {"@lang": "en", "$": "Application title"}}},
+  {"@kind": "B1", "bibliographic-data": {
+    "publication-reference": {"document-id": [
+      {"@document-id-type": "docdb", "date": {"$": "20210317"}},
+      {"@document-id-type": "epodoc", "date": {"$": "20210317"}}]},
+    "invention-title": [{"@lang": "de", "$": "Verfahren zum Testen"}, {"@lang": "en", "$": "Method for testing"}],
+    "parties": {"inventors": {"inventor": [
+      {"@data-format": "epodoc", "inventor-name": {"name": {"$": "MUSTER MAX [DE]"}}},
+      {"@data-format": "original", "inventor-name": {"name": {"$": "Muster, Max, "}}}]}}},
+   "abstract": [{"@lang": "de", "p": {"$": "Ein Verfahren."}}, {"@lang": "en", "p": {"$": "A method."}}]}
+]}}}`
+
+func TestAcquireEPPatent(t *testing.T) {
+	var tokenRequests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/images/"):
+			w.Header().Set("Content-Type", "application/pdf")
+			fmt.Fprint(w, fakePDF(r.URL.Path))
+		case r.URL.Path == "/auth":
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != "key" || pass != "secret" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			tokenRequests++
+			fmt.Fprint(w, `{"access_token": "tok", "expires_in": "1199"}`)
+		case r.URL.Path == "/publication/EP1234567/biblio":
+			if r.Header.Get("Authorization") != "Bearer tok" {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, sampleOPSBiblio)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	origImages, origAuth, origPub := espacenetImagesBase, opsAuthURL, opsPublicationBase
+	espacenetImagesBase, opsAuthURL, opsPublicationBase = ts.URL+"/images/", ts.URL+"/auth", ts.URL+"/publication/"
+	defer func() { espacenetImagesBase, opsAuthURL, opsPublicationBase = origImages, origAuth, origPub }()
+	opsTokens = opsToken{}
+	defer func() { opsTokens = opsToken{} }()
+
+	cfg := testConfig(t.TempDir())
+	cfg.OPSKey, cfg.OPSSecret = "key", "secret"
+	var buf bytes.Buffer
+	paper, _, err := AcquirePaper(ts.Client(), "EP1234567B1", cfg, &buf)
+	if err != nil {
+		t.Fatalf("AcquirePaper: %v\n%s", err, buf.String())
+	}
+	if paper.Source != "epo-ops" || paper.SourceURL != ts.URL+"/images/EP/1234567/B1/fullimage.pdf" {
+		t.Errorf("source = %q from %q", paper.Source, paper.SourceURL)
+	}
+	if paper.Title != "Method for testing" || paper.Abstract != "A method." {
+		t.Errorf("title %q, abstract %q", paper.Title, paper.Abstract)
+	}
+	if len(paper.Authors) != 1 || paper.Authors[0] != "Muster, Max" {
+		t.Errorf("authors = %q", paper.Authors)
+	}
+	if !paper.Date.Equal(time.Date(2021, 3, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("date = %v", paper.Date)
+	}
+
+	// The token is reused for the next patent.
+	if _, _, err := AcquirePaper(ts.Client(), "EP1234567A1", cfg, &buf); err != nil {
+		t.Fatalf("second AcquirePaper: %v", err)
+	}
+	if tokenRequests != 1 {
+		t.Errorf("token requested %d times, want 1", tokenRequests)
+	}
+
+	// Without credentials the PDF is still downloaded.
+	cfg = testConfig(t.TempDir())
+	buf.Reset()
+	paper, _, err = AcquirePaper(ts.Client(), "EP1234567", cfg, &buf)
+	if err != nil {
+		t.Fatalf("AcquirePaper without OPS credentials: %v", err)
+	}
+	if !strings.HasSuffix(paper.SourceURL, "/EP/1234567/A1/fullimage.pdf") || !strings.Contains(buf.String(), "credentials not configured") {
+		t.Errorf("source %q, output:\n%s", paper.SourceURL, buf.String())
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"strings"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// DefaultLandingTemplates map identifier types to landing page URLs. The
+// placeholder {id} is replaced with the identifier. Templates can be
+// overridden, for example to route DOIs through a library proxy.
+var DefaultLandingTemplates = map[string]string{
+	"doi":    "https://doi.org/{id}",
+	"arxiv":  "https://arxiv.org/abs/{id}",
+	"patent": "https://patents.google.com/patent/{id}",
+	"pmcid":  "https://pmc.ncbi.nlm.nih.gov/articles/{id}/",
+	"pmid":   "https://pubmed.ncbi.nlm.nih.gov/{id}/",
+}
+
+// LandingURL returns the human-readable landing page for a paper: the
+// publisher page for a DOI, the abstract page for an arXiv preprint, the
+// PubMed Central or PubMed page, the Google Patents page for a patent, or
+// the download URL otherwise.
+// templates override DefaultLandingTemplates per identifier type.
+func LandingURL(p *types.Paper, templates map[string]string) string {
+	expand := func(idType, id string) string {
+		tmpl := templates[idType]
+		if tmpl == "" {
+			tmpl = DefaultLandingTemplates[idType]
+		}
+		return strings.ReplaceAll(tmpl, "{id}", id)
+	}
+	switch {
+	case p.DOI != "":
+		return expand("doi", p.DOI)
+	case p.ArxivID != "":
+		return expand("arxiv", p.ArxivID)
+	case p.PMCID != "":
+		return expand("pmcid", p.PMCID)
+	case p.PMID != "":
+		return expand("pmid", p.PMID)
+	case p.Source == "patentsview":
+		return expand("patent", p.ID)
+	default:
+		return p.SourceURL
+	}
+}
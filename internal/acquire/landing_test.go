@@ -0,0 +1,34 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestLandingURL(t *testing.T) {
+	proxy := map[string]string{"doi": "https://doi-org.proxy.example.edu/{id}"}
+	tests := []struct {
+		name      string
+		paper     types.Paper
+		templates map[string]string
+		want      string
+	}{
+		{"doi", types.Paper{DOI: "10.1145/abc", ArxivID: "2301.07041"}, nil, "https://doi.org/10.1145/abc"},
+		{"doi via proxy", types.Paper{DOI: "10.1145/abc"}, proxy, "https://doi-org.proxy.example.edu/10.1145/abc"},
+		{"arxiv", types.Paper{ArxivID: "hep-th/9901001"}, proxy, "https://arxiv.org/abs/hep-th/9901001"},
+		{"pmcid", types.Paper{PMID: "23193287", PMCID: "PMC3531190"}, nil, "https://pmc.ncbi.nlm.nih.gov/articles/PMC3531190/"},
+		{"pmid", types.Paper{PMID: "23193287"}, nil, "https://pubmed.ncbi.nlm.nih.gov/23193287/"},
+		{"patent", types.Paper{ID: "US7654321B2", Source: "patentsview"}, nil, "https://patents.google.com/patent/US7654321B2"},
+		{"url", types.Paper{SourceURL: "https://example.com/p.pdf", Source: "url"}, nil, "https://example.com/p.pdf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LandingURL(&tt.paper, tt.templates); got != tt.want {
+				t.Errorf("LandingURL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
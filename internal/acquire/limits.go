@@ -0,0 +1,154 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// FileTooLargeError reports a download larger than MaxFileSize (R2.13).
+type FileTooLargeError struct {
+	URL string
+	// Size is the Content-Length, or -1 when the limit was hit while
+	// reading a response that did not announce its length.
+	Size  int64
+	Limit int64
+}
+
+func (e *FileTooLargeError) Error() string {
+	if e.Size < 0 {
+		return fmt.Sprintf("file too large: %s exceeded the %s per-file limit", e.URL, formatBytes(e.Limit))
+	}
+	return fmt.Sprintf("file too large: %s is %s, over the %s per-file limit", e.URL, formatBytes(e.Size), formatBytes(e.Limit))
+}
+
+// QuotaExceededError reports a download that would take the papers
+// directory past MaxTotalBytes (R2.13). A batch stops at the first one.
+type QuotaExceededError struct {
+	PapersDir string
+	Used      int64
+	Limit     int64
+	// Size is the download that did not fit: its Content-Length, -1 when
+	// the quota was hit while reading, or 0 when the quota was already
+	// used up.
+	Size int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	msg := fmt.Sprintf("papers directory quota exceeded: %s holds %s of its %s quota", e.PapersDir, formatBytes(e.Used), formatBytes(e.Limit))
+	switch {
+	case e.Size > 0:
+		msg += fmt.Sprintf("; a %s download does not fit", formatBytes(e.Size))
+	case e.Size < 0:
+		msg += fmt.Sprintf("; a download exceeded the %s left", formatBytes(e.Limit-e.Used))
+	}
+	return msg
+}
+
+// quotaExceeded reports whether err is, or wraps, a QuotaExceededError.
+func quotaExceeded(err error) bool {
+	var quota *QuotaExceededError
+	return errors.As(err, &quota)
+}
+
+// downloadLimit is the most a download may write: the smaller of the
+// per-file limit and what is left of the papers directory quota.
+type downloadLimit struct {
+	// bytes is the limit; zero means none.
+	bytes int64
+	// fromQuota reports that the quota, not the per-file limit, is the
+	// tighter bound.
+	fromQuota bool
+	used      int64
+}
+
+// limitFor computes the limit for a download to destPath. The file being
+// replaced, if any, does not count against the quota. It returns a
+// QuotaExceededError when the quota is already used up.
+func limitFor(cfg types.AcquisitionConfig, destPath string) (downloadLimit, error) {
+	l := downloadLimit{bytes: cfg.MaxFileSize}
+	if cfg.MaxTotalBytes <= 0 {
+		return l, nil
+	}
+	used, err := dirSize(cfg.PapersDir)
+	if err != nil {
+		return l, fmt.Errorf("measuring %s: %w", cfg.PapersDir, err)
+	}
+	if info, err := os.Stat(destPath); err == nil {
+		used -= info.Size()
+	}
+	l.used = used
+	remaining := cfg.MaxTotalBytes - used
+	if remaining <= 0 {
+		return l, &QuotaExceededError{PapersDir: cfg.PapersDir, Used: used, Limit: cfg.MaxTotalBytes}
+	}
+	if l.bytes <= 0 || remaining < l.bytes {
+		l.bytes, l.fromQuota = remaining, true
+	}
+	return l, nil
+}
+
+// exceeded returns the error for a download of size bytes (-1 when
+// unknown) over the limit.
+func (l downloadLimit) exceeded(cfg types.AcquisitionConfig, url string, size int64) error {
+	if l.fromQuota {
+		return &QuotaExceededError{PapersDir: cfg.PapersDir, Used: l.used, Limit: cfg.MaxTotalBytes, Size: size}
+	}
+	return &FileTooLargeError{URL: url, Size: size, Limit: l.bytes}
+}
+
+// dirSize returns the total size of the regular files under dir; a
+// missing directory is empty.
+func dirSize(dir string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			total += info.Size()
+		}
+		return nil
+	})
+	return total, err
+}
+
+// ParseSize parses a byte count such as "500", "200KB", "50MB", or
+// "1.5GB". Units are powers of 1024, case-insensitive, and the trailing B
+// is optional. Zero means no limit.
+func ParseSize(s string) (int64, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	if v == "" {
+		return 0, nil
+	}
+	multiplier := int64(1)
+	for _, u := range []struct {
+		suffix string
+		mult   int64
+	}{{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"T", 1 << 40}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(v, u.suffix) {
+			v, multiplier = strings.TrimSpace(strings.TrimSuffix(v, u.suffix)), u.mult
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q: use a number with an optional KB, MB, GB, or TB suffix", s)
+	}
+	return int64(n * float64(multiplier)), nil
+}
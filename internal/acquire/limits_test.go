@@ -0,0 +1,118 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSize(t *testing.T) {
+	for in, want := range map[string]int64{
+		"":      0,
+		"500":   500,
+		"200KB": 200 << 10,
+		"50mb":  50 << 20,
+		"1.5G":  3 << 29,
+		"2 TB":  2 << 40,
+		"10B":   10,
+	} {
+		got, err := ParseSize(in)
+		if err != nil || got != want {
+			t.Errorf("ParseSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"MB", "-1", "ten"} {
+		if _, err := ParseSize(in); err == nil {
+			t.Errorf("ParseSize(%q) should fail", in)
+		}
+	}
+}
+
+func TestDownloadFileMaxFileSize(t *testing.T) {
+	pdf := minimalPDF("/big")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		if r.URL.Path == "/chunked" {
+			// Flushing before writing the body leaves Content-Length unset.
+			w.(http.Flusher).Flush()
+		}
+		w.Write([]byte(pdf))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	cfg := testConfig(dir)
+	cfg.MaxFileSize = int64(len(pdf)) - 1
+	for _, path := range []string{"/announced", "/chunked"} {
+		dest := filepath.Join(dir, "raw", strings.TrimPrefix(path, "/")+".pdf")
+		os.MkdirAll(filepath.Dir(dest), 0o755)
+		err := downloadFile(ts.Client(), ts.URL+path, dest, cfg, true)
+		var tooLarge *FileTooLargeError
+		if !errors.As(err, &tooLarge) {
+			t.Fatalf("%s: err = %v, want FileTooLargeError", path, err)
+		}
+		if wantSize := map[string]int64{"/announced": int64(len(pdf)), "/chunked": -1}[path]; tooLarge.Size != wantSize {
+			t.Errorf("%s: Size = %d, want %d", path, tooLarge.Size, wantSize)
+		}
+		entries, _ := os.ReadDir(filepath.Dir(dest))
+		if len(entries) != 0 {
+			t.Errorf("%s: left files behind: %v", path, entries)
+		}
+	}
+
+	cfg.MaxFileSize = int64(len(pdf))
+	if err := downloadFile(ts.Client(), ts.URL+"/chunked", filepath.Join(dir, "raw", "ok.pdf"), cfg, true); err != nil {
+		t.Errorf("download at the limit: %v", err)
+	}
+}
+
+func TestAcquireBatchQuota(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+	restore := overrideBaseURLs(ts.URL)
+	defer restore()
+
+	// Measure what one paper takes, then allow half a PDF more.
+	probe := t.TempDir()
+	AcquireBatch(ts.Client(), []string{"2301.07041"}, testConfig(probe), &bytes.Buffer{})
+	os.Remove(ManifestPath(probe))
+	one, err := dirSize(probe)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	cfg := testConfig(dir)
+	cfg.MaxTotalBytes = one + int64(len(fakePDF("/pdf/2301.07042")))/2
+	ids := []string{"2301.07041", "2301.07042", "2301.07043"}
+	var buf bytes.Buffer
+	result := AcquireBatch(ts.Client(), ids, cfg, &buf)
+
+	if result.Downloaded != 1 || result.NotAttempted != 2 || result.Failed != 0 || result.Stopped == "" {
+		t.Fatalf("result = %+v\n%s", result, buf.String())
+	}
+	if got := result.Items[1]; got.Status != OutcomeNotAttempted || !strings.Contains(got.Error, "quota") {
+		t.Errorf("item = %+v", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "raw", "2301.07042.pdf")); !os.IsNotExist(err) {
+		t.Errorf("PDF past the quota was kept: %v", err)
+	}
+	if !strings.Contains(buf.String(), "2 identifiers not acquired") {
+		t.Errorf("output missing stop message:\n%s", buf.String())
+	}
+
+	manifest, err := LoadManifest(ManifestPath(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(manifest.Resume(nil), ","); got != "2301.07042,2301.07043" {
+		t.Errorf("Resume = %s", got)
+	}
+}
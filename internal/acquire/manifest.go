@@ -0,0 +1,178 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// ManifestFile is the name of the acquisition manifest in the papers
+// directory.
+const ManifestFile = "acquisition-manifest.yaml"
+
+// Manifest item statuses.
+const (
+	// StatusPending marks an identifier queued by a batch that has not
+	// been attempted yet, for example because the run was interrupted.
+	StatusPending = "pending"
+	// StatusDone marks an identifier downloaded or already on disk.
+	StatusDone = "done"
+	// StatusRetry marks a transient failure (network error, HTTP 429 or
+	// 5xx) that a resumed run tries again.
+	StatusRetry = "retry"
+	// StatusFailed marks a permanent failure that resuming does not retry.
+	StatusFailed = "failed"
+)
+
+// Manifest records the status of every identifier acquired into a papers
+// directory so an interrupted batch can be resumed.
+type Manifest struct {
+	Updated time.Time      `yaml:"updated"`
+	Items   []ManifestItem `yaml:"items"`
+}
+
+// ManifestItem is the acquisition status of one identifier. A failed
+// attempt keeps the requests it made, since a failure writes no metadata
+// record to hold them (R3.10).
+type ManifestItem struct {
+	ID         string                 `yaml:"id"`
+	Status     string                 `yaml:"status"`
+	Attempts   int                    `yaml:"attempts,omitempty"`
+	Error      string                 `yaml:"error,omitempty"`
+	Updated    time.Time              `yaml:"updated,omitempty"`
+	Provenance []types.ProvenanceStep `yaml:"provenance,omitempty"`
+}
+
+// ManifestPath returns the manifest location for a papers directory.
+func ManifestPath(papersDir string) string {
+	return filepath.Join(papersDir, ManifestFile)
+}
+
+// LoadManifest reads the manifest at path. A missing file yields an empty
+// manifest.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Manifest{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// Save writes the manifest through a temporary file, so an interrupted
+// write leaves the previous manifest intact.
+func (m *Manifest) Save(path string) error {
+	m.Updated = time.Now()
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshaling manifest: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// Resume returns the identifiers a resumed batch processes: pending and
+// retry items in manifest order, then those of ids the manifest does not
+// list. Completed and permanently failed identifiers are left out.
+func (m *Manifest) Resume(ids []string) []string {
+	var out []string
+	for _, item := range m.Items {
+		if item.Status == StatusPending || item.Status == StatusRetry {
+			out = append(out, item.ID)
+		}
+	}
+	for _, id := range ids {
+		if m.find(id) == nil {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// queue marks ids as pending, adding those not yet listed.
+func (m *Manifest) queue(ids []string) {
+	for _, id := range ids {
+		if item := m.find(id); item != nil {
+			item.Status = StatusPending
+			continue
+		}
+		m.Items = append(m.Items, ManifestItem{ID: id, Status: StatusPending})
+	}
+}
+
+// record sets the outcome of an attempt on id.
+func (m *Manifest) record(id string, err error, now time.Time) {
+	item := m.find(id)
+	if item == nil {
+		m.Items = append(m.Items, ManifestItem{ID: id})
+		item = &m.Items[len(m.Items)-1]
+	}
+	item.Attempts++
+	item.Updated = now
+	item.Error = ""
+	item.Provenance = nil
+	switch {
+	case err == nil:
+		item.Status = StatusDone
+	case IsTransient(err):
+		item.Status = StatusRetry
+		item.Error = err.Error()
+	default:
+		item.Status = StatusFailed
+		item.Error = err.Error()
+	}
+}
+
+func (m *Manifest) find(id string) *ManifestItem {
+	for i := range m.Items {
+		if m.Items[i].ID == id {
+			return &m.Items[i]
+		}
+	}
+	return nil
+}
+
+// HTTPStatusError reports a download that returned a non-200 status.
+type HTTPStatusError struct {
+	StatusCode int
+	URL        string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d from %s", e.StatusCode, e.URL)
+}
+
+// IsTransient reports whether an acquisition error may succeed on retry:
+// network failures and HTTP 408, 429, and 5xx responses. Unrecognized
+// identifiers and other HTTP errors are permanent.
+func IsTransient(err error) bool {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		code := statusErr.StatusCode
+		return code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,102 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"503", &HTTPStatusError{StatusCode: 503}, true},
+		{"429 wrapped", fmt.Errorf("downloading x: %w", &HTTPStatusError{StatusCode: 429}), true},
+		{"404", &HTTPStatusError{StatusCode: 404}, false},
+		{"unknown identifier", errors.New("unrecognized identifier format"), false},
+	}
+	for _, tt := range tests {
+		if got := IsTransient(tt.err); got != tt.want {
+			t.Errorf("%s: IsTransient = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestManifestResume(t *testing.T) {
+	m := &Manifest{}
+	m.queue([]string{"a", "b", "c", "d"})
+	now := time.Now()
+	m.record("a", nil, now)
+	m.record("b", &HTTPStatusError{StatusCode: 502}, now)
+	m.record("c", &HTTPStatusError{StatusCode: 404}, now)
+
+	got := m.Resume([]string{"a", "e"})
+	want := []string{"b", "d", "e"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Resume = %v, want %v", got, want)
+	}
+}
+
+func TestAcquireBatchResume(t *testing.T) {
+	busy := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/busy.pdf":
+			if busy {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case "/gone.pdf":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, fakePDF(r.URL.Path))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	cfg := testConfig(dir)
+	ids := []string{ts.URL + "/ok.pdf", ts.URL + "/busy.pdf", ts.URL + "/gone.pdf"}
+
+	var buf bytes.Buffer
+	result := AcquireBatch(ts.Client(), ids, cfg, &buf)
+	if result.Downloaded != 1 || result.Failed != 2 || result.Transient != 1 {
+		t.Fatalf("first run = %+v", result)
+	}
+
+	m, err := LoadManifest(ManifestPath(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := map[string]string{}
+	for _, item := range m.Items {
+		statuses[item.ID] = item.Status
+	}
+	want := map[string]string{ids[0]: StatusDone, ids[1]: StatusRetry, ids[2]: StatusFailed}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Errorf("manifest statuses = %v, want %v", statuses, want)
+	}
+
+	busy = false
+	resumed := m.Resume(nil)
+	if !reflect.DeepEqual(resumed, []string{ids[1]}) {
+		t.Fatalf("Resume = %v, want only the transient failure", resumed)
+	}
+	result = AcquireBatch(ts.Client(), resumed, cfg, &buf)
+	if result.Downloaded != 1 || result.Failed != 0 {
+		t.Errorf("resumed run = %+v", result)
+	}
+	m, _ = LoadManifest(ManifestPath(dir))
+	if got := m.Resume(nil); len(got) != 0 {
+		t.Errorf("nothing should remain to resume, got %v", got)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+const (
+	markdownDir  = "markdown"
+	extractedDir = "extracted"
+)
+
+// MigrateSummary holds counts from a DOI slug migration.
+type MigrateSummary struct {
+	Renamed   int
+	Conflicts int
+	Failed    int
+}
+
+// MigrateDOISlugs renames papers acquired before DOIs were normalized so
+// that their slugs match what acquisition produces now. A DOI slug is
+// lowercased; the PDF, metadata, Markdown, and extraction files move to the
+// new slug, and the metadata and extraction records are rewritten with the
+// new paper ID and normalized DOI. When a file for the new slug already
+// exists the paper is a case-variant duplicate; it is reported as a
+// conflict and left in place. With dryRun set, nothing is changed.
+func MigrateDOISlugs(papersDir, knowledgeDir string, dryRun bool, w io.Writer) (MigrateSummary, error) {
+	metaDir := filepath.Join(papersDir, metadataDir)
+	entries, err := os.ReadDir(metaDir)
+	if err != nil {
+		return MigrateSummary{}, fmt.Errorf("reading metadata directory %s: %w", metaDir, err)
+	}
+
+	var summary MigrateSummary
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" {
+			continue
+		}
+		oldID := strings.TrimSuffix(entry.Name(), ".yaml")
+		if !strings.HasPrefix(oldID, "10.") {
+			continue
+		}
+		newID := strings.ToLower(oldID)
+		if newID == oldID {
+			continue
+		}
+
+		moves := migrationMoves(papersDir, knowledgeDir, oldID, newID)
+		if conflict := firstExisting(moves); conflict != "" {
+			fmt.Fprintf(w, "conflict %s -> %s: %s already exists\n", oldID, newID, conflict)
+			summary.Conflicts++
+			continue
+		}
+
+		if dryRun {
+			fmt.Fprintf(w, "would rename %s -> %s\n", oldID, newID)
+			summary.Renamed++
+			continue
+		}
+
+		if err := migratePaper(papersDir, knowledgeDir, oldID, newID, moves); err != nil {
+			fmt.Fprintf(w, "failed   %s: %v\n", oldID, err)
+			summary.Failed++
+			continue
+		}
+		fmt.Fprintf(w, "renamed  %s -> %s\n", oldID, newID)
+		summary.Renamed++
+	}
+
+	fmt.Fprintf(w, "\nrenamed: %d, conflicts: %d, failed: %d\n",
+		summary.Renamed, summary.Conflicts, summary.Failed)
+	return summary, nil
+}
+
+// fileMove is one file rename performed by the migration.
+type fileMove struct {
+	from, to string
+}
+
+// migrationMoves lists the per-paper files that exist under the old slug.
+func migrationMoves(papersDir, knowledgeDir, oldID, newID string) []fileMove {
+	candidates := []fileMove{
+		{filepath.Join(papersDir, rawDir, oldID+".pdf"), filepath.Join(papersDir, rawDir, newID+".pdf")},
+		{filepath.Join(papersDir, metadataDir, oldID+".yaml"), filepath.Join(papersDir, metadataDir, newID+".yaml")},
+		{filepath.Join(papersDir, markdownDir, oldID+".md"), filepath.Join(papersDir, markdownDir, newID+".md")},
+	}
+	if knowledgeDir != "" {
+		candidates = append(candidates, fileMove{
+			filepath.Join(knowledgeDir, extractedDir, oldID+"-items.yaml"),
+			filepath.Join(knowledgeDir, extractedDir, newID+"-items.yaml"),
+		})
+	}
+
+	var moves []fileMove
+	for _, m := range candidates {
+		if _, err := os.Stat(m.from); err == nil {
+			moves = append(moves, m)
+		}
+	}
+	return moves
+}
+
+// firstExisting returns the first move target that already exists. On
+// case-insensitive filesystems the target is the source itself, which is
+// not a conflict.
+func firstExisting(moves []fileMove) string {
+	for _, m := range moves {
+		toInfo, err := os.Stat(m.to)
+		if err != nil {
+			continue
+		}
+		if fromInfo, err := os.Stat(m.from); err == nil && os.SameFile(fromInfo, toInfo) {
+			continue
+		}
+		return m.to
+	}
+	return ""
+}
+
+// migratePaper renames the paper's files and rewrites the records that
+// embed its ID.
+func migratePaper(papersDir, knowledgeDir, oldID, newID string, moves []fileMove) error {
+	for _, m := range moves {
+		// Rename through a temporary name so case-only renames work on
+		// case-insensitive filesystems.
+		tmp := m.from + ".migrate"
+		if err := os.Rename(m.from, tmp); err != nil {
+			return fmt.Errorf("renaming %s: %w", m.from, err)
+		}
+		if err := os.Rename(tmp, m.to); err != nil {
+			return fmt.Errorf("renaming %s: %w", m.from, err)
+		}
+	}
+
+	metaPath := filepath.Join(papersDir, metadataDir, newID+".yaml")
+	paper, err := readMetadata(metaPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", metaPath, err)
+	}
+	paper.ID = newID
+	if paper.DOI != "" {
+		paper.DOI = NormalizeDOI(paper.DOI)
+	}
+	if paper.PDFPath != "" {
+		paper.PDFPath = filepath.Join(filepath.Dir(paper.PDFPath), newID+".pdf")
+	}
+	if err := writeMetadata(paper, metaPath); err != nil {
+		return err
+	}
+
+	if knowledgeDir == "" {
+		return nil
+	}
+	itemsPath := filepath.Join(knowledgeDir, extractedDir, newID+"-items.yaml")
+	data, err := os.ReadFile(itemsPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", itemsPath, err)
+	}
+	var result types.ExtractionResult
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("parsing %s: %w", itemsPath, err)
+	}
+	result.PaperID = newID
+	for i := range result.Items {
+		if result.Items[i].PaperID == oldID {
+			result.Items[i].PaperID = newID
+		}
+	}
+	out, err := yaml.Marshal(&result)
+	if err != nil {
+		return fmt.Errorf("marshaling %s: %w", itemsPath, err)
+	}
+	return os.WriteFile(itemsPath, out, 0o644)
+}
@@ -0,0 +1,108 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// writeCorpusFile creates a file and its parent directory.
+func writeCorpusFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeCorpusPaper writes the PDF, metadata, and extraction for one paper.
+func writeCorpusPaper(t *testing.T, papersDir, knowledgeDir, id string) {
+	t.Helper()
+	writeCorpusFile(t, filepath.Join(papersDir, rawDir, id+".pdf"), "%PDF")
+	meta, _ := yaml.Marshal(&types.Paper{
+		ID: id, DOI: "10.1145/ABC", PDFPath: filepath.Join(papersDir, rawDir, id+".pdf"),
+	})
+	writeCorpusFile(t, filepath.Join(papersDir, metadataDir, id+".yaml"), string(meta))
+	items, _ := yaml.Marshal(&types.ExtractionResult{
+		PaperID: id,
+		Items:   []types.KnowledgeItem{{ID: "item1", PaperID: id}},
+	})
+	writeCorpusFile(t, filepath.Join(knowledgeDir, extractedDir, id+"-items.yaml"), string(items))
+}
+
+func TestMigrateDOISlugs(t *testing.T) {
+	tmp := t.TempDir()
+	papersDir := filepath.Join(tmp, "papers")
+	knowledgeDir := filepath.Join(tmp, "knowledge")
+	writeCorpusPaper(t, papersDir, knowledgeDir, "10.1145-ABC")
+	writeCorpusPaper(t, papersDir, knowledgeDir, "2301.07041")
+
+	var out strings.Builder
+	summary, err := MigrateDOISlugs(papersDir, knowledgeDir, false, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Renamed != 1 || summary.Conflicts != 0 || summary.Failed != 0 {
+		t.Fatalf("summary = %+v, want 1 renamed\n%s", summary, out.String())
+	}
+
+	for _, path := range []string{
+		filepath.Join(papersDir, rawDir, "10.1145-abc.pdf"),
+		filepath.Join(papersDir, metadataDir, "10.1145-abc.yaml"),
+		filepath.Join(knowledgeDir, extractedDir, "10.1145-abc-items.yaml"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s: %v", path, err)
+		}
+	}
+
+	paper, err := readMetadata(filepath.Join(papersDir, metadataDir, "10.1145-abc.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paper.ID != "10.1145-abc" || paper.DOI != "10.1145/abc" || filepath.Base(paper.PDFPath) != "10.1145-abc.pdf" {
+		t.Errorf("metadata not rewritten: %+v", paper)
+	}
+
+	data, err := os.ReadFile(filepath.Join(knowledgeDir, extractedDir, "10.1145-abc-items.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result types.ExtractionResult
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.PaperID != "10.1145-abc" || result.Items[0].PaperID != "10.1145-abc" {
+		t.Errorf("extraction not rewritten: %+v", result)
+	}
+}
+
+func TestMigrateDOISlugsConflictAndDryRun(t *testing.T) {
+	tmp := t.TempDir()
+	papersDir := filepath.Join(tmp, "papers")
+	knowledgeDir := filepath.Join(tmp, "knowledge")
+	writeCorpusPaper(t, papersDir, knowledgeDir, "10.1145-XYZ")
+	writeCorpusPaper(t, papersDir, knowledgeDir, "10.1145-DUP")
+	writeCorpusPaper(t, papersDir, knowledgeDir, "10.1145-dup")
+
+	var out strings.Builder
+	summary, err := MigrateDOISlugs(papersDir, knowledgeDir, true, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Renamed != 1 || summary.Conflicts != 1 {
+		t.Errorf("summary = %+v, want 1 renamed and 1 conflict\n%s", summary, out.String())
+	}
+	if _, err := os.Stat(filepath.Join(papersDir, rawDir, "10.1145-XYZ.pdf")); err != nil {
+		t.Errorf("dry run must not rename files: %v", err)
+	}
+}
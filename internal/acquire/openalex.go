@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
 
 	"github.com/pdiddy/research-engine/pkg/types"
 )
@@ -14,9 +16,37 @@ import (
 // can substitute an httptest server.
 var openAlexAPIBase = "https://api.openalex.org/works/"
 
+// arxivLandingPattern matches an arxiv.org abstract page URL and captures
+// the arXiv ID in either the current or the pre-2007 scheme.
+var arxivLandingPattern = regexp.MustCompile(`arxiv\.org/abs/(\d{4}\.\d{4,5}(?:v\d+)?|[a-z]+(?:-[a-z]+)?/\d{7}(?:v\d+)?)`)
+
 // openAlexResponse captures the fields we need from an OpenAlex work record.
 type openAlexResponse struct {
-	BestOALocation *openAlexLocation `json:"best_oa_location"`
+	BestOALocation *openAlexLocation    `json:"best_oa_location"`
+	Locations      []openAlexLocation   `json:"locations"`
+	Authorships    []openAlexAuthorship `json:"authorships"`
+	// PrimaryLocation is where the work was published.
+	PrimaryLocation *struct {
+		Source *struct {
+			DisplayName string `json:"display_name"`
+			Type        string `json:"type"`
+			ISSNL       string `json:"issn_l"`
+		} `json:"source"`
+	} `json:"primary_location"`
+}
+
+// openAlexAuthorship is one author of a work with the institutions given
+// on it.
+type openAlexAuthorship struct {
+	Author struct {
+		DisplayName string `json:"display_name"`
+		ORCID       string `json:"orcid"`
+	} `json:"author"`
+	Institutions []struct {
+		DisplayName string `json:"display_name"`
+		ROR         string `json:"ror"`
+		CountryCode string `json:"country_code"`
+	} `json:"institutions"`
 }
 
 // openAlexLocation represents an open-access location in the OpenAlex response.
@@ -29,6 +59,15 @@ type openAlexLocation struct {
 // open-access PDF URL if one exists. It returns an empty string when the
 // paper is not available or has no open-access PDF.
 func resolveOpenAlex(client *http.Client, doi string, cfg types.AcquisitionConfig) (string, error) {
+	oa, err := lookupOpenAlex(client, doi, cfg)
+	if err != nil {
+		return "", err
+	}
+	return oa.pdfURL(), nil
+}
+
+// lookupOpenAlex fetches the OpenAlex work record for a DOI.
+func lookupOpenAlex(client *http.Client, doi string, cfg types.AcquisitionConfig) (openAlexResponse, error) {
 	apiURL := openAlexAPIBase + "https://doi.org/" + doi
 	if cfg.UserAgent != "" {
 		apiURL += "?mailto=" + cfg.UserAgent
@@ -36,30 +75,87 @@ func resolveOpenAlex(client *http.Client, doi string, cfg types.AcquisitionConfi
 
 	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
 	if err != nil {
-		return "", fmt.Errorf("creating OpenAlex request: %w", err)
+		return openAlexResponse{}, fmt.Errorf("creating OpenAlex request: %w", err)
 	}
 	req.Header.Set("User-Agent", cfg.UserAgent)
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("OpenAlex API request: %w", err)
+		return openAlexResponse{}, fmt.Errorf("OpenAlex API request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("OpenAlex API returned HTTP %d", resp.StatusCode)
+		return openAlexResponse{}, fmt.Errorf("OpenAlex API returned HTTP %d", resp.StatusCode)
 	}
 
 	var oa openAlexResponse
 	if err := json.NewDecoder(resp.Body).Decode(&oa); err != nil {
-		return "", fmt.Errorf("parsing OpenAlex response: %w", err)
+		return openAlexResponse{}, fmt.Errorf("parsing OpenAlex response: %w", err)
 	}
 
+	return oa, nil
+}
+
+// pdfURL returns the best open-access PDF URL, or "" when there is none.
+func (oa openAlexResponse) pdfURL() string {
 	if oa.BestOALocation == nil {
-		return "", nil
+		return ""
+	}
+	return oa.BestOALocation.PDFURL
+}
+
+// arxivID returns the versionless arXiv ID of the work's preprint when one
+// of its locations is an arxiv.org landing page, or "" otherwise.
+func (oa openAlexResponse) arxivID() string {
+	for _, loc := range oa.Locations {
+		if m := arxivLandingPattern.FindStringSubmatch(loc.LandingURL); m != nil {
+			return StripArxivVersion(m[1])
+		}
+	}
+	return ""
+}
+
+// authors returns the work's authorships with bare ORCID iDs and their
+// institutions (R3.8).
+func (oa openAlexResponse) authors() []types.PaperAuthor {
+	var authors []types.PaperAuthor
+	for _, as := range oa.Authorships {
+		if as.Author.DisplayName == "" {
+			continue
+		}
+		a := types.PaperAuthor{
+			Name:  as.Author.DisplayName,
+			ORCID: strings.TrimPrefix(strings.TrimPrefix(as.Author.ORCID, "https://orcid.org/"), "http://orcid.org/"),
+		}
+		for _, inst := range as.Institutions {
+			if inst.DisplayName == "" {
+				continue
+			}
+			a.Affiliations = append(a.Affiliations, types.Affiliation{
+				Name:    inst.DisplayName,
+				ROR:     inst.ROR,
+				Country: inst.CountryCode,
+			})
+		}
+		authors = append(authors, a)
+	}
+	return authors
+}
+
+// venue returns the work's publication venue from its primary location,
+// or nil when OpenAlex does not name one (R3.9).
+func (oa openAlexResponse) venue() *types.Venue {
+	if oa.PrimaryLocation == nil || oa.PrimaryLocation.Source == nil {
+		return nil
+	}
+	src := oa.PrimaryLocation.Source
+	if src.DisplayName == "" {
+		return nil
 	}
-	if oa.BestOALocation.PDFURL != "" {
-		return oa.BestOALocation.PDFURL, nil
+	return &types.Venue{
+		Name: src.DisplayName,
+		Type: venueType(src.DisplayName, src.Type),
+		ISSN: src.ISSNL,
 	}
-	return "", nil
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 
@@ -128,3 +129,72 @@ func TestResolveOpenAlexNetworkError(t *testing.T) {
 		t.Fatal("expected error for unreachable server")
 	}
 }
+
+func TestOpenAlexArxivID(t *testing.T) {
+	const body = `{
+  "best_oa_location": {"pdf_url": "https://example.com/p.pdf"},
+  "locations": [
+    {"landing_page_url": "https://dl.acm.org/doi/10.1145/1234567.1234568"},
+    {"landing_page_url": "https://arxiv.org/abs/2301.07041v3", "pdf_url": "https://arxiv.org/pdf/2301.07041v3"}
+  ]
+}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	orig := openAlexAPIBase
+	openAlexAPIBase = srv.URL + "/"
+	defer func() { openAlexAPIBase = orig }()
+
+	oa, err := lookupOpenAlex(srv.Client(), "10.1145/1234567.1234568", types.AcquisitionConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := oa.arxivID(); got != "2301.07041" {
+		t.Errorf("arxivID() = %q, want 2301.07041", got)
+	}
+	if got := oa.pdfURL(); got != "https://example.com/p.pdf" {
+		t.Errorf("pdfURL() = %q", got)
+	}
+}
+
+func TestOpenAlexAuthors(t *testing.T) {
+	const body = `{
+  "authorships": [
+    {"author": {"display_name": "Ada Lovelace", "orcid": "https://orcid.org/0000-0002-1825-0097"},
+     "institutions": [{"display_name": "University of London", "ror": "https://ror.org/04cw6st05", "country_code": "GB"}]},
+    {"author": {"display_name": "Alan Turing", "orcid": null}, "institutions": []},
+    {"author": {"display_name": ""}, "institutions": []}
+  ]
+}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	orig := openAlexAPIBase
+	openAlexAPIBase = srv.URL + "/"
+	defer func() { openAlexAPIBase = orig }()
+
+	oa, err := lookupOpenAlex(srv.Client(), "10.1145/1234567.1234568", types.AcquisitionConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []types.PaperAuthor{
+		{Name: "Ada Lovelace", ORCID: "0000-0002-1825-0097", Affiliations: []types.Affiliation{
+			{Name: "University of London", ROR: "https://ror.org/04cw6st05", Country: "GB"},
+		}},
+		{Name: "Alan Turing"},
+	}
+	if got := oa.authors(); !reflect.DeepEqual(got, want) {
+		t.Errorf("authors() = %+v, want %+v", got, want)
+	}
+}
+
+func TestArxivLandingPatternOldStyle(t *testing.T) {
+	m := arxivLandingPattern.FindStringSubmatch("https://arxiv.org/abs/math/0309136v1")
+	if m == nil || StripArxivVersion(m[1]) != "math/0309136" {
+		t.Errorf("old-style landing URL match = %v", m)
+	}
+}
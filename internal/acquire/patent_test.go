@@ -25,12 +25,12 @@ func newPatentTestServer(t *testing.T) *httptest.Server {
 		// Patent PDF at the Google Patents storage path.
 		case strings.HasPrefix(r.URL.Path, "/patent-pdf/"):
 			w.Header().Set("Content-Type", "application/pdf")
-			fmt.Fprint(w, fakePDFContent)
+			fmt.Fprint(w, fakePDF(r.URL.Path))
 
 		// Google Patents HTML fallback.
 		case strings.HasPrefix(r.URL.Path, "/google-patents/"):
 			w.Header().Set("Content-Type", "application/pdf")
-			fmt.Fprint(w, fakePDFContent)
+			fmt.Fprint(w, fakePDF(r.URL.Path))
 
 		// PatentsView metadata API.
 		case strings.HasPrefix(r.URL.Path, "/patentsview-api/"):
@@ -40,7 +40,7 @@ func newPatentTestServer(t *testing.T) *httptest.Server {
 		// arXiv PDF (for mixed batch tests).
 		case strings.HasPrefix(r.URL.Path, "/pdf/"):
 			w.Header().Set("Content-Type", "application/pdf")
-			fmt.Fprint(w, fakePDFContent)
+			fmt.Fprint(w, fakePDF(r.URL.Path))
 
 		// arXiv API (for mixed batch tests).
 		case r.URL.Path == "/api/query":
@@ -102,8 +102,8 @@ func TestAcquirePatentSuccess(t *testing.T) {
 	if err != nil {
 		t.Fatalf("reading PDF: %v", err)
 	}
-	if string(data) != fakePDFContent {
-		t.Errorf("PDF content = %q, want %q", string(data), fakePDFContent)
+	if want := fakePDF("/patent-pdf/US7654321B2.pdf"); string(data) != want {
+		t.Errorf("PDF content = %q, want %q", string(data), want)
 	}
 
 	// Verify metadata YAML exists.
@@ -162,7 +162,7 @@ func TestAcquirePatentFallback(t *testing.T) {
 		case strings.HasPrefix(r.URL.Path, "/google-patents/"):
 			// Fallback Google Patents HTML serves PDF.
 			w.Header().Set("Content-Type", "application/pdf")
-			fmt.Fprint(w, fakePDFContent)
+			fmt.Fprint(w, fakePDF(r.URL.Path))
 		case strings.HasPrefix(r.URL.Path, "/patentsview-api/"):
 			w.Header().Set("Content-Type", "application/json")
 			fmt.Fprint(w, samplePatentsViewJSON)
@@ -245,7 +245,7 @@ func TestFetchPatentMetadataAPIFailure(t *testing.T) {
 			w.WriteHeader(http.StatusInternalServerError)
 		case strings.HasPrefix(r.URL.Path, "/patent-pdf/"):
 			w.Header().Set("Content-Type", "application/pdf")
-			fmt.Fprint(w, fakePDFContent)
+			fmt.Fprint(w, fakePDF(r.URL.Path))
 		default:
 			http.NotFound(w, r)
 		}
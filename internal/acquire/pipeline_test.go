@@ -88,17 +88,17 @@ func newPipelineTestServer(t *testing.T) *httptest.Server {
 		// Google Patents PDF storage (primary download path).
 		case strings.HasPrefix(r.URL.Path, "/patent-pdf/"):
 			w.Header().Set("Content-Type", "application/pdf")
-			fmt.Fprint(w, fakePDFContent)
+			fmt.Fprint(w, fakePDF(r.URL.Path))
 
 		// Google Patents HTML fallback.
 		case strings.HasPrefix(r.URL.Path, "/google-patents/"):
 			w.Header().Set("Content-Type", "application/pdf")
-			fmt.Fprint(w, fakePDFContent)
+			fmt.Fprint(w, fakePDF(r.URL.Path))
 
 		// arXiv PDF endpoint (for mixed batch).
 		case strings.HasPrefix(r.URL.Path, "/pdf/"):
 			w.Header().Set("Content-Type", "application/pdf")
-			fmt.Fprint(w, fakePDFContent)
+			fmt.Fprint(w, fakePDF(r.URL.Path))
 
 		// arXiv API (for mixed batch metadata).
 		case r.URL.Path == "/api/query":
@@ -246,7 +246,7 @@ func TestPipelineIdempotentReacquire(t *testing.T) {
 	if err != nil {
 		t.Fatalf("reading PDF: %v", err)
 	}
-	if string(data) != fakePDFContent {
+	if string(data) != fakePDF("/patent-pdf/US7654321.pdf") {
 		t.Errorf("PDF content should be unchanged after skip")
 	}
 }
@@ -0,0 +1,195 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// Planned actions of a dry run.
+const (
+	PlanDownload = "download"
+	PlanMetadata = "metadata"
+	PlanNoPDF    = "no pdf"
+	PlanSkip     = "skip"
+	PlanFail     = "fail"
+)
+
+// PlanItem is what acquiring one identifier would do.
+type PlanItem struct {
+	Identifier string `json:"identifier"`
+	// Slug is the paper ID the identifier is stored under; empty for an
+	// unrecognized identifier.
+	Slug   string         `json:"paper_id,omitempty"`
+	Type   IdentifierType `json:"type"`
+	Action string         `json:"action"`
+	// URL is the download URL, through the proxy when one applies.
+	URL    string `json:"url,omitempty"`
+	Source string `json:"source,omitempty"`
+	// Size is the Content-Length a HEAD request reported, or -1 when the
+	// server gave none or does not answer HEAD.
+	Size int64 `json:"size"`
+	// Reason explains a skip or failure.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Plan is the outcome of a dry run over a batch.
+type Plan struct {
+	Items []PlanItem `json:"items"`
+	// Downloads counts the PDFs that would be downloaded, and KnownBytes
+	// the total size of those whose size is known.
+	Downloads  int   `json:"downloads"`
+	KnownBytes int64 `json:"known_bytes"`
+	// UnknownSize counts downloads whose size is not known.
+	UnknownSize int `json:"unknown_size"`
+	Skipped     int `json:"skipped"`
+	Failed      int `json:"failed"`
+}
+
+// PlanBatch classifies and resolves identifiers as AcquireBatch would and
+// reports what would be downloaded, with sizes from HEAD requests where
+// servers support them, without writing anything: no PDFs, metadata, or
+// manifest (R4.7). Resolving a DOI, PMID, or ISBN still queries OpenAlex,
+// NCBI, or Crossref.
+func PlanBatch(client *http.Client, identifiers []string, cfg types.AcquisitionConfig, w io.Writer) Plan {
+	var plan Plan
+	for _, id := range identifiers {
+		item := planIdentifier(client, id, cfg)
+		switch item.Action {
+		case PlanDownload:
+			plan.Downloads++
+			if item.Size >= 0 {
+				plan.KnownBytes += item.Size
+			} else {
+				plan.UnknownSize++
+			}
+		case PlanSkip:
+			plan.Skipped++
+		case PlanFail:
+			plan.Failed++
+		}
+		plan.Items = append(plan.Items, item)
+		printPlanItem(w, item)
+	}
+	fmt.Fprintf(w, "\nDry run: %d to download (%s", plan.Downloads, formatBytes(plan.KnownBytes))
+	if plan.UnknownSize > 0 {
+		fmt.Fprintf(w, " plus %d of unknown size", plan.UnknownSize)
+	}
+	fmt.Fprintf(w, "), %d skipped, %d unresolvable (total: %d); nothing was written\n", plan.Skipped, plan.Failed, len(plan.Items))
+	return plan
+}
+
+// planIdentifier plans one identifier, mirroring AcquirePaper's skip
+// checks and resolution.
+func planIdentifier(client *http.Client, identifier string, cfg types.AcquisitionConfig) PlanItem {
+	item := PlanItem{Identifier: identifier, Size: -1}
+	idType, normalized := Classify(identifier)
+	item.Type = idType
+	if idType == TypeUnknown {
+		item.Action, item.Reason = PlanFail, "unrecognized identifier format"
+		return item
+	}
+	item.Slug = Slug(idType, normalized)
+	pdfPath := filepath.Join(cfg.PapersDir, rawDir, item.Slug+".pdf")
+	metaPath := filepath.Join(cfg.PapersDir, metadataDir, item.Slug+".yaml")
+
+	if !cfg.Force {
+		if _, err := os.Stat(pdfPath); err == nil {
+			item.Action, item.Reason = PlanSkip, "already exists"
+			return item
+		}
+		if p, err := readMetadata(metaPath); err == nil {
+			switch {
+			case p.DuplicateOf != "":
+				item.Action, item.Reason = PlanSkip, "same PDF as "+p.DuplicateOf
+				return item
+			case cfg.MetadataOnly:
+				item.Action, item.Reason = PlanSkip, "metadata exists"
+				return item
+			}
+		}
+	}
+
+	r, err := resolveIdentifier(client, identifier, idType, normalized, cfg, io.Discard)
+	if err != nil {
+		item.Action, item.Reason = PlanFail, err.Error()
+		return item
+	}
+	item.URL, item.Source = r.downloadURL, r.source
+	if item.Source == "" {
+		item.Source = idType.String()
+	}
+	switch {
+	case cfg.MetadataOnly:
+		item.Action = PlanMetadata
+	case r.pdfURL == "" && (idType == TypeISBN || idType == TypeChapter):
+		item.Action, item.Reason = PlanNoPDF, "no open-access PDF; metadata only"
+	case r.pdfURL == "":
+		item.Action, item.Reason = PlanFail, "cannot resolve PDF URL"
+	default:
+		item.Action = PlanDownload
+		item.Size = headSize(client, r.downloadURL, cfg)
+	}
+	return item
+}
+
+// headSize returns the Content-Length of a HEAD request for url, or -1
+// when the request fails or the server reports no length.
+func headSize(client *http.Client, url string, cfg types.AcquisitionConfig) int64 {
+	req, err := http.NewRequest(http.MethodHead, url, nil)
+	if err != nil {
+		return -1
+	}
+	req.Header.Set("User-Agent", cfg.UserAgent)
+	req.Header.Set("Accept", "application/pdf")
+	setDownloadHeaders(req, cfg)
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.ContentLength < 0 {
+		return -1
+	}
+	return resp.ContentLength
+}
+
+func printPlanItem(w io.Writer, item PlanItem) {
+	switch item.Action {
+	case PlanDownload:
+		size := "size unknown"
+		if item.Size >= 0 {
+			size = formatBytes(item.Size)
+		}
+		fmt.Fprintf(w, "download: %s (%s, %s) %s\n", item.Slug, item.Source, size, item.URL)
+	case PlanMetadata:
+		fmt.Fprintf(w, "metadata: %s (%s; PDF not downloaded)\n", item.Slug, item.Source)
+	case PlanNoPDF:
+		fmt.Fprintf(w, "no pdf:  %s (%s)\n", item.Slug, item.Reason)
+	case PlanSkip:
+		fmt.Fprintf(w, "skip:    %s (%s)\n", item.Slug, item.Reason)
+	case PlanFail:
+		fmt.Fprintf(w, "fail:    %s (%s)\n", item.Identifier, item.Reason)
+	}
+}
+
+// formatBytes renders a size in B, KB, MB, or GB.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	size, suffix := float64(n), []string{"KB", "MB", "GB"}
+	i := -1
+	for size >= unit && i < len(suffix)-1 {
+		size /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", size, suffix[i])
+}
@@ -0,0 +1,92 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPlanBatch(t *testing.T) {
+	var downloads int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/openalex/"):
+			fmt.Fprint(w, `{"best_oa_location": null}`)
+		case strings.HasPrefix(r.URL.Path, "/pdf/"):
+			if r.Method != http.MethodHead {
+				downloads++
+			}
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Header().Set("Content-Length", "2097152")
+		case strings.HasPrefix(r.URL.Path, "/doi/"):
+			// Publishers often refuse HEAD.
+			if r.Method == http.MethodHead {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			downloads++
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	restore := overrideBaseURLs(ts.URL)
+	defer restore()
+
+	dir := t.TempDir()
+	cfg := testConfig(dir)
+	rawPath := filepath.Join(dir, rawDir)
+	if err := os.MkdirAll(rawPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rawPath, "2301.00002.pdf"), []byte(fakePDF("/pdf/2301.00002")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	plan := PlanBatch(ts.Client(), []string{"2301.00001", "10.1234/Paper", "2301.00002", "not-an-id"}, cfg, &out)
+	if plan.Downloads != 2 || plan.KnownBytes != 2<<20 || plan.UnknownSize != 1 || plan.Skipped != 1 || plan.Failed != 1 {
+		t.Fatalf("plan = %+v\n%s", plan, out.String())
+	}
+	doi := plan.Items[1]
+	if doi.Slug != "10.1234-paper" || doi.URL != ts.URL+"/doi/10.1234/paper" || doi.Source != "doi" || doi.Size != -1 {
+		t.Errorf("DOI item = %+v", doi)
+	}
+	for _, want := range []string{
+		"download: 2301.00001 (arxiv, 2.0 MB) " + ts.URL + "/pdf/2301.00001",
+		"download: 10.1234-paper (doi, size unknown)",
+		"skip:    2301.00002 (already exists)",
+		"fail:    not-an-id (unrecognized identifier format)",
+		"2 to download (2.0 MB plus 1 of unknown size), 1 skipped, 1 unresolvable (total: 4)",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	if downloads != 0 {
+		t.Errorf("dry run downloaded %d files", downloads)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("dry run wrote files: %v", entries)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{0: "0 B", 1023: "1023 B", 1536: "1.5 KB", 5 << 20: "5.0 MB", 3 << 30: "3.0 GB"}
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// redactedParams are query parameters whose values are left out of the
+// provenance log.
+var redactedParams = []string{"email", "mailto", "api_key", "apikey", "key", "token"}
+
+// provenanceRecorder is an http.RoundTripper that logs every request of
+// one paper's acquisition, redirects included (R3.10).
+type provenanceRecorder struct {
+	base  http.RoundTripper
+	mu    sync.Mutex
+	steps []types.ProvenanceStep
+}
+
+func (r *provenanceRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	step := types.ProvenanceStep{
+		Time:   time.Now().UTC().Truncate(time.Millisecond),
+		Method: req.Method,
+		URL:    redactURL(req.URL),
+	}
+	base := r.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		step.Error = err.Error()
+	} else {
+		step.Status = resp.StatusCode
+	}
+	r.mu.Lock()
+	r.steps = append(r.steps, step)
+	r.mu.Unlock()
+	return resp, err
+}
+
+// withProvenance returns a copy of client that records its requests, or
+// client itself when it already does.
+func withProvenance(client *http.Client) *http.Client {
+	if _, ok := client.Transport.(*provenanceRecorder); ok {
+		return client
+	}
+	traced := *client
+	traced.Transport = &provenanceRecorder{base: client.Transport}
+	return &traced
+}
+
+// provenanceSteps returns a copy of the requests client has recorded, or
+// nil when it does not record them.
+func provenanceSteps(client *http.Client) []types.ProvenanceStep {
+	r, ok := client.Transport.(*provenanceRecorder)
+	if !ok {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]types.ProvenanceStep(nil), r.steps...)
+}
+
+// stampProvenance sets p's provenance to the requests client has recorded.
+func stampProvenance(client *http.Client, p *types.Paper) {
+	if steps := provenanceSteps(client); steps != nil {
+		p.Provenance = steps
+	}
+}
+
+// markSaved marks client's latest request, the final hop of a completed
+// download, as the response saved to the PDF file.
+func markSaved(client *http.Client) {
+	r, ok := client.Transport.(*provenanceRecorder)
+	if !ok {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n := len(r.steps); n > 0 {
+		r.steps[n-1].Saved = true
+	}
+}
+
+// redactURL returns u with the values of redactedParams removed.
+func redactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	q := u.Query()
+	changed := false
+	for _, name := range redactedParams {
+		if q.Has(name) {
+			q.Set(name, "REDACTED")
+			changed = true
+		}
+	}
+	if !changed {
+		return u.String()
+	}
+	clean := *u
+	clean.RawQuery = q.Encode()
+	return clean.String()
+}
@@ -0,0 +1,106 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestAcquireProvenance(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/openalex/"):
+			fmt.Fprint(w, `{"best_oa_location": null}`)
+		case r.URL.Path == "/doi/10.1234/moved":
+			http.Redirect(w, r, "/files/moved.pdf", http.StatusFound)
+		case r.URL.Path == "/files/moved.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			fmt.Fprint(w, fakePDF(r.URL.Path))
+		case strings.HasPrefix(r.URL.Path, "/works/"):
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, sampleCrossRefJSON)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	restore := overrideBaseURLs(ts.URL)
+	defer restore()
+
+	dir := t.TempDir()
+	cfg := testConfig(dir)
+	var out strings.Builder
+	result := AcquireBatch(ts.Client(), []string{"10.1234/moved", "10.1234/missing"}, cfg, &out)
+	if result.Downloaded != 1 || result.Failed != 1 {
+		t.Fatalf("result = %+v\n%s", result, out.String())
+	}
+
+	// The OpenAlex lookup, the redirect, the saved PDF, and the Crossref
+	// metadata request, in order.
+	paper, err := LoadPaper(dir, "10.1234-moved")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range paper.Provenance {
+		u, _ := url.Parse(s.URL)
+		step := fmt.Sprintf("%s %s %d", s.Method, u.Path, s.Status)
+		if s.Saved {
+			step += " saved"
+		}
+		got = append(got, step)
+		if s.Time.IsZero() {
+			t.Errorf("step %q has no time", step)
+		}
+	}
+	want := []string{
+		"GET /openalex/https://doi.org/10.1234/moved 200",
+		"GET /doi/10.1234/moved 302",
+		"GET /files/moved.pdf 200 saved",
+		"GET /works/10.1234/moved 200",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("provenance:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if !strings.Contains(paper.Provenance[0].URL, "mailto=REDACTED") {
+		t.Errorf("contact address not redacted: %s", paper.Provenance[0].URL)
+	}
+
+	// A failure writes no metadata, so the manifest keeps its requests.
+	manifest, err := LoadManifest(ManifestPath(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed := manifest.find("10.1234/missing")
+	if failed == nil || failed.Status != StatusFailed {
+		t.Fatalf("manifest item = %+v", failed)
+	}
+	if n := len(failed.Provenance); n == 0 || failed.Provenance[n-1].Status != http.StatusNotFound {
+		t.Errorf("failed item provenance = %+v, want the 404 download last", failed.Provenance)
+	}
+	if done := manifest.find("10.1234/moved"); done == nil || done.Provenance != nil {
+		t.Errorf("completed item should not keep provenance: %+v", done)
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"https://api.unpaywall.org/v2/10.1/x?email=me%40example.org", "https://api.unpaywall.org/v2/10.1/x?email=REDACTED"},
+		{"https://example.com/paper.pdf?download=1", "https://example.com/paper.pdf?download=1"},
+		{"https://example.com/paper.pdf", "https://example.com/paper.pdf"},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := redactURL(u); got != tt.want {
+			t.Errorf("redactURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
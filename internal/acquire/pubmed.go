@@ -0,0 +1,83 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// NCBI endpoints. Declared as vars so tests can substitute an httptest
+// server.
+var (
+	// ncbiIDConvBase is the PMC ID converter, which maps PMIDs, PMCIDs,
+	// and DOIs to each other.
+	ncbiIDConvBase = "https://www.ncbi.nlm.nih.gov/pmc/utils/idconv/v1.0/"
+
+	// pmcPDFBase renders the PDF of a PubMed Central open-access article.
+	pmcPDFBase = "https://europepmc.org/backend/ptpmcrender.fcgi?blobtype=pdf&accid="
+)
+
+// pmcRecord is one record of the ID converter response.
+type pmcRecord struct {
+	PMID   idString `json:"pmid"`
+	PMCID  string   `json:"pmcid"`
+	DOI    string   `json:"doi"`
+	Status string   `json:"status"`
+	ErrMsg string   `json:"errmsg"`
+}
+
+type idConvResponse struct {
+	Status  string      `json:"status"`
+	Records []pmcRecord `json:"records"`
+}
+
+// idString decodes an ID the converter may write as a number or a string.
+type idString string
+
+func (s *idString) UnmarshalJSON(data []byte) error {
+	*s = idString(strings.Trim(string(data), `"`))
+	if *s == "null" {
+		*s = ""
+	}
+	return nil
+}
+
+// lookupPMC maps a PMID or PMCID to its PMID, PMCID, and DOI with the NCBI
+// ID converter. A PMID without a PubMed Central copy has no PMCID.
+func lookupPMC(client *http.Client, id string, cfg types.AcquisitionConfig) (pmcRecord, error) {
+	q := url.Values{"ids": {id}, "format": {"json"}, "tool": {"research-engine"}}
+	req, err := http.NewRequest(http.MethodGet, ncbiIDConvBase+"?"+q.Encode(), nil)
+	if err != nil {
+		return pmcRecord{}, fmt.Errorf("creating ID converter request: %w", err)
+	}
+	req.Header.Set("User-Agent", cfg.UserAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return pmcRecord{}, fmt.Errorf("NCBI ID converter request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return pmcRecord{}, &HTTPStatusError{StatusCode: resp.StatusCode, URL: ncbiIDConvBase}
+	}
+
+	var out idConvResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return pmcRecord{}, fmt.Errorf("parsing ID converter response: %w", err)
+	}
+	if len(out.Records) == 0 {
+		return pmcRecord{}, fmt.Errorf("ID converter returned no record for %s", id)
+	}
+	rec := out.Records[0]
+	if rec.Status == "error" {
+		return pmcRecord{}, fmt.Errorf("ID converter: %s: %s", id, rec.ErrMsg)
+	}
+	rec.DOI = NormalizeDOI(rec.DOI)
+	return rec, nil
+}
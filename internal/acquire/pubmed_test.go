@@ -0,0 +1,125 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newPMCTestServer serves the NCBI ID converter from records, keyed by the
+// queried ID, and PMC PDFs under /pmc-pdf/.
+func newPMCTestServer(t *testing.T, records map[string]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/idconv/":
+			if r.URL.Query().Get("format") != "json" {
+				t.Errorf("format = %q, want json", r.URL.Query().Get("format"))
+			}
+			rec, ok := records[r.URL.Query().Get("ids")]
+			if !ok {
+				rec = `{"status":"error","errmsg":"invalid article id"}`
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"status":"ok","records":[%s]}`, rec)
+		case strings.HasPrefix(r.URL.Path, "/pmc-pdf/"):
+			w.Header().Set("Content-Type", "application/pdf")
+			fmt.Fprint(w, fakePDF(r.URL.Path))
+		case strings.HasPrefix(r.URL.Path, "/doi/"):
+			w.Header().Set("Content-Type", "application/pdf")
+			fmt.Fprint(w, fakePDF(r.URL.Path))
+		case strings.HasPrefix(r.URL.Path, "/openalex/"):
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"best_oa_location": null}`)
+		case strings.HasPrefix(r.URL.Path, "/works/"):
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, sampleCrossRefJSON)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func overridePMCBaseURLs(tsURL string) func() {
+	restore := overrideBaseURLs(tsURL)
+	origIDConv, origPDF := ncbiIDConvBase, pmcPDFBase
+	ncbiIDConvBase = tsURL + "/idconv/"
+	pmcPDFBase = tsURL + "/pmc-pdf/"
+	return func() {
+		restore()
+		ncbiIDConvBase, pmcPDFBase = origIDConv, origPDF
+	}
+}
+
+func TestLookupPMC(t *testing.T) {
+	ts := newPMCTestServer(t, map[string]string{
+		"23193287": `{"pmcid":"PMC3531190","pmid":23193287,"doi":"10.1093/nar/gks1195"}`,
+	})
+	defer ts.Close()
+	defer overridePMCBaseURLs(ts.URL)()
+
+	rec, err := lookupPMC(ts.Client(), "23193287", testConfig(t.TempDir()))
+	if err != nil {
+		t.Fatalf("lookupPMC: %v", err)
+	}
+	if rec.PMID != "23193287" || rec.PMCID != "PMC3531190" || rec.DOI != "10.1093/nar/gks1195" {
+		t.Errorf("record = %+v", rec)
+	}
+
+	if _, err := lookupPMC(ts.Client(), "99999999", testConfig(t.TempDir())); err == nil {
+		t.Error("expected error for an unknown ID")
+	}
+}
+
+func TestAcquirePaperPMID(t *testing.T) {
+	ts := newPMCTestServer(t, map[string]string{
+		"23193287":   `{"pmcid":"PMC3531190","pmid":"23193287","doi":"10.1093/nar/gks1195"}`,
+		"11111111":   `{"pmid":"11111111","doi":"10.1234/closed"}`,
+		"22222222":   `{"pmid":"22222222"}`,
+		"PMC3531190": `{"pmcid":"PMC3531190","pmid":"23193287","doi":"10.1093/nar/gks1195"}`,
+	})
+	defer ts.Close()
+	defer overridePMCBaseURLs(ts.URL)()
+
+	cfg := testConfig(t.TempDir())
+	var buf bytes.Buffer
+
+	p, _, err := AcquirePaper(ts.Client(), "PMID:23193287", cfg, &buf)
+	if err != nil {
+		t.Fatalf("AcquirePaper(PMID): %v", err)
+	}
+	if p.ID != "pmid-23193287" || p.Source != "pmc" || p.PMCID != "PMC3531190" || p.DOI != "10.1093/nar/gks1195" {
+		t.Errorf("paper = %+v", p)
+	}
+	if p.SourceURL != ts.URL+"/pmc-pdf/PMC3531190" {
+		t.Errorf("SourceURL = %q", p.SourceURL)
+	}
+
+	// The PMCID of the same article downloads the same PDF and is linked
+	// to the paper acquired by PMID.
+	p, skipped, err := AcquirePaper(ts.Client(), "PMC3531190", cfg, &buf)
+	if err != nil {
+		t.Fatalf("AcquirePaper(PMCID): %v", err)
+	}
+	if !skipped || p.ID != "pmid-23193287" {
+		t.Errorf("skipped = %v, paper = %+v", skipped, p)
+	}
+
+	// A PMID without a PubMed Central copy falls back to its DOI.
+	p, _, err = AcquirePaper(ts.Client(), "PMID:11111111", cfg, &buf)
+	if err != nil {
+		t.Fatalf("AcquirePaper(PMID without PMC): %v", err)
+	}
+	if p.SourceURL != ts.URL+"/doi/10.1234/closed" || p.PMCID != "" {
+		t.Errorf("paper = %+v", p)
+	}
+
+	if _, _, err := AcquirePaper(ts.Client(), "PMID:22222222", cfg, &buf); err == nil || !strings.Contains(err.Error(), "no PubMed Central copy") {
+		t.Errorf("err = %v, want no PubMed Central copy", err)
+	}
+}
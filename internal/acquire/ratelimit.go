@@ -0,0 +1,37 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"time"
+
+	"github.com/pdiddy/research-engine/internal/httputil"
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// RateLimits returns the built-in per-host request budget for the
+// metadata APIs acquisition calls (R5.1).
+func RateLimits(cfg types.AcquisitionConfig) map[string]httputil.HostLimit {
+	limits := map[string]httputil.HostLimit{
+		"export.arxiv.org":        httputil.Every(3 * time.Second),
+		"api.openalex.org":        {Rate: 10, Burst: 10},
+		"api.crossref.org":        {Rate: 10, Burst: 10},
+		"api.unpaywall.org":       {Rate: 10, Burst: 10},
+		"search.patentsview.org":  {Rate: 45.0 / 60, Burst: 1},
+		"www.ncbi.nlm.nih.gov":    {Rate: 3, Burst: 1},
+		"api.zotero.org":          {Rate: 5, Burst: 5},
+		"ops.epo.org":             {Rate: 1, Burst: 5},
+		"worldwide.espacenet.com": httputil.Every(2 * time.Second),
+	}
+	return limits
+}
+
+// NewRateLimiter returns the limiter shared by every download in an
+// acquisition run. Its host budgets are the process-wide ones, so a
+// search composed with acquisition in one process spends from the same
+// arXiv and OpenAlex buckets; cfg.RateLimits overrides any host. Hosts
+// without a budget, such as publisher sites and arxiv.org PDFs, are
+// limited to one request per cfg.DownloadDelay.
+func NewRateLimiter(cfg types.AcquisitionConfig) *httputil.RateLimiter {
+	return httputil.SharedRateLimiter(httputil.Every(cfg.DownloadDelay), RateLimits(cfg), httputil.PerSecond(cfg.RateLimits))
+}
@@ -0,0 +1,162 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// RecheckSummary holds counts from an open-access re-check of the papers
+// recorded as paywalled.
+type RecheckSummary struct {
+	Checked int
+	// Available counts papers that now have an open-access PDF.
+	Available  int
+	Downloaded int
+	// StillPaywalled counts papers with no open-access copy yet.
+	StillPaywalled int
+	// NoDOI counts paywalled records without a DOI to look up.
+	NoDOI  int
+	Failed int
+}
+
+// RecheckOA asks OpenAlex, then Unpaywall when cfg.Email is set, for an
+// open-access copy of every paper recorded as paywalled, since embargoes
+// lapse, and downloads the copies found (R2.12). A downloaded paper's
+// record loses its paywalled status and names the open-access source; a
+// paper still without a copy, or whose copy fails to download, keeps its
+// record unchanged. With dryRun set, the copies are listed and nothing is
+// downloaded. A download past the papers directory quota stops the
+// re-check (R2.13).
+func RecheckOA(client *http.Client, cfg types.AcquisitionConfig, dryRun bool, w io.Writer) (RecheckSummary, error) {
+	var summary RecheckSummary
+	metaDir := filepath.Join(cfg.PapersDir, metadataDir)
+	entries, err := os.ReadDir(metaDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return summary, nil
+		}
+		return summary, fmt.Errorf("reading %s: %w", metaDir, err)
+	}
+	if cfg.Email == "" {
+		fmt.Fprintln(w, "note: no contact email configured; checking OpenAlex only (set --email to also ask Unpaywall)")
+	}
+
+	for _, entry := range entries {
+		slug, ok := strings.CutSuffix(entry.Name(), ".yaml")
+		if entry.IsDir() || !ok {
+			continue
+		}
+		metaPath := filepath.Join(metaDir, entry.Name())
+		p, err := readMetadata(metaPath)
+		if err != nil || p.Status != types.AcquisitionPaywalled {
+			continue
+		}
+		summary.Checked++
+		if p.DOI == "" {
+			fmt.Fprintf(w, "no doi:  %s (cannot look up an open-access copy)\n", slug)
+			summary.NoDOI++
+			continue
+		}
+
+		traced := withProvenance(client)
+		pdfURL, source := findOACopy(traced, p.DOI, cfg, w)
+		if pdfURL == "" {
+			fmt.Fprintf(w, "paywalled: %s (no open-access copy yet)\n", slug)
+			summary.StillPaywalled++
+			continue
+		}
+		summary.Available++
+		if dryRun {
+			fmt.Fprintf(w, "available: %s (%s: %s)\n", slug, source, pdfURL)
+			continue
+		}
+
+		if err := downloadOACopy(traced, p, pdfURL, source, metaPath, cfg, w); err != nil {
+			if quotaExceeded(err) {
+				return summary, fmt.Errorf("downloading %s: %w", slug, err)
+			}
+			fmt.Fprintf(w, "failed:  %s (%v)\n", slug, err)
+			summary.Failed++
+			continue
+		}
+		summary.Downloaded++
+	}
+
+	fmt.Fprintf(w, "\nRe-check summary: %d paywalled, %d now open access, %d downloaded, %d still paywalled, %d without DOI, %d failed\n",
+		summary.Checked, summary.Available, summary.Downloaded, summary.StillPaywalled, summary.NoDOI, summary.Failed)
+	return summary, nil
+}
+
+// findOACopy returns the open-access PDF URL of a DOI and the service that
+// named it, preferring OpenAlex to Unpaywall, or "" when neither has one.
+// Lookup failures are reported as warnings.
+func findOACopy(client *http.Client, doi string, cfg types.AcquisitionConfig, w io.Writer) (pdfURL, source string) {
+	if oaURL, err := resolveOpenAlex(client, doi, cfg); err != nil {
+		fmt.Fprintf(w, "  warning: OpenAlex lookup failed for %s: %v\n", doi, err)
+	} else if oaURL != "" {
+		return oaURL, "openalex"
+	}
+	if cfg.Email == "" {
+		return "", ""
+	}
+	upURL, err := lookupUnpaywall(client, doi, cfg)
+	if err != nil {
+		fmt.Fprintf(w, "  warning: Unpaywall lookup failed for %s: %v\n", doi, err)
+		return "", ""
+	}
+	if upURL != "" {
+		return upURL, "unpaywall"
+	}
+	return "", ""
+}
+
+// downloadOACopy downloads the open-access PDF of a paywalled paper and
+// rewrites its record as an acquired paper, linking it to an existing
+// paper with the same PDF (R2.8). Its provenance becomes the requests
+// client has recorded, lookups included (R3.10).
+func downloadOACopy(client *http.Client, p *types.Paper, pdfURL, source, metaPath string, cfg types.AcquisitionConfig, w io.Writer) error {
+	rawPath := filepath.Join(cfg.PapersDir, rawDir)
+	if err := os.MkdirAll(rawPath, 0o755); err != nil {
+		return fmt.Errorf("creating directory %s: %w", rawPath, err)
+	}
+	pdfPath := filepath.Join(rawPath, p.ID+".pdf")
+	if err := downloadFile(client, pdfURL, pdfPath, cfg, true); err != nil {
+		return err
+	}
+	sum, err := fileSHA256(pdfPath)
+	if err != nil {
+		return err
+	}
+
+	p.Status = ""
+	p.PDFPath = pdfPath
+	p.SourceURL = pdfURL
+	p.Source = source
+	p.SHA256 = sum
+	stampProvenance(client, p)
+	if info, err := os.Stat(pdfPath); err == nil {
+		dup, err := findDuplicate(cfg.PapersDir, p.ID, sum, info.Size())
+		if err != nil {
+			fmt.Fprintf(w, "  warning: duplicate check failed: %v\n", err)
+		} else if dup != "" {
+			if _, err := linkDuplicate(cfg.PapersDir, p, dup); err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "linked:  %s (same PDF as %s)\n", p.ID, dup)
+			return nil
+		}
+	}
+	if err := writeMetadata(p, metaPath); err != nil {
+		return fmt.Errorf("writing metadata for %s: %w", p.ID, err)
+	}
+	fmt.Fprintf(w, "downloaded: %s (open access via %s)\n", p.ID, source)
+	return nil
+}
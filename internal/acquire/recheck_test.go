@@ -0,0 +1,123 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestRecheckOA(t *testing.T) {
+	// OpenAlex has an open-access copy of 10.1234/open, Unpaywall one of
+	// 10.1234/unpaywall, and neither one of 10.1234/closed.
+	var tsURL string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/openalex/https://doi.org/10.1234/open"):
+			fmt.Fprintf(w, `{"best_oa_location": {"pdf_url": "%s/oa/open.pdf"}}`, tsURL)
+		case strings.HasPrefix(r.URL.Path, "/openalex/"):
+			fmt.Fprint(w, `{"best_oa_location": null}`)
+		case r.URL.Path == "/unpaywall/10.1234/unpaywall":
+			if r.URL.Query().Get("email") != "me@example.org" {
+				http.Error(w, "email required", http.StatusUnprocessableEntity)
+				return
+			}
+			fmt.Fprintf(w, `{"best_oa_location": {"url_for_pdf": "%s/oa/unpaywall.pdf"}}`, tsURL)
+		case strings.HasPrefix(r.URL.Path, "/unpaywall/"):
+			fmt.Fprint(w, `{"best_oa_location": null}`)
+		case strings.HasPrefix(r.URL.Path, "/oa/"):
+			w.Header().Set("Content-Type", "application/pdf")
+			fmt.Fprint(w, fakePDF(r.URL.Path))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	tsURL = ts.URL
+	origOA, origUP := openAlexAPIBase, unpaywallAPIBase
+	openAlexAPIBase, unpaywallAPIBase = ts.URL+"/openalex/", ts.URL+"/unpaywall/"
+	defer func() { openAlexAPIBase, unpaywallAPIBase = origOA, origUP }()
+
+	dir := t.TempDir()
+	metaDir := filepath.Join(dir, metadataDir)
+	if err := os.MkdirAll(metaDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []*types.Paper{
+		{ID: "10.1234-open", DOI: "10.1234/open", Title: "Open Now", Status: types.AcquisitionPaywalled, SourceURL: "https://publisher.example/open"},
+		{ID: "10.1234-unpaywall", DOI: "10.1234/unpaywall", Status: types.AcquisitionPaywalled},
+		{ID: "10.1234-closed", DOI: "10.1234/closed", Status: types.AcquisitionPaywalled},
+		{ID: "example.com-paper", Status: types.AcquisitionPaywalled},
+		{ID: "10.1234-metadata", DOI: "10.1234/open", Status: types.AcquisitionMetadataOnly},
+	} {
+		if err := writeMetadata(p, filepath.Join(metaDir, p.ID+".yaml")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := testConfig(dir)
+
+	// Without an email only OpenAlex is asked; a dry run downloads nothing.
+	var dry strings.Builder
+	summary, err := RecheckOA(ts.Client(), cfg, true, &dry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (RecheckSummary{Checked: 4, Available: 1, StillPaywalled: 2, NoDOI: 1}); summary != want {
+		t.Fatalf("dry run summary = %+v, want %+v\n%s", summary, want, dry.String())
+	}
+	if _, err := os.Stat(filepath.Join(dir, rawDir, "10.1234-open.pdf")); err == nil {
+		t.Fatal("dry run should not download")
+	}
+
+	cfg.Email = "me@example.org"
+	var out strings.Builder
+	summary, err = RecheckOA(ts.Client(), cfg, false, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (RecheckSummary{Checked: 4, Available: 2, Downloaded: 2, StillPaywalled: 1, NoDOI: 1}); summary != want {
+		t.Fatalf("summary = %+v, want %+v\n%s", summary, want, out.String())
+	}
+
+	open, err := LoadPaper(dir, "10.1234-open")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if open.Status != "" || open.Source != "openalex" || open.SourceURL != ts.URL+"/oa/open.pdf" || open.SHA256 == "" || open.Title != "Open Now" {
+		t.Errorf("open record = %+v", open)
+	}
+	if data, err := os.ReadFile(open.PDFPath); err != nil || string(data) != fakePDF("/oa/open.pdf") {
+		t.Errorf("open PDF not downloaded: %v", err)
+	}
+	unpaywalled, err := LoadPaper(dir, "10.1234-unpaywall")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unpaywalled.Status != "" || unpaywalled.Source != "unpaywall" {
+		t.Errorf("unpaywall record = %+v", unpaywalled)
+	}
+	closed, err := LoadPaper(dir, "10.1234-closed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.Status != types.AcquisitionPaywalled || closed.PDFPath != "" {
+		t.Errorf("closed record = %+v, want it left paywalled", closed)
+	}
+
+	// The downloaded papers are no longer paywalled.
+	summary, err = RecheckOA(ts.Client(), cfg, false, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Checked != 2 || summary.Downloaded != 0 {
+		t.Errorf("second re-check = %+v", summary)
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// RepairSummary holds counts from a repair of the papers directory.
+type RepairSummary struct {
+	Checked int
+	// Corrupt counts PDFs that are empty, not PDFs, or truncated.
+	Corrupt int
+	// Missing counts metadata records whose PDF is gone.
+	Missing         int
+	Reacquired      int
+	MetadataRebuilt int
+	// Unrecoverable counts papers with no identifier to re-acquire from.
+	Unrecoverable int
+	Failed        int
+}
+
+// Repair finds and fixes damaged papers so that the skip-existing check
+// does not keep them broken (R2.9):
+//
+//   - a PDF in raw/ that fails validation (zero bytes, an HTML page, or,
+//     with cfg.VerifyXref, a truncated file) is downloaded again;
+//   - a PDF without a metadata record gets one rebuilt from the metadata
+//     APIs, or a minimal record when its identifier cannot be recovered;
+//   - a metadata record whose PDF is missing is acquired again.
+//
+// Identifiers are recovered from the metadata record, the acquisition
+// manifest, or the slug itself. With dryRun set, nothing is changed. A
+// download past the papers directory quota stops the repair (R2.13).
+func Repair(client *http.Client, cfg types.AcquisitionConfig, dryRun bool, w io.Writer) (RepairSummary, error) {
+	var summary RepairSummary
+	rawPath := filepath.Join(cfg.PapersDir, rawDir)
+	metaDir := filepath.Join(cfg.PapersDir, metadataDir)
+
+	manifest, err := LoadManifest(ManifestPath(cfg.PapersDir))
+	if err != nil {
+		return summary, err
+	}
+
+	entries, err := os.ReadDir(rawPath)
+	if err != nil && !os.IsNotExist(err) {
+		return summary, fmt.Errorf("reading %s: %w", rawPath, err)
+	}
+	forced := cfg
+	forced.Force = true
+	forced.MetadataOnly = false
+	for _, entry := range entries {
+		slug := strings.TrimSuffix(entry.Name(), ".pdf")
+		if entry.IsDir() || slug == entry.Name() {
+			continue
+		}
+		summary.Checked++
+		pdfPath := filepath.Join(rawPath, entry.Name())
+		meta, metaErr := readMetadata(filepath.Join(metaDir, slug+".yaml"))
+		if metaErr != nil {
+			meta = nil
+		}
+
+		reason, err := pdfProblem(pdfPath, meta, cfg.VerifyXref)
+		if err != nil {
+			fmt.Fprintf(w, "failed:  %s: %v\n", slug, err)
+			summary.Failed++
+			continue
+		}
+		if reason != "" {
+			summary.Corrupt++
+			identifier := recoverIdentifier(slug, meta, manifest)
+			if identifier == "" {
+				fmt.Fprintf(w, "corrupt: %s (%s): no identifier to re-acquire from\n", slug, reason)
+				summary.Unrecoverable++
+				continue
+			}
+			if dryRun {
+				fmt.Fprintf(w, "corrupt: %s (%s): would re-acquire %s\n", slug, reason, identifier)
+				continue
+			}
+			fmt.Fprintf(w, "corrupt: %s (%s): re-acquiring %s\n", slug, reason, identifier)
+			if _, _, err := AcquirePaper(client, identifier, forced, w); err != nil {
+				if quotaExceeded(err) {
+					return summary, err
+				}
+				fmt.Fprintf(w, "failed:  %s: %v\n", slug, err)
+				summary.Failed++
+				continue
+			}
+			summary.Reacquired++
+			continue
+		}
+
+		if metaErr == nil {
+			continue
+		}
+		identifier := recoverIdentifier(slug, nil, manifest)
+		if dryRun {
+			fmt.Fprintf(w, "no metadata: %s: would rebuild\n", slug)
+			summary.MetadataRebuilt++
+			continue
+		}
+		if err := rebuildMetadata(client, slug, identifier, pdfPath, cfg, w); err != nil {
+			fmt.Fprintf(w, "failed:  %s: %v\n", slug, err)
+			summary.Failed++
+			continue
+		}
+		if identifier == "" {
+			fmt.Fprintf(w, "no metadata: %s: wrote a minimal record (identifier unknown)\n", slug)
+		} else {
+			fmt.Fprintf(w, "no metadata: %s: rebuilt from %s\n", slug, identifier)
+		}
+		summary.MetadataRebuilt++
+	}
+
+	metaEntries, err := os.ReadDir(metaDir)
+	if err != nil && !os.IsNotExist(err) {
+		return summary, fmt.Errorf("reading %s: %w", metaDir, err)
+	}
+	for _, entry := range metaEntries {
+		slug := strings.TrimSuffix(entry.Name(), ".yaml")
+		if entry.IsDir() || slug == entry.Name() {
+			continue
+		}
+		meta, err := readMetadata(filepath.Join(metaDir, entry.Name()))
+		// Duplicates, metadata-only records, and books without a PDF have
+		// no PDF of their own.
+		if err != nil || meta.DuplicateOf != "" || meta.Status != "" {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(rawPath, slug+".pdf")); err == nil {
+			continue
+		}
+		summary.Missing++
+		identifier := recoverIdentifier(slug, meta, manifest)
+		switch {
+		case identifier == "":
+			fmt.Fprintf(w, "missing: %s: no identifier to re-acquire from\n", slug)
+			summary.Unrecoverable++
+			continue
+		case dryRun:
+			fmt.Fprintf(w, "missing: %s: would re-acquire %s\n", slug, identifier)
+			continue
+		}
+		fmt.Fprintf(w, "missing: %s: re-acquiring %s\n", slug, identifier)
+		if _, _, err := AcquirePaper(client, identifier, forced, w); err != nil {
+			if quotaExceeded(err) {
+				return summary, err
+			}
+			fmt.Fprintf(w, "failed:  %s: %v\n", slug, err)
+			summary.Failed++
+			continue
+		}
+		summary.Reacquired++
+	}
+
+	fmt.Fprintf(w, "\nchecked: %d, corrupt: %d, missing: %d, re-acquired: %d, metadata rebuilt: %d, unrecoverable: %d, failed: %d\n",
+		summary.Checked, summary.Corrupt, summary.Missing, summary.Reacquired, summary.MetadataRebuilt, summary.Unrecoverable, summary.Failed)
+	return summary, nil
+}
+
+// pdfProblem returns why the file at pdfPath is not a usable PDF, or "".
+// Patents saved from the Google Patents HTML fallback are not PDFs by
+// design and pass.
+func pdfProblem(pdfPath string, meta *types.Paper, checkXref bool) (string, error) {
+	if meta != nil && strings.HasPrefix(meta.SourceURL, googlePatentsHTMLBase) {
+		return "", nil
+	}
+	return validatePDF(pdfPath, checkXref)
+}
+
+// recoverIdentifier returns an identifier that acquires to slug: from the
+// metadata record, then the acquisition manifest, then the slug itself
+// (arXiv IDs, patents, PMCIDs, and pmid- and isbn- slugs). It returns ""
+// when none does, as for DOIs known only by their slug.
+func recoverIdentifier(slug string, meta *types.Paper, manifest *Manifest) string {
+	var candidates []string
+	if meta != nil {
+		candidates = append(candidates, meta.ArxivID, meta.DOI, meta.PMCID)
+		if meta.PMID != "" {
+			candidates = append(candidates, "PMID:"+meta.PMID)
+		}
+		candidates = append(candidates, meta.ISBN, meta.SourceURL)
+	}
+	if manifest != nil {
+		for _, item := range manifest.Items {
+			candidates = append(candidates, item.ID)
+		}
+	}
+	candidates = append(candidates, slug)
+	if rest, ok := strings.CutPrefix(slug, "pmid-"); ok {
+		candidates = append(candidates, "PMID:"+rest)
+	}
+	if rest, ok := strings.CutPrefix(slug, "isbn-"); ok {
+		candidates = append(candidates, rest)
+	}
+
+	for _, c := range candidates {
+		if c == "" {
+			continue
+		}
+		if idType, normalized := Classify(c); idType != TypeUnknown && Slug(idType, normalized) == slug {
+			return c
+		}
+	}
+	return ""
+}
+
+// rebuildMetadata writes the metadata record of an existing PDF. With an
+// identifier the record is filled from the metadata APIs; without one it
+// holds the paper ID, PDF path, and checksum.
+func rebuildMetadata(client *http.Client, slug, identifier, pdfPath string, cfg types.AcquisitionConfig, w io.Writer) error {
+	sum, err := fileSHA256(pdfPath)
+	if err != nil {
+		return err
+	}
+	p := &types.Paper{
+		ID:               slug,
+		PDFPath:          pdfPath,
+		SHA256:           sum,
+		ConversionStatus: types.ConversionNone,
+	}
+	if _, err := os.Stat(filepath.Join(cfg.PapersDir, markdownDir, slug+".md")); err == nil {
+		p.ConversionStatus = types.ConversionDone
+	}
+	if identifier != "" {
+		idType, normalized := Classify(identifier)
+		p.Source = idType.String()
+		p.SourceURL = PDFURL(idType, normalized)
+		switch idType {
+		case TypeArxiv:
+			p.ArxivID = StripArxivVersion(normalized)
+		case TypeDOI, TypeChapter:
+			p.DOI = normalized
+		case TypePMID:
+			p.PMID = normalized
+		case TypePMCID:
+			p.PMCID = normalized
+		case TypeISBN:
+			p.ISBN = normalized
+		}
+		fetchMetadata(client, idType, normalized, p, cfg, w)
+	}
+	if err := os.MkdirAll(filepath.Join(cfg.PapersDir, metadataDir), 0o755); err != nil {
+		return fmt.Errorf("creating metadata directory: %w", err)
+	}
+	return writeMetadata(p, filepath.Join(cfg.PapersDir, metadataDir, slug+".yaml"))
+}
@@ -0,0 +1,131 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestRecoverIdentifier(t *testing.T) {
+	manifest := &Manifest{Items: []ManifestItem{{ID: "10.1145/ABC.123", Status: StatusDone}}}
+	tests := []struct {
+		name string
+		slug string
+		meta *types.Paper
+		want string
+	}{
+		{"arxiv slug", "2301.07041", nil, "2301.07041"},
+		{"pmid slug", "pmid-12345", nil, "PMID:12345"},
+		{"pmcid slug", "PMC123456", nil, "PMC123456"},
+		{"doi from metadata", "10.1000-xyz", &types.Paper{DOI: "10.1000/xyz"}, "10.1000/xyz"},
+		{"doi from manifest", "10.1145-abc.123", nil, "10.1145/ABC.123"},
+		{"doi slug alone", "10.9999-lost", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recoverIdentifier(tt.slug, tt.meta, manifest); got != tt.want {
+				t.Errorf("recoverIdentifier(%q) = %q, want %q", tt.slug, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepair(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+	restore := overrideBaseURLs(ts.URL)
+	defer restore()
+
+	dir := t.TempDir()
+	cfg := testConfig(dir)
+	rawPath := filepath.Join(dir, rawDir)
+	metaDir := filepath.Join(dir, metadataDir)
+	for _, d := range []string{rawPath, metaDir} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeMeta := func(p *types.Paper) {
+		t.Helper()
+		data, err := yaml.Marshal(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		write(filepath.Join(metaDir, p.ID+".yaml"), string(data))
+	}
+
+	// A zero-byte download, an HTML page saved as a PDF, a valid PDF with
+	// no metadata, a record whose PDF is gone, and a healthy paper.
+	write(filepath.Join(rawPath, "2301.07041.pdf"), "")
+	writeMeta(&types.Paper{ID: "2301.07041", ArxivID: "2301.07041"})
+	write(filepath.Join(rawPath, "10.9999-lost.pdf"), "<html>Sign in</html>")
+	write(filepath.Join(rawPath, "2302.00001.pdf"), fakePDF("/pdf/2302.00001"))
+	writeMeta(&types.Paper{ID: "2303.00002", ArxivID: "2303.00002", PDFPath: filepath.Join(rawPath, "2303.00002.pdf")})
+	write(filepath.Join(rawPath, "2304.00003.pdf"), fakePDF("/pdf/2304.00003"))
+	writeMeta(&types.Paper{ID: "2304.00003", ArxivID: "2304.00003"})
+	writeMeta(&types.Paper{ID: "isbn-9780262035613", Status: types.AcquisitionMetadataOnly})
+
+	var dry strings.Builder
+	summary, err := Repair(ts.Client(), cfg, true, &dry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Corrupt != 2 || summary.Missing != 1 || summary.MetadataRebuilt != 1 || summary.Reacquired != 0 {
+		t.Fatalf("dry run summary = %+v\n%s", summary, dry.String())
+	}
+	if info, err := os.Stat(filepath.Join(rawPath, "2301.07041.pdf")); err != nil || info.Size() != 0 {
+		t.Fatal("dry run should not change files")
+	}
+
+	var out strings.Builder
+	summary, err = Repair(ts.Client(), cfg, false, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := RepairSummary{Checked: 4, Corrupt: 2, Missing: 1, Reacquired: 2, MetadataRebuilt: 1, Unrecoverable: 1}
+	if summary != want {
+		t.Fatalf("summary = %+v, want %+v\n%s", summary, want, out.String())
+	}
+
+	for _, id := range []string{"2301.07041", "2303.00002"} {
+		data, err := os.ReadFile(filepath.Join(rawPath, id+".pdf"))
+		if err != nil {
+			t.Fatalf("%s: %v", id, err)
+		}
+		if string(data) != fakePDF("/pdf/"+id) {
+			t.Errorf("%s was not re-downloaded", id)
+		}
+	}
+	rebuilt, err := readMetadata(filepath.Join(metaDir, "2302.00001.yaml"))
+	if err != nil {
+		t.Fatalf("metadata not rebuilt: %v", err)
+	}
+	if rebuilt.Title != "Test Paper Title" || rebuilt.SHA256 == "" {
+		t.Errorf("rebuilt metadata = %+v, want arXiv title and checksum", rebuilt)
+	}
+	if !strings.Contains(out.String(), "10.9999-lost") {
+		t.Errorf("output should report the unrecoverable paper:\n%s", out.String())
+	}
+
+	var again strings.Builder
+	summary, err = Repair(ts.Client(), cfg, false, &again)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Reacquired != 0 || summary.MetadataRebuilt != 0 || summary.Missing != 0 {
+		t.Errorf("second repair should find nothing new to fix, got %+v", summary)
+	}
+}
@@ -20,6 +20,10 @@ const (
 	TypeDOI
 	TypeURL
 	TypePatent
+	TypePMID
+	TypePMCID
+	TypeISBN
+	TypeChapter
 )
 
 func (t IdentifierType) String() string {
@@ -32,11 +36,24 @@ func (t IdentifierType) String() string {
 		return "url"
 	case TypePatent:
 		return "patent"
+	case TypePMID:
+		return "pmid"
+	case TypePMCID:
+		return "pmcid"
+	case TypeISBN:
+		return "isbn"
+	case TypeChapter:
+		return "chapter"
 	default:
 		return "unknown"
 	}
 }
 
+// MarshalText encodes the type by name, as in JSON output.
+func (t IdentifierType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
 // Base URLs for identifier resolution. Declared as vars so tests can
 // substitute httptest servers.
 var (
@@ -50,6 +67,12 @@ var (
 // arxivPattern matches arXiv IDs: "2301.07041", "arXiv:2301.07041", "2301.07041v2".
 var arxivPattern = regexp.MustCompile(`^(?:arXiv:)?(\d{4}\.\d{4,5}(?:v\d+)?)$`)
 
+// arxivOldPattern matches pre-2007 arXiv IDs: "hep-th/9901001",
+// "math.GT/0309136", "arXiv:cond-mat/0102536v1". It captures the archive
+// and the number; the optional subject class (".GT") is not part of the
+// canonical ID.
+var arxivOldPattern = regexp.MustCompile(`^(?:arXiv:)?([a-z]+(?:-[a-z]+)?)(?:\.[A-Z]{2})?/(\d{7}(?:v\d+)?)$`)
+
 // doiPattern matches DOIs: "10.1145/1234567.1234568".
 var doiPattern = regexp.MustCompile(`^10\.\d{4,9}/[^\s]+$`)
 
@@ -57,17 +80,69 @@ var doiPattern = regexp.MustCompile(`^10\.\d{4,9}/[^\s]+$`)
 // "US20230012345A1". Captures the full number including optional kind code.
 var patentPattern = regexp.MustCompile(`^US(\d{6,11}[A-Z]\d{0,2})$|^US(\d{6,11})$`)
 
+// pmidPattern matches PubMed IDs, which need a prefix because bare digits
+// are ambiguous: "PMID:12345678", "pmid 12345678".
+var pmidPattern = regexp.MustCompile(`^(?i:pmid):?\s*(\d{1,9})$`)
+
+// pmcidPattern matches PubMed Central IDs: "PMC1234567", "PMCID: PMC1234567".
+var pmcidPattern = regexp.MustCompile(`^(?i:(?:pmcid:?\s*)?pmc)(\d{1,9})$`)
+
+// doiPrefixes are the forms a DOI is commonly written with. NormalizeDOI
+// strips them case-insensitively.
+var doiPrefixes = []string{
+	"https://doi.org/",
+	"http://doi.org/",
+	"https://dx.doi.org/",
+	"http://dx.doi.org/",
+	"doi.org/",
+	"doi:",
+}
+
+// NormalizeDOI returns the canonical form of a DOI: surrounding whitespace
+// and any resolver URL or "doi:" prefix removed, lowercased. DOIs are
+// case-insensitive, so "10.1145/ABC" and "10.1145/abc" name the same work.
+// Inputs that are not DOIs are returned unchanged.
+func NormalizeDOI(s string) string {
+	doi := strings.TrimSpace(s)
+	lower := strings.ToLower(doi)
+	for _, prefix := range doiPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			doi = strings.TrimSpace(doi[len(prefix):])
+			break
+		}
+	}
+	doi = strings.ToLower(doi)
+	if !doiPattern.MatchString(doi) {
+		return s
+	}
+	return doi
+}
+
 // Classify determines the identifier type and returns the normalized form.
-// For arXiv, it strips the optional "arXiv:" prefix.
+// For arXiv, it strips the optional "arXiv:" prefix and, for pre-2007 IDs,
+// the subject class ("math.GT/0309136" becomes "math/0309136"). DOIs are normalized
+// by NormalizeDOI, so resolver URLs and "doi:" prefixes classify as DOIs.
+// PMIDs normalize to their digits and PMCIDs to "PMC" plus digits. EP,
+// WO, JP, CN, and KR publication numbers classify as patents alongside US
+// ones, with separators removed ("WO2023/123456A1" becomes
+// "WO2023123456A1").
+// Springer and Elsevier chapter DOIs classify as chapters, and ISBNs
+// normalize to the ISBN-13 digits.
 func Classify(identifier string) (IdentifierType, string) {
 	identifier = strings.TrimSpace(identifier)
 
 	if m := arxivPattern.FindStringSubmatch(identifier); m != nil {
 		return TypeArxiv, m[1]
 	}
+	if m := arxivOldPattern.FindStringSubmatch(identifier); m != nil {
+		return TypeArxiv, m[1] + "/" + m[2]
+	}
 
-	if doiPattern.MatchString(identifier) {
-		return TypeDOI, identifier
+	if doi := NormalizeDOI(identifier); doiPattern.MatchString(doi) {
+		if chapterISBN(doi) != "" {
+			return TypeChapter, doi
+		}
+		return TypeDOI, doi
 	}
 
 	if m := patentPattern.FindStringSubmatch(identifier); m != nil {
@@ -78,6 +153,20 @@ func Classify(identifier string) (IdentifierType, string) {
 		}
 		return TypePatent, "US" + num
 	}
+	if patent := classifyIntlPatent(identifier); patent != "" {
+		return TypePatent, patent
+	}
+
+	if isbn := classifyISBN(identifier); isbn != "" {
+		return TypeISBN, isbn
+	}
+
+	if m := pmidPattern.FindStringSubmatch(identifier); m != nil {
+		return TypePMID, m[1]
+	}
+	if m := pmcidPattern.FindStringSubmatch(identifier); m != nil {
+		return TypePMCID, "PMC" + m[1]
+	}
 
 	if u, err := url.Parse(identifier); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
 		return TypeURL, identifier
@@ -86,12 +175,68 @@ func Classify(identifier string) (IdentifierType, string) {
 	return TypeUnknown, identifier
 }
 
+// arxivVersionSuffix matches the trailing version of an arXiv ID ("v2").
+var arxivVersionSuffix = regexp.MustCompile(`v\d+$`)
+
+// StripArxivVersion removes the version suffix from an arXiv ID so that
+// every version of a preprint compares equal.
+func StripArxivVersion(id string) string {
+	return arxivVersionSuffix.ReplaceAllString(id, "")
+}
+
+// IdentifierInfo describes how the pipeline interprets one identifier.
+type IdentifierInfo struct {
+	// Input is the identifier as given.
+	Input string `json:"input" yaml:"input"`
+
+	// Type is the classified identifier type ("arxiv", "doi", ...).
+	Type string `json:"type" yaml:"type"`
+
+	// Normalized is the form acquisition uses after Classify.
+	Normalized string `json:"normalized" yaml:"normalized"`
+
+	// Base drops the parts that vary between versions of the same work:
+	// the arXiv version suffix and the patent kind code.
+	Base string `json:"base" yaml:"base"`
+
+	// Slug is the filename stem under papers/raw/ and papers/metadata/.
+	Slug string `json:"slug,omitempty" yaml:"slug,omitempty"`
+
+	// PDFURL is the download URL acquisition tries first.
+	PDFURL string `json:"pdf_url,omitempty" yaml:"pdf_url,omitempty"`
+}
+
+// Describe classifies an identifier and reports its normalized form, slug,
+// and PDF URL exactly as acquisition would derive them.
+func Describe(identifier string) IdentifierInfo {
+	idType, normalized := Classify(identifier)
+	info := IdentifierInfo{
+		Input:      identifier,
+		Type:       idType.String(),
+		Normalized: normalized,
+		Base:       normalized,
+	}
+	switch idType {
+	case TypeUnknown:
+		return info
+	case TypeArxiv:
+		info.Base = StripArxivVersion(normalized)
+	case TypePatent:
+		country, number, _ := patentParts(normalized)
+		info.Base = country + number
+	}
+	info.Slug = Slug(idType, normalized)
+	info.PDFURL = PDFURL(idType, normalized)
+	return info
+}
+
 // Slug returns a filesystem-safe filename stem for the identifier.
+// Pre-2007 arXiv IDs replace the slash with a hyphen ("hep-th-9901001").
 func Slug(idType IdentifierType, normalized string) string {
 	switch idType {
 	case TypeArxiv:
-		return normalized
-	case TypeDOI:
+		return strings.ReplaceAll(normalized, "/", "-")
+	case TypeDOI, TypeChapter:
 		return strings.NewReplacer("/", "-", ":", "-").Replace(normalized)
 	case TypeURL:
 		u, err := url.Parse(normalized)
@@ -103,8 +248,12 @@ func Slug(idType IdentifierType, normalized string) string {
 			return urlHashSlug(normalized)
 		}
 		return base
-	case TypePatent:
+	case TypePatent, TypePMCID:
 		return normalized
+	case TypePMID:
+		return "pmid-" + normalized
+	case TypeISBN:
+		return "isbn-" + normalized
 	default:
 		return "unknown"
 	}
@@ -113,6 +262,11 @@ func Slug(idType IdentifierType, normalized string) string {
 // PDFURL returns the download URL for the identifier. For arXiv, this is
 // the arxiv.org PDF endpoint. For DOI, this is the doi.org resolver
 // (the HTTP client follows redirects). For direct URLs, it returns as-is.
+// US patents come from Google Patents storage and other patents from
+// Espacenet. For PMCIDs it is the Europe PMC rendering of the open-access PDF; PMIDs
+// have no URL until the NCBI ID converter maps them (see lookupPMC).
+// Springer chapters use the publisher's PDF endpoint; other chapters and
+// ISBNs have no URL until OpenAlex reports an open-access copy.
 func PDFURL(idType IdentifierType, normalized string) string {
 	switch idType {
 	case TypeArxiv:
@@ -122,7 +276,14 @@ func PDFURL(idType IdentifierType, normalized string) string {
 	case TypeURL:
 		return normalized
 	case TypePatent:
+		if !isUSPatent(normalized) {
+			return espacenetPDFURL(normalized)
+		}
 		return googlePatentsPDFBase + normalized + ".pdf"
+	case TypePMCID:
+		return pmcPDFBase + normalized
+	case TypeChapter:
+		return chapterPDFURL(normalized)
 	default:
 		return ""
 	}
@@ -32,6 +32,18 @@ func TestClassifyPatent(t *testing.T) {
 
 		// Whitespace handling.
 		{"patent with whitespace", "  US7654321B2  ", TypePatent, "US7654321B2"},
+
+		// Positive: EP, WO, JP, CN, and KR publications.
+		{"EP granted", "EP1234567B1", TypePatent, "EP1234567B1"},
+		{"EP no kind code", "EP1234567", TypePatent, "EP1234567"},
+		{"WO with slash", "WO2023/123456A1", TypePatent, "WO2023123456A1"},
+		{"JP with spaces and hyphen", "JP 2020-123456 A", TypePatent, "JP2020123456A"},
+		{"CN application", "CN112345678A", TypePatent, "CN112345678A"},
+		{"KR application", "KR1020200012345A", TypePatent, "KR1020200012345A"},
+
+		// Negative: other offices and malformed numbers.
+		{"DE not supported", "DE102020123456A1", TypeUnknown, "DE102020123456A1"},
+		{"EP too short", "EP1234", TypeUnknown, "EP1234"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -85,3 +97,75 @@ func TestPDFURLPatent(t *testing.T) {
 		})
 	}
 }
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		input string
+		want  IdentifierInfo
+	}{
+		{"arXiv:2301.07041v2", IdentifierInfo{
+			Input: "arXiv:2301.07041v2", Type: "arxiv", Normalized: "2301.07041v2", Base: "2301.07041",
+			Slug: "2301.07041v2", PDFURL: "https://arxiv.org/pdf/2301.07041v2",
+		}},
+		{"10.1145/1234567.1234568", IdentifierInfo{
+			Input: "10.1145/1234567.1234568", Type: "doi", Normalized: "10.1145/1234567.1234568",
+			Base: "10.1145/1234567.1234568", Slug: "10.1145-1234567.1234568",
+			PDFURL: "https://doi.org/10.1145/1234567.1234568",
+		}},
+		{"US7654321B2", IdentifierInfo{
+			Input: "US7654321B2", Type: "patent", Normalized: "US7654321B2", Base: "US7654321",
+			Slug: "US7654321B2", PDFURL: "https://patentimages.storage.googleapis.com/pdfs/US7654321B2.pdf",
+		}},
+		{"WO2023/123456", IdentifierInfo{
+			Input: "WO2023/123456", Type: "patent", Normalized: "WO2023123456", Base: "WO2023123456",
+			Slug:   "WO2023123456",
+			PDFURL: "https://worldwide.espacenet.com/3.2/rest-services/published-data/images/WO/2023123456/A1/fullimage.pdf",
+		}},
+		{"not-an-id", IdentifierInfo{
+			Input: "not-an-id", Type: "unknown", Normalized: "not-an-id", Base: "not-an-id",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Describe(tt.input); got != tt.want {
+				t.Errorf("Describe(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeDOI(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"10.1145/ABC.123", "10.1145/abc.123"},
+		{"  10.1145/abc.123\n", "10.1145/abc.123"},
+		{"doi:10.1145/ABC", "10.1145/abc"},
+		{"DOI: 10.1145/ABC", "10.1145/abc"},
+		{"https://doi.org/10.1145/ABC", "10.1145/abc"},
+		{"http://dx.doi.org/10.1145/abc", "10.1145/abc"},
+		{"10.1145/has space", "10.1145/has space"},
+		{"2301.07041", "2301.07041"},
+		{"https://example.com/paper.pdf", "https://example.com/paper.pdf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeDOI(tt.input); got != tt.want {
+				t.Errorf("NormalizeDOI(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyDOINormalization(t *testing.T) {
+	for _, input := range []string{"10.1145/ABC", "doi:10.1145/abc", "https://doi.org/10.1145/Abc"} {
+		gotType, gotNorm := Classify(input)
+		if gotType != TypeDOI || gotNorm != "10.1145/abc" {
+			t.Errorf("Classify(%q) = %v, %q; want doi, 10.1145/abc", input, gotType, gotNorm)
+		}
+		if slug := Slug(gotType, gotNorm); slug != "10.1145-abc" {
+			t.Errorf("Slug for %q = %q, want 10.1145-abc", input, slug)
+		}
+	}
+}
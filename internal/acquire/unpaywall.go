@@ -0,0 +1,61 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// unpaywallAPIBase is the Unpaywall v2 DOI endpoint. Declared as a var so
+// tests can substitute an httptest server.
+var unpaywallAPIBase = "https://api.unpaywall.org/v2/"
+
+// unpaywallResponse captures the fields we need from an Unpaywall record.
+type unpaywallResponse struct {
+	BestOALocation *struct {
+		URLForPDF string `json:"url_for_pdf"`
+	} `json:"best_oa_location"`
+}
+
+// lookupUnpaywall returns the best open-access PDF URL Unpaywall knows for
+// a DOI, or "" when there is none. Unpaywall requires a contact address,
+// taken from cfg.Email.
+func lookupUnpaywall(client *http.Client, doi string, cfg types.AcquisitionConfig) (string, error) {
+	if cfg.Email == "" {
+		return "", fmt.Errorf("Unpaywall needs a contact email")
+	}
+	apiURL := unpaywallAPIBase + doi + "?email=" + url.QueryEscape(cfg.Email)
+
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("creating Unpaywall request: %w", err)
+	}
+	req.Header.Set("User-Agent", cfg.UserAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Unpaywall API request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Unpaywall API returned HTTP %d", resp.StatusCode)
+	}
+
+	var up unpaywallResponse
+	if err := json.NewDecoder(resp.Body).Decode(&up); err != nil {
+		return "", fmt.Errorf("parsing Unpaywall response: %w", err)
+	}
+	if up.BestOALocation == nil {
+		return "", nil
+	}
+	return up.BestOALocation.URLForPDF, nil
+}
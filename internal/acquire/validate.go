@@ -0,0 +1,123 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// minPDFSize is the smallest download accepted as a PDF. The smallest
+// well-formed single-page PDFs are a few hundred bytes; anything shorter
+// is an empty or truncated response.
+const minPDFSize = 256
+
+// xrefTailSize is how much of the end of a file is searched for startxref.
+const xrefTailSize = 2048
+
+var (
+	pdfMagic         = []byte("%PDF-")
+	startxrefPattern = regexp.MustCompile(`startxref\s+(\d+)\s+%%EOF`)
+	xrefObjPattern   = regexp.MustCompile(`^\d+\s+\d+\s+obj\b`)
+)
+
+// InvalidPDFError reports a download that is not a usable PDF, such as an
+// HTML error or login page served with status 200.
+type InvalidPDFError struct {
+	URL         string
+	ContentType string
+	Reason      string
+}
+
+func (e *InvalidPDFError) Error() string {
+	ct := e.ContentType
+	if ct == "" {
+		ct = "none"
+	}
+	return fmt.Sprintf("invalid PDF from %s (content-type %s): %s", e.URL, ct, e.Reason)
+}
+
+// PaywallError reports a PDF download answered with an HTML page, such as
+// a publisher's paywall or landing page (R2.11). Nothing is written.
+type PaywallError struct {
+	URL         string
+	ContentType string
+}
+
+func (e *PaywallError) Error() string {
+	ct := e.ContentType
+	if ct == "" {
+		ct = "none"
+	}
+	return fmt.Sprintf("paywalled: %s returned an HTML page (content-type %s) instead of a PDF", e.URL, ct)
+}
+
+// isHTML reports whether a response with this Content-Type header and
+// these first bytes is an HTML page. A body starting with the PDF magic is
+// a PDF whatever its header says.
+func isHTML(contentType string, head []byte) bool {
+	if bytes.HasPrefix(head, pdfMagic) {
+		return false
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil &&
+		(mediaType == "text/html" || mediaType == "application/xhtml+xml") {
+		return true
+	}
+	return strings.HasPrefix(http.DetectContentType(head), "text/html")
+}
+
+// validatePDF checks that the file at path starts with the %PDF- magic
+// bytes and is at least minPDFSize long. With checkXref it also checks
+// that the trailer's startxref offset points at a cross-reference table or
+// stream, which catches truncated downloads. It returns the reason the file
+// is invalid, or "" if it passes.
+func validatePDF(path string, checkXref bool) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	head := make([]byte, len(pdfMagic))
+	if _, err := io.ReadFull(f, head); err != nil || !bytes.Equal(head, pdfMagic) {
+		return "missing %PDF- header", nil
+	}
+	if info.Size() < minPDFSize {
+		return fmt.Sprintf("only %d bytes", info.Size()), nil
+	}
+	if !checkXref {
+		return "", nil
+	}
+
+	tailStart := max(info.Size()-xrefTailSize, 0)
+	tail := make([]byte, info.Size()-tailStart)
+	if _, err := f.ReadAt(tail, tailStart); err != nil && err != io.EOF {
+		return "", err
+	}
+	matches := startxrefPattern.FindAllSubmatch(tail, -1)
+	if len(matches) == 0 {
+		return "no startxref trailer (truncated?)", nil
+	}
+	offset, err := strconv.ParseInt(string(matches[len(matches)-1][1]), 10, 64)
+	if err != nil || offset <= 0 || offset >= info.Size() {
+		return "startxref offset out of range", nil
+	}
+	at := make([]byte, 32)
+	n, _ := f.ReadAt(at, offset)
+	at = at[:n]
+	if !bytes.HasPrefix(at, []byte("xref")) && !xrefObjPattern.Match(at) {
+		return "startxref does not point at a cross-reference table", nil
+	}
+	return "", nil
+}
@@ -0,0 +1,150 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// minimalPDF builds a one-page PDF with a correct cross-reference table.
+// tag is written as a comment so different tags give different files.
+func minimalPDF(tag string) string {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << >> >>",
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%%PDF-1.4\n%% %s\n", tag)
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.String()
+}
+
+func TestValidatePDF(t *testing.T) {
+	valid := minimalPDF("test")
+	tests := []struct {
+		name      string
+		content   string
+		checkXref bool
+		want      string
+	}{
+		{"valid", valid, true, ""},
+		{"html error page", "<!DOCTYPE html><html><body>Access denied</body></html>" + strings.Repeat(" ", 300), false, "missing %PDF- header"},
+		{"too small", "%PDF-1.4\n%%EOF\n", false, "only 15 bytes"},
+		{"truncated without xref check", valid[:len(valid)-40], false, ""},
+		{"truncated", valid[:len(valid)-40], true, "no startxref trailer (truncated?)"},
+		{"bad offset", regexp.MustCompile(`startxref\n\d+`).ReplaceAllString(valid, "startxref\n3"), true, "startxref does not point at a cross-reference table"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "f.pdf")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := validatePDF(path, tt.checkXref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("validatePDF = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAcquirePaperRejectsInvalidPDF(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		fmt.Fprint(w, "GIF89a"+strings.Repeat("x", 300))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	_, _, err := AcquirePaper(ts.Client(), ts.URL+"/paper.pdf", testConfig(dir), os.Stderr)
+	var invalid *InvalidPDFError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("error = %v, want InvalidPDFError", err)
+	}
+	if !strings.Contains(err.Error(), "content-type application/octet-stream") {
+		t.Errorf("error %q should name the content type", err)
+	}
+	if IsTransient(err) {
+		t.Error("an invalid PDF should not be retried by --resume")
+	}
+	entries, _ := os.ReadDir(filepath.Join(dir, rawDir))
+	if len(entries) != 0 {
+		t.Errorf("invalid download left files behind: %v", entries)
+	}
+}
+
+func TestAcquirePaperRecordsPaywall(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// No Content-Type: the page is recognised by its markup.
+		w.Header()["Content-Type"] = nil
+		fmt.Fprint(w, "<!DOCTYPE html><html><body>Purchase this article</body></html>"+strings.Repeat(" ", 300))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	paper, skipped, err := AcquirePaper(ts.Client(), ts.URL+"/paper.pdf", testConfig(dir), &buf)
+	if err != nil || skipped {
+		t.Fatalf("AcquirePaper = %v, skipped %v\n%s", err, skipped, buf.String())
+	}
+	if paper.Status != types.AcquisitionPaywalled || paper.PDFPath != "" || paper.SourceURL != ts.URL+"/paper.pdf" {
+		t.Errorf("paper = %+v", paper)
+	}
+	entries, _ := os.ReadDir(filepath.Join(dir, rawDir))
+	if len(entries) != 0 {
+		t.Errorf("paywall page was written: %v", entries)
+	}
+	saved, err := LoadPaper(dir, "paper")
+	if err != nil || saved.Status != types.AcquisitionPaywalled {
+		t.Fatalf("saved record = %+v, %v", saved, err)
+	}
+
+	result := AcquireBatch(ts.Client(), []string{ts.URL + "/paper.pdf"}, testConfig(dir), &buf)
+	if result.Paywalled != 1 || result.Failed != 0 || result.Total() != 1 {
+		t.Errorf("re-check = %+v", result)
+	}
+}
+
+func TestIsHTML(t *testing.T) {
+	tests := []struct {
+		contentType, body string
+		want              bool
+	}{
+		{"text/html; charset=utf-8", "<html>", true},
+		{"application/xhtml+xml", "<?xml", true},
+		{"", "  <!doctype html><html>", true},
+		{"text/html", "%PDF-1.7", false},
+		{"application/pdf", "%PDF-1.4", false},
+		{"application/octet-stream", "GIF89a", false},
+	}
+	for _, tt := range tests {
+		if got := isHTML(tt.contentType, []byte(tt.body)); got != tt.want {
+			t.Errorf("isHTML(%q, %q) = %v, want %v", tt.contentType, tt.body, got, tt.want)
+		}
+	}
+}
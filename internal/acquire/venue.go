@@ -0,0 +1,47 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"strings"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// venueType classifies a venue from the source type OpenAlex reports or
+// the work type Crossref reports (R3.9). Proceedings whose name mentions a
+// workshop are workshops.
+func venueType(name, kind string) types.VenueType {
+	switch strings.ToLower(kind) {
+	case "journal", "journal-article":
+		return types.VenueJournal
+	case "conference", "proceedings", "proceedings-article":
+		if strings.Contains(strings.ToLower(name), "workshop") {
+			return types.VenueWorkshop
+		}
+		return types.VenueConference
+	case "repository", "posted-content":
+		return types.VenuePreprint
+	case "ebook platform", "book series", "book", "book-chapter", "monograph", "edited-book", "reference-book":
+		return types.VenueBook
+	default:
+		return types.VenueOther
+	}
+}
+
+// venue returns the container a Crossref work was published in, or nil
+// when Crossref names none.
+func (cw crossrefWork) venue() *types.Venue {
+	var name string
+	if len(cw.ContainerTitle) > 0 {
+		name = strings.TrimSpace(cw.ContainerTitle[0])
+	}
+	if name == "" {
+		return nil
+	}
+	v := &types.Venue{Name: name, Type: venueType(name, cw.Type)}
+	if len(cw.ISSN) > 0 {
+		v.ISSN = cw.ISSN[0]
+	}
+	return v
+}
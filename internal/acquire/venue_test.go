@@ -0,0 +1,57 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestVenueType(t *testing.T) {
+	tests := []struct {
+		name, kind string
+		want       types.VenueType
+	}{
+		{"Nature", "journal", types.VenueJournal},
+		{"Journal of Machine Learning Research", "journal-article", types.VenueJournal},
+		{"Neural Information Processing Systems", "conference", types.VenueConference},
+		{"Proceedings of the Workshop on Systems for ML", "proceedings-article", types.VenueWorkshop},
+		{"arXiv (Cornell University)", "repository", types.VenuePreprint},
+		{"bioRxiv", "posted-content", types.VenuePreprint},
+		{"Lecture Notes in Computer Science", "book series", types.VenueBook},
+		{"Some Report Series", "report", types.VenueOther},
+	}
+	for _, tt := range tests {
+		if got := venueType(tt.name, tt.kind); got != tt.want {
+			t.Errorf("venueType(%q, %q) = %q, want %q", tt.name, tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestOpenAlexAndCrossrefVenue(t *testing.T) {
+	var oa openAlexResponse
+	if err := json.Unmarshal([]byte(`{"primary_location": {"source":
+		{"display_name": "Journal of Machine Learning Research", "type": "journal", "issn_l": "1532-4435"}}}`), &oa); err != nil {
+		t.Fatal(err)
+	}
+	want := types.Venue{Name: "Journal of Machine Learning Research", Type: types.VenueJournal, ISSN: "1532-4435"}
+	if got := oa.venue(); got == nil || *got != want {
+		t.Errorf("OpenAlex venue = %+v, want %+v", got, want)
+	}
+	if got := (openAlexResponse{}).venue(); got != nil {
+		t.Errorf("venue without a primary location = %+v, want nil", got)
+	}
+
+	var cw crossrefWork
+	if err := json.Unmarshal([]byte(`{"type": "proceedings-article",
+		"container-title": ["Proceedings of the 40th International Conference on Machine Learning"],
+		"ISSN": ["2640-3498"]}`), &cw); err != nil {
+		t.Fatal(err)
+	}
+	want = types.Venue{Name: "Proceedings of the 40th International Conference on Machine Learning", Type: types.VenueConference, ISSN: "2640-3498"}
+	if got := cw.venue(); got == nil || *got != want {
+		t.Errorf("Crossref venue = %+v, want %+v", got, want)
+	}
+}
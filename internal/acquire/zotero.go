@@ -0,0 +1,429 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// zoteroAPIBase is the Zotero Web API. Declared as a var so tests can
+// substitute an httptest server.
+var zoteroAPIBase = "https://api.zotero.org"
+
+// zoteroPageSize is the largest page the Zotero API returns.
+const zoteroPageSize = 100
+
+// ZoteroLibrary identifies a Zotero library and the API key that reads it.
+type ZoteroLibrary struct {
+	// Path is the library's API path: "users/<id>" or "groups/<id>".
+	Path string
+
+	// APIKey is a Zotero API key with read access to the library.
+	APIKey string
+}
+
+// ParseZoteroLibrary returns the API path of a library given as
+// "users/<id>", "groups/<id>", or a bare user ID.
+func ParseZoteroLibrary(s string) (string, error) {
+	s = strings.Trim(strings.TrimSpace(s), "/")
+	kind, id, ok := strings.Cut(s, "/")
+	if !ok {
+		kind, id = "users", s
+	}
+	if _, err := strconv.ParseUint(id, 10, 64); err != nil || (kind != "users" && kind != "groups") {
+		return "", fmt.Errorf("invalid Zotero library %q: use users/<id>, groups/<id>, or a user ID", s)
+	}
+	return kind + "/" + id, nil
+}
+
+// zoteroItem is one item of a Zotero API response. Attachments are items
+// too, with ParentItem set.
+type zoteroItem struct {
+	Key  string         `json:"key"`
+	Data zoteroItemData `json:"data"`
+	Meta struct {
+		ParsedDate string `json:"parsedDate"`
+	} `json:"meta"`
+}
+
+type zoteroItemData struct {
+	ItemType         string          `json:"itemType"`
+	Title            string          `json:"title"`
+	Creators         []zoteroCreator `json:"creators"`
+	AbstractNote     string          `json:"abstractNote"`
+	DOI              string          `json:"DOI"`
+	ISBN             string          `json:"ISBN"`
+	ISSN             string          `json:"ISSN"`
+	URL              string          `json:"url"`
+	Extra            string          `json:"extra"`
+	ArchiveID        string          `json:"archiveID"`
+	PublicationTitle string          `json:"publicationTitle"`
+	ProceedingsTitle string          `json:"proceedingsTitle"`
+	BookTitle        string          `json:"bookTitle"`
+	Repository       string          `json:"repository"`
+	ContentType      string          `json:"contentType"`
+	LinkMode         string          `json:"linkMode"`
+	ParentItem       string          `json:"parentItem"`
+}
+
+type zoteroCreator struct {
+	CreatorType string `json:"creatorType"`
+	FirstName   string `json:"firstName"`
+	LastName    string `json:"lastName"`
+	Name        string `json:"name"`
+}
+
+type zoteroCollection struct {
+	Key  string `json:"key"`
+	Data struct {
+		Name string `json:"name"`
+	} `json:"data"`
+}
+
+// zoteroArxivPattern finds an arXiv ID in an item's URL, archive ID, or
+// extra field ("arXiv: 2301.07041").
+var zoteroArxivPattern = regexp.MustCompile(`(?i)(?:arxiv\.org/(?:abs|pdf)/|arxiv:\s*)(\d{4}\.\d{4,5}|[a-z-]+/\d{7})`)
+
+// ImportZotero acquires every top-level item of a Zotero collection,
+// named by key or by name (R1.9). Each item's PDF attachment is downloaded
+// through the Zotero API into papers/raw and its Zotero metadata written to
+// papers/metadata under the paper ID its DOI, arXiv ID, or ISBN gives, or
+// "zotero-<key>" when it has none. The record keeps the Zotero item key.
+// Items without a PDF attachment are recorded as metadata only. Notes are
+// skipped.
+func ImportZotero(client *http.Client, lib ZoteroLibrary, collection string, cfg types.AcquisitionConfig, w io.Writer) (BatchResult, error) {
+	key, name, err := findZoteroCollection(client, lib, collection, cfg)
+	if err != nil {
+		return BatchResult{}, err
+	}
+	var items []zoteroItem
+	if err := zoteroGetAll(client, lib, "/collections/"+key+"/items/top", cfg, &items); err != nil {
+		return BatchResult{}, fmt.Errorf("listing Zotero collection %q: %w", name, err)
+	}
+	fmt.Fprintf(w, "zotero:  collection %q (%s), %d items\n", name, key, len(items))
+
+	var result BatchResult
+	for i, item := range items {
+		if item.Data.ItemType == "note" {
+			continue
+		}
+		paper, wasSkipped, err := importZoteroItem(client, lib, item, cfg, w)
+		// The papers directory quota stops the import (R2.13).
+		if quotaExceeded(err) {
+			var rest []string
+			for _, r := range items[i:] {
+				if r.Data.ItemType != "note" {
+					rest = append(rest, "zotero:"+r.Key)
+				}
+			}
+			fmt.Fprintf(w, "stopped: zotero %s (%v); %d items not imported\n", item.Key, err, len(rest))
+			result.stop(err, rest)
+			break
+		}
+		if err != nil {
+			fmt.Fprintf(w, "failed:  zotero %s (%v)\n", item.Key, err)
+		}
+		result.add("zotero:"+item.Key, item.slug(), paper, wasSkipped, err, cfg)
+	}
+	fmt.Fprintf(w, "\nZotero summary: %d downloaded, %d skipped, %d failed (total: %d)\n",
+		result.Downloaded, result.Skipped, result.Failed, result.Total())
+	if result.MetadataOnly > 0 {
+		fmt.Fprintf(w, "%d items had no PDF attachment; metadata recorded with status %s\n", result.MetadataOnly, types.AcquisitionMetadataOnly)
+	}
+	return result, nil
+}
+
+// importZoteroItem acquires one Zotero item. An item whose PDF already
+// exists is skipped unless cfg.Force is set; its record gains the Zotero
+// key if it lacks one.
+func importZoteroItem(client *http.Client, lib ZoteroLibrary, item zoteroItem, cfg types.AcquisitionConfig, w io.Writer) (*types.Paper, bool, error) {
+	slug := item.slug()
+	pdfPath := filepath.Join(cfg.PapersDir, rawDir, slug+".pdf")
+	metaPath := filepath.Join(cfg.PapersDir, metadataDir, slug+".yaml")
+
+	if _, err := os.Stat(pdfPath); err == nil && !cfg.Force {
+		p, readErr := readMetadata(metaPath)
+		if readErr != nil {
+			p = &types.Paper{ID: slug, PDFPath: pdfPath}
+		}
+		if p.ZoteroKey == "" {
+			p.ZoteroKey = item.Key
+			if err := writeMetadata(p, metaPath); err != nil {
+				return nil, false, fmt.Errorf("writing metadata for %s: %w", slug, err)
+			}
+		}
+		fmt.Fprintf(w, "skipped: %s (already exists)\n", slug)
+		return p, true, nil
+	}
+
+	for _, dir := range []string{
+		filepath.Join(cfg.PapersDir, rawDir),
+		filepath.Join(cfg.PapersDir, metadataDir),
+	} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, false, fmt.Errorf("creating directory %s: %w", dir, err)
+		}
+	}
+
+	p := item.paper(slug, pdfPath)
+	var attachment *zoteroItem
+	if item.isPDF() {
+		// A standalone PDF is its own attachment.
+		attachment = &item
+	} else if !cfg.MetadataOnly {
+		var children []zoteroItem
+		if err := zoteroGetAll(client, lib, "/items/"+item.Key+"/children", cfg, &children); err != nil {
+			return nil, false, fmt.Errorf("listing attachments: %w", err)
+		}
+		for i := range children {
+			if children[i].isPDF() {
+				attachment = &children[i]
+				break
+			}
+		}
+	}
+
+	if attachment == nil || cfg.MetadataOnly {
+		p.PDFPath = ""
+		p.Status = types.AcquisitionMetadataOnly
+		if err := writeMetadata(p, metaPath); err != nil {
+			return nil, false, fmt.Errorf("writing metadata for %s: %w", slug, err)
+		}
+		if cfg.MetadataOnly {
+			fmt.Fprintf(w, "metadata: %s (PDF not downloaded)\n", slug)
+		} else {
+			fmt.Fprintf(w, "metadata: %s (no PDF attachment)\n", slug)
+		}
+		return p, false, nil
+	}
+
+	fmt.Fprintf(w, "downloading: %s (zotero %s)\n", slug, item.Key)
+	// The file endpoint redirects to Zotero's storage; the key goes in a
+	// header so it does not appear in error messages.
+	dcfg := cfg
+	dcfg.Headers = maps.Clone(cfg.Headers)
+	if dcfg.Headers == nil {
+		dcfg.Headers = make(map[string]string)
+	}
+	dcfg.Headers["Zotero-API-Key"] = lib.APIKey
+	fileURL := zoteroAPIBase + "/" + lib.Path + "/items/" + attachment.Key + "/file"
+	if err := downloadFile(client, fileURL, pdfPath, dcfg, true); err != nil {
+		return nil, false, fmt.Errorf("downloading %s: %w", slug, err)
+	}
+
+	sum, err := fileSHA256(pdfPath)
+	if err != nil {
+		return nil, false, err
+	}
+	p.SHA256 = sum
+	if info, err := os.Stat(pdfPath); err == nil {
+		dup, err := findDuplicate(cfg.PapersDir, slug, sum, info.Size())
+		if err != nil {
+			fmt.Fprintf(w, "  warning: duplicate check failed: %v\n", err)
+		} else if dup != "" {
+			canonical, err := linkDuplicate(cfg.PapersDir, p, dup)
+			if err != nil {
+				return nil, false, err
+			}
+			fmt.Fprintf(w, "linked:  %s (same PDF as %s)\n", slug, dup)
+			return canonical, true, nil
+		}
+	}
+
+	if err := writeMetadata(p, metaPath); err != nil {
+		return nil, false, fmt.Errorf("writing metadata for %s: %w", slug, err)
+	}
+	return p, false, nil
+}
+
+// isPDF reports whether the item is a stored PDF attachment. Linked files
+// live on the owner's disk and cannot be downloaded.
+func (it zoteroItem) isPDF() bool {
+	return it.Data.ItemType == "attachment" &&
+		it.Data.ContentType == "application/pdf" &&
+		(it.Data.LinkMode == "imported_file" || it.Data.LinkMode == "imported_url")
+}
+
+// identifier returns the arXiv ID, DOI, or ISBN the item's fields give,
+// classified as acquire would classify it, or TypeUnknown.
+func (it zoteroItem) identifier() (IdentifierType, string) {
+	d := it.Data
+	if doi := strings.TrimSpace(d.DOI); doi != "" {
+		if idType, normalized := Classify(doi); idType == TypeArxiv || idType == TypeDOI || idType == TypeChapter {
+			return idType, normalized
+		}
+	}
+	for _, field := range []string{d.ArchiveID, d.Extra, d.URL} {
+		if m := zoteroArxivPattern.FindStringSubmatch(field); m != nil {
+			if idType, normalized := Classify(m[1]); idType == TypeArxiv {
+				return idType, normalized
+			}
+		}
+	}
+	if isbn, _, _ := strings.Cut(d.ISBN, " "); isbn != "" {
+		if normalized := normalizeISBN(isbn); normalized != "" {
+			return TypeISBN, normalized
+		}
+	}
+	return TypeUnknown, ""
+}
+
+// slug returns the paper ID the item is stored under.
+func (it zoteroItem) slug() string {
+	if idType, normalized := it.identifier(); idType != TypeUnknown {
+		return Slug(idType, normalized)
+	}
+	return "zotero-" + strings.ToLower(it.Key)
+}
+
+// paper builds the metadata record for the item from its Zotero fields.
+func (it zoteroItem) paper(slug, pdfPath string) *types.Paper {
+	d := it.Data
+	p := &types.Paper{
+		ID:               slug,
+		SourceURL:        d.URL,
+		PDFPath:          pdfPath,
+		Title:            d.Title,
+		Abstract:         d.AbstractNote,
+		Source:           "zotero",
+		ConversionStatus: types.ConversionNone,
+		ZoteroKey:        it.Key,
+	}
+	for _, c := range d.Creators {
+		if c.CreatorType != "" && c.CreatorType != "author" {
+			continue
+		}
+		name := strings.TrimSpace(c.Name)
+		if name == "" {
+			name = strings.TrimSpace(c.FirstName + " " + c.LastName)
+		}
+		if name != "" {
+			p.Authors = append(p.Authors, name)
+		}
+	}
+	for _, layout := range []string{"2006-01-02", "2006-01", "2006"} {
+		if t, err := time.Parse(layout, it.Meta.ParsedDate); err == nil {
+			p.Date = t
+			break
+		}
+	}
+	switch idType, normalized := it.identifier(); idType {
+	case TypeArxiv:
+		p.ArxivID = StripArxivVersion(normalized)
+	case TypeDOI, TypeChapter:
+		p.DOI = normalized
+	case TypeISBN:
+		p.ISBN = normalized
+	}
+	p.Venue = it.venue()
+	return p
+}
+
+// venue returns the journal, proceedings, book, or repository the item
+// appeared in, or nil when Zotero names none (R3.9).
+func (it zoteroItem) venue() *types.Venue {
+	d := it.Data
+	var name string
+	var kind types.VenueType
+	switch d.ItemType {
+	case "journalArticle":
+		name, kind = d.PublicationTitle, types.VenueJournal
+	case "conferencePaper":
+		name, kind = d.ProceedingsTitle, venueType(d.ProceedingsTitle, "proceedings")
+	case "bookSection":
+		name, kind = d.BookTitle, types.VenueBook
+	case "preprint":
+		name, kind = d.Repository, types.VenuePreprint
+	default:
+		name, kind = d.PublicationTitle, types.VenueOther
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+	return &types.Venue{Name: name, Type: kind, ISSN: strings.TrimSpace(d.ISSN)}
+}
+
+// findZoteroCollection returns the key and name of the collection whose
+// key or name (case-insensitive) is collection.
+func findZoteroCollection(client *http.Client, lib ZoteroLibrary, collection string, cfg types.AcquisitionConfig) (key, name string, err error) {
+	var all []zoteroCollection
+	if err := zoteroGetAll(client, lib, "/collections", cfg, &all); err != nil {
+		return "", "", fmt.Errorf("listing Zotero collections: %w", err)
+	}
+	var matches []zoteroCollection
+	for _, c := range all {
+		if c.Key == collection {
+			return c.Key, c.Data.Name, nil
+		}
+		if strings.EqualFold(c.Data.Name, collection) {
+			matches = append(matches, c)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", "", fmt.Errorf("no Zotero collection named %q in %s", collection, lib.Path)
+	case 1:
+		return matches[0].Key, matches[0].Data.Name, nil
+	default:
+		keys := make([]string, len(matches))
+		for i, c := range matches {
+			keys[i] = c.Key
+		}
+		return "", "", fmt.Errorf("%d Zotero collections are named %q; give its key instead (%s)",
+			len(matches), collection, strings.Join(keys, ", "))
+	}
+}
+
+// zoteroGetAll appends every page of a Zotero API list endpoint to out.
+func zoteroGetAll[T any](client *http.Client, lib ZoteroLibrary, path string, cfg types.AcquisitionConfig, out *[]T) error {
+	for start := 0; ; start += zoteroPageSize {
+		q := url.Values{
+			"format": {"json"},
+			"limit":  {strconv.Itoa(zoteroPageSize)},
+			"start":  {strconv.Itoa(start)},
+		}
+		apiURL := zoteroAPIBase + "/" + lib.Path + path + "?" + q.Encode()
+		req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+		if err != nil {
+			return fmt.Errorf("creating Zotero request: %w", err)
+		}
+		req.Header.Set("User-Agent", cfg.UserAgent)
+		req.Header.Set("Zotero-API-Version", "3")
+		req.Header.Set("Zotero-API-Key", lib.APIKey)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("Zotero API request: %w", err)
+		}
+		var page []T
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return &HTTPStatusError{StatusCode: resp.StatusCode, URL: zoteroAPIBase + "/" + lib.Path + path}
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("parsing Zotero response: %w", err)
+		}
+		*out = append(*out, page...)
+
+		total, err := strconv.Atoi(resp.Header.Get("Total-Results"))
+		if err != nil || len(page) < zoteroPageSize || start+len(page) >= total {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package acquire
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+const sampleZoteroItems = `[
+  {"key": "AAAA1111", "meta": {"parsedDate": "2023-01-17"}, "data": {
+    "itemType": "journalArticle", "title": "A Journal Paper",
+    "creators": [{"creatorType": "author", "firstName": "Ada", "lastName": "Lovelace"},
+                 {"creatorType": "editor", "firstName": "Ed", "lastName": "Itor"},
+                 {"creatorType": "author", "name": "The Consortium"}],
+    "DOI": "10.1234/Journal.Paper", "publicationTitle": "Journal of Tests", "ISSN": "1234-5678"}},
+  {"key": "BBBB2222", "meta": {"parsedDate": "2023"}, "data": {
+    "itemType": "preprint", "title": "A Preprint", "repository": "arXiv",
+    "url": "https://arxiv.org/abs/2301.07041v2"}},
+  {"key": "CCCC3333", "data": {"itemType": "report", "title": "No PDF Here"}},
+  {"key": "DDDD4444", "data": {"itemType": "note"}}
+]`
+
+// newZoteroServer serves one library with a "Reading List" collection.
+// Attachment files are served only with the API key.
+func newZoteroServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	children := map[string]string{
+		"AAAA1111": `[{"key": "PDF11111", "data": {"itemType": "attachment", "contentType": "application/pdf", "linkMode": "imported_file", "parentItem": "AAAA1111"}}]`,
+		"BBBB2222": `[{"key": "LNK22222", "data": {"itemType": "attachment", "contentType": "application/pdf", "linkMode": "linked_file"}},
+		              {"key": "PDF22222", "data": {"itemType": "attachment", "contentType": "application/pdf", "linkMode": "imported_url"}}]`,
+		"CCCC3333": `[]`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Zotero-API-Key") != "secret" {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		path := strings.TrimPrefix(r.URL.Path, "/users/42")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path == "/collections":
+			w.Header().Set("Total-Results", "2")
+			fmt.Fprint(w, `[{"key": "COLL0001", "data": {"name": "Reading List"}}, {"key": "COLL0002", "data": {"name": "Other"}}]`)
+		case path == "/collections/COLL0001/items/top":
+			w.Header().Set("Total-Results", "4")
+			fmt.Fprint(w, sampleZoteroItems)
+		case strings.HasSuffix(path, "/children"):
+			key := strings.TrimSuffix(strings.TrimPrefix(path, "/items/"), "/children")
+			fmt.Fprint(w, children[key])
+		case strings.HasSuffix(path, "/file"):
+			w.Header().Set("Content-Type", "application/pdf")
+			fmt.Fprint(w, fakePDF(path))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestImportZotero(t *testing.T) {
+	ts := newZoteroServer(t)
+	defer ts.Close()
+	orig := zoteroAPIBase
+	zoteroAPIBase = ts.URL
+	defer func() { zoteroAPIBase = orig }()
+
+	dir := t.TempDir()
+	cfg := testConfig(dir)
+	lib := ZoteroLibrary{Path: "users/42", APIKey: "secret"}
+
+	var buf bytes.Buffer
+	result, err := ImportZotero(ts.Client(), lib, "reading list", cfg, &buf)
+	if err != nil {
+		t.Fatalf("ImportZotero: %v\n%s", err, buf.String())
+	}
+	if result.Downloaded != 2 || result.MetadataOnly != 1 || result.Failed != 0 {
+		t.Fatalf("result = %+v\n%s", result, buf.String())
+	}
+
+	journal, err := LoadPaper(dir, "10.1234-journal.paper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if journal.ZoteroKey != "AAAA1111" || journal.Source != "zotero" || journal.DOI != "10.1234/journal.paper" {
+		t.Errorf("journal record = %+v", journal)
+	}
+	if strings.Join(journal.Authors, "; ") != "Ada Lovelace; The Consortium" {
+		t.Errorf("authors = %v", journal.Authors)
+	}
+	if journal.Date.Year() != 2023 || journal.Date.Day() != 17 {
+		t.Errorf("date = %v", journal.Date)
+	}
+	if want := (types.Venue{Name: "Journal of Tests", Type: types.VenueJournal, ISSN: "1234-5678"}); journal.Venue == nil || *journal.Venue != want {
+		t.Errorf("venue = %+v, want %+v", journal.Venue, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, rawDir, "10.1234-journal.paper.pdf")); err != nil {
+		t.Errorf("journal PDF not written: %v", err)
+	}
+
+	// The arXiv ID comes from the URL, and the linked file is passed over
+	// for the stored one.
+	preprint, err := LoadPaper(dir, "2301.07041")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preprint.ArxivID != "2301.07041" || preprint.ZoteroKey != "BBBB2222" || preprint.Venue.Type != types.VenuePreprint {
+		t.Errorf("preprint record = %+v", preprint)
+	}
+
+	report, err := LoadPaper(dir, "zotero-cccc3333")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Status != types.AcquisitionMetadataOnly || report.PDFPath != "" {
+		t.Errorf("report record = %+v", report)
+	}
+
+	// A second import skips the papers whose PDF exists.
+	buf.Reset()
+	result, err = ImportZotero(ts.Client(), lib, "COLL0001", cfg, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Skipped != 2 || result.Downloaded != 0 {
+		t.Errorf("second import = %+v\n%s", result, buf.String())
+	}
+
+	if _, err := ImportZotero(ts.Client(), lib, "Missing", cfg, &buf); err == nil {
+		t.Error("expected an error for an unknown collection")
+	}
+	lib.APIKey = "wrong"
+	if _, err := ImportZotero(ts.Client(), lib, "Reading List", cfg, &buf); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected HTTP 403 for a bad key, got %v", err)
+	}
+}
+
+func TestParseZoteroLibrary(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{"12345", "users/12345", false},
+		{"users/12345", "users/12345", false},
+		{"/groups/678/", "groups/678", false},
+		{"teams/1", "", true},
+		{"users/abc", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseZoteroLibrary(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseZoteroLibrary(%q) = %q, %v", tt.in, got, err)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+// Package audit keeps a log of the CLI runs that change the corpus: the
+// exact arguments, the config file in effect, and what each run produced,
+// so that how a corpus was built can be reconstructed and a run replayed
+// with the same parameters. The log is a JSON Lines file in the project
+// directory and is never sent anywhere.
+package audit
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pdiddy/research-engine/internal/usage"
+)
+
+// DefaultPath is the audit log location relative to the project directory.
+const DefaultPath = ".research-engine/audit.log"
+
+// Redacted replaces secret flag values in logged arguments.
+const Redacted = "REDACTED"
+
+// APICalls counts the requests a run made to one host.
+type APICalls struct {
+	Host  string `json:"host"`
+	Calls int    `json:"calls"`
+}
+
+// Results summarizes what a run produced.
+type Results struct {
+	// Items is the number of papers or inputs a batch command processed,
+	// of which Skipped were already up to date.
+	Items   int `json:"items,omitempty"`
+	Skipped int `json:"skipped,omitempty"`
+
+	APICalls     []APICalls `json:"api_calls,omitempty"`
+	InputTokens  int64      `json:"input_tokens,omitempty"`
+	OutputTokens int64      `json:"output_tokens,omitempty"`
+
+	// Corpus is the corpus size after the run.
+	Corpus usage.Corpus `json:"corpus"`
+}
+
+// Entry is one logged run.
+type Entry struct {
+	// ID identifies the run for replay, for example "20260302-141503-9f2c".
+	ID      string    `json:"id"`
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`
+	// Args are the command-line arguments after the program name, with
+	// secret flag values replaced by Redacted.
+	Args    []string `json:"args"`
+	Dir     string   `json:"dir"`
+	Version string   `json:"version"`
+	// ConfigFile is the config file in effect, and ConfigHash the SHA-256
+	// of its contents; both are empty when no config file was read.
+	ConfigFile string        `json:"config_file,omitempty"`
+	ConfigHash string        `json:"config_hash,omitempty"`
+	Duration   time.Duration `json:"duration_ns"`
+	OK         bool          `json:"ok"`
+	Error      string        `json:"error,omitempty"`
+	// ReplayOf is the ID of the run this run replayed.
+	ReplayOf string  `json:"replay_of,omitempty"`
+	Results  Results `json:"results"`
+}
+
+// NewID returns a run ID: the start time to the second and four random hex
+// digits.
+func NewID(start time.Time) string {
+	var b [2]byte
+	rand.Read(b[:])
+	return start.UTC().Format("20060102-150405") + "-" + hex.EncodeToString(b[:])
+}
+
+// HashConfig returns the hex SHA-256 of the file at path, or "" when path
+// is empty.
+func HashConfig(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading config %s: %w", path, err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// RedactArgs returns a copy of args with the values of long flags for
+// which secret reports true replaced by Redacted, in both the --name=value
+// and --name value forms.
+func RedactArgs(args []string, secret func(name string) bool) []string {
+	out := make([]string, len(args))
+	copy(out, args)
+	for i := 0; i < len(out); i++ {
+		name, value, hasValue := parseLongFlag(out[i])
+		if name == "" || !secret(name) {
+			continue
+		}
+		if hasValue {
+			if value != "" {
+				out[i] = "--" + name + "=" + Redacted
+			}
+			continue
+		}
+		if i+1 < len(out) {
+			out[i+1] = Redacted
+			i++
+		}
+	}
+	return out
+}
+
+// ReplayArgs returns the arguments to replay e with. Flags whose values
+// were redacted are left out, so that replay falls back to the secrets
+// and config in effect; their names are returned as dropped.
+func ReplayArgs(e Entry) (args, dropped []string) {
+	for i := 0; i < len(e.Args); i++ {
+		name, value, hasValue := parseLongFlag(e.Args[i])
+		switch {
+		case name != "" && hasValue && value == Redacted:
+			dropped = append(dropped, name)
+		case name != "" && !hasValue && i+1 < len(e.Args) && e.Args[i+1] == Redacted:
+			dropped = append(dropped, name)
+			i++
+		default:
+			args = append(args, e.Args[i])
+		}
+	}
+	return args, dropped
+}
+
+// parseLongFlag splits "--name=value" or "--name". It returns an empty
+// name for anything else, including "--" and short flags.
+func parseLongFlag(arg string) (name, value string, hasValue bool) {
+	if !strings.HasPrefix(arg, "--") || arg == "--" {
+		return "", "", false
+	}
+	name, value, hasValue = strings.Cut(arg[2:], "=")
+	return name, value, hasValue
+}
+
+// Append adds e to the log at path, creating the file and its directory.
+func Append(path string, e Entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshaling audit entry: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// Read returns the entries logged at path in file order. A missing log
+// has no entries; malformed lines are skipped.
+func Read(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) == nil && e.ID != "" {
+			entries = append(entries, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// Find returns the entry whose ID is id or, failing that, the only entry
+// whose ID starts with id.
+func Find(entries []Entry, id string) (Entry, error) {
+	var matches []Entry
+	for _, e := range entries {
+		if e.ID == id {
+			return e, nil
+		}
+		if strings.HasPrefix(e.ID, id) {
+			matches = append(matches, e)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Entry{}, fmt.Errorf("no run %q in the audit log", id)
+	case 1:
+		return matches[0], nil
+	default:
+		return Entry{}, fmt.Errorf("run ID %q is ambiguous: %d runs match", id, len(matches))
+	}
+}
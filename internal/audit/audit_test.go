@@ -0,0 +1,79 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func isSecret(name string) bool { return strings.HasSuffix(name, "key") || name == "header" }
+
+func TestRedactAndReplayArgs(t *testing.T) {
+	args := []string{"extract", "--batch", "--api-key", "sk-123", "--model=claude", "--header=Authorization: Bearer x", "--lens-api-key=", "2301.00001"}
+	got := RedactArgs(args, isSecret)
+	want := []string{"extract", "--batch", "--api-key", Redacted, "--model=claude", "--header=" + Redacted, "--lens-api-key=", "2301.00001"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("RedactArgs = %q, want %q", got, want)
+	}
+	if args[3] != "sk-123" {
+		t.Error("RedactArgs modified its input")
+	}
+
+	replay, dropped := ReplayArgs(Entry{Args: got})
+	if strings.Join(replay, " ") != "extract --batch --model=claude --lens-api-key= 2301.00001" {
+		t.Errorf("ReplayArgs = %q", replay)
+	}
+	if strings.Join(dropped, ",") != "api-key,header" {
+		t.Errorf("dropped = %q", dropped)
+	}
+}
+
+func TestAppendReadFind(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".research-engine", "audit.log")
+	start := time.Date(2026, 3, 2, 14, 15, 3, 0, time.UTC)
+	first := Entry{ID: NewID(start), Time: start, Command: "acquire", Args: []string{"acquire", "2301.00001"}, OK: true}
+	second := Entry{ID: "20260302-141503-ffff", Time: start, Command: "convert", Args: []string{"convert", "--batch"}, Results: Results{Items: 3, Skipped: 1}}
+	if !strings.HasPrefix(first.ID, "20260302-141503-") || len(first.ID) != len(second.ID) {
+		t.Fatalf("NewID = %q", first.ID)
+	}
+	for _, e := range []Entry{first, second} {
+		if err := Append(path, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[1].Results.Items != 3 {
+		t.Fatalf("entries = %+v", entries)
+	}
+
+	if e, err := Find(entries, second.ID); err != nil || e.Command != "convert" {
+		t.Errorf("Find exact = %+v, %v", e, err)
+	}
+	if _, err := Find(entries, "20260302"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("Find ambiguous prefix: err = %v", err)
+	}
+	if _, err := Find(entries, "2025"); err == nil {
+		t.Error("Find of a missing run should fail")
+	}
+}
+
+func TestHashConfig(t *testing.T) {
+	if h, err := HashConfig(""); h != "" || err != nil {
+		t.Errorf("HashConfig(\"\") = %q, %v", h, err)
+	}
+	path := filepath.Join(t.TempDir(), "research-engine.yaml")
+	if err := os.WriteFile(path, []byte("usage_log: false\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	h, err := HashConfig(path)
+	if err != nil || len(h) != 64 {
+		t.Errorf("HashConfig = %q, %v", h, err)
+	}
+}
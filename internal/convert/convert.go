@@ -7,13 +7,17 @@
 package convert
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"go.yaml.in/yaml/v3"
+
 	"github.com/pdiddy/research-engine/pkg/types"
 )
 
@@ -22,6 +26,9 @@ const (
 	markdownDir = "markdown"
 	// rawDir is the subdirectory under the papers base for raw PDFs.
 	rawDir = "raw"
+	// metadataDir is the subdirectory under the papers base for paper
+	// metadata written by acquisition.
+	metadataDir = "metadata"
 )
 
 // Converter transforms a PDF file into Markdown text. Different backends
@@ -31,11 +38,13 @@ type Converter interface {
 	Convert(pdfPath string) (string, error)
 }
 
-// BatchResult holds the outcome of a batch conversion run.
+// BatchResult holds the outcome of a batch conversion run. PoorQuality
+// counts the converted papers whose quality level is poor.
 type BatchResult struct {
-	Converted int
-	Skipped   int
-	Failed    int
+	Converted   int
+	Skipped     int
+	Failed      int
+	PoorQuality int
 }
 
 // Total returns the total number of papers processed.
@@ -51,36 +60,94 @@ func (r BatchResult) HasFailures() bool {
 // ConvertPaper converts a single PDF to Markdown, writing the result to the
 // output directory. It returns the status of the conversion. If the Markdown
 // output already exists, it skips conversion and returns ConversionNone.
+// The conversion's quality score goes into the Markdown frontmatter and,
+// with the status, into the paper's metadata record when it has one (R1.4,
+// R4).
 func ConvertPaper(c Converter, paper types.Paper, papersDir string, w io.Writer) types.ConversionStatus {
+	status, _ := convertPaper(c, paper, papersDir, w)
+	return status
+}
+
+// convertPaper is ConvertPaper, also returning the quality of a successful
+// conversion.
+func convertPaper(c Converter, paper types.Paper, papersDir string, w io.Writer) (types.ConversionStatus, *types.ConversionQuality) {
 	outDir := filepath.Join(papersDir, markdownDir)
 	base := strings.TrimSuffix(filepath.Base(paper.PDFPath), filepath.Ext(paper.PDFPath))
 	mdPath := filepath.Join(outDir, base+".md")
 
 	if _, err := os.Stat(mdPath); err == nil {
 		fmt.Fprintf(w, "skipped: %s (already exists)\n", base)
-		return ConversionNone
+		return ConversionNone, nil
 	}
 
-	if err := os.MkdirAll(outDir, 0o755); err != nil {
+	fail := func(err error) (types.ConversionStatus, *types.ConversionQuality) {
 		fmt.Fprintf(w, "failed:  %s (%v)\n", base, err)
-		return types.ConversionFailed
+		recordConversion(papersDir, base, types.ConversionFailed, nil, "")
+		return types.ConversionFailed, nil
+	}
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return fail(err)
 	}
 
 	raw, err := c.Convert(paper.PDFPath)
 	if err != nil {
-		fmt.Fprintf(w, "failed:  %s (%v)\n", base, err)
-		return types.ConversionFailed
+		return fail(err)
 	}
 
-	content := addFrontmatter(paper, raw)
+	quality := ScoreQuality(raw)
+	lang, translated := markdownLanguage(raw)
+	content := addFrontmatter(paper, raw, quality, lang, translated)
 
 	if err := os.WriteFile(mdPath, []byte(content), 0o644); err != nil {
-		fmt.Fprintf(w, "failed:  %s (%v)\n", base, err)
-		return types.ConversionFailed
+		return fail(err)
+	}
+
+	recorded := types.ConversionDone
+	if quality.Level == types.QualityPoor {
+		recorded = types.ConversionPartial
+	}
+	if err := recordConversion(papersDir, base, recorded, &quality, lang); err != nil {
+		fmt.Fprintf(w, "  warning: updating metadata for %s: %v\n", base, err)
 	}
 
-	fmt.Fprintf(w, "converted: %s\n", base)
-	return types.ConversionDone
+	note := ""
+	switch {
+	case translated:
+		note = ", translated from " + LanguageName(lang)
+	case lang != "" && lang != "en":
+		note = ", in " + LanguageName(lang)
+	}
+	fmt.Fprintf(w, "converted: %s (quality %s, %.2f%s)\n", base, quality.Level, quality.Score, note)
+	return types.ConversionDone, &quality
+}
+
+// recordConversion updates the conversion status, quality, and detected
+// language in the paper's metadata record. Papers converted without a
+// metadata record, such as PDFs given by path, are left alone.
+func recordConversion(papersDir, paperID string, status types.ConversionStatus, quality *types.ConversionQuality, lang string) error {
+	path := filepath.Join(papersDir, metadataDir, paperID+".yaml")
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var paper types.Paper
+	if err := yaml.Unmarshal(data, &paper); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	paper.ConversionStatus = status
+	paper.ConversionQuality = quality
+	if lang != "" {
+		paper.Language = lang
+	}
+	out, err := yaml.Marshal(&paper)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, out, 0o644)
 }
 
 // ConvertBatch processes a list of papers through the converter, printing
@@ -88,10 +155,13 @@ func ConvertPaper(c Converter, paper types.Paper, papersDir string, w io.Writer)
 func ConvertBatch(c Converter, papers []types.Paper, papersDir string, w io.Writer) BatchResult {
 	var result BatchResult
 	for _, p := range papers {
-		status := ConvertPaper(c, p, papersDir, w)
+		status, quality := convertPaper(c, p, papersDir, w)
 		switch status {
 		case types.ConversionDone:
 			result.Converted++
+			if quality.Level == types.QualityPoor {
+				result.PoorQuality++
+			}
 		case ConversionNone:
 			result.Skipped++
 		case types.ConversionFailed:
@@ -100,6 +170,9 @@ func ConvertBatch(c Converter, papers []types.Paper, papersDir string, w io.Writ
 	}
 	fmt.Fprintf(w, "\nBatch summary: %d converted, %d skipped, %d failed (total: %d)\n",
 		result.Converted, result.Skipped, result.Failed, result.Total())
+	if result.PoorQuality > 0 {
+		fmt.Fprintf(w, "%d converted paper(s) scored poor quality and are recorded as partial; try another backend or OCR\n", result.PoorQuality)
+	}
 	return result
 }
 
@@ -122,13 +195,23 @@ func ConvertPaths(c Converter, pdfPaths []string, papersDir string, w io.Writer)
 const ConversionNone = types.ConversionNone
 
 // addFrontmatter prepends YAML frontmatter to the converted Markdown content.
-func addFrontmatter(paper types.Paper, body string) string {
+// The language is the detected one, or the one the body was translated
+// from.
+func addFrontmatter(paper types.Paper, body string, quality types.ConversionQuality, lang string, translated bool) string {
 	ts := time.Now().UTC().Format(time.RFC3339)
 	var b strings.Builder
 	b.WriteString("---\n")
 	fmt.Fprintf(&b, "paper_id: %q\n", paper.ID)
 	fmt.Fprintf(&b, "source_pdf: %q\n", paper.PDFPath)
 	fmt.Fprintf(&b, "converted_at: %q\n", ts)
+	fmt.Fprintf(&b, "quality: %s\n", quality.Level)
+	fmt.Fprintf(&b, "quality_score: %.2f\n", quality.Score)
+	if lang != "" {
+		fmt.Fprintf(&b, "language: %s\n", lang)
+	}
+	if translated {
+		b.WriteString("translated: true\n")
+	}
 	b.WriteString("---\n\n")
 	b.WriteString(body)
 	return b.String()
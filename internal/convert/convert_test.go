@@ -133,6 +133,63 @@ func TestConvertPaper_Frontmatter(t *testing.T) {
 	if !strings.Contains(content, "# Paper Title") {
 		t.Error("output should contain the original Markdown body")
 	}
+	if !strings.Contains(content, "quality: poor\nquality_score:") {
+		t.Error("frontmatter should contain the conversion quality")
+	}
+}
+
+func TestConvertPaper_RecordsQuality(t *testing.T) {
+	pdfPath, tmpDir := setupPDF(t)
+	metaPath := filepath.Join(tmpDir, "metadata", "2301.07041.yaml")
+	os.MkdirAll(filepath.Dir(metaPath), 0o755)
+	os.WriteFile(metaPath, []byte("id: \"2301.07041\"\ntitle: Attention\n"), 0o644)
+	paper := types.Paper{ID: "2301.07041", PDFPath: pdfPath}
+
+	var log bytes.Buffer
+	if status := ConvertPaper(&fakeConverter{output: "x1 y2 z3"}, paper, tmpDir, &log); status != types.ConversionDone {
+		t.Fatalf("expected ConversionDone, got %q", status)
+	}
+	if !strings.Contains(log.String(), "converted: 2301.07041 (quality poor, ") {
+		t.Errorf("log = %q", log.String())
+	}
+	data, _ := os.ReadFile(metaPath)
+	meta := string(data)
+	for _, want := range []string{"title: Attention", "conversion_status: partial", "conversion_quality:", "level: poor"} {
+		if !strings.Contains(meta, want) {
+			t.Errorf("metadata missing %q:\n%s", want, meta)
+		}
+	}
+
+	os.RemoveAll(filepath.Join(tmpDir, "markdown"))
+	ConvertPaper(&fakeConverter{err: errors.New("boom")}, paper, tmpDir, &log)
+	data, _ = os.ReadFile(metaPath)
+	if meta := string(data); !strings.Contains(meta, "conversion_status: failed") || strings.Contains(meta, "conversion_quality") {
+		t.Errorf("metadata after failure:\n%s", meta)
+	}
+}
+
+func TestConvertPaper_RecordsLanguage(t *testing.T) {
+	pdfPath, tmpDir := setupPDF(t)
+	metaPath := filepath.Join(tmpDir, "metadata", "2301.07041.yaml")
+	os.MkdirAll(filepath.Dir(metaPath), 0o755)
+	os.WriteFile(metaPath, []byte("id: \"2301.07041\"\n"), 0o644)
+	paper := types.Paper{ID: "2301.07041", PDFPath: pdfPath}
+
+	conv := NewTranslatingConverter(&fakeConverter{output: germanPaper}, &upperTranslator{})
+	var log bytes.Buffer
+	if status := ConvertPaper(conv, paper, tmpDir, &log); status != types.ConversionDone {
+		t.Fatalf("expected ConversionDone, got %q", status)
+	}
+	if !strings.Contains(log.String(), ", translated from German)") {
+		t.Errorf("log = %q", log.String())
+	}
+	data, _ := os.ReadFile(filepath.Join(tmpDir, "markdown", "2301.07041.md"))
+	if !strings.Contains(string(data), "language: de\ntranslated: true\n---") {
+		t.Errorf("frontmatter:\n%s", data)
+	}
+	if data, _ := os.ReadFile(metaPath); !strings.Contains(string(data), "language: de") {
+		t.Errorf("metadata:\n%s", data)
+	}
 }
 
 func TestConvertBatch(t *testing.T) {
@@ -0,0 +1,96 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// wordsPerPage is the length of a synthetic page: about one printed page
+// of a paper.
+const wordsPerPage = 500
+
+// documentExtensions are the input formats convert accepts in papers/raw/,
+// besides PDF (R2.15).
+var documentExtensions = map[string]bool{".html": true, ".htm": true, ".xhtml": true, ".docx": true}
+
+// IsDocument reports whether name has an extension convert accepts: PDF,
+// HTML, or DOCX.
+func IsDocument(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".pdf" || documentExtensions[ext]
+}
+
+// DocumentConverter converts HTML and DOCX full text in Go and hands PDFs
+// to the configured PDF backend. HTML and DOCX have no pages, so the
+// output gets synthetic <!-- page N --> markers: at the page breaks Word
+// recorded, or every wordsPerPage words.
+type DocumentConverter struct {
+	pdf Converter
+}
+
+// NewDocumentConverter returns a converter that accepts HTML and DOCX as
+// well as the PDFs pdf converts.
+func NewDocumentConverter(pdf Converter) *DocumentConverter {
+	return &DocumentConverter{pdf: pdf}
+}
+
+// Convert converts the document at path by its extension.
+func (d *DocumentConverter) Convert(path string) (string, error) {
+	var blocks []docBlock
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm", ".xhtml":
+		blocks, err = htmlBlocks(path)
+	case ".docx":
+		blocks, err = docxBlocks(path)
+	default:
+		return d.pdf.Convert(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	md := paginate(blocks)
+	if md == "" {
+		return "", fmt.Errorf("%w in %s", errNoText, path)
+	}
+	return md, nil
+}
+
+// docBlock is one Markdown block of an HTML or DOCX document. newPage
+// marks a block that starts a page.
+type docBlock struct {
+	text    string
+	newPage bool
+}
+
+// paginate joins blocks into Markdown with page markers. Documents with
+// recorded page breaks are paged at them; others get a new page before
+// the block that would take a page past wordsPerPage words. Renderers
+// drop empty blocks, so no blocks means the document has no text.
+func paginate(blocks []docBlock) string {
+	if len(blocks) == 0 {
+		return ""
+	}
+	explicit := false
+	for _, b := range blocks[1:] {
+		explicit = explicit || b.newPage
+	}
+
+	var sb strings.Builder
+	page, words := 0, 0
+	for _, b := range blocks {
+		n := len(strings.Fields(b.text))
+		if page == 0 || explicit && b.newPage || !explicit && words > 0 && words+n > wordsPerPage {
+			page++
+			words = 0
+			fmt.Fprintf(&sb, "<!-- page %d -->\n\n", page)
+		}
+		words += n
+		sb.WriteString(b.text)
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
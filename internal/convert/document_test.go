@@ -0,0 +1,117 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"archive/zip"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleHTML = `<!DOCTYPE html>
+<html><head><title>Attention Is All You Need</title>
+<script>if (a < b) { render(); }</script></head>
+<body>
+<nav><a href="/">Home</a></nav>
+<article>
+<h2>1 Introduction</h2>
+<p>Recurrent models&nbsp;are <em>sequential</em>,
+so training is slow<br>
+across long sequences.</p>
+<table class="ltx_equation"><tr>
+<td><math display="block" alttext="y = Wx + b"><mi>y</mi></math></td>
+<td><span>(1)</span></td>
+</tr></table>
+<p>Inline <math alttext="x^2"><msup><mi>x</mi><mn>2</mn></msup></math> math.
+<ul><li>Encoder<ul><li>six layers</li></ul></li><li>Decoder</li></ul>
+<table><caption>Table 1: Results.</caption>
+<tr><th>Model</th><th>BLEU</th></tr>
+<tr><td>Transformer</td><td><b>28.4</b></td></tr>
+</table>
+</article>
+<footer>Copyright</footer>
+</body></html>`
+
+func TestHTMLConversion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "paper.html")
+	os.WriteFile(path, []byte(sampleHTML), 0o644)
+
+	md, err := NewDocumentConverter(stubConverter{err: errors.New("not a PDF")}).Convert(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<!-- page 1 -->\n\n" +
+		"# Attention Is All You Need\n\n" +
+		"## 1 Introduction\n\n" +
+		"Recurrent models are *sequential*, so training is slow\nacross long sequences.\n\n" +
+		"<!-- equation 1 -->\n\n$$\ny = Wx + b\n$$\n\n" +
+		"Inline $x^2$ math.\n\n" +
+		"- Encoder\n\n  - six layers\n\n- Decoder\n\n" +
+		"| Model | BLEU |\n| --- | --- |\n| Transformer | **28.4** |\n\n" +
+		"Table 1: Results.\n\n"
+	if md != want {
+		t.Errorf("Markdown =\n%s\nwant\n%s", md, want)
+	}
+}
+
+// docx writes a DOCX package whose body is the given WordprocessingML.
+func docx(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "report.docx")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	w, _ := zw.Create(docxBody)
+	w.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		body + `</w:body></w:document>`))
+	zw.Close()
+	f.Close()
+	return path
+}
+
+func TestDOCXConversion(t *testing.T) {
+	path := docx(t, `<w:p><w:pPr><w:pStyle w:val="Title"/></w:pPr><w:r><w:t>Annual Report</w:t></w:r></w:p>`+
+		`<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Findings</w:t></w:r></w:p>`+
+		`<w:p><w:r><w:t xml:space="preserve">Costs fell </w:t></w:r><w:r><w:t>by half.</w:t></w:r>`+
+		`<w:r><w:br w:type="page"/></w:r></w:p>`+
+		`<w:p><w:pPr><w:numPr><w:ilvl w:val="1"/></w:numPr></w:pPr><w:r><w:t>Nested point</w:t></w:r></w:p>`+
+		`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Year</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Cost</w:t></w:r></w:p></w:tc></w:tr>`+
+		`<w:tr><w:tc><w:p><w:r><w:t>2025</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>4</w:t></w:r></w:p></w:tc></w:tr></w:tbl>`)
+
+	md, err := NewDocumentConverter(nil).Convert(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<!-- page 1 -->\n\n# Annual Report\n\n## Findings\n\nCosts fell by half.\n\n" +
+		"<!-- page 2 -->\n\n  - Nested point\n\n| Year | Cost |\n| --- | --- |\n| 2025 | 4 |\n\n"
+	if md != want {
+		t.Errorf("Markdown =\n%q\nwant\n%q", md, want)
+	}
+
+	empty := docx(t, `<w:p><w:r><w:t> </w:t></w:r></w:p>`)
+	if _, err := NewDocumentConverter(nil).Convert(empty); !errors.Is(err, errNoText) {
+		t.Errorf("empty DOCX: err = %v, want no extractable text", err)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	para := strings.TrimSpace(strings.Repeat("word ", 200))
+	md := paginate([]docBlock{{text: para}, {text: para}, {text: para}, {text: para}})
+	if got := strings.Count(md, "<!-- page "); got != 2 {
+		t.Errorf("got %d pages, want 2:\n%s", got, md)
+	}
+	if !strings.HasPrefix(md, "<!-- page 1 -->\n\n") || !strings.Contains(md, "\n\n<!-- page 2 -->\n\n"+para+"\n\n"+para+"\n\n") {
+		t.Errorf("unexpected pages:\n%s", md)
+	}
+
+	pdf, _ := setupPDF(t)
+	if md, _ := NewDocumentConverter(stubConverter{md: "from PDF"}).Convert(pdf); md != "from PDF" {
+		t.Errorf("PDF went to %q, want the PDF backend", md)
+	}
+}
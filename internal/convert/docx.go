@@ -0,0 +1,195 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// docxBody is the part of a DOCX package holding the document text.
+const docxBody = "word/document.xml"
+
+// docxBlocks reads the DOCX document at path as Markdown blocks.
+func docxBlocks(path string) ([]docBlock, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	for _, f := range zr.File {
+		if f.Name != docxBody {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return renderDOCX(io.LimitReader(rc, maxSourceSize))
+	}
+	return nil, fmt.Errorf("no %s in the DOCX package", docxBody)
+}
+
+// docxRenderer turns WordprocessingML into Markdown blocks: paragraphs
+// styled Title and Heading N become headings, numbered paragraphs list
+// items, and tables pipe tables. Page breaks, whether typed or where
+// Word last laid out a page, start a new page.
+type docxRenderer struct {
+	blocks []docBlock
+	para   strings.Builder
+	style  string
+	list   bool
+	level  int
+	inText bool
+
+	tables int
+	rows   [][]string
+	cells  []string
+	cell   []string
+
+	// pending starts a page at the next block; after starts one at the
+	// block following the current paragraph.
+	pending, after bool
+}
+
+// renderDOCX renders the main document part of a DOCX package.
+func renderDOCX(r io.Reader) ([]docBlock, error) {
+	d := xml.NewDecoder(r)
+	x := &docxRenderer{}
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			x.start(t)
+		case xml.EndElement:
+			x.end(t.Name.Local)
+		case xml.CharData:
+			if x.inText {
+				x.para.Write(t)
+			}
+		}
+	}
+	return x.blocks, nil
+}
+
+// docxAttr returns the value of the attribute with local name key.
+func docxAttr(t xml.StartElement, key string) string {
+	for _, a := range t.Attr {
+		if a.Name.Local == key {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func (x *docxRenderer) start(t xml.StartElement) {
+	switch t.Name.Local {
+	case "p":
+		x.para.Reset()
+		x.style, x.list, x.level = "", false, 0
+	case "pStyle":
+		x.style = docxAttr(t, "val")
+	case "numPr":
+		x.list = true
+	case "ilvl":
+		x.level, _ = strconv.Atoi(docxAttr(t, "val"))
+	case "t":
+		x.inText = true
+	case "tab":
+		x.para.WriteString(" ")
+	case "br":
+		if docxAttr(t, "type") != "page" {
+			x.para.WriteString("\n")
+			break
+		}
+		x.pageBreak()
+	case "lastRenderedPageBreak":
+		x.pageBreak()
+	case "tbl":
+		x.tables++
+		if x.tables == 1 {
+			x.rows = nil
+		}
+	case "tr":
+		x.cells = nil
+	case "tc":
+		x.cell = nil
+	}
+}
+
+func (x *docxRenderer) end(name string) {
+	switch name {
+	case "t":
+		x.inText = false
+	case "p":
+		text := strings.TrimSpace(x.para.String())
+		switch {
+		case x.tables > 0:
+			if text != "" {
+				x.cell = append(x.cell, text)
+			}
+		case text == "":
+		case headingLevel(x.style) > 0:
+			x.add(strings.Repeat("#", headingLevel(x.style)) + " " + collapseSpace(text))
+		case x.list:
+			x.add(strings.Repeat("  ", x.level) + "- " + collapseSpace(text))
+		default:
+			x.add(text)
+		}
+		if x.after {
+			x.pending, x.after = true, false
+		}
+	case "tc":
+		x.cells = append(x.cells, strings.Join(x.cell, " "))
+	case "tr":
+		x.rows = append(x.rows, x.cells)
+	case "tbl":
+		x.tables--
+		if x.tables == 0 && len(x.rows) > 0 {
+			x.add(pipeTable(x.rows))
+		}
+	}
+}
+
+// pageBreak records a page break: before the current paragraph when it
+// has no text yet, otherwise after it.
+func (x *docxRenderer) pageBreak() {
+	if strings.TrimSpace(x.para.String()) == "" {
+		x.pending = true
+	} else {
+		x.after = true
+	}
+}
+
+// add appends a block, starting a page at it if one is pending.
+func (x *docxRenderer) add(text string) {
+	x.blocks = append(x.blocks, docBlock{text: strings.TrimRight(text, "\n"), newPage: x.pending})
+	x.pending = false
+}
+
+// headingLevel maps a paragraph style to a Markdown heading level: Title
+// is 1, Heading N is N+1, and other styles 0.
+func headingLevel(style string) int {
+	s := strings.ToLower(strings.ReplaceAll(style, " ", ""))
+	if s == "title" {
+		return 1
+	}
+	if rest, ok := strings.CutPrefix(s, "heading"); ok {
+		if n, err := strconv.Atoi(rest); err == nil && n > 0 {
+			return min(n+1, 6)
+		}
+	}
+	return 0
+}
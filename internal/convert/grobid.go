@@ -0,0 +1,517 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultGROBIDURL is where a GROBID server listens when run locally, for
+// example with "docker run -p 8070:8070 grobid/grobid".
+const DefaultGROBIDURL = "http://localhost:8070"
+
+// GROBIDConverter converts PDFs by posting them to a GROBID server and
+// rendering the TEI XML it returns as Markdown (R2.10): section headings
+// from GROBID's numbered heads, <!-- page N --> markers from the element
+// coordinates, and a numbered references list from the parsed
+// bibliography.
+type GROBIDConverter struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewGROBIDConverter creates a converter that posts PDFs to the GROBID
+// server at baseURL. It verifies that the server is alive before
+// returning.
+func NewGROBIDConverter(client *http.Client, baseURL string) (*GROBIDConverter, error) {
+	baseURL = strings.TrimRight(baseURL, "/")
+	resp, err := client.Get(baseURL + "/api/isalive")
+	if err != nil {
+		return nil, fmt.Errorf("GROBID server not reachable at %s: %w", baseURL, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64))
+	if resp.StatusCode != http.StatusOK || strings.TrimSpace(string(body)) != "true" {
+		return nil, fmt.Errorf("GROBID server at %s is not alive (HTTP %d)", baseURL, resp.StatusCode)
+	}
+	return &GROBIDConverter{client: client, baseURL: baseURL}, nil
+}
+
+// Convert posts the PDF at pdfPath to GROBID's full-text service and
+// returns the Markdown rendering of the TEI result.
+func (g *GROBIDConverter) Convert(pdfPath string) (string, error) {
+	f, err := os.Open(pdfPath)
+	if err != nil {
+		return "", fmt.Errorf("opening PDF %s: %w", pdfPath, err)
+	}
+	defer f.Close()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("input", filepath.Base(pdfPath))
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return "", fmt.Errorf("reading PDF %s: %w", pdfPath, err)
+	}
+	// Coordinates on heads, paragraphs, and figures give the page
+	// markers; raw citations are the fallback for unparsed references.
+	for _, element := range []string{"head", "p", "figure", "formula", "biblStruct"} {
+		form.WriteField("teiCoordinates", element)
+	}
+	form.WriteField("includeRawCitations", "1")
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, g.baseURL+"/api/processFulltextDocument", &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Accept", "application/xml")
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("converting %s with GROBID: %w", pdfPath, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusServiceUnavailable:
+		return "", fmt.Errorf("converting %s with GROBID: server busy (HTTP 503); retry later", pdfPath)
+	case resp.StatusCode == http.StatusNoContent:
+		return "", fmt.Errorf("GROBID extracted no text from %s", pdfPath)
+	case resp.StatusCode != http.StatusOK:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("converting %s with GROBID: HTTP %d: %s", pdfPath, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	doc, err := parseTEI(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("parsing GROBID output for %s: %w", pdfPath, err)
+	}
+	if doc.child("text").content() == "" {
+		return "", fmt.Errorf("GROBID produced empty output for %s: %w", pdfPath, errNoText)
+	}
+	return renderTEI(doc), nil
+}
+
+// teiNode is an element of a TEI document, or a text node when name is
+// empty. Mixed content keeps its order.
+type teiNode struct {
+	name     string
+	attrs    map[string]string
+	text     string
+	children []*teiNode
+}
+
+// parseTEI reads a TEI document into a tree, dropping namespaces.
+func parseTEI(r io.Reader) (*teiNode, error) {
+	dec := xml.NewDecoder(r)
+	root := &teiNode{}
+	stack := []*teiNode{root}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		top := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &teiNode{name: t.Name.Local, attrs: make(map[string]string, len(t.Attr))}
+			for _, a := range t.Attr {
+				n.attrs[a.Name.Local] = a.Value
+			}
+			top.children = append(top.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			top.children = append(top.children, &teiNode{text: string(t)})
+		}
+	}
+	if root.child("TEI") == nil {
+		return nil, fmt.Errorf("not a TEI document")
+	}
+	return root.child("TEI"), nil
+}
+
+// child returns the first child element named name, or nil.
+func (n *teiNode) child(name string) *teiNode {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// path follows child elements by name.
+func (n *teiNode) path(names ...string) *teiNode {
+	for _, name := range names {
+		n = n.child(name)
+	}
+	return n
+}
+
+// all returns the child elements named name.
+func (n *teiNode) all(name string) []*teiNode {
+	if n == nil {
+		return nil
+	}
+	var out []*teiNode
+	for _, c := range n.children {
+		if c.name == name {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// content returns the text of n and its descendants with whitespace
+// collapsed.
+func (n *teiNode) content() string {
+	if n == nil {
+		return ""
+	}
+	var b strings.Builder
+	var walk func(*teiNode)
+	walk = func(n *teiNode) {
+		if n.name == "" {
+			b.WriteString(n.text)
+			return
+		}
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// page returns the page of n's first coordinate box, or 0 when it has
+// none. GROBID writes coords as "page,x,y,w,h;...".
+func (n *teiNode) page() int {
+	coords := n.attrs["coords"]
+	if coords == "" {
+		return 0
+	}
+	first, _, _ := strings.Cut(coords, ",")
+	p, err := strconv.Atoi(first)
+	if err != nil {
+		return 0
+	}
+	return p
+}
+
+// teiWriter renders TEI elements as Markdown, emitting a page marker
+// whenever an element starts on a later page than the last.
+type teiWriter struct {
+	b    strings.Builder
+	page int
+}
+
+func (w *teiWriter) mark(n *teiNode) {
+	p := n.page()
+	if n.name == "pb" {
+		p, _ = strconv.Atoi(n.attrs["n"])
+	}
+	if p > w.page {
+		w.page = p
+		fmt.Fprintf(&w.b, "<!-- page %d -->\n\n", p)
+	}
+}
+
+func (w *teiWriter) block(n *teiNode, s string) {
+	if s == "" {
+		return
+	}
+	w.mark(n)
+	w.b.WriteString(s)
+	w.b.WriteString("\n\n")
+}
+
+// renderTEI renders a GROBID TEI document as Markdown: the title, the
+// abstract, the body and back-matter sections, and the references.
+func renderTEI(doc *teiNode) string {
+	var w teiWriter
+	w.mark(&teiNode{name: "pb", attrs: map[string]string{"n": "1"}})
+	header := doc.child("teiHeader")
+	if title := header.path("fileDesc", "titleStmt", "title").content(); title != "" {
+		w.b.WriteString("# " + title + "\n\n")
+	}
+	if abstract := header.path("profileDesc", "abstract"); abstract != nil && abstract.content() != "" {
+		w.b.WriteString("## Abstract\n\n")
+		w.divContent(abstract)
+	}
+
+	text := doc.child("text")
+	w.divContent(text.child("body"))
+	back := text.child("back")
+	if back == nil {
+		return w.b.String()
+	}
+	for _, c := range back.children {
+		if c.name != "div" {
+			continue
+		}
+		if c.attrs["type"] == "references" {
+			w.references(c)
+			continue
+		}
+		if c.child("head") == nil {
+			if heading := backHeading(c.attrs["type"]); heading != "" {
+				w.block(firstPlaced(c), "## "+heading)
+			}
+		}
+		w.divContent(c)
+	}
+	return w.b.String()
+}
+
+// firstPlaced returns the first element under n that has coordinates, or
+// n when none has, so a heading GROBID did not place lands on the page of
+// the text it introduces.
+func firstPlaced(n *teiNode) *teiNode {
+	for _, c := range n.children {
+		if c.page() > 0 {
+			return c
+		}
+		if c.name != "" {
+			if found := firstPlaced(c); found != c {
+				return found
+			}
+		}
+	}
+	return n
+}
+
+// backHeading names a back-matter division that has no head of its own.
+func backHeading(divType string) string {
+	switch divType {
+	case "acknowledgement":
+		return "Acknowledgements"
+	case "funding":
+		return "Funding"
+	case "availability":
+		return "Availability"
+	}
+	return ""
+}
+
+// divContent renders the blocks of a division, recursing into nested
+// divisions.
+func (w *teiWriter) divContent(n *teiNode) {
+	if n == nil {
+		return
+	}
+	for _, c := range n.children {
+		switch c.name {
+		case "div":
+			w.divContent(c)
+		case "head":
+			w.block(c, headingPrefix(c.attrs["n"])+headingText(c))
+		case "p", "ab":
+			w.block(c, c.content())
+		case "formula":
+			w.formula(c)
+		case "list":
+			var items []string
+			for _, item := range c.all("item") {
+				items = append(items, "- "+item.content())
+			}
+			w.block(c, strings.Join(items, "\n"))
+		case "figure":
+			w.figure(c)
+		case "pb":
+			w.mark(c)
+		}
+	}
+}
+
+// formula writes a display formula as a $$ block led by an
+// <!-- equation N --> marker (R2.13), N being the number GROBID read from
+// its label. GROBID recovers formulas as Unicode text, not LaTeX.
+func (w *teiWriter) formula(n *teiNode) {
+	text := &teiNode{name: n.name}
+	label := ""
+	for _, c := range n.children {
+		if c.name == "label" {
+			label = strings.Trim(c.content(), "() ")
+			continue
+		}
+		text.children = append(text.children, c)
+	}
+	w.block(n, equationMarker(label)+"\n\n$$ "+text.content()+" $$")
+}
+
+// equationMarker returns the comment that tags a display equation for
+// extraction, with its number when it has one.
+func equationMarker(n string) string {
+	if n == "" {
+		return "<!-- equation -->"
+	}
+	return "<!-- equation " + n + " -->"
+}
+
+// headingPrefix maps a GROBID section number to a Markdown heading level:
+// "3" is a second-level heading, "3.2" third-level, and so on. Unnumbered
+// heads are second-level.
+func headingPrefix(n string) string {
+	level := 2
+	if n = strings.Trim(n, "."); n != "" {
+		level = min(strings.Count(n, ".")+2, 6)
+	}
+	return strings.Repeat("#", level) + " "
+}
+
+func headingText(head *teiNode) string {
+	if n := head.attrs["n"]; n != "" {
+		return n + " " + head.content()
+	}
+	return head.content()
+}
+
+// captionLabel matches the "Table 1:" GROBID leaves at the start of a
+// caption head; the label is rendered separately.
+var captionLabel = regexp.MustCompile(`^(?i)(fig\.?|figure|table)\s*[0-9]*\s*[.:]?\s*`)
+
+// figure renders a figure or table caption: "**Figure 2.** caption".
+func (w *teiWriter) figure(n *teiNode) {
+	label := "Figure"
+	if n.attrs["type"] == "table" {
+		label = "Table"
+	}
+	if num := n.child("label").content(); num != "" {
+		label += " " + num
+	}
+	head := captionLabel.ReplaceAllString(n.child("head").content(), "")
+	caption := strings.TrimSpace(head + " " + n.child("figDesc").content())
+	if caption == "" {
+		return
+	}
+	w.block(n, "**"+label+".** "+caption)
+}
+
+// references renders the bibliography as a numbered list.
+func (w *teiWriter) references(div *teiNode) {
+	var bibl []*teiNode
+	for _, list := range div.all("listBibl") {
+		bibl = append(bibl, list.all("biblStruct")...)
+	}
+	if len(bibl) == 0 {
+		return
+	}
+	w.b.WriteString("## References\n\n")
+	for i, b := range bibl {
+		if ref := formatReference(b); ref != "" {
+			w.mark(b)
+			fmt.Fprintf(&w.b, "%d. %s\n", i+1, ref)
+		}
+	}
+	w.b.WriteString("\n")
+}
+
+// formatReference renders a parsed reference as "Authors (Year). Title.
+// *Venue*, volume(issue), pages. doi:...", falling back to the raw
+// citation string when GROBID found no title.
+func formatReference(b *teiNode) string {
+	analytic, monogr := b.child("analytic"), b.child("monogr")
+	title := analytic.child("title").content()
+	venue := monogr.child("title").content()
+	if title == "" {
+		title, venue = venue, ""
+	}
+	if title == "" {
+		for _, note := range b.all("note") {
+			if note.attrs["type"] == "raw_reference" {
+				return note.content()
+			}
+		}
+		return ""
+	}
+
+	var authors []string
+	for _, a := range append(analytic.all("author"), monogr.all("author")...) {
+		if name := authorName(a.child("persName")); name != "" {
+			authors = append(authors, name)
+		}
+	}
+	var parts []string
+	head := strings.Join(authors, ", ")
+	imprint := monogr.child("imprint")
+	if date := imprint.child("date"); date != nil {
+		year := date.attrs["when"]
+		if len(year) > 4 {
+			year = year[:4]
+		}
+		if year != "" {
+			head = strings.TrimSpace(head + " (" + year + ")")
+		}
+	}
+	if head != "" {
+		parts = append(parts, head+".")
+	}
+	parts = append(parts, strings.TrimSuffix(title, ".")+".")
+	if venue != "" {
+		v := "*" + venue + "*"
+		for _, s := range imprint.all("biblScope") {
+			switch s.attrs["unit"] {
+			case "volume":
+				v += ", " + s.content()
+			case "issue":
+				v += "(" + s.content() + ")"
+			case "page":
+				if from, to := s.attrs["from"], s.attrs["to"]; from != "" && to != "" {
+					v += ", " + from + "–" + to
+				} else if p := s.content() + from; p != "" {
+					v += ", " + p
+				}
+			}
+		}
+		parts = append(parts, v+".")
+	}
+	for _, id := range append(analytic.all("idno"), monogr.all("idno")...) {
+		if strings.EqualFold(id.attrs["type"], "DOI") {
+			parts = append(parts, "doi:"+id.content())
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// authorName renders a persName as "Surname, F."
+func authorName(p *teiNode) string {
+	surname := p.child("surname").content()
+	if surname == "" {
+		return ""
+	}
+	var initials []string
+	for _, f := range p.all("forename") {
+		for _, name := range strings.Fields(f.content()) {
+			initials = append(initials, string([]rune(name)[0])+".")
+		}
+	}
+	if len(initials) == 0 {
+		return surname
+	}
+	return surname + ", " + strings.Join(initials, " ")
+}
@@ -0,0 +1,114 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const sampleTEI = `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0">
+  <teiHeader>
+    <fileDesc><titleStmt><title level="a" type="main">Attention Is All You Need</title></titleStmt></fileDesc>
+    <profileDesc><abstract><div><p coords="1,72,300,450,80">The dominant sequence transduction models are complex.</p></div></abstract></profileDesc>
+  </teiHeader>
+  <text>
+    <body>
+      <div><head n="1" coords="1,72,400,100,12">Introduction</head>
+        <p coords="1,72,420,450,60">Recurrent models <ref type="bibr" target="#b0">[1]</ref> are
+          sequential.</p></div>
+      <div><head n="3.2" coords="3,72,100,100,12">Attention</head>
+        <p coords="3,72,120,450,60">An attention function maps a query.</p>
+        <formula coords="4,72,100,300,20">Attention(Q, K, V) = softmax(QK^T)V<label>(1)</label></formula>
+        <figure type="table" coords="4,72,200,450,100"><head>Table 1:</head><label>1</label><figDesc>Complexity per layer.</figDesc></figure></div>
+    </body>
+    <back>
+      <div type="acknowledgement"><div><p coords="5,72,100,450,20">We thank the reviewers.</p></div></div>
+      <div type="references"><listBibl>
+        <biblStruct xml:id="b0" coords="5,72,200,450,20">
+          <analytic><title level="a">Long short-term memory</title>
+            <author><persName><forename type="first">Sepp</forename><surname>Hochreiter</surname></persName></author>
+            <author><persName><forename type="first">J</forename><surname>Schmidhuber</surname></persName></author>
+            <idno type="DOI">10.1162/neco.1997.9.8.1735</idno></analytic>
+          <monogr><title level="j">Neural Computation</title>
+            <imprint><biblScope unit="volume">9</biblScope><biblScope unit="issue">8</biblScope>
+              <biblScope unit="page" from="1735" to="1780"/><date type="published" when="1997-11-15"/></imprint></monogr>
+        </biblStruct>
+        <biblStruct xml:id="b1"><monogr><title/><imprint/></monogr>
+          <note type="raw_reference">Anonymous. Unpublished notes, 2016.</note></biblStruct>
+      </listBibl></div>
+    </back>
+  </text>
+</TEI>`
+
+func newGROBIDServer(t *testing.T, alive bool) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/isalive":
+			fmt.Fprint(w, alive)
+		case "/api/processFulltextDocument":
+			file, _, err := r.FormFile("input")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			data, _ := io.ReadAll(file)
+			if string(data) != "fake pdf" || len(r.MultipartForm.Value["teiCoordinates"]) == 0 {
+				http.Error(w, "bad request", http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, sampleTEI)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestGROBIDConverter(t *testing.T) {
+	ts := newGROBIDServer(t, true)
+	defer ts.Close()
+	pdfPath, _ := setupPDF(t)
+
+	c, err := NewGROBIDConverter(ts.Client(), ts.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	md, err := c.Convert(pdfPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<!-- page 1 -->\n\n# Attention Is All You Need\n\n## Abstract\n\nThe dominant sequence transduction models are complex.\n\n",
+		"## 1 Introduction\n\nRecurrent models [1] are sequential.\n\n",
+		"<!-- page 3 -->\n\n### 3.2 Attention\n\n",
+		"<!-- page 4 -->\n\n<!-- equation 1 -->\n\n$$ Attention(Q, K, V) = softmax(QK^T)V $$\n\n**Table 1.** Complexity per layer.\n\n",
+		"<!-- page 5 -->\n\n## Acknowledgements\n\nWe thank the reviewers.\n\n",
+		"## References\n\n1. Hochreiter, S., Schmidhuber, J. (1997). Long short-term memory. *Neural Computation*, 9(8), 1735–1780. doi:10.1162/neco.1997.9.8.1735\n2. Anonymous. Unpublished notes, 2016.\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Count(md, "<!-- page 1 -->") != 1 {
+		t.Errorf("page 1 marked more than once:\n%s", md)
+	}
+}
+
+func TestGROBIDConverterNotAlive(t *testing.T) {
+	ts := newGROBIDServer(t, false)
+	defer ts.Close()
+	if _, err := NewGROBIDConverter(ts.Client(), ts.URL); err == nil || !strings.Contains(err.Error(), "not alive") {
+		t.Errorf("err = %v", err)
+	}
+	ts.Close()
+	if _, err := NewGROBIDConverter(ts.Client(), ts.URL); err == nil || !strings.Contains(err.Error(), "not reachable") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,420 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// htmlNode is an element or text node of a parsed HTML document. Text
+// nodes have an empty tag.
+type htmlNode struct {
+	tag      string
+	attr     map[string]string
+	text     string
+	children []*htmlNode
+}
+
+var (
+	// htmlUnparsed matches the parts of an HTML document the XML decoder
+	// cannot read and that carry no text: comments, doctype, and the
+	// bodies of scripts and styles, whose "<" is not markup.
+	htmlUnparsed = regexp.MustCompile(`(?is)<!--.*?-->|<!doctype[^>]*>|<(script|style|noscript|template)\b[^>]*>.*?</(?:script|style|noscript|template)\s*>`)
+	// eqNumber matches an equation number such as "(3)" or "(2.1)".
+	eqNumber = regexp.MustCompile(`^\(([\w.]+)\)$`)
+)
+
+// parseHTML parses an HTML document leniently: unclosed and mismatched
+// tags are closed, void elements need no end tag, and HTML entities are
+// decoded.
+func parseHTML(data []byte) (*htmlNode, error) {
+	d := xml.NewDecoder(strings.NewReader(htmlUnparsed.ReplaceAllString(string(data), "")))
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+	d.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) { return r, nil }
+
+	root := &htmlNode{tag: "#document"}
+	stack := []*htmlNode{root}
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// Keep what parsed before malformed markup.
+			break
+		}
+		top := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &htmlNode{tag: strings.ToLower(t.Name.Local), attr: map[string]string{}}
+			for _, a := range t.Attr {
+				n.attr[strings.ToLower(a.Name.Local)] = a.Value
+			}
+			top.children = append(top.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			top.children = append(top.children, &htmlNode{text: string(t)})
+		}
+	}
+	return root, nil
+}
+
+// htmlBlocks reads the HTML document at path as Markdown blocks.
+func htmlBlocks(path string) ([]docBlock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	root, err := parseHTML(data)
+	if err != nil {
+		return nil, err
+	}
+	r := &htmlRenderer{}
+	r.block(root)
+	r.flush()
+	if !r.titled {
+		if title := strings.TrimSpace(collapseSpace(textContent(find(root, "title")))); title != "" {
+			r.blocks = append([]docBlock{{text: "# " + title}}, r.blocks...)
+		}
+	}
+	return r.blocks, nil
+}
+
+// htmlSkipped are elements whose content is not part of the paper's text.
+var htmlSkipped = map[string]bool{
+	"head": true, "nav": true, "footer": true, "aside": true, "form": true,
+	"button": true, "svg": true, "iframe": true, "img": true, "input": true,
+	"select": true, "textarea": true,
+}
+
+// htmlBlockTags are the elements that start a new Markdown block.
+var htmlBlockTags = map[string]bool{
+	"#document": true, "html": true, "body": true, "main": true, "article": true,
+	"section": true, "div": true, "header": true, "p": true, "figure": true,
+	"figcaption": true, "caption": true, "dl": true, "dt": true, "dd": true,
+	"address": true, "center": true, "details": true, "summary": true, "hr": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "li": true, "table": true, "blockquote": true, "pre": true,
+}
+
+// htmlRenderer renders an HTML tree as Markdown blocks. Inline content
+// accumulates in para until a block element ends the paragraph.
+type htmlRenderer struct {
+	blocks []docBlock
+	para   strings.Builder
+	titled bool
+}
+
+// flush ends the current paragraph.
+func (r *htmlRenderer) flush() {
+	r.add(r.para.String())
+	r.para.Reset()
+}
+
+// add appends a block, trimming each line and dropping empty ones.
+func (r *htmlRenderer) add(text string) {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > 0 {
+		r.blocks = append(r.blocks, docBlock{text: strings.Join(lines, "\n")})
+	}
+}
+
+// block renders the children of n, starting blocks at block elements.
+func (r *htmlRenderer) block(n *htmlNode) {
+	for _, c := range n.children {
+		switch {
+		case c.tag == "" || !htmlBlockTags[c.tag] && !isDisplayMath(c):
+			r.para.WriteString(inline(c))
+		default:
+			r.flush()
+			r.element(c)
+		}
+	}
+}
+
+// element renders the block element n.
+func (r *htmlRenderer) element(n *htmlNode) {
+	switch n.tag {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		r.titled = r.titled || n.tag == "h1"
+		if text := strings.TrimSpace(collapseSpace(inlineChildren(n))); text != "" {
+			r.add(strings.Repeat("#", int(n.tag[1]-'0')) + " " + text)
+		}
+	case "math":
+		r.blocks = append(r.blocks, docBlock{text: displayMath("", mathTeX(n))})
+	case "ul", "ol":
+		r.list(n, 0)
+	case "table":
+		if r.equations(n) {
+			break
+		}
+		r.add(htmlTable(n))
+		if caption := find(n, "caption"); caption != nil {
+			r.add(collapseSpace(inlineChildren(caption)))
+		}
+	case "blockquote":
+		sub := &htmlRenderer{}
+		sub.block(n)
+		sub.flush()
+		for _, b := range sub.blocks {
+			r.add("> " + strings.ReplaceAll(b.text, "\n", "\n> "))
+		}
+	case "pre":
+		r.blocks = append(r.blocks, docBlock{text: "```\n" + strings.Trim(textContent(n), "\n") + "\n```"})
+	case "hr":
+	default:
+		r.block(n)
+		r.flush()
+	}
+}
+
+// list renders a list at the given nesting depth, one block per item.
+func (r *htmlRenderer) list(n *htmlNode, depth int) {
+	indent := strings.Repeat("  ", depth)
+	num := 0
+	for _, li := range n.children {
+		if li.tag != "li" {
+			continue
+		}
+		num++
+		marker := "- "
+		if n.tag == "ol" {
+			marker = strconv.Itoa(num) + ". "
+		}
+		var text strings.Builder
+		var nested []*htmlNode
+		for _, c := range li.children {
+			if c.tag == "ul" || c.tag == "ol" {
+				nested = append(nested, c)
+				continue
+			}
+			text.WriteString(inline(c))
+		}
+		if item := strings.TrimSpace(collapseSpace(text.String())); item != "" {
+			r.blocks = append(r.blocks, docBlock{text: indent + marker + item})
+		}
+		for _, c := range nested {
+			r.list(c, depth+1)
+		}
+	}
+}
+
+// equations renders a table laying out numbered display equations, as
+// LaTeX-generated HTML does, as equation blocks with their markers. It
+// reports false for a table without display math.
+func (r *htmlRenderer) equations(table *htmlNode) bool {
+	rows := findAll(table, "tr")
+	found := false
+	for _, row := range rows {
+		var tex []string
+		number := ""
+		for _, cell := range findAll(row, "td") {
+			maths := 0
+			for _, m := range findAll(cell, "math") {
+				if isDisplayMath(m) {
+					tex = append(tex, mathTeX(m))
+					maths++
+				}
+			}
+			if m := eqNumber.FindStringSubmatch(strings.TrimSpace(collapseSpace(textContent(cell)))); maths == 0 && m != nil {
+				number = m[1]
+			}
+		}
+		if len(tex) > 0 {
+			found = true
+			r.blocks = append(r.blocks, docBlock{text: displayMath(number, strings.Join(tex, " "))})
+		}
+	}
+	return found
+}
+
+// displayMath renders a display equation as a $$ block led by its
+// equation marker.
+func displayMath(number, tex string) string {
+	return equationMarker(number) + "\n\n$$\n" + tex + "\n$$"
+}
+
+// htmlTable renders a table as a Markdown pipe table.
+func htmlTable(table *htmlNode) string {
+	var rows [][]string
+	for _, tr := range findAll(table, "tr") {
+		var cells []string
+		for _, c := range tr.children {
+			if c.tag == "td" || c.tag == "th" {
+				cells = append(cells, inlineChildren(c))
+			}
+		}
+		if len(cells) > 0 {
+			rows = append(rows, cells)
+		}
+	}
+	return pipeTable(rows)
+}
+
+// pipeTable renders rows as a Markdown pipe table with the first row as
+// its header.
+func pipeTable(rows [][]string) string {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	var b strings.Builder
+	for i, row := range rows {
+		cells := make([]string, width)
+		for j, cell := range row {
+			cells[j] = strings.ReplaceAll(strings.TrimSpace(collapseSpace(cell)), "|", `\|`)
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		if i == 0 {
+			b.WriteString(strings.Repeat("| --- ", width) + "|\n")
+		}
+	}
+	return b.String()
+}
+
+// inline renders n as inline Markdown.
+func inline(n *htmlNode) string {
+	if n.tag == "" {
+		return collapseSpace(n.text)
+	}
+	if htmlSkipped[n.tag] {
+		return ""
+	}
+	switch n.tag {
+	case "br":
+		return "\n"
+	case "math":
+		return "$" + mathTeX(n) + "$"
+	case "em", "i":
+		return wrapInline("*", inlineChildren(n))
+	case "strong", "b":
+		return wrapInline("**", inlineChildren(n))
+	case "code", "kbd", "samp", "tt":
+		return wrapInline("`", textContent(n))
+	}
+	return inlineChildren(n)
+}
+
+// inlineChildren renders the children of n as inline Markdown.
+func inlineChildren(n *htmlNode) string {
+	var b strings.Builder
+	for _, c := range n.children {
+		if c.tag == "caption" {
+			continue
+		}
+		b.WriteString(inline(c))
+	}
+	return b.String()
+}
+
+// wrapInline surrounds the text of s with marker, keeping its outer
+// spaces outside.
+func wrapInline(marker, s string) string {
+	text := strings.TrimSpace(s)
+	if text == "" {
+		return s
+	}
+	lead := s[:strings.Index(s, text)]
+	trail := s[len(lead)+len(text):]
+	return lead + marker + text + marker + trail
+}
+
+// isDisplayMath reports whether n is a MathML display equation.
+func isDisplayMath(n *htmlNode) bool {
+	return n.tag == "math" && n.attr["display"] == "block"
+}
+
+// mathTeX returns the LaTeX of a MathML element: its alttext, or its TeX
+// annotation, or failing both its text.
+func mathTeX(n *htmlNode) string {
+	if tex := n.attr["alttext"]; tex != "" {
+		return strings.TrimSpace(tex)
+	}
+	for _, a := range findAll(n, "annotation") {
+		if strings.Contains(a.attr["encoding"], "tex") {
+			return strings.TrimSpace(textContent(a))
+		}
+	}
+	return strings.TrimSpace(collapseSpace(textContent(n)))
+}
+
+// textContent returns the text of n and its descendants.
+func textContent(n *htmlNode) string {
+	if n == nil {
+		return ""
+	}
+	if n.tag == "" {
+		return n.text
+	}
+	var b strings.Builder
+	for _, c := range n.children {
+		b.WriteString(textContent(c))
+	}
+	return b.String()
+}
+
+// find returns the first descendant of n with tag, or nil.
+func find(n *htmlNode, tag string) *htmlNode {
+	for _, c := range n.children {
+		if c.tag == tag {
+			return c
+		}
+		if f := find(c, tag); f != nil {
+			return f
+		}
+	}
+	return nil
+}
+
+// findAll returns the descendants of n with tag, not looking inside
+// matches.
+func findAll(n *htmlNode, tag string) []*htmlNode {
+	var out []*htmlNode
+	for _, c := range n.children {
+		if c.tag == tag {
+			out = append(out, c)
+			continue
+		}
+		out = append(out, findAll(c, tag)...)
+	}
+	return out
+}
+
+// collapseSpace replaces each run of whitespace in s with one space.
+func collapseSpace(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
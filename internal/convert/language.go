@@ -0,0 +1,117 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"strings"
+	"unicode"
+)
+
+// minLanguageWords is the number of words below which DetectLanguage does
+// not guess.
+const minLanguageWords = 50
+
+// stopwords are frequent function words of the Latin-script languages
+// DetectLanguage tells apart, by ISO 639-1 code.
+var stopwords = map[string][]string{
+	"en": {"the", "of", "and", "to", "in", "is", "that", "for", "with", "as", "on", "are", "this", "by", "be", "we", "which", "from", "an", "it"},
+	"de": {"der", "die", "und", "in", "den", "von", "zu", "das", "mit", "sich", "des", "auf", "für", "ist", "im", "dem", "nicht", "ein", "eine", "wird"},
+	"fr": {"de", "la", "le", "et", "les", "des", "en", "un", "une", "du", "est", "que", "pour", "dans", "par", "sur", "au", "sont", "avec", "nous"},
+	"es": {"de", "la", "que", "el", "en", "y", "los", "del", "se", "las", "por", "un", "para", "con", "una", "es", "al", "como", "más", "su"},
+	"it": {"di", "il", "la", "che", "e", "in", "per", "un", "del", "della", "è", "le", "non", "una", "con", "si", "sono", "dei", "gli", "nel"},
+	"pt": {"de", "a", "o", "que", "e", "do", "da", "em", "um", "para", "é", "com", "não", "uma", "os", "no", "se", "na", "por", "mais"},
+	"nl": {"de", "en", "van", "het", "een", "in", "is", "dat", "op", "te", "zijn", "voor", "met", "die", "niet", "aan", "er", "worden", "wordt", "ook"},
+}
+
+// latinLanguages orders the stopword languages; English wins ties.
+var latinLanguages = []string{"en", "de", "fr", "es", "it", "pt", "nl"}
+
+// languageOf indexes stopwords by word.
+var languageOf = func() map[string][]string {
+	index := make(map[string][]string)
+	for lang, words := range stopwords {
+		for _, w := range words {
+			index[w] = append(index[w], lang)
+		}
+	}
+	return index
+}()
+
+// scriptLanguages maps writing systems to the language they most likely
+// are in a research corpus.
+var scriptLanguages = []struct {
+	script *unicode.RangeTable
+	lang   string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Han, "zh"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Arabic, "ar"},
+	{unicode.Greek, "el"},
+	{unicode.Hebrew, "he"},
+}
+
+// DetectLanguage guesses the language of converted Markdown (R5.1) and
+// returns its ISO 639-1 code, or "" when the text is too short to tell.
+// Text mostly in a non-Latin script is classified by script (Japanese
+// when it has kana, Chinese for Han alone); Latin-script text by its
+// most frequent stopwords among English, German, French, Spanish,
+// Italian, Portuguese, and Dutch. Math, code, comments, and URLs are
+// ignored.
+func DetectLanguage(md string) string {
+	prose := unscored.ReplaceAllString(md, " ")
+
+	letters, latin := 0, 0
+	scripts := make(map[string]int)
+	for _, r := range prose {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.Is(unicode.Latin, r) {
+			latin++
+			continue
+		}
+		for _, s := range scriptLanguages {
+			if unicode.Is(s.script, r) {
+				scripts[s.lang]++
+				break
+			}
+		}
+	}
+	if letters-latin > latin {
+		if scripts["ja"] > 0 {
+			return "ja"
+		}
+		best := ""
+		for _, s := range scriptLanguages {
+			if scripts[s.lang] > scripts[best] {
+				best = s.lang
+			}
+		}
+		if scripts[best] >= minLanguageWords {
+			return best
+		}
+		return ""
+	}
+
+	words := wordToken.FindAllString(strings.ToLower(prose), -1)
+	if len(words) < minLanguageWords {
+		return ""
+	}
+	counts := make(map[string]int)
+	for _, w := range words {
+		for _, lang := range languageOf[w] {
+			counts[lang]++
+		}
+	}
+	best := latinLanguages[0]
+	for _, lang := range latinLanguages[1:] {
+		if counts[lang] > counts[best] {
+			best = lang
+		}
+	}
+	return best
+}
@@ -0,0 +1,28 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	for _, tc := range []struct {
+		name, text, want string
+	}{
+		{"english", qualityProse, "en"},
+		{"german", "Die Ergebnisse der Studie zeigen, dass das Modell mit den Daten in der Praxis nicht immer die beste Wahl ist und sich für die Analyse von Texten eignet. ", "de"},
+		{"french", "Les résultats de cette étude montrent que le modèle est une bonne solution pour la classification des textes et que nous avons obtenu des gains sur les données. ", "fr"},
+		{"spanish", "Los resultados del estudio muestran que el modelo es una buena opción para la clasificación de los textos y que se obtienen mejoras con las técnicas más recientes. ", "es"},
+		{"japanese", "本研究では、ニューラルネットワークを用いた文書分類の新しい手法を提案する。実験の結果、提案手法は従来手法よりも高い精度を達成した。", "ja"},
+		{"russian", "В данной работе предлагается новый метод классификации текстов на основе нейронных сетей, который показывает высокую точность. ", "ru"},
+	} {
+		if got := DetectLanguage(strings.Repeat(tc.text, 5)); got != tc.want {
+			t.Errorf("%s: DetectLanguage = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+	if got := DetectLanguage("# Titel\n\nEin kurzer Text."); got != "" {
+		t.Errorf("short text: DetectLanguage = %q, want no guess", got)
+	}
+}
@@ -0,0 +1,1056 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultArxivSourceURL is the arXiv endpoint that serves a paper's source
+// files (its e-print) by identifier.
+const DefaultArxivSourceURL = "https://arxiv.org/e-print/"
+
+// maxSourceSize bounds the e-print archive and each file read from it.
+const maxSourceSize = 100 << 20
+
+// errNoSource reports that a paper has no LaTeX source to convert: it is
+// not on arXiv, or arXiv serves only its PDF.
+var errNoSource = errors.New("no LaTeX source")
+
+// LaTeXConverter converts arXiv papers from their LaTeX source instead of
+// the PDF (R2.13), so display equations keep their LaTeX rather than the
+// Unicode a PDF extractor recovers. Papers without arXiv source are
+// converted by a fallback PDF backend.
+type LaTeXConverter struct {
+	client   *http.Client
+	baseURL  string
+	fallback Converter
+}
+
+// NewLaTeXConverter creates a converter that fetches e-prints from baseURL
+// (DefaultArxivSourceURL in production) and hands papers without source to
+// fallback.
+func NewLaTeXConverter(client *http.Client, baseURL string, fallback Converter) *LaTeXConverter {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	return &LaTeXConverter{client: client, baseURL: baseURL, fallback: fallback}
+}
+
+// Convert renders the LaTeX source of the arXiv paper behind pdfPath as
+// Markdown, or converts the PDF with the fallback backend when the paper
+// has no source.
+func (l *LaTeXConverter) Convert(pdfPath string) (string, error) {
+	id := arxivIDFor(pdfPath)
+	if id == "" {
+		return l.fallback.Convert(pdfPath)
+	}
+	files, err := l.fetchSource(id)
+	if errors.Is(err, errNoSource) {
+		return l.fallback.Convert(pdfPath)
+	}
+	if err != nil {
+		return "", fmt.Errorf("fetching LaTeX source of arXiv:%s: %w", id, err)
+	}
+	md, err := renderLaTeXSource(files)
+	if errors.Is(err, errNoSource) {
+		return l.fallback.Convert(pdfPath)
+	}
+	return md, err
+}
+
+var (
+	// newArxivSlug matches a raw PDF name that is a new-style arXiv ID.
+	newArxivSlug = regexp.MustCompile(`^\d{4}\.\d{4,5}(v\d+)?$`)
+	// oldArxivSlug matches an old-style arXiv ID whose slash acquisition
+	// replaced with a hyphen, such as hep-th-9901001.
+	oldArxivSlug = regexp.MustCompile(`^([a-z]+(?:-[a-z]+)?(?:\.[A-Z]{2})?)-(\d{7}(?:v\d+)?)$`)
+)
+
+// arxivIDFor returns the arXiv ID of the paper whose PDF is at pdfPath:
+// the arxiv_id in its metadata, which also covers published papers with a
+// linked preprint, or else the ID in the file name. It returns "" for
+// papers not on arXiv.
+func arxivIDFor(pdfPath string) string {
+	if p, ok := paperMetadata(pdfPath); ok && p.ArxivID != "" {
+		return p.ArxivID
+	}
+	base := strings.TrimSuffix(filepath.Base(pdfPath), filepath.Ext(pdfPath))
+	if newArxivSlug.MatchString(base) {
+		return base
+	}
+	if m := oldArxivSlug.FindStringSubmatch(base); m != nil {
+		return m[1] + "/" + m[2]
+	}
+	return ""
+}
+
+// fetchSource downloads the e-print of the arXiv paper id and returns its
+// TeX and BibTeX output files by path.
+func (l *LaTeXConverter) fetchSource(id string) (map[string]string, error) {
+	resp, err := l.client.Get(l.baseURL + id)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errNoSource
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSourceSize))
+	if err != nil {
+		return nil, err
+	}
+	return unpackSource(data)
+}
+
+// unpackSource returns the .tex and .bbl files of an e-print, which arXiv
+// serves as a gzipped tar archive or, for single-file submissions, a
+// gzipped TeX file. A PDF means the paper has no source.
+func unpackSource(data []byte) (map[string]string, error) {
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("decompressing source: %w", err)
+		}
+		if data, err = io.ReadAll(io.LimitReader(zr, maxSourceSize)); err != nil {
+			return nil, fmt.Errorf("decompressing source: %w", err)
+		}
+	}
+	if bytes.HasPrefix(data, []byte("%PDF")) {
+		return nil, errNoSource
+	}
+	if len(data) < 262 || string(data[257:262]) != "ustar" {
+		return map[string]string{"main.tex": string(data)}, nil
+	}
+
+	files := make(map[string]string)
+	tr := tar.NewReader(bytes.NewReader(data))
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading source archive: %w", err)
+		}
+		switch strings.ToLower(path.Ext(h.Name)) {
+		case ".tex", ".ltx", ".bbl":
+		default:
+			continue
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		b, err := io.ReadAll(io.LimitReader(tr, maxSourceSize))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s from source archive: %w", h.Name, err)
+		}
+		files[path.Clean(h.Name)] = string(b)
+	}
+	return files, nil
+}
+
+// renderLaTeXSource renders the main TeX file of an e-print, with its
+// \input files and compiled bibliography inlined, as Markdown.
+func renderLaTeXSource(files map[string]string) (string, error) {
+	main := mainTeX(files)
+	if main == "" {
+		return "", errNoSource
+	}
+	src := expandInputs(files, main, 0)
+	src = bibliographyCmd.ReplaceAllLiteralString(src, findBBL(files, main))
+	return newLaTeXRenderer().render(src), nil
+}
+
+// mainTeX returns the file that holds \documentclass, preferring one with
+// a document body and then the longest, or "" when there is none.
+func mainTeX(files map[string]string) string {
+	var names []string
+	for name, src := range files {
+		if strings.HasSuffix(name, ".bbl") {
+			continue
+		}
+		if strings.Contains(stripComments(src), `\documentclass`) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Slice(names, func(i, j int) bool {
+		bi := strings.Contains(files[names[i]], `\begin{document}`)
+		bj := strings.Contains(files[names[j]], `\begin{document}`)
+		if bi != bj {
+			return bi
+		}
+		if len(files[names[i]]) != len(files[names[j]]) {
+			return len(files[names[i]]) > len(files[names[j]])
+		}
+		return names[i] < names[j]
+	})
+	return names[0]
+}
+
+// inputCmd matches \input and \include of another source file.
+var inputCmd = regexp.MustCompile(`\\(?:input|include)(?:\s*\{([^}]*)\}|\s+([^\s{}\\]+))`)
+
+// bibliographyCmd matches the commands that print a BibTeX bibliography.
+var bibliographyCmd = regexp.MustCompile(`\\bibliography\s*\{[^}]*\}`)
+
+// expandInputs returns the named file with comments removed and the files
+// it inputs inlined, to a bounded depth.
+func expandInputs(files map[string]string, name string, depth int) string {
+	src := stripComments(files[name])
+	if depth >= 8 {
+		return src
+	}
+	return inputCmd.ReplaceAllStringFunc(src, func(m string) string {
+		sub := inputCmd.FindStringSubmatch(m)
+		target := strings.TrimSpace(sub[1] + sub[2])
+		for _, candidate := range []string{target, target + ".tex"} {
+			candidate = path.Clean(candidate)
+			if _, ok := files[candidate]; ok {
+				return expandInputs(files, candidate, depth+1)
+			}
+		}
+		return ""
+	})
+}
+
+// findBBL returns the compiled bibliography for the main file: the .bbl
+// of the same name, or the only .bbl in the source.
+func findBBL(files map[string]string, main string) string {
+	if bbl, ok := files[strings.TrimSuffix(main, path.Ext(main))+".bbl"]; ok {
+		return stripComments(bbl)
+	}
+	var found []string
+	for name, src := range files {
+		if strings.HasSuffix(name, ".bbl") {
+			found = append(found, src)
+		}
+	}
+	if len(found) == 1 {
+		return stripComments(found[0])
+	}
+	return ""
+}
+
+// stripComments removes TeX comments. A line holding only a comment is
+// removed with its line break, so it does not end a paragraph.
+func stripComments(src string) string {
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(src, "\n") {
+		cut := commentStart(line)
+		if cut < 0 {
+			b.WriteString(line)
+			continue
+		}
+		if strings.TrimSpace(line[:cut]) == "" {
+			continue
+		}
+		b.WriteString(line[:cut])
+		if strings.HasSuffix(line, "\n") {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// commentStart returns the index of the % that starts a comment in line,
+// or -1. A % preceded by an odd number of backslashes is escaped.
+func commentStart(line string) int {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '%':
+			return i
+		}
+	}
+	return -1
+}
+
+// mathEnvs are the display math environments. The value is the
+// environment a Markdown math renderer accepts inside $$ for environments
+// that align several rows, or "" to keep the body as is.
+var mathEnvs = map[string]string{
+	"equation":    "",
+	"displaymath": "",
+	"math":        "",
+	"align":       "aligned",
+	"flalign":     "aligned",
+	"eqnarray":    "aligned",
+	"alignat":     "aligned",
+	"gather":      "gathered",
+	"multline":    "gathered",
+}
+
+// multiRowEnvs number each row rather than the whole display.
+var multiRowEnvs = map[string]bool{
+	"align": true, "flalign": true, "eqnarray": true, "alignat": true, "gather": true,
+}
+
+// theoremEnvs are rendered as paragraphs led by their bold name.
+var theoremEnvs = map[string]string{
+	"theorem": "Theorem", "lemma": "Lemma", "proposition": "Proposition",
+	"corollary": "Corollary", "definition": "Definition", "remark": "Remark",
+	"example": "Example", "assumption": "Assumption", "conjecture": "Conjecture",
+}
+
+// skippedEnvs have no text worth keeping outside a float.
+var skippedEnvs = map[string]bool{
+	"tabular": true, "tabular*": true, "tabularx": true, "tikzpicture": true,
+	"picture": true, "comment": true, "filecontents": true, "filecontents*": true,
+}
+
+// envArgs is the number of braced arguments that follow \begin{name}.
+var envArgs = map[string]int{
+	"minipage": 1, "wrapfigure": 2, "wraptable": 2, "multicols": 1,
+	"alignat": 1, "alignat*": 1, "subfigure": 1, "thebibliography": 1,
+}
+
+// latexRenderer renders a LaTeX document body as Markdown. It numbers
+// sections, equations, figures, and tables the way LaTeX does, so \ref and
+// \eqref resolve to the numbers the PDF shows.
+type latexRenderer struct {
+	out      strings.Builder
+	para     strings.Builder // LaTeX of the paragraph being collected
+	sections [3]int
+	appendix bool
+	equation int
+	figures  int
+	tables   int
+	context  string // the number a \label outside a float or equation names
+	labels   map[string]string
+	cites    map[string]int
+}
+
+func newLaTeXRenderer() *latexRenderer {
+	return &latexRenderer{labels: make(map[string]string), cites: make(map[string]int)}
+}
+
+// render returns the document as Markdown: the title, then the body.
+func (r *latexRenderer) render(src string) string {
+	body := src
+	if _, after, ok := strings.Cut(src, `\begin{document}`); ok {
+		body, _, _ = strings.Cut(after, `\end{document}`)
+	}
+	if title := commandArg(src, "title"); title != "" {
+		r.heading(1, r.text(title))
+	}
+	r.blocks(body)
+	return r.resolve(r.out.String())
+}
+
+// block writes a paragraph-level block of Markdown.
+func (r *latexRenderer) block(s string) {
+	r.out.WriteString(s)
+	r.out.WriteString("\n\n")
+}
+
+func (r *latexRenderer) heading(level int, s string) {
+	r.block(strings.Repeat("#", level) + " " + s)
+}
+
+// flush writes the collected paragraph, if it has any text.
+func (r *latexRenderer) flush() {
+	if text := r.text(r.para.String()); text != "" {
+		r.block(text)
+	}
+	r.para.Reset()
+}
+
+// blocks renders block-level LaTeX: paragraphs, sectioning commands,
+// environments, and display math. Everything else is collected into the
+// current paragraph and rendered by text.
+func (r *latexRenderer) blocks(s string) {
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == '\n':
+			j := i + 1
+			for j < len(s) && (s[j] == ' ' || s[j] == '\t' || s[j] == '\r') {
+				j++
+			}
+			if j < len(s) && s[j] == '\n' {
+				r.flush()
+				i = j + 1
+				continue
+			}
+			r.para.WriteByte(' ')
+			i++
+		case strings.HasPrefix(s[i:], "$$"):
+			end := strings.Index(s[i+2:], "$$")
+			if end < 0 {
+				r.para.WriteString(s[i:])
+				return
+			}
+			r.display("", s[i+2:i+2+end])
+			i += end + 4
+		case c == '$':
+			end := inlineMathEnd(s, i+1)
+			r.para.WriteString(s[i:end])
+			i = end
+		case c == '\\':
+			i = r.blockCommand(s, i)
+		default:
+			r.para.WriteByte(c)
+			i++
+		}
+	}
+	r.flush()
+}
+
+// blockCommand handles the control sequence at s[i] in block context and
+// returns the index after it.
+func (r *latexRenderer) blockCommand(s string, i int) int {
+	name, end := cmdName(s, i)
+	star := end < len(s) && s[end] == '*'
+	if star {
+		end++
+	}
+	switch name {
+	case "[":
+		close := strings.Index(s[end:], `\]`)
+		if close < 0 {
+			r.para.WriteString(s[i:])
+			return len(s)
+		}
+		r.display("", s[end:end+close])
+		return end + close + 2
+	case "(":
+		close := strings.Index(s[end:], `\)`)
+		if close < 0 {
+			close = len(s) - end
+		}
+		r.para.WriteString(s[i:min(end+close+2, len(s))])
+		return min(end+close+2, len(s))
+	case "begin":
+		env, next, ok := group(s, end)
+		if !ok {
+			return end
+		}
+		inner, after := envBody(s, next, env)
+		r.environment(env, inner)
+		return after
+	case "section", "subsection", "subsubsection":
+		_, next, _ := optional(s, skipSpace(s, end))
+		title, next, _ := group(s, next)
+		level := map[string]int{"section": 0, "subsection": 1, "subsubsection": 2}[name]
+		r.section(level, star, title)
+		return next
+	case "paragraph", "subparagraph":
+		title, next, _ := group(s, end)
+		r.flush()
+		r.para.WriteString(`\textbf{` + title + `} `)
+		return next
+	case "appendix":
+		r.flush()
+		r.appendix = true
+		r.sections = [3]int{}
+		return end
+	case "title", "author", "date", "thanks", "affiliation", "institute", "email":
+		_, next, _ := optional(s, end)
+		_, next, _ = group(s, next)
+		return next
+	}
+	r.para.WriteString(s[i:end])
+	return end
+}
+
+// section writes a sectioning command as a Markdown heading numbered like
+// LaTeX numbers it; level 0 is \section.
+func (r *latexRenderer) section(level int, star bool, title string) {
+	r.flush()
+	prefix := ""
+	if !star {
+		r.sections[level]++
+		for k := level + 1; k < len(r.sections); k++ {
+			r.sections[k] = 0
+		}
+		parts := make([]string, level+1)
+		for k := range parts {
+			parts[k] = strconv.Itoa(r.sections[k])
+		}
+		if r.appendix {
+			parts[0] = string(rune('A' + r.sections[0] - 1))
+		}
+		r.context = strings.Join(parts, ".")
+		prefix = r.context + " "
+	}
+	r.heading(level+2, prefix+r.text(title))
+}
+
+// environment renders the body of \begin{env}...\end{env}.
+func (r *latexRenderer) environment(env, body string) {
+	for range envArgs[env] {
+		_, next, _ := optional(body, skipSpace(body, 0))
+		_, next, _ = group(body, next)
+		body = body[next:]
+	}
+	base := strings.TrimSuffix(env, "*")
+	if _, ok := mathEnvs[base]; ok {
+		r.display(env, body)
+		return
+	}
+	if name, ok := theoremEnvs[base]; ok {
+		if note, next, ok := optional(body, skipSpace(body, 0)); ok {
+			name += " (" + note + ")"
+			body = body[next:]
+		}
+		r.flush()
+		r.para.WriteString(`\textbf{` + name + `.} `)
+		r.blocks(body)
+		return
+	}
+	switch base {
+	case "document":
+		r.blocks(body)
+	case "abstract":
+		r.flush()
+		r.heading(2, "Abstract")
+		r.blocks(body)
+	case "proof":
+		r.flush()
+		r.para.WriteString(`\emph{Proof.} `)
+		r.blocks(body)
+	case "figure", "table", "wrapfigure", "wraptable", "sidewaysfigure", "sidewaystable":
+		r.flush()
+		r.float(base, body)
+	case "itemize", "enumerate", "description":
+		r.flush()
+		r.list(base, body)
+	case "thebibliography":
+		r.flush()
+		r.bibliography(body)
+	case "verbatim", "lstlisting", "minted":
+		r.flush()
+		r.block("```\n" + strings.Trim(body, "\n") + "\n```")
+	default:
+		if !skippedEnvs[env] {
+			r.blocks(body)
+		}
+	}
+}
+
+// display writes a display equation as a $$ block led by an
+// <!-- equation N --> marker (R2.13). env is the math environment, or ""
+// for \[...\] and $$...$$. Numbered environments take the next equation
+// numbers, one per row for aligned environments; N is a range when a
+// display takes several, and absent when it takes none.
+func (r *latexRenderer) display(env, body string) {
+	r.flush()
+	base := strings.TrimSuffix(env, "*")
+	numbered := env != "" && env == base && base != "displaymath" && base != "math"
+	rows := []string{body}
+	if multiRowEnvs[base] {
+		rows = splitTop(body, `\\`)
+	}
+	var nums []string
+	for _, row := range rows {
+		n := ""
+		if tag := commandArg(row, "tag"); tag != "" {
+			n = tag
+		} else if numbered && !strings.Contains(row, `\nonumber`) && !strings.Contains(row, `\notag`) {
+			r.equation++
+			n = strconv.Itoa(r.equation)
+		}
+		if n != "" {
+			nums = append(nums, n)
+		}
+		for _, m := range labelCmd.FindAllStringSubmatch(row, -1) {
+			r.labels[m[1]] = n
+		}
+	}
+
+	body = strings.TrimSpace(mathCleanup.ReplaceAllString(body, ""))
+	if wrap := mathEnvs[base]; wrap != "" {
+		body = `\begin{` + wrap + "}\n" + body + "\n" + `\end{` + wrap + "}"
+	}
+	n := ""
+	switch len(nums) {
+	case 0:
+	case 1:
+		n = nums[0]
+	default:
+		n = nums[0] + "-" + nums[len(nums)-1]
+	}
+	r.block(equationMarker(n) + "\n\n$$\n" + body + "\n$$")
+}
+
+var (
+	// labelCmd matches a \label and captures its key.
+	labelCmd = regexp.MustCompile(`\\label\s*\{([^}]*)\}`)
+	// mathCleanup matches the numbering commands removed from display math.
+	mathCleanup = regexp.MustCompile(`[ \t]*(?:\\label\s*\{[^}]*\}|\\tag\*?\s*\{[^}]*\}|\\nonumber\b|\\notag\b)`)
+)
+
+// float writes the caption of a figure or table environment, numbered the
+// way LaTeX numbers it. Floats without a caption are dropped.
+func (r *latexRenderer) float(env, body string) {
+	caption := commandArg(body, "caption")
+	if caption == "" {
+		return
+	}
+	kind, counter := "Figure", &r.figures
+	if strings.Contains(env, "table") {
+		kind, counter = "Table", &r.tables
+	}
+	*counter++
+	n := strconv.Itoa(*counter)
+	for _, m := range labelCmd.FindAllStringSubmatch(body, -1) {
+		r.labels[m[1]] = n
+	}
+	r.block("**" + kind + " " + n + ".** " + r.text(caption))
+}
+
+// list writes an itemize, enumerate, or description environment as a
+// Markdown list.
+func (r *latexRenderer) list(env, body string) {
+	var lines []string
+	for i, item := range splitTop(body, `\item`)[1:] {
+		term, rest, _ := optional(item, skipSpace(item, 0))
+		text := r.text(item[rest:])
+		if term != "" {
+			text = "**" + r.text(term) + "** " + text
+		}
+		marker := "- "
+		if env == "enumerate" {
+			marker = strconv.Itoa(i+1) + ". "
+		}
+		lines = append(lines, marker+text)
+	}
+	if len(lines) > 0 {
+		r.block(strings.Join(lines, "\n"))
+	}
+}
+
+// bibliography writes a thebibliography environment, usually from the
+// .bbl file, as a References section numbered in \bibitem order, and
+// numbers each \bibitem key for \cite.
+func (r *latexRenderer) bibliography(body string) {
+	var lines []string
+	for i, item := range splitTop(body, `\bibitem`)[1:] {
+		_, next, _ := optional(item, skipSpace(item, 0))
+		key, next, _ := group(item, next)
+		r.cites[strings.TrimSpace(key)] = i + 1
+		text := strings.ReplaceAll(item[next:], `\newblock`, " ")
+		lines = append(lines, fmt.Sprintf("[%d] %s", i+1, r.text(text)))
+	}
+	if len(lines) > 0 {
+		r.heading(2, "References")
+		r.block(strings.Join(lines, "\n"))
+	}
+}
+
+// text renders inline LaTeX as Markdown on one line. Inline math is kept
+// as $...$; \cite and \ref become placeholders that resolve replaces once
+// the whole document has been numbered.
+func (r *latexRenderer) text(s string) string {
+	var b strings.Builder
+	r.inline(&b, s)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func (r *latexRenderer) inline(b *strings.Builder, s string) {
+	for i := 0; i < len(s); {
+		switch c := s[i]; c {
+		case '$':
+			end := inlineMathEnd(s, i+1)
+			b.WriteString(s[i:end])
+			i = end
+		case '{':
+			inner, end, _ := group(s, i)
+			r.inline(b, inner)
+			i = end
+		case '}':
+			i++
+		case '~':
+			b.WriteByte(' ')
+			i++
+		case '`':
+			if strings.HasPrefix(s[i:], "``") {
+				b.WriteString("“")
+				i += 2
+			} else {
+				b.WriteString("‘")
+				i++
+			}
+		case '\'':
+			if strings.HasPrefix(s[i:], "''") {
+				b.WriteString("”")
+				i += 2
+			} else {
+				b.WriteString("’")
+				i++
+			}
+		case '-':
+			switch {
+			case strings.HasPrefix(s[i:], "---"):
+				b.WriteString("—")
+				i += 3
+			case strings.HasPrefix(s[i:], "--"):
+				b.WriteString("–")
+				i += 2
+			default:
+				b.WriteByte('-')
+				i++
+			}
+		case '\\':
+			i = r.command(b, s, i)
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+}
+
+// accents maps accent commands to Unicode combining marks.
+var accents = map[string]string{
+	"'": "́", "`": "̀", "^": "̂", "\"": "̈", "~": "̃",
+	"=": "̄", ".": "̇", "c": "̧", "v": "̌", "u": "̆",
+	"H": "̋", "k": "̨", "r": "̊",
+}
+
+// symbols maps text-mode commands to the characters they print.
+var symbols = map[string]string{
+	"%": "%", "&": "&", "_": "_", "#": "#", "$": "$", "{": "{", "}": "}",
+	"\\": " ", " ": " ", ",": " ", ";": " ", ":": " ", "!": "", "/": "", "-": "", "@": "",
+	"ss": "ß", "o": "ø", "O": "Ø", "ae": "æ", "AE": "Æ", "oe": "œ", "OE": "Œ",
+	"aa": "å", "AA": "Å", "l": "ł", "L": "Ł", "i": "ı", "j": "ȷ",
+	"ldots": "…", "dots": "…", "textellipsis": "…", "S": "§", "P": "¶",
+	"textendash": "–", "textemdash": "—", "LaTeX": "LaTeX", "TeX": "TeX",
+}
+
+// droppedArgs is the number of braced arguments removed with a command
+// that prints nothing useful in Markdown.
+var droppedArgs = map[string]int{
+	"vspace": 1, "hspace": 1, "includegraphics": 1, "bibliographystyle": 1,
+	"thanks": 1, "setlength": 2, "addtolength": 2, "setcounter": 2,
+	"newcommand": 2, "renewcommand": 2, "providecommand": 2, "newtheorem": 2,
+	"pagestyle": 1, "thispagestyle": 1, "usepackage": 1, "documentclass": 1,
+	"hypersetup": 1, "color": 1, "definecolor": 3, "begin": 1, "end": 1,
+	"input": 1, "include": 1, "author": 1, "title": 1, "date": 1,
+	"affiliation": 1, "institute": 1, "email": 1,
+}
+
+// command renders the control sequence at s[i] in text and returns the
+// index after it and its arguments.
+func (r *latexRenderer) command(b *strings.Builder, s string, i int) int {
+	name, end := cmdName(s, i)
+	if end < len(s) && s[end] == '*' && isLetter(name) {
+		end++
+	}
+	if mark, ok := accents[name]; ok && (!isLetter(name) || end < len(s) && (s[end] == '{' || s[end] == ' ')) {
+		arg, next, ok := group(s, end)
+		if !ok {
+			next = skipSpace(s, end)
+			if next < len(s) {
+				arg, next = s[next:next+1], next+1
+			}
+		}
+		if text := r.text(arg); text != "" {
+			_, size := firstRune(text)
+			b.WriteString(text[:size] + mark + text[size:])
+		}
+		return next
+	}
+	if sym, ok := symbols[name]; ok {
+		b.WriteString(sym)
+		if name == `\` {
+			_, end, _ = optional(s, end)
+		}
+		return end
+	}
+
+	switch name {
+	case "(":
+		close := strings.Index(s[end:], `\)`)
+		if close < 0 {
+			b.WriteString("$" + s[end:] + "$")
+			return len(s)
+		}
+		b.WriteString("$" + s[end:end+close] + "$")
+		return end + close + 2
+	case "emph", "textit", "textsl":
+		arg, next, _ := group(s, end)
+		b.WriteString("*" + r.text(arg) + "*")
+		return next
+	case "textbf":
+		arg, next, _ := group(s, end)
+		b.WriteString("**" + r.text(arg) + "**")
+		return next
+	case "texttt":
+		arg, next, _ := group(s, end)
+		b.WriteString("`" + r.text(arg) + "`")
+		return next
+	case "verb":
+		if end >= len(s) {
+			return end
+		}
+		close := strings.IndexByte(s[end+1:], s[end])
+		if close < 0 {
+			return len(s)
+		}
+		b.WriteString("`" + s[end+1:end+1+close] + "`")
+		return end + close + 2
+	case "url":
+		arg, next, _ := group(s, end)
+		b.WriteString(arg)
+		return next
+	case "href":
+		target, next, _ := group(s, end)
+		arg, next, _ := group(s, next)
+		b.WriteString("[" + r.text(arg) + "](" + target + ")")
+		return next
+	case "footnote":
+		_, next, _ := optional(s, end)
+		arg, next, _ := group(s, next)
+		b.WriteString(" (" + r.text(arg) + ")")
+		return next
+	case "cite", "citep", "citet", "citealp", "citealt", "citeauthor", "citeyear",
+		"parencite", "textcite", "autocite", "citenum":
+		_, next, _ := optional(s, end)
+		_, next, _ = optional(s, next)
+		keys, next, _ := group(s, next)
+		var refs []string
+		for _, key := range strings.Split(keys, ",") {
+			refs = append(refs, "[\x00cite{"+strings.TrimSpace(key)+"}\x00]")
+		}
+		b.WriteString(strings.Join(refs, ", "))
+		return next
+	case "ref", "autoref", "cref", "Cref", "eqref":
+		key, next, _ := group(s, end)
+		ref := "\x00ref{" + strings.TrimSpace(key) + "}\x00"
+		if name == "eqref" {
+			ref = "(" + ref + ")"
+		}
+		b.WriteString(ref)
+		return next
+	case "label":
+		key, next, _ := group(s, end)
+		if _, ok := r.labels[key]; !ok {
+			r.labels[key] = r.context
+		}
+		return next
+	case "item":
+		_, next, _ := optional(s, skipSpace(s, end))
+		b.WriteString("; ")
+		return next
+	}
+
+	next := end
+	for range droppedArgs[name] {
+		_, next, _ = optional(s, next)
+		_, next, _ = group(s, next)
+	}
+	// Other commands print nothing themselves; a braced argument that
+	// follows is kept as text, so \textsc{Bert} renders as Bert.
+	_, next, _ = optional(s, next)
+	return next
+}
+
+// placeholder matches the \cite and \ref placeholders text writes.
+var placeholder = regexp.MustCompile("\x00(cite|ref)\\{([^}]*)\\}\x00")
+
+// resolve replaces placeholders with bibliography and label numbers.
+// Unknown citation keys are kept as written; unknown labels become "?",
+// as in LaTeX.
+func (r *latexRenderer) resolve(md string) string {
+	return placeholder.ReplaceAllStringFunc(md, func(m string) string {
+		sub := placeholder.FindStringSubmatch(m)
+		if sub[1] == "cite" {
+			if n, ok := r.cites[sub[2]]; ok {
+				return strconv.Itoa(n)
+			}
+			return sub[2]
+		}
+		if n := r.labels[sub[2]]; n != "" {
+			return n
+		}
+		return "?"
+	})
+}
+
+// cmdName returns the name of the control sequence at s[i] (a run of
+// letters, or one other character) and the index after it.
+func cmdName(s string, i int) (string, int) {
+	j := i + 1
+	for j < len(s) && isLetterByte(s[j]) {
+		j++
+	}
+	if j == i+1 && j < len(s) {
+		j++
+	}
+	return s[i+1 : j], j
+}
+
+func isLetterByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// isLetter reports whether a command name is a control word.
+func isLetter(name string) bool {
+	return name != "" && isLetterByte(name[0])
+}
+
+func skipSpace(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+func firstRune(s string) (rune, int) {
+	for _, r := range s {
+		return r, len(string(r))
+	}
+	return 0, 0
+}
+
+// group returns the braced argument at or after s[i], skipping
+// whitespace, and the index after it. ok is false when no group follows.
+func group(s string, i int) (arg string, next int, ok bool) {
+	j := skipSpace(s, i)
+	if j >= len(s) || s[j] != '{' {
+		return "", i, false
+	}
+	depth := 0
+	for k := j; k < len(s); k++ {
+		switch s[k] {
+		case '\\':
+			k++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return s[j+1 : k], k + 1, true
+			}
+		}
+	}
+	return s[j+1:], len(s), true
+}
+
+// optional returns the bracketed optional argument at s[i] and the index
+// after it. ok is false when none starts there.
+func optional(s string, i int) (arg string, next int, ok bool) {
+	if i >= len(s) || s[i] != '[' {
+		return "", i, false
+	}
+	depth := 0
+	for k := i; k < len(s); k++ {
+		switch s[k] {
+		case '\\':
+			k++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ']':
+			if depth == 0 {
+				return s[i+1 : k], k + 1, true
+			}
+		}
+	}
+	return "", i, false
+}
+
+// commandArg returns the braced argument of the first \name in s, or "".
+func commandArg(s, name string) string {
+	for i := 0; ; {
+		k := strings.Index(s[i:], `\`+name)
+		if k < 0 {
+			return ""
+		}
+		i += k + 1 + len(name)
+		if i < len(s) && isLetterByte(s[i]) {
+			continue
+		}
+		_, next, _ := optional(s, skipSpace(s, i))
+		if arg, _, ok := group(s, next); ok {
+			return arg
+		}
+	}
+}
+
+// envBody returns the body of the environment name whose \begin ends at
+// s[i], and the index after its matching \end.
+func envBody(s string, i int, name string) (string, int) {
+	begin, end := `\begin{`+name+`}`, `\end{`+name+`}`
+	depth := 1
+	for j := i; j < len(s); {
+		e := strings.Index(s[j:], end)
+		if e < 0 {
+			break
+		}
+		if b := strings.Index(s[j:], begin); b >= 0 && b < e {
+			depth++
+			j += b + len(begin)
+			continue
+		}
+		if depth--; depth == 0 {
+			return s[i : j+e], j + e + len(end)
+		}
+		j += e + len(end)
+	}
+	return s[i:], len(s)
+}
+
+// inlineMathEnd returns the index after the $ that closes inline math
+// opened before s[i].
+func inlineMathEnd(s string, i int) int {
+	for ; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '$':
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// splitTop splits s at each sep (a control sequence such as \item or \\)
+// that is outside braces and nested environments.
+func splitTop(s, sep string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '\\':
+			switch {
+			case strings.HasPrefix(s[i:], `\begin{`):
+				depth++
+			case strings.HasPrefix(s[i:], `\end{`):
+				depth--
+			case depth == 0 && strings.HasPrefix(s[i:], sep) &&
+				(!isLetter(sep[1:]) || i+len(sep) >= len(s) || !isLetterByte(s[i+len(sep)])):
+				parts = append(parts, s[start:i])
+				start = i + len(sep)
+				i = start - 1
+				continue
+			}
+			i++
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,168 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleMainTeX = `\documentclass{article}
+\usepackage{amsmath}
+\title{Attention Is All You Need}
+\author{A. Vaswani}
+\begin{document}
+\maketitle
+\begin{abstract}
+The dominant sequence transduction models are complex.% trimmed
+\end{abstract}
+
+\section{Introduction}\label{sec:intro}
+\input{sections/intro}
+
+\section{Model}
+\subsection{Attention}
+An attention function maps a query:
+\begin{equation}
+  \mathrm{Attention}(Q, K, V) = \mathrm{softmax}\left(\frac{QK^T}{\sqrt{d_k}}\right)V
+  \label{eq:attention}
+\end{equation}
+as in Eq.~\eqref{eq:attention} and Section~\ref{sec:intro}.
+\begin{align}
+  a &= b + c \\
+  d &= e \nonumber \\
+  f &= g
+\end{align}
+Unnumbered: \[ x^2 \]
+\begin{figure}[t]
+  \includegraphics[width=\linewidth]{fig1.pdf}
+  \caption{The \emph{Transformer} architecture.}\label{fig:arch}
+\end{figure}
+\begin{itemize}
+  \item Encoder --- six layers
+  \item Decoder
+\end{itemize}
+
+\appendix
+\section{Proofs}
+\bibliography{refs}
+\end{document}
+`
+
+const sampleIntroTeX = `Recurrent models~\cite{hochreiter,graves} are ` + "``sequential''." + `
+% a comment line
+
+See Figure~\ref{fig:arch}.
+`
+
+const sampleBBL = `\begin{thebibliography}{2}
+\bibitem{hochreiter}
+S.~Hochreiter and J.~Schmidhuber.
+\newblock Long short-term memory.
+\newblock {\em Neural Computation}, 1997.
+\bibitem[Graves(2013)]{graves}
+A.~Graves.
+\newblock Generating sequences.
+\end{thebibliography}
+`
+
+// eprint returns a gzipped tar archive of files, as arXiv serves it.
+func eprint(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	zw.Close()
+	return buf.Bytes()
+}
+
+func TestLaTeXConverter(t *testing.T) {
+	archive := eprint(t, map[string]string{
+		"main.tex":           sampleMainTeX,
+		"sections/intro.tex": sampleIntroTeX,
+		"main.bbl":           sampleBBL,
+		"fig1.pdf":           "%PDF-1.5",
+	})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2301.07041" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(archive)
+	}))
+	defer ts.Close()
+	pdfPath, _ := setupPDF(t)
+
+	md, err := NewLaTeXConverter(ts.Client(), ts.URL, stubConverter{err: errNoText}).Convert(pdfPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Attention Is All You Need\n\n## Abstract\n\nThe dominant sequence transduction models are complex.\n\n",
+		"## 1 Introduction\n\nRecurrent models [1], [2] are “sequential”.\n\nSee Figure 1.\n\n",
+		"### 2.1 Attention\n\nAn attention function maps a query:\n\n" +
+			"<!-- equation 1 -->\n\n$$\n\\mathrm{Attention}(Q, K, V) = \\mathrm{softmax}\\left(\\frac{QK^T}{\\sqrt{d_k}}\\right)V\n$$\n\n" +
+			"as in Eq. (1) and Section 1.\n\n",
+		"<!-- equation 2-3 -->\n\n$$\n\\begin{aligned}\na &= b + c \\\\\n  d &= e \\\\\n  f &= g\n\\end{aligned}\n$$\n\n",
+		"Unnumbered:\n\n<!-- equation -->\n\n$$\nx^2\n$$\n\n",
+		"**Figure 1.** The *Transformer* architecture.\n\n- Encoder — six layers\n- Decoder\n\n",
+		"## A Proofs\n\n## References\n\n[1] S. Hochreiter and J. Schmidhuber. Long short-term memory. Neural Computation, 1997.\n[2] A. Graves. Generating sequences.\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestLaTeXConverterFallback(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("%PDF-1.5 no source"))
+	}))
+	defer ts.Close()
+	fallback := stubConverter{md: "from the PDF\n"}
+
+	arxivPDF, _ := setupPDF(t)
+	other := filepath.Join(t.TempDir(), "raw", "10.1000-xyz.pdf")
+	os.MkdirAll(filepath.Dir(other), 0o755)
+	os.WriteFile(other, []byte("fake pdf"), 0o644)
+
+	for _, path := range []string{arxivPDF, other} {
+		md, err := NewLaTeXConverter(ts.Client(), ts.URL, fallback).Convert(path)
+		if err != nil || md != "from the PDF\n" {
+			t.Errorf("%s: Convert = %q, %v; want the fallback output", path, md, err)
+		}
+	}
+}
+
+func TestArxivIDFor(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "metadata"), 0o755)
+	os.WriteFile(filepath.Join(dir, "metadata", "10.18653-v1-2020.acl-main.1.yaml"), []byte("id: x\narxiv_id: \"2004.10151\"\n"), 0o644)
+
+	for name, want := range map[string]string{
+		"2301.07041":                  "2301.07041",
+		"2301.07041v2":                "2301.07041v2",
+		"hep-th-9901001":              "hep-th/9901001",
+		"math.AG-0501001":             "math.AG/0501001",
+		"10.18653-v1-2020.acl-main.1": "2004.10151",
+		"10.1038-nature14539":         "",
+	} {
+		if got := arxivIDFor(filepath.Join(dir, "raw", name+".pdf")); got != want {
+			t.Errorf("arxivIDFor(%s) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/pdiddy/research-engine/internal/container"
 )
@@ -43,8 +44,8 @@ func (m *MarkitdownConverter) Convert(pdfPath string) (string, error) {
 		return "", fmt.Errorf("converting %s with markitdown: %w", pdfPath, err)
 	}
 
-	if out.Len() == 0 {
-		return "", fmt.Errorf("markitdown produced empty output for %s", pdfPath)
+	if strings.TrimSpace(out.String()) == "" {
+		return "", fmt.Errorf("markitdown produced empty output for %s: %w", pdfPath, errNoText)
 	}
 
 	return out.String(), nil
@@ -0,0 +1,528 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// NativeConverter extracts the text of a PDF in Go, without an external
+// tool or server (R2.11). It is the fallback when the configured backend
+// is missing. The output is plain text with <!-- page N --> markers and
+// paragraph breaks; it has no headings or tables. Scanned PDFs yield no
+// text unless an OCR engine is attached with WithOCR.
+type NativeConverter struct {
+	ocr PageOCR
+}
+
+// NewNativeConverter creates a native text extraction converter.
+func NewNativeConverter() *NativeConverter {
+	return &NativeConverter{}
+}
+
+// WithOCR attaches an OCR engine that recognises pages with no
+// extractable text (R2.12) and returns the converter.
+func (n *NativeConverter) WithOCR(o PageOCR) *NativeConverter {
+	n.ocr = o
+	return n
+}
+
+// Convert reads the PDF at pdfPath and returns its text as Markdown.
+func (n *NativeConverter) Convert(pdfPath string) (string, error) {
+	data, err := os.ReadFile(pdfPath)
+	if err != nil {
+		return "", fmt.Errorf("opening PDF %s: %w", pdfPath, err)
+	}
+	f, err := parsePDF(data)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", pdfPath, err)
+	}
+	pages := f.pages()
+	if len(pages) == 0 {
+		return "", fmt.Errorf("reading %s: no pages found", pdfPath)
+	}
+
+	var b strings.Builder
+	hasText := false
+	for i, page := range pages {
+		var t textWriter
+		f.interpret(&t, f.contents(page), page["Resources"], 0)
+		text := t.String()
+		fmt.Fprintf(&b, "<!-- page %d -->\n\n", i+1)
+		if text == "" && n.ocr != nil {
+			if text, err = n.ocr.OCRPage(pdfPath, i+1); err != nil {
+				return "", fmt.Errorf("OCR of page %d of %s: %w", i+1, pdfPath, err)
+			}
+			if text != "" {
+				b.WriteString(ocrMarker)
+			}
+		}
+		if text != "" {
+			b.WriteString(text)
+			b.WriteString("\n\n")
+			hasText = true
+		}
+	}
+	if !hasText {
+		if n.ocr != nil {
+			return "", fmt.Errorf("%w in %s, even with OCR", errNoText, pdfPath)
+		}
+		return "", fmt.Errorf("%w in %s (scanned or image-only PDF? OCR needs pdftoppm and tesseract)", errNoText, pdfPath)
+	}
+	return b.String(), nil
+}
+
+// textWriter accumulates the text shown on a page, breaking lines when the
+// text position moves down and paragraphs when it moves down further than
+// the usual line spacing.
+type textWriter struct {
+	b       strings.Builder
+	y       float64
+	hasY    bool
+	leading float64
+}
+
+func (t *textWriter) write(s string) {
+	t.b.WriteString(s)
+}
+
+// space separates words unless the text already ends with whitespace.
+func (t *textWriter) space() {
+	s := t.b.String()
+	if s != "" && !strings.HasSuffix(s, " ") && !strings.HasSuffix(s, "\n") {
+		t.b.WriteByte(' ')
+	}
+}
+
+// moveTo records a new baseline at y, starting a line or, after a gap
+// larger than the usual line spacing, a paragraph.
+func (t *textWriter) moveTo(y float64) {
+	if !t.hasY {
+		t.y, t.hasY = y, true
+		return
+	}
+	gap := math.Abs(t.y - y)
+	t.y = y
+	if gap < 1 {
+		t.space()
+		return
+	}
+	if t.leading > 0 && gap > 1.6*t.leading {
+		t.newline(true)
+		return
+	}
+	if t.leading == 0 || gap < t.leading {
+		t.leading = gap
+	}
+	t.newline(false)
+}
+
+func (t *textWriter) newline(paragraph bool) {
+	s := t.b.String()
+	if s == "" {
+		return
+	}
+	s = strings.TrimRight(s, " ")
+	t.b.Reset()
+	t.b.WriteString(s)
+	switch {
+	case paragraph && !strings.HasSuffix(s, "\n\n"):
+		t.b.WriteString(strings.Repeat("\n", 2-trailingNewlines(s)))
+	case !strings.HasSuffix(s, "\n"):
+		t.b.WriteByte('\n')
+	}
+}
+
+func trailingNewlines(s string) int {
+	return len(s) - len(strings.TrimRight(s, "\n"))
+}
+
+var hyphenBreak = regexp.MustCompile(`(\p{Ll})-\n(\p{Ll})`)
+
+// String returns the page text with words hyphenated across lines joined.
+func (t *textWriter) String() string {
+	return cleanText(t.b.String())
+}
+
+// cleanText joins words hyphenated across lines and collapses runs of
+// spaces within each line.
+func cleanText(s string) string {
+	s = hyphenBreak.ReplaceAllString(s, "$1$2")
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		lines = append(lines, strings.Join(strings.Fields(line), " "))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// inlineImageEnd matches the EI that ends inline image data.
+var inlineImageEnd = regexp.MustCompile(`[\s]EI[\s]`)
+
+// interpret runs the text operators of a content stream, writing the text
+// shown to t. Form XObjects are followed to a bounded depth.
+func (f *pdfFile) interpret(t *textWriter, content []byte, resources any, depth int) {
+	res := f.dict(resources)
+	fonts := f.dict(res["Font"])
+	cache := map[pdfName]*pdfFont{}
+	var font *pdfFont
+	var y float64
+
+	l := &pdfLexer{data: content}
+	var operands []any
+	for {
+		v, err := l.next()
+		if err != nil {
+			return
+		}
+		op, ok := v.(pdfOperator)
+		if !ok {
+			operands = append(operands, v)
+			continue
+		}
+		switch op {
+		case "BI":
+			// Skip the inline image's data up to EI.
+			if loc := inlineImageEnd.FindIndex(content[l.pos:]); loc != nil {
+				l.pos += loc[1]
+			} else {
+				return
+			}
+		case "Tf":
+			if len(operands) >= 2 {
+				if name, ok := operands[0].(pdfName); ok {
+					if cache[name] == nil {
+						cache[name] = f.font(fonts[name])
+					}
+					font = cache[name]
+				}
+			}
+		case "BT":
+			y = t.y
+		case "Tm":
+			if len(operands) >= 6 {
+				y = number(operands[5])
+				t.moveTo(y)
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				if ty := number(operands[1]); ty != 0 {
+					y += ty
+					t.moveTo(y)
+				} else if number(operands[0]) > 0 {
+					t.space()
+				}
+			}
+		case "T*":
+			t.newline(false)
+		case "Tj", "'", "\"":
+			if op != "Tj" {
+				t.newline(false)
+			}
+			if len(operands) > 0 {
+				if s, ok := operands[len(operands)-1].([]byte); ok {
+					t.write(font.decode(s))
+				}
+			}
+		case "TJ":
+			if len(operands) > 0 {
+				arr, _ := operands[len(operands)-1].([]any)
+				for _, e := range arr {
+					switch e := e.(type) {
+					case []byte:
+						t.write(font.decode(e))
+					case float64:
+						// A large negative adjustment is a word gap.
+						if e < -180 {
+							t.space()
+						}
+					}
+				}
+			}
+		case "Do":
+			if len(operands) > 0 && depth < 8 {
+				if name, ok := operands[0].(pdfName); ok {
+					if xo, ok := f.resolve(f.dict(res["XObject"])[name]).(pdfStream); ok && xo.dict["Subtype"] == pdfName("Form") {
+						if data, err := f.decode(xo); err == nil {
+							inner := xo.dict["Resources"]
+							if inner == nil {
+								inner = resources
+							}
+							f.interpret(t, data, inner, depth+1)
+						}
+					}
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+}
+
+func number(v any) float64 {
+	n, _ := v.(float64)
+	return n
+}
+
+// pdfFont maps the character codes of a font to text: through its
+// ToUnicode CMap when it has one, otherwise through its encoding
+// differences and WinAnsi.
+type pdfFont struct {
+	toUnicode map[string]string
+	codeLens  []int
+	composite bool
+	macRoman  bool
+	diffs     map[byte]string
+}
+
+// font builds the decoder of a font dictionary.
+func (f *pdfFile) font(v any) *pdfFont {
+	d := f.dict(v)
+	font := &pdfFont{composite: d["Subtype"] == pdfName("Type0")}
+	if cmap, ok := f.resolve(d["ToUnicode"]).(pdfStream); ok {
+		if data, err := f.decode(cmap); err == nil {
+			font.parseCMap(data)
+		}
+	}
+	base := f.resolve(d["Encoding"])
+	if enc := f.dict(d["Encoding"]); enc != nil {
+		base = f.resolve(enc["BaseEncoding"])
+		font.diffs = map[byte]string{}
+		code := 0
+		for _, e := range f.array(enc["Differences"]) {
+			switch e := f.resolve(e).(type) {
+			case float64:
+				code = int(e)
+			case pdfName:
+				if code >= 0 && code < 256 {
+					if s := glyphText(string(e)); s != "" {
+						font.diffs[byte(code)] = s
+					}
+				}
+				code++
+			}
+		}
+	}
+	font.macRoman = base == pdfName("MacRomanEncoding")
+	return font
+}
+
+// parseCMap reads the codespace ranges and bfchar/bfrange mappings of a
+// ToUnicode CMap.
+func (font *pdfFont) parseCMap(data []byte) {
+	font.toUnicode = map[string]string{}
+	lens := map[int]bool{}
+	l := &pdfLexer{data: data}
+	var operands []any
+	for {
+		v, err := l.next()
+		if err != nil {
+			break
+		}
+		op, ok := v.(pdfOperator)
+		if !ok {
+			operands = append(operands, v)
+			continue
+		}
+		switch op {
+		case "endcodespacerange":
+			for _, o := range operands {
+				if b, ok := o.([]byte); ok && len(b) > 0 {
+					lens[len(b)] = true
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].([]byte)
+				dst, ok2 := operands[i+1].([]byte)
+				if ok1 && ok2 {
+					font.toUnicode[string(src)] = utf16Text(dst)
+					lens[len(src)] = true
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, ok1 := operands[i].([]byte)
+				hi, ok2 := operands[i+1].([]byte)
+				if !ok1 || !ok2 || len(lo) != len(hi) || len(lo) == 0 {
+					continue
+				}
+				lens[len(lo)] = true
+				start, end := codeValue(lo), codeValue(hi)
+				if end < start || end-start > 0xffff {
+					continue
+				}
+				for c := start; c <= end; c++ {
+					src := codeBytes(c, len(lo))
+					switch dst := operands[i+2].(type) {
+					case []byte:
+						font.toUnicode[string(src)] = utf16Text(offsetUTF16(dst, int(c-start)))
+					case []any:
+						if int(c-start) < len(dst) {
+							if b, ok := dst[c-start].([]byte); ok {
+								font.toUnicode[string(src)] = utf16Text(b)
+							}
+						}
+					}
+				}
+			}
+		}
+		if strings.HasPrefix(string(op), "end") || strings.HasPrefix(string(op), "begin") {
+			operands = operands[:0]
+		}
+	}
+	for n := range lens {
+		font.codeLens = append(font.codeLens, n)
+	}
+	sort.Ints(font.codeLens)
+}
+
+func codeValue(b []byte) uint32 {
+	var v uint32
+	for _, c := range b {
+		v = v<<8 | uint32(c)
+	}
+	return v
+}
+
+func codeBytes(v uint32, n int) []byte {
+	b := make([]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	return b
+}
+
+// offsetUTF16 adds off to the last code unit of a UTF-16BE string, as
+// bfrange destinations increment.
+func offsetUTF16(b []byte, off int) []byte {
+	if len(b) < 2 {
+		return b
+	}
+	out := append([]byte(nil), b...)
+	v := int(out[len(out)-2])<<8 | int(out[len(out)-1]) + off
+	out[len(out)-2], out[len(out)-1] = byte(v>>8), byte(v)
+	return out
+}
+
+func utf16Text(b []byte) string {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+// decode returns the text of a string shown in the font. Codes a composite
+// font without a ToUnicode CMap uses cannot be mapped and are dropped.
+func (font *pdfFont) decode(s []byte) string {
+	if font == nil {
+		return simpleText(s, nil, false)
+	}
+	if font.toUnicode != nil && len(font.codeLens) > 0 {
+		var b strings.Builder
+		for i := 0; i < len(s); {
+			matched := false
+			for _, n := range font.codeLens {
+				if i+n <= len(s) {
+					if text, ok := font.toUnicode[string(s[i:i+n])]; ok {
+						b.WriteString(text)
+						i += n
+						matched = true
+						break
+					}
+				}
+			}
+			if !matched {
+				i += font.codeLens[0]
+			}
+		}
+		return b.String()
+	}
+	if font.composite {
+		return ""
+	}
+	return simpleText(s, font.diffs, font.macRoman)
+}
+
+// winAnsiDiffs are the WinAnsiEncoding codes that differ from Latin-1.
+var winAnsiDiffs = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡',
+	0x88: 'ˆ', 0x89: '‰', 0x8a: 'Š', 0x8b: '‹', 0x8c: 'Œ', 0x8e: 'Ž', 0x91: '‘',
+	0x92: '’', 0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—', 0x98: '˜',
+	0x99: '™', 0x9a: 'š', 0x9b: '›', 0x9c: 'œ', 0x9e: 'ž', 0x9f: 'Ÿ',
+}
+
+// macRomanHigh is MacRomanEncoding from 0x80 to 0xFF.
+var macRomanHigh = []rune("ÄÅÇÉÑÖÜáàâäãåçéèêëíìîïñóòôöõúùûü†°¢£§•¶ß®©™´¨≠ÆØ∞±≤≥¥µ∂∑∏π∫ªºΩæø¿¡¬√ƒ≈∆«»…\u00a0ÀÃÕŒœ–—“”‘’÷◊ÿŸ⁄€‹›ﬁﬂ‡·‚„‰ÂÊÁËÈÍÎÏÌÓÔ\uf8ffÒÚÛÙıˆ˜¯˘˙˚¸˝˛ˇ")
+
+// simpleText decodes a simple font's string through its differences,
+// then MacRoman or WinAnsi.
+func simpleText(s []byte, diffs map[byte]string, macRoman bool) string {
+	var b strings.Builder
+	for _, c := range s {
+		if text, ok := diffs[c]; ok {
+			b.WriteString(text)
+			continue
+		}
+		switch r, ok := winAnsiDiffs[c]; {
+		case macRoman && c >= 0x80:
+			b.WriteRune(macRomanHigh[c-0x80])
+		case ok:
+			b.WriteRune(r)
+		case c >= 0x20 && c != 0x7f:
+			b.WriteRune(rune(c))
+		}
+	}
+	return b.String()
+}
+
+// glyphNames maps the glyph names of encoding differences that are not a
+// single character, "uniXXXX", or a name with a suffix.
+var glyphNames = map[string]string{
+	"space": " ", "exclam": "!", "quotedbl": "\"", "numbersign": "#", "dollar": "$",
+	"percent": "%", "ampersand": "&", "quotesingle": "'", "quoteright": "’", "quoteleft": "‘",
+	"parenleft": "(", "parenright": ")", "asterisk": "*", "plus": "+", "comma": ",",
+	"hyphen": "-", "period": ".", "slash": "/", "colon": ":", "semicolon": ";",
+	"less": "<", "equal": "=", "greater": ">", "question": "?", "at": "@",
+	"bracketleft": "[", "backslash": "\\", "bracketright": "]", "asciicircum": "^",
+	"underscore": "_", "grave": "`", "braceleft": "{", "bar": "|", "braceright": "}",
+	"asciitilde": "~", "zero": "0", "one": "1", "two": "2", "three": "3", "four": "4",
+	"five": "5", "six": "6", "seven": "7", "eight": "8", "nine": "9",
+	"fi": "fi", "fl": "fl", "ff": "ff", "ffi": "ffi", "ffl": "ffl",
+	"endash": "–", "emdash": "—", "bullet": "•", "quotedblleft": "“", "quotedblright": "”",
+	"dotlessi": "ı", "germandbls": "ß", "minus": "−", "multiply": "×", "divide": "÷",
+	"degree": "°", "section": "§", "paragraph": "¶", "dagger": "†", "daggerdbl": "‡",
+	"ellipsis": "…", "copyright": "©", "registered": "®", "trademark": "™",
+}
+
+// glyphText returns the text of a glyph name, or "" when it is unknown.
+func glyphText(name string) string {
+	if base, _, ok := strings.Cut(name, "."); ok && base != "" {
+		name = base
+	}
+	if utf8.RuneCountInString(name) == 1 {
+		return name
+	}
+	if text, ok := glyphNames[name]; ok {
+		return text
+	}
+	for _, prefix := range []string{"uni", "u"} {
+		if hexCode, ok := strings.CutPrefix(name, prefix); ok && len(hexCode) >= 4 && len(hexCode) <= 6 {
+			if v, err := strconv.ParseUint(hexCode[:4], 16, 32); err == nil && prefix == "uni" {
+				return string(rune(v))
+			}
+			if v, err := strconv.ParseUint(hexCode, 16, 32); err == nil && prefix == "u" {
+				return string(rune(v))
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buildPDF writes a PDF whose objects are numbered from 1 in order, with
+// a cross-reference table and a trailer naming object 1 as the catalog.
+func buildPDF(t *testing.T, objects []string) string {
+	t.Helper()
+	var b strings.Builder
+	b.WriteString("%PDF-1.5\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	path := filepath.Join(t.TempDir(), "paper.pdf")
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// stream renders a stream object, Flate-compressed when compress is set.
+func stream(content string, compress bool) string {
+	if !compress {
+		return fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content)
+	}
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write([]byte(content))
+	w.Close()
+	return fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", buf.Len(), buf.String())
+}
+
+func TestNativeConverter(t *testing.T) {
+	cmap := `/CIDInit /ProcSet findresource begin
+begincmap
+1 begincodespacerange <0000> <FFFF> endcodespacerange
+1 beginbfchar <0010> <0066006C> endbfchar
+1 beginbfrange <0020> <0039> <0041> endbfrange
+endcmap`
+	path := buildPDF(t, []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		// The page tree order, not the object order, gives the pages.
+		"<< /Type /Pages /Kids [4 0 R 3 0 R] /Count 2 /Resources << /Font << /F1 5 0 R /F2 6 0 R /F3 8 0 R >> >> >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 7 0 R >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 9 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type0 /BaseFont /Foo /Encoding /Identity-H /ToUnicode 10 0 R >>",
+		stream("BT /F2 12 Tf 72 700 Td [<00200021> -300 <0022>] TJ ET", true),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /CMR10 /Encoding << /Differences [12 /fi /quoteright] >> >>",
+		stream("BT /F1 12 Tf 72 700 Td (Attention is all you) Tj 0 -14 Td (need for trans-) Tj 0 -14 Td (lation \\(MT\\).) Tj 0 -40 Td /F3 12 Tf (A \\014eld\\015s scope) Tj ET", false),
+		stream(cmap, true),
+	})
+
+	md, err := NewNativeConverter().Convert(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<!-- page 1 -->\n\n" +
+		"Attention is all you\nneed for translation (MT).\n\nA field’s scope\n\n" +
+		"<!-- page 2 -->\n\nAB C\n\n"
+	if md != want {
+		t.Errorf("Markdown =\n%q\nwant\n%q", md, want)
+	}
+}
+
+func TestNativeConverterErrors(t *testing.T) {
+	empty := buildPDF(t, []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Resources << >> >>",
+	})
+	if _, err := NewNativeConverter().Convert(empty); err == nil || !strings.Contains(err.Error(), "no extractable text") {
+		t.Errorf("image-only PDF: err = %v", err)
+	}
+
+	notPDF := filepath.Join(t.TempDir(), "page.pdf")
+	os.WriteFile(notPDF, []byte("<html></html>"), 0o644)
+	if _, err := NewNativeConverter().Convert(notPDF); err == nil || !strings.Contains(err.Error(), "not a PDF") {
+		t.Errorf("HTML file: err = %v", err)
+	}
+}
+
+func TestGlyphText(t *testing.T) {
+	for name, want := range map[string]string{"a": "a", "A.sc": "A", "ffi": "ffi", "uni00E9": "é", "u1D400": "𝐀", "zero": "0", "g123": ""} {
+		if got := glyphText(name); got != want {
+			t.Errorf("glyphText(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
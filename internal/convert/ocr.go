@@ -0,0 +1,141 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	binPdftoppm  = "pdftoppm"
+	binTesseract = "tesseract"
+
+	// DefaultOCRLang is the Tesseract language used when none is configured.
+	DefaultOCRLang = "eng"
+
+	// ocrDPI is the resolution pages are rendered at before recognition;
+	// Tesseract is most accurate on text around 300 DPI.
+	ocrDPI = 300
+
+	// ocrMarker follows the page marker of a page whose text came from OCR,
+	// so readers and extraction know to expect recognition errors.
+	ocrMarker = "<!-- ocr -->\n\n"
+)
+
+// PageOCR recognises the text of a single PDF page rendered as an image.
+type PageOCR interface {
+	// OCRPage returns the text of page (1-based) of the PDF at pdfPath.
+	OCRPage(pdfPath string, page int) (string, error)
+}
+
+// commandRunner runs an external command, piping stdin and stdout.
+type commandRunner func(name string, args []string, stdin io.Reader, stdout io.Writer) error
+
+// runCommand is the production commandRunner. A failing command's error
+// carries the last line it wrote to stderr.
+func runCommand(name string, args []string, stdin io.Reader, stdout io.Writer) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+		if msg := lines[len(lines)-1]; msg != "" {
+			return fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// TesseractOCR renders a page with pdftoppm (poppler-utils) and recognises
+// it with the tesseract binary (R2.12).
+type TesseractOCR struct {
+	lang string
+	run  commandRunner
+}
+
+// NewTesseractOCR creates an OCR engine for the given Tesseract language
+// (e.g. "eng", "deu+eng"). It verifies that pdftoppm and tesseract are on
+// PATH before returning.
+func NewTesseractOCR(lang string) (*TesseractOCR, error) {
+	for _, bin := range []string{binPdftoppm, binTesseract} {
+		if _, err := exec.LookPath(bin); err != nil {
+			return nil, fmt.Errorf("OCR requires %s on PATH (install poppler-utils and tesseract-ocr): %w", bin, err)
+		}
+	}
+	if lang == "" {
+		lang = DefaultOCRLang
+	}
+	return &TesseractOCR{lang: lang, run: runCommand}, nil
+}
+
+// OCRPage renders the page as a grayscale PNG and returns Tesseract's text
+// for it, with hyphenated line breaks joined and paragraphs separated by a
+// single blank line.
+func (o *TesseractOCR) OCRPage(pdfPath string, page int) (string, error) {
+	n := strconv.Itoa(page)
+	var png bytes.Buffer
+	args := []string{"-f", n, "-l", n, "-r", strconv.Itoa(ocrDPI), "-gray", "-png", pdfPath}
+	if err := o.run(binPdftoppm, args, nil, &png); err != nil {
+		return "", fmt.Errorf("rendering page %d: %w", page, err)
+	}
+	if png.Len() == 0 {
+		return "", fmt.Errorf("rendering page %d: %s produced no image", page, binPdftoppm)
+	}
+
+	var text bytes.Buffer
+	if err := o.run(binTesseract, []string{"stdin", "stdout", "-l", o.lang}, &png, &text); err != nil {
+		return "", fmt.Errorf("recognising page %d: %w", page, err)
+	}
+	return blankRuns.ReplaceAllString(cleanText(text.String()), "\n\n"), nil
+}
+
+// blankRuns matches two or more consecutive blank lines.
+var blankRuns = regexp.MustCompile(`\n{3,}`)
+
+// errNoText marks a backend result with no text in it, which OCRFallback
+// retries with OCR.
+var errNoText = errors.New("no extractable text")
+
+// OCRFallback converts with a primary backend and, when the result has no
+// text (a scanned PDF the backend could not read), converts again with the
+// native extractor and OCR (R2.12).
+type OCRFallback struct {
+	primary Converter
+	native  *NativeConverter
+}
+
+// NewOCRFallback wraps primary so that PDFs it returns no text for are
+// recognised with ocr instead.
+func NewOCRFallback(primary Converter, ocr PageOCR) *OCRFallback {
+	return &OCRFallback{primary: primary, native: NewNativeConverter().WithOCR(ocr)}
+}
+
+// Convert returns the primary backend's Markdown, or the OCR result when
+// the backend found no text or returned nothing but whitespace and
+// comments.
+func (o *OCRFallback) Convert(pdfPath string) (string, error) {
+	md, err := o.primary.Convert(pdfPath)
+	if (err != nil && !errors.Is(err, errNoText)) || (err == nil && hasText(md)) {
+		return md, err
+	}
+	return o.native.Convert(pdfPath)
+}
+
+// htmlComment matches an HTML comment such as a page marker.
+var htmlComment = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// hasText reports whether md contains anything besides whitespace and
+// HTML comments.
+func hasText(md string) bool {
+	return strings.TrimSpace(htmlComment.ReplaceAllString(md, "")) != ""
+}
@@ -0,0 +1,127 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// fakeOCR returns canned text per page and records the pages asked for.
+type fakeOCR struct {
+	text  map[int]string
+	err   error
+	pages []int
+}
+
+func (f *fakeOCR) OCRPage(pdfPath string, page int) (string, error) {
+	f.pages = append(f.pages, page)
+	return f.text[page], f.err
+}
+
+func TestTesseractOCR(t *testing.T) {
+	var calls []string
+	o := &TesseractOCR{lang: "deu+eng", run: func(name string, args []string, stdin io.Reader, stdout io.Writer) error {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		switch name {
+		case binPdftoppm:
+			fmt.Fprint(stdout, "PNG")
+		case binTesseract:
+			data, _ := io.ReadAll(stdin)
+			if string(data) != "PNG" {
+				return errors.New("tesseract got no image")
+			}
+			fmt.Fprint(stdout, "Scanned  trans-\nlation text\n\n\n\nSecond para\n\f")
+		}
+		return nil
+	}}
+
+	text, err := o.OCRPage("paper.pdf", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Scanned translation text\n\nSecond para"; text != want {
+		t.Errorf("text = %q, want %q", text, want)
+	}
+	want := []string{
+		"pdftoppm -f 3 -l 3 -r 300 -gray -png paper.pdf",
+		"tesseract stdin stdout -l deu+eng",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("commands =\n%s\nwant\n%s", strings.Join(calls, "\n"), strings.Join(want, "\n"))
+	}
+
+	o.run = func(name string, args []string, stdin io.Reader, stdout io.Writer) error {
+		return errors.New("exit status 1")
+	}
+	if _, err := o.OCRPage("paper.pdf", 1); err == nil || !strings.Contains(err.Error(), "rendering page 1") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestNativeConverterOCR(t *testing.T) {
+	path := buildPDF(t, []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /Resources << /Font << /F1 5 0 R >> >> >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 6 0 R >>",
+		"<< /Type /Page /Parent 2 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		stream("BT /F1 12 Tf 72 700 Td (Typed page) Tj ET", false),
+	})
+
+	ocr := &fakeOCR{text: map[int]string{2: "Scanned page"}}
+	md, err := NewNativeConverter().WithOCR(ocr).Convert(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<!-- page 1 -->\n\nTyped page\n\n<!-- page 2 -->\n\n<!-- ocr -->\n\nScanned page\n\n"
+	if md != want {
+		t.Errorf("Markdown =\n%q\nwant\n%q", md, want)
+	}
+	if len(ocr.pages) != 1 || ocr.pages[0] != 2 {
+		t.Errorf("OCR pages = %v, want [2]", ocr.pages)
+	}
+
+	ocr = &fakeOCR{err: errors.New("tesseract: exit status 1")}
+	if _, err := NewNativeConverter().WithOCR(ocr).Convert(path); err == nil || !strings.Contains(err.Error(), "OCR of page 2") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+// stubConverter returns fixed Markdown and error.
+type stubConverter struct {
+	md  string
+	err error
+}
+
+func (s stubConverter) Convert(string) (string, error) { return s.md, s.err }
+
+func TestOCRFallback(t *testing.T) {
+	path := buildPDF(t, []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R >>",
+	})
+	ocr := &fakeOCR{text: map[int]string{1: "Scanned page"}}
+	ocrMD := "<!-- page 1 -->\n\n<!-- ocr -->\n\nScanned page\n\n"
+
+	for _, tc := range []struct {
+		name    string
+		primary stubConverter
+		want    string
+		wantErr bool
+	}{
+		{"backend text kept", stubConverter{md: "# Title\n"}, "# Title\n", false},
+		{"whitespace output", stubConverter{md: "\n<!-- page 1 -->\n\n"}, ocrMD, false},
+		{"no text error", stubConverter{err: fmt.Errorf("empty output: %w", errNoText)}, ocrMD, false},
+		{"other error", stubConverter{err: errors.New("HTTP 503")}, "", true},
+	} {
+		md, err := NewOCRFallback(tc.primary, ocr).Convert(path)
+		if (err != nil) != tc.wantErr || md != tc.want {
+			t.Errorf("%s: Convert = %q, %v; want %q", tc.name, md, err, tc.want)
+		}
+	}
+}
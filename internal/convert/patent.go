@@ -0,0 +1,400 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// patentSources are the metadata sources acquisition records for patents:
+// PatentsView for US patents and EPO Open Patent Services for the rest.
+var patentSources = map[string]bool{"patentsview": true, "epo-ops": true}
+
+// PatentConverter applies the patent profile (R2.14) to the output of
+// another converter. Patents have a front page, drawing sheets, a
+// description of numbered paragraphs, and numbered claims rather than
+// academic sections; the profile gives them "## Abstract", "## Drawings",
+// "## Description", and "## Claims" sections and tags each claim with a
+// <!-- claim N --> marker so extraction can target claims. Papers whose
+// metadata does not mark them as patents pass through unchanged.
+type PatentConverter struct {
+	inner Converter
+}
+
+// NewPatentConverter returns a converter that applies the patent profile
+// to inner's output for patents.
+func NewPatentConverter(inner Converter) *PatentConverter {
+	return &PatentConverter{inner: inner}
+}
+
+// Convert converts pdfPath with the inner converter and restructures the
+// output when the paper is a patent.
+func (p *PatentConverter) Convert(pdfPath string) (string, error) {
+	md, err := p.inner.Convert(pdfPath)
+	if err != nil {
+		return "", err
+	}
+	if meta, ok := paperMetadata(pdfPath); !ok || !patentSources[meta.Source] {
+		return md, nil
+	}
+	return patentMarkdown(md), nil
+}
+
+// paperMetadata reads the metadata record acquisition wrote for the PDF
+// at pdfPath (papers/raw/<id>.pdf has papers/metadata/<id>.yaml).
+func paperMetadata(pdfPath string) (types.Paper, bool) {
+	var p types.Paper
+	base := strings.TrimSuffix(filepath.Base(pdfPath), filepath.Ext(pdfPath))
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(filepath.Dir(pdfPath)), metadataDir, base+".yaml"))
+	if err != nil || yaml.Unmarshal(data, &p) != nil {
+		return p, false
+	}
+	return p, true
+}
+
+// Patent parts, in the order a US grant prints them. Applications and EP
+// documents move the abstract or drawings after the claims.
+type patentPart int
+
+const (
+	partFront patentPart = iota
+	partAbstract
+	partDrawings
+	partDescription
+	partClaims
+)
+
+var partHeadings = map[patentPart]string{
+	partAbstract:    "Abstract",
+	partDrawings:    "Drawings",
+	partDescription: "Description",
+	partClaims:      "Claims",
+}
+
+var (
+	// claimsIntro matches the phrase that opens a claim set, such as
+	// "What is claimed is:" or "We claim:".
+	claimsIntro = regexp.MustCompile(`(?i)\b(?:what is claimed is|(?:the invention|what) (?:is )?claimed is|(?:i|we) claim)\s*:`)
+	// claimsHeading matches a heading line that opens a claim set.
+	claimsHeading = regexp.MustCompile(`(?i)^claims?:?$`)
+	// abstractHeading matches the abstract heading, with the INID code
+	// (57) of a front page.
+	abstractHeading = regexp.MustCompile(`(?i)^(?:\(57\)\s*)?abstract(?: of the disclosure)?$`)
+	// descriptionHeading matches the standard headings of a patent
+	// specification.
+	descriptionHeading = regexp.MustCompile(`(?i)^(?:description|(?:technical )?field\b.*|background\b.*|cross[- ]references?\b.*|related applications?|summary\b.*|(?:brief|detailed) description\b.*|statement regarding\b.*)$`)
+	// paragraphNumber matches the [0012] number leading a numbered
+	// paragraph.
+	paragraphNumber = regexp.MustCompile(`^[\[(]\d{4,5}[\])]\s`)
+	// sheetHeader matches the "Sheet 3 of 7" running header of a drawing
+	// sheet.
+	sheetHeader = regexp.MustCompile(`(?i)\bsheet (\d+) of \d+\b`)
+	// patentNoise matches lines that carry no text: column and line
+	// numbers, drawing reference numerals, and running headers such as
+	// "U.S. Patent ..." and "US 10,223,456 B2".
+	patentNoise = regexp.MustCompile(`^(?:\d{1,3}|U\.\s?S\.\s?Patent\b.*|(?:US|EP|WO)\s?\d[\d,/ .]*\s?[A-Z]\d?(?:\s+[A-Z][a-z]{2}\.? \d{1,2}, \d{4})?)$`)
+	// figureLabel matches a figure label on a drawing sheet.
+	figureLabel = regexp.MustCompile(`\bFIG(?:URE)?\.?\s*(\d+[A-Z]?)\b`)
+	// claimNumber matches a candidate claim start: a number, a period,
+	// and a capitalised word.
+	claimNumber = regexp.MustCompile(`(?:^|\s)(\d{1,3})\s?\.\s+[A-Z]`)
+	// claimReference matches the claim a dependent claim refers to.
+	claimReference = regexp.MustCompile(`(?i)\bclaims? (\d+)`)
+)
+
+// patentMarkdown restructures converted patent text into the sections of
+// the patent profile. Page markers are kept in place; drawing sheets are
+// reduced to a <!-- drawing sheet N --> marker and their figure labels.
+func patentMarkdown(md string) string {
+	r := &patentRenderer{}
+	for i, page := range splitPages(md) {
+		r.page(i, page)
+	}
+	r.flushClaims()
+	return strings.TrimSpace(blankRuns.ReplaceAllString(strings.Join(r.out, "\n"), "\n\n")) + "\n"
+}
+
+// splitPages splits md before each page marker. Output without page
+// markers is one page.
+func splitPages(md string) [][]string {
+	var pages [][]string
+	var cur []string
+	for _, line := range strings.Split(md, "\n") {
+		if strings.HasPrefix(line, "<!-- page ") && len(cur) > 0 {
+			pages = append(pages, cur)
+			cur = nil
+		}
+		cur = append(cur, line)
+	}
+	return append(pages, cur)
+}
+
+// patentRenderer accumulates the restructured lines.
+type patentRenderer struct {
+	out       []string
+	part      patentPart
+	described bool
+	sheets    int
+	claims    []string
+}
+
+// enter starts part, emitting its heading when it changes.
+func (r *patentRenderer) enter(part patentPart) {
+	if r.part == part {
+		return
+	}
+	if r.part == partClaims {
+		r.flushClaims()
+	}
+	r.part = part
+	r.described = r.described || part == partDescription
+	r.out = append(r.out, "", "## "+partHeadings[part], "")
+}
+
+// markers writes the page marker and other comments that open a page,
+// returning the remaining lines, so that a part starting with the page
+// has its heading after the marker.
+func (r *patentRenderer) markers(lines []string) []string {
+	for len(lines) > 0 {
+		line := strings.TrimSpace(lines[0])
+		if line != "" && !strings.HasPrefix(line, "<!--") {
+			break
+		}
+		r.emit(line)
+		lines = lines[1:]
+	}
+	return lines
+}
+
+// emit writes a line to the current part.
+func (r *patentRenderer) emit(line string) {
+	if r.part == partClaims {
+		r.claims = append(r.claims, line)
+		return
+	}
+	r.out = append(r.out, line)
+}
+
+// page renders one page of the patent.
+func (r *patentRenderer) page(index int, lines []string) {
+	drawing := index > 0 && isDrawingSheet(lines)
+	lines = r.markers(lines)
+	if drawing {
+		r.drawingSheet(lines)
+		return
+	}
+	// The specification follows the drawings of a US patent; an EP
+	// document's drawings come last, followed by its search report.
+	if r.part == partDrawings && !r.described {
+		r.enter(partDescription)
+	}
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "<!--") {
+			r.emit(trimmed)
+			continue
+		}
+		if patentNoise.MatchString(trimmed) || sheetHeader.MatchString(trimmed) && len(strings.Fields(trimmed)) < 12 {
+			continue
+		}
+		if r.heading(trimmed, lines, i) {
+			continue
+		}
+		if r.part != partClaims {
+			if loc := claimsIntro.FindStringIndex(trimmed); loc != nil {
+				if before := strings.TrimSpace(trimmed[:loc[0]]); before != "" {
+					r.emit(before)
+				}
+				r.enter(partClaims)
+				r.emit(trimmed[loc[1]:])
+				continue
+			}
+		}
+		if paragraphNumber.MatchString(trimmed) {
+			if r.part == partFront {
+				r.enter(partDescription)
+			}
+			r.emit("")
+		}
+		r.emit(trimmed)
+	}
+}
+
+// heading handles line if it is a section heading, reporting whether it
+// was one.
+func (r *patentRenderer) heading(line string, lines []string, i int) bool {
+	text, isMarkdown := strings.TrimSpace(strings.TrimLeft(line, "#")), strings.HasPrefix(line, "#")
+	if text == "" || len(strings.Fields(text)) > 10 || strings.HasSuffix(text, ".") {
+		return false
+	}
+	standalone := isMarkdown || isUpperText(text) ||
+		(i == 0 || strings.TrimSpace(lines[i-1]) == "") && (i+1 == len(lines) || strings.TrimSpace(lines[i+1]) == "")
+	if !standalone {
+		return false
+	}
+	switch {
+	case abstractHeading.MatchString(text):
+		r.enter(partAbstract)
+	case claimsHeading.MatchString(text):
+		r.enter(partClaims)
+	case descriptionHeading.MatchString(text):
+		r.enter(partDescription)
+		if !strings.EqualFold(text, "description") {
+			r.out = append(r.out, "", "### "+sentenceCase(text), "")
+		}
+	case r.part == partDescription && (isMarkdown || isUpperText(text)):
+		r.out = append(r.out, "", "### "+sentenceCase(text), "")
+	default:
+		return false
+	}
+	return true
+}
+
+// drawingSheet renders a page of drawings as its sheet marker and the
+// figure labels on it.
+func (r *patentRenderer) drawingSheet(lines []string) {
+	r.enter(partDrawings)
+	r.sheets++
+	sheet := r.sheets
+	var figures []string
+	seen := map[string]bool{}
+	for _, line := range lines {
+		if m := sheetHeader.FindStringSubmatch(line); m != nil {
+			sheet, _ = strconv.Atoi(m[1])
+			r.sheets = sheet
+		}
+		for _, m := range figureLabel.FindAllStringSubmatch(line, -1) {
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				figures = append(figures, "FIG. "+m[1])
+			}
+		}
+	}
+	r.out = append(r.out, fmt.Sprintf("<!-- drawing sheet %d -->", sheet), "")
+	if len(figures) > 0 {
+		r.out = append(r.out, strings.Join(figures, ", "), "")
+	}
+}
+
+// isDrawingSheet reports whether a page is a drawing sheet: it has the
+// sheet running header, or little text besides figure labels and
+// reference numerals.
+func isDrawingSheet(lines []string) bool {
+	words := 0
+	figures := false
+	for _, line := range lines {
+		if strings.HasPrefix(line, "<!--") {
+			continue
+		}
+		if sheetHeader.MatchString(line) && strings.Contains(line, "Patent") {
+			return true
+		}
+		if figureLabel.MatchString(line) {
+			figures = true
+		}
+		for _, w := range strings.Fields(figureLabel.ReplaceAllString(line, "")) {
+			if strings.IndexFunc(w, unicode.IsLetter) >= 0 {
+				words++
+			}
+		}
+	}
+	return figures && words < 15
+}
+
+// flushClaims renders the collected claim text, one paragraph per claim
+// led by its <!-- claim N --> marker; dependent claims name the claim
+// they depend on. Claims are recognised by their numbers in sequence, so
+// numbers inside claim text are not mistaken for claim starts.
+func (r *patentRenderer) flushClaims() {
+	if len(r.claims) == 0 {
+		return
+	}
+	text := strings.Join(r.claims, "\n")
+	r.claims = nil
+
+	var starts []int
+	next := 1
+	for _, m := range claimNumber.FindAllStringSubmatchIndex(text, -1) {
+		if n, _ := strconv.Atoi(text[m[2]:m[3]]); n == next {
+			starts = append(starts, m[2])
+			next++
+		}
+	}
+	if len(starts) == 0 {
+		r.out = append(r.out, claimParagraphs(text)...)
+		return
+	}
+	r.out = append(r.out, claimParagraphs(text[:starts[0]])...)
+	for i, start := range starts {
+		end := len(text)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		claim := text[start:end]
+		marker := fmt.Sprintf("<!-- claim %d -->", i+1)
+		if m := claimReference.FindStringSubmatch(claim[:min(len(claim), 200)]); m != nil {
+			marker = fmt.Sprintf("<!-- claim %d depends on %s -->", i+1, m[1])
+		}
+		r.out = append(r.out, "", marker, "")
+		r.out = append(r.out, claimParagraphs(claim)...)
+	}
+}
+
+// claimParagraphs joins the lines of claim text into a paragraph, keeping
+// page and OCR markers as paragraphs of their own.
+func claimParagraphs(text string) []string {
+	var out, words []string
+	flush := func() {
+		if len(words) > 0 {
+			out = append(out, "", strings.Join(words, " "), "")
+			words = nil
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "<!--") {
+			flush()
+			out = append(out, "", line, "")
+			continue
+		}
+		if line != "" {
+			words = append(words, line)
+		}
+	}
+	flush()
+	return out
+}
+
+// isUpperText reports whether s has letters and none in lower case.
+func isUpperText(s string) bool {
+	letters := 0
+	for _, r := range s {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters >= 4
+}
+
+// sentenceCase turns an all-capitals heading into sentence case.
+func sentenceCase(s string) string {
+	if !isUpperText(s) {
+		return s
+	}
+	lower := []rune(strings.ToLower(s))
+	lower[0] = unicode.ToUpper(lower[0])
+	return string(lower)
+}
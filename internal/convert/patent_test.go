@@ -0,0 +1,104 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// samplePatent is native-backend output for a US grant: front page,
+// two drawing sheets, the specification with column line numbers, and
+// the claims running onto the next page.
+const samplePatent = `<!-- page 1 -->
+
+(12) United States Patent
+(10) Patent No.: US 10,223,456 B2
+(54) NEURAL NETWORK ACCELERATOR
+
+(57) ABSTRACT
+An accelerator for neural networks
+with a systolic array.
+
+<!-- page 2 -->
+
+U.S. Patent Mar. 5, 2019 Sheet 1 of 2 US 10,223,456 B2
+FIG. 1
+102
+104
+FIG. 2
+
+<!-- page 3 -->
+
+U.S. Patent Mar. 5, 2019 Sheet 2 of 2 US 10,223,456 B2
+FIG. 3A
+
+<!-- page 4 -->
+
+US 10,223,456 B2
+1
+NEURAL NETWORK ACCELERATOR
+
+BACKGROUND OF THE INVENTION
+Neural networks need many
+multiplications.
+5
+SUMMARY
+An array of cells computes them. What is claimed is:
+1. An accelerator comprising: an array
+of 2. cells; and a buffer.
+2. The accelerator of claim 1, wherein
+the array is systolic.
+
+<!-- page 5 -->
+
+3. A method comprising: loading weights
+into a systolic array.
+`
+
+func TestPatentMarkdown(t *testing.T) {
+	md := patentMarkdown(samplePatent)
+	for _, want := range []string{
+		"## Abstract\n\nAn accelerator for neural networks\nwith a systolic array.\n\n" +
+			"<!-- page 2 -->\n\n## Drawings\n\n<!-- drawing sheet 1 -->\n\nFIG. 1, FIG. 2\n\n" +
+			"<!-- page 3 -->\n\n<!-- drawing sheet 2 -->\n\nFIG. 3A\n\n",
+		"<!-- page 4 -->\n\n## Description\n\n### Neural network accelerator\n\n" +
+			"### Background of the invention\n\nNeural networks need many\nmultiplications.\n\n### Summary\n\n" +
+			"An array of cells computes them.\n\n## Claims\n\n",
+		"<!-- claim 1 -->\n\n1. An accelerator comprising: an array of 2. cells; and a buffer.\n\n" +
+			"<!-- claim 2 depends on 1 -->\n\n2. The accelerator of claim 1, wherein the array is systolic.\n\n" +
+			"<!-- page 5 -->\n\n<!-- claim 3 -->\n\n3. A method comprising: loading weights into a systolic array.\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown missing %q:\n%s", want, md)
+		}
+	}
+	for _, noise := range []string{"U.S. Patent", "\n102\n", "\n5\n"} {
+		if strings.Contains(md, noise) {
+			t.Errorf("Markdown contains %q:\n%s", noise, md)
+		}
+	}
+}
+
+func TestPatentConverter(t *testing.T) {
+	pdfPath, dir := setupPDF(t)
+	os.MkdirAll(filepath.Join(dir, "metadata"), 0o755)
+	metaPath := filepath.Join(dir, "metadata", "2301.07041.yaml")
+	inner := stubConverter{md: "Text.\nWhat is claimed is:\n1. A thing.\n"}
+
+	os.WriteFile(metaPath, []byte("id: \"2301.07041\"\nsource: arxiv\n"), 0o644)
+	if md, _ := NewPatentConverter(inner).Convert(pdfPath); md != inner.md {
+		t.Errorf("paper restructured:\n%s", md)
+	}
+
+	os.WriteFile(metaPath, []byte("id: \"2301.07041\"\nsource: patentsview\n"), 0o644)
+	md, err := NewPatentConverter(inner).Convert(pdfPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Text.\n\n## Claims\n\n<!-- claim 1 -->\n\n1. A thing.\n"; md != want {
+		t.Errorf("Markdown = %q, want %q", md, want)
+	}
+}
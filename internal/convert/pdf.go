@@ -0,0 +1,557 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// This file holds the minimal PDF object reader behind NativeConverter:
+// enough of the syntax to find the pages, their fonts, and their content
+// streams. It reads objects by scanning for "N G obj" rather than through
+// the cross-reference table, so files with damaged tables still read, and
+// it unpacks object streams. Encryption is not supported.
+
+// pdfName is a PDF name object such as /Type, stored without the slash.
+type pdfName string
+
+// pdfRef is an indirect reference "N G R".
+type pdfRef struct{ num, gen int }
+
+// pdfDict is a PDF dictionary.
+type pdfDict map[pdfName]any
+
+// pdfStream is a stream object: its dictionary and its raw, still encoded
+// data.
+type pdfStream struct {
+	dict pdfDict
+	raw  []byte
+}
+
+// pdfOperator is a bare keyword in a content stream, such as Tj or BT.
+type pdfOperator string
+
+// pdfLexer reads PDF tokens and objects from a byte slice.
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelim(c byte) bool {
+	return bytes.IndexByte([]byte("()<>[]{}/%"), c) >= 0
+}
+
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case isPDFSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// next returns the next object or operator, or io.EOF at the end of the
+// data. Dictionaries and arrays are read whole; "N G R" becomes a pdfRef.
+func (l *pdfLexer) next() (any, error) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, io.EOF
+	}
+	c := l.data[l.pos]
+	switch {
+	case c == '/':
+		l.pos++
+		return pdfName(l.readName()), nil
+	case c == '(':
+		l.pos++
+		return l.readLiteral(), nil
+	case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+		l.pos += 2
+		return l.readDict()
+	case c == '<':
+		l.pos++
+		return l.readHex(), nil
+	case c == '[':
+		l.pos++
+		return l.readArray()
+	case c == ']' || c == '>' || c == ')' || c == '{' || c == '}':
+		l.pos++
+		return pdfOperator(c), nil
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelim(l.data[l.pos]) {
+		l.pos++
+	}
+	word := string(l.data[start:l.pos])
+	if n, err := strconv.ParseFloat(word, 64); err == nil {
+		// An integer may start a reference "N G R".
+		if i, err := strconv.Atoi(word); err == nil {
+			save := l.pos
+			if gen, ok := l.peekInt(); ok {
+				l.skipSpace()
+				if l.pos < len(l.data) && l.data[l.pos] == 'R' && (l.pos+1 == len(l.data) || isPDFSpace(l.data[l.pos+1]) || isPDFDelim(l.data[l.pos+1])) {
+					l.pos++
+					return pdfRef{i, gen}, nil
+				}
+			}
+			l.pos = save
+		}
+		return n, nil
+	}
+	switch word {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	case "":
+		// A stray delimiter; skip it.
+		l.pos++
+		return pdfOperator(c), nil
+	}
+	return pdfOperator(word), nil
+}
+
+// peekInt reads an unsigned integer after whitespace, advancing past it.
+func (l *pdfLexer) peekInt() (int, bool) {
+	l.skipSpace()
+	start := l.pos
+	for l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '9' {
+		l.pos++
+	}
+	if start == l.pos || (l.pos < len(l.data) && !isPDFSpace(l.data[l.pos])) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(string(l.data[start:l.pos]))
+	return n, err == nil
+}
+
+func (l *pdfLexer) readName() string {
+	var b []byte
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelim(l.data[l.pos]) {
+		c := l.data[l.pos]
+		if c == '#' && l.pos+2 < len(l.data) {
+			if v, err := strconv.ParseUint(string(l.data[l.pos+1:l.pos+3]), 16, 8); err == nil {
+				b = append(b, byte(v))
+				l.pos += 3
+				continue
+			}
+		}
+		b = append(b, c)
+		l.pos++
+	}
+	return string(b)
+}
+
+func (l *pdfLexer) readLiteral() []byte {
+	var b []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return b
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return b
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(v)
+				} else {
+					c = e
+				}
+			}
+		}
+		b = append(b, c)
+	}
+	return b
+}
+
+func (l *pdfLexer) readHex() []byte {
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; !isPDFSpace(c) {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	l.pos++
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, len(digits)/2)
+	n, _ := hex.Decode(out, digits)
+	return out[:n]
+}
+
+func (l *pdfLexer) readArray() ([]any, error) {
+	var arr []any
+	for {
+		v, err := l.next()
+		if err != nil {
+			return arr, err
+		}
+		if v == pdfOperator("]") {
+			return arr, nil
+		}
+		arr = append(arr, v)
+	}
+}
+
+func (l *pdfLexer) readDict() (pdfDict, error) {
+	d := pdfDict{}
+	for {
+		l.skipSpace()
+		if l.pos+1 < len(l.data) && l.data[l.pos] == '>' && l.data[l.pos+1] == '>' {
+			l.pos += 2
+			return d, nil
+		}
+		k, err := l.next()
+		if err != nil {
+			return d, err
+		}
+		key, ok := k.(pdfName)
+		if !ok {
+			continue
+		}
+		v, err := l.next()
+		if err != nil {
+			return d, err
+		}
+		d[key] = v
+	}
+}
+
+// pdfFile is a parsed PDF: its objects by number and its trailer.
+type pdfFile struct {
+	objects map[int]any
+	trailer pdfDict
+}
+
+var (
+	objHeader     = regexp.MustCompile(`(?m)(?:^|[\r\n\s])(\d+)\s+(\d+)\s+obj\b`)
+	trailerHeader = regexp.MustCompile(`trailer\s*<<`)
+	endstream     = []byte("endstream")
+)
+
+// parsePDF reads every object in data. Later definitions of an object
+// number replace earlier ones, as incremental updates do.
+func parsePDF(data []byte) (*pdfFile, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \r\n\t"), []byte("%PDF-")) {
+		return nil, fmt.Errorf("not a PDF file")
+	}
+	f := &pdfFile{objects: make(map[int]any), trailer: pdfDict{}}
+	var objStreams []pdfStream
+	// Matches inside a stream already read are stream bytes, not objects.
+	skipUntil := 0
+	for _, m := range objHeader.FindAllSubmatchIndex(data, -1) {
+		if m[0] < skipUntil {
+			continue
+		}
+		num, _ := strconv.Atoi(string(data[m[2]:m[3]]))
+		l := &pdfLexer{data: data, pos: m[1]}
+		v, err := l.next()
+		if err != nil {
+			continue
+		}
+		if d, ok := v.(pdfDict); ok {
+			l.skipSpace()
+			if bytes.HasPrefix(data[l.pos:], []byte("stream")) {
+				raw, end := streamData(data, l.pos+len("stream"), d)
+				s := pdfStream{dict: d, raw: raw}
+				skipUntil = end
+				v = s
+				if d["Type"] == pdfName("ObjStm") {
+					objStreams = append(objStreams, s)
+				}
+				// XRef streams carry the trailer entries.
+				if d["Type"] == pdfName("XRef") {
+					for k, tv := range d {
+						f.trailer[k] = tv
+					}
+				}
+			}
+		}
+		f.objects[num] = v
+	}
+	for _, m := range trailerHeader.FindAllIndex(data, -1) {
+		l := &pdfLexer{data: data, pos: m[1] - 2}
+		if v, err := l.next(); err == nil {
+			if d, ok := v.(pdfDict); ok {
+				for k, tv := range d {
+					f.trailer[k] = tv
+				}
+			}
+		}
+	}
+	if _, ok := f.trailer["Encrypt"]; ok {
+		return nil, fmt.Errorf("encrypted PDFs are not supported")
+	}
+	for _, s := range objStreams {
+		f.unpackObjStm(s)
+	}
+	return f, nil
+}
+
+// streamData returns the bytes of a stream starting after the "stream"
+// keyword at pos, using /Length when it is direct and matches, and the
+// next endstream otherwise, with the offset where the data ends.
+func streamData(data []byte, pos int, d pdfDict) ([]byte, int) {
+	if pos < len(data) && data[pos] == '\r' {
+		pos++
+	}
+	if pos < len(data) && data[pos] == '\n' {
+		pos++
+	}
+	if n, ok := d["Length"].(float64); ok {
+		end := pos + int(n)
+		if end <= len(data) && bytes.HasPrefix(bytes.TrimLeft(data[end:], " \r\n"), endstream) {
+			return data[pos:end], end
+		}
+	}
+	end := bytes.Index(data[pos:], endstream)
+	if end < 0 {
+		return data[pos:], len(data)
+	}
+	return bytes.TrimRight(data[pos:pos+end], "\r\n"), pos + end
+}
+
+// unpackObjStm adds the objects compressed in an object stream, unless the
+// file defines them directly.
+func (f *pdfFile) unpackObjStm(s pdfStream) {
+	data, err := f.decode(s)
+	if err != nil {
+		return
+	}
+	n, _ := f.resolve(s.dict["N"]).(float64)
+	first, _ := f.resolve(s.dict["First"]).(float64)
+	l := &pdfLexer{data: data}
+	type entry struct{ num, off int }
+	var entries []entry
+	for i := 0; i < int(n); i++ {
+		num, err1 := l.next()
+		off, err2 := l.next()
+		if err1 != nil || err2 != nil {
+			return
+		}
+		nf, _ := num.(float64)
+		of, _ := off.(float64)
+		entries = append(entries, entry{int(nf), int(of)})
+	}
+	for _, e := range entries {
+		if _, ok := f.objects[e.num]; ok {
+			continue
+		}
+		pos := int(first) + e.off
+		if pos < 0 || pos >= len(data) {
+			continue
+		}
+		ol := &pdfLexer{data: data, pos: pos}
+		if v, err := ol.next(); err == nil {
+			f.objects[e.num] = v
+		}
+	}
+}
+
+// resolve follows indirect references.
+func (f *pdfFile) resolve(v any) any {
+	for i := 0; i < 32; i++ {
+		ref, ok := v.(pdfRef)
+		if !ok {
+			return v
+		}
+		v = f.objects[ref.num]
+	}
+	return nil
+}
+
+func (f *pdfFile) dict(v any) pdfDict {
+	switch d := f.resolve(v).(type) {
+	case pdfDict:
+		return d
+	case pdfStream:
+		return d.dict
+	}
+	return nil
+}
+
+func (f *pdfFile) array(v any) []any {
+	a, _ := f.resolve(v).([]any)
+	return a
+}
+
+// decode applies a stream's filters. It fails on filters other than
+// FlateDecode, ASCIIHexDecode, and ASCII85Decode, such as image codecs.
+func (f *pdfFile) decode(s pdfStream) ([]byte, error) {
+	var filters []any
+	switch v := f.resolve(s.dict["Filter"]).(type) {
+	case pdfName:
+		filters = []any{v}
+	case []any:
+		filters = v
+	}
+	data := s.raw
+	for _, filter := range filters {
+		var err error
+		switch f.resolve(filter) {
+		case pdfName("FlateDecode"), pdfName("Fl"):
+			data, err = inflate(data)
+		case pdfName("ASCIIHexDecode"), pdfName("AHx"):
+			data = (&pdfLexer{data: append(bytes.TrimSpace(data), '>')}).readHex()
+		case pdfName("ASCII85Decode"), pdfName("A85"):
+			data, err = decodeASCII85(data)
+		default:
+			return nil, fmt.Errorf("unsupported filter %v", filter)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// inflate decompresses zlib data, keeping what was read when the stream is
+// truncated, as is common in damaged files.
+func inflate(data []byte) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	out, err := io.ReadAll(r)
+	if err != nil && len(out) == 0 {
+		return nil, err
+	}
+	return out, nil
+}
+
+func decodeASCII85(data []byte) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	data = bytes.TrimPrefix(data, []byte("<~"))
+	if i := bytes.Index(data, []byte("~>")); i >= 0 {
+		data = data[:i]
+	}
+	out := make([]byte, len(data))
+	n, _, err := ascii85.Decode(out, data, true)
+	return out[:n], err
+}
+
+// pages returns the page dictionaries in order, with inherited resources
+// filled in. Without a usable page tree, every /Type /Page object is
+// returned in object-number order.
+func (f *pdfFile) pages() []pdfDict {
+	var pages []pdfDict
+	var walk func(node pdfDict, resources any, depth int)
+	walk = func(node pdfDict, resources any, depth int) {
+		// The depth bound stops cyclic page trees.
+		if node == nil || depth > 64 {
+			return
+		}
+		if r, ok := node["Resources"]; ok {
+			resources = r
+		}
+		if node["Type"] == pdfName("Page") || node["Kids"] == nil {
+			page := pdfDict{}
+			for k, v := range node {
+				page[k] = v
+			}
+			page["Resources"] = resources
+			pages = append(pages, page)
+			return
+		}
+		for _, kid := range f.array(node["Kids"]) {
+			walk(f.dict(kid), resources, depth+1)
+		}
+	}
+	if root := f.dict(f.trailer["Root"]); root != nil {
+		walk(f.dict(root["Pages"]), nil, 0)
+	}
+	if len(pages) > 0 {
+		return pages
+	}
+
+	nums := make([]int, 0, len(f.objects))
+	for n := range f.objects {
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+	for _, n := range nums {
+		if d, ok := f.objects[n].(pdfDict); ok && d["Type"] == pdfName("Page") {
+			pages = append(pages, d)
+		}
+	}
+	return pages
+}
+
+// contents returns the decoded content streams of a page, concatenated.
+func (f *pdfFile) contents(page pdfDict) []byte {
+	var streams []any
+	switch v := f.resolve(page["Contents"]).(type) {
+	case pdfStream:
+		streams = []any{v}
+	case []any:
+		streams = v
+	}
+	var out []byte
+	for _, s := range streams {
+		if st, ok := f.resolve(s).(pdfStream); ok {
+			if data, err := f.decode(st); err == nil {
+				out = append(out, data...)
+				out = append(out, '\n')
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,179 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	_ "embed"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// Quality thresholds (R4.2). A conversion scoring below FairScore is
+// recorded as partial.
+const (
+	GoodScore = 0.7
+	FairScore = 0.4
+)
+
+// minScoredWords is the word count below which a conversion's word ratio
+// is scaled down: a handful of recognised words is not a usable paper.
+const minScoredWords = 200
+
+//go:embed words.txt
+var wordList string
+
+// dictionary holds common English and research vocabulary in lower case.
+var dictionary = func() map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.Fields(wordList) {
+		words[w] = true
+	}
+	return words
+}()
+
+var (
+	// unscored matches the parts of a conversion that are not prose:
+	// frontmatter, HTML comments, math, code, and URLs.
+	unscored = regexp.MustCompile("(?s)\\A---\n.*?\n---\n|<!--.*?-->|\\$\\$.*?\\$\\$|\\$[^$\n]*\\$|```.*?```|https?://\\S+|\\\\[a-zA-Z]+")
+	// wordToken matches a run of letters.
+	wordToken = regexp.MustCompile(`\p{L}+`)
+	// pageMarker matches a <!-- page N --> marker.
+	pageMarker = regexp.MustCompile(`(?m)^<!-- page \d+ -->$`)
+	// referencesHeading matches a heading that opens a reference list.
+	referencesHeading = regexp.MustCompile(`(?im)^#+\s.*\b(references|bibliography|works cited)\b`)
+)
+
+// ScoreQuality measures how usable converted Markdown is for extraction
+// (R4.1): the share of its words found in an English dictionary, its
+// heading count, whether it has a reference section, and the share of
+// its pages with text. The score weighs these into a value in [0, 1];
+// page coverage counts only when the output has page markers.
+func ScoreQuality(md string) types.ConversionQuality {
+	var q types.ConversionQuality
+	prose := unscored.ReplaceAllString(md, " ")
+
+	known := 0
+	for _, w := range wordToken.FindAllString(prose, -1) {
+		if len([]rune(w)) < 2 || isAcronym(w) {
+			continue
+		}
+		q.Words++
+		if knownWord(strings.ToLower(w), 2) {
+			known++
+		}
+	}
+	if q.Words > 0 {
+		q.WordRatio = round2(float64(known) / float64(q.Words))
+	}
+
+	for _, line := range strings.Split(md, "\n") {
+		if strings.HasPrefix(line, "#") && strings.HasPrefix(strings.TrimLeft(line, "#"), " ") {
+			q.Headings++
+		}
+	}
+	q.References = referencesHeading.MatchString(md)
+
+	if marks := pageMarker.FindAllStringIndex(md, -1); len(marks) > 0 {
+		q.Pages = len(marks)
+		withText := 0
+		for i, m := range marks {
+			end := len(md)
+			if i+1 < len(marks) {
+				end = marks[i+1][0]
+			}
+			if len(wordToken.FindAllString(unscored.ReplaceAllString(md[m[1]:end], " "), 20)) >= 20 {
+				withText++
+			}
+		}
+		q.PageCoverage = round2(float64(withText) / float64(q.Pages))
+	}
+
+	// Clean English prose has a word ratio around 0.8 against this
+	// dictionary; garbled text falls below half that.
+	words := math.Max(0, math.Min(1, (q.WordRatio-0.3)/0.5)) * math.Min(1, float64(q.Words)/minScoredWords)
+	score := 0.55*words + 0.2*math.Min(1, float64(q.Headings)/4)
+	if q.References {
+		score += 0.1
+	}
+	total := 0.85
+	if q.Pages > 0 {
+		score += 0.15 * q.PageCoverage
+		total = 1
+	}
+	q.Score = round2(score / total)
+	q.Level = qualityLevel(q.Score)
+	return q
+}
+
+// qualityLevel maps a score to its level.
+func qualityLevel(score float64) types.QualityLevel {
+	switch {
+	case score >= GoodScore:
+		return types.QualityGood
+	case score >= FairScore:
+		return types.QualityFair
+	}
+	return types.QualityPoor
+}
+
+// suffixes are the inflections knownWord strips, each with the ending
+// that restores the stem (e.g. "ies" to "y").
+var suffixes = [][2]string{
+	{"ies", "y"}, {"ied", "y"}, {"ing", ""}, {"ing", "e"}, {"es", ""},
+	{"ed", ""}, {"ed", "e"}, {"s", ""}, {"d", ""}, {"ly", ""}, {"ily", "y"},
+	{"er", ""}, {"er", "e"}, {"est", ""}, {"ness", ""}, {"ment", ""},
+	{"ation", ""}, {"ation", "e"}, {"ity", ""}, {"al", ""}, {"ally", ""},
+	{"ize", ""}, {"ise", ""}, {"ised", "ize"}, {"isation", "ization"},
+	{"ful", ""}, {"less", ""}, {"able", ""}, {"ive", ""}, {"ous", ""},
+}
+
+// prefixes are the prefixes knownWord strips.
+var prefixes = []string{"un", "re", "non", "pre", "sub", "multi", "co", "inter", "over", "under", "self", "in", "dis"}
+
+// knownWord reports whether w, or w with up to depth affixes removed, is
+// in the dictionary.
+func knownWord(w string, depth int) bool {
+	if dictionary[w] {
+		return true
+	}
+	if depth == 0 || len(w) < 4 {
+		return false
+	}
+	for _, s := range suffixes {
+		stem, ok := strings.CutSuffix(w, s[0])
+		if !ok || len(stem) < 2 {
+			continue
+		}
+		if knownWord(stem+s[1], depth-1) {
+			return true
+		}
+		// running -> run, stopped -> stop
+		if n := len(stem); n > 2 && stem[n-1] == stem[n-2] && knownWord(stem[:n-1], depth-1) {
+			return true
+		}
+	}
+	for _, p := range prefixes {
+		if rest, ok := strings.CutPrefix(w, p); ok && len(rest) > 2 && knownWord(strings.TrimPrefix(rest, "-"), depth-1) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAcronym reports whether w is all upper case, like BLEU or LSTM.
+func isAcronym(w string) bool {
+	for _, r := range w {
+		if !unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
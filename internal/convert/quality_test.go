@@ -0,0 +1,63 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+const qualityProse = "We propose a new model for sequence transduction based on attention. " +
+	"The encoder maps an input sequence to a continuous representation, and the decoder " +
+	"generates the output one token at a time. Experiments on two translation tasks show " +
+	"that the model is superior in quality while being more parallel and requiring less time to train. "
+
+func TestScoreQuality(t *testing.T) {
+	body := strings.Repeat(qualityProse, 5)
+	good := "# Title\n\n## Introduction\n\n" + body + "\n\n## Method\n\n$$\nx^2\n$$\n\n" + body +
+		"\n\n## Results\n\n" + body + "\n\n## References\n\n[1] A. Author. A paper.\n"
+	garbled := strings.Repeat("Tlie rnodel xqz vvith ptrn qwe ftl klm brq zzv. ", 60)
+
+	for _, tc := range []struct {
+		name  string
+		md    string
+		level types.QualityLevel
+	}{
+		{"clean paper", good, types.QualityGood},
+		{"garbled text", garbled, types.QualityPoor},
+		{"too short", "# Title\n\nContent here.", types.QualityPoor},
+		{"empty", "", types.QualityPoor},
+	} {
+		q := ScoreQuality(tc.md)
+		if q.Level != tc.level {
+			t.Errorf("%s: level = %s (%+v), want %s", tc.name, q.Level, q, tc.level)
+		}
+	}
+
+	q := ScoreQuality(good)
+	if q.Headings != 5 || !q.References || q.Pages != 0 || q.WordRatio < 0.9 {
+		t.Errorf("clean paper: %+v", q)
+	}
+
+	paged := "<!-- page 1 -->\n\n" + body + "\n\n<!-- page 2 -->\n\n<!-- page 3 -->\n\nFig\n"
+	if q := ScoreQuality(paged); q.Pages != 3 || q.PageCoverage != 0.33 {
+		t.Errorf("paged: pages = %d, coverage = %v", q.Pages, q.PageCoverage)
+	}
+}
+
+func TestKnownWord(t *testing.T) {
+	for w, want := range map[string]bool{
+		"models":       true,
+		"running":      true,
+		"studies":      true,
+		"unsupervised": true,
+		"xqz":          false,
+		"tlie":         false,
+	} {
+		if got := knownWord(w, 2); got != want {
+			t.Errorf("knownWord(%q) = %v, want %v", w, got, want)
+		}
+	}
+}
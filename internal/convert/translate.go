@@ -0,0 +1,265 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// DefaultDeepLURL is the DeepL API Free translation endpoint. DeepL API
+// Pro keys use https://api.deepl.com/v2/translate.
+const DefaultDeepLURL = "https://api-free.deepl.com/v2/translate"
+
+// maxTranslateChars bounds the text sent in one translation request;
+// longer sections are split at paragraph boundaries.
+const maxTranslateChars = 8000
+
+// Translator translates paragraphs of a paper from the language with ISO
+// 639-1 code from into English, returning one translation per paragraph.
+type Translator interface {
+	Translate(paragraphs []string, from string) ([]string, error)
+}
+
+// languageNames are the English names of the languages DetectLanguage
+// reports.
+var languageNames = map[string]string{
+	"en": "English", "de": "German", "fr": "French", "es": "Spanish",
+	"it": "Italian", "pt": "Portuguese", "nl": "Dutch", "ja": "Japanese",
+	"ko": "Korean", "zh": "Chinese", "ru": "Russian", "ar": "Arabic",
+	"el": "Greek", "he": "Hebrew",
+}
+
+// LanguageName returns the English name of a language code, or the code
+// itself when it is not one DetectLanguage reports.
+func LanguageName(code string) string {
+	if name, ok := languageNames[code]; ok {
+		return name
+	}
+	return code
+}
+
+// translatedMarker opens Markdown translated from another language.
+var translatedMarker = regexp.MustCompile(`\A<!-- translated from ([a-z]{2}) -->\n`)
+
+// TranslatingConverter translates the output of another converter into
+// English when it is in another language (R5.2), section by section.
+// Headings and prose are translated; page and equation markers, math,
+// code, tables, and reference lists are kept as they are. The output is
+// led by a <!-- translated from xx --> marker.
+type TranslatingConverter struct {
+	inner      Converter
+	translator Translator
+}
+
+// NewTranslatingConverter returns a converter that translates inner's
+// non-English output with translator.
+func NewTranslatingConverter(inner Converter, translator Translator) *TranslatingConverter {
+	return &TranslatingConverter{inner: inner, translator: translator}
+}
+
+// Convert converts path with the inner converter and translates the
+// result when it is not in English.
+func (c *TranslatingConverter) Convert(path string) (string, error) {
+	md, err := c.inner.Convert(path)
+	if err != nil {
+		return "", err
+	}
+	lang := DetectLanguage(md)
+	if lang == "" || lang == "en" {
+		return md, nil
+	}
+	translated, err := translateMarkdown(md, lang, c.translator)
+	if err != nil {
+		return "", fmt.Errorf("translating %s from %s: %w", path, LanguageName(lang), err)
+	}
+	return fmt.Sprintf("<!-- translated from %s -->\n\n", lang) + translated, nil
+}
+
+// markdownLanguage returns the language of converted Markdown: the
+// language it was translated from, or the detected one.
+func markdownLanguage(md string) (lang string, translated bool) {
+	if m := translatedMarker.FindStringSubmatch(md); m != nil {
+		return m[1], true
+	}
+	return DetectLanguage(md), false
+}
+
+var (
+	// untranslated matches blocks kept as they are: comments, math, code,
+	// tables, and numbered reference entries.
+	untranslated = regexp.MustCompile(`\A(?:<!--|\$\$|` + "```" + `|\||\[\d+\] )`)
+	// headingBlock matches a Markdown heading, capturing its marker.
+	headingBlock = regexp.MustCompile(`\A(#{1,6} )(.*)\z`)
+	// referenceTitle matches the heading of a reference list in the
+	// languages DetectLanguage reports.
+	referenceTitle = regexp.MustCompile(`(?i)\b(?:references|bibliography|works cited|literatur\w*|références|bibliographie|referencias|bibliografía|riferimenti|bibliografia|referências|literatuur)\b|参考文献|литература`)
+)
+
+// translateMarkdown translates the prose blocks of md from lang into
+// English, one request per section or per maxTranslateChars of it.
+func translateMarkdown(md, lang string, t Translator) (string, error) {
+	blocks := strings.Split(blankRuns.ReplaceAllString(strings.TrimSpace(md), "\n\n"), "\n\n")
+
+	var batch []int
+	size := 0
+	send := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		texts := make([]string, len(batch))
+		for i, b := range batch {
+			texts[i] = blocks[b]
+			if m := headingBlock.FindStringSubmatch(blocks[b]); m != nil {
+				texts[i] = m[2]
+			}
+		}
+		out, err := t.Translate(texts, lang)
+		if err != nil {
+			return err
+		}
+		if len(out) != len(texts) {
+			return fmt.Errorf("got %d translations for %d paragraphs", len(out), len(texts))
+		}
+		for i, b := range batch {
+			prefix := ""
+			if m := headingBlock.FindStringSubmatch(blocks[b]); m != nil {
+				prefix = m[1]
+			}
+			blocks[b] = prefix + strings.TrimSpace(out[i])
+		}
+		batch, size = nil, 0
+		return nil
+	}
+
+	references := false
+	for i, b := range blocks {
+		heading := headingBlock.MatchString(b)
+		if heading {
+			if err := send(); err != nil {
+				return "", err
+			}
+			references = referenceTitle.MatchString(b)
+		}
+		if references && !heading || untranslated.MatchString(b) || !strings.ContainsFunc(b, unicode.IsLetter) {
+			continue
+		}
+		if size+len(b) > maxTranslateChars {
+			if err := send(); err != nil {
+				return "", err
+			}
+		}
+		batch = append(batch, i)
+		size += len(b)
+	}
+	if err := send(); err != nil {
+		return "", err
+	}
+	return strings.Join(blocks, "\n\n") + "\n", nil
+}
+
+// translatePrompt asks a model to translate a JSON array of paragraphs.
+const translatePrompt = `Translate each paragraph of this %s research paper into English. The paragraphs are a JSON array of strings. Keep Markdown formatting, inline LaTeX math ($...$), citation markers such as [12], numbers, units, and proper names unchanged. Translate faithfully without summarising, adding, or omitting anything.
+
+Respond with only a JSON array of strings holding the translations, one per input paragraph, in the same order.
+
+%s`
+
+// ModelTranslator translates with a generative model. complete sends a
+// prompt to the model and returns its reply.
+type ModelTranslator struct {
+	complete func(prompt string) (string, error)
+}
+
+// NewModelTranslator returns a translator that prompts a model through
+// complete.
+func NewModelTranslator(complete func(prompt string) (string, error)) *ModelTranslator {
+	return &ModelTranslator{complete: complete}
+}
+
+// Translate asks the model to translate paragraphs from the language from.
+func (m *ModelTranslator) Translate(paragraphs []string, from string) ([]string, error) {
+	in, err := json.Marshal(paragraphs)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := m.complete(fmt.Sprintf(translatePrompt, LanguageName(from), in))
+	if err != nil {
+		return nil, err
+	}
+	start, end := strings.Index(reply, "["), strings.LastIndex(reply, "]")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON array in the model's reply")
+	}
+	var out []string
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &out); err != nil {
+		return nil, fmt.Errorf("parsing the model's translations: %w", err)
+	}
+	return out, nil
+}
+
+// DeepLTranslator translates with the DeepL API.
+type DeepLTranslator struct {
+	client *http.Client
+	url    string
+	key    string
+}
+
+// NewDeepLTranslator returns a translator calling the DeepL API at url
+// with the authentication key key.
+func NewDeepLTranslator(client *http.Client, url, key string) *DeepLTranslator {
+	return &DeepLTranslator{client: client, url: url, key: key}
+}
+
+// deeplRequest is the body of a DeepL translate request.
+type deeplRequest struct {
+	Text       []string `json:"text"`
+	SourceLang string   `json:"source_lang"`
+	TargetLang string   `json:"target_lang"`
+}
+
+// deeplResponse is the body of a DeepL translate response.
+type deeplResponse struct {
+	Translations []struct {
+		Text string `json:"text"`
+	} `json:"translations"`
+}
+
+// Translate sends paragraphs to DeepL for translation into English.
+func (d *DeepLTranslator) Translate(paragraphs []string, from string) ([]string, error) {
+	body, err := json.Marshal(deeplRequest{Text: paragraphs, SourceLang: strings.ToUpper(from), TargetLang: "EN-US"})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, d.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "DeepL-Auth-Key "+d.key)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling DeepL: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("DeepL returned %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	var out deeplResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decoding DeepL response: %w", err)
+	}
+	texts := make([]string, len(out.Translations))
+	for i, t := range out.Translations {
+		texts[i] = t.Text
+	}
+	return texts, nil
+}
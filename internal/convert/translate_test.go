@@ -0,0 +1,106 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package convert
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// upperTranslator "translates" by upper-casing and records its requests.
+type upperTranslator struct {
+	requests [][]string
+}
+
+func (u *upperTranslator) Translate(paragraphs []string, from string) ([]string, error) {
+	u.requests = append(u.requests, paragraphs)
+	out := make([]string, len(paragraphs))
+	for i, p := range paragraphs {
+		out[i] = strings.ToUpper(p)
+	}
+	return out, nil
+}
+
+const germanPaper = "<!-- page 1 -->\n\n# Ein Modell\n\n" +
+	"Die Ergebnisse der Studie zeigen, dass das Modell mit den Daten in der Praxis nicht immer die beste Wahl ist. " +
+	"Die Methode ist für die Analyse von Texten geeignet, und sie wird in der Arbeit mit den anderen verglichen. " +
+	"Wir zeigen, dass die Ergebnisse auf den Daten stabil sind und sich mit der Zeit nicht ändern.\n\n" +
+	"<!-- equation 1 -->\n\n$$\nx = y\n$$\n\n" +
+	"## Literatur\n\nA. Autor. Ein Titel der Arbeit. 2020.\n"
+
+func TestTranslatingConverter(t *testing.T) {
+	tr := &upperTranslator{}
+	md, err := NewTranslatingConverter(stubConverter{md: germanPaper}, tr).Convert("paper.pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(md, "<!-- translated from de -->\n\n<!-- page 1 -->\n\n# EIN MODELL\n\nDIE ERGEBNISSE") {
+		t.Errorf("Markdown:\n%s", md)
+	}
+	for _, kept := range []string{"<!-- equation 1 -->\n\n$$\nx = y\n$$", "## LITERATUR\n\nA. Autor. Ein Titel der Arbeit. 2020.\n"} {
+		if !strings.Contains(md, kept) {
+			t.Errorf("Markdown missing %q:\n%s", kept, md)
+		}
+	}
+	// One request per section.
+	if len(tr.requests) != 2 || len(tr.requests[0]) != 2 || tr.requests[1][0] != "Literatur" {
+		t.Errorf("requests = %q", tr.requests)
+	}
+	if lang, translated := markdownLanguage(md); lang != "de" || !translated {
+		t.Errorf("markdownLanguage = %q, %v", lang, translated)
+	}
+
+	english := stubConverter{md: qualityProse}
+	tr = &upperTranslator{}
+	if md, _ := NewTranslatingConverter(english, tr).Convert("paper.pdf"); md != qualityProse || len(tr.requests) != 0 {
+		t.Errorf("English paper translated: %d requests", len(tr.requests))
+	}
+}
+
+func TestModelTranslator(t *testing.T) {
+	var prompt string
+	m := NewModelTranslator(func(p string) (string, error) {
+		prompt = p
+		return "Here you go:\n[\"The model\", \"Results\"]", nil
+	})
+	out, err := m.Translate([]string{"Das Modell", "Ergebnisse"}, "de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(out, "|") != "The model|Results" {
+		t.Errorf("out = %q", out)
+	}
+	if !strings.Contains(prompt, "German research paper") || !strings.Contains(prompt, `["Das Modell","Ergebnisse"]`) {
+		t.Errorf("prompt:\n%s", prompt)
+	}
+}
+
+func TestDeepLTranslator(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "DeepL-Auth-Key k" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req deeplRequest
+		json.Unmarshal(body, &req)
+		if req.SourceLang != "FR" || req.TargetLang != "EN-US" || len(req.Text) != 1 {
+			http.Error(w, string(body), http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"translations":[{"detected_source_language":"FR","text":"The results"}]}`))
+	}))
+	defer ts.Close()
+
+	out, err := NewDeepLTranslator(ts.Client(), ts.URL, "k").Translate([]string{"Les résultats"}, "fr")
+	if err != nil || len(out) != 1 || out[0] != "The results" {
+		t.Errorf("Translate = %q, %v", out, err)
+	}
+	if _, err := NewDeepLTranslator(ts.Client(), ts.URL, "bad").Translate([]string{"x"}, "fr"); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package draft
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// binPandoc is the document converter Compile runs.
+const binPandoc = "pandoc"
+
+// referenceDocFile is the Word template a project may hold; docx output
+// takes its styles when no other is given (R6.6).
+const referenceDocFile = "reference.docx"
+
+// CompileFormats are the output formats of Compile (R6.6, R6.7).
+var CompileFormats = []string{"pdf", "tex", "docx", "html"}
+
+// pandocWriters holds the pandoc writer arguments of each format. PDF is
+// typeset through LaTeX, and tex is that LaTeX source, for submission;
+// HTML is a standalone page with its images and styles embedded, so it
+// can be mailed as one file.
+var pandocWriters = map[string][]string{
+	"pdf":  {"--to=latex"},
+	"tex":  {"--to=latex", "--standalone"},
+	"docx": {"--to=docx"},
+	"html": {"--to=html5", "--standalone", "--embed-resources"},
+}
+
+// CompileOptions configures Compile.
+type CompileOptions struct {
+	// Formats are the outputs to write: pdf, tex, docx, html. Empty is
+	// pdf.
+	Formats []string
+
+	// Template is the LaTeX template of pdf and tex output: a built-in
+	// venue format (ieee, acm, arxiv), a template in templates/, or a
+	// template file (R6.7). Empty uses pandoc's default.
+	Template string
+
+	// Columns sets one- or two-column layout; zero keeps the template's
+	// own, two columns for ieee and acm and one otherwise (R6.7).
+	Columns int
+
+	// CSL is a Citation Style Language file that formats the citations
+	// and bibliography, such as a venue's style from the Zotero style
+	// repository (R6.5). Empty uses pandoc's default, Chicago
+	// author-date.
+	CSL string
+
+	// ReferenceDoc is a Word document whose styles docx output takes.
+	// Empty uses the project's reference.docx, if any (R6.6).
+	ReferenceDoc string
+}
+
+// Compile renders a paper project with pandoc (R6.4, R6.6, R6.7) and
+// returns the output paths, <project>/<slug>.pdf, .tex, .docx, or .html
+// for each format. The project's metadata (paper.yaml, else the title
+// page) and numbered sections are joined into one document in which [Key]
+// citations of references.yaml entries become pandoc citations and
+// evidence blocks are dropped. The references are written beside the
+// output as <slug>.json, the CSL-JSON bibliography pandoc formats, and
+// <slug>.bib for LaTeX submissions.
+func Compile(projectDir string, opts CompileOptions) ([]string, error) {
+	formats := opts.Formats
+	if len(formats) == 0 {
+		formats = []string{"pdf"}
+	}
+	for _, f := range formats {
+		if _, ok := pandocWriters[f]; !ok {
+			return nil, fmt.Errorf("unknown output format %q: use %s", f, strings.Join(CompileFormats, ", "))
+		}
+	}
+	if opts.Columns < 0 || opts.Columns > 2 {
+		return nil, fmt.Errorf("invalid column count %d: use 1 or 2", opts.Columns)
+	}
+	if _, err := exec.LookPath(binPandoc); err != nil {
+		return nil, fmt.Errorf("pandoc not found on PATH: install it from https://pandoc.org")
+	}
+	var latex []string
+	if opts.Template != "" {
+		path, cleanup, err := resolveTemplate(opts.Template)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		latex = append(latex, "--template="+path)
+	}
+	switch opts.Columns {
+	case 1:
+		latex = append(latex, "--variable=onecolumn")
+	case 2:
+		latex = append(latex, "--variable=twocolumn", "--variable=classoption:twocolumn")
+	}
+	for _, f := range []struct{ what, path string }{{"citation style", opts.CSL}, {"reference document", opts.ReferenceDoc}} {
+		if f.path == "" {
+			continue
+		}
+		if _, err := os.Stat(f.path); err != nil {
+			return nil, fmt.Errorf("%s: %w", f.what, err)
+		}
+	}
+	if opts.ReferenceDoc == "" {
+		if path := filepath.Join(projectDir, referenceDocFile); fileExists(path) {
+			opts.ReferenceDoc = path
+		}
+	}
+	slug := filepath.Base(filepath.Clean(projectDir))
+
+	refs, err := LoadReferences(projectDir)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		refs = &types.ReferencesFile{}
+	case err != nil:
+		return nil, err
+	}
+	keys := make(map[string]bool)
+	common := []string{"--from=markdown", "--resource-path=" + projectDir}
+	if len(refs.Papers) > 0 {
+		for _, r := range refs.Papers {
+			keys[r.CitationKey] = true
+		}
+		csl, err := GenerateCSLJSON(refs)
+		if err != nil {
+			return nil, err
+		}
+		bibPath := filepath.Join(projectDir, slug+".json")
+		if err := os.WriteFile(bibPath, csl, 0o644); err != nil {
+			return nil, fmt.Errorf("writing CSL-JSON: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(projectDir, slug+".bib"), []byte(GenerateBibTeX(refs)), 0o644); err != nil {
+			return nil, fmt.Errorf("writing BibTeX: %w", err)
+		}
+		common = append(common, "--citeproc", "--bibliography="+bibPath)
+		if opts.CSL != "" {
+			common = append(common, "--csl="+opts.CSL)
+		}
+	}
+
+	doc, err := manuscript(projectDir, keys)
+	if err != nil {
+		return nil, err
+	}
+	var outputs []string
+	for _, format := range formats {
+		out := filepath.Join(projectDir, slug+"."+format)
+		args := append(append([]string{}, common...), pandocWriters[format]...)
+		switch {
+		case format == "docx" && opts.ReferenceDoc != "":
+			args = append(args, "--reference-doc="+opts.ReferenceDoc)
+		case format == "pdf" || format == "tex":
+			args = append(args, latex...)
+		}
+		args = append(args, "-o", out)
+
+		cmd := exec.Command(binPandoc, args...)
+		cmd.Stdin = strings.NewReader(doc)
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return outputs, fmt.Errorf("pandoc (%s): %w", format, err)
+		}
+		outputs = append(outputs, out)
+	}
+	return outputs, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// manuscript joins a project's metadata block and numbered sections into
+// one Markdown document, rewriting citations of keys as pandoc citations
+// ([Key1; Key2] becomes [@Key1; @Key2]) and dropping evidence blocks.
+// Brackets holding anything but known keys are left alone.
+func manuscript(projectDir string, keys map[string]bool) (string, error) {
+	files, err := SectionFiles(projectDir)
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("no numbered section files (NN-*.md) found in %s", projectDir)
+	}
+	meta, err := LoadPaperMeta(projectDir)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if meta != nil {
+		block, err := pandocMetadata(meta)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(block)
+		b.WriteString("\n")
+	}
+	for _, f := range files {
+		if meta != nil && filepath.Base(f) == titlePageFile {
+			continue
+		}
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", filepath.Base(f), err)
+		}
+		before, _, after := splitEvidence(string(data))
+		text := citationPattern.ReplaceAllStringFunc(before+after, func(m string) string {
+			parts := strings.Split(m[1:len(m)-1], ";")
+			for i, p := range parts {
+				key := strings.TrimSpace(p)
+				if !keys[key] {
+					return m
+				}
+				parts[i] = "@" + key
+			}
+			return "[" + strings.Join(parts, "; ") + "]"
+		})
+		b.WriteString(strings.TrimRight(text, "\n"))
+		b.WriteString("\n\n")
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,74 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package draft
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// cslTypes maps reference types to CSL item types (R5.5).
+var cslTypes = map[types.ReferenceType]string{
+	types.RefArticle:       "article-journal",
+	types.RefInProceedings: "paper-conference",
+	types.RefPatent:        "patent",
+	types.RefMisc:          "article",
+}
+
+// cslItem is a bibliography entry in CSL-JSON, the input format of
+// citeproc and most reference managers.
+type cslItem struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	Title          string    `json:"title,omitempty"`
+	Author         []cslName `json:"author,omitempty"`
+	Issued         *cslDate  `json:"issued,omitempty"`
+	ContainerTitle string    `json:"container-title,omitempty"`
+	Number         string    `json:"number,omitempty"`
+	DOI            string    `json:"DOI,omitempty"`
+}
+
+type cslName struct {
+	Family string `json:"family"`
+}
+
+type cslDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+// GenerateCSLJSON produces a CSL-JSON bibliography from a ReferencesFile
+// (R6.5): one item per reference, keyed by its citation key and typed as
+// article-journal, paper-conference, patent, or article (for misc).
+func GenerateCSLJSON(refs *types.ReferencesFile) ([]byte, error) {
+	items := make([]cslItem, 0, len(refs.Papers))
+	for _, r := range refs.Papers {
+		refType := r.Type
+		if refType == "" {
+			refType = types.RefArticle
+		}
+		cslType, ok := cslTypes[refType]
+		if !ok {
+			return nil, fmt.Errorf("reference %s: unknown type %q (use article, inproceedings, patent, or misc)", r.CitationKey, r.Type)
+		}
+		item := cslItem{ID: r.CitationKey, Type: cslType, Title: r.Title, DOI: r.DOI}
+		for _, a := range r.Authors {
+			item.Author = append(item.Author, cslName{Family: a})
+		}
+		if r.Year > 0 {
+			item.Issued = &cslDate{DateParts: [][]int{{r.Year}}}
+		}
+		if refType == types.RefPatent {
+			item.Number = r.PaperID
+		} else {
+			item.ContainerTitle = r.Venue
+		}
+		items = append(items, item)
+	}
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding CSL-JSON: %w", err)
+	}
+	return append(data, '\n'), nil
+}
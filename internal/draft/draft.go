@@ -1,7 +1,7 @@
 // Copyright Mesh Intelligence Inc., 2026. All rights reserved.
 
 // Package draft provides utilities for loading and validating paper projects.
-// Implements: prd007-paper-writing (R4, R5, R6);
+// Implements: prd007-paper-writing (R4, R5, R6, R7);
 //
 //	docs/ARCHITECTURE § Claude Skills § write-paper.
 package draft
@@ -78,6 +78,8 @@ func SectionFiles(projectDir string) ([]string, error) {
 
 // ValidateCitations scans section files for inline citation keys and returns
 // any keys that have no corresponding entry in references.yaml. Per R6.3.
+// Keys suggested in a section's evidence block are not citations and are
+// skipped.
 func ValidateCitations(projectDir string) ([]string, error) {
 	refs, err := LoadReferences(projectDir)
 	if err != nil {
@@ -100,7 +102,8 @@ func ValidateCitations(projectDir string) ([]string, error) {
 		if err != nil {
 			return nil, fmt.Errorf("reading %s: %w", filepath.Base(f), err)
 		}
-		for _, key := range extractCitationKeys(string(data)) {
+		before, _, after := splitEvidence(string(data))
+		for _, key := range extractCitationKeys(before + after) {
 			if !knownKeys[key] && !seen[key] {
 				seen[key] = true
 			}
@@ -157,11 +160,26 @@ func isCitationKey(s string) bool {
 	return hasLetter && hasDigit
 }
 
+// bibVenueFields names the BibTeX field holding the venue of each reference
+// type; a patent has none.
+var bibVenueFields = map[types.ReferenceType]string{
+	types.RefArticle:       "journal",
+	types.RefInProceedings: "booktitle",
+	types.RefMisc:          "howpublished",
+}
+
 // GenerateBibTeX produces BibTeX content from a ReferencesFile. Per R6.4.
+// Each entry takes its type from the reference (R5.5): @article with a
+// journal, @inproceedings with a booktitle, @misc with howpublished, or the
+// biblatex @patent with the patent number.
 func GenerateBibTeX(refs *types.ReferencesFile) string {
 	var b strings.Builder
 	for _, r := range refs.Papers {
-		fmt.Fprintf(&b, "@article{%s,\n", r.CitationKey)
+		refType := r.Type
+		if refType == "" {
+			refType = types.RefArticle
+		}
+		fmt.Fprintf(&b, "@%s{%s,\n", refType, r.CitationKey)
 		fmt.Fprintf(&b, "  title = {%s},\n", r.Title)
 		if len(r.Authors) > 0 {
 			fmt.Fprintf(&b, "  author = {%s},\n", strings.Join(r.Authors, " and "))
@@ -169,8 +187,14 @@ func GenerateBibTeX(refs *types.ReferencesFile) string {
 		if r.Year > 0 {
 			fmt.Fprintf(&b, "  year = {%d},\n", r.Year)
 		}
-		if r.Venue != "" {
-			fmt.Fprintf(&b, "  journal = {%s},\n", r.Venue)
+		if field := bibVenueFields[refType]; r.Venue != "" && field != "" {
+			fmt.Fprintf(&b, "  %s = {%s},\n", field, r.Venue)
+		}
+		if refType == types.RefPatent {
+			fmt.Fprintf(&b, "  number = {%s},\n", r.PaperID)
+		}
+		if r.DOI != "" {
+			fmt.Fprintf(&b, "  doi = {%s},\n", r.DOI)
 		}
 		fmt.Fprintf(&b, "}\n\n")
 	}
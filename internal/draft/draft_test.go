@@ -3,10 +3,13 @@
 package draft
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pdiddy/research-engine/pkg/types"
 )
@@ -522,6 +525,21 @@ func TestGenerateBibTeX(t *testing.T) {
 				"@article{B2021,",
 			},
 		},
+		{
+			name: "typed entries",
+			refs: &types.ReferencesFile{
+				Papers: []types.ReferenceEntry{
+					{CitationKey: "C2022", Title: "Conf", Year: 2022, Venue: "NeurIPS", Type: types.RefInProceedings, DOI: "10.1/c"},
+					{CitationKey: "P2019", PaperID: "US10000000B2", Title: "Patent", Year: 2019, Venue: "USPTO", Type: types.RefPatent},
+					{CitationKey: "M2024", Title: "Preprint", Year: 2024, Venue: "arXiv", Type: types.RefMisc},
+				},
+			},
+			contains: []string{
+				"@inproceedings{C2022,\n  title = {Conf},\n  year = {2022},\n  booktitle = {NeurIPS},\n  doi = {10.1/c},\n}",
+				"@patent{P2019,\n  title = {Patent},\n  year = {2019},\n  number = {US10000000B2},\n}",
+				"@misc{M2024,\n  title = {Preprint},\n  year = {2024},\n  howpublished = {arXiv},\n}",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -553,3 +571,400 @@ func TestGenerateBibTeXNoVenue(t *testing.T) {
 		t.Error("BibTeX should not contain journal field when venue is empty")
 	}
 }
+
+func TestGenerateCSLJSON(t *testing.T) {
+	refs := &types.ReferencesFile{Papers: []types.ReferenceEntry{
+		{CitationKey: "Vaswani2017", Title: "Attention Is All You Need", Authors: []string{"Vaswani", "Shazeer"}, Year: 2017, Venue: "NeurIPS", Type: types.RefInProceedings},
+		{CitationKey: "Brown2020", Title: "Language Models", Year: 2020, Venue: "JMLR"},
+		{CitationKey: "Lee2019", PaperID: "US10000000B2", Title: "Widget", Type: types.RefPatent},
+	}}
+	data, err := GenerateCSLJSON(refs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var items []map[string]any
+	if err := json.Unmarshal(data, &items); err != nil {
+		t.Fatalf("invalid CSL-JSON: %v\n%s", err, data)
+	}
+	if len(items) != 3 {
+		t.Fatalf("got %d items", len(items))
+	}
+	for i, want := range []map[string]string{
+		{"id": "Vaswani2017", "type": "paper-conference", "container-title": "NeurIPS"},
+		{"id": "Brown2020", "type": "article-journal", "container-title": "JMLR"},
+		{"id": "Lee2019", "type": "patent", "number": "US10000000B2"},
+	} {
+		for k, v := range want {
+			if items[i][k] != v {
+				t.Errorf("item %d %s = %v, want %s", i, k, items[i][k], v)
+			}
+		}
+	}
+	if !strings.Contains(string(data), `"family": "Shazeer"`) || !strings.Contains(string(data), `"date-parts": [`) {
+		t.Errorf("authors or date missing:\n%s", data)
+	}
+
+	refs.Papers[0].Type = "book"
+	if _, err := GenerateCSLJSON(refs); err == nil {
+		t.Error("unknown reference type accepted")
+	}
+}
+
+func TestManuscript(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "00-title-page.md", "---\ntitle: T\n---\n")
+	writeFile(t, dir, "01-intro.md", "# Intro\n\nAs shown [Smith2023; Doe2024], see [the code](https://x.org) and [Other2020].\n\n"+
+		RenderEvidence("intro", []Evidence{{ItemID: "p1-claim1", Type: "claim", Content: "c", CiteKey: "Smith2023"}}))
+	writeFile(t, dir, "02-end.md", "# End\n[Smith2023]\n")
+
+	got, err := manuscript(dir, map[string]bool{"Smith2023": true, "Doe2024": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "---\ntitle: T\n---\n\n# Intro\n\nAs shown [@Smith2023; @Doe2024], see [the code](https://x.org) and [Other2020].\n\n# End\n[@Smith2023]\n\n"
+	if got != want {
+		t.Errorf("manuscript =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestInit(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "output", "papers", "efficient-attention")
+	refs := []types.ReferenceEntry{{CitationKey: "Smith2023", PaperID: "2301.00001", Title: "Efficient Attention", Authors: []string{"Smith"}, Year: 2023}}
+	files, err := Init(dir, InitOptions{
+		Topic:      "efficient attention",
+		Sections:   []string{"Introduction", "Sparse & Linear Attention", "Conclusion"},
+		References: refs,
+		Date:       time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 6 {
+		t.Errorf("wrote %d files, want title page, 3 sections, outline, references: %v", len(files), files)
+	}
+
+	outline, err := LoadOutline(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outline.Sections) != 3 {
+		t.Fatalf("outline has %d sections", len(outline.Sections))
+	}
+	sec := outline.Sections[1]
+	if sec.Number != "02" || sec.File != "02-sparse-linear-attention.md" || sec.Status != types.StatusOutline {
+		t.Errorf("section = %+v", sec)
+	}
+	if d := outline.Sections[0].Description; !strings.Contains(d, "efficient attention") {
+		t.Errorf("introduction description = %q", d)
+	}
+	stub, err := os.ReadFile(filepath.Join(dir, sec.File))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(stub), "# Sparse & Linear Attention\n") {
+		t.Errorf("stub = %q", stub)
+	}
+	sections, _ := SectionFiles(dir)
+	if len(sections) != 4 {
+		t.Errorf("section files = %v", sections)
+	}
+
+	loaded, err := LoadReferences(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Papers) != 1 || loaded.Papers[0].CitationKey != "Smith2023" {
+		t.Errorf("references = %+v", loaded.Papers)
+	}
+	title, err := os.ReadFile(filepath.Join(dir, "00-title-page.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"title: efficient attention", "date: \"2026-03-01\"", "type: survey"} {
+		if !strings.Contains(string(title), want) {
+			t.Errorf("title page missing %q:\n%s", want, title)
+		}
+	}
+
+	if _, err := Init(dir, InitOptions{Topic: "again"}); err == nil {
+		t.Error("Init overwrote an existing project")
+	}
+}
+
+func TestInitRejects(t *testing.T) {
+	for name, opts := range map[string]InitOptions{
+		"no topic":          {},
+		"bad type":          {Topic: "x", Type: "novel"},
+		"duplicate section": {Topic: "x", Sections: []string{"Results", "results"}},
+		"empty section":     {Topic: "x", Sections: []string{"Results", "??"}},
+	} {
+		dir := filepath.Join(t.TempDir(), "p")
+		if _, err := Init(dir, opts); err == nil {
+			t.Errorf("%s: Init accepted %+v", name, opts)
+		}
+		if _, err := os.Stat(dir); err == nil {
+			t.Errorf("%s: rejected project directory was created", name)
+		}
+	}
+}
+
+func TestSlug(t *testing.T) {
+	for in, want := range map[string]string{
+		"Related Work":             "related-work",
+		"  Attention: A Survey!  ": "attention-a-survey",
+		"GPT-4 and Beyond (2024)":  "gpt-4-and-beyond-2024",
+	} {
+		if got := Slug(in); got != want {
+			t.Errorf("Slug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestEvidenceQuery(t *testing.T) {
+	content := "# Sparse Attention\n\n" +
+		"Sparse patterns cut the cost of attention [Child2019]. Sparse kernels\n" +
+		"and sparse masks are the most common patterns.\n\n" +
+		RenderEvidence("old query", []Evidence{{ItemID: "x-claim1", Type: "claim", Content: "unrelated", CiteKey: "Old2020"}})
+	got := EvidenceQuery(content)
+	// Heading words first, then body words by frequency; stopwords,
+	// citation keys, and the old evidence block are left out.
+	if !strings.HasPrefix(got, "sparse attention patterns ") {
+		t.Errorf("query = %q", got)
+	}
+	for _, unwanted := range []string{"the", "child2019", "unrelated", "old"} {
+		for _, w := range strings.Fields(got) {
+			if w == unwanted {
+				t.Errorf("query %q contains %q", got, unwanted)
+			}
+		}
+	}
+}
+
+func TestWriteEvidence(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "01-intro.md", "# Intro\n\nText [Smith2023].\n")
+	writeFile(t, dir, "references.yaml", "papers:\n  - citation_key: Smith2023\n    paper_id: p1\n    title: T\n    authors: [Smith]\n    year: 2023\n")
+	path := filepath.Join(dir, "01-intro.md")
+
+	first := RenderEvidence("intro text", []Evidence{
+		{ItemID: "p1-claim1", Type: "claim", Content: "A claim -- with dashes", CiteKey: "Smith2023", Listed: true},
+		{ItemID: "p2-result1", Type: "result", Content: "A result", CiteKey: "Doe2024"},
+	})
+	if strings.Count(first, "-->") != 1 {
+		t.Errorf("content closed the comment early:\n%s", first)
+	}
+	if !strings.Contains(first, "- [Doe2024] (not in references.yaml) p2-result1 (result): A result\n") {
+		t.Errorf("block:\n%s", first)
+	}
+	if err := WriteEvidence(path, first); err != nil {
+		t.Fatal(err)
+	}
+	// Text written after the block survives a second run, which replaces
+	// the block in place.
+	data, _ := os.ReadFile(path)
+	os.WriteFile(path, append(data, "More text.\n"...), 0o644)
+	second := RenderEvidence("intro text", nil)
+	if err := WriteEvidence(path, second); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(path)
+	want := "# Intro\n\nText [Smith2023].\n\n" + second + "More text.\n"
+	if string(data) != want {
+		t.Errorf("section =\n%q\nwant\n%q", data, want)
+	}
+
+	// Suggested keys are not citations.
+	if err := WriteEvidence(path, first); err != nil {
+		t.Fatal(err)
+	}
+	missing, err := ValidateCitations(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 0 {
+		t.Errorf("missing = %v, want none", missing)
+	}
+}
+
+// fakePandoc puts a pandoc on PATH that records its arguments, one per
+// line, in the returned file and creates the -o output.
+func fakePandoc(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake pandoc is a shell script")
+	}
+	bin := t.TempDir()
+	log := filepath.Join(bin, "args.log")
+	script := "#!/bin/sh\nwhile read -r _; do :; done\nout=\nfor a in \"$@\"; do echo \"$a\" >>" + log + "; [ \"$prev\" = -o ] && out=$a; prev=$a; done\necho --- >>" + log + "\n: >\"$out\"\n"
+	if err := os.WriteFile(filepath.Join(bin, "pandoc"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+	return log
+}
+
+func TestCompileFormats(t *testing.T) {
+	log := fakePandoc(t)
+	dir := filepath.Join(t.TempDir(), "my-survey")
+	os.Mkdir(dir, 0o755)
+	writeFile(t, dir, "01-intro.md", "# Intro\n\n[Smith2023]\n")
+	writeFile(t, dir, "references.yaml", "papers:\n  - citation_key: Smith2023\n    paper_id: p1\n    title: T\n    authors: [Smith]\n    year: 2023\n")
+	writeFile(t, dir, "reference.docx", "")
+
+	outputs, err := Compile(dir, CompileOptions{Formats: []string{"pdf", "docx", "html"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 3 || filepath.Base(outputs[1]) != "my-survey.docx" {
+		t.Fatalf("outputs = %v", outputs)
+	}
+	for _, name := range []string{"my-survey.json", "my-survey.bib", "my-survey.html"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s not written: %v", name, err)
+		}
+	}
+
+	data, _ := os.ReadFile(log)
+	runs := strings.Split(strings.TrimSuffix(string(data), "---\n"), "---\n")
+	if len(runs) != 3 {
+		t.Fatalf("pandoc ran %d times:\n%s", len(runs), data)
+	}
+	for i, want := range [][]string{
+		{"--to=latex", "--citeproc"},
+		{"--to=docx", "--reference-doc=" + filepath.Join(dir, "reference.docx")},
+		{"--to=html5", "--standalone", "--embed-resources"},
+	} {
+		for _, arg := range want {
+			if !strings.Contains(runs[i], arg+"\n") {
+				t.Errorf("run %d missing %s:\n%s", i, arg, runs[i])
+			}
+		}
+	}
+	if strings.Contains(runs[0], "--reference-doc") {
+		t.Errorf("pdf run got a reference doc:\n%s", runs[0])
+	}
+
+	if _, err := Compile(dir, CompileOptions{Formats: []string{"epub"}}); err == nil {
+		t.Error("unknown format accepted")
+	}
+	if _, err := Compile(dir, CompileOptions{CSL: filepath.Join(dir, "missing.csl")}); err == nil {
+		t.Error("missing CSL style accepted")
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	if got := strings.Join(Templates(), ","); got != "acm,arxiv,ieee" {
+		t.Errorf("Templates() = %s", got)
+	}
+	for _, name := range Templates() {
+		data, err := builtinTemplates.ReadFile("templates/" + name + templateExt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{"${ common() }", "$body$", "$for(authors)$", "$abstract$", "$for(keywords)$"} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("%s template lacks %s", name, want)
+			}
+		}
+	}
+}
+
+func TestResolveTemplate(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	path, cleanup, err := resolveTemplate("ieee")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A built-in template is written with the partial it includes.
+	if _, err := os.Stat(filepath.Join(filepath.Dir(path), "common.latex")); err != nil {
+		t.Errorf("common partial not written: %v", err)
+	}
+	cleanup()
+	if _, err := os.Stat(path); err == nil {
+		t.Error("cleanup left the template behind")
+	}
+
+	os.Mkdir("templates", 0o755)
+	writeFile(t, "templates", "ieee.latex", "$body$\n")
+	if path, _, _ := resolveTemplate("ieee"); path != filepath.Join("templates", "ieee.latex") {
+		t.Errorf("local template not preferred: %s", path)
+	}
+	writeFile(t, ".", "mine.tex", "$body$\n")
+	if path, _, _ := resolveTemplate("mine.tex"); path != "mine.tex" {
+		t.Errorf("template path = %s", path)
+	}
+	for _, name := range []string{"springer", "common", "templates"} {
+		if _, _, err := resolveTemplate(name); err == nil {
+			t.Errorf("resolveTemplate(%q) accepted", name)
+		}
+	}
+}
+
+func TestLoadPaperMeta(t *testing.T) {
+	dir := t.TempDir()
+	meta, err := LoadPaperMeta(dir)
+	if err != nil || meta != nil {
+		t.Fatalf("empty project: meta = %+v, err = %v", meta, err)
+	}
+
+	writeFile(t, dir, "00-title-page.md", "---\ntitle: From Title Page\ndate: 2026-03-01\nkeywords: [a]\n---\n")
+	meta, err = LoadPaperMeta(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Title != "From Title Page" || meta.Date != "2026-03-01" {
+		t.Errorf("title page meta = %+v", meta)
+	}
+
+	writeFile(t, dir, "paper.yaml", "title: Venue Title\nauthors:\n  - name: Ada Lovelace\n    affiliation: Analytical Society\n    email: ada@example.org\nabstract: We survey engines.\nkeywords: [engines]\n")
+	meta, err = LoadPaperMeta(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Title != "Venue Title" || len(meta.Authors) != 1 || meta.Authors[0].Email != "ada@example.org" {
+		t.Errorf("paper.yaml meta = %+v", meta)
+	}
+
+	// paper.yaml replaces the title page in the manuscript.
+	writeFile(t, dir, "01-intro.md", "# Intro\n")
+	doc, err := manuscript(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(doc, "From Title Page") || !strings.Contains(doc, "title: Venue Title") ||
+		!strings.Contains(doc, "author:\n    - Ada Lovelace") || !strings.Contains(doc, "email: ada@example.org") {
+		t.Errorf("manuscript =\n%s", doc)
+	}
+}
+
+func TestCompileTemplate(t *testing.T) {
+	log := fakePandoc(t)
+	dir := filepath.Join(t.TempDir(), "p")
+	os.Mkdir(dir, 0o755)
+	writeFile(t, dir, "01-intro.md", "# Intro\n")
+
+	outputs, err := Compile(dir, CompileOptions{Formats: []string{"tex", "html"}, Template: "acm", Columns: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 2 || filepath.Base(outputs[0]) != "p.tex" {
+		t.Fatalf("outputs = %v", outputs)
+	}
+	data, _ := os.ReadFile(log)
+	runs := strings.Split(strings.TrimSuffix(string(data), "---\n"), "---\n")
+	if !strings.Contains(runs[0], "acm.latex\n") || !strings.Contains(runs[0], "--variable=onecolumn\n") || !strings.Contains(runs[0], "--standalone\n") {
+		t.Errorf("tex run:\n%s", runs[0])
+	}
+	// The template only applies to LaTeX output.
+	if strings.Contains(runs[1], "--template") || strings.Contains(runs[1], "onecolumn") {
+		t.Errorf("html run:\n%s", runs[1])
+	}
+
+	if _, err := Compile(dir, CompileOptions{Columns: 3}); err == nil {
+		t.Error("three columns accepted")
+	}
+	if _, err := Compile(dir, CompileOptions{Template: "springer"}); err == nil {
+		t.Error("unknown template accepted")
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package draft
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// evidenceStart opens the evidence comment block of a section file (R8.2).
+const evidenceStart = "<!-- Evidence"
+
+// maxEvidenceTerms bounds the words of a section's evidence query, so a
+// long section does not become a query that matches everything.
+const maxEvidenceTerms = 24
+
+// proseStopwords are common English words that carry no retrieval signal
+// in section prose.
+var proseStopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true,
+	"that": true, "this": true, "these": true, "those": true, "there": true,
+	"their": true, "they": true, "them": true, "have": true, "has": true,
+	"had": true, "are": true, "was": true, "were": true, "been": true,
+	"being": true, "which": true, "what": true, "when": true, "where": true,
+	"who": true, "why": true, "how": true, "into": true, "onto": true,
+	"about": true, "also": true, "can": true, "could": true, "would": true,
+	"should": true, "may": true, "might": true, "must": true, "will": true,
+	"not": true, "but": true, "such": true, "than": true, "then": true,
+	"its": true, "our": true, "over": true, "under": true, "more": true,
+	"most": true, "other": true, "some": true, "any": true, "each": true,
+	"all": true, "both": true, "between": true, "while": true, "does": true,
+	"section": true, "describe": true, "covers": true, "paper": true,
+}
+
+// Evidence is a knowledge item suggested as support for a section (R8.1).
+type Evidence struct {
+	ItemID  string
+	Type    string
+	Content string
+	PaperID string
+
+	// CiteKey is the key to cite the item's paper with, and Listed whether
+	// references.yaml already has it.
+	CiteKey string
+	Listed  bool
+}
+
+// EvidenceQuery returns the words to search the knowledge base with for a
+// section file's content (R8.1): the words of its headings, then its most
+// frequent other content words, at most 24 in all. Any evidence block
+// already in the file is ignored.
+func EvidenceQuery(content string) string {
+	before, _, after := splitEvidence(content)
+	content = citationPattern.ReplaceAllStringFunc(before+after, func(m string) string {
+		if len(extractCitationKeys(m)) > 0 {
+			return ""
+		}
+		return m
+	})
+
+	var terms []string
+	seen := make(map[string]bool)
+	add := func(w string) {
+		if len(terms) < maxEvidenceTerms && !seen[w] {
+			seen[w] = true
+			terms = append(terms, w)
+		}
+	}
+
+	counts := make(map[string]int)
+	var order []string
+	for _, line := range strings.Split(content, "\n") {
+		heading := strings.HasPrefix(strings.TrimSpace(line), "#")
+		for _, w := range contentWords(line) {
+			if heading {
+				add(w)
+				continue
+			}
+			if counts[w] == 0 {
+				order = append(order, w)
+			}
+			counts[w]++
+		}
+	}
+	// Most frequent first; ties keep the order of first use.
+	sort.SliceStable(order, func(i, j int) bool { return counts[order[i]] > counts[order[j]] })
+	for _, w := range order {
+		add(w)
+	}
+	return strings.Join(terms, " ")
+}
+
+// contentWords returns the lowercase words of text worth searching for:
+// three letters or more and not stopwords.
+func contentWords(text string) []string {
+	var words []string
+	for _, w := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	}) {
+		w = strings.ToLower(strings.Trim(w, "-"))
+		if len(w) < 3 || proseStopwords[w] {
+			continue
+		}
+		words = append(words, w)
+	}
+	return words
+}
+
+// RenderEvidence formats the evidence comment block for a section (R8.2):
+// the query, then one line per item with its suggested citation, item ID,
+// type, and content. Citations not yet in references.yaml are marked. The
+// block is an HTML comment, so it never reaches compiled output.
+func RenderEvidence(query string, evidence []Evidence) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (draft evidence): %s\n", evidenceStart, commentSafe(query))
+	if len(evidence) == 0 {
+		b.WriteString("No matching items in the knowledge base.\n")
+	}
+	for _, e := range evidence {
+		mark := ""
+		if !e.Listed {
+			mark = " (not in references.yaml)"
+		}
+		fmt.Fprintf(&b, "- [%s]%s %s (%s): %s\n", e.CiteKey, mark, e.ItemID, e.Type,
+			commentSafe(strings.Join(strings.Fields(e.Content), " ")))
+	}
+	b.WriteString("-->\n")
+	return b.String()
+}
+
+// WriteEvidence replaces the evidence block of a section file with block,
+// or appends block when the file has none.
+func WriteEvidence(path, block string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading section: %w", err)
+	}
+	before, old, after := splitEvidence(string(data))
+	var content string
+	if old == "" {
+		content = strings.TrimRight(before, "\n") + "\n\n" + block
+	} else {
+		content = before + block + after
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("writing section: %w", err)
+	}
+	return nil
+}
+
+// splitEvidence splits a section file's content around its evidence
+// block: the text before it, the block through its closing line, and the
+// text after it. Without a block, all of content is before.
+func splitEvidence(content string) (before, block, after string) {
+	start := strings.Index("\n"+content, "\n"+evidenceStart+" ")
+	if start < 0 {
+		return content, "", ""
+	}
+	end := strings.Index(content[start:], "\n-->")
+	if end < 0 {
+		return content[:start], content[start:], ""
+	}
+	end += start + len("\n-->")
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+	return content[:start], content[start:end], content[end:]
+}
+
+// commentSafe keeps text from closing the HTML comment it is written in.
+func commentSafe(s string) string {
+	return strings.ReplaceAll(s, "--", "- -")
+}
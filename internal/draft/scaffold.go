@@ -0,0 +1,228 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package draft
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// titlePageFile is the title page of a paper project (R1.3).
+const titlePageFile = "00-title-page.md"
+
+// PaperTypes are the paper types a title page may declare (R2.1).
+var PaperTypes = []string{"survey", "literature-review", "original-research", "position-paper"}
+
+// DefaultSections are the sections of a new paper project when none are
+// given, with what each covers; %s is the topic.
+var DefaultSections = []types.OutlineSection{
+	{Title: "Introduction", Description: "Motivates the study of %s and states the scope and contributions."},
+	{Title: "Background", Description: "Defines the terms and prior results a reader needs to follow the discussion of %s."},
+	{Title: "Related Work", Description: "Reviews and compares existing approaches to %s."},
+	{Title: "Discussion", Description: "Synthesizes the findings on %s: agreements, conflicts, and open problems."},
+	{Title: "Conclusion", Description: "Summarizes what is known about %s and where future work should go."},
+}
+
+// InitOptions describes a new paper project (R7.1).
+type InitOptions struct {
+	// Topic is what the paper is about. It fills the section descriptions
+	// and, when Title is empty, the title.
+	Topic string
+
+	// Title is the paper title; empty uses the topic.
+	Title string
+
+	// Type is one of PaperTypes; empty is "survey".
+	Type string
+
+	// Keywords for the title page; empty uses the topic.
+	Keywords []string
+
+	// Sections are the section titles in order; empty uses
+	// DefaultSections.
+	Sections []string
+
+	// References seed references.yaml.
+	References []types.ReferenceEntry
+
+	// Date is the title page date; zero is today.
+	Date time.Time
+}
+
+// Init creates a paper project in projectDir (R7.1): 00-title-page.md,
+// one numbered stub per section, outline.yaml with every section at
+// status outline, and references.yaml. It returns the files written.
+// A projectDir that exists and is not empty is an error, so a project is
+// never overwritten.
+func Init(projectDir string, opts InitOptions) ([]string, error) {
+	if strings.TrimSpace(opts.Topic) == "" {
+		return nil, fmt.Errorf("a paper project requires a topic")
+	}
+	if opts.Type == "" {
+		opts.Type = "survey"
+	}
+	if !validPaperType(opts.Type) {
+		return nil, fmt.Errorf("invalid paper type %q: use one of %s", opts.Type, strings.Join(PaperTypes, ", "))
+	}
+	outline, err := newOutline(opts)
+	if err != nil {
+		return nil, err
+	}
+	if entries, err := os.ReadDir(projectDir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("%s already exists and is not empty", projectDir)
+	}
+	if err := os.MkdirAll(projectDir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating project directory: %w", err)
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = opts.Topic
+	}
+	keywords := opts.Keywords
+	if len(keywords) == 0 {
+		keywords = []string{opts.Topic}
+	}
+	date := opts.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	meta := types.TitlePageMeta{
+		Title:    title,
+		Authors:  []types.Author{},
+		Date:     date.Format("2006-01-02"),
+		Type:     opts.Type,
+		Keywords: keywords,
+	}
+	refs := types.ReferencesFile{Papers: opts.References}
+	if refs.Papers == nil {
+		refs.Papers = []types.ReferenceEntry{}
+	}
+
+	var written []string
+	write := func(name string, data []byte) error {
+		path := filepath.Join(projectDir, name)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+		written = append(written, path)
+		return nil
+	}
+
+	front, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("encoding title page: %w", err)
+	}
+	if err := write(titlePageFile, []byte("---\n"+string(front)+"---\n")); err != nil {
+		return nil, err
+	}
+	for _, sec := range outline.Sections {
+		if err := write(sec.File, []byte(sectionStub(sec))); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range []struct {
+		name string
+		v    any
+	}{{outlineFile, outline}, {referencesFile, refs}} {
+		data, err := yaml.Marshal(f.v)
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s: %w", f.name, err)
+		}
+		if err := write(f.name, data); err != nil {
+			return nil, err
+		}
+	}
+	return written, nil
+}
+
+// newOutline numbers the sections of opts from 01 (R3.4) and names their
+// files NN-slug.md (R3.5).
+func newOutline(opts InitOptions) (*types.Outline, error) {
+	sections := DefaultSections
+	if len(opts.Sections) > 0 {
+		sections = make([]types.OutlineSection, len(opts.Sections))
+		for i, title := range opts.Sections {
+			sections[i].Title = strings.TrimSpace(title)
+			for _, d := range DefaultSections {
+				if strings.EqualFold(d.Title, sections[i].Title) {
+					sections[i].Description = d.Description
+				}
+			}
+		}
+	}
+	if len(sections) > 99 {
+		return nil, fmt.Errorf("too many sections: %d (at most 99)", len(sections))
+	}
+
+	outline := &types.Outline{}
+	slugs := make(map[string]bool)
+	for i, sec := range sections {
+		slug := Slug(sec.Title)
+		if slug == "" {
+			return nil, fmt.Errorf("section %d has no usable title %q", i+1, sec.Title)
+		}
+		if slugs[slug] {
+			return nil, fmt.Errorf("duplicate section %q", sec.Title)
+		}
+		slugs[slug] = true
+		number := fmt.Sprintf("%02d", i+1)
+		description := sec.Description
+		if strings.Contains(description, "%s") {
+			description = fmt.Sprintf(description, opts.Topic)
+		}
+		outline.Sections = append(outline.Sections, types.OutlineSection{
+			Number:      number,
+			Title:       sec.Title,
+			File:        number + "-" + slug + ".md",
+			Description: description,
+			Status:      types.StatusOutline,
+		})
+	}
+	return outline, nil
+}
+
+// sectionStub is the initial content of a section file (R3.3): its
+// heading and what it will cover.
+func sectionStub(sec types.OutlineSection) string {
+	description := sec.Description
+	if description == "" {
+		description = "Describe what this section covers."
+	}
+	return fmt.Sprintf("# %s\n\n<!-- %s -->\n", sec.Title, description)
+}
+
+// Slug returns the lowercase hyphenated form of a title, as used in
+// project directory and section file names (R1.1, R3.5).
+func Slug(title string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+	return b.String()
+}
+
+func validPaperType(t string) bool {
+	for _, p := range PaperTypes {
+		if p == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package draft
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+const (
+	// paperMetaFile holds a project's metadata for venue templates; when
+	// present it replaces the title page frontmatter (R2.4).
+	paperMetaFile = "paper.yaml"
+
+	// templateDir holds LaTeX templates in the working directory, which
+	// take precedence over the built-in templates of the same name (R6.7).
+	templateDir = "templates"
+
+	templateExt = ".latex"
+)
+
+// builtinTemplates are the pandoc LaTeX templates for venue formats.
+// common.latex is a partial the others include.
+//
+//go:embed templates/*.latex
+var builtinTemplates embed.FS
+
+// Templates returns the names of the built-in LaTeX templates: acm, arxiv,
+// and ieee (R6.7).
+func Templates() []string {
+	entries, _ := builtinTemplates.ReadDir("templates")
+	var names []string
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), templateExt)
+		if name != "common" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveTemplate returns the path of the LaTeX template named by name: a
+// template file, templates/<name>.latex in the working directory, or a
+// built-in template, which is written with its partials to a temporary
+// directory that cleanup removes.
+func resolveTemplate(name string) (path string, cleanup func(), err error) {
+	cleanup = func() {}
+	if fileExists(name) && !isDir(name) {
+		return name, cleanup, nil
+	}
+	if local := filepath.Join(templateDir, name+templateExt); fileExists(local) {
+		return local, cleanup, nil
+	}
+	if _, err := builtinTemplates.ReadFile("templates/" + name + templateExt); err != nil || name == "common" {
+		return "", cleanup, fmt.Errorf("unknown template %q: use %s, a file in %s/, or a template path",
+			name, strings.Join(Templates(), ", "), templateDir)
+	}
+
+	dir, err := os.MkdirTemp("", "research-engine-template-")
+	if err != nil {
+		return "", cleanup, fmt.Errorf("creating template directory: %w", err)
+	}
+	cleanup = func() { os.RemoveAll(dir) }
+	entries, _ := builtinTemplates.ReadDir("templates")
+	for _, e := range entries {
+		data, _ := builtinTemplates.ReadFile("templates/" + e.Name())
+		if err := os.WriteFile(filepath.Join(dir, e.Name()), data, 0o644); err != nil {
+			cleanup()
+			return "", func() {}, fmt.Errorf("writing template: %w", err)
+		}
+	}
+	return filepath.Join(dir, name+templateExt), cleanup, nil
+}
+
+// LoadPaperMeta returns a project's metadata (R2.4): paper.yaml when the
+// project has one, else the frontmatter of 00-title-page.md. A project with
+// neither has no metadata, and LoadPaperMeta returns nil.
+func LoadPaperMeta(projectDir string) (*types.TitlePageMeta, error) {
+	var meta types.TitlePageMeta
+	data, err := os.ReadFile(filepath.Join(projectDir, paperMetaFile))
+	if err == nil {
+		if err := yaml.Unmarshal(data, &meta); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", paperMetaFile, err)
+		}
+		return &meta, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("reading %s: %w", paperMetaFile, err)
+	}
+
+	data, err = os.ReadFile(filepath.Join(projectDir, titlePageFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading title page: %w", err)
+	}
+	front, ok := frontmatter(string(data))
+	if !ok {
+		return nil, nil
+	}
+	if err := yaml.Unmarshal([]byte(front), &meta); err != nil {
+		return nil, fmt.Errorf("parsing title page frontmatter: %w", err)
+	}
+	return &meta, nil
+}
+
+// frontmatter returns the YAML between the opening and closing --- lines
+// of a Markdown file.
+func frontmatter(content string) (string, bool) {
+	rest, ok := strings.CutPrefix(content, "---\n")
+	if !ok {
+		return "", false
+	}
+	if strings.HasPrefix(rest, "---\n") || rest == "---" {
+		return "", true
+	}
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return "", false
+	}
+	return rest[:end+1], true
+}
+
+// pandocMetadata is the YAML metadata block a manuscript starts with:
+// author holds the names for pandoc's own templates, and authors the
+// names, affiliations, and emails the venue templates print.
+func pandocMetadata(meta *types.TitlePageMeta) (string, error) {
+	block := map[string]any{"title": meta.Title}
+	if len(meta.Authors) > 0 {
+		names := make([]string, len(meta.Authors))
+		for i, a := range meta.Authors {
+			names[i] = a.Name
+		}
+		block["author"] = names
+		block["authors"] = meta.Authors
+	}
+	if meta.Date != "" {
+		block["date"] = meta.Date
+	}
+	if strings.TrimSpace(meta.Abstract) != "" {
+		block["abstract"] = meta.Abstract
+	}
+	if len(meta.Keywords) > 0 {
+		block["keywords"] = meta.Keywords
+	}
+	data, err := yaml.Marshal(block)
+	if err != nil {
+		return "", fmt.Errorf("encoding metadata: %w", err)
+	}
+	return "---\n" + string(data) + "---\n", nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,180 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// Identifier patterns in bibliography entries (R3.6).
+var (
+	// bibDOIRe matches a DOI, bare or inside a doi: prefix or doi.org URL.
+	bibDOIRe = regexp.MustCompile(`\b10\.\d{4,9}/[^\s"<>]+`)
+
+	// bibArxivRe matches an arXiv ID after "arXiv:" or in an arxiv.org
+	// URL, new style (2301.07041) or old (hep-th/9901001), with its
+	// version.
+	bibArxivRe = regexp.MustCompile(`(?i)(?:arxiv:\s*|arxiv\.org/(?:abs|pdf)/)(\d{4}\.\d{4,5}|[a-z]+(?:-[a-z]+)?(?:\.[a-z]{2})?/\d{7})(?:v\d+)?`)
+
+	// arxivDOIRe matches the DOI arXiv assigns its preprints.
+	arxivDOIRe = regexp.MustCompile(`(?i)^10\.48550/arxiv\.(.+)$`)
+
+	// bibURLRe matches a URL.
+	bibURLRe = regexp.MustCompile(`https?://[^\s<>"\])]+`)
+
+	// doiPrefixRe matches the text that introduces a DOI.
+	doiPrefixRe = regexp.MustCompile(`(?i)(?:https?://(?:dx\.)?doi\.org/|doi:\s*)$`)
+
+	// newArxivIDRe matches a new-style arXiv ID used as a paper ID.
+	newArxivIDRe = regexp.MustCompile(`^\d{4}\.\d{4,5}$`)
+)
+
+// extractBibIDs finds the DOI, arXiv ID, and URL in a bibliography entry
+// and returns them with the entry's text minus them, so they are not read
+// as its title, venue, or year.
+func extractBibIDs(raw string) (doi, arxivID, url, rest string) {
+	rest = raw
+	if loc := bibDOIRe.FindStringIndex(raw); loc != nil {
+		doi = trimIDPunct(raw[loc[0]:loc[1]])
+		start := loc[0]
+		if p := doiPrefixRe.FindStringIndex(raw[:start]); p != nil {
+			start = p[0]
+		}
+		rest = cutSpan(raw, start, loc[0]+len(doi))
+		if m := arxivDOIRe.FindStringSubmatch(doi); m != nil {
+			arxivID = m[1]
+		}
+	}
+	if m := bibArxivRe.FindStringSubmatchIndex(rest); m != nil {
+		arxivID = rest[m[2]:m[3]]
+		rest = cutSpan(rest, m[0], m[1])
+	}
+	if m := bibURLRe.FindStringIndex(rest); m != nil {
+		url = trimIDPunct(rest[m[0]:m[1]])
+		rest = cutSpan(rest, m[0], m[0]+len(url))
+	}
+	rest = strings.Join(strings.Fields(rest), " ")
+	return doi, arxivID, url, strings.TrimRight(rest, " ,;:")
+}
+
+// cutSpan removes s[start:end] and tidies the join: the space before
+// punctuation that followed the span, punctuation doubled by the cut, and
+// brackets left empty.
+func cutSpan(s string, start, end int) string {
+	before := strings.TrimRight(s[:start], " \t")
+	after := strings.TrimLeft(s[end:], " \t")
+	if strings.HasSuffix(before, "(") && strings.HasPrefix(after, ")") {
+		before = strings.TrimRight(strings.TrimSuffix(before, "("), " \t")
+		after = after[1:]
+	}
+	if after != "" && strings.ContainsAny(after[:1], ".,;:") {
+		if before == "" || strings.ContainsAny(before[len(before)-1:], ".,;:") {
+			after = after[1:]
+		}
+		return before + after
+	}
+	return before + " " + after
+}
+
+// trimIDPunct trims the sentence punctuation after an identifier, and a
+// closing bracket it does not open, as in "(doi:10.1/x)".
+func trimIDPunct(id string) string {
+	for {
+		trimmed := strings.TrimRight(id, ".,;:")
+		if strings.HasSuffix(trimmed, ")") && strings.Count(trimmed, "(") < strings.Count(trimmed, ")") {
+			trimmed = trimmed[:len(trimmed)-1]
+		}
+		if trimmed == id {
+			return id
+		}
+		id = trimmed
+	}
+}
+
+// corpusIndex maps the DOIs (lowercased) and arXiv IDs of the papers in
+// papersDir/metadata/ to their paper IDs.
+type corpusIndex struct {
+	doi   map[string]string
+	arxiv map[string]string
+}
+
+// corpusIndexes caches one corpusIndex per metadata directory for the
+// life of the process, so a batch reads the metadata once. An index is
+// reloaded when papers are added to or removed from the directory.
+var corpusIndexes = struct {
+	sync.Mutex
+	byDir map[string]cachedCorpusIndex
+}{byDir: make(map[string]cachedCorpusIndex)}
+
+type cachedCorpusIndex struct {
+	modTime time.Time
+	index   *corpusIndex
+}
+
+// loadCorpusIndex returns the index of the papers acquired in papersDir.
+// A missing or unreadable directory gives an empty index.
+func loadCorpusIndex(papersDir string) *corpusIndex {
+	dir := filepath.Join(papersDir, metadataDir)
+	info, err := os.Stat(dir)
+	if err != nil {
+		return &corpusIndex{}
+	}
+
+	corpusIndexes.Lock()
+	defer corpusIndexes.Unlock()
+	if c, ok := corpusIndexes.byDir[dir]; ok && c.modTime.Equal(info.ModTime()) {
+		return c.index
+	}
+
+	index := &corpusIndex{doi: make(map[string]string), arxiv: make(map[string]string)}
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".yaml") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		var paper types.Paper
+		if yaml.Unmarshal(data, &paper) != nil || paper.ID == "" {
+			continue
+		}
+		if paper.DOI != "" {
+			index.doi[strings.ToLower(paper.DOI)] = paper.ID
+		}
+		if paper.ArxivID != "" {
+			index.arxiv[paper.ArxivID] = paper.ID
+		} else if newArxivIDRe.MatchString(paper.ID) {
+			index.arxiv[paper.ID] = paper.ID
+		}
+	}
+	corpusIndexes.byDir[dir] = cachedCorpusIndex{modTime: info.ModTime(), index: index}
+	return index
+}
+
+// linkBibliography sets the PaperID of each bibliography entry whose DOI
+// or arXiv ID names a paper acquired in papersDir, other than the citing
+// paper itself (R3.6).
+func linkBibliography(entries []types.BibliographyEntry, papersDir, paperID string) {
+	index := loadCorpusIndex(papersDir)
+	for i := range entries {
+		e := &entries[i]
+		id := index.doi[strings.ToLower(e.DOI)]
+		if id == "" {
+			id = index.arxiv[e.ArxivID]
+		}
+		if id != paperID {
+			e.PaperID = id
+		}
+	}
+}
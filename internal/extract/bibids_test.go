@@ -0,0 +1,102 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractBibIDs(t *testing.T) {
+	tests := []struct {
+		raw                   string
+		doi, arxiv, url, rest string
+	}{
+		{
+			raw:  "Smith, A. Title one. NeurIPS, 2017. https://doi.org/10.5555/3295222.3295349.",
+			doi:  "10.5555/3295222.3295349",
+			rest: "Smith, A. Title one. NeurIPS, 2017.",
+		},
+		{
+			raw:   "Jones, B. Title two. arXiv preprint arXiv:2012.01234v2, 2020.",
+			arxiv: "2012.01234",
+			rest:  "Jones, B. Title two. arXiv preprint, 2020.",
+		},
+		{
+			raw:  "Lee, C. Title three. Lancet, 2020 (doi:10.1016/S0140-6736(20)30183-5).",
+			doi:  "10.1016/S0140-6736(20)30183-5",
+			rest: "Lee, C. Title three. Lancet, 2020.",
+		},
+		{
+			raw:   "Kim, D. Title four. 2023. doi: 10.48550/arXiv.2301.07041",
+			doi:   "10.48550/arXiv.2301.07041",
+			arxiv: "2301.07041",
+			rest:  "Kim, D. Title four. 2023.",
+		},
+		{
+			raw:  "Park, E. Software. https://github.com/example/tool.",
+			url:  "https://github.com/example/tool",
+			rest: "Park, E. Software.",
+		},
+	}
+	for _, tt := range tests {
+		doi, arxiv, url, rest := extractBibIDs(tt.raw)
+		if doi != tt.doi || arxiv != tt.arxiv || url != tt.url || rest != tt.rest {
+			t.Errorf("extractBibIDs(%q) = %q, %q, %q, %q; want %q, %q, %q, %q",
+				tt.raw, doi, arxiv, url, rest, tt.doi, tt.arxiv, tt.url, tt.rest)
+		}
+	}
+}
+
+func TestParseBibliographyIDs(t *testing.T) {
+	entries := ParseBibliography("## References\n\n[1] Jones, B. Title two. arXiv preprint arXiv:2012.01234, 2020.\n")
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	if e := entries[0]; e.ArxivID != "2012.01234" || e.Year != "2020" || e.Title != "Title two" {
+		t.Errorf("entry = %+v, want arXiv ID 2012.01234, year 2020, title Title two", e)
+	}
+}
+
+func TestExtractPaperLinksCorpusPapers(t *testing.T) {
+	papersDir := t.TempDir()
+	metaDir := filepath.Join(papersDir, metadataDir)
+	os.MkdirAll(metaDir, 0o755)
+	os.WriteFile(filepath.Join(metaDir, "vaswani-2017.yaml"), []byte("id: vaswani-2017\ndoi: 10.5555/3295222.3295349\n"), 0o644)
+	os.WriteFile(filepath.Join(metaDir, "2301.07041.yaml"), []byte("id: \"2301.07041\"\n"), 0o644)
+	os.WriteFile(filepath.Join(metaDir, "self.yaml"), []byte("id: self\ndoi: 10.1000/self\n"), 0o644)
+
+	mdPath := filepath.Join(papersDir, "self.md")
+	md := "## Introduction\n\nWe build on [1] and [2], unlike [3], as in [4].\n\n## References\n\n" +
+		"[1] Vaswani, A. Attention is all you need. NeurIPS, 2017. doi:10.5555/3295222.3295349\n" +
+		"[2] Kim, D. A preprint. arXiv:2301.07041v3, 2023.\n" +
+		"[3] Lee, C. Not acquired. Nature, 2020. https://doi.org/10.1038/xyz\n" +
+		"[4] Self, S. Our earlier version. 2024. doi:10.1000/SELF\n"
+	os.WriteFile(mdPath, []byte(md), 0o644)
+
+	backend := &mockAIBackend{responses: map[string]AIResponse{
+		"## Introduction": {Items: []AIResponseItem{{Type: "claim", Content: "We build on [1] and [2], unlike [3], as in [4].", Confidence: 0.9}}},
+	}}
+	result, err := ExtractPaper(context.Background(), backend, "self", mdPath, testConfig(papersDir, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"vaswani-2017", "2301.07041", "", ""}
+	for i, e := range result.Bibliography {
+		if e.PaperID != want[i] {
+			t.Errorf("entry %s: paper ID %q, want %q", e.Key, e.PaperID, want[i])
+		}
+	}
+	citations := result.Items[0].Citations
+	if len(citations) != 4 {
+		t.Fatalf("got %d citations, want 4", len(citations))
+	}
+	for i, c := range citations {
+		if c.PaperID != want[i] {
+			t.Errorf("citation %s: paper ID %q, want %q", c.Key, c.PaperID, want[i])
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// cacheDir is the directory under knowledgeDir holding cached AI
+// responses, one file per section.
+const cacheDir = "cache"
+
+// responseCache stores the AI response for each section keyed by backend,
+// model, a hash of the prompt text, and the SHA-256 of the section text, so re-running
+// extraction after a crash or an edit to one section does not pay again
+// for unchanged sections (R6.7). A nil cache stores nothing.
+type responseCache struct {
+	dir     string
+	backend string
+	model   string
+	prompt  string
+}
+
+// newResponseCache returns the cache for cfg and prompt, or nil when
+// caching is off or there is no knowledge directory to keep it in. Editing
+// the prompt text invalidates the cache.
+func newResponseCache(cfg types.ExtractionConfig, prompt *Prompt) *responseCache {
+	if cfg.NoCache || cfg.KnowledgeDir == "" {
+		return nil
+	}
+	return &responseCache{dir: filepath.Join(cfg.KnowledgeDir, cacheDir), backend: cfg.Backend, model: cfg.Model, prompt: prompt.hash()}
+}
+
+// path returns the file caching the response to chunk, sharded by the
+// first two hex digits of its key.
+func (c *responseCache) path(chunk string) string {
+	sum := sha256.Sum256([]byte(chunk))
+	key := sha256.Sum256([]byte(c.backend + "\x00" + c.model + "\x00" + c.prompt + "\x00" + hex.EncodeToString(sum[:])))
+	name := hex.EncodeToString(key[:])
+	return filepath.Join(c.dir, name[:2], name+".yaml")
+}
+
+// get returns the cached response to chunk.
+func (c *responseCache) get(chunk string) (AIResponse, bool) {
+	var resp AIResponse
+	return resp, c.load(chunk, &resp)
+}
+
+// put caches the response to chunk.
+func (c *responseCache) put(chunk string, resp AIResponse) {
+	c.store(chunk, resp)
+}
+
+// getSummary returns the cached summary of a paper with summary input
+// input (R9.1).
+func (c *responseCache) getSummary(input string) (types.PaperSummary, bool) {
+	var summary types.PaperSummary
+	return summary, c.load(summaryChunk(input), &summary)
+}
+
+// putSummary caches the summary of a paper with summary input input.
+func (c *responseCache) putSummary(input string, summary types.PaperSummary) {
+	c.store(summaryChunk(input), summary)
+}
+
+// summaryCacheKey prefixes summary inputs so they never share a key with
+// a section.
+const summaryCacheKey = "summary\x00"
+
+// summaryPromptHash identifies the summary prompts, so editing them
+// invalidates cached summaries as editing the extraction prompt
+// invalidates cached sections.
+var summaryPromptHash = func() string {
+	sum := sha256.Sum256([]byte(summaryPrompt + "\x00" + ollamaSummarySystemPrompt + "\x00" + string(ollamaSummaryFormat)))
+	return hex.EncodeToString(sum[:])
+}()
+
+// summaryChunk returns the cache key text for a summary input.
+func summaryChunk(input string) string {
+	return summaryCacheKey + summaryPromptHash + "\x00" + input
+}
+
+// load reads the value cached for chunk into v.
+func (c *responseCache) load(chunk string, v any) bool {
+	if c == nil {
+		return false
+	}
+	data, err := os.ReadFile(c.path(chunk))
+	if err != nil {
+		return false
+	}
+	return yaml.Unmarshal(data, v) == nil
+}
+
+// store caches v for chunk. Failing to cache does not fail the
+// extraction, so errors are dropped.
+func (c *responseCache) store(chunk string, v any) {
+	if c == nil {
+		return
+	}
+	path := c.path(chunk)
+	data, err := yaml.Marshal(v)
+	if err != nil || os.MkdirAll(filepath.Dir(path), 0o755) != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".put-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil && cerr == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/pdiddy/research-engine/pkg/types"
 )
@@ -21,8 +22,8 @@ var (
 	numericCiteRe = regexp.MustCompile(`\[(\d+)\]`)
 
 	// authorYearCiteRe matches author-year citations like
-	// [Smith et al., 2020] or [Smith and Jones, 2019].
-	authorYearCiteRe = regexp.MustCompile(`\[([A-Z][a-z]+(?:\s+(?:et\s+al\.|and\s+[A-Z][a-z]+))?(?:,\s*\d{4}))\]`)
+	// [Smith et al., 2020], [Smith and Jones, 2019], or [Smith, 2020a].
+	authorYearCiteRe = regexp.MustCompile(`\[([A-Z][a-z]+(?:\s+(?:et\s+al\.|and\s+[A-Z][a-z]+))?(?:,\s*\d{4}[a-z]?))\]`)
 
 	// bibEntryRe matches numbered bibliography entries like:
 	// [1] Authors. Title. Venue, Year.
@@ -99,6 +100,8 @@ func extractContext(text string, start, end int) string {
 // ParseBibliography extracts bibliography entries from the references section
 // of Markdown content. It looks for a heading containing "references" or
 // "bibliography" and parses numbered entries like "[1] Authors. Title." (R3.2).
+// A list without numbers is parsed as author-year entries keyed by
+// "Surname, Year" (R3.5).
 func ParseBibliography(content string) []types.BibliographyEntry {
 	refSection := findReferencesSection(content)
 	if refSection == "" {
@@ -107,7 +110,7 @@ func ParseBibliography(content string) []types.BibliographyEntry {
 
 	matches := bibEntryRe.FindAllStringSubmatch(refSection, -1)
 	if len(matches) == 0 {
-		return nil
+		return parseAuthorYearBibliography(refSection)
 	}
 
 	var entries []types.BibliographyEntry
@@ -157,10 +160,11 @@ var authorBlockRe = regexp.MustCompile(
 )
 
 // parseBibEntry extracts metadata from a raw bibliography entry string.
-// It uses regex to identify the author block, then splits the remainder
-// into title and venue.
+// It takes out the DOI, arXiv ID, and URL (R3.6), uses regex to identify
+// the author block, then splits the remainder into title and venue.
 func parseBibEntry(key, raw string) types.BibliographyEntry {
 	entry := types.BibliographyEntry{Key: key}
+	entry.DOI, entry.ArxivID, entry.URL, raw = extractBibIDs(raw)
 	entry.Year = extractYear(raw)
 
 	m := authorBlockRe.FindStringSubmatch(raw)
@@ -189,6 +193,142 @@ func parseBibEntry(key, raw string) types.BibliographyEntry {
 	return entry
 }
 
+// Author-year bibliography patterns (R3.5).
+var (
+	// entryStartRe matches the start of an author-year entry in a list
+	// without hanging indents: a surname, a comma, and an initial.
+	entryStartRe = regexp.MustCompile(`^\p{Lu}[\p{L}'’-]+,\s+\p{Lu}\.`)
+
+	// listMarkerRe matches a Markdown bullet before an entry.
+	listMarkerRe = regexp.MustCompile(`^[-*+]\s+`)
+
+	// entryYearRe matches the year of an entry, with the letter that tells
+	// apart one author's papers of the same year (2020a, 2020b).
+	entryYearRe = regexp.MustCompile(`\b((?:19|20)\d{2}[a-z]?)\b`)
+
+	// parenYearRe matches an APA-style "(2020)." after the authors.
+	parenYearRe = regexp.MustCompile(`\s*\(((?:19|20)\d{2}[a-z]?|n\.d\.)\)\.?`)
+
+	// authorYearKeyRe splits an author-year citation key such as
+	// "Smith et al., 2020" into the first surname and the year.
+	authorYearKeyRe = regexp.MustCompile(`^(\S+).*,\s*(\d{4}[a-z]?)$`)
+)
+
+// parseAuthorYearBibliography parses an unnumbered reference list. Each
+// entry is keyed by its first author's surname and year, as "Smith, 2020"
+// or "Smith, n.d."; entries without a surname are dropped.
+func parseAuthorYearBibliography(refSection string) []types.BibliographyEntry {
+	var entries []types.BibliographyEntry
+	for _, raw := range splitUnnumberedEntries(refSection) {
+		surname := firstSurname(raw)
+		if surname == "" {
+			continue
+		}
+		year := "n.d."
+		_, _, _, text := extractBibIDs(raw)
+		if m := entryYearRe.FindStringSubmatch(text); m != nil {
+			year = m[1]
+		}
+		entry := parseBibEntry(surname+", "+year, parenYearRe.ReplaceAllString(raw, ""))
+		if year != "n.d." {
+			entry.Year = year[:4]
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// splitUnnumberedEntries splits a reference list into one string per
+// entry. Blank lines and list bullets separate entries. Within a block of
+// lines, a hanging indent marks continuation lines; without indents, a
+// line opening with "Surname, I." starts a new entry once the current one
+// has a year.
+func splitUnnumberedEntries(refSection string) []string {
+	var entries []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			entries = append(entries, strings.Join(current, " "))
+			current = nil
+		}
+	}
+
+	var block []string
+	endBlock := func() {
+		hanging := false
+		for _, line := range block[min(1, len(block)):] {
+			if line != strings.TrimLeft(line, " \t") {
+				hanging = true
+			}
+		}
+		for _, line := range block {
+			text := strings.TrimSpace(line)
+			bullet := listMarkerRe.MatchString(text)
+			text = listMarkerRe.ReplaceAllString(text, "")
+			indented := line != strings.TrimLeft(line, " \t")
+			switch {
+			case bullet:
+				flush()
+			case hanging && !indented:
+				flush()
+			case !hanging && entryStartRe.MatchString(text) && yearRe.MatchString(strings.Join(current, " ")):
+				flush()
+			}
+			current = append(current, text)
+		}
+		flush()
+		block = nil
+	}
+
+	for _, line := range strings.Split(refSection, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if _, ok := parsePageMarker(trimmed); ok {
+			continue
+		}
+		if trimmed == "" {
+			endBlock()
+			continue
+		}
+		block = append(block, line)
+	}
+	endBlock()
+	return entries
+}
+
+// firstSurname returns the surname of an entry's first author: the word
+// before the first comma ("Smith, J."), or the last word of a name written
+// in full ("John Smith, ..."), or the first word of a name followed by
+// initials ("Smith J, ...").
+func firstSurname(raw string) string {
+	name, _, ok := strings.Cut(raw, ",")
+	if !ok {
+		return ""
+	}
+	words := strings.Fields(name)
+	if len(words) == 0 || len(words) > 4 {
+		return ""
+	}
+	surname := words[len(words)-1]
+	if len(words) > 1 && strings.ToUpper(surname) == strings.TrimRight(surname, ".") {
+		surname = words[0]
+	}
+	r := []rune(surname)
+	if len(r) < 2 || !unicode.IsUpper(r[0]) {
+		return ""
+	}
+	return surname
+}
+
+// authorYearBibKey returns the bibliography key an author-year citation
+// links to: "Smith et al., 2020" becomes "Smith, 2020".
+func authorYearBibKey(citationKey string) string {
+	m := authorYearKeyRe.FindStringSubmatch(citationKey)
+	if m == nil {
+		return ""
+	}
+	return m[1] + ", " + m[2]
+}
+
 // yearRe matches a 4-digit year.
 var yearRe = regexp.MustCompile(`\b((?:19|20)\d{2})\b`)
 
@@ -249,7 +389,9 @@ func parseAuthors(authorStr string) []string {
 	// Handle "Smith, A., Jones, B." pattern by looking for ", " after initials.
 	var authors []string
 
-	// Split on " and " connector.
+	// Split on " and " connector, or its "&" in author-year lists.
+	authorStr = strings.Replace(authorStr, ", & ", " and ", 1)
+	authorStr = strings.Replace(authorStr, " & ", " and ", 1)
 	halves := strings.SplitN(authorStr, " and ", 2)
 	for _, half := range halves {
 		half = strings.TrimSpace(half)
@@ -273,7 +415,9 @@ func cleanVenue(text string) string {
 
 // LinkCitations matches Citation objects to BibliographyEntry objects by
 // comparing citation keys to bibliography entry keys (R3.3). Numeric
-// citations are matched to numbered bibliography entries.
+// citations are matched to numbered bibliography entries, and author-year
+// citations to entries keyed by first surname and year (R3.5). A linked
+// citation takes the entry's corpus paper ID (R3.6).
 func LinkCitations(citations []types.Citation, bibliography []types.BibliographyEntry) []types.Citation {
 	if len(bibliography) == 0 {
 		return citations
@@ -281,15 +425,22 @@ func LinkCitations(citations []types.Citation, bibliography []types.Bibliography
 
 	keyIndex := make(map[string]int, len(bibliography))
 	for i, entry := range bibliography {
-		keyIndex[entry.Key] = i
+		if _, dup := keyIndex[entry.Key]; !dup {
+			keyIndex[entry.Key] = i
+		}
 	}
 
 	linked := make([]types.Citation, len(citations))
 	copy(linked, citations)
 
 	for i := range linked {
-		if idx, ok := keyIndex[linked[i].Key]; ok {
+		idx, ok := keyIndex[linked[i].Key]
+		if !ok {
+			idx, ok = keyIndex[authorYearBibKey(linked[i].Key)]
+		}
+		if ok {
 			linked[i].BibIndex = idx
+			linked[i].PaperID = bibliography[idx].PaperID
 		}
 	}
 
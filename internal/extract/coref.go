@@ -0,0 +1,139 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// Method names are introduced in a few stock phrasings. namedPattern and
+// proposePattern require a capitalized name ("called FlashAttention", "we
+// propose BERT"); definePattern accepts any case ("we define efficient
+// attention as") and applies only to method items, since definition items
+// define terms of every kind.
+var (
+	namedPattern   = regexp.MustCompile(`(?i:\b(?:called|named|dubbed|termed|we\s+call\s+(?:it|this|our\s+\w+))\s+)([A-Z][\w-]*(?:\s+[A-Z][\w-]*){0,3})`)
+	proposePattern = regexp.MustCompile(`(?i:\bwe\s+(?:propose|introduce|present)\s+)([A-Z][\w-]*(?:\s+[A-Z][\w-]*){0,3})`)
+	definePattern  = regexp.MustCompile(`(?i:\bwe\s+define\s+)([\w-]+(?:\s+[\w-]+){0,3}?)(?i:\s+as\b)`)
+)
+
+// selfReferencePattern matches phrases a paper uses for its own method:
+// "our method", "our proposed approach", "the proposed model", "this
+// approach".
+var selfReferencePattern = regexp.MustCompile(`(?i)\b(?:our\s+(?:proposed\s+|new\s+|novel\s+)?(?:method|approach|model|framework|algorithm|architecture|technique|system)|the\s+proposed\s+(?:method|approach|model|framework|algorithm|architecture|technique|system)|this\s+(?:method|approach|framework|technique))\b`)
+
+// nonNameWords cannot start a method name; they mark a captured phrase as
+// ordinary prose ("we propose A new loss", "we define the loss as").
+var nonNameWords = map[string]bool{
+	"a": true, "an": true, "the": true, "this": true, "that": true,
+	"these": true, "our": true, "it": true, "its": true, "their": true,
+}
+
+// ResolveReferences finds the name a paper gives its own method in its
+// definition and method items and sets ResolvedContent on every other item
+// whose content refers to "our method", "this approach", and the like,
+// replacing those phrases with the name (R7.1, R7.2). Content is left as
+// extracted. It returns the method name, or "" when the paper names none,
+// in which case no item is changed.
+func ResolveReferences(items []types.KnowledgeItem) string {
+	name, sources := methodName(items)
+	if name == "" {
+		return ""
+	}
+	for i := range items {
+		if sources[i] {
+			continue
+		}
+		resolved := replaceSelfReferences(items[i].Content, name)
+		if resolved != items[i].Content {
+			items[i].ResolvedContent = resolved
+		}
+	}
+	return name
+}
+
+// methodName returns the most frequently introduced method name, taking
+// definition items before method items and earlier mentions on ties, and
+// the indexes of the items that introduce it.
+func methodName(items []types.KnowledgeItem) (string, map[int]bool) {
+	var order []string
+	counts := make(map[string]int)
+	found := make(map[string]map[int]bool)
+	for _, itemType := range []types.KnowledgeItemType{types.ItemDefinition, types.ItemMethod} {
+		for i, item := range items {
+			if item.Type != itemType {
+				continue
+			}
+			for _, name := range nameCandidates(item) {
+				if counts[name] == 0 {
+					order = append(order, name)
+					found[name] = make(map[int]bool)
+				}
+				counts[name]++
+				found[name][i] = true
+			}
+		}
+	}
+
+	best := ""
+	for _, name := range order {
+		if counts[name] > counts[best] {
+			best = name
+		}
+	}
+	return best, found[best]
+}
+
+// nameCandidates returns the method names introduced in an item.
+func nameCandidates(item types.KnowledgeItem) []string {
+	patterns := []*regexp.Regexp{namedPattern, proposePattern}
+	if item.Type == types.ItemMethod {
+		patterns = append(patterns, definePattern)
+	}
+	var names []string
+	for _, p := range patterns {
+		for _, m := range p.FindAllStringSubmatch(item.Content, -1) {
+			name := strings.TrimSpace(m[1])
+			first, _, _ := strings.Cut(name, " ")
+			if name == "" || nonNameWords[strings.ToLower(first)] {
+				continue
+			}
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// replaceSelfReferences replaces self-reference phrases in content with
+// name, capitalizing it at the start of a sentence.
+func replaceSelfReferences(content, name string) string {
+	capitalized := name
+	if r, size := utf8.DecodeRuneInString(name); unicode.IsLower(r) {
+		capitalized = string(unicode.ToUpper(r)) + name[size:]
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range selfReferencePattern.FindAllStringIndex(content, -1) {
+		b.WriteString(content[last:loc[0]])
+		if sentenceStart(content[:loc[0]]) {
+			b.WriteString(capitalized)
+		} else {
+			b.WriteString(name)
+		}
+		last = loc[1]
+	}
+	b.WriteString(content[last:])
+	return b.String()
+}
+
+// sentenceStart reports whether text preceding a match ends a sentence.
+func sentenceStart(before string) bool {
+	before = strings.TrimRight(before, " \t\n")
+	return before == "" || strings.HasSuffix(before, ".") || strings.HasSuffix(before, "!") || strings.HasSuffix(before, "?")
+}
@@ -0,0 +1,63 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestResolveReferences(t *testing.T) {
+	items := []types.KnowledgeItem{
+		{ID: "m1", Type: types.ItemMethod, Content: "We propose FlashAttention, an IO-aware exact attention algorithm."},
+		{ID: "c1", Type: types.ItemClaim, Content: "Our method is 3x faster than standard attention. Compared to this approach, our model uses less memory."},
+		{ID: "r1", Type: types.ItemResult, Content: "The proposed method reaches 89.2% accuracy."},
+		{ID: "c2", Type: types.ItemClaim, Content: "Prior work [3] is memory-bound."},
+	}
+
+	if name := ResolveReferences(items); name != "FlashAttention" {
+		t.Fatalf("ResolveReferences = %q, want FlashAttention", name)
+	}
+	want := map[string]string{
+		"m1": "",
+		"c1": "FlashAttention is 3x faster than standard attention. Compared to FlashAttention, FlashAttention uses less memory.",
+		"r1": "FlashAttention reaches 89.2% accuracy.",
+		"c2": "",
+	}
+	for _, item := range items {
+		if item.ResolvedContent != want[item.ID] {
+			t.Errorf("%s: ResolvedContent = %q, want %q", item.ID, item.ResolvedContent, want[item.ID])
+		}
+	}
+	if items[1].Content != "Our method is 3x faster than standard attention. Compared to this approach, our model uses less memory." {
+		t.Errorf("Content was modified: %q", items[1].Content)
+	}
+}
+
+func TestResolveReferencesDefinedName(t *testing.T) {
+	items := []types.KnowledgeItem{
+		{ID: "d1", Type: types.ItemDefinition, Content: "We define perplexity as the exponentiated loss."},
+		{ID: "m1", Type: types.ItemMethod, Content: "We define efficient attention as a linear approximation of softmax."},
+		{ID: "c1", Type: types.ItemClaim, Content: "Our approach scales linearly."},
+	}
+	if name := ResolveReferences(items); name != "efficient attention" {
+		t.Fatalf("ResolveReferences = %q, want efficient attention", name)
+	}
+	if got := items[2].ResolvedContent; got != "Efficient attention scales linearly." {
+		t.Errorf("ResolvedContent = %q", got)
+	}
+}
+
+func TestResolveReferencesNoName(t *testing.T) {
+	items := []types.KnowledgeItem{
+		{ID: "m1", Type: types.ItemMethod, Content: "We propose a new loss for contrastive learning."},
+		{ID: "c1", Type: types.ItemClaim, Content: "Our method improves recall."},
+	}
+	if name := ResolveReferences(items); name != "" {
+		t.Errorf("ResolveReferences = %q, want none", name)
+	}
+	if items[1].ResolvedContent != "" {
+		t.Errorf("ResolvedContent = %q, want empty", items[1].ResolvedContent)
+	}
+}
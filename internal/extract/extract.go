@@ -7,14 +7,18 @@
 package extract
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.yaml.in/yaml/v3"
@@ -25,6 +29,7 @@ import (
 const (
 	markdownDir  = "markdown"
 	extractedDir = "extracted"
+	metadataDir  = "metadata"
 )
 
 // validItemTypes is the set of accepted KnowledgeItemType values (R1.1).
@@ -55,6 +60,11 @@ type AIResponseItem struct {
 	Page       int      `json:"page" yaml:"page"`
 	Confidence float64  `json:"confidence" yaml:"confidence"`
 	Tags       []string `json:"tags" yaml:"tags"`
+	// Metric is the measurement of a result item (R1.5).
+	Metric *types.Metric `json:"metric,omitempty" yaml:"metric,omitempty"`
+	// ClaimNumber and DependsOn number a patent claim (R5.15).
+	ClaimNumber int `json:"claim_number,omitempty" yaml:"claim_number,omitempty"`
+	DependsOn   int `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
 }
 
 // BatchSummary holds counts from a batch extraction run (R6.4).
@@ -62,6 +72,9 @@ type BatchSummary struct {
 	Extracted int
 	Skipped   int
 	Failed    int
+	// Partial counts the extracted papers with some sections failed
+	// (R6.9); they are included in Extracted.
+	Partial int
 }
 
 // Total returns the number of papers processed.
@@ -76,12 +89,15 @@ func (s BatchSummary) HasFailures() bool {
 
 // ExtractAll processes all Markdown files in papersDir/markdown/, extracts
 // knowledge items via the AI backend, and writes results to knowledgeDir/extracted/.
-// It skips unchanged files and re-extracts changed ones (R6.1, R6.2).
+// It skips unchanged files and re-extracts changed ones (R6.1, R6.2), and
+// skips or warns on low-quality conversions (R5.8). Papers and their
+// sections are extracted concurrently up to cfg.MaxConcurrentCalls (R6.6).
+// Progress is checkpointed in knowledgeDir/extracted/.progress.yaml, and a
+// batch that did not finish cleanly resumes from it (R6.10).
 func ExtractAll(ctx context.Context, backend AIBackend, cfg types.ExtractionConfig, w io.Writer) (BatchSummary, error) {
 	mdDir := filepath.Join(cfg.PapersDir, markdownDir)
-	outDir := filepath.Join(cfg.KnowledgeDir, extractedDir)
 
-	if err := os.MkdirAll(outDir, 0o755); err != nil {
+	if err := os.MkdirAll(filepath.Join(cfg.KnowledgeDir, extractedDir), 0o755); err != nil {
 		return BatchSummary{}, fmt.Errorf("creating output directory: %w", err)
 	}
 
@@ -90,66 +106,224 @@ func ExtractAll(ctx context.Context, backend AIBackend, cfg types.ExtractionConf
 		return BatchSummary{}, fmt.Errorf("reading markdown directory %s: %w", mdDir, err)
 	}
 
+	var paperIDs []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			paperIDs = append(paperIDs, strings.TrimSuffix(entry.Name(), ".md"))
+		}
+	}
+	progress, resumed := loadProgress(cfg, promptOf(backend), paperIDs)
+	if resumed {
+		fmt.Fprintf(w, "resuming batch from %s (%d of %d papers done)\n", progress.path, progress.doneCount(), len(paperIDs))
+	}
+	summary := extractBatch(ctx, backend, cfg, paperIDs, true, progress, w)
+	if ctx.Err() == nil && summary.Failed == 0 && summary.Partial == 0 {
+		progress.remove()
+	} else {
+		fmt.Fprintf(w, "progress saved in %s; run the batch again to resume\n", progress.path)
+	}
+	return summary, nil
+}
+
+// ExtractPapers extracts the given papers from papersDir/markdown/ whether
+// or not their Markdown changed, with the same quality gate, concurrency,
+// and status output as ExtractAll.
+func ExtractPapers(ctx context.Context, backend AIBackend, cfg types.ExtractionConfig, paperIDs []string, w io.Writer) (BatchSummary, error) {
+	if err := os.MkdirAll(filepath.Join(cfg.KnowledgeDir, extractedDir), 0o755); err != nil {
+		return BatchSummary{}, fmt.Errorf("creating output directory: %w", err)
+	}
+	return extractBatch(ctx, backend, cfg, paperIDs, false, nil, w), nil
+}
+
+// paperOutcome is how extracting one paper in a batch ended.
+type paperOutcome int
+
+const (
+	paperExtracted paperOutcome = iota
+	paperPartial
+	paperSkipped
+	paperFailed
+	paperNotStarted
+)
+
+// extractBatch extracts paperIDs with up to cfg.MaxConcurrentCalls papers
+// in flight, all sharing one limit on concurrent AI calls. Each paper's
+// status lines are buffered and written to w in paperIDs order, so the
+// log reads as it would for a serial run. onlyChanged skips papers whose
+// Markdown has not changed since their last extraction. Finished papers
+// and answered sections are recorded in progress, which may be nil. Once
+// ctx is done no further paper is started.
+func extractBatch(ctx context.Context, backend AIBackend, cfg types.ExtractionConfig, paperIDs []string, onlyChanged bool, progress *batchProgress, w io.Writer) BatchSummary {
+	calls := newCallSlots(cfg.MaxConcurrentCalls)
+	workers := min(cap(calls), len(paperIDs))
+
 	var summary BatchSummary
+	tally := func(outcome paperOutcome) {
+		switch outcome {
+		case paperExtracted:
+			summary.Extracted++
+		case paperPartial:
+			summary.Extracted++
+			summary.Partial++
+		case paperSkipped:
+			summary.Skipped++
+		case paperNotStarted:
+		default:
+			summary.Failed++
+		}
+	}
 
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-			continue
+	if workers <= 1 {
+		for _, paperID := range paperIDs {
+			if ctx.Err() != nil {
+				break
+			}
+			tally(extractOne(ctx, backend, calls, cfg, paperID, onlyChanged, progress, w))
 		}
+		return summary
+	}
+
+	logs := make([]bytes.Buffer, len(paperIDs))
+	outcomes := make([]paperOutcome, len(paperIDs))
+	done := make([]chan struct{}, len(paperIDs))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for i := range paperIDs {
+			jobs <- i
+		}
+	}()
+	for range workers {
+		go func() {
+			for i := range jobs {
+				if ctx.Err() != nil {
+					outcomes[i] = paperNotStarted
+				} else {
+					outcomes[i] = extractOne(ctx, backend, calls, cfg, paperIDs[i], onlyChanged, progress, &logs[i])
+				}
+				close(done[i])
+			}
+		}()
+	}
 
-		paperID := strings.TrimSuffix(entry.Name(), ".md")
-		mdPath := filepath.Join(mdDir, entry.Name())
-		outPath := filepath.Join(outDir, paperID+"-items.yaml")
+	for i := range paperIDs {
+		<-done[i]
+		w.Write(logs[i].Bytes())
+		tally(outcomes[i])
+	}
+	return summary
+}
+
+// extractOne extracts one paper of a batch, writing its status lines to w
+// and recording it in progress once it is done.
+func extractOne(ctx context.Context, backend AIBackend, calls callSlots, cfg types.ExtractionConfig, paperID string, onlyChanged bool, progress *batchProgress, w io.Writer) (outcome paperOutcome) {
+	mdPath := filepath.Join(cfg.PapersDir, markdownDir, paperID+".md")
+	outPath := filepath.Join(cfg.KnowledgeDir, extractedDir, paperID+"-items.yaml")
+	defer func() {
+		if outcome == paperExtracted || outcome == paperSkipped {
+			progress.finish(paperID)
+		}
+	}()
 
+	if onlyChanged {
 		changed, err := hasChanged(mdPath, outPath)
 		if err != nil {
 			fmt.Fprintf(w, "failed  %s: %v\n", paperID, err)
-			summary.Failed++
-			continue
+			return paperFailed
 		}
 		if !changed {
-			fmt.Fprintf(w, "skipped %s\n", paperID)
-			summary.Skipped++
-			continue
+			prompt, partial := recordedState(outPath)
+			if !partial && (prompt == "" || prompt == promptOf(backend).Name || prompt == patentPromptOf(backend).Name) {
+				fmt.Fprintf(w, "skipped %s\n", paperID)
+				return paperSkipped
+			}
 		}
+	} else if _, err := os.Stat(mdPath); err != nil {
+		fmt.Fprintf(w, "failed  %s: %v\n", paperID, err)
+		return paperFailed
+	}
 
-		fmt.Fprintf(w, "extracting %s\n", paperID)
+	if warning, skip := CheckQuality(mdPath, cfg.MinQuality); skip {
+		fmt.Fprintf(w, "skipped %s: %s\n", paperID, warning)
+		return paperSkipped
+	} else if warning != "" {
+		fmt.Fprintf(w, "warning %s: %s\n", paperID, warning)
+	}
 
-		result, err := ExtractPaper(ctx, backend, paperID, mdPath, cfg)
-		if err != nil {
-			fmt.Fprintf(w, "failed  %s: %v\n", paperID, err)
-			summary.Failed++
-			continue
-		}
+	fmt.Fprintf(w, "extracting %s\n", paperID)
 
-		if err := writeResult(outPath, result); err != nil {
-			fmt.Fprintf(w, "failed  %s: write error: %v\n", paperID, err)
-			summary.Failed++
-			continue
-		}
+	result, cached, err := extractPaper(ctx, backend, calls, paperID, mdPath, cfg, progress.paper(paperID))
+	if err != nil {
+		fmt.Fprintf(w, "failed  %s: %v\n", paperID, err)
+		return paperFailed
+	}
 
-		fmt.Fprintf(w, "extracted %s (%d items)\n", paperID, len(result.Items))
-		summary.Extracted++
+	if err := writeResult(outPath, result); err != nil {
+		fmt.Fprintf(w, "failed  %s: write error: %v\n", paperID, err)
+		return paperFailed
 	}
 
-	return summary, nil
+	switch {
+	case result.Partial:
+		fmt.Fprintf(w, "partial %s (%d items, %d sections failed)\n", paperID, len(result.Items), len(result.Errors))
+		for _, e := range result.Errors {
+			fmt.Fprintf(w, "  section %q: %s\n", e.Section, e.Error)
+		}
+		return paperPartial
+	case cached > 0:
+		fmt.Fprintf(w, "extracted %s (%d items, %d sections cached)\n", paperID, len(result.Items), cached)
+	default:
+		fmt.Fprintf(w, "extracted %s (%d items)\n", paperID, len(result.Items))
+	}
+	return paperExtracted
 }
 
 // ExtractPaper extracts knowledge items from a single paper's Markdown.
-// It chunks the Markdown by section headings, calls the AI backend for
-// each chunk (R5.1, R5.3), then builds the citation graph (R3) and
-// aggregates paper-level tags (R4.3).
+// It chunks the Markdown by section headings, splits sections too long
+// for one call into overlapping parts (R5.13), calls the AI backend for
+// each chunk (R5.1, R5.3), up to cfg.MaxConcurrentCalls at a time (R6.6),
+// then builds the citation graph (R3), aggregates paper-level tags
+// (R4.3), and with cfg.Summarize summarizes the paper (R9.1). Items keep
+// section order however the calls interleave. Responses cached in
+// knowledgeDir/cache/ are reused (R6.7).
 func ExtractPaper(ctx context.Context, backend AIBackend, paperID, mdPath string, cfg types.ExtractionConfig) (*types.ExtractionResult, error) {
+	result, _, err := extractPaper(ctx, backend, newCallSlots(cfg.MaxConcurrentCalls), paperID, mdPath, cfg, nil)
+	return result, err
+}
+
+// extractPaper is ExtractPaper with its AI calls bounded by calls, which
+// a batch shares across papers. It also returns how many sections were
+// answered from the response cache.
+func extractPaper(ctx context.Context, backend AIBackend, calls callSlots, paperID, mdPath string, cfg types.ExtractionConfig, progress *paperProgress) (*types.ExtractionResult, int, error) {
 	content, err := os.ReadFile(mdPath)
 	if err != nil {
-		return nil, fmt.Errorf("reading markdown %s: %w", mdPath, err)
+		return nil, 0, fmt.Errorf("reading markdown %s: %w", mdPath, err)
 	}
 
 	fullText := string(content)
 	sections := chunkByHeadings(fullText)
 
+	var vocab *TagVocabulary
+	if cfg.KnowledgeDir != "" {
+		if vocab, err = LoadTagVocabulary(cfg.KnowledgeDir); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// A patent's sections go to the patent prompt (R5.15). A backend
+	// without one, such as a test double, keeps its own.
+	prompt := promptOf(backend)
+	_, patentPrompts := backend.(patentPromptBackend)
+	patent := isPatent(fullText)
+	if patent && patentPrompts {
+		prompt = patentPromptOf(backend)
+	}
 	result := &types.ExtractionResult{
-		PaperID: paperID,
+		PaperID:       paperID,
+		PromptVersion: prompt.Name,
 	}
 
 	maxRetries := cfg.MaxRetries
@@ -157,44 +331,207 @@ func ExtractPaper(ctx context.Context, backend AIBackend, paperID, mdPath string
 		maxRetries = 3
 	}
 
+	maxTokens := cfg.MaxSectionTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultMaxSectionTokens
+	}
+
+	var chunks []section
 	for _, sec := range sections {
-		if strings.TrimSpace(sec.body) == "" {
-			continue
+		if strings.TrimSpace(sec.body) != "" && selectSection(sec, cfg.Sections, cfg.SkipSections) {
+			chunks = append(chunks, splitSection(sec, maxTokens)...)
 		}
+	}
 
-		chunk := formatChunk(sec)
+	cache := newResponseCache(cfg, prompt)
+	sectionBackend := backend
+	if patent {
+		sectionBackend = withPatentPrompt(backend)
+	}
+	responses, errs, cached := extractSections(ctx, sectionBackend, calls, cache, progress, chunks, maxRetries, !cfg.AllowPartial)
+	if !cfg.AllowPartial {
+		if err := firstSectionError(chunks, errs); err != nil {
+			return nil, 0, err
+		}
+	}
 
-		resp, err := callWithRetry(ctx, backend, chunk, maxRetries)
+	// Parts of a split section share its heading, so an item read twice
+	// from their overlap gets the same ID and is kept once (R5.13). With
+	// cfg.AllowPartial a failed or invalid section is recorded and the
+	// others are kept (R6.9).
+	seen := make(map[string]bool)
+	for i, sec := range chunks {
+		err := errs[i]
+		var items []types.KnowledgeItem
+		if err == nil {
+			var validationErrors []string
+			items, validationErrors = convertItems(responses[i].Items, paperID, sec.heading, vocab)
+			if len(validationErrors) > 0 {
+				err = fmt.Errorf("validation errors in section %q: %s", sec.heading, strings.Join(validationErrors, "; "))
+			}
+		}
 		if err != nil {
-			return nil, fmt.Errorf("extracting section %q: %w", sec.heading, err)
+			if !cfg.AllowPartial {
+				return nil, 0, err
+			}
+			result.Errors = append(result.Errors, types.SectionError{Section: sec.heading, Page: sec.page, Error: err.Error()})
+			continue
+		}
+
+		for _, item := range items {
+			if !seen[item.ID] {
+				seen[item.ID] = true
+				result.Items = append(result.Items, item)
+			}
 		}
+	}
 
-		items, validationErrors := convertItems(resp.Items, paperID, sec.heading)
-		if len(validationErrors) > 0 {
-			return nil, fmt.Errorf("validation errors in section %q: %s", sec.heading, strings.Join(validationErrors, "; "))
+	if len(result.Errors) > 0 {
+		if len(result.Errors) == len(chunks) {
+			return nil, 0, fmt.Errorf("every section failed; first: %s", result.Errors[0].Error)
 		}
+		result.Partial = true
+	}
 
-		result.Items = append(result.Items, items...)
+	// Patent claim numbers (R5.15).
+	if patent {
+		linkPatentClaims(result.Items, fullText)
 	}
 
 	// Citation graph construction (R3.1-R3.4).
 	result.Bibliography = ParseBibliography(fullText)
+	linkBibliography(result.Bibliography, cfg.PapersDir, paperID)
 	for i := range result.Items {
 		citations := ParseCitations(result.Items[i].Content)
 		result.Items[i].Citations = LinkCitations(citations, result.Bibliography)
 	}
 
+	// Self-reference resolution (R7).
+	ResolveReferences(result.Items)
+
+	// Funding and conflict-of-interest statements (R8).
+	result.Funding, result.Grants, result.ConflictOfInterest = extractDisclosures(sections)
+
 	// Paper-level tag aggregation (R4.3).
 	result.PaperTags = AggregatePaperTags(result.Items)
 
-	return result, nil
+	// Paper summary (R9.1). A failed summary fails the paper, or with
+	// cfg.AllowPartial marks it partial so the next batch retries it.
+	if cfg.Summarize && len(result.Items) > 0 {
+		summary, err := summarizePaper(ctx, backend, calls, cache, summaryInput(sections, result.Items, maxTokens), maxRetries)
+		switch {
+		case err == nil:
+			result.Summary = &summary
+		case !cfg.AllowPartial:
+			return nil, 0, err
+		default:
+			result.Errors = append(result.Errors, types.SectionError{Section: summarySection, Error: err.Error()})
+			result.Partial = true
+		}
+	}
+
+	return result, cached, nil
+}
+
+// callSlots bounds the AI calls in flight: a call holds a slot while it
+// runs. Its capacity is the concurrency limit.
+type callSlots chan struct{}
+
+// newCallSlots returns slots for limit concurrent calls; a limit below 1
+// means one call at a time.
+func newCallSlots(limit int) callSlots {
+	return make(callSlots, max(limit, 1))
+}
+
+// extractSections calls the backend for each section with up to
+// cap(calls) workers taking sections in order, and returns the responses
+// and errors in section order with the number found in the batch
+// checkpoint or the cache instead. With stopOnError a failed section stops
+// the others. Valid responses are cached and checkpointed, so a rerun
+// after a failure pays only for the sections that did not finish.
+func extractSections(ctx context.Context, backend AIBackend, calls callSlots, cache *responseCache, progress *paperProgress, secs []section, maxRetries int, stopOnError bool) ([]AIResponse, []error, int) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	responses := make([]AIResponse, len(secs))
+	errs := make([]error, len(secs))
+	var next, cached atomic.Int64
+	var wg sync.WaitGroup
+	for range min(cap(calls), len(secs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < len(secs); i = int(next.Add(1) - 1) {
+				chunk := formatChunk(secs[i])
+				if resp, ok := progress.get(chunk); ok {
+					responses[i] = resp
+					cached.Add(1)
+					continue
+				}
+				if resp, ok := cache.get(chunk); ok {
+					responses[i] = resp
+					cached.Add(1)
+					continue
+				}
+				select {
+				case calls <- struct{}{}:
+				case <-ctx.Done():
+				}
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				responses[i], errs[i] = callWithRetry(ctx, backend, chunk, maxRetries)
+				<-calls
+				if errs[i] != nil {
+					if stopOnError {
+						cancel()
+					}
+					continue
+				}
+				// An invalid response is not cached, so a retry asks again.
+				if _, invalid := convertItems(responses[i].Items, "", "", nil); len(invalid) == 0 {
+					cache.put(chunk, responses[i])
+					progress.put(chunk, responses[i])
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			errs[i] = fmt.Errorf("extracting section %q: %w", secs[i].heading, err)
+		}
+	}
+	return responses, errs, int(cached.Load())
+}
+
+// firstSectionError returns the error of the first failed section,
+// preferring a real failure over the cancellations it caused.
+func firstSectionError(secs []section, errs []error) error {
+	failed := -1
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if failed < 0 || errors.Is(errs[failed], context.Canceled) && !errors.Is(err, context.Canceled) {
+			failed = i
+		}
+	}
+	if failed < 0 {
+		return nil
+	}
+	return errs[failed]
 }
 
 // section represents a chunk of Markdown under one heading.
 type section struct {
 	heading string
-	body    string
-	page    int
+	// parent is the enclosing ## heading of a ### section.
+	parent string
+	body   string
+	page   int
 }
 
 // chunkByHeadings splits Markdown into sections based on heading boundaries
@@ -204,7 +541,7 @@ type section struct {
 func chunkByHeadings(content string) []section {
 	lines := strings.Split(content, "\n")
 	var sections []section
-	currentHeading := ""
+	currentHeading, currentParent, top := "", "", ""
 	currentPage := 1
 	var bodyLines []string
 
@@ -213,6 +550,7 @@ func chunkByHeadings(content string) []section {
 		if currentHeading != "" || strings.TrimSpace(body) != "" {
 			sections = append(sections, section{
 				heading: currentHeading,
+				parent:  currentParent,
 				body:    body,
 				page:    currentPage,
 			})
@@ -232,7 +570,12 @@ func chunkByHeadings(content string) []section {
 		// Detect headings (## or ###)
 		if isHeading(trimmed) {
 			flush()
-			currentHeading = stripHeadingPrefix(trimmed)
+			currentHeading, currentParent = stripHeadingPrefix(trimmed), ""
+			if strings.HasPrefix(trimmed, "### ") {
+				currentParent = top
+			} else {
+				top = currentHeading
+			}
 			continue
 		}
 
@@ -281,28 +624,42 @@ var backoffBase = time.Second
 
 // callWithRetry calls the AI backend with exponential backoff (R5.5).
 func callWithRetry(ctx context.Context, backend AIBackend, chunk string, maxRetries int) (AIResponse, error) {
+	return withRetry(ctx, maxRetries, func() (AIResponse, error) {
+		return backend.Extract(ctx, chunk)
+	})
+}
+
+// withRetry calls call until it succeeds, up to maxRetries more times,
+// backing off exponentially between attempts.
+func withRetry[T any](ctx context.Context, maxRetries int, call func() (T, error)) (T, error) {
+	var zero T
 	var lastErr error
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
 			backoff := time.Duration(math.Pow(2, float64(attempt-1))) * backoffBase
 			select {
 			case <-ctx.Done():
-				return AIResponse{}, ctx.Err()
+				return zero, ctx.Err()
 			case <-time.After(backoff):
 			}
 		}
 
-		resp, err := backend.Extract(ctx, chunk)
+		resp, err := call()
 		if err == nil {
 			return resp, nil
 		}
+		if errors.Is(err, ErrNoRecording) {
+			// Retrying cannot make a recording appear.
+			return zero, err
+		}
 		lastErr = err
 	}
-	return AIResponse{}, fmt.Errorf("after %d retries: %w", maxRetries, lastErr)
+	return zero, fmt.Errorf("after %d retries: %w", maxRetries, lastErr)
 }
 
-// convertItems validates AI response items and converts them to KnowledgeItems (R5.4).
-func convertItems(items []AIResponseItem, paperID, sectionHeading string) ([]types.KnowledgeItem, []string) {
+// convertItems validates AI response items and converts them to KnowledgeItems (R5.4),
+// normalizing their tags with vocab, which may be nil (R4.5).
+func convertItems(items []AIResponseItem, paperID, sectionHeading string, vocab *TagVocabulary) ([]types.KnowledgeItem, []string) {
 	var result []types.KnowledgeItem
 	var errors []string
 
@@ -334,7 +691,13 @@ func convertItems(items []AIResponseItem, paperID, sectionHeading string) ([]typ
 			Section:    sec,
 			Page:       item.Page,
 			Confidence: item.Confidence,
-			Tags:       item.Tags,
+			Tags:       vocab.normalizeTags(item.Tags),
+		}
+		if itemType == types.ItemResult {
+			ki.Metric = normalizeMetric(item.Metric)
+		}
+		if itemType == types.ItemClaim && item.ClaimNumber > 0 {
+			ki.PatentClaim = &types.PatentClaim{Number: item.ClaimNumber, DependsOn: item.DependsOn, Independent: item.DependsOn == 0}
 		}
 		result = append(result, ki)
 	}
@@ -342,6 +705,24 @@ func convertItems(items []AIResponseItem, paperID, sectionHeading string) ([]typ
 	return result, errors
 }
 
+// normalizeMetric trims a result's metric and lowercases its name (R1.5).
+// A metric without a name or with a value that is not a finite number is
+// dropped rather than failing the item.
+func normalizeMetric(m *types.Metric) *types.Metric {
+	if m == nil {
+		return nil
+	}
+	out := *m
+	out.Name = strings.ToLower(strings.Join(strings.Fields(m.Name), " "))
+	out.Unit = strings.TrimSpace(m.Unit)
+	out.Dataset = strings.TrimSpace(m.Dataset)
+	out.Baseline = strings.TrimSpace(m.Baseline)
+	if out.Name == "" || math.IsNaN(out.Value) || math.IsInf(out.Value, 0) {
+		return nil
+	}
+	return &out
+}
+
 // stableID generates a deterministic ID from paper ID, section, and content (R2.5).
 // The ID is the first 12 hex characters of SHA-256(paperID + section + content).
 func stableID(paperID, section, content string) string {
@@ -352,6 +733,24 @@ func stableID(paperID, section, content string) string {
 	return fmt.Sprintf("%x", h.Sum(nil))[:12]
 }
 
+// recordedState returns the prompt version recorded in the extraction
+// result at outPath (R6.8), "" when it records none or cannot be read, and
+// whether the result is partial (R6.9).
+func recordedState(outPath string) (prompt string, partial bool) {
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		return "", false
+	}
+	var result struct {
+		PromptVersion string `yaml:"prompt_version"`
+		Partial       bool   `yaml:"partial"`
+	}
+	if yaml.Unmarshal(data, &result) != nil {
+		return "", false
+	}
+	return result.PromptVersion, result.Partial
+}
+
 // hasChanged reports whether the Markdown file is newer than the output file (R6.1).
 // Returns true if the output does not exist or the Markdown is more recent.
 func hasChanged(mdPath, outPath string) (bool, error) {
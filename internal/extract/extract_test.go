@@ -4,10 +4,14 @@ package extract
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -242,7 +246,7 @@ func TestConvertItems(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			items, errors := convertItems(tt.items, tt.paperID, tt.section)
+			items, errors := convertItems(tt.items, tt.paperID, tt.section, nil)
 			if len(items) != tt.wantCount {
 				t.Errorf("got %d items, want %d", len(items), tt.wantCount)
 			}
@@ -268,6 +272,27 @@ func TestConvertItems(t *testing.T) {
 	}
 }
 
+func TestConvertItemsMetric(t *testing.T) {
+	base := 4.1
+	items := []AIResponseItem{
+		{Type: "result", Content: "89.2% accuracy on GLUE.", Confidence: 0.9,
+			Metric: &types.Metric{Name: " Top-1  Accuracy ", Value: 89.2, Unit: " % ", Dataset: " GLUE ", Baseline: "BERT", BaselineValue: &base}},
+		{Type: "result", Content: "Results improve.", Confidence: 0.9, Metric: &types.Metric{Value: 3}},
+		{Type: "claim", Content: "A claim.", Confidence: 0.9, Metric: &types.Metric{Name: "accuracy", Value: 1}},
+	}
+	got, errs := convertItems(items, "p", "Results", nil)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	want := types.Metric{Name: "top-1 accuracy", Value: 89.2, Unit: "%", Dataset: "GLUE", Baseline: "BERT", BaselineValue: &base}
+	if m := got[0].Metric; m == nil || m.Name != want.Name || m.Unit != want.Unit || m.Dataset != want.Dataset || m.Baseline != want.Baseline || *m.BaselineValue != base {
+		t.Errorf("metric = %+v, want %+v", m, want)
+	}
+	if got[1].Metric != nil || got[2].Metric != nil {
+		t.Error("unnamed metric and metric on a claim should be dropped")
+	}
+}
+
 // --- callWithRetry ---
 
 func TestCallWithRetry(t *testing.T) {
@@ -440,6 +465,60 @@ func TestExtractAll(t *testing.T) {
 	}
 }
 
+// --- Conversion quality gate ---
+
+func TestExtractAllQualityGate(t *testing.T) {
+	tmpDir := t.TempDir()
+	mdDir := filepath.Join(tmpDir, "papers", markdownDir)
+	if err := os.MkdirAll(mdDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, md := range map[string]string{
+		"good.md":    "---\npaper_id: \"good\"\nquality: good\nquality_score: 0.82\n---\n\n## Intro\n\nClaim.",
+		"poor.md":    "---\npaper_id: \"poor\"\nquality: poor\nquality_score: 0.31\n---\n\n## Intro\n\nClaim.",
+		"garbled.md": "---\npaper_id: \"garbled\"\nquality: poor\nquality_score: 0.12\n---\n\n## Intro\n\nClaim.",
+		"legacy.md":  "## Intro\n\nClaim.",
+		"german.md":  "---\npaper_id: \"german\"\nlanguage: de\nquality: good\nquality_score: 0.80\n---\n\n## Intro\n\nClaim.",
+		"french.md":  "---\npaper_id: \"french\"\nlanguage: fr\ntranslated: true\nquality: good\nquality_score: 0.80\n---\n\n## Intro\n\nClaim.",
+	} {
+		if err := os.WriteFile(filepath.Join(mdDir, name), []byte(md), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	backend := &mockAIBackend{
+		responses: map[string]AIResponse{
+			"## Intro": {Items: []AIResponseItem{
+				{Type: "claim", Content: "A claim.", Section: "Intro", Page: 1, Confidence: 0.9, Tags: []string{"test"}},
+			}},
+		},
+	}
+
+	cfg := testConfig(filepath.Join(tmpDir, "papers"), filepath.Join(tmpDir, "knowledge"))
+	cfg.MinQuality = 0.2
+
+	var buf strings.Builder
+	summary, err := ExtractAll(context.Background(), backend, cfg, &buf)
+	if err != nil {
+		t.Fatalf("ExtractAll: %v", err)
+	}
+	if summary.Extracted != 5 || summary.Skipped != 1 {
+		t.Errorf("summary = %+v, want 5 extracted and 1 skipped", summary)
+	}
+	log := buf.String()
+	for _, want := range []string{
+		"skipped garbled: conversion quality 0.12 is below --min-quality 0.20",
+		"warning poor: conversion quality is poor (0.31)",
+		"warning german: paper is in German and was not translated; re-convert with --translate",
+	} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log missing %q:\n%s", want, log)
+		}
+	}
+	if strings.Contains(log, "warning good") || strings.Contains(log, "warning legacy") || strings.Contains(log, "warning french") {
+		t.Errorf("unexpected warning:\n%s", log)
+	}
+}
+
 // --- Skip unchanged files ---
 
 func TestExtractAllSkipsUnchanged(t *testing.T) {
@@ -598,6 +677,122 @@ func TestExtractPaperValidationFailure(t *testing.T) {
 	}
 }
 
+// --- Partial extraction ---
+
+// sectionFailBackend fails the sections whose heading line is in fail and
+// answers the others like mockAIBackend.
+type sectionFailBackend struct {
+	mockAIBackend
+	fail map[string]bool
+}
+
+func (s *sectionFailBackend) Extract(ctx context.Context, section string) (AIResponse, error) {
+	if s.fail[strings.SplitN(section, "\n", 2)[0]] {
+		s.calls++
+		return AIResponse{}, fmt.Errorf("model overloaded")
+	}
+	return s.mockAIBackend.Extract(ctx, section)
+}
+
+func partialPaper(t *testing.T, path string) {
+	t.Helper()
+	md := "## Intro\n\nText.\n\n## Methods\n\nWe train.\n\n## Results\n\nIt works.\n"
+	if err := os.WriteFile(path, []byte(md), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func partialBackend() *sectionFailBackend {
+	return &sectionFailBackend{
+		mockAIBackend: mockAIBackend{responses: map[string]AIResponse{
+			"## Intro":   {Items: []AIResponseItem{{Type: "opinion", Content: "Not a valid type.", Confidence: 0.5}}},
+			"## Methods": {Items: []AIResponseItem{{Type: "method", Content: "We train a model.", Confidence: 0.9}}},
+		}},
+		fail: map[string]bool{"## Results": true},
+	}
+}
+
+func TestExtractPaperAllowPartial(t *testing.T) {
+	mdPath := filepath.Join(t.TempDir(), "p.md")
+	partialPaper(t, mdPath)
+	cfg := testConfig("", "")
+	ctx := context.Background()
+
+	if _, err := ExtractPaper(ctx, partialBackend(), "p", mdPath, cfg); err == nil {
+		t.Fatal("expected an error without AllowPartial")
+	}
+
+	cfg.AllowPartial = true
+	result, err := ExtractPaper(ctx, partialBackend(), "p", mdPath, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Partial || len(result.Items) != 1 || result.Items[0].Section != "Methods" {
+		t.Fatalf("partial %v, items %v; want the Methods item of a partial result", result.Partial, result.Items)
+	}
+	if len(result.Errors) != 2 || result.Errors[0].Section != "Intro" || result.Errors[1].Section != "Results" {
+		t.Fatalf("errors = %v, want Intro and Results", result.Errors)
+	}
+	if !strings.Contains(result.Errors[0].Error, "invalid type") || !strings.Contains(result.Errors[1].Error, "model overloaded") {
+		t.Errorf("errors = %v", result.Errors)
+	}
+
+	// A paper with no section left is still a failure.
+	all := partialBackend()
+	all.fail = map[string]bool{"## Intro": true, "## Methods": true, "## Results": true}
+	if _, err := ExtractPaper(ctx, all, "p", mdPath, cfg); err == nil {
+		t.Error("expected an error when every section fails")
+	}
+}
+
+func TestExtractAllRetriesPartial(t *testing.T) {
+	tmpDir := t.TempDir()
+	papersDir := filepath.Join(tmpDir, "papers")
+	knowledgeDir := filepath.Join(tmpDir, "knowledge")
+	os.MkdirAll(filepath.Join(papersDir, markdownDir), 0o755)
+	partialPaper(t, filepath.Join(papersDir, markdownDir, "p.md"))
+	cfg := testConfig(papersDir, knowledgeDir)
+	cfg.AllowPartial = true
+	ctx := context.Background()
+
+	var buf strings.Builder
+	summary, err := ExtractAll(ctx, partialBackend(), cfg, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Extracted != 1 || summary.Partial != 1 || !strings.Contains(buf.String(), "partial p") {
+		t.Fatalf("first run: %+v\n%s", summary, buf.String())
+	}
+	outPath := filepath.Join(knowledgeDir, extractedDir, "p-items.yaml")
+	if _, partial := recordedState(outPath); !partial {
+		t.Error("result not recorded as partial")
+	}
+
+	// The retry sends only the failed sections: the valid one is cached
+	// and the invalid one is not.
+	fixed := partialBackend()
+	fixed.fail = nil
+	fixed.responses["## Intro"] = AIResponse{Items: []AIResponseItem{{Type: "claim", Content: "It is new.", Confidence: 0.8}}}
+	summary, err = ExtractAll(ctx, fixed, cfg, &strings.Builder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Extracted != 1 || summary.Partial != 0 || fixed.calls != 2 {
+		t.Errorf("retry: %+v after %d calls; want one complete paper after 2 calls", summary, fixed.calls)
+	}
+	if _, partial := recordedState(outPath); partial {
+		t.Error("result still partial after a successful retry")
+	}
+
+	summary, err = ExtractAll(ctx, fixed, cfg, &strings.Builder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Skipped != 1 {
+		t.Errorf("third run: %+v, want the complete paper skipped", summary)
+	}
+}
+
 // --- Retry exhaustion in batch ---
 
 func TestExtractAllRetryExhaustion(t *testing.T) {
@@ -655,7 +850,7 @@ func TestBatchSummary(t *testing.T) {
 // --- renderPrompt ---
 
 func TestRenderPrompt(t *testing.T) {
-	prompt, err := renderPrompt("## Introduction\n\nSome text.")
+	prompt, err := DefaultPrompt().render("## Introduction\n\nSome text.")
 	if err != nil {
 		t.Fatalf("renderPrompt: %v", err)
 	}
@@ -833,6 +1028,52 @@ func TestParseBibEntryMetadata(t *testing.T) {
 	}
 }
 
+func TestParseAuthorYearBibliography(t *testing.T) {
+	tests := []struct {
+		name     string
+		refs     string
+		wantKeys []string
+	}{
+		{
+			name:     "hanging indent",
+			refs:     "Smith, J., & Jones, B. (2020a). Attention over\n    everything. In Proceedings of NeurIPS.\nSmith, J. (2020b). Another paper. Journal of AI, 3(2).\n<!-- page 9 -->\nBrown, T. et al. (2019). Language models.\n    arXiv preprint.\n",
+			wantKeys: []string{"Smith, 2020a", "Smith, 2020b", "Brown, 2019"},
+		},
+		{
+			name:     "one entry per line",
+			refs:     "Smith, J. (2020). First. Venue A.\nJones, B. (2021). Second. Venue B.\n",
+			wantKeys: []string{"Smith, 2020", "Jones, 2021"},
+		},
+		{
+			name:     "names in full, blank-line separated",
+			refs:     "Ashish Vaswani, Noam Shazeer, and Niki Parmar. 2017. Attention is all you need. In\nAdvances in Neural Information Processing Systems.\n\nJacob Devlin, Ming-Wei Chang. 2019. BERT. In NAACL.\n",
+			wantKeys: []string{"Vaswani, 2017", "Devlin, 2019"},
+		},
+		{
+			name:     "bullets and initials after surname",
+			refs:     "- Vaswani A, Shazeer N. Attention is all you need. 2017.\n- Lee K, Park J. Untitled draft. n.d.\n",
+			wantKeys: []string{"Vaswani, 2017", "Lee, n.d."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := ParseBibliography("## References\n\n" + tt.refs)
+			var keys []string
+			for _, e := range entries {
+				keys = append(keys, e.Key)
+			}
+			if strings.Join(keys, "; ") != strings.Join(tt.wantKeys, "; ") {
+				t.Errorf("keys = %q, want %q", keys, tt.wantKeys)
+			}
+		})
+	}
+
+	entries := ParseBibliography("## References\n\n" + tests[0].refs)
+	if e := entries[0]; e.Year != "2020" || e.Title != "Attention over everything" || len(e.Authors) == 0 {
+		t.Errorf("entry = %+v, want year 2020, title and authors", e)
+	}
+}
+
 // --- LinkCitations ---
 
 func TestLinkCitations(t *testing.T) {
@@ -886,6 +1127,21 @@ func TestLinkCitations(t *testing.T) {
 	}
 }
 
+func TestLinkAuthorYearCitations(t *testing.T) {
+	bib := ParseBibliography("## References\n\nSmith, J., & Jones, B. (2020a). First.\n    Venue.\nSmith, J. (2020b). Second. Venue.\nBrown, T. (2019). Third. Venue.\n")
+	citations := ParseCitations("As [Smith et al., 2020b] and [Brown and Lee, 2019] show, unlike [Smith, 2020] and [Green, 2018].")
+	linked := LinkCitations(citations, bib)
+	want := map[string]int{"Smith et al., 2020b": 1, "Brown and Lee, 2019": 2, "Smith, 2020": -1, "Green, 2018": -1}
+	if len(linked) != len(want) {
+		t.Fatalf("got %d citations, want %d", len(linked), len(want))
+	}
+	for _, c := range linked {
+		if c.BibIndex != want[c.Key] {
+			t.Errorf("%q: BibIndex = %d, want %d", c.Key, c.BibIndex, want[c.Key])
+		}
+	}
+}
+
 func TestLinkCitationsEmptyBibliography(t *testing.T) {
 	citations := []types.Citation{
 		{Key: "1", BibIndex: -1},
@@ -1152,3 +1408,295 @@ As shown by [Smith et al., 2020], the approach works well.
 		}
 	}
 }
+
+// --- Claude backend tests ---
+
+func TestClaudeBackendRecordsUsage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"content":[{"type":"text","text":"{\"items\":[]}"}],`+
+			`"usage":{"input_tokens":120,"output_tokens":30}}`)
+	}))
+	defer ts.Close()
+
+	origURL := claudeAPIURL
+	claudeAPIURL = ts.URL
+	defer func() { claudeAPIURL = origURL }()
+
+	backend := &ClaudeBackend{APIKey: "k", Model: "m", Client: ts.Client()}
+	for i := 0; i < 2; i++ {
+		if _, err := backend.Extract(context.Background(), "## Intro\ntext"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	in, out := backend.Usage()
+	if in != 240 || out != 60 {
+		t.Errorf("Usage() = %d, %d; want 240, 60", in, out)
+	}
+}
+
+// --- OpenAI-compatible backend tests ---
+
+func TestOpenAIBackendExtract(t *testing.T) {
+	var gotPath, gotAuth string
+	var gotReq openAIRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&gotReq)
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"`+
+			"```json\\n{\\\"items\\\":[{\\\"type\\\":\\\"claim\\\",\\\"content\\\":\\\"A claim.\\\",\\\"section\\\":\\\"Intro\\\",\\\"page\\\":1,\\\"confidence\\\":0.9,\\\"tags\\\":[\\\"t\\\"]}]}\\n```"+
+			`"}}],"usage":{"prompt_tokens":100,"completion_tokens":20}}`)
+	}))
+	defer ts.Close()
+
+	backend := &OpenAIBackend{BaseURL: ts.URL + "/v1/", APIKey: "k", Model: "gpt-test", Client: ts.Client()}
+	resp, err := backend.Extract(context.Background(), "## Intro\ntext")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].Content != "A claim." {
+		t.Errorf("items = %+v", resp.Items)
+	}
+	if gotPath != "/v1/chat/completions" || gotAuth != "Bearer k" {
+		t.Errorf("path = %q, auth = %q", gotPath, gotAuth)
+	}
+	if gotReq.Model != "gpt-test" || len(gotReq.Messages) != 1 || !strings.Contains(gotReq.Messages[0].Content, "## Intro") {
+		t.Errorf("request = %+v", gotReq)
+	}
+	if in, out := backend.Usage(); in != 100 || out != 20 {
+		t.Errorf("Usage() = %d, %d; want 100, 20", in, out)
+	}
+}
+
+func TestOpenAIBackendLocalServer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" || r.URL.Query().Get("api-version") != "2024-06-01" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"choices":[]}`)
+	}))
+	defer ts.Close()
+
+	backend := &OpenAIBackend{BaseURL: ts.URL + "/deployments/gpt?api-version=2024-06-01", Model: "m", Client: ts.Client()}
+	_, err := backend.Extract(context.Background(), "## Intro\ntext")
+	if err == nil || !strings.Contains(err.Error(), "empty content") {
+		t.Errorf("err = %v, want empty content", err)
+	}
+}
+
+// --- Ollama backend tests ---
+
+func TestOllamaBackendExtract(t *testing.T) {
+	var gotPath string
+	var gotReq ollamaRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotReq)
+		items := `{"items":[` +
+			`{"type":"claim","content":"Sure.","section":"Intro","page":1,"confidence":0.9,"tags":["t"]},` +
+			`{"type":"result","content":"Percent.","section":"Intro","page":1,"confidence":85,"tags":["t"]},` +
+			`{"type":"claim","content":"Unsure.","section":"Intro","page":1,"confidence":0.2,"tags":["t"]}]}`
+		reply, _ := json.Marshal(map[string]any{
+			"message":           map[string]string{"role": "assistant", "content": items},
+			"prompt_eval_count": 300,
+			"eval_count":        50,
+		})
+		w.Write(reply)
+	}))
+	defer ts.Close()
+
+	backend := &OllamaBackend{URL: ts.URL + "/", Model: "qwen2.5:7b", Client: ts.Client(), MinConfidence: DefaultOllamaMinConfidence}
+	resp, err := backend.Extract(context.Background(), "## Intro\ntext")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "/api/chat" || gotReq.Stream || gotReq.Options.NumCtx != DefaultOllamaContext || len(gotReq.Format) == 0 {
+		t.Errorf("path = %q, request = %+v", gotPath, gotReq)
+	}
+	if len(gotReq.Messages) != 2 || gotReq.Messages[0].Role != "system" || !strings.Contains(gotReq.Messages[1].Content, "## Intro") {
+		t.Errorf("messages = %+v", gotReq.Messages)
+	}
+
+	if len(resp.Items) != 2 {
+		t.Fatalf("got %d items, want 2 (low confidence dropped): %+v", len(resp.Items), resp.Items)
+	}
+	if resp.Items[0].Confidence != 0.72 || resp.Items[1].Confidence != 0.68 {
+		t.Errorf("confidence = %v, %v; want 0.72, 0.68", resp.Items[0].Confidence, resp.Items[1].Confidence)
+	}
+	if in, out := backend.Usage(); in != 300 || out != 50 {
+		t.Errorf("Usage() = %d, %d; want 300, 50", in, out)
+	}
+}
+
+// --- Concurrent extraction ---
+
+// slowBackend echoes each section's heading as a claim after a delay,
+// recording the most calls it saw in flight. Sections whose heading is in
+// fail return an error.
+type slowBackend struct {
+	fail     map[string]bool
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (s *slowBackend) Extract(ctx context.Context, section string) (AIResponse, error) {
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		p := s.peak.Load()
+		if n <= p || s.peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	heading := strings.TrimPrefix(strings.SplitN(section, "\n", 2)[0], "## ")
+	if s.fail[heading] {
+		return AIResponse{}, fmt.Errorf("%s failed", heading)
+	}
+	return AIResponse{Items: []AIResponseItem{
+		{Type: "claim", Content: "Claim in " + heading + ".", Section: heading, Page: 1, Confidence: 0.9, Tags: []string{"t"}},
+	}}, nil
+}
+
+func writeSections(t *testing.T, path string, n int) {
+	t.Helper()
+	var sb strings.Builder
+	for i := range n {
+		fmt.Fprintf(&sb, "## S%d\n\nBody %d of %s.\n\n", i, i, filepath.Base(path))
+	}
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractPaperConcurrent(t *testing.T) {
+	mdPath := filepath.Join(t.TempDir(), "p.md")
+	writeSections(t, mdPath, 12)
+
+	for _, limit := range []int{0, 4} {
+		backend := &slowBackend{}
+		cfg := testConfig("", "")
+		cfg.MaxConcurrentCalls = limit
+		result, err := ExtractPaper(context.Background(), backend, "p", mdPath, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, item := range result.Items {
+			if want := fmt.Sprintf("Claim in S%d.", i); item.Content != want {
+				t.Errorf("limit %d: item %d = %q, want %q", limit, i, item.Content, want)
+			}
+		}
+		if want := int32(max(limit, 1)); backend.peak.Load() != want {
+			t.Errorf("limit %d: peak calls in flight = %d, want %d", limit, backend.peak.Load(), want)
+		}
+	}
+
+	backend := &slowBackend{fail: map[string]bool{"S3": true, "S9": true}}
+	cfg := testConfig("", "")
+	cfg.MaxRetries = 1
+	cfg.MaxConcurrentCalls = 3
+	_, err := ExtractPaper(context.Background(), backend, "p", mdPath, cfg)
+	if err == nil || !strings.Contains(err.Error(), `section "S3"`) {
+		t.Errorf("err = %v, want the failure of S3", err)
+	}
+}
+
+func TestExtractAllConcurrent(t *testing.T) {
+	origBackoff := backoffBase
+	backoffBase = time.Millisecond
+	defer func() { backoffBase = origBackoff }()
+
+	tmpDir := t.TempDir()
+	mdDir := filepath.Join(tmpDir, "papers", markdownDir)
+	if err := os.MkdirAll(mdDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 6 {
+		writeSections(t, filepath.Join(mdDir, fmt.Sprintf("paper%d.md", i)), 3)
+	}
+	if err := os.WriteFile(filepath.Join(mdDir, "paper9.md"), []byte("## Broken\n\nText."), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	backend := &slowBackend{fail: map[string]bool{"Broken": true}}
+	cfg := testConfig(filepath.Join(tmpDir, "papers"), filepath.Join(tmpDir, "knowledge"))
+	cfg.MaxRetries = 0
+	cfg.MaxConcurrentCalls = 5
+
+	var buf strings.Builder
+	summary, err := ExtractAll(context.Background(), backend, cfg, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Extracted != 6 || summary.Failed != 1 {
+		t.Errorf("summary = %+v, want 6 extracted and 1 failed", summary)
+	}
+	if peak := backend.peak.Load(); peak < 2 || peak > 5 {
+		t.Errorf("peak calls in flight = %d, want 2-5", peak)
+	}
+
+	var want strings.Builder
+	for i := range 6 {
+		fmt.Fprintf(&want, "extracting paper%d\nextracted paper%d (3 items)\n", i, i)
+	}
+	want.WriteString("extracting paper9\n")
+	if log := buf.String(); !strings.HasPrefix(log, want.String()) || !strings.Contains(log, `failed  paper9: extracting section "Broken"`) {
+		t.Errorf("log:\n%s", log)
+	}
+
+	summary, err = ExtractPapers(context.Background(), backend, cfg, []string{"paper1", "missing"}, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Extracted != 1 || summary.Failed != 1 {
+		t.Errorf("ExtractPapers summary = %+v, want 1 extracted and 1 failed", summary)
+	}
+}
+
+// --- Response cache ---
+
+func TestExtractPaperCachesSections(t *testing.T) {
+	tmpDir := t.TempDir()
+	mdPath := filepath.Join(tmpDir, "p.md")
+	writeSections(t, mdPath, 4)
+	cfg := testConfig(tmpDir, filepath.Join(tmpDir, "knowledge"))
+	ctx := context.Background()
+
+	backend := &mockAIBackend{}
+	first, err := ExtractPaper(ctx, backend, "p", mdPath, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backend.calls != 4 {
+		t.Fatalf("first run made %d calls, want 4", backend.calls)
+	}
+
+	// Edit one section: only it is sent again.
+	data, _ := os.ReadFile(mdPath)
+	os.WriteFile(mdPath, []byte(strings.Replace(string(data), "Body 2", "Body two", 1)), 0o644)
+	backend.calls = 0
+	second, err := ExtractPaper(ctx, backend, "p", mdPath, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backend.calls != 1 || len(second.Items) != len(first.Items) {
+		t.Errorf("after editing one section: %d calls, %d items; want 1 call, %d items", backend.calls, len(second.Items), len(first.Items))
+	}
+
+	// Another model, or no cache, pays for every section.
+	other := cfg
+	other.Model = "other-model"
+	noCache := cfg
+	noCache.NoCache = true
+	for name, c := range map[string]types.ExtractionConfig{"other model": other, "no cache": noCache} {
+		backend.calls = 0
+		if _, err := ExtractPaper(ctx, backend, "p", mdPath, c); err != nil {
+			t.Fatal(err)
+		}
+		if backend.calls != 4 {
+			t.Errorf("%s: %d calls, want 4", name, backend.calls)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Funding and disclosure statements live in a few conventional sections.
+// Headings may carry a section number ("7 Acknowledgments").
+var (
+	fundingHeadingPattern = regexp.MustCompile(`(?i)^(?:[\d.]+\s*)?(?:funding(?:\s+(?:information|sources?|statement))?|financial\s+support)\b`)
+	ackHeadingPattern     = regexp.MustCompile(`(?i)^(?:[\d.]+\s*)?acknowledge?ments?\b`)
+	coiHeadingPattern     = regexp.MustCompile(`(?i)^(?:[\d.]+\s*)?(?:conflicts?\s+of\s+interests?|competing\s+(?:financial\s+)?interests?|declaration\s+of\s+(?:competing\s+)?interests?|(?:financial\s+)?disclosures?)\b`)
+)
+
+// Sentences in an acknowledgments section are sorted into funding and
+// conflict-of-interest statements by these keywords.
+var (
+	fundingSentencePattern = regexp.MustCompile(`(?i)\b(?:fund(?:ed|ing|s)?|grants?|supported\s+(?:in\s+part\s+)?by|support\s+from|sponsor(?:ed|ship)?|fellowships?|award(?:ed)?)\b`)
+	coiSentencePattern     = regexp.MustCompile(`(?i)\b(?:conflicts?\s+of\s+interests?|competing\s+(?:financial\s+)?interests?|declares?\s+no|financial\s+disclosures?)\b`)
+)
+
+// grantPattern matches the word introducing a grant number ("grant",
+// "Grant No.", "award #", "Nos."); grantIDPattern matches the number
+// itself, which must contain a digit ("CCF-1918757", "EP/T022132/1").
+// grantListPattern continues a list of numbers ("..., 456 and 789").
+var (
+	grantPattern     = regexp.MustCompile(`(?i:\b(?:grants?|awards?|contracts?)(?:\s+(?:no|nos|number|numbers)\.?)?\s*[:#]?\s*|\bnos?\.\s*)`)
+	grantIDPattern   = regexp.MustCompile(`^[A-Z0-9][A-Za-z0-9/_-]*\d[A-Za-z0-9/_-]*`)
+	grantListPattern = regexp.MustCompile(`^(?:,\s*(?:and\s+)?|\s+and\s+)`)
+)
+
+// extractDisclosures collects the funding statement, the grant numbers it
+// names, and the conflict-of-interest disclosure from a paper's sections
+// (R8). Funding and disclosure sections are taken whole; acknowledgments
+// contribute only the sentences that mention funding or competing
+// interests.
+func extractDisclosures(sections []section) (funding string, grants []string, coi string) {
+	var fundingParts, coiParts []string
+	for _, sec := range sections {
+		body := collapseSpace(sec.body)
+		if body == "" {
+			continue
+		}
+		switch {
+		case coiHeadingPattern.MatchString(sec.heading):
+			coiParts = append(coiParts, body)
+		case fundingHeadingPattern.MatchString(sec.heading):
+			fundingParts = append(fundingParts, body)
+		case ackHeadingPattern.MatchString(sec.heading):
+			for _, s := range splitSentences(body) {
+				switch {
+				case coiSentencePattern.MatchString(s):
+					coiParts = append(coiParts, s)
+				case fundingSentencePattern.MatchString(s):
+					fundingParts = append(fundingParts, s)
+				}
+			}
+		}
+	}
+	funding = strings.Join(fundingParts, " ")
+	return funding, grantNumbers(funding), strings.Join(coiParts, " ")
+}
+
+// grantNumbers returns the grant numbers in text in order of first
+// appearance.
+func grantNumbers(text string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, loc := range grantPattern.FindAllStringIndex(text, -1) {
+		rest := text[loc[1]:]
+		for {
+			id := grantIDPattern.FindString(rest)
+			if id == "" {
+				break
+			}
+			rest = rest[len(id):]
+			id = strings.TrimRight(id, "./_-")
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+			sep := grantListPattern.FindString(rest)
+			if sep == "" {
+				break
+			}
+			rest = rest[len(sep):]
+		}
+	}
+	return ids
+}
+
+// sentenceAbbrevs end in a period without ending a sentence.
+var sentenceAbbrevs = map[string]bool{
+	"no": true, "nos": true, "dr": true, "prof": true, "al": true,
+	"e.g": true, "i.e": true, "inc": true, "ltd": true, "co": true,
+}
+
+// splitSentences splits text after '.', '!', or '?' followed by a space
+// and a capital letter, except after abbreviations and initials.
+func splitSentences(text string) []string {
+	var sentences []string
+	start := 0
+	runes := []rune(text)
+	for i := 0; i+2 < len(runes); i++ {
+		if !strings.ContainsRune(".!?", runes[i]) || runes[i+1] != ' ' || !unicode.IsUpper(runes[i+2]) {
+			continue
+		}
+		word := string(runes[start:i])
+		if j := strings.LastIndexByte(word, ' '); j >= 0 {
+			word = word[j+1:]
+		}
+		if runes[i] == '.' && (sentenceAbbrevs[strings.ToLower(word)] || len([]rune(word)) == 1) {
+			continue
+		}
+		sentences = append(sentences, strings.TrimSpace(string(runes[start:i+1])))
+		start = i + 2
+	}
+	if tail := strings.TrimSpace(string(runes[start:])); tail != "" {
+		sentences = append(sentences, tail)
+	}
+	return sentences
+}
+
+// collapseSpace joins the lines of a section body into single-spaced text.
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
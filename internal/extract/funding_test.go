@@ -0,0 +1,65 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractDisclosures(t *testing.T) {
+	md := `## 1 Introduction
+
+We study attention.
+
+## Acknowledgments
+
+We thank J. Smith for helpful discussions. This work was supported by
+NSF Grant No. CCF-1918757 and EPSRC grants EP/T022132/1 and EP/V000001/1.
+The authors declare no competing interests.
+
+## References
+
+[1] A. Author. Title. 2020.
+`
+	funding, grants, coi := extractDisclosures(chunkByHeadings(md))
+
+	wantFunding := "This work was supported by NSF Grant No. CCF-1918757 and EPSRC grants EP/T022132/1 and EP/V000001/1."
+	if funding != wantFunding {
+		t.Errorf("funding = %q, want %q", funding, wantFunding)
+	}
+	if want := []string{"CCF-1918757", "EP/T022132/1", "EP/V000001/1"}; !reflect.DeepEqual(grants, want) {
+		t.Errorf("grants = %v, want %v", grants, want)
+	}
+	if coi != "The authors declare no competing interests." {
+		t.Errorf("coi = %q", coi)
+	}
+}
+
+func TestExtractDisclosuresDedicatedSections(t *testing.T) {
+	md := `## Funding
+
+Funded by the Wellcome Trust (award 221234/Z/20/Z).
+
+## Conflict of Interest Statement
+
+J.D. is a consultant for Acme Corp.
+`
+	funding, grants, coi := extractDisclosures(chunkByHeadings(md))
+	if funding != "Funded by the Wellcome Trust (award 221234/Z/20/Z)." {
+		t.Errorf("funding = %q", funding)
+	}
+	if !reflect.DeepEqual(grants, []string{"221234/Z/20/Z"}) {
+		t.Errorf("grants = %v", grants)
+	}
+	if coi != "J.D. is a consultant for Acme Corp." {
+		t.Errorf("coi = %q", coi)
+	}
+}
+
+func TestExtractDisclosuresNone(t *testing.T) {
+	funding, grants, coi := extractDisclosures(chunkByHeadings("## Method\n\nWe fund nothing here.\n"))
+	if funding != "" || grants != nil || coi != "" {
+		t.Errorf("got %q, %v, %q; want nothing outside disclosure sections", funding, grants, coi)
+	}
+}
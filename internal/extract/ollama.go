@@ -0,0 +1,255 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// DefaultOllamaURL is the address a local Ollama server listens on.
+	DefaultOllamaURL = "http://localhost:11434"
+	// DefaultOllamaContext is the context window requested from Ollama.
+	// Ollama's own default of 2048 tokens silently truncates the
+	// extraction prompt for long sections.
+	DefaultOllamaContext = 8192
+	// DefaultOllamaMinConfidence is the discounted confidence below which
+	// items from a local model are dropped.
+	DefaultOllamaMinConfidence = 0.3
+)
+
+// ollamaConfidenceDiscount scales the confidence local models report.
+// Small models rate nearly everything as certain, so their items rank
+// below items from a larger model with the same stated confidence.
+const ollamaConfidenceDiscount = 0.8
+
+// ollamaSystemPrompt keeps small models to the response format; the
+// extraction prompt follows as the user message.
+const ollamaSystemPrompt = `You extract knowledge items from academic papers. Reply with one JSON object of the form {"items": [...]} and nothing else: no explanation, no Markdown. Copy each item's content word for word from the section. If the section has no claims, methods, definitions, or results, reply {"items": []}.`
+
+// ollamaFormat is the JSON schema Ollama constrains the reply to, so small
+// models cannot drift from the AIResponse shape.
+var ollamaFormat = json.RawMessage(`{
+	"type": "object",
+	"properties": {
+		"items": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"properties": {
+					"type": {"type": "string", "enum": ["claim", "method", "definition", "result"]},
+					"content": {"type": "string"},
+					"section": {"type": "string"},
+					"page": {"type": "integer"},
+					"confidence": {"type": "number"},
+					"tags": {"type": "array", "items": {"type": "string"}},
+					"claim_number": {"type": "integer"},
+					"depends_on": {"type": "integer"},
+					"metric": {
+						"type": "object",
+						"properties": {
+							"name": {"type": "string"},
+							"value": {"type": "number"},
+							"unit": {"type": "string"},
+							"dataset": {"type": "string"},
+							"baseline": {"type": "string"},
+							"baseline_value": {"type": "number"}
+						},
+						"required": ["name", "value"]
+					}
+				},
+				"required": ["type", "content", "section", "page", "confidence", "tags"]
+			}
+		}
+	},
+	"required": ["items"]
+}`)
+
+// OllamaBackend calls a local Ollama server to extract knowledge items, so
+// extraction runs offline on private corpora. Replies are constrained to
+// the item schema, and item confidence is discounted and filtered since
+// small models are poorly calibrated. Per prd003-extraction R5.12.
+type OllamaBackend struct {
+	// URL is the Ollama server address (default DefaultOllamaURL).
+	URL    string
+	Model  string
+	Client *http.Client
+	// Prompt is the extraction prompt (default DefaultPrompt).
+	Prompt *Prompt
+	// PatentPrompt is the extraction prompt for patents (default
+	// DefaultPatentPrompt).
+	PatentPrompt *Prompt
+	// ContextSize is the context window in tokens (default
+	// DefaultOllamaContext).
+	ContextSize int
+	// MinConfidence drops items whose discounted confidence is below it.
+	MinConfidence float64
+
+	inputTokens  atomic.Int64
+	outputTokens atomic.Int64
+}
+
+// Usage returns the prompt and reply tokens Ollama reported across all
+// successful calls made through this backend.
+func (o *OllamaBackend) Usage() (input, output int64) {
+	return o.inputTokens.Load(), o.outputTokens.Load()
+}
+
+// ollamaRequest is the request body for Ollama's chat API.
+type ollamaRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Stream   bool            `json:"stream"`
+	Format   json.RawMessage `json:"format,omitempty"`
+	Options  ollamaOptions   `json:"options"`
+}
+
+// ollamaMessage is a single message in an Ollama chat.
+type ollamaMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ollamaOptions are the model parameters sent with each request.
+type ollamaOptions struct {
+	Temperature float64 `json:"temperature"`
+	NumCtx      int     `json:"num_ctx"`
+}
+
+// ollamaResponse is the non-streaming response from Ollama's chat API.
+type ollamaResponse struct {
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	PromptEvalCount int64 `json:"prompt_eval_count"`
+	EvalCount       int64 `json:"eval_count"`
+}
+
+func (o *OllamaBackend) extractionPrompt() *Prompt { return o.Prompt }
+func (o *OllamaBackend) patentPrompt() *Prompt     { return o.PatentPrompt }
+
+// Extract sends the extraction prompt for one section to Ollama and
+// adjusts the confidence of the items it returns.
+func (o *OllamaBackend) Extract(ctx context.Context, section string) (AIResponse, error) {
+	return o.extractWith(ctx, o.Prompt, section)
+}
+
+// extractWith sends prompt for one section to Ollama and adjusts the
+// confidence of the items it returns.
+func (o *OllamaBackend) extractWith(ctx context.Context, p *Prompt, section string) (AIResponse, error) {
+	prompt, err := p.render(section)
+	if err != nil {
+		return AIResponse{}, fmt.Errorf("rendering prompt: %w", err)
+	}
+
+	text, err := o.chat(ctx, ollamaSystemPrompt, ollamaFormat, prompt)
+	if err != nil {
+		return AIResponse{}, err
+	}
+
+	var aiResp AIResponse
+	if err := json.Unmarshal([]byte(text), &aiResp); err != nil {
+		return AIResponse{}, fmt.Errorf("parsing AI response JSON: %w", err)
+	}
+	aiResp.Items = o.discount(aiResp.Items)
+	return aiResp, nil
+}
+
+// discount rescales item confidence for a local model: percentages are
+// read as fractions, the result is scaled by ollamaConfidenceDiscount, and
+// items left below MinConfidence are dropped.
+func (o *OllamaBackend) discount(items []AIResponseItem) []AIResponseItem {
+	kept := items[:0]
+	for _, item := range items {
+		c := item.Confidence
+		if c > 1 && c <= 100 {
+			c /= 100
+		}
+		if c >= 0 && c <= 1 {
+			c = math.Round(c*ollamaConfidenceDiscount*100) / 100
+		}
+		if c < o.MinConfidence {
+			continue
+		}
+		item.Confidence = c
+		kept = append(kept, item)
+	}
+	return kept
+}
+
+// Complete sends prompt to Ollama as a single user message and returns
+// the reply. Ollama bounds replies by its context window, so maxTokens is
+// not sent.
+func (o *OllamaBackend) Complete(ctx context.Context, prompt string, maxTokens int) (string, error) {
+	return o.chat(ctx, "", nil, prompt)
+}
+
+// chat sends prompt to Ollama after the system prompt, if any,
+// constraining the reply to the JSON schema format, if any, and returns
+// the reply.
+func (o *OllamaBackend) chat(ctx context.Context, system string, format json.RawMessage, prompt string) (string, error) {
+	base := o.URL
+	if base == "" {
+		base = DefaultOllamaURL
+	}
+	numCtx := o.ContextSize
+	if numCtx <= 0 {
+		numCtx = DefaultOllamaContext
+	}
+	var messages []ollamaMessage
+	if system != "" {
+		messages = append(messages, ollamaMessage{Role: "system", Content: system})
+	}
+	messages = append(messages, ollamaMessage{Role: "user", Content: prompt})
+	bodyBytes, err := json.Marshal(ollamaRequest{
+		Model:    o.Model,
+		Messages: messages,
+		Format:   format,
+		Options:  ollamaOptions{Temperature: 0, NumCtx: numCtx},
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(base, "/")+"/api/chat", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := o.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("calling Ollama (is \"ollama serve\" running?): %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("Ollama returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var oResp ollamaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&oResp); err != nil {
+		return "", fmt.Errorf("decoding Ollama response: %w", err)
+	}
+	o.inputTokens.Add(oResp.PromptEvalCount)
+	o.outputTokens.Add(oResp.EvalCount)
+
+	if oResp.Message.Content == "" {
+		return "", fmt.Errorf("Ollama returned empty content")
+	}
+	return oResp.Message.Content, nil
+}
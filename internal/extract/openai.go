@@ -0,0 +1,179 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultOpenAIBaseURL is the OpenAI API base URL; the backend posts to
+// its /chat/completions endpoint.
+const DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// OpenAIBackend calls an OpenAI-compatible chat-completions API to extract
+// knowledge items from a section of Markdown: OpenAI, Azure OpenAI, or a
+// local server such as Ollama, vLLM, or llama.cpp. Per prd003-extraction
+// R5.11.
+type OpenAIBackend struct {
+	// BaseURL is the API base the /chat/completions path is appended to
+	// (default DefaultOpenAIBaseURL). A query string, such as Azure's
+	// api-version, is kept.
+	BaseURL string
+	// APIKey is sent as a bearer token, or in the api-key header for
+	// Azure OpenAI hosts. Local servers often need none.
+	APIKey string
+	Model  string
+	Client *http.Client
+	// Prompt is the extraction prompt (default DefaultPrompt).
+	Prompt *Prompt
+	// PatentPrompt is the extraction prompt for patents (default
+	// DefaultPatentPrompt).
+	PatentPrompt *Prompt
+
+	inputTokens  atomic.Int64
+	outputTokens atomic.Int64
+}
+
+// Usage returns the prompt and completion tokens reported by the API
+// across all successful calls made through this backend.
+func (o *OpenAIBackend) Usage() (input, output int64) {
+	return o.inputTokens.Load(), o.outputTokens.Load()
+}
+
+// openAIRequest is the request body for the chat-completions API.
+type openAIRequest struct {
+	Model     string          `json:"model"`
+	MaxTokens int             `json:"max_tokens"`
+	Messages  []openAIMessage `json:"messages"`
+}
+
+// openAIMessage is a single message in the chat-completions conversation.
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// openAIResponse is the response body from the chat-completions API.
+type openAIResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int64 `json:"prompt_tokens"`
+		CompletionTokens int64 `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+func (o *OpenAIBackend) extractionPrompt() *Prompt { return o.Prompt }
+func (o *OpenAIBackend) patentPrompt() *Prompt     { return o.PatentPrompt }
+
+// Extract calls the chat-completions API with the extraction prompt for
+// one section. Models that wrap the JSON object in prose or a code fence
+// are tolerated.
+func (o *OpenAIBackend) Extract(ctx context.Context, section string) (AIResponse, error) {
+	return o.extractWith(ctx, o.Prompt, section)
+}
+
+// extractWith calls the chat-completions API with prompt for one section.
+func (o *OpenAIBackend) extractWith(ctx context.Context, p *Prompt, section string) (AIResponse, error) {
+	prompt, err := p.render(section)
+	if err != nil {
+		return AIResponse{}, fmt.Errorf("rendering prompt: %w", err)
+	}
+
+	text, err := o.Complete(ctx, prompt, 4096)
+	if err != nil {
+		return AIResponse{}, err
+	}
+	if start, end := strings.Index(text, "{"), strings.LastIndex(text, "}"); start >= 0 && end > start {
+		text = text[start : end+1]
+	}
+
+	var aiResp AIResponse
+	if err := json.Unmarshal([]byte(text), &aiResp); err != nil {
+		return AIResponse{}, fmt.Errorf("parsing AI response JSON: %w", err)
+	}
+	return aiResp, nil
+}
+
+// Complete sends prompt to the chat-completions API as a single user
+// message and returns the text of the reply.
+func (o *OpenAIBackend) Complete(ctx context.Context, prompt string, maxTokens int) (string, error) {
+	endpoint, err := o.endpoint()
+	if err != nil {
+		return "", err
+	}
+	bodyBytes, err := json.Marshal(openAIRequest{
+		Model:     o.Model,
+		MaxTokens: maxTokens,
+		Messages:  []openAIMessage{{Role: "user", Content: prompt}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.APIKey != "" {
+		if strings.HasSuffix(endpoint.Hostname(), ".openai.azure.com") {
+			req.Header.Set("api-key", o.APIKey)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+o.APIKey)
+		}
+	}
+
+	client := o.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("calling %s: %w", endpoint.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("%s returned %d: %s", endpoint.Host, resp.StatusCode, string(body))
+	}
+
+	var oResp openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&oResp); err != nil {
+		return "", fmt.Errorf("decoding chat-completions response: %w", err)
+	}
+	o.inputTokens.Add(oResp.Usage.PromptTokens)
+	o.outputTokens.Add(oResp.Usage.CompletionTokens)
+
+	if len(oResp.Choices) == 0 || oResp.Choices[0].Message.Content == "" {
+		return "", fmt.Errorf("%s returned empty content", endpoint.Host)
+	}
+	return oResp.Choices[0].Message.Content, nil
+}
+
+// endpoint returns the chat-completions URL under BaseURL.
+func (o *OpenAIBackend) endpoint() (*url.URL, error) {
+	base := o.BaseURL
+	if base == "" {
+		base = DefaultOpenAIBaseURL
+	}
+	u, err := url.Parse(base)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q", base)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/chat/completions"
+	return u, nil
+}
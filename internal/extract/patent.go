@@ -0,0 +1,61 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+var (
+	// claimMarkerRe matches the <!-- claim N --> and <!-- claim N depends
+	// on M --> markers conversion writes before each claim of a patent.
+	claimMarkerRe = regexp.MustCompile(`<!--\s*claim\s+(\d+)(?:\s+depends\s+on\s+(\d+))?\s*-->`)
+	// leadingClaimNumberRe matches the number a claim's text begins with.
+	leadingClaimNumberRe = regexp.MustCompile(`^\s*(\d{1,3})\s*[.)]\s`)
+)
+
+// isPatent reports whether a paper's Markdown is a converted patent: one
+// whose claims carry claim markers (R5.15).
+func isPatent(markdown string) bool {
+	return claimMarkerRe.MatchString(markdown)
+}
+
+// claimDependencies maps the number of each claim marked in a patent's
+// Markdown to the claim it depends on, zero for an independent claim.
+func claimDependencies(markdown string) map[int]int {
+	deps := make(map[int]int)
+	for _, m := range claimMarkerRe.FindAllStringSubmatch(markdown, -1) {
+		n, _ := strconv.Atoi(m[1])
+		deps[n], _ = strconv.Atoi(m[2])
+	}
+	return deps
+}
+
+// linkPatentClaims numbers the claim items of a patent (R5.15). A claim
+// takes its number from the backend's claim_number or else from the number
+// its text begins with; only numbers marked in the Markdown count. The
+// markers, not the backend, decide which claim a claim depends on.
+func linkPatentClaims(items []types.KnowledgeItem, markdown string) {
+	deps := claimDependencies(markdown)
+	for i := range items {
+		item := &items[i]
+		if item.Type != types.ItemClaim {
+			continue
+		}
+		n := 0
+		if item.PatentClaim != nil {
+			n = item.PatentClaim.Number
+		} else if m := leadingClaimNumberRe.FindStringSubmatch(item.Content); m != nil {
+			n, _ = strconv.Atoi(m[1])
+		}
+		dependsOn, marked := deps[n]
+		if !marked {
+			item.PatentClaim = nil
+			continue
+		}
+		item.PatentClaim = &types.PatentClaim{Number: n, DependsOn: dependsOn, Independent: dependsOn == 0}
+	}
+}
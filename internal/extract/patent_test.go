@@ -0,0 +1,122 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// patentPromptedBackend is a mock backend that records the prompt each
+// section was extracted with.
+type patentPromptedBackend struct {
+	mockAIBackend
+	patent  *Prompt
+	prompts []string
+}
+
+func (p *patentPromptedBackend) patentPrompt() *Prompt { return p.patent }
+
+func (p *patentPromptedBackend) Extract(ctx context.Context, section string) (AIResponse, error) {
+	p.prompts = append(p.prompts, DefaultPromptName)
+	return p.mockAIBackend.Extract(ctx, section)
+}
+
+func (p *patentPromptedBackend) extractWith(ctx context.Context, prompt *Prompt, section string) (AIResponse, error) {
+	p.prompts = append(p.prompts, prompt.Name)
+	return p.mockAIBackend.Extract(ctx, section)
+}
+
+const testPatent = `# Neural network compression
+
+## Abstract
+
+A method of compressing neural networks.
+
+## Claims
+
+<!-- claim 1 -->
+
+1. A method of compressing a neural network, comprising pruning weights below a threshold.
+
+<!-- claim 2 depends on 1 -->
+
+2. The method of claim 1, wherein the threshold is learned.
+`
+
+func TestExtractPaperPatentClaims(t *testing.T) {
+	papersDir := t.TempDir()
+	mdPath := filepath.Join(papersDir, "US1234567B2.md")
+	os.WriteFile(mdPath, []byte(testPatent), 0o644)
+
+	backend := &patentPromptedBackend{
+		mockAIBackend: mockAIBackend{responses: map[string]AIResponse{
+			"## Claims": {Items: []AIResponseItem{
+				// The backend numbers claim 1 but misreads its dependency;
+				// claim 2 is numbered only by its text.
+				{Type: "claim", Content: "1. A method of compressing a neural network, comprising pruning weights below a threshold.", Confidence: 0.95, ClaimNumber: 1, DependsOn: 2},
+				{Type: "claim", Content: "2. The method of claim 1, wherein the threshold is learned.", Confidence: 0.95},
+				{Type: "claim", Content: "7. A claim the patent does not have.", Confidence: 0.5, ClaimNumber: 7},
+			}},
+		}},
+		patent: DefaultPatentPrompt(),
+	}
+	result, err := ExtractPaper(context.Background(), backend, "US1234567B2", mdPath, testConfig(papersDir, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.PromptVersion != DefaultPatentPromptName {
+		t.Errorf("PromptVersion = %q, want %q", result.PromptVersion, DefaultPatentPromptName)
+	}
+	for _, p := range backend.prompts {
+		if p != DefaultPatentPromptName {
+			t.Errorf("sections extracted with %v, want only the patent prompt", backend.prompts)
+			break
+		}
+	}
+
+	want := []*types.PatentClaim{
+		{Number: 1, Independent: true},
+		{Number: 2, DependsOn: 1},
+		nil,
+	}
+	if len(result.Items) != len(want) {
+		t.Fatalf("got %d items, want %d", len(result.Items), len(want))
+	}
+	for i, w := range want {
+		got := result.Items[i].PatentClaim
+		if (got == nil) != (w == nil) || got != nil && *got != *w {
+			t.Errorf("item %d claim = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestExtractPaperNotPatent(t *testing.T) {
+	papersDir := t.TempDir()
+	mdPath := filepath.Join(papersDir, "p.md")
+	os.WriteFile(mdPath, []byte("## Methods\n\nWe prune weights.\n"), 0o644)
+
+	backend := &patentPromptedBackend{patent: DefaultPatentPrompt()}
+	result, err := ExtractPaper(context.Background(), backend, "p", mdPath, testConfig(papersDir, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.PromptVersion != DefaultPromptName || strings.Join(backend.prompts, ",") != DefaultPromptName {
+		t.Errorf("paper extracted with %v recording %q, want the extraction prompt", backend.prompts, result.PromptVersion)
+	}
+}
+
+func TestDefaultPatentPrompt(t *testing.T) {
+	out, err := DefaultPatentPrompt().render("## Claims\n\n<!-- claim 1 -->\n\n1. A method.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "claim_number") || !strings.Contains(out, "1. A method.") {
+		t.Errorf("rendered patent prompt lacks claim numbers or the section:\n%s", out)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// progressFile is the checkpoint of a batch extraction, kept in
+// knowledgeDir/extracted/ until the batch finishes cleanly.
+const progressFile = ".progress.yaml"
+
+// Progress is the checkpoint of a batch extraction (R6.10). It records
+// the papers the batch finished and the responses to each section of the
+// papers it had not, so a batch stopped by an interrupt or an API outage
+// resumes where it left off, mid-paper included, even with the response
+// cache off. Finished papers are skipped on resume as unchanged. A checkpoint applies only to the same papers, backend,
+// model, and prompt.
+type Progress struct {
+	Backend  string                           `yaml:"backend"`
+	Model    string                           `yaml:"model"`
+	Prompt   string                           `yaml:"prompt"`
+	Started  time.Time                        `yaml:"started"`
+	Updated  time.Time                        `yaml:"updated"`
+	Papers   []string                         `yaml:"papers"`
+	Done     []string                         `yaml:"done,omitempty"`
+	Sections map[string]map[string]AIResponse `yaml:"sections,omitempty"`
+}
+
+// batchProgress is the checkpoint of the running batch, shared by its
+// workers. A nil batchProgress records nothing.
+type batchProgress struct {
+	mu   sync.Mutex
+	path string
+	p    Progress
+}
+
+// loadProgress returns the checkpoint for a batch of paperIDs extracted
+// with cfg and prompt, resuming the one in knowledgeDir/extracted/ when it
+// was written for the same batch, and whether it did.
+func loadProgress(cfg types.ExtractionConfig, prompt *Prompt, paperIDs []string) (*batchProgress, bool) {
+	path := filepath.Join(cfg.KnowledgeDir, extractedDir, progressFile)
+	papers := slices.Sorted(slices.Values(paperIDs))
+	fresh := Progress{Backend: cfg.Backend, Model: cfg.Model, Prompt: prompt.hash(), Started: time.Now(), Papers: papers}
+
+	var old Progress
+	data, err := os.ReadFile(path)
+	resumed := err == nil && yaml.Unmarshal(data, &old) == nil &&
+		old.Backend == fresh.Backend && old.Model == fresh.Model && old.Prompt == fresh.Prompt &&
+		slices.Equal(old.Papers, papers)
+	if !resumed {
+		old = fresh
+	}
+	return &batchProgress{path: path, p: old}, resumed
+}
+
+// doneCount returns how many papers the checkpoint records as done.
+func (bp *batchProgress) doneCount() int {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	return len(bp.p.Done)
+}
+
+// paperProgress is the part of a checkpoint holding one paper's section
+// responses, with the get and put of a responseCache. A nil paperProgress
+// records nothing.
+type paperProgress struct {
+	bp      *batchProgress
+	paperID string
+}
+
+// paper returns the checkpoint of paperID's sections.
+func (bp *batchProgress) paper(paperID string) *paperProgress {
+	if bp == nil {
+		return nil
+	}
+	return &paperProgress{bp: bp, paperID: paperID}
+}
+
+// get returns the response to chunk recorded before an interruption.
+func (pp *paperProgress) get(chunk string) (AIResponse, bool) {
+	if pp == nil {
+		return AIResponse{}, false
+	}
+	pp.bp.mu.Lock()
+	defer pp.bp.mu.Unlock()
+	resp, ok := pp.bp.p.Sections[pp.paperID][chunkKey(chunk)]
+	return resp, ok
+}
+
+// put records the response to chunk.
+func (pp *paperProgress) put(chunk string, resp AIResponse) {
+	if pp == nil {
+		return
+	}
+	bp := pp.bp
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	if bp.p.Sections == nil {
+		bp.p.Sections = make(map[string]map[string]AIResponse)
+	}
+	if bp.p.Sections[pp.paperID] == nil {
+		bp.p.Sections[pp.paperID] = make(map[string]AIResponse)
+	}
+	bp.p.Sections[pp.paperID][chunkKey(chunk)] = resp
+	bp.save()
+}
+
+// finish records paperID as done, dropping its section responses.
+func (bp *batchProgress) finish(paperID string) {
+	if bp == nil {
+		return
+	}
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	if !slices.Contains(bp.p.Done, paperID) {
+		bp.p.Done = append(bp.p.Done, paperID)
+	}
+	delete(bp.p.Sections, paperID)
+	bp.save()
+}
+
+// save writes the checkpoint atomically. Failing to checkpoint does not
+// fail the extraction, so errors are dropped. The caller holds bp.mu.
+func (bp *batchProgress) save() {
+	bp.p.Updated = time.Now()
+	data, err := yaml.Marshal(&bp.p)
+	if err != nil {
+		return
+	}
+	tmp := bp.path + ".tmp"
+	if os.WriteFile(tmp, data, 0o644) != nil || os.Rename(tmp, bp.path) != nil {
+		os.Remove(tmp)
+	}
+}
+
+// remove deletes the checkpoint of a batch that finished cleanly.
+func (bp *batchProgress) remove() {
+	os.Remove(bp.path)
+}
+
+// chunkKey returns the hex SHA-256 of a section chunk.
+func chunkKey(chunk string) string {
+	sum := sha256.Sum256([]byte(chunk))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,95 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// interruptingBackend cancels the batch on its stopAt-th call, as Ctrl-C
+// would, and answers every other call.
+type interruptingBackend struct {
+	calls  int
+	stopAt int
+	cancel context.CancelFunc
+}
+
+func (b *interruptingBackend) Extract(ctx context.Context, _ string) (AIResponse, error) {
+	b.calls++
+	if b.calls == b.stopAt {
+		b.cancel()
+		return AIResponse{}, ctx.Err()
+	}
+	return AIResponse{Items: []AIResponseItem{{Type: "claim", Content: "A claim.", Confidence: 0.9}}}, nil
+}
+
+func TestExtractAllResumesFromProgress(t *testing.T) {
+	tmpDir := t.TempDir()
+	papersDir := filepath.Join(tmpDir, "papers")
+	knowledgeDir := filepath.Join(tmpDir, "knowledge")
+	os.MkdirAll(filepath.Join(papersDir, markdownDir), 0o755)
+	for _, id := range []string{"a", "b", "c"} {
+		writeSections(t, filepath.Join(papersDir, markdownDir, id+".md"), 3)
+	}
+	cfg := testConfig(papersDir, knowledgeDir)
+	cfg.NoCache = true
+	progressPath := filepath.Join(knowledgeDir, extractedDir, progressFile)
+
+	// Interrupt the batch on the second section of paper b.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var buf strings.Builder
+	summary, err := ExtractAll(ctx, &interruptingBackend{stopAt: 5, cancel: cancel}, cfg, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Extracted != 1 || summary.Failed != 1 || strings.Contains(buf.String(), "extracting c") {
+		t.Fatalf("interrupted run: %+v\n%s", summary, buf.String())
+	}
+	if _, err := os.Stat(progressPath); err != nil {
+		t.Fatalf("no checkpoint after the interruption: %v", err)
+	}
+
+	// The rerun sends only b's last two sections and all of c, even
+	// without the response cache.
+	backend := &interruptingBackend{}
+	buf.Reset()
+	summary, err = ExtractAll(context.Background(), backend, cfg, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "resuming batch") || !strings.Contains(buf.String(), "1 of 3 papers done") {
+		t.Errorf("rerun did not report resuming:\n%s", buf.String())
+	}
+	if backend.calls != 5 || summary.Extracted != 2 || summary.Skipped != 1 {
+		t.Errorf("rerun: %d calls, %+v; want 5 calls, 2 extracted, 1 skipped\n%s", backend.calls, summary, buf.String())
+	}
+	if _, err := os.Stat(progressPath); !os.IsNotExist(err) {
+		t.Errorf("checkpoint left after a clean batch: %v", err)
+	}
+}
+
+func TestLoadProgressOtherBatch(t *testing.T) {
+	knowledgeDir := t.TempDir()
+	os.MkdirAll(filepath.Join(knowledgeDir, extractedDir), 0o755)
+	cfg := testConfig("", knowledgeDir)
+
+	bp, _ := loadProgress(cfg, nil, []string{"b", "a"})
+	bp.finish("a")
+
+	if bp, resumed := loadProgress(cfg, nil, []string{"a", "b"}); !resumed || bp.doneCount() != 1 {
+		t.Errorf("same batch: resumed %v", resumed)
+	}
+	other := cfg
+	other.Model = "other-model"
+	if _, resumed := loadProgress(other, nil, []string{"a", "b"}); resumed {
+		t.Error("resumed a checkpoint written for another model")
+	}
+	if _, resumed := loadProgress(cfg, nil, []string{"a", "b", "c"}); resumed {
+		t.Error("resumed a checkpoint written for other papers")
+	}
+}
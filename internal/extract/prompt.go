@@ -5,17 +5,31 @@ package extract
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"text/template"
 )
 
-// extractionPromptTmpl is the prompt template sent to the Claude API for each
-// section of Markdown. It instructs the model to extract typed knowledge items
-// with provenance. Per prd003-extraction R5.2.
-var extractionPromptTmpl = template.Must(template.New("extraction").Parse(`You are a research knowledge extraction system. Analyze the following section of an academic paper and extract typed knowledge items.
+// DefaultPromptName is the prompt version recorded for the built-in
+// extraction prompt.
+const DefaultPromptName = "extract-v2"
+
+// promptsDir is the directory under knowledgeDir holding extraction prompt
+// templates.
+const promptsDir = "prompts"
+
+// extractionPrompt is the built-in prompt template sent to the AI backend for
+// each section of Markdown. It instructs the model to extract typed knowledge
+// items with provenance. Per prd003-extraction R5.2.
+const extractionPrompt = `You are a research knowledge extraction system. Analyze the following section of an academic paper and extract typed knowledge items.
 
 For each item, identify:
 - type: one of "claim", "method", "definition", "result"
@@ -28,15 +42,226 @@ For each item, identify:
 - page: the page number if available (0 if unknown)
 - confidence: a float between 0.0 and 1.0 indicating how certain you are about the type classification and item boundaries
 - tags: one or more lowercase, hyphenated topic labels drawn from the paper's vocabulary (e.g. "transformer", "attention-mechanism", "benchmark")
+- metric: for a result reporting a number, an object with "name" (the lowercase metric, e.g. "accuracy", "bleu", "f1", "perplexity"), "value" (the number as reported, without the unit), "unit" ("%", "ms", or "" if none), "dataset" (the benchmark or dataset, "" if none), "baseline" (the method compared against, "" if none), and "baseline_value" (the baseline's number, omitted if not given); when a result reports several numbers, describe the main one for the paper's method; omit metric for other items
+
+Display equations appear as LaTeX in $$ blocks, each preceded by a marker such as <!-- equation 3 --> giving its number in the paper. When an item states or depends on an equation, keep the equation's LaTeX verbatim in the content and refer to it by that number.
+
+Patents are divided into Abstract, Drawings, Description, and Claims sections. Each claim is preceded by a marker such as <!-- claim 1 -->, or <!-- claim 2 depends on 1 --> for a dependent claim. Extract each claim as one item of type "claim" whose content is the claim's full text verbatim, beginning with its number.
 
-Respond with a JSON object containing an "items" array. Each element must have all fields listed above. Do not include any text outside the JSON object.
+Respond with a JSON object containing an "items" array. Each element must have all fields listed above except metric, which only results have. Do not include any text outside the JSON object.
 
 Example response:
-{"items": [{"type": "claim", "content": "Attention mechanisms improve translation quality by 2 BLEU points.", "section": "Results", "page": 5, "confidence": 0.92, "tags": ["attention-mechanism", "machine-translation", "bleu"]}]}
+{"items": [{"type": "claim", "content": "Attention mechanisms improve translation quality.", "section": "Results", "page": 5, "confidence": 0.9, "tags": ["attention-mechanism", "machine-translation"]}, {"type": "result", "content": "Our model reaches 28.4 BLEU on WMT 2014 English-German, 2.0 above the best previous model.", "section": "Results", "page": 5, "confidence": 0.92, "tags": ["machine-translation", "bleu"], "metric": {"name": "bleu", "value": 28.4, "unit": "", "dataset": "WMT 2014 English-German", "baseline": "best previous model", "baseline_value": 26.4}}]}
 
 Paper section:
 {{.Section}}
-`))
+`
+
+// DefaultPatentPromptName is the prompt version recorded for the built-in
+// patent extraction prompt.
+const DefaultPatentPromptName = "extract-patent-v1"
+
+// patentExtractionPrompt is the built-in prompt template for the sections
+// of a patent. It extracts each claim as one item numbered after its
+// <!-- claim N --> marker. Per prd003-extraction R5.15.
+const patentExtractionPrompt = `You are a patent knowledge extraction system. Analyze the following section of a patent and extract typed knowledge items.
+
+Patents are divided into Abstract, Drawings, Description, and Claims sections. In the Claims section each claim is preceded by a marker such as <!-- claim 1 -->, or <!-- claim 2 depends on 1 --> for a claim that depends on claim 1.
+
+For each item, identify:
+- type: one of "claim", "method", "definition", "result"
+  - claim: in the Claims section, one patent claim; elsewhere, a factual assertion about the invention or the prior art
+  - method: a process, apparatus, or embodiment the description discloses
+  - definition: a term the patent defines, as in "as used herein, X means ..."
+  - result: an advantage or measured effect the patent states for the invention
+- content: for a patent claim, the claim's full text verbatim, beginning with its number; for other items, the original text (preserve exact language, do not paraphrase)
+- section: the section heading where the item appears
+- page: the page number if available (0 if unknown)
+- confidence: a float between 0.0 and 1.0 indicating how certain you are about the type classification and item boundaries
+- tags: one or more lowercase, hyphenated topic labels; for a claim, the elements it recites (e.g. "memory-controller", "neural-network")
+- claim_number: for a patent claim, its number from the marker
+- depends_on: for a dependent claim, the number of the claim it depends on; 0 for an independent claim
+
+Extract every claim in the Claims section as its own item, independent and dependent claims alike, and do not split or merge claims.
+
+Respond with a JSON object containing an "items" array. Each element must have type, content, section, page, confidence, and tags; patent claims also have claim_number and depends_on. Do not include any text outside the JSON object.
+
+Example response:
+{"items": [{"type": "claim", "content": "1. A method of compressing a neural network, comprising: pruning weights below a threshold; and quantizing the remaining weights.", "section": "Claims", "page": 14, "confidence": 0.95, "tags": ["neural-network", "pruning", "quantization"], "claim_number": 1, "depends_on": 0}, {"type": "claim", "content": "2. The method of claim 1, wherein the threshold is learned during training.", "section": "Claims", "page": 14, "confidence": 0.95, "tags": ["pruning", "learned-threshold"], "claim_number": 2, "depends_on": 1}]}
+
+Patent section:
+{{.Section}}
+`
+
+// defaultPatentPrompt is the built-in patent extraction prompt.
+var defaultPatentPrompt = func() *Prompt {
+	p, err := ParsePrompt(DefaultPatentPromptName, patentExtractionPrompt)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}()
+
+// DefaultPatentPrompt returns the built-in patent extraction prompt.
+func DefaultPatentPrompt() *Prompt {
+	return defaultPatentPrompt
+}
+
+// DefaultPatentPromptText returns the built-in patent prompt template.
+func DefaultPatentPromptText() string {
+	return patentExtractionPrompt
+}
+
+// defaultPrompt is the built-in extraction prompt.
+var defaultPrompt = func() *Prompt {
+	p, err := ParsePrompt(DefaultPromptName, extractionPrompt)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}()
+
+// Prompt is an extraction prompt template, rendered with the text of one
+// section as {{.Section}}. Its Name is the prompt version recorded in each
+// extraction result, so changing to a prompt with another name re-extracts
+// papers (R6.8). A nil Prompt is the built-in one.
+type Prompt struct {
+	Name string
+	text string
+	tmpl *template.Template
+}
+
+// DefaultPrompt returns the built-in extraction prompt.
+func DefaultPrompt() *Prompt {
+	return defaultPrompt
+}
+
+// DefaultPromptText returns the built-in prompt template, as a starting
+// point for a template file.
+func DefaultPromptText() string {
+	return extractionPrompt
+}
+
+// ParsePrompt parses a prompt template named name. The template must
+// include the section text with {{.Section}}.
+func ParsePrompt(name, text string) (*Prompt, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing prompt %s: %w", name, err)
+	}
+	p := &Prompt{Name: name, text: text, tmpl: tmpl}
+	const probe = "\x00section\x00"
+	out, err := p.execute(probe)
+	if err != nil {
+		return nil, fmt.Errorf("rendering prompt %s: %w", name, err)
+	}
+	if !strings.Contains(out, probe) {
+		return nil, fmt.Errorf("prompt %s does not include the section text ({{.Section}})", name)
+	}
+	return p, nil
+}
+
+// LoadPrompt loads the extraction prompt ref names: the path of a
+// template file, or the name of one in knowledgeDir/prompts/ with or
+// without its .tmpl extension. The prompt's name is the file name without
+// the extension. An empty ref is the built-in prompt.
+func LoadPrompt(knowledgeDir, ref string) (*Prompt, error) {
+	if ref == "" {
+		return defaultPrompt, nil
+	}
+	path := ref
+	if !strings.ContainsRune(ref, filepath.Separator) && !strings.ContainsRune(ref, '/') {
+		path = filepath.Join(knowledgeDir, promptsDir, ref)
+		if filepath.Ext(ref) == "" {
+			path += ".tmpl"
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading prompt: %w", err)
+	}
+	return ParsePrompt(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), string(data))
+}
+
+// render renders the prompt, or the built-in one if p is nil, for the
+// given section.
+func (p *Prompt) render(section string) (string, error) {
+	if p == nil {
+		p = defaultPrompt
+	}
+	return p.execute(section)
+}
+
+// execute executes the prompt template with the given section.
+func (p *Prompt) execute(section string) (string, error) {
+	var buf bytes.Buffer
+	if err := p.tmpl.Execute(&buf, struct{ Section string }{Section: section}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// hash identifies the prompts sent with this template, including the
+// Ollama system prompt, so editing either invalidates cached responses.
+func (p *Prompt) hash() string {
+	if p == nil {
+		p = defaultPrompt
+	}
+	sum := sha256.Sum256([]byte(p.text + "\x00" + ollamaSystemPrompt))
+	return hex.EncodeToString(sum[:6])
+}
+
+// promptBackend is an AI backend that renders a configurable prompt.
+type promptBackend interface {
+	extractionPrompt() *Prompt
+}
+
+// patentPromptBackend is an AI backend that also renders a configurable
+// patent prompt for the sections of patents (R5.15).
+type patentPromptBackend interface {
+	patentPrompt() *Prompt
+	extractWith(ctx context.Context, prompt *Prompt, section string) (AIResponse, error)
+}
+
+// promptOf returns the prompt backend renders: the built-in prompt unless
+// it was given another.
+func promptOf(backend AIBackend) *Prompt {
+	if b, ok := backend.(promptBackend); ok && b.extractionPrompt() != nil {
+		return b.extractionPrompt()
+	}
+	return defaultPrompt
+}
+
+// patentPromptOf returns the prompt backend renders for patents: the
+// built-in patent prompt unless it was given another.
+func patentPromptOf(backend AIBackend) *Prompt {
+	if b, ok := backend.(patentPromptBackend); ok && b.patentPrompt() != nil {
+		return b.patentPrompt()
+	}
+	return defaultPatentPrompt
+}
+
+// patentBackend extracts the sections of a patent with the patent prompt
+// in place of the prompt its backend renders by default.
+type patentBackend struct {
+	backend patentPromptBackend
+	prompt  *Prompt
+}
+
+// withPatentPrompt returns backend rendering its patent prompt. A backend
+// without one is returned as is.
+func withPatentPrompt(backend AIBackend) AIBackend {
+	b, ok := backend.(patentPromptBackend)
+	if !ok {
+		return backend
+	}
+	return patentBackend{backend: b, prompt: patentPromptOf(backend)}
+}
+
+// Extract extracts section with the patent prompt.
+func (p patentBackend) Extract(ctx context.Context, section string) (AIResponse, error) {
+	return p.backend.extractWith(ctx, p.prompt, section)
+}
 
 // claudeAPIURL is the Claude API endpoint. Package-level var for test substitution.
 var claudeAPIURL = "https://api.anthropic.com/v1/messages"
@@ -47,6 +272,20 @@ type ClaudeBackend struct {
 	APIKey string
 	Model  string
 	Client *http.Client
+	// Prompt is the extraction prompt (default DefaultPrompt).
+	Prompt *Prompt
+	// PatentPrompt is the extraction prompt for patents (default
+	// DefaultPatentPrompt).
+	PatentPrompt *Prompt
+
+	inputTokens  atomic.Int64
+	outputTokens atomic.Int64
+}
+
+// Usage returns the input and output tokens reported by the Claude API
+// across all successful calls made through this backend.
+func (c *ClaudeBackend) Usage() (input, output int64) {
+	return c.inputTokens.Load(), c.outputTokens.Load()
 }
 
 // claudeRequest is the request body for the Claude Messages API.
@@ -65,6 +304,13 @@ type claudeMessage struct {
 // claudeResponse is the response body from the Claude Messages API.
 type claudeResponse struct {
 	Content []claudeContent `json:"content"`
+	Usage   claudeUsage     `json:"usage"`
+}
+
+// claudeUsage reports the tokens consumed by one Claude API call.
+type claudeUsage struct {
+	InputTokens  int64 `json:"input_tokens"`
+	OutputTokens int64 `json:"output_tokens"`
 }
 
 // claudeContent is a content block in the Claude API response.
@@ -73,16 +319,40 @@ type claudeContent struct {
 	Text string `json:"text"`
 }
 
+func (c *ClaudeBackend) extractionPrompt() *Prompt { return c.Prompt }
+func (c *ClaudeBackend) patentPrompt() *Prompt     { return c.PatentPrompt }
+
 // Extract calls the Claude API with the extraction prompt for one section (R5.2).
 func (c *ClaudeBackend) Extract(ctx context.Context, section string) (AIResponse, error) {
-	prompt, err := renderPrompt(section)
+	return c.extractWith(ctx, c.Prompt, section)
+}
+
+// extractWith calls the Claude API with prompt for one section.
+func (c *ClaudeBackend) extractWith(ctx context.Context, p *Prompt, section string) (AIResponse, error) {
+	prompt, err := p.render(section)
 	if err != nil {
 		return AIResponse{}, fmt.Errorf("rendering prompt: %w", err)
 	}
 
+	text, err := c.Complete(ctx, prompt, 4096)
+	if err != nil {
+		return AIResponse{}, err
+	}
+
+	var aiResp AIResponse
+	if err := json.Unmarshal([]byte(text), &aiResp); err != nil {
+		return AIResponse{}, fmt.Errorf("parsing AI response JSON: %w", err)
+	}
+	return aiResp, nil
+}
+
+// Complete sends prompt to the Claude API as a single user message and
+// returns the text of the reply. Other stages use it for their own
+// prompts, such as translation during conversion.
+func (c *ClaudeBackend) Complete(ctx context.Context, prompt string, maxTokens int) (string, error) {
 	reqBody := claudeRequest{
 		Model:     c.Model,
-		MaxTokens: 4096,
+		MaxTokens: maxTokens,
 		Messages: []claudeMessage{
 			{Role: "user", Content: prompt},
 		},
@@ -90,12 +360,12 @@ func (c *ClaudeBackend) Extract(ctx context.Context, section string) (AIResponse
 
 	bodyBytes, err := json.Marshal(reqBody)
 	if err != nil {
-		return AIResponse{}, fmt.Errorf("marshaling request: %w", err)
+		return "", fmt.Errorf("marshaling request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, claudeAPIURL, bytes.NewReader(bodyBytes))
 	if err != nil {
-		return AIResponse{}, fmt.Errorf("creating request: %w", err)
+		return "", fmt.Errorf("creating request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("x-api-key", c.APIKey)
@@ -108,43 +378,31 @@ func (c *ClaudeBackend) Extract(ctx context.Context, section string) (AIResponse
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return AIResponse{}, fmt.Errorf("calling Claude API: %w", err)
+		return "", fmt.Errorf("calling Claude API: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return AIResponse{}, fmt.Errorf("Claude API returned %d: %s", resp.StatusCode, string(body))
+		return "", fmt.Errorf("Claude API returned %d: %s", resp.StatusCode, string(body))
 	}
 
 	var cResp claudeResponse
 	if err := json.NewDecoder(resp.Body).Decode(&cResp); err != nil {
-		return AIResponse{}, fmt.Errorf("decoding Claude response: %w", err)
+		return "", fmt.Errorf("decoding Claude response: %w", err)
 	}
+	c.inputTokens.Add(cResp.Usage.InputTokens)
+	c.outputTokens.Add(cResp.Usage.OutputTokens)
 
 	if len(cResp.Content) == 0 {
-		return AIResponse{}, fmt.Errorf("Claude API returned empty content")
+		return "", fmt.Errorf("Claude API returned empty content")
 	}
 
-	var aiResp AIResponse
 	for _, block := range cResp.Content {
-		if block.Type != "text" {
-			continue
-		}
-		if err := json.Unmarshal([]byte(block.Text), &aiResp); err != nil {
-			return AIResponse{}, fmt.Errorf("parsing AI response JSON: %w", err)
+		if block.Type == "text" {
+			return block.Text, nil
 		}
-		return aiResp, nil
 	}
 
-	return AIResponse{}, fmt.Errorf("no text content in Claude API response")
-}
-
-// renderPrompt executes the extraction prompt template with the given section.
-func renderPrompt(section string) (string, error) {
-	var buf bytes.Buffer
-	if err := extractionPromptTmpl.Execute(&buf, struct{ Section string }{Section: section}); err != nil {
-		return "", err
-	}
-	return buf.String(), nil
+	return "", fmt.Errorf("no text content in Claude API response")
 }
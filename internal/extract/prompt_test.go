@@ -0,0 +1,115 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestLoadPrompt(t *testing.T) {
+	knowledgeDir := t.TempDir()
+	dir := filepath.Join(knowledgeDir, promptsDir)
+	os.MkdirAll(dir, 0o755)
+	os.WriteFile(filepath.Join(dir, "extract-v3.tmpl"), []byte("Extract items as JSON.\n\n{{.Section}}\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "nosection.tmpl"), []byte("Extract items as JSON.\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "broken.tmpl"), []byte("{{.Section"), 0o644)
+
+	p, err := LoadPrompt(knowledgeDir, "")
+	if err != nil || p != DefaultPrompt() || p.Name != DefaultPromptName {
+		t.Errorf("empty ref: got %v, %v; want the built-in prompt", p, err)
+	}
+
+	for _, ref := range []string{"extract-v3", "extract-v3.tmpl", filepath.Join(dir, "extract-v3.tmpl")} {
+		p, err := LoadPrompt(knowledgeDir, ref)
+		if err != nil {
+			t.Fatalf("%s: %v", ref, err)
+		}
+		if p.Name != "extract-v3" {
+			t.Errorf("%s: name %q, want extract-v3", ref, p.Name)
+		}
+		out, err := p.render("## Intro\n\nText.")
+		if err != nil || out != "Extract items as JSON.\n\n## Intro\n\nText.\n" {
+			t.Errorf("%s: rendered %q, %v", ref, out, err)
+		}
+	}
+
+	for _, ref := range []string{"nosection", "broken", "missing"} {
+		if _, err := LoadPrompt(knowledgeDir, ref); err == nil {
+			t.Errorf("%s: expected an error", ref)
+		}
+	}
+}
+
+// promptedBackend is a mock backend rendering a configured prompt.
+type promptedBackend struct {
+	mockAIBackend
+	prompt *Prompt
+}
+
+func (p *promptedBackend) extractionPrompt() *Prompt { return p.prompt }
+
+func TestExtractAllReextractsOnPromptChange(t *testing.T) {
+	tmpDir := t.TempDir()
+	papersDir := filepath.Join(tmpDir, "papers")
+	knowledgeDir := filepath.Join(tmpDir, "knowledge")
+	os.MkdirAll(filepath.Join(papersDir, markdownDir), 0o755)
+	os.MkdirAll(filepath.Join(knowledgeDir, extractedDir), 0o755)
+	for _, id := range []string{"versioned", "unversioned"} {
+		os.WriteFile(filepath.Join(papersDir, markdownDir, id+".md"), []byte("## Intro\n\nText of "+id+"."), 0o644)
+	}
+	cfg := testConfig(papersDir, knowledgeDir)
+	ctx := context.Background()
+
+	// First run records the built-in prompt version.
+	backend := &mockAIBackend{}
+	if _, err := ExtractAll(ctx, backend, cfg, &strings.Builder{}); err != nil {
+		t.Fatal(err)
+	}
+	outPath := filepath.Join(knowledgeDir, extractedDir, "versioned-items.yaml")
+	if got, _ := recordedState(outPath); got != DefaultPromptName {
+		t.Fatalf("recorded prompt %q, want %q", got, DefaultPromptName)
+	}
+
+	// A result written before prompt versions were recorded is kept.
+	unversioned := filepath.Join(knowledgeDir, extractedDir, "unversioned-items.yaml")
+	data, _ := yaml.Marshal(&types.ExtractionResult{PaperID: "unversioned"})
+	os.WriteFile(unversioned, data, 0o644)
+	future := time.Now().Add(time.Hour)
+	for _, path := range []string{outPath, unversioned} {
+		os.Chtimes(path, future, future)
+	}
+
+	summary, err := ExtractAll(ctx, backend, cfg, &strings.Builder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Skipped != 2 {
+		t.Errorf("same prompt: skipped %d, want 2", summary.Skipped)
+	}
+
+	// A new prompt version re-extracts the paper extracted with the old one.
+	v3, err := ParsePrompt("extract-v3", "v3 {{.Section}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf strings.Builder
+	summary, err = ExtractAll(ctx, &promptedBackend{prompt: v3}, cfg, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Extracted != 1 || summary.Skipped != 1 {
+		t.Errorf("new prompt: extracted %d, skipped %d; want 1, 1\n%s", summary.Extracted, summary.Skipped, buf.String())
+	}
+	if got, _ := recordedState(outPath); got != "extract-v3" {
+		t.Errorf("recorded prompt %q after re-extraction, want extract-v3", got)
+	}
+}
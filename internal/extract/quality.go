@@ -0,0 +1,70 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pdiddy/research-engine/internal/convert"
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// frontmatter reads the key: value lines of a Markdown file's YAML
+// frontmatter, as the convert stage writes them. It returns nil when the
+// file has none.
+func frontmatter(mdPath string) map[string]string {
+	f, err := os.Open(mdPath)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	if !sc.Scan() || sc.Text() != "---" {
+		return nil
+	}
+	fields := make(map[string]string)
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "---" {
+			break
+		}
+		key, value, _ := strings.Cut(line, ":")
+		fields[key] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return fields
+}
+
+// conversionQuality reads the quality level and score the convert stage
+// wrote into a Markdown file's frontmatter. ok is false when the file has
+// no quality recorded, as with Markdown converted before scoring existed.
+func conversionQuality(fields map[string]string) (level types.QualityLevel, score float64, ok bool) {
+	level = types.QualityLevel(fields["quality"])
+	score, err := strconv.ParseFloat(fields["quality_score"], 64)
+	return level, score, level != "" && err == nil
+}
+
+// CheckQuality applies the conversion quality gate (R5.8) to one paper's
+// Markdown. It returns a warning for a poor conversion or an untranslated
+// non-English paper (R5.10), and skip when the conversion scores below
+// minScore. Papers without a recorded quality pass.
+func CheckQuality(mdPath string, minScore float64) (warning string, skip bool) {
+	fields := frontmatter(mdPath)
+	var warnings []string
+	if level, score, ok := conversionQuality(fields); ok {
+		if minScore > 0 && score < minScore {
+			return fmt.Sprintf("conversion quality %.2f is below --min-quality %.2f", score, minScore), true
+		}
+		if level == types.QualityPoor {
+			warnings = append(warnings, fmt.Sprintf("conversion quality is poor (%.2f); items may be unreliable", score))
+		}
+	}
+	if lang := fields["language"]; lang != "" && lang != "en" && fields["translated"] != "true" {
+		warnings = append(warnings, fmt.Sprintf("paper is in %s and was not translated; re-convert with --translate", convert.LanguageName(lang)))
+	}
+	return strings.Join(warnings, "; "), false
+}
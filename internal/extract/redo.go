@@ -0,0 +1,294 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+const (
+	redoDir          = "redo"
+	redoManifestFile = "manifest.yaml"
+	redoPreviousDir  = "previous"
+)
+
+// RedoOptions bounds one invocation of RedoAll. Zero values are unlimited.
+type RedoOptions struct {
+	// MaxTokens stops the run before the next paper once the backend has
+	// used this many input plus output tokens. It needs a backend that
+	// reports usage, such as ClaudeBackend or OpenAIBackend.
+	MaxTokens int64
+
+	// MaxPapers stops the run after extracting this many papers.
+	MaxPapers int
+}
+
+// RedoManifest is the checkpoint of a full-corpus re-extraction, stored in
+// knowledgeDir/redo/manifest.yaml next to the staged results.
+type RedoManifest struct {
+	Model     string            `yaml:"model"`
+	Prompt    string            `yaml:"prompt,omitempty"`
+	Started   time.Time         `yaml:"started"`
+	Updated   time.Time         `yaml:"updated"`
+	Completed []string          `yaml:"completed"`
+	Failed    map[string]string `yaml:"failed,omitempty"`
+}
+
+// RedoSummary reports the outcome of one RedoAll invocation.
+type RedoSummary struct {
+	// Extracted counts papers extracted by this invocation; Resumed counts
+	// papers already staged by an earlier one.
+	Extracted int
+	Resumed   int
+	Failed    int
+
+	// Remaining is the number of papers still to extract.
+	Remaining int
+
+	// Stopped explains why the run ended before the corpus was done.
+	Stopped string
+
+	// Swapped reports whether the staged results replaced the live ones.
+	Swapped bool
+}
+
+// usageReporter is implemented by backends that count tokens.
+type usageReporter interface {
+	Usage() (input, output int64)
+}
+
+// RedoAll re-extracts every paper in papersDir/markdown/ with cfg.Model,
+// writing results to knowledgeDir/redo/extracted/ instead of the live
+// extracted/ directory. A manifest records each completed paper, so an
+// interrupted or budget-limited run resumes where it stopped; failed papers
+// are retried on the next run. When every paper has been extracted, the
+// staged directory is swapped in and the previous results are kept in
+// knowledgeDir/redo/previous/. Extractions for papers that no longer have
+// Markdown are carried over unchanged.
+//
+// A run in progress is tied to its model and prompt: starting RedoAll with a
+// different one is an error until the staging area is removed.
+func RedoAll(ctx context.Context, backend AIBackend, cfg types.ExtractionConfig, opts RedoOptions, w io.Writer) (RedoSummary, error) {
+	mdDir := filepath.Join(cfg.PapersDir, markdownDir)
+	stageDir := filepath.Join(cfg.KnowledgeDir, redoDir, extractedDir)
+	manifestPath := filepath.Join(cfg.KnowledgeDir, redoDir, redoManifestFile)
+
+	entries, err := os.ReadDir(mdDir)
+	if err != nil {
+		return RedoSummary{}, fmt.Errorf("reading markdown directory %s: %w", mdDir, err)
+	}
+	var paperIDs []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			paperIDs = append(paperIDs, strings.TrimSuffix(entry.Name(), ".md"))
+		}
+	}
+
+	manifest, err := loadRedoManifest(manifestPath)
+	if err != nil {
+		return RedoSummary{}, err
+	}
+	prompt := promptOf(backend).Name
+	if manifest == nil {
+		manifest = &RedoManifest{Model: cfg.Model, Prompt: prompt, Started: time.Now()}
+	} else if manifest.Model != cfg.Model {
+		return RedoSummary{}, fmt.Errorf("a re-extraction with model %s is in progress in %s: rerun with that model or remove the directory to start over",
+			manifest.Model, filepath.Dir(manifestPath))
+	} else if manifest.Prompt != "" && manifest.Prompt != prompt {
+		return RedoSummary{}, fmt.Errorf("a re-extraction with prompt %s is in progress in %s: rerun with that prompt or remove the directory to start over",
+			manifest.Prompt, filepath.Dir(manifestPath))
+	} else {
+		fmt.Fprintf(w, "resuming re-extraction with %s (%d papers staged)\n", manifest.Model, len(manifest.Completed))
+	}
+	if manifest.Failed == nil {
+		manifest.Failed = make(map[string]string)
+	}
+
+	if err := os.MkdirAll(stageDir, 0o755); err != nil {
+		return RedoSummary{}, fmt.Errorf("creating staging directory: %w", err)
+	}
+
+	usage, _ := backend.(usageReporter)
+	var summary RedoSummary
+	for _, paperID := range paperIDs {
+		outPath := filepath.Join(stageDir, paperID+"-items.yaml")
+		if slices.Contains(manifest.Completed, paperID) {
+			if _, err := os.Stat(outPath); err == nil {
+				summary.Resumed++
+				continue
+			}
+		}
+
+		if summary.Stopped = redoStopReason(ctx, opts, summary, usage); summary.Stopped != "" {
+			break
+		}
+
+		fmt.Fprintf(w, "extracting %s\n", paperID)
+		result, err := ExtractPaper(ctx, backend, paperID, filepath.Join(mdDir, paperID+".md"), cfg)
+		if err == nil {
+			err = writeResultAtomic(outPath, result)
+		}
+		if err != nil {
+			fmt.Fprintf(w, "failed  %s: %v\n", paperID, err)
+			manifest.Failed[paperID] = err.Error()
+			summary.Failed++
+		} else {
+			if result.Partial {
+				fmt.Fprintf(w, "partial %s (%d items, %d sections failed)\n", paperID, len(result.Items), len(result.Errors))
+			} else {
+				fmt.Fprintf(w, "extracted %s (%d items)\n", paperID, len(result.Items))
+			}
+			delete(manifest.Failed, paperID)
+			if !slices.Contains(manifest.Completed, paperID) {
+				manifest.Completed = append(manifest.Completed, paperID)
+			}
+			summary.Extracted++
+		}
+
+		if err := saveRedoManifest(manifestPath, manifest); err != nil {
+			return summary, err
+		}
+	}
+
+	summary.Remaining = len(paperIDs) - summary.Extracted - summary.Resumed
+	if summary.Remaining > 0 {
+		return summary, nil
+	}
+
+	if err := swapStaged(cfg.KnowledgeDir); err != nil {
+		return summary, err
+	}
+	summary.Swapped = true
+	fmt.Fprintf(w, "swapped in %d re-extracted papers; previous results kept in %s\n",
+		len(paperIDs), filepath.Join(cfg.KnowledgeDir, redoDir, redoPreviousDir))
+	return summary, nil
+}
+
+// redoStopReason returns why the run must stop before the next paper, or
+// "" to continue.
+func redoStopReason(ctx context.Context, opts RedoOptions, summary RedoSummary, usage usageReporter) string {
+	if ctx.Err() != nil {
+		return "interrupted"
+	}
+	if opts.MaxPapers > 0 && summary.Extracted+summary.Failed >= opts.MaxPapers {
+		return fmt.Sprintf("paper limit of %d reached", opts.MaxPapers)
+	}
+	if opts.MaxTokens > 0 && usage != nil {
+		if in, out := usage.Usage(); in+out >= opts.MaxTokens {
+			return fmt.Sprintf("token budget of %d reached", opts.MaxTokens)
+		}
+	}
+	return ""
+}
+
+// swapStaged replaces knowledgeDir/extracted/ with the staged results. Live
+// extractions missing from the stage are copied in first, keeping their
+// modification times so the knowledge base does not re-index them. The
+// old directory moves to redo/previous/; if the final rename fails it is
+// moved back.
+func swapStaged(knowledgeDir string) error {
+	live := filepath.Join(knowledgeDir, extractedDir)
+	stage := filepath.Join(knowledgeDir, redoDir, extractedDir)
+	previous := filepath.Join(knowledgeDir, redoDir, redoPreviousDir)
+
+	entries, err := os.ReadDir(live)
+	hadLive := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading %s: %w", live, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		dst := filepath.Join(stage, entry.Name())
+		if _, err := os.Stat(dst); err == nil {
+			continue
+		}
+		if err := copyPreservingModTime(filepath.Join(live, entry.Name()), dst); err != nil {
+			return err
+		}
+	}
+
+	if err := os.RemoveAll(previous); err != nil {
+		return fmt.Errorf("removing old backup %s: %w", previous, err)
+	}
+	if hadLive {
+		if err := os.Rename(live, previous); err != nil {
+			return fmt.Errorf("moving %s aside: %w", live, err)
+		}
+	}
+	if err := os.Rename(stage, live); err != nil {
+		if hadLive {
+			os.Rename(previous, live)
+		}
+		return fmt.Errorf("swapping in %s: %w", stage, err)
+	}
+	if err := os.Remove(filepath.Join(knowledgeDir, redoDir, redoManifestFile)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing manifest: %w", err)
+	}
+	return nil
+}
+
+func copyPreservingModTime(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", src, err)
+	}
+	if err := os.WriteFile(dst, data, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", dst, err)
+	}
+	return os.Chtimes(dst, info.ModTime(), info.ModTime())
+}
+
+// writeResultAtomic writes the result through a temporary file so an
+// interrupted run never leaves a partial file that looks complete.
+func writeResultAtomic(path string, result *types.ExtractionResult) error {
+	tmp := path + ".tmp"
+	if err := writeResult(tmp, result); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func loadRedoManifest(path string) (*RedoManifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var m RedoManifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+func saveRedoManifest(path string, m *RedoManifest) error {
+	m.Updated = time.Now()
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshaling manifest: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,172 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// redoFixture creates papers/markdown with the given papers and a live
+// extracted/ directory holding an old result for "orphan", a paper whose
+// Markdown is gone. Each paper's text differs, so no paper's sections are
+// answered from another's cached responses.
+func redoFixture(t *testing.T, papers ...string) (papersDir, knowledgeDir string) {
+	t.Helper()
+	tmp := t.TempDir()
+	papersDir = filepath.Join(tmp, "papers")
+	knowledgeDir = filepath.Join(tmp, "knowledge")
+	mdDir := filepath.Join(papersDir, markdownDir)
+	liveDir := filepath.Join(knowledgeDir, extractedDir)
+	for _, dir := range []string{mdDir, liveDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, p := range papers {
+		if err := os.WriteFile(filepath.Join(mdDir, p+".md"), []byte("## Intro\n\nText of "+p+"."), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(liveDir, p+"-items.yaml"), []byte("paper_id: "+p+"\nitems: []\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(liveDir, "orphan-items.yaml"), []byte("paper_id: orphan\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return papersDir, knowledgeDir
+}
+
+func redoBackend() *mockAIBackend {
+	return &mockAIBackend{responses: map[string]AIResponse{
+		"## Intro": {Items: []AIResponseItem{
+			{Type: "claim", Content: "New claim.", Section: "Intro", Page: 1, Confidence: 0.9},
+		}},
+	}}
+}
+
+func TestRedoAllSwapsWhenComplete(t *testing.T) {
+	papersDir, knowledgeDir := redoFixture(t, "p1", "p2")
+	cfg := testConfig(papersDir, knowledgeDir)
+
+	var buf strings.Builder
+	summary, err := RedoAll(context.Background(), redoBackend(), cfg, RedoOptions{}, &buf)
+	if err != nil {
+		t.Fatalf("RedoAll: %v", err)
+	}
+	if summary.Extracted != 2 || summary.Remaining != 0 || !summary.Swapped {
+		t.Fatalf("summary = %+v", summary)
+	}
+
+	live := filepath.Join(knowledgeDir, extractedDir)
+	data, err := os.ReadFile(filepath.Join(live, "p1-items.yaml"))
+	if err != nil || !strings.Contains(string(data), "New claim.") {
+		t.Errorf("live p1 not replaced: %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(live, "orphan-items.yaml")); err != nil {
+		t.Errorf("orphan extraction not carried over: %v", err)
+	}
+	prev, err := os.ReadFile(filepath.Join(knowledgeDir, redoDir, redoPreviousDir, "p1-items.yaml"))
+	if err != nil || strings.Contains(string(prev), "New claim.") {
+		t.Errorf("previous p1 not kept: %q, %v", prev, err)
+	}
+	if _, err := os.Stat(filepath.Join(knowledgeDir, redoDir, redoManifestFile)); !os.IsNotExist(err) {
+		t.Errorf("manifest should be removed after swap, stat err = %v", err)
+	}
+}
+
+func TestRedoAllResumesAfterLimit(t *testing.T) {
+	papersDir, knowledgeDir := redoFixture(t, "p1", "p2", "p3")
+	cfg := testConfig(papersDir, knowledgeDir)
+	ctx := context.Background()
+
+	backend := redoBackend()
+	var buf strings.Builder
+	summary, err := RedoAll(ctx, backend, cfg, RedoOptions{MaxPapers: 2}, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Extracted != 2 || summary.Remaining != 1 || summary.Swapped || summary.Stopped == "" {
+		t.Fatalf("first run summary = %+v", summary)
+	}
+	live, _ := os.ReadFile(filepath.Join(knowledgeDir, extractedDir, "p1-items.yaml"))
+	if strings.Contains(string(live), "New claim.") {
+		t.Error("live results changed before the run completed")
+	}
+
+	other := cfg
+	other.Model = "other-model"
+	if _, err := RedoAll(ctx, backend, other, RedoOptions{}, &buf); err == nil {
+		t.Error("expected error resuming with a different model")
+	}
+
+	calls := backend.calls
+	summary, err = RedoAll(ctx, backend, cfg, RedoOptions{}, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Resumed != 2 || summary.Extracted != 1 || !summary.Swapped {
+		t.Errorf("second run summary = %+v", summary)
+	}
+	if got := backend.calls - calls; got != 1 {
+		t.Errorf("second run made %d backend calls, want 1", got)
+	}
+}
+
+// usageBackend reports a fixed token count per call.
+type usageBackend struct {
+	mockAIBackend
+	perCall int64
+}
+
+func (u *usageBackend) Usage() (int64, int64) {
+	return int64(u.calls) * u.perCall, 0
+}
+
+func TestRedoAllStopsAtTokenBudget(t *testing.T) {
+	papersDir, knowledgeDir := redoFixture(t, "p1", "p2", "p3")
+	cfg := testConfig(papersDir, knowledgeDir)
+
+	backend := &usageBackend{mockAIBackend: *redoBackend(), perCall: 100}
+	var buf strings.Builder
+	summary, err := RedoAll(context.Background(), backend, cfg, RedoOptions{MaxTokens: 150}, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Extracted != 2 || !strings.Contains(summary.Stopped, "token budget") {
+		t.Errorf("summary = %+v", summary)
+	}
+}
+
+func TestRedoAllFailureBlocksSwap(t *testing.T) {
+	papersDir, knowledgeDir := redoFixture(t, "p1")
+	cfg := testConfig(papersDir, knowledgeDir)
+	cfg.MaxRetries = 1
+
+	var buf strings.Builder
+	summary, err := RedoAll(context.Background(), &mockAIBackend{err: errors.New("overloaded")}, cfg, RedoOptions{}, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Failed != 1 || summary.Swapped {
+		t.Fatalf("summary = %+v", summary)
+	}
+
+	m, err := loadRedoManifest(filepath.Join(knowledgeDir, redoDir, redoManifestFile))
+	if err != nil || m == nil || m.Failed["p1"] == "" {
+		t.Fatalf("manifest = %+v, %v", m, err)
+	}
+
+	// The failed paper is retried on the next run.
+	summary, err = RedoAll(context.Background(), redoBackend(), cfg, RedoOptions{}, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Extracted != 1 || !summary.Swapped {
+		t.Errorf("retry summary = %+v", summary)
+	}
+}
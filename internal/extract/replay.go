@@ -0,0 +1,198 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// ErrNoRecording is returned by a ReplayBackend without a backend for a
+// call it has no recording of.
+var ErrNoRecording = errors.New("no recorded response")
+
+// ReplayBackend answers extraction and summary calls from recordings in
+// Dir, one YAML file per call keyed by the prompt text and the section,
+// so extraction runs are reproducible and prompt or parsing changes can be
+// regression-tested without API calls (R6.11). A call without a recording
+// goes to Backend and its response is recorded; with no Backend it fails
+// with ErrNoRecording, as in CI.
+//
+// Unlike the response cache, recordings do not depend on the backend or
+// model, are meant to be committed with the papers they were recorded
+// from, and record only responses, never errors.
+type ReplayBackend struct {
+	// Dir holds the recordings.
+	Dir string
+
+	// Backend answers and is recorded for calls without a recording; nil
+	// replays only.
+	Backend AIBackend
+
+	// Prompt and PatentPrompt are the prompts recordings are keyed by
+	// when there is no Backend to take them from (default the built-in
+	// prompts).
+	Prompt       *Prompt
+	PatentPrompt *Prompt
+
+	replayed atomic.Int64
+	recorded atomic.Int64
+}
+
+// replayRecording is one recorded call. Prompt and Section identify the
+// call for a reader of the file; the key is in its name.
+type replayRecording struct {
+	Prompt   string              `yaml:"prompt,omitempty"`
+	Section  string              `yaml:"section"`
+	Response *AIResponse         `yaml:"response,omitempty"`
+	Summary  *types.PaperSummary `yaml:"summary,omitempty"`
+}
+
+// Counts returns how many calls were answered from recordings and how many
+// were recorded.
+func (r *ReplayBackend) Counts() (replayed, recorded int64) {
+	return r.replayed.Load(), r.recorded.Load()
+}
+
+// Usage reports the tokens Backend spent, if it counts them.
+func (r *ReplayBackend) Usage() (input, output int64) {
+	if u, ok := r.Backend.(usageReporter); ok {
+		return u.Usage()
+	}
+	return 0, 0
+}
+
+func (r *ReplayBackend) extractionPrompt() *Prompt {
+	if r.Backend != nil {
+		return promptOf(r.Backend)
+	}
+	return r.Prompt
+}
+
+func (r *ReplayBackend) patentPrompt() *Prompt {
+	if r.Backend != nil {
+		return patentPromptOf(r.Backend)
+	}
+	return r.PatentPrompt
+}
+
+// Extract replays or records the response to section under the extraction
+// prompt.
+func (r *ReplayBackend) Extract(ctx context.Context, section string) (AIResponse, error) {
+	return r.extractWith(ctx, promptOf(r), section)
+}
+
+// extractWith replays or records the response to section under prompt.
+func (r *ReplayBackend) extractWith(ctx context.Context, prompt *Prompt, section string) (AIResponse, error) {
+	path := r.path(prompt.hash() + "\x00" + section)
+	var rec replayRecording
+	if r.load(path, &rec) && rec.Response != nil {
+		r.replayed.Add(1)
+		return *rec.Response, nil
+	}
+	if r.Backend == nil {
+		return AIResponse{}, fmt.Errorf("%w for section %q in %s", ErrNoRecording, firstLine(section), r.Dir)
+	}
+
+	var resp AIResponse
+	var err error
+	if b, ok := r.Backend.(patentPromptBackend); ok {
+		resp, err = b.extractWith(ctx, prompt, section)
+	} else {
+		resp, err = r.Backend.Extract(ctx, section)
+	}
+	if err != nil {
+		return AIResponse{}, err
+	}
+	if err := r.store(path, replayRecording{Prompt: prompt.Name, Section: firstLine(section), Response: &resp}); err != nil {
+		return AIResponse{}, err
+	}
+	return resp, nil
+}
+
+// Summarize replays or records the summary of a paper with summary input
+// paper.
+func (r *ReplayBackend) Summarize(ctx context.Context, paper string) (types.PaperSummary, error) {
+	path := r.path(summaryCacheKey + paper)
+	var rec replayRecording
+	if r.load(path, &rec) && rec.Summary != nil {
+		r.replayed.Add(1)
+		return *rec.Summary, nil
+	}
+	if r.Backend == nil {
+		return types.PaperSummary{}, fmt.Errorf("%w for the summary in %s", ErrNoRecording, r.Dir)
+	}
+	s, ok := r.Backend.(Summarizer)
+	if !ok {
+		return types.PaperSummary{}, fmt.Errorf("the AI backend cannot summarize papers")
+	}
+	summary, err := s.Summarize(ctx, paper)
+	if err != nil {
+		return types.PaperSummary{}, err
+	}
+	if err := r.store(path, replayRecording{Section: summarySection, Summary: &summary}); err != nil {
+		return types.PaperSummary{}, err
+	}
+	return summary, nil
+}
+
+// path returns the recording file of a call with key.
+func (r *ReplayBackend) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(r.Dir, hex.EncodeToString(sum[:12])+".yaml")
+}
+
+// load reads the recording at path into rec.
+func (r *ReplayBackend) load(path string, rec *replayRecording) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return yaml.Unmarshal(data, rec) == nil
+}
+
+// store writes rec to path. Unlike a cache miss, a recording that cannot
+// be written fails the call, since the run would not be reproducible.
+func (r *ReplayBackend) store(path string, rec replayRecording) error {
+	data, err := yaml.Marshal(&rec)
+	if err != nil {
+		return fmt.Errorf("marshaling recording: %w", err)
+	}
+	if err := os.MkdirAll(r.Dir, 0o755); err != nil {
+		return fmt.Errorf("creating recording directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(r.Dir, ".record-*")
+	if err != nil {
+		return fmt.Errorf("writing recording: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing recording: %w", err)
+	}
+	r.recorded.Add(1)
+	return nil
+}
+
+// firstLine returns the first line of a section, its heading.
+func firstLine(section string) string {
+	line, _, _ := strings.Cut(section, "\n")
+	return line
+}
@@ -0,0 +1,95 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func replayPaper(t *testing.T) (papersDir, mdPath string) {
+	t.Helper()
+	papersDir = t.TempDir()
+	mdPath = filepath.Join(papersDir, "p.md")
+	os.WriteFile(mdPath, []byte("## Abstract\n\nWe study attention.\n\n## Methods\n\nWe approximate softmax.\n"), 0o644)
+	return papersDir, mdPath
+}
+
+func TestReplayBackendRecordsThenReplays(t *testing.T) {
+	papersDir, mdPath := replayPaper(t)
+	dir := filepath.Join(t.TempDir(), "replay")
+	cfg := testConfig(papersDir, "")
+	cfg.Summarize = true
+	ctx := context.Background()
+
+	live := newSummarizingBackend(0)
+	recorder := &ReplayBackend{Dir: dir, Backend: live}
+	recorded, err := ExtractPaper(ctx, recorder, "p", mdPath, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed, n := recorder.Counts(); replayed != 0 || n != 3 {
+		t.Errorf("first run: %d replayed, %d recorded; want 0 and 3 (two sections and the summary)", replayed, n)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if len(files) != 3 {
+		t.Errorf("got %d recordings, want 3", len(files))
+	}
+
+	// Without a backend every call is answered from the recordings, and
+	// the result is the same.
+	player := &ReplayBackend{Dir: dir}
+	replayed, err := ExtractPaper(ctx, player, "p", mdPath, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, rec := player.Counts(); n != 3 || rec != 0 {
+		t.Errorf("replay: %d replayed, %d recorded; want 3 and 0", n, rec)
+	}
+	if len(replayed.Items) != 1 || replayed.Items[0].ID != recorded.Items[0].ID || *replayed.Summary != *recorded.Summary {
+		t.Errorf("replayed result %+v differs from the recorded %+v", replayed, recorded)
+	}
+
+	// With the live backend again nothing new is sent.
+	again := newSummarizingBackend(0)
+	if _, err := ExtractPaper(ctx, &ReplayBackend{Dir: dir, Backend: again}, "p", mdPath, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if again.calls != 0 || again.summaries != 0 {
+		t.Errorf("rerun made %d section and %d summary calls, want none", again.calls, again.summaries)
+	}
+}
+
+func TestReplayBackendMissingRecording(t *testing.T) {
+	papersDir, mdPath := replayPaper(t)
+	dir := t.TempDir()
+	ctx := context.Background()
+	if _, err := ExtractPaper(ctx, &ReplayBackend{Dir: dir, Backend: &mockAIBackend{}}, "p", mdPath, testConfig(papersDir, "")); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new prompt misses every recording.
+	v3, err := ParsePrompt("extract-v3", "v3 {{.Section}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ExtractPaper(ctx, &ReplayBackend{Dir: dir, Prompt: v3}, "p", mdPath, testConfig(papersDir, ""))
+	if !errors.Is(err, ErrNoRecording) || !strings.Contains(err.Error(), "## Abstract") {
+		t.Errorf("err = %v, want ErrNoRecording naming the section", err)
+	}
+}
+
+func TestReplayBackendDoesNotRecordErrors(t *testing.T) {
+	dir := t.TempDir()
+	r := &ReplayBackend{Dir: dir, Backend: &mockAIBackend{err: errors.New("overloaded")}}
+	if _, err := r.Extract(context.Background(), "## Methods\n\nText."); err == nil {
+		t.Fatal("expected the backend's error")
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.yaml")); len(files) != 0 {
+		t.Errorf("recorded %v after an error", files)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"regexp"
+	"strings"
+)
+
+// backMatterHeadingPattern matches the headings of reference lists and
+// acknowledgments, which hold no knowledge items.
+var backMatterHeadingPattern = regexp.MustCompile(`(?i)^(?:[\d.]+\s*)?(?:references|bibliography|works\s+cited|literature\s+cited|acknowledge?ments?)\b`)
+
+// isBackMatter reports whether sec is back matter that extraction skips
+// by default: references, acknowledgments, and the funding and
+// competing-interest statements read without the AI backend (R8).
+func isBackMatter(sec section) bool {
+	for _, h := range []string{sec.heading, sec.parent} {
+		if backMatterHeadingPattern.MatchString(h) || fundingHeadingPattern.MatchString(h) || coiHeadingPattern.MatchString(h) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectSection reports whether sec is sent to the AI backend (R5.14).
+// A section is skipped when its heading, or the ## heading it falls under,
+// contains one of skip; otherwise, when include is given, it is sent only
+// if one of include matches. Without include, back matter is skipped.
+// Matching ignores case.
+func selectSection(sec section, include, skip []string) bool {
+	if headingMatches(sec, skip) {
+		return false
+	}
+	if len(include) > 0 {
+		return headingMatches(sec, include)
+	}
+	return !isBackMatter(sec)
+}
+
+// headingMatches reports whether sec's heading or parent contains one of
+// names, ignoring case.
+func headingMatches(sec section, names []string) bool {
+	heading, parent := strings.ToLower(sec.heading), strings.ToLower(sec.parent)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if strings.Contains(heading, name) || parent != "" && strings.Contains(parent, name) {
+			return true
+		}
+	}
+	return false
+}
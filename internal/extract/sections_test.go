@@ -0,0 +1,104 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestChunkByHeadingsParent(t *testing.T) {
+	secs := chunkByHeadings("## 3 Methods\n\nA.\n\n### 3.1 Training\n\nB.\n\n## 4 Results\n\nC.\n")
+	want := [][2]string{{"3 Methods", ""}, {"3.1 Training", "3 Methods"}, {"4 Results", ""}}
+	if len(secs) != len(want) {
+		t.Fatalf("got %d sections, want %d", len(secs), len(want))
+	}
+	for i, w := range want {
+		if secs[i].heading != w[0] || secs[i].parent != w[1] {
+			t.Errorf("section %d: heading %q parent %q, want %q %q", i, secs[i].heading, secs[i].parent, w[0], w[1])
+		}
+	}
+}
+
+func TestSelectSection(t *testing.T) {
+	methods := section{heading: "3 Methods"}
+	training := section{heading: "3.1 Training", parent: "3 Methods"}
+	related := section{heading: "2 Related Work"}
+	refs := section{heading: "References"}
+	ack := section{heading: "Acknowledgements"}
+	funding := section{heading: "Funding"}
+
+	tests := []struct {
+		name          string
+		include, skip []string
+		want          []section
+	}{
+		{"default skips back matter", nil, nil, []section{methods, training, related}},
+		{"include matches subsections", []string{"methods"}, nil, []section{methods, training}},
+		{"skip", nil, []string{"Related Work"}, []section{methods, training}},
+		{"skip beats include", []string{"Methods"}, []string{"training"}, []section{methods}},
+		{"include names back matter", []string{"references"}, nil, []section{refs}},
+	}
+	for _, tt := range tests {
+		var got []section
+		for _, sec := range []section{methods, training, related, refs, ack, funding} {
+			if selectSection(sec, tt.include, tt.skip) {
+				got = append(got, sec)
+			}
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestExtractPaperSkipsSections(t *testing.T) {
+	mdPath := filepath.Join(t.TempDir(), "p.md")
+	md := "## Methods\n\nWe train.\n\n## Results\n\nIt works.\n\n## Acknowledgments\n\nThis work was supported by NSF Grant No. CCF-1918757.\n\n## References\n\n[1] A. Smith. A paper. 2020.\n"
+	if err := os.WriteFile(mdPath, []byte(md), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var sent []string
+	backend := &recordingBackend{sent: &sent}
+	cfg := testConfig("", "")
+	result, err := ExtractPaper(context.Background(), backend, "p", mdPath, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(sent, ","); got != "Methods,Results" {
+		t.Errorf("sent %q, want Methods,Results", got)
+	}
+	if len(result.Bibliography) != 1 || len(result.Grants) != 1 {
+		t.Errorf("back matter not read without the backend: %d references, grants %v", len(result.Bibliography), result.Grants)
+	}
+
+	sent = nil
+	cfg.Sections = []string{"results"}
+	if _, err := ExtractPaper(context.Background(), backend, "p", mdPath, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(sent, ","); got != "Results" {
+		t.Errorf("with --sections results, sent %q", got)
+	}
+}
+
+// recordingBackend records the heading of each section it is sent.
+type recordingBackend struct {
+	sent *[]string
+}
+
+func (r *recordingBackend) Extract(_ context.Context, chunk string) (AIResponse, error) {
+	*r.sent = append(*r.sent, strings.TrimPrefix(strings.SplitN(chunk, "\n", 2)[0], "## "))
+	return AIResponse{}, nil
+}
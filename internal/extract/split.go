@@ -0,0 +1,159 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultMaxSectionTokens is the estimated size above which a section
+	// is split before extraction. It leaves room in a model's context for
+	// the prompt, and keeps the items of one call within its reply limit.
+	DefaultMaxSectionTokens = 6000
+	// sectionOverlapTokens is how much of the end of one part of a split
+	// section is repeated at the start of the next, so statements that
+	// straddle the cut are seen whole.
+	sectionOverlapTokens = 200
+	// minSectionTokens is the smallest budget splitSection honours.
+	minSectionTokens = 500
+)
+
+// sentenceEnd matches the break after a sentence.
+var sentenceEnd = regexp.MustCompile(`[.!?]["')\]]*\s+`)
+
+// estimateTokens estimates the tokens text costs a model: about four
+// characters per token for English prose, and one per character for
+// scripts written without spaces.
+func estimateTokens(text string) int {
+	runes, wide := 0, 0
+	for _, r := range text {
+		runes++
+		if utf8.RuneLen(r) >= 3 {
+			wide++
+		}
+	}
+	return (runes-wide+3)/4 + wide
+}
+
+// splitSection splits a section whose body is estimated above maxTokens
+// into parts under the same heading (R5.13). Parts break at paragraphs,
+// then sentences, then words, and each part after the first repeats
+// about sectionOverlapTokens of the end of the one before. A section that
+// fits is returned as it is.
+func splitSection(sec section, maxTokens int) []section {
+	maxTokens = max(maxTokens, minSectionTokens)
+	if estimateTokens(sec.body) <= maxTokens {
+		return []section{sec}
+	}
+
+	var units []string
+	for _, para := range strings.Split(strings.TrimSpace(sec.body), "\n\n") {
+		units = append(units, splitUnit(strings.TrimSpace(para), maxTokens-sectionOverlapTokens)...)
+	}
+
+	// Each unit is counted with a token for the break that joins it.
+	var parts []section
+	var current []string
+	size := 0
+	for _, u := range units {
+		n := estimateTokens(u) + 1
+		if size > 0 && size+n > maxTokens {
+			parts = append(parts, sec.withBody(strings.Join(current, "\n\n")))
+			current, size = overlap(current), 0
+			for _, o := range current {
+				size += estimateTokens(o) + 1
+			}
+		}
+		current = append(current, u)
+		size += n
+	}
+	return append(parts, sec.withBody(strings.Join(current, "\n\n")))
+}
+
+// withBody returns a copy of sec holding body.
+func (sec section) withBody(body string) section {
+	sec.body = body
+	return sec
+}
+
+// splitUnit splits one paragraph over maxTokens at sentence ends, and a
+// sentence still over it at words.
+func splitUnit(para string, maxTokens int) []string {
+	if para == "" {
+		return nil
+	}
+	if estimateTokens(para) <= maxTokens {
+		return []string{para}
+	}
+
+	var out []string
+	var b strings.Builder
+	size := 0
+	add := func(piece string) {
+		n := estimateTokens(piece)
+		if size > 0 && size+n > maxTokens {
+			out = append(out, strings.TrimSpace(b.String()))
+			b.Reset()
+			size = 0
+		}
+		b.WriteString(piece)
+		size += n
+	}
+	for _, sentence := range sentences(para) {
+		if estimateTokens(sentence) <= maxTokens {
+			add(sentence)
+			continue
+		}
+		for _, w := range strings.Fields(sentence) {
+			add(w + " ")
+		}
+	}
+	if rest := strings.TrimSpace(b.String()); rest != "" {
+		out = append(out, rest)
+	}
+	return out
+}
+
+// sentences splits text after each sentence end, keeping the spacing.
+func sentences(text string) []string {
+	var out []string
+	last := 0
+	for _, m := range sentenceEnd.FindAllStringIndex(text, -1) {
+		out = append(out, text[last:m[1]])
+		last = m[1]
+	}
+	if last < len(text) {
+		out = append(out, text[last:])
+	}
+	return out
+}
+
+// overlap returns the end of a finished part, up to sectionOverlapTokens,
+// to open the next part: its last whole units, or the last sentences of a
+// unit too long to repeat.
+func overlap(units []string) []string {
+	var out []string
+	size := 0
+	for i := len(units) - 1; i >= 0; i-- {
+		n := estimateTokens(units[i])
+		if size+n <= sectionOverlapTokens {
+			out = append([]string{units[i]}, out...)
+			size += n
+			continue
+		}
+		ss := sentences(units[i])
+		j := len(ss)
+		for j > 0 && size+estimateTokens(ss[j-1]) <= sectionOverlapTokens {
+			j--
+			size += estimateTokens(ss[j])
+		}
+		if tail := strings.TrimSpace(strings.Join(ss[j:], "")); tail != "" {
+			out = append([]string{tail}, out...)
+		}
+		break
+	}
+	return out
+}
@@ -0,0 +1,133 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"abcd", 1},
+		{"abcde", 2},
+		{strings.Repeat("word ", 100), 125},
+		{"注意力机制", 5},
+	}
+	for _, tt := range tests {
+		if got := estimateTokens(tt.text); got != tt.want {
+			t.Errorf("estimateTokens(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+// facts returns n paragraphs, each stating one numbered fact.
+func facts(n int) string {
+	paras := make([]string, n)
+	for i := range paras {
+		paras[i] = fmt.Sprintf("Fact %03d holds for widgets in the general setting studied here.", i)
+	}
+	return strings.Join(paras, "\n\n")
+}
+
+func TestSplitSection(t *testing.T) {
+	small := section{heading: "Intro", body: facts(3), page: 2}
+	if parts := splitSection(small, 1000); len(parts) != 1 || parts[0] != small {
+		t.Errorf("small section split into %d parts", len(parts))
+	}
+
+	long := section{heading: "Survey", body: facts(300), page: 4}
+	parts := splitSection(long, 1000)
+	if len(parts) < 4 {
+		t.Fatalf("got %d parts, want at least 4", len(parts))
+	}
+	for i, p := range parts {
+		if p.heading != "Survey" || p.page != 4 {
+			t.Errorf("part %d: heading %q page %d, want the parent's", i, p.heading, p.page)
+		}
+		if n := estimateTokens(p.body); n > 1000 {
+			t.Errorf("part %d: %d tokens, over budget", i, n)
+		}
+		if i > 0 {
+			prev := strings.Split(parts[i-1].body, "\n\n")
+			if !strings.Contains(p.body, prev[len(prev)-1]) {
+				t.Errorf("part %d does not repeat the end of part %d", i, i-1)
+			}
+		}
+	}
+	if !strings.HasPrefix(parts[0].body, "Fact 000") || !strings.HasSuffix(parts[len(parts)-1].body, "Fact 299 holds for widgets in the general setting studied here.") {
+		t.Error("parts do not cover the whole section")
+	}
+}
+
+func TestSplitSectionLongParagraph(t *testing.T) {
+	// One paragraph of sentences, and one sentence with no end at all.
+	para := strings.ReplaceAll(facts(200), "\n\n", " ")
+	words := strings.TrimSpace(strings.Repeat("word ", 3000))
+	for _, body := range []string{para, words} {
+		parts := splitSection(section{heading: "H", body: body}, 600)
+		if len(parts) < 2 {
+			t.Fatalf("got %d parts, want several", len(parts))
+		}
+		for i, p := range parts {
+			if n := estimateTokens(p.body); n > 600 {
+				t.Errorf("part %d: %d tokens, over budget", i, n)
+			}
+		}
+	}
+}
+
+// factBackend returns one item per fact in the section it is sent.
+type factBackend struct {
+	calls int
+}
+
+var factPattern = regexp.MustCompile(`Fact \d+`)
+
+func (f *factBackend) Extract(_ context.Context, chunk string) (AIResponse, error) {
+	f.calls++
+	var resp AIResponse
+	for _, fact := range factPattern.FindAllString(chunk, -1) {
+		resp.Items = append(resp.Items, AIResponseItem{Type: "claim", Content: fact + " holds.", Confidence: 0.9})
+	}
+	return resp, nil
+}
+
+func TestExtractPaperSplitsLongSection(t *testing.T) {
+	mdPath := filepath.Join(t.TempDir(), "p.md")
+	md := "## Introduction\n\nFact 999 is short.\n\n## Survey\n\n" + facts(300) + "\n"
+	if err := os.WriteFile(mdPath, []byte(md), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	backend := &factBackend{}
+	cfg := testConfig("", "")
+	cfg.MaxSectionTokens = 1000
+	result, err := ExtractPaper(context.Background(), backend, "p", mdPath, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backend.calls < 5 {
+		t.Errorf("%d calls, want the survey split into several", backend.calls)
+	}
+	if len(result.Items) != 301 {
+		t.Fatalf("got %d items, want 301 with overlap duplicates removed", len(result.Items))
+	}
+	for _, item := range result.Items[1:] {
+		if item.Section != "Survey" {
+			t.Errorf("item %q in section %q, want Survey", item.Content, item.Section)
+		}
+	}
+	if result.Items[1].Content != "Fact 000 holds." || result.Items[300].Content != "Fact 299 holds." {
+		t.Errorf("items out of order: first %q, last %q", result.Items[1].Content, result.Items[300].Content)
+	}
+}
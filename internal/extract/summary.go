@@ -0,0 +1,152 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// summaryPrompt asks for a paper's summary; the paper's abstract and
+// extracted items replace %s.
+const summaryPrompt = `You summarize an academic paper for a researcher's knowledge base. From the paper's abstract and the knowledge items extracted from it below, write a summary of 3 to 5 sentences in three parts:
+
+- problem: the question or gap the paper addresses, in one sentence
+- approach: how the paper addresses it, in one or two sentences
+- findings: its main results, with their numbers where the items give them, in one or two sentences
+
+Use only what the text below states. Reply with one JSON object of the form {"problem": "...", "approach": "...", "findings": "..."} and nothing else.
+
+<paper>
+%s
+</paper>`
+
+// ollamaSummarySystemPrompt keeps small models to the summary format.
+const ollamaSummarySystemPrompt = `You summarize academic papers. Reply with one JSON object of the form {"problem": "...", "approach": "...", "findings": "..."} and nothing else: no explanation, no Markdown.`
+
+// ollamaSummaryFormat is the JSON schema Ollama constrains a summary to.
+var ollamaSummaryFormat = json.RawMessage(`{
+	"type": "object",
+	"properties": {
+		"problem": {"type": "string"},
+		"approach": {"type": "string"},
+		"findings": {"type": "string"}
+	},
+	"required": ["problem", "approach", "findings"]
+}`)
+
+// summarySection names a failed summary among a partial result's
+// section errors.
+const summarySection = "(summary)"
+
+// summaryMaxTokens bounds the reply to a summary prompt.
+const summaryMaxTokens = 1024
+
+// Summarizer is an AI backend that can summarize a paper from its abstract
+// and extracted items (R9.1).
+type Summarizer interface {
+	Summarize(ctx context.Context, paper string) (types.PaperSummary, error)
+}
+
+// Summarize asks Claude for the summary of a paper.
+func (c *ClaudeBackend) Summarize(ctx context.Context, paper string) (types.PaperSummary, error) {
+	text, err := c.Complete(ctx, fmt.Sprintf(summaryPrompt, paper), summaryMaxTokens)
+	if err != nil {
+		return types.PaperSummary{}, err
+	}
+	return parseSummary(text)
+}
+
+// Summarize asks the chat-completions API for the summary of a paper.
+func (o *OpenAIBackend) Summarize(ctx context.Context, paper string) (types.PaperSummary, error) {
+	text, err := o.Complete(ctx, fmt.Sprintf(summaryPrompt, paper), summaryMaxTokens)
+	if err != nil {
+		return types.PaperSummary{}, err
+	}
+	return parseSummary(text)
+}
+
+// Summarize asks Ollama for the summary of a paper, constrained to the
+// summary schema.
+func (o *OllamaBackend) Summarize(ctx context.Context, paper string) (types.PaperSummary, error) {
+	text, err := o.chat(ctx, ollamaSummarySystemPrompt, ollamaSummaryFormat, fmt.Sprintf(summaryPrompt, paper))
+	if err != nil {
+		return types.PaperSummary{}, err
+	}
+	return parseSummary(text)
+}
+
+// parseSummary reads a summary reply, tolerating JSON wrapped in prose or
+// a code fence. Every part must be present.
+func parseSummary(text string) (types.PaperSummary, error) {
+	if start, end := strings.Index(text, "{"), strings.LastIndex(text, "}"); start >= 0 && end > start {
+		text = text[start : end+1]
+	}
+	var s types.PaperSummary
+	if err := json.Unmarshal([]byte(text), &s); err != nil {
+		return types.PaperSummary{}, fmt.Errorf("parsing summary JSON: %w", err)
+	}
+	s.Problem = strings.TrimSpace(s.Problem)
+	s.Approach = strings.TrimSpace(s.Approach)
+	s.Findings = strings.TrimSpace(s.Findings)
+	if s.Problem == "" || s.Approach == "" || s.Findings == "" {
+		return types.PaperSummary{}, fmt.Errorf("summary lacks a problem, approach, or findings")
+	}
+	return s, nil
+}
+
+// summaryInput is the text a paper is summarized from: its abstract, if a
+// section is headed so, then its items in order, as many as fit in
+// maxTokens. Items are shorter and denser than the sections they came
+// from, so a long paper's summary costs one call of bounded size.
+func summaryInput(sections []section, items []types.KnowledgeItem, maxTokens int) string {
+	var b strings.Builder
+	for _, sec := range sections {
+		if strings.Contains(strings.ToLower(sec.heading), "abstract") {
+			fmt.Fprintf(&b, "Abstract:\n%s\n\n", strings.TrimSpace(sec.body))
+			break
+		}
+	}
+	b.WriteString("Extracted items:\n")
+	tokens := estimateTokens(b.String())
+	for _, item := range items {
+		line := fmt.Sprintf("- (%s) %s\n", item.Type, item.Content)
+		if tokens += estimateTokens(line); tokens > maxTokens {
+			break
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// summarizePaper returns the summary of a paper with summary input input,
+// from the cache or from one call to backend in a slot of calls, retried
+// with backoff (R9.1).
+func summarizePaper(ctx context.Context, backend AIBackend, calls callSlots, cache *responseCache, input string, maxRetries int) (types.PaperSummary, error) {
+	s, ok := backend.(Summarizer)
+	if !ok {
+		return types.PaperSummary{}, fmt.Errorf("summarizing: the AI backend cannot summarize papers")
+	}
+	if summary, ok := cache.getSummary(input); ok {
+		return summary, nil
+	}
+
+	select {
+	case calls <- struct{}{}:
+	case <-ctx.Done():
+		return types.PaperSummary{}, ctx.Err()
+	}
+	summary, err := withRetry(ctx, maxRetries, func() (types.PaperSummary, error) {
+		return s.Summarize(ctx, input)
+	})
+	<-calls
+	if err != nil {
+		return types.PaperSummary{}, fmt.Errorf("summarizing: %w", err)
+	}
+	cache.putSummary(input, summary)
+	return summary, nil
+}
@@ -0,0 +1,171 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// summarizingBackend is a mock backend that also summarizes papers,
+// failing the first failures summaries.
+type summarizingBackend struct {
+	mockAIBackend
+	summary   string
+	failures  int
+	summaries int
+	input     string
+}
+
+func (s *summarizingBackend) Summarize(_ context.Context, paper string) (types.PaperSummary, error) {
+	s.summaries++
+	s.input = paper
+	if s.summaries <= s.failures {
+		return types.PaperSummary{}, fmt.Errorf("transient error")
+	}
+	return parseSummary(s.summary)
+}
+
+const testSummary = "Here is the summary:\n```json\n" +
+	`{"problem": "Attention is quadratic.", "approach": "A linear approximation.", "findings": "Same accuracy at 3x speed."}` +
+	"\n```"
+
+func TestParseSummary(t *testing.T) {
+	s, err := parseSummary(testSummary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Problem != "Attention is quadratic." || s.Findings != "Same accuracy at 3x speed." {
+		t.Errorf("parseSummary = %+v", s)
+	}
+	if got := s.Text(); got != "Attention is quadratic. A linear approximation. Same accuracy at 3x speed." {
+		t.Errorf("Text() = %q", got)
+	}
+
+	if _, err := parseSummary(`{"problem": "P", "approach": "A"}`); err == nil {
+		t.Error("expected an error for a summary without findings")
+	}
+	if _, err := parseSummary("no JSON here"); err == nil {
+		t.Error("expected an error for a reply without JSON")
+	}
+}
+
+func TestSummaryInput(t *testing.T) {
+	secs := chunkByHeadings("## Abstract\n\nWe study attention.\n\n## Methods\n\nText.\n")
+	items := []types.KnowledgeItem{
+		{Type: types.ItemMethod, Content: "We approximate softmax linearly."},
+		{Type: types.ItemResult, Content: strings.Repeat("long result ", 200)},
+	}
+	input := summaryInput(secs, items, 100)
+	if !strings.Contains(input, "Abstract:\nWe study attention.") {
+		t.Errorf("input lacks the abstract:\n%s", input)
+	}
+	if !strings.Contains(input, "- (method) We approximate softmax linearly.") {
+		t.Errorf("input lacks the first item:\n%s", input)
+	}
+	if strings.Contains(input, "long result") {
+		t.Errorf("input kept an item over the token budget:\n%s", input)
+	}
+}
+
+func summaryPaper(t *testing.T) (papersDir, mdPath string) {
+	t.Helper()
+	papersDir = t.TempDir()
+	mdPath = filepath.Join(papersDir, "p.md")
+	os.WriteFile(mdPath, []byte("## Abstract\n\nWe study attention.\n\n## Methods\n\nWe approximate softmax.\n"), 0o644)
+	return papersDir, mdPath
+}
+
+func newSummarizingBackend(failures int) *summarizingBackend {
+	return &summarizingBackend{
+		mockAIBackend: mockAIBackend{responses: map[string]AIResponse{
+			"## Methods": {Items: []AIResponseItem{{Type: "method", Content: "We approximate softmax.", Confidence: 0.9}}},
+		}},
+		summary:  testSummary,
+		failures: failures,
+	}
+}
+
+func TestExtractPaperSummarizes(t *testing.T) {
+	papersDir, mdPath := summaryPaper(t)
+	knowledgeDir := t.TempDir()
+	cfg := testConfig(papersDir, knowledgeDir)
+	cfg.Summarize = true
+
+	backend := newSummarizingBackend(0)
+	result, err := ExtractPaper(context.Background(), backend, "p", mdPath, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Summary == nil || result.Summary.Approach != "A linear approximation." {
+		t.Fatalf("Summary = %+v, want the backend's summary", result.Summary)
+	}
+	if !strings.Contains(backend.input, "- (method) We approximate softmax.") {
+		t.Errorf("summary input lacks the extracted item:\n%s", backend.input)
+	}
+
+	// A rerun answers the summary from the cache.
+	again := newSummarizingBackend(0)
+	if _, err := ExtractPaper(context.Background(), again, "p", mdPath, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if again.summaries != 0 {
+		t.Errorf("rerun made %d summary calls, want 0", again.summaries)
+	}
+
+	// Editing the summary prompts invalidates the cached summary.
+	hash := summaryPromptHash
+	t.Cleanup(func() { summaryPromptHash = hash })
+	summaryPromptHash = "edited"
+	edited := newSummarizingBackend(0)
+	if _, err := ExtractPaper(context.Background(), edited, "p", mdPath, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if edited.summaries != 1 {
+		t.Errorf("rerun after a prompt edit made %d summary calls, want 1", edited.summaries)
+	}
+}
+
+func TestExtractPaperWithoutSummarize(t *testing.T) {
+	papersDir, mdPath := summaryPaper(t)
+	backend := newSummarizingBackend(0)
+	result, err := ExtractPaper(context.Background(), backend, "p", mdPath, testConfig(papersDir, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Summary != nil || backend.summaries != 0 {
+		t.Errorf("summary %+v after %d calls, want none without Summarize", result.Summary, backend.summaries)
+	}
+}
+
+func TestExtractPaperSummaryFailure(t *testing.T) {
+	papersDir, mdPath := summaryPaper(t)
+	cfg := testConfig(papersDir, "")
+	cfg.Summarize = true
+	cfg.MaxRetries = 1
+
+	if _, err := ExtractPaper(context.Background(), newSummarizingBackend(5), "p", mdPath, cfg); err == nil || !strings.Contains(err.Error(), "summarizing") {
+		t.Errorf("err = %v, want a summarizing error", err)
+	}
+
+	cfg.AllowPartial = true
+	result, err := ExtractPaper(context.Background(), newSummarizingBackend(5), "p", mdPath, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Partial || len(result.Items) != 1 || len(result.Errors) != 1 || result.Errors[0].Section != summarySection {
+		t.Errorf("result = partial %v, %d items, errors %+v; want partial with the items and a summary error", result.Partial, len(result.Items), result.Errors)
+	}
+
+	// A backend that cannot summarize fails the paper.
+	cfg.AllowPartial = false
+	if _, err := ExtractPaper(context.Background(), &newSummarizingBackend(0).mockAIBackend, "p", mdPath, cfg); err == nil {
+		t.Error("expected an error from a backend that cannot summarize")
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// tagsFile is the tag vocabulary under knowledgeDir.
+const tagsFile = "tags.yaml"
+
+// TagVocabulary is the controlled vocabulary of knowledge item tags read
+// from knowledgeDir/tags.yaml (R4.5). Each canonical tag lists the
+// synonyms mapped to it:
+//
+//	strict: false
+//	tags:
+//	  transformer: [transformer-model, transformer-architecture]
+//	  large-language-model: [llm]
+//
+// A nil vocabulary only formats and singularizes tags.
+type TagVocabulary struct {
+	// Strict drops tags that are neither canonical nor a synonym.
+	Strict bool `yaml:"strict"`
+
+	Tags map[string][]string `yaml:"tags"`
+
+	// canonical maps the normalized form of every canonical tag and
+	// synonym to its canonical tag.
+	canonical map[string]string
+}
+
+// LoadTagVocabulary reads knowledgeDir/tags.yaml. It returns nil and no
+// error when the file does not exist.
+func LoadTagVocabulary(knowledgeDir string) (*TagVocabulary, error) {
+	path := filepath.Join(knowledgeDir, tagsFile)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading tag vocabulary: %w", err)
+	}
+	v, err := ParseTagVocabulary(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return v, nil
+}
+
+// ParseTagVocabulary parses a tag vocabulary. A synonym may belong to
+// only one canonical tag.
+func ParseTagVocabulary(data []byte) (*TagVocabulary, error) {
+	var v TagVocabulary
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("parsing tag vocabulary: %w", err)
+	}
+	v.canonical = make(map[string]string)
+	add := func(name, canonical string) error {
+		key := baseTag(name)
+		if key == "" {
+			return nil
+		}
+		if other, ok := v.canonical[key]; ok && other != canonical {
+			return fmt.Errorf("tag %q maps to both %q and %q", name, other, canonical)
+		}
+		v.canonical[key] = canonical
+		return nil
+	}
+	for tag := range v.Tags {
+		if err := add(tag, formatTag(tag)); err != nil {
+			return nil, err
+		}
+	}
+	for tag, synonyms := range v.Tags {
+		for _, syn := range synonyms {
+			if err := add(syn, formatTag(tag)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &v, nil
+}
+
+// Normalize returns the tag as stored: lowercased and hyphenated, its
+// last word singularized, and mapped to its canonical tag when it is one
+// of the vocabulary's synonyms, so "Transformers" and "transformer-models"
+// both become "transformer". It returns "" for a tag to drop: an empty
+// one, or with a strict vocabulary, one outside it.
+func (v *TagVocabulary) Normalize(tag string) string {
+	key := baseTag(tag)
+	if key == "" || v == nil {
+		return key
+	}
+	if canonical, ok := v.canonical[key]; ok {
+		return canonical
+	}
+	if v.Strict {
+		return ""
+	}
+	return key
+}
+
+// normalizeTags normalizes tags, dropping empty and repeated ones.
+func (v *TagVocabulary) normalizeTags(tags []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		if t := v.Normalize(tag); t != "" && !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// baseTag formats tag and singularizes its last word.
+func baseTag(tag string) string {
+	t := formatTag(tag)
+	i := strings.LastIndexByte(t, '-')
+	return t[:i+1] + singularize(t[i+1:])
+}
+
+// formatTag lowercases tag and joins its words with single hyphens.
+// Letters, digits, and the "+", "#", and "." of names like "c++" and
+// "node.js" are kept; other characters separate words.
+func formatTag(tag string) string {
+	var b strings.Builder
+	sep := false
+	for _, r := range strings.ToLower(tag) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#' || r == '.' {
+			if sep && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			sep = false
+			b.WriteRune(r)
+			continue
+		}
+		sep = true
+	}
+	return strings.Trim(b.String(), ".")
+}
+
+// singularize returns the singular of an English plural noun, leaving
+// words it does not recognize as plural unchanged.
+func singularize(word string) string {
+	switch {
+	case len(word) <= 3 || singularExceptions[word]:
+		return word
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "ches"),
+		strings.HasSuffix(word, "shes"), strings.HasSuffix(word, "xes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"),
+		strings.HasSuffix(word, "is"), strings.HasSuffix(word, "ics"):
+		return word
+	case strings.HasSuffix(word, "s"):
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+// singularExceptions are words ending in "s" that are not plurals, or
+// whose plural singularize would get wrong.
+var singularExceptions = map[string]bool{
+	"series":  true,
+	"species": true,
+	"news":    true,
+	"bias":    true,
+	"alias":   true,
+	"atlas":   true,
+	"canvas":  true,
+	"lens":    true,
+}
@@ -0,0 +1,125 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTagWithoutVocabulary(t *testing.T) {
+	var v *TagVocabulary
+	tests := map[string]string{
+		"Transformers":        "transformer",
+		"transformer":         "transformer",
+		"Attention Mechanism": "attention-mechanism",
+		"neural_networks":     "neural-network",
+		"LLMs":                "llm",
+		"case studies":        "case-study",
+		"approaches":          "approach",
+		"Time Series":         "time-series",
+		"loss":                "loss",
+		"analysis":            "analysis",
+		"statistics":          "statistics",
+		"bias":                "bias",
+		"C++":                 "c++",
+		"GPT":                 "gpt",
+		"  ":                  "",
+	}
+	for in, want := range tests {
+		if got := v.Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNormalizeTagSynonyms(t *testing.T) {
+	v, err := ParseTagVocabulary([]byte("tags:\n  transformer: [transformer-model, transformer architecture]\n  large-language-model: [llm]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		"Transformers":              "transformer",
+		"transformer-models":        "transformer",
+		"Transformer Architectures": "transformer",
+		"LLMs":                      "large-language-model",
+		"large language models":     "large-language-model",
+		"benchmarks":                "benchmark",
+	}
+	for in, want := range tests {
+		if got := v.Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	got := v.normalizeTags([]string{"Transformers", "transformer", "transformer-models", "LLM", ""})
+	if strings.Join(got, ",") != "transformer,large-language-model" {
+		t.Errorf("normalizeTags = %v, want [transformer large-language-model]", got)
+	}
+}
+
+func TestNormalizeTagStrict(t *testing.T) {
+	v, err := ParseTagVocabulary([]byte("strict: true\ntags:\n  transformer: [transformer-model]\n  benchmark: []\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := v.normalizeTags([]string{"Transformer Models", "benchmarks", "graph-neural-network"})
+	if strings.Join(got, ",") != "transformer,benchmark" {
+		t.Errorf("normalizeTags = %v, want [transformer benchmark] without the unlisted tag", got)
+	}
+}
+
+func TestParseTagVocabularyConflict(t *testing.T) {
+	_, err := ParseTagVocabulary([]byte("tags:\n  transformer: [attention-model]\n  attention: [attention-models]\n"))
+	if err == nil {
+		t.Fatal("expected an error for a synonym under two canonical tags")
+	}
+}
+
+func TestLoadTagVocabularyMissing(t *testing.T) {
+	v, err := LoadTagVocabulary(t.TempDir())
+	if err != nil || v != nil {
+		t.Errorf("LoadTagVocabulary of a directory without tags.yaml = %v, %v; want nil, nil", v, err)
+	}
+}
+
+func TestExtractPaperNormalizesTags(t *testing.T) {
+	papersDir := t.TempDir()
+	knowledgeDir := t.TempDir()
+	os.WriteFile(filepath.Join(knowledgeDir, tagsFile), []byte("tags:\n  transformer: [transformer-model]\n"), 0o644)
+	mdPath := filepath.Join(papersDir, "p.md")
+	os.WriteFile(mdPath, []byte("## Methods\n\nWe train a transformer.\n"), 0o644)
+
+	backend := &mockAIBackend{responses: map[string]AIResponse{
+		"## Methods": {Items: []AIResponseItem{
+			{Type: "method", Content: "A transformer.", Confidence: 0.9, Tags: []string{"Transformers", "transformer-models"}},
+			{Type: "claim", Content: "It is trained.", Confidence: 0.9, Tags: []string{"transformer", "Training"}},
+		}},
+	}}
+	result, err := ExtractPaper(context.Background(), backend, "p", mdPath, testConfig(papersDir, knowledgeDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result.Items[0].Tags; len(got) != 1 || got[0] != "transformer" {
+		t.Errorf("first item tags = %v, want [transformer]", got)
+	}
+	if got := result.Items[1].Tags; len(got) != 2 || got[0] != "transformer" || got[1] != "training" {
+		t.Errorf("second item tags = %v, want [transformer training]", got)
+	}
+}
+
+func TestExtractPaperBadTagVocabulary(t *testing.T) {
+	papersDir := t.TempDir()
+	knowledgeDir := t.TempDir()
+	os.WriteFile(filepath.Join(knowledgeDir, tagsFile), []byte("tags: [not, a, map]\n"), 0o644)
+	mdPath := filepath.Join(papersDir, "p.md")
+	os.WriteFile(mdPath, []byte("## Methods\n\nText.\n"), 0o644)
+
+	_, err := ExtractPaper(context.Background(), &mockAIBackend{}, "p", mdPath, testConfig(papersDir, knowledgeDir))
+	if err == nil || !strings.Contains(err.Error(), tagsFile) {
+		t.Errorf("err = %v, want an error naming %s", err, tagsFile)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// ConfidenceBins is the number of equal-width bins between 0 and 1 in a
+// confidence histogram; a confidence of exactly 1 falls in the last.
+const ConfidenceBins = 10
+
+// DefaultMinMeanConfidence is the mean item confidence below which
+// ValidateExtractions flags a paper.
+const DefaultMinMeanConfidence = 0.5
+
+// ValidationReport is the quality report of the extraction results in
+// knowledgeDir/extracted/ (R10.1).
+type ValidationReport struct {
+	Papers []PaperValidation `json:"papers"`
+
+	// Items, ItemsByType, and Confidence total the papers.
+	Items       int                 `json:"items"`
+	ItemsByType map[string]int      `json:"items_by_type"`
+	Confidence  [ConfidenceBins]int `json:"confidence"`
+}
+
+// PaperValidation reports the statistics of one paper's extraction and
+// the problems they suggest.
+type PaperValidation struct {
+	PaperID     string              `json:"paper_id"`
+	Items       int                 `json:"items"`
+	ItemsByType map[string]int      `json:"items_by_type"`
+	Confidence  [ConfidenceBins]int `json:"confidence"`
+
+	// MeanConfidence is the mean item confidence, rounded to two places.
+	MeanConfidence float64 `json:"mean_confidence"`
+
+	// Untagged counts the items without tags.
+	Untagged int `json:"untagged"`
+
+	// Citations counts the inline citations of the paper's items, and
+	// UnlinkedCitations those matching no bibliography entry.
+	Citations         int `json:"citations"`
+	UnlinkedCitations int `json:"unlinked_citations"`
+
+	// MissingSections lists the sections of the paper's Markdown that
+	// extraction would send but that yielded no items. It is empty when
+	// the Markdown is not in papersDir/markdown/.
+	MissingSections []string `json:"missing_sections,omitempty"`
+
+	// FailedSections counts the sections listed as failed in a partial
+	// result.
+	FailedSections int `json:"failed_sections,omitempty"`
+
+	// Problems describes why the paper is flagged; empty when it is not.
+	Problems []string `json:"problems,omitempty"`
+}
+
+// Flagged reports whether the paper's extraction looks poor.
+func (p PaperValidation) Flagged() bool {
+	return len(p.Problems) > 0
+}
+
+// Flagged returns the papers whose extraction looks poor.
+func (r *ValidationReport) Flagged() []PaperValidation {
+	var flagged []PaperValidation
+	for _, p := range r.Papers {
+		if p.Flagged() {
+			flagged = append(flagged, p)
+		}
+	}
+	return flagged
+}
+
+// ValidateExtractions reads the extraction results in
+// cfg.KnowledgeDir/extracted/, or only those of paperIDs when given, and
+// reports per-paper statistics: item counts by type, the confidence
+// histogram, untagged items, unlinked citations, and sections of the
+// paper's Markdown in cfg.PapersDir that yielded no items, where the
+// sections considered follow cfg.Sections and cfg.SkipSections as
+// extraction does (R10.1). A paper is flagged when it has no items, is
+// partial, has a mean confidence below minMeanConfidence, or has untagged
+// items, unlinked citations, or sections without items. No AI backend is
+// called.
+func ValidateExtractions(cfg types.ExtractionConfig, paperIDs []string, minMeanConfidence float64) (*ValidationReport, error) {
+	dir := filepath.Join(cfg.KnowledgeDir, extractedDir)
+	if len(paperIDs) == 0 {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("reading extraction directory %s: %w", dir, err)
+		}
+		for _, e := range entries {
+			if !e.IsDir() && strings.HasSuffix(e.Name(), "-items.yaml") {
+				paperIDs = append(paperIDs, strings.TrimSuffix(e.Name(), "-items.yaml"))
+			}
+		}
+	}
+
+	report := &ValidationReport{ItemsByType: make(map[string]int)}
+	for _, paperID := range paperIDs {
+		data, err := os.ReadFile(filepath.Join(dir, paperID+"-items.yaml"))
+		if err != nil {
+			return nil, fmt.Errorf("reading extraction of %s: %w", paperID, err)
+		}
+		var result types.ExtractionResult
+		if err := yaml.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("parsing extraction of %s: %w", paperID, err)
+		}
+		mdPath := filepath.Join(cfg.PapersDir, markdownDir, paperID+".md")
+		p := validatePaper(paperID, &result, mdPath, cfg, minMeanConfidence)
+
+		report.Papers = append(report.Papers, p)
+		report.Items += p.Items
+		for t, n := range p.ItemsByType {
+			report.ItemsByType[t] += n
+		}
+		for i, n := range p.Confidence {
+			report.Confidence[i] += n
+		}
+	}
+	return report, nil
+}
+
+// validatePaper computes the statistics of one result and flags its
+// problems.
+func validatePaper(paperID string, result *types.ExtractionResult, mdPath string, cfg types.ExtractionConfig, minMeanConfidence float64) PaperValidation {
+	p := PaperValidation{
+		PaperID:        paperID,
+		Items:          len(result.Items),
+		ItemsByType:    make(map[string]int),
+		FailedSections: len(result.Errors),
+	}
+	var sum float64
+	for _, item := range result.Items {
+		p.ItemsByType[string(item.Type)]++
+		p.Confidence[confidenceBin(item.Confidence)]++
+		sum += item.Confidence
+		if len(item.Tags) == 0 {
+			p.Untagged++
+		}
+		for _, c := range item.Citations {
+			p.Citations++
+			if c.BibIndex < 0 || c.BibIndex >= len(result.Bibliography) {
+				p.UnlinkedCitations++
+			}
+		}
+	}
+	if p.Items > 0 {
+		p.MeanConfidence = math.Round(sum/float64(p.Items)*100) / 100
+	}
+	p.MissingSections = missingSections(result, mdPath, cfg)
+
+	switch {
+	case result.Error != "":
+		p.Problems = append(p.Problems, "failed: "+result.Error)
+	case p.Items == 0:
+		p.Problems = append(p.Problems, "no items")
+	}
+	if result.Partial {
+		p.Problems = append(p.Problems, fmt.Sprintf("partial: %d sections failed", p.FailedSections))
+	}
+	if p.Items > 0 && p.MeanConfidence < minMeanConfidence {
+		p.Problems = append(p.Problems, fmt.Sprintf("mean confidence %.2f below %.2f", p.MeanConfidence, minMeanConfidence))
+	}
+	if p.Untagged > 0 {
+		p.Problems = append(p.Problems, fmt.Sprintf("%d of %d items without tags", p.Untagged, p.Items))
+	}
+	if p.UnlinkedCitations > 0 {
+		p.Problems = append(p.Problems, fmt.Sprintf("%d of %d citations unlinked", p.UnlinkedCitations, p.Citations))
+	}
+	if len(p.MissingSections) > 0 {
+		p.Problems = append(p.Problems, fmt.Sprintf("sections without items: %s", strings.Join(p.MissingSections, ", ")))
+	}
+	return p
+}
+
+// confidenceBin returns the histogram bin of a confidence, clamped to
+// [0, 1].
+func confidenceBin(c float64) int {
+	return min(max(int(c*ConfidenceBins), 0), ConfidenceBins-1)
+}
+
+// minMissingSectionTokens is the estimated size below which a section
+// without items is not reported: short sections such as a data
+// availability statement often hold nothing to extract.
+const minMissingSectionTokens = 100
+
+// missingSections returns the headings of the sections extraction would
+// send from the Markdown at mdPath that no item of result names, other
+// than sections the result records as failed, untitled text before the
+// first heading, and sections under minMissingSectionTokens. A missing
+// Markdown file gives none.
+func missingSections(result *types.ExtractionResult, mdPath string, cfg types.ExtractionConfig) []string {
+	content, err := os.ReadFile(mdPath)
+	if err != nil {
+		return nil
+	}
+	named := make(map[string]bool)
+	for _, item := range result.Items {
+		named[sectionKey(item.Section)] = true
+	}
+	for _, e := range result.Errors {
+		named[sectionKey(e.Section)] = true
+	}
+
+	var missing []string
+	for _, sec := range chunkByHeadings(string(content)) {
+		if sec.heading == "" || estimateTokens(sec.body) < minMissingSectionTokens || !selectSection(sec, cfg.Sections, cfg.SkipSections) {
+			continue
+		}
+		if key := sectionKey(sec.heading); !named[key] && !slices.Contains(missing, sec.heading) {
+			missing = append(missing, sec.heading)
+		}
+	}
+	return missing
+}
+
+// sectionKey normalizes a section heading for comparison: lowercased,
+// with Markdown heading marks and surrounding space removed.
+func sectionKey(heading string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(heading), "#")))
+}
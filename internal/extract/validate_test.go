@@ -0,0 +1,106 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package extract
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestValidateExtractions(t *testing.T) {
+	papersDir := t.TempDir()
+	knowledgeDir := t.TempDir()
+	os.MkdirAll(filepath.Join(papersDir, markdownDir), 0o755)
+	os.MkdirAll(filepath.Join(knowledgeDir, extractedDir), 0o755)
+
+	body := strings.Repeat("Some words of the section. ", 40)
+	md := "# Title\n\nAuthors.\n\n## Methods\n\n" + body + "\n\n## Discussion\n\n" + body +
+		"\n\n## Data Availability\n\nOn request.\n\n## References\n\n[1] Smith, A. A paper. 2020.\n"
+	os.WriteFile(filepath.Join(papersDir, markdownDir, "weak.md"), []byte(md), 0o644)
+
+	results := []types.ExtractionResult{
+		{
+			PaperID:      "weak",
+			Bibliography: []types.BibliographyEntry{{Key: "1"}},
+			Items: []types.KnowledgeItem{
+				{ID: "w1", Type: types.ItemMethod, Section: "Methods", Confidence: 0.35, Tags: []string{"attention"},
+					Citations: []types.Citation{{Key: "1", BibIndex: 0}, {Key: "7", BibIndex: -1}}},
+				{ID: "w2", Type: types.ItemClaim, Section: "Methods", Confidence: 0.45},
+			},
+		},
+		{
+			PaperID: "good",
+			Items: []types.KnowledgeItem{
+				{ID: "g1", Type: types.ItemResult, Section: "Results", Confidence: 0.95, Tags: []string{"accuracy"}},
+				{ID: "g2", Type: types.ItemResult, Section: "Results", Confidence: 1, Tags: []string{"accuracy"}},
+			},
+		},
+		{PaperID: "empty"},
+	}
+	for _, r := range results {
+		data, _ := yaml.Marshal(&r)
+		os.WriteFile(filepath.Join(knowledgeDir, extractedDir, r.PaperID+"-items.yaml"), data, 0o644)
+	}
+
+	report, err := ValidateExtractions(testConfig(papersDir, knowledgeDir), nil, DefaultMinMeanConfidence)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Papers) != 3 || report.Items != 4 || report.ItemsByType["result"] != 2 {
+		t.Fatalf("report = %d papers, %d items, by type %v; want 3 papers, 4 items, 2 results", len(report.Papers), report.Items, report.ItemsByType)
+	}
+	if report.Confidence[3] != 1 || report.Confidence[4] != 1 || report.Confidence[9] != 2 {
+		t.Errorf("corpus histogram = %v, want one item in 0.3, one in 0.4, and two in 0.9", report.Confidence)
+	}
+
+	papers := make(map[string]PaperValidation)
+	for _, p := range report.Papers {
+		papers[p.PaperID] = p
+	}
+
+	weak := papers["weak"]
+	if weak.MeanConfidence != 0.4 || weak.Untagged != 1 || weak.Citations != 2 || weak.UnlinkedCitations != 1 {
+		t.Errorf("weak = %+v, want mean 0.4, 1 untagged, 1 of 2 citations unlinked", weak)
+	}
+	if len(weak.MissingSections) != 1 || weak.MissingSections[0] != "Discussion" {
+		t.Errorf("weak missing sections = %v, want [Discussion] without the title, short, or back-matter sections", weak.MissingSections)
+	}
+	if len(weak.Problems) != 4 {
+		t.Errorf("weak problems = %v, want confidence, tags, citations, and sections", weak.Problems)
+	}
+
+	if good := papers["good"]; good.Flagged() {
+		t.Errorf("good flagged: %v", good.Problems)
+	}
+	if empty := papers["empty"]; len(empty.Problems) != 1 || empty.Problems[0] != "no items" {
+		t.Errorf("empty problems = %v, want [no items]", empty.Problems)
+	}
+	if got := len(report.Flagged()); got != 2 {
+		t.Errorf("Flagged() = %d papers, want 2", got)
+	}
+
+	// Sections outside --sections are not reported missing.
+	cfg := testConfig(papersDir, knowledgeDir)
+	cfg.Sections = []string{"Methods"}
+	report, err = ValidateExtractions(cfg, []string{"weak"}, DefaultMinMeanConfidence)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Papers) != 1 || len(report.Papers[0].MissingSections) != 0 {
+		t.Errorf("with --sections Methods: %+v, want only weak with no missing sections", report.Papers)
+	}
+}
+
+func TestValidateExtractionsUnknownPaper(t *testing.T) {
+	knowledgeDir := t.TempDir()
+	os.MkdirAll(filepath.Join(knowledgeDir, extractedDir), 0o755)
+	if _, err := ValidateExtractions(testConfig(t.TempDir(), knowledgeDir), []string{"missing"}, DefaultMinMeanConfidence); err == nil {
+		t.Error("expected an error for a paper without an extraction result")
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package httputil
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// HostLimit is the token bucket for one host: Rate requests per second
+// with up to Burst requests sent back to back. A zero Rate is unlimited.
+type HostLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerSecond returns the limits for rates in requests per second, as
+// configured by the user. A zero rate is unlimited.
+func PerSecond(rates map[string]float64) map[string]HostLimit {
+	limits := make(map[string]HostLimit, len(rates))
+	for host, rate := range rates {
+		limits[host] = HostLimit{Rate: rate, Burst: 1}
+	}
+	return limits
+}
+
+// Every returns a HostLimit allowing one request per interval. A zero
+// interval is unlimited.
+func Every(interval time.Duration) HostLimit {
+	if interval <= 0 {
+		return HostLimit{}
+	}
+	return HostLimit{Rate: float64(time.Second) / float64(interval), Burst: 1}
+}
+
+// RateLimiter spaces outgoing requests with one token bucket per host.
+// Every backend and downloader in a run shares the same limiter, so
+// concurrent callers draw from the same budget for a host instead of
+// sleeping independently.
+type RateLimiter struct {
+	fallback HostLimit
+	*budget
+}
+
+// budget holds the per-host limits and buckets behind one or more
+// limiters. Limiters returned by SharedRateLimiter share one budget, so
+// search and acquisition in the same process draw from the same buckets.
+type budget struct {
+	mu      sync.Mutex
+	limits  map[string]HostLimit
+	buckets map[string]*bucket
+
+	// configured marks hosts whose limit the user set; built-in
+	// defaults no longer change them.
+	configured map[string]bool
+
+	// now and sleep are replaced by tests.
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// bucket holds the tokens available for one host as of last.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newBudget() *budget {
+	return &budget{
+		limits:     make(map[string]HostLimit),
+		buckets:    make(map[string]*bucket),
+		configured: make(map[string]bool),
+		now:        time.Now,
+		sleep:      sleepContext,
+	}
+}
+
+// NewRateLimiter returns a limiter applying limits by host name. Hosts
+// without an entry use fallback.
+func NewRateLimiter(fallback HostLimit, limits map[string]HostLimit) *RateLimiter {
+	l := &RateLimiter{fallback: fallback, budget: newBudget()}
+	for host, limit := range limits {
+		l.limits[host] = limit
+	}
+	return l
+}
+
+var (
+	sharedOnce   sync.Once
+	sharedBudget *budget
+)
+
+// SharedRateLimiter returns a limiter drawing from the process-wide host
+// budget, so stages composed in one process (a program that searches and
+// then acquires through the arXiv API, say) are paced together. Each CLI
+// command builds a single stage's limiter, so separate commands do not
+// share a budget.
+//
+// defaults are built-in limits: when two callers list the same host the
+// stricter one wins. configured are limits the user set; they replace
+// the host's limit, even with a looser or unlimited one, and later
+// defaults leave them alone. Hosts without an entry use fallback, which
+// is kept per limiter.
+func SharedRateLimiter(fallback HostLimit, defaults, configured map[string]HostLimit) *RateLimiter {
+	sharedOnce.Do(func() { sharedBudget = newBudget() })
+	l := &RateLimiter{fallback: fallback, budget: sharedBudget}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for host, limit := range defaults {
+		if l.configured[host] {
+			continue
+		}
+		if cur, ok := l.limits[host]; !ok || stricter(limit, cur) {
+			l.limits[host] = limit
+		}
+	}
+	for host, limit := range configured {
+		l.limits[host] = limit
+		l.configured[host] = true
+	}
+	return l
+}
+
+// stricter reports whether a allows fewer requests than b. A zero Rate is
+// unlimited.
+func stricter(a, b HostLimit) bool {
+	switch {
+	case a.Rate <= 0:
+		return false
+	case b.Rate <= 0:
+		return true
+	case a.Rate != b.Rate:
+		return a.Rate < b.Rate
+	default:
+		return a.Burst < b.Burst
+	}
+}
+
+// Limit returns the limit applied to host.
+func (l *RateLimiter) Limit(host string) HostLimit {
+	if limit, ok := l.limits[host]; ok {
+		return limit
+	}
+	return l.fallback
+}
+
+// Wait blocks until a request to host may be sent. The token is reserved
+// before sleeping so concurrent callers queue in order. If ctx is
+// cancelled first, Wait returns ctx.Err().
+func (l *RateLimiter) Wait(ctx context.Context, host string) error {
+	delay := l.reserve(host)
+	if delay <= 0 {
+		return nil
+	}
+	return l.sleep(ctx, delay)
+}
+
+// reserve takes one token from host's bucket and returns how long the
+// caller must wait for it. The balance goes negative while callers queue.
+func (l *RateLimiter) reserve(host string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[host]
+	limit := l.Limit(host)
+	if limit.Rate <= 0 {
+		if ok && b.last.After(now) {
+			// A rate-limited response paused the host; see Pause.
+			return b.last.Sub(now)
+		}
+		return 0
+	}
+	burst := float64(max(limit.Burst, 1))
+
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[host] = b
+	}
+	if now.After(b.last) {
+		b.tokens = min(burst, b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+		b.last = now
+	}
+	b.tokens--
+	wait := b.last.Sub(now)
+	if b.tokens >= 0 {
+		return wait
+	}
+	return wait + time.Duration(-b.tokens/limit.Rate*float64(time.Second))
+}
+
+// Pause holds every request to host, from any limiter sharing this
+// budget, until d from now. The transport pauses a host that answers
+// HTTP 429 so the other stages calling it back off too instead of
+// spending their own retries.
+func (l *RateLimiter) Pause(host string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := l.now().Add(d)
+	b, ok := l.buckets[host]
+	if !ok {
+		b = &bucket{tokens: 1}
+		l.buckets[host] = b
+	}
+	if until.After(b.last) {
+		// One token is left for the first caller after the pause.
+		b.tokens = min(b.tokens, 1)
+		b.last = until
+	}
+}
+
+// Transport wraps base so every request waits for its host's bucket. A
+// nil base uses http.DefaultTransport.
+func (l *RateLimiter) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &limitedTransport{base: base, limiter: l}
+}
+
+// limitedTransport waits on the limiter before delegating.
+type limitedTransport struct {
+	base    http.RoundTripper
+	limiter *RateLimiter
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+	if err := t.limiter.Wait(req.Context(), host); err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		t.limiter.Pause(host, retryAfter(resp))
+	}
+	return resp, err
+}
+
+// retryAfter returns the delay a 429 response asks for in its
+// Retry-After header (seconds or an HTTP date), or RetryBaseDelay when
+// it gives none.
+func retryAfter(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return RetryBaseDelay
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package httputil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock drives a RateLimiter without real sleeps; each sleep advances
+// the clock and is recorded.
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) install(l *RateLimiter) {
+	l.now = func() time.Time { return c.now }
+	l.sleep = func(_ context.Context, d time.Duration) error {
+		c.sleeps = append(c.sleeps, d)
+		c.now = c.now.Add(d)
+		return nil
+	}
+}
+
+func TestRateLimiter_SpacesRequestsPerHost(t *testing.T) {
+	l := NewRateLimiter(HostLimit{}, map[string]HostLimit{
+		"api.semanticscholar.org": {Rate: 1, Burst: 1},
+	})
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	clock.install(l)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.Wait(ctx, "api.semanticscholar.org"))
+	}
+	assert.Equal(t, []time.Duration{time.Second, time.Second}, clock.sleeps)
+
+	// Unlisted hosts use the fallback, which is unlimited here.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.Wait(ctx, "api.openalex.org"))
+	}
+	assert.Len(t, clock.sleeps, 2)
+}
+
+func TestRateLimiter_BurstAndRefill(t *testing.T) {
+	l := NewRateLimiter(HostLimit{Rate: 2, Burst: 3}, nil)
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	clock.install(l)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.Wait(ctx, "example.org"))
+	}
+	assert.Empty(t, clock.sleeps, "burst is sent without waiting")
+
+	require.NoError(t, l.Wait(ctx, "example.org"))
+	assert.Equal(t, []time.Duration{500 * time.Millisecond}, clock.sleeps)
+
+	// After idling, the bucket refills up to Burst, not beyond.
+	clock.now = clock.now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.Wait(ctx, "example.org"))
+	}
+	assert.Len(t, clock.sleeps, 1)
+}
+
+func TestRateLimiter_QueuedCallersReserveInOrder(t *testing.T) {
+	l := NewRateLimiter(Every(time.Second), nil)
+	now := time.Unix(0, 0)
+	l.now = func() time.Time { return now }
+
+	assert.Equal(t, time.Duration(0), l.reserve("h"))
+	assert.Equal(t, time.Second, l.reserve("h"))
+	assert.Equal(t, 2*time.Second, l.reserve("h"))
+}
+
+func TestRateLimiter_WaitHonoursContext(t *testing.T) {
+	l := NewRateLimiter(Every(time.Hour), nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.NoError(t, l.Wait(ctx, "h"))
+	assert.ErrorIs(t, l.Wait(ctx, "h"), context.Canceled)
+}
+
+func TestRateLimiter_Transport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	l := NewRateLimiter(HostLimit{Rate: 1, Burst: 1}, nil)
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	clock.install(l)
+
+	client := &http.Client{Transport: l.Transport(nil)}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(ts.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, []time.Duration{time.Second}, clock.sleeps)
+}
+
+func TestEvery(t *testing.T) {
+	assert.Equal(t, HostLimit{Rate: 0.5, Burst: 1}, Every(2*time.Second))
+	assert.Equal(t, HostLimit{}, Every(0))
+}
+
+func TestSharedRateLimiter_SharesBudgetAcrossCallers(t *testing.T) {
+	searchLimiter := SharedRateLimiter(HostLimit{}, map[string]HostLimit{
+		"shared.example": {Rate: 10, Burst: 10},
+	}, nil)
+	acquireLimiter := SharedRateLimiter(Every(time.Second), map[string]HostLimit{
+		"shared.example": Every(3 * time.Second),
+	}, nil)
+	now, sleep := searchLimiter.now, searchLimiter.sleep
+	t.Cleanup(func() { searchLimiter.now, searchLimiter.sleep = now, sleep })
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	clock.install(searchLimiter)
+
+	// The stricter limit wins for both callers; fallbacks stay per caller.
+	assert.Equal(t, Every(3*time.Second), searchLimiter.Limit("shared.example"))
+	assert.Equal(t, HostLimit{}, searchLimiter.Limit("publisher.example"))
+	assert.Equal(t, Every(time.Second), acquireLimiter.Limit("publisher.example"))
+
+	ctx := context.Background()
+	require.NoError(t, searchLimiter.Wait(ctx, "shared.example"))
+	require.NoError(t, acquireLimiter.Wait(ctx, "shared.example"))
+	assert.Equal(t, []time.Duration{3 * time.Second}, clock.sleeps)
+}
+
+func TestSharedRateLimiter_ConfiguredLimitsReplaceDefaults(t *testing.T) {
+	SharedRateLimiter(HostLimit{}, map[string]HostLimit{
+		"loose.example":     Every(3 * time.Second),
+		"unlimited.example": Every(3 * time.Second),
+	}, nil)
+
+	// A configured limit replaces the stricter default, and zero removes it.
+	l := SharedRateLimiter(HostLimit{}, nil, PerSecond(map[string]float64{
+		"loose.example":     5,
+		"unlimited.example": 0,
+	}))
+	assert.Equal(t, HostLimit{Rate: 5, Burst: 1}, l.Limit("loose.example"))
+	assert.Equal(t, HostLimit{Rate: 0, Burst: 1}, l.Limit("unlimited.example"))
+
+	// Defaults registered later do not tighten a configured host again.
+	l = SharedRateLimiter(HostLimit{}, map[string]HostLimit{
+		"loose.example":     Every(10 * time.Second),
+		"unlimited.example": Every(10 * time.Second),
+	}, nil)
+	assert.Equal(t, HostLimit{Rate: 5, Burst: 1}, l.Limit("loose.example"))
+	assert.Equal(t, HostLimit{Rate: 0, Burst: 1}, l.Limit("unlimited.example"))
+}
+
+func TestRateLimiter_PausesHostOnTooManyRequests(t *testing.T) {
+	limited := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if limited {
+			limited = false
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	l := NewRateLimiter(HostLimit{}, nil)
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	clock.install(l)
+
+	// An unlimited host is held for Retry-After once it answers 429.
+	client := &http.Client{Transport: l.Transport(nil)}
+	for _, want := range []int{http.StatusTooManyRequests, http.StatusOK} {
+		resp, err := client.Get(ts.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, want, resp.StatusCode)
+	}
+	assert.Equal(t, []time.Duration{30 * time.Second}, clock.sleeps)
+
+	// A limited host resumes its normal spacing after the pause.
+	l = NewRateLimiter(HostLimit{Rate: 1, Burst: 1}, nil)
+	clock = &fakeClock{now: time.Unix(0, 0)}
+	clock.install(l)
+	l.Pause("h", 10*time.Second)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, l.Wait(context.Background(), "h"))
+	}
+	assert.Equal(t, []time.Duration{10 * time.Second, time.Second}, clock.sleeps)
+}
@@ -32,7 +32,17 @@ func DoWithRetry(ctx context.Context, client *http.Client, req *http.Request, ma
 	}
 
 	for attempt := 0; ; attempt++ {
-		resp, err := client.Do(req.Clone(ctx))
+		attemptReq := req.Clone(ctx)
+		// Requests with a body (POST) need a fresh reader on each retry.
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("rewinding request body: %w", err)
+			}
+			attemptReq.Body = body
+		}
+
+		resp, err := client.Do(attemptReq)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,71 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package httputil
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// CallStats counts outgoing HTTP requests per host. Commands wrap their
+// client transport with it to report API usage in the run footer.
+type CallStats struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// NewCallStats returns an empty CallStats.
+func NewCallStats() *CallStats {
+	return &CallStats{counts: make(map[string]int)}
+}
+
+// Transport wraps base so every request it sends is counted. A nil base
+// uses http.DefaultTransport.
+func (s *CallStats) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &countingTransport{base: base, stats: s}
+}
+
+// Record counts one request to host.
+func (s *CallStats) Record(host string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counts[host]++
+}
+
+// HostCount is the number of requests sent to one host.
+type HostCount struct {
+	Host  string
+	Calls int
+}
+
+// Counts returns the per-host request counts, busiest host first.
+func (s *CallStats) Counts() []HostCount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]HostCount, 0, len(s.counts))
+	for host, n := range s.counts {
+		out = append(out, HostCount{Host: host, Calls: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Calls != out[j].Calls {
+			return out[i].Calls > out[j].Calls
+		}
+		return out[i].Host < out[j].Host
+	})
+	return out
+}
+
+// countingTransport records each request's host before delegating.
+type countingTransport struct {
+	base  http.RoundTripper
+	stats *CallStats
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.stats.Record(req.URL.Host)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,35 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallStats_CountsPerHost(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	stats := NewCallStats()
+	client := &http.Client{Transport: stats.Transport(nil)}
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(ts.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	stats.Record("api.example.org")
+
+	u, _ := url.Parse(ts.URL)
+	assert.Equal(t, []HostCount{
+		{Host: u.Host, Calls: 3},
+		{Host: "api.example.org", Calls: 1},
+	}, stats.Counts())
+}
@@ -0,0 +1,200 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+const (
+	notesDir      = "notes"
+	absencePrefix = "absence-"
+)
+
+// Absence query kinds.
+const (
+	AbsenceSearch   = "search"
+	AbsenceRetrieve = "retrieve"
+)
+
+// AbsencePath returns the file of the absence note for topic:
+// knowledgeDir/notes/absence-<slug>.yaml.
+func AbsencePath(knowledgeDir, topic string) (string, error) {
+	slug := topicSlug(topic)
+	if slug == "" {
+		return "", fmt.Errorf("topic %q has no letters or digits", topic)
+	}
+	return filepath.Join(knowledgeDir, notesDir, absencePrefix+slug+".yaml"), nil
+}
+
+// RecordAbsence writes an absence note (R9.1). When the topic already has
+// a note, the queries are merged into it: a query already listed (same
+// kind, file, and text) is replaced by its new run, others are appended.
+// A new comment replaces the old one. It returns the note's file.
+func RecordAbsence(knowledgeDir string, note types.AbsenceNote) (string, error) {
+	path, err := AbsencePath(knowledgeDir, note.Topic)
+	if err != nil {
+		return "", err
+	}
+	if existing, err := readAbsence(path); err == nil {
+		for _, q := range note.Queries {
+			existing.Queries = mergeAbsenceQuery(existing.Queries, q)
+		}
+		if note.Note != "" {
+			existing.Note = note.Note
+		}
+		existing.Recorded = note.Recorded
+		note = *existing
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+	if note.Recorded.IsZero() {
+		note.Recorded = time.Now().UTC().Truncate(time.Second)
+	}
+
+	data, err := yaml.Marshal(note)
+	if err != nil {
+		return "", fmt.Errorf("marshaling absence note: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("writing %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// LoadAbsences reads every absence note in knowledgeDir, sorted by topic.
+func LoadAbsences(knowledgeDir string) ([]types.AbsenceNote, error) {
+	dir := filepath.Join(knowledgeDir, notesDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading %s: %w", dir, err)
+	}
+	var notes []types.AbsenceNote
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, absencePrefix) || !strings.HasSuffix(name, ".yaml") {
+			continue
+		}
+		note, err := readAbsence(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, *note)
+	}
+	sort.Slice(notes, func(i, j int) bool {
+		return strings.ToLower(notes[i].Topic) < strings.ToLower(notes[j].Topic)
+	})
+	return notes, nil
+}
+
+// CheckRetrieval runs a full-text retrieval for an absence note and
+// records how many items it returned (R9.1).
+func (s *Store) CheckRetrieval(ctx context.Context, query string) (types.AbsenceQuery, error) {
+	results, err := s.Retrieve(ctx, QueryOptions{Query: query})
+	if err != nil {
+		return types.AbsenceQuery{}, err
+	}
+	return types.AbsenceQuery{
+		Kind:    AbsenceRetrieve,
+		Query:   query,
+		Run:     time.Now().UTC().Truncate(time.Second),
+		Results: len(results),
+	}, nil
+}
+
+// RenderAbsences writes the absence notes as a Markdown section a survey
+// can cite: each topic with its comment and the searches behind it (R9.2).
+func RenderAbsences(w io.Writer, notes []types.AbsenceNote) error {
+	var b strings.Builder
+	b.WriteString("## Searched and not found\n\n")
+	if len(notes) == 0 {
+		b.WriteString("No negative findings recorded.\n")
+	}
+	for _, n := range notes {
+		fmt.Fprintf(&b, "- **%s**", n.Topic)
+		if n.Note != "" {
+			fmt.Fprintf(&b, ": %s", n.Note)
+		}
+		b.WriteString("\n")
+		for _, q := range n.Queries {
+			fmt.Fprintf(&b, "  - %s\n", describeAbsenceQuery(q))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// describeAbsenceQuery renders one query of a note, for example
+// `search "graph coloring" (2026-03-02; 14 results, none kept; queries/gc.yaml)`.
+func describeAbsenceQuery(q types.AbsenceQuery) string {
+	var details []string
+	details = append(details, q.Run.Format("2006-01-02"))
+	switch q.Kind {
+	case AbsenceSearch:
+		kept := "none kept"
+		if q.Kept > 0 {
+			kept = fmt.Sprintf("%d kept", q.Kept)
+		}
+		details = append(details, fmt.Sprintf("%d results, %s", q.Results, kept))
+	default:
+		details = append(details, fmt.Sprintf("%d items", q.Results))
+	}
+	if q.File != "" {
+		details = append(details, q.File)
+	}
+	if len(q.BackendErrors) > 0 {
+		details = append(details, "failed backends: "+strings.Join(q.BackendErrors, ", "))
+	}
+	return fmt.Sprintf("%s %q (%s)", q.Kind, q.Query, strings.Join(details, "; "))
+}
+
+// mergeAbsenceQuery replaces the query in queries with q's kind, file,
+// and text, or appends q.
+func mergeAbsenceQuery(queries []types.AbsenceQuery, q types.AbsenceQuery) []types.AbsenceQuery {
+	for i, old := range queries {
+		if old.Kind == q.Kind && old.File == q.File && old.Query == q.Query {
+			queries[i] = q
+			return queries
+		}
+	}
+	return append(queries, q)
+}
+
+func readAbsence(path string) (*types.AbsenceNote, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var note types.AbsenceNote
+	if err := yaml.Unmarshal(data, &note); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &note, nil
+}
+
+// topicSlug lowercases topic and joins its runs of letters and digits
+// with hyphens.
+func topicSlug(topic string) string {
+	words := strings.FieldsFunc(strings.ToLower(topic), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "-")
+}
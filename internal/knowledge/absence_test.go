@@ -0,0 +1,93 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestRecordAbsence(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestHelper(t, store, tmpDir, "2301.00001")
+	knowledgeDir := filepath.Join(tmpDir, "knowledge")
+	ctx := context.Background()
+
+	none, err := store.CheckRetrieval(ctx, "quantum annealing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if none.Kind != AbsenceRetrieve || none.Results != 0 || none.Run.IsZero() {
+		t.Errorf("retrieval = %+v, want no items", none)
+	}
+	some, err := store.CheckRetrieval(ctx, "attention")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if some.Results == 0 {
+		t.Error("retrieval of an indexed term should count its items")
+	}
+
+	search := types.AbsenceQuery{
+		Kind: AbsenceSearch, Query: "quantum annealing SAT", File: "queries/qa.yaml",
+		Run: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), Results: 12,
+		BackendErrors: []string{"semantic_scholar: HTTP 429"},
+	}
+	path, err := RecordAbsence(knowledgeDir, types.AbsenceNote{
+		Topic: "Quantum annealing for SAT", Note: "No empirical comparisons",
+		Queries: []types.AbsenceQuery{search, none},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "absence-quantum-annealing-for-sat.yaml" {
+		t.Errorf("path = %s", path)
+	}
+
+	// Recording the topic again updates the query already listed and
+	// keeps the comment.
+	search.Results = 15
+	if _, err := RecordAbsence(knowledgeDir, types.AbsenceNote{
+		Topic: "quantum annealing for SAT", Queries: []types.AbsenceQuery{search},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RecordAbsence(knowledgeDir, types.AbsenceNote{Topic: "Analog solvers", Queries: []types.AbsenceQuery{none}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RecordAbsence(knowledgeDir, types.AbsenceNote{Topic: "?!"}); err == nil {
+		t.Error("expected an error for a topic without letters")
+	}
+
+	notes, err := LoadAbsences(knowledgeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notes) != 2 || notes[0].Topic != "Analog solvers" {
+		t.Fatalf("notes = %+v", notes)
+	}
+	qa := notes[1]
+	if qa.Topic != "Quantum annealing for SAT" || qa.Note != "No empirical comparisons" || len(qa.Queries) != 2 || qa.Queries[0].Results != 15 {
+		t.Errorf("merged note = %+v", qa)
+	}
+
+	var md strings.Builder
+	if err := RenderAbsences(&md, notes); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"## Searched and not found",
+		"- **Quantum annealing for SAT**: No empirical comparisons",
+		`search "quantum annealing SAT" (2026-03-02; 15 results, none kept; queries/qa.yaml; failed backends: semantic_scholar: HTTP 429)`,
+		`retrieve "quantum annealing" (`,
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("Markdown missing %q:\n%s", want, md.String())
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// ankiTag marks every exported card, so a deck's research-engine notes can
+// be found in Anki.
+const ankiTag = "research-engine"
+
+// maxTermWords is the longest defined term ankiFront puts on a card front;
+// a longer match is a clause rather than a term.
+const maxTermWords = 6
+
+// definitionPatterns find the term a definition defines, tried in order.
+var definitionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^we (?:define|call|refer to) (.+?) (?:as|to be) `),
+	regexp.MustCompile(`(?i)^(.+?) (?:is|are) (?:defined|formally defined) as `),
+	regexp.MustCompile(`(?i)^(.+?) (?:refers|refer|denotes|denote) (?:to )?`),
+	regexp.MustCompile(`^([^:]+?): `),
+	regexp.MustCompile(`(?i)^(.+?) (?:is|are) `),
+}
+
+// ExportAnki writes the claim and definition items to
+// knowledge/index/export-anki.txt as an Anki text import (R6.7): one note
+// per item, with the defined term or a question about the claim in front,
+// the item's content and provenance on the back, and the item's tags and
+// type as Anki tags. The item ID is each note's GUID, so re-importing the
+// file updates notes instead of duplicating them. It filters and orders
+// items like ExportYAML; a type filter must be claim or definition.
+func (s *Store) ExportAnki(ctx context.Context, opts QueryOptions) error {
+	switch opts.Type {
+	case "", types.ItemClaim, types.ItemDefinition:
+	default:
+		return fmt.Errorf("anki export takes %s and %s items, not %s", types.ItemClaim, types.ItemDefinition, opts.Type)
+	}
+	// Limit after dropping the other item types.
+	limit := opts.MaxResults
+	opts.MaxResults = 0
+	entries, err := s.exportEntries(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString("#separator:tab\n#html:true\n#guid column:1\n#tags column:4\n")
+	n := 0
+	for _, e := range entries {
+		if e.Type != string(types.ItemClaim) && e.Type != string(types.ItemDefinition) {
+			continue
+		}
+		if limit > 0 && n == limit {
+			break
+		}
+		n++
+		tags := []string{ankiTag, e.Type}
+		for _, t := range e.Tags {
+			tags = append(tags, strings.ReplaceAll(t, " ", "-"))
+		}
+		fmt.Fprintf(&b, "%s\t%s\t%s\t%s\n", ankiField(e.ID), ankiFront(e), ankiBack(e), ankiField(strings.Join(tags, " ")))
+	}
+
+	path := filepath.Join(s.knowledgeDir, indexDir, "export-anki.txt")
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// ankiFront returns the front of an item's card: "Define: <term>" for a
+// definition whose term can be found, and otherwise a question naming the
+// paper and the item's first tag or section.
+func ankiFront(e ExportEntry) string {
+	if e.Type == string(types.ItemDefinition) {
+		if term := definitionTerm(e.Content); term != "" {
+			return "Define: " + ankiField(term)
+		}
+	}
+	source := e.PaperID
+	if e.Paper != nil && e.Paper.Title != "" {
+		source = e.Paper.Title
+	}
+	verb := "claim"
+	if e.Type == string(types.ItemDefinition) {
+		verb = "define"
+	}
+	q := fmt.Sprintf("What does <i>%s</i> %s", ankiField(source), verb)
+	switch {
+	case len(e.Tags) > 0:
+		q += " about " + ankiField(e.Tags[0])
+	case e.Section != "":
+		q += " in its " + ankiField(e.Section) + " section"
+	}
+	return q + "?"
+}
+
+// ankiBack returns the back of an item's card: its content and where it
+// comes from.
+func ankiBack(e ExportEntry) string {
+	source := e.PaperID
+	if e.Paper != nil && e.Paper.Title != "" {
+		source = e.Paper.Title
+	}
+	prov := []string{"<i>" + ankiField(source) + "</i>"}
+	if e.Section != "" {
+		prov = append(prov, ankiField(e.Section))
+	}
+	if e.Page > 0 {
+		prov = append(prov, fmt.Sprintf("p. %d", e.Page))
+	}
+	prov = append(prov, ankiField(e.ID))
+	return ankiField(e.Content) + "<br><br><small>" + strings.Join(prov, ", ") + "</small>"
+}
+
+// definitionTerm returns the term a definition defines, or "" when no
+// short term can be found.
+func definitionTerm(content string) string {
+	content = strings.TrimSpace(content)
+	for _, re := range definitionPatterns {
+		m := re.FindStringSubmatch(content)
+		if m == nil {
+			continue
+		}
+		term := strings.Trim(m[1], ` "'`)
+		for _, article := range []string{"the ", "The ", "a ", "A ", "an ", "An "} {
+			term = strings.TrimPrefix(term, article)
+		}
+		if n := len(strings.Fields(term)); n > 0 && n <= maxTermWords {
+			return term
+		}
+	}
+	return ""
+}
+
+// ankiField escapes text for an HTML field of a tab-separated Anki import,
+// which cannot hold tabs or line breaks.
+func ankiField(s string) string {
+	s = html.EscapeString(s)
+	s = strings.ReplaceAll(s, "\t", " ")
+	s = strings.ReplaceAll(s, "\r\n", "<br>")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
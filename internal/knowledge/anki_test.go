@@ -0,0 +1,100 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestDefinitionTerm(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"We define efficient attention as a linear approximation of softmax", "efficient attention"},
+		{"A transformer is defined as a stack of attention layers", "transformer"},
+		{"Perplexity refers to the exponentiated average negative log-likelihood", "Perplexity"},
+		{"Sparsity: the fraction of weights that are zero", "Sparsity"},
+		{"Knowledge distillation is the transfer of knowledge from a large model to a small one", "Knowledge distillation"},
+		{"Softmax attention computes weighted averages over all input positions", ""},
+		{"In the setting where every layer of the network shares one set of weights and the inputs are tokens is a case we call tying", ""},
+	}
+	for _, tt := range tests {
+		if got := definitionTerm(tt.content); got != tt.want {
+			t.Errorf("definitionTerm(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestExportAnki(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	items := sampleItems("anki-paper")
+	items = append(items, types.KnowledgeItem{
+		ID: "anki-paper-def2", Type: types.ItemDefinition, PaperID: "anki-paper",
+		Content: "Sparsity: the fraction of weights\tthat are <zero>", Section: "Background", Page: 1,
+		Confidence: 0.9, Tags: []string{"pruning"},
+	})
+	writeExtraction(t, tmpDir, "anki-paper", items)
+	writePaperMeta(t, tmpDir, samplePaper("anki-paper"))
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.ExportAnki(context.Background(), QueryOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, "knowledge", indexDir, "export-anki.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 4+3 || lines[0] != "#separator:tab" {
+		t.Fatalf("got %d lines, want 4 headers and 3 notes:\n%s", len(lines), data)
+	}
+	notes := make(map[string][]string)
+	for _, line := range lines[4:] {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 4 {
+			t.Fatalf("note %q has %d fields, want 4", line, len(fields))
+		}
+		notes[fields[0]] = fields
+	}
+	if _, ok := notes["anki-paper-method1"]; ok {
+		t.Error("method item exported")
+	}
+
+	claim := notes["anki-paper-claim1"]
+	if claim[1] != "What does <i>Efficient Attention Mechanisms for Transformers</i> claim about attention?" {
+		t.Errorf("claim front = %q", claim[1])
+	}
+	if !strings.HasPrefix(claim[2], "Efficient attention reduces computation") ||
+		!strings.Contains(claim[2], "Method, p. 2, anki-paper-claim1") {
+		t.Errorf("claim back = %q", claim[2])
+	}
+	if claim[3] != "research-engine claim attention efficiency" {
+		t.Errorf("claim tags = %q", claim[3])
+	}
+
+	def := notes["anki-paper-def2"]
+	if def[1] != "Define: Sparsity" || !strings.Contains(def[2], "weights that are &lt;zero&gt;") {
+		t.Errorf("definition card = %q / %q", def[1], def[2])
+	}
+
+	if err := store.ExportAnki(context.Background(), QueryOptions{Type: types.ItemMethod}); err == nil {
+		t.Error("method type filter accepted")
+	}
+	if err := store.ExportAnki(context.Background(), QueryOptions{MaxResults: 1}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(filepath.Join(tmpDir, "knowledge", indexDir, "export-anki.txt"))
+	if n := strings.Count(string(data), "\n"); n != 5 {
+		t.Errorf("limited export has %d lines, want 4 headers and 1 note", n)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// minQuestionTermLen is the shortest word kept when turning a question into
+// an FTS5 query. Shorter words are mostly articles and prepositions.
+const minQuestionTermLen = 3
+
+// questionStopwords lists interrogatives and filler words that carry no
+// retrieval signal in a natural-language question.
+var questionStopwords = map[string]bool{
+	"what": true, "which": true, "when": true, "where": true, "who": true,
+	"why": true, "how": true, "does": true, "did": true, "are": true,
+	"was": true, "were": true, "the": true, "and": true, "for": true,
+	"with": true, "from": true, "that": true, "this": true, "there": true,
+	"about": true, "into": true, "can": true, "any": true,
+}
+
+// Answer holds the items retrieved for a natural-language question. Each
+// item becomes one statement in the rendered answer with a numbered
+// footnote pointing back to its source.
+type Answer struct {
+	Question string
+	Results  []QueryResult
+}
+
+// Ask retrieves the knowledge items most relevant to a natural-language
+// question. The question is reduced to an OR query over its content words
+// so punctuation and interrogatives do not break FTS5 matching. Structured
+// filters in opts (type, tags, paper) still apply.
+func (s *Store) Ask(ctx context.Context, question string, opts QueryOptions) (Answer, error) {
+	query := QuestionQuery(question)
+	if query == "" {
+		return Answer{}, fmt.Errorf("question %q has no searchable terms", question)
+	}
+	opts.Query = query
+
+	results, err := s.Retrieve(ctx, opts)
+	if err != nil {
+		return Answer{}, err
+	}
+	return Answer{Question: question, Results: results}, nil
+}
+
+// QuestionQuery converts a natural-language question into an FTS5 query
+// string: lowercase content words joined with OR, each quoted so FTS5
+// treats them as plain terms.
+func QuestionQuery(question string) string {
+	words := strings.FieldsFunc(strings.ToLower(question), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+
+	seen := make(map[string]bool)
+	var terms []string
+	for _, w := range words {
+		w = strings.Trim(w, "-")
+		if len(w) < minQuestionTermLen || questionStopwords[w] || seen[w] {
+			continue
+		}
+		seen[w] = true
+		terms = append(terms, `"`+w+`"`)
+	}
+	return strings.Join(terms, " OR ")
+}
+
+// RenderMarkdown writes the answer as Markdown with numbered footnotes. Each
+// footnote names the paper title, section, page, and item ID so a statement
+// pasted into a draft keeps verifiable provenance.
+func (a Answer) RenderMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", a.Question)
+
+	if len(a.Results) == 0 {
+		b.WriteString("No supporting items found in the knowledge base.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	for i, r := range a.Results {
+		fmt.Fprintf(&b, "- %s[^%d]\n", strings.TrimSpace(r.Content), i+1)
+	}
+	b.WriteString("\n")
+	for i, r := range a.Results {
+		fmt.Fprintf(&b, "[^%d]: %s\n", i+1, footnoteText(r))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// footnoteText formats the provenance of one result: paper title (or ID
+// when the title is unknown), DOI, section, page, and item ID. For a linked
+// paper the title and DOI are those of the canonical version.
+func footnoteText(r QueryResult) string {
+	title := r.PaperTitle
+	if title == "" {
+		title = r.PaperID
+	}
+	parts := []string{title}
+	if r.PaperDOI != "" {
+		parts = append(parts, "doi:"+r.PaperDOI)
+	}
+	if r.Section != "" {
+		parts = append(parts, "§ "+r.Section)
+	}
+	if r.Page > 0 {
+		parts = append(parts, fmt.Sprintf("p. %d", r.Page))
+	}
+	return fmt.Sprintf("%s (item `%s`)", strings.Join(parts, ", "), r.ID)
+}
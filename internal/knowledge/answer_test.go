@@ -0,0 +1,87 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestQuestionQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		question string
+		want     string
+	}{
+		{"drops interrogatives and punctuation", "How does efficient attention work?", `"efficient" OR "attention" OR "work"`},
+		{"deduplicates terms", "attention, attention!", `"attention"`},
+		{"keeps hyphenated terms", "What is self-attention?", `"self-attention"`},
+		{"only stopwords", "What is it?", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := QuestionQuery(tt.question); got != tt.want {
+				t.Errorf("QuestionQuery(%q) = %q, want %q", tt.question, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAskRendersFootnotes(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestHelper(t, store, tmpDir, "2301.07041")
+
+	answer, err := store.Ask(context.Background(), "What accuracy on the GLUE benchmark?", QueryOptions{})
+	if err != nil {
+		t.Fatalf("Ask: %v", err)
+	}
+	if len(answer.Results) == 0 {
+		t.Fatal("expected at least one supporting item")
+	}
+
+	var buf strings.Builder
+	if err := answer.RenderMarkdown(&buf); err != nil {
+		t.Fatalf("RenderMarkdown: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"# What accuracy on the GLUE benchmark?",
+		"89.2% accuracy on the GLUE benchmark[^1]",
+		"[^1]: Efficient Attention Mechanisms for Transformers, § Results, p. 5 (item `2301.07041-result1`)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestAskNoSearchableTerms(t *testing.T) {
+	store, _ := testSetup(t)
+	if _, err := store.Ask(context.Background(), "what is it?", QueryOptions{}); err == nil {
+		t.Error("expected error for question without searchable terms")
+	}
+}
+
+func TestRenderMarkdownNoResults(t *testing.T) {
+	var buf strings.Builder
+	if err := (Answer{Question: "Anything?"}).RenderMarkdown(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "No supporting items") {
+		t.Errorf("expected empty-answer notice, got %q", buf.String())
+	}
+}
+
+func TestFootnoteTextFallsBackToPaperID(t *testing.T) {
+	r := QueryResult{}
+	r.ID = "abc123"
+	r.PaperID = "2301.07041"
+	got := footnoteText(r)
+	want := "2301.07041 (item `abc123`)"
+	if got != want {
+		t.Errorf("footnoteText = %q, want %q", got, want)
+	}
+}
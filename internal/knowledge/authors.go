@@ -0,0 +1,313 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// authorSchema creates the author tables (R7.1). Authors are identified by
+// ORCID when known ("orcid:0000-0002-1825-0097") and otherwise by their
+// normalized name ("name:ada lovelace"). Affiliations belong to the
+// authorship, since an author's institution changes between papers.
+var authorSchema = []string{
+	`CREATE TABLE IF NOT EXISTS authors (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		orcid TEXT,
+		name_key TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS authors_name_key ON authors(name_key)`,
+	`CREATE TABLE IF NOT EXISTS paper_authors (
+		paper_id TEXT NOT NULL,
+		position INTEGER NOT NULL,
+		author_id TEXT NOT NULL,
+		PRIMARY KEY (paper_id, position)
+	)`,
+	`CREATE TABLE IF NOT EXISTS author_affiliations (
+		paper_id TEXT NOT NULL,
+		position INTEGER NOT NULL,
+		institution TEXT NOT NULL,
+		ror TEXT,
+		country TEXT
+	)`,
+	`CREATE INDEX IF NOT EXISTS author_affiliations_paper ON author_affiliations(paper_id)`,
+}
+
+// normalizeAuthorName returns the key name-only authors are merged on:
+// lowercase letters and digits with punctuation folded to single spaces,
+// so "A. Vaswani" and "a vaswani" match.
+func normalizeAuthorName(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// replacePaperAuthors rewrites the authorship rows of paper from its
+// AuthorDetails, or from its plain author names when OpenAlex supplied
+// none, and removes authors left without papers (R7.1, R7.2).
+func replacePaperAuthors(ctx context.Context, tx *sql.Tx, paper *types.Paper) error {
+	for _, stmt := range []string{
+		`DELETE FROM paper_authors WHERE paper_id = ?`,
+		`DELETE FROM author_affiliations WHERE paper_id = ?`,
+	} {
+		if _, err := tx.ExecContext(ctx, stmt, paper.ID); err != nil {
+			return fmt.Errorf("clearing authors of %s: %w", paper.ID, err)
+		}
+	}
+
+	authors := paper.AuthorDetails
+	if len(authors) == 0 {
+		for _, name := range paper.Authors {
+			authors = append(authors, types.PaperAuthor{Name: name})
+		}
+	}
+	for pos, a := range authors {
+		authorID, err := resolveAuthor(ctx, tx, a)
+		if err != nil {
+			return err
+		}
+		if authorID == "" {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO paper_authors (paper_id, position, author_id) VALUES (?, ?, ?)`,
+			paper.ID, pos, authorID,
+		); err != nil {
+			return fmt.Errorf("inserting author of %s: %w", paper.ID, err)
+		}
+		for _, aff := range a.Affiliations {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO author_affiliations (paper_id, position, institution, ror, country) VALUES (?, ?, ?, ?, ?)`,
+				paper.ID, pos, aff.Name, aff.ROR, aff.Country,
+			); err != nil {
+				return fmt.Errorf("inserting affiliation of %s: %w", paper.ID, err)
+			}
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM authors WHERE id NOT IN (SELECT author_id FROM paper_authors)`,
+	); err != nil {
+		return fmt.Errorf("removing orphaned authors: %w", err)
+	}
+	return nil
+}
+
+// resolveAuthor returns the authors row for a, creating it if needed and
+// merging duplicates (R7.2): an author with an ORCID absorbs the name-only
+// author with the same normalized name, and a name-only author is
+// attributed to the ORCID author with that name when there is exactly one.
+// It returns "" for an author without a usable name.
+func resolveAuthor(ctx context.Context, tx *sql.Tx, a types.PaperAuthor) (string, error) {
+	nameKey := normalizeAuthorName(a.Name)
+	if nameKey == "" {
+		return "", nil
+	}
+	nameID := "name:" + nameKey
+
+	orcidIDs, err := orcidAuthorsNamed(ctx, tx, nameKey)
+	if err != nil {
+		return "", err
+	}
+
+	if a.ORCID == "" {
+		if len(orcidIDs) == 1 {
+			return orcidIDs[0], nil
+		}
+		_, err := tx.ExecContext(ctx,
+			`INSERT OR IGNORE INTO authors (id, name, name_key) VALUES (?, ?, ?)`,
+			nameID, a.Name, nameKey,
+		)
+		if err != nil {
+			return "", fmt.Errorf("inserting author %s: %w", a.Name, err)
+		}
+		return nameID, nil
+	}
+
+	id := "orcid:" + a.ORCID
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO authors (id, name, orcid, name_key) VALUES (?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET name=excluded.name, name_key=excluded.name_key`,
+		id, a.Name, a.ORCID, nameKey,
+	); err != nil {
+		return "", fmt.Errorf("inserting author %s: %w", a.Name, err)
+	}
+	// Merge the name-only record unless the name is shared by another
+	// ORCID, in which case it is ambiguous.
+	if len(orcidIDs) == 0 || (len(orcidIDs) == 1 && orcidIDs[0] == id) {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE paper_authors SET author_id = ? WHERE author_id = ?`, id, nameID,
+		); err != nil {
+			return "", fmt.Errorf("merging author %s: %w", a.Name, err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM authors WHERE id = ?`, nameID); err != nil {
+			return "", fmt.Errorf("merging author %s: %w", a.Name, err)
+		}
+	}
+	return id, nil
+}
+
+// orcidAuthorsNamed returns the IDs of authors with an ORCID whose
+// normalized name is nameKey.
+func orcidAuthorsNamed(ctx context.Context, tx *sql.Tx, nameKey string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id FROM authors WHERE name_key = ? AND orcid IS NOT NULL AND orcid != '' ORDER BY id`, nameKey)
+	if err != nil {
+		return nil, fmt.Errorf("looking up author %s: %w", nameKey, err)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// CorpusStats summarizes the knowledge base (R7.3).
+type CorpusStats struct {
+	Papers int `json:"papers"`
+	// PapersWithItems counts papers with at least one knowledge item;
+	// the rest are metadata-only references.
+	PapersWithItems int            `json:"papers_with_items"`
+	Items           int            `json:"items"`
+	ItemsByType     map[string]int `json:"items_by_type"`
+	Authors         int            `json:"authors"`
+	// AuthorsWithORCID counts authors identified by ORCID.
+	AuthorsWithORCID int `json:"authors_with_orcid"`
+	Institutions     int `json:"institutions"`
+	Venues           int `json:"venues"`
+	// PapersByVenueType counts papers per venue type (R8.3).
+	PapersByVenueType map[string]int `json:"papers_by_venue_type"`
+
+	// ItemsByPaper, ItemsByTag, and ItemsBySection count items per
+	// paper, tag, and section (R7.5).
+	ItemsByPaper   map[string]int `json:"items_by_paper"`
+	ItemsByTag     map[string]int `json:"items_by_tag"`
+	ItemsBySection map[string]int `json:"items_by_section"`
+	// TopTags lists the most used tags, most items first (R7.5).
+	TopTags []FacetCount `json:"top_tags"`
+	// Confidence is the histogram of item extraction confidence (R7.5).
+	Confidence []ConfidenceBucket `json:"confidence"`
+}
+
+// InstitutionStat counts the papers and authors affiliated with one
+// institution (R7.3).
+type InstitutionStat struct {
+	Institution string `json:"institution"`
+	ROR         string `json:"ror,omitempty"`
+	Country     string `json:"country,omitempty"`
+	Papers      int    `json:"papers"`
+	Authors     int    `json:"authors"`
+}
+
+// Stats returns corpus-wide counts.
+func (s *Store) Stats(ctx context.Context) (CorpusStats, error) {
+	st := CorpusStats{
+		ItemsByType:       make(map[string]int),
+		PapersByVenueType: make(map[string]int),
+		ItemsByPaper:      make(map[string]int),
+		ItemsByTag:        make(map[string]int),
+		ItemsBySection:    make(map[string]int),
+	}
+	counts := []struct {
+		query string
+		dest  *int
+	}{
+		{`SELECT COUNT(*) FROM papers`, &st.Papers},
+		{`SELECT COUNT(DISTINCT paper_id) FROM items`, &st.PapersWithItems},
+		{`SELECT COUNT(*) FROM items`, &st.Items},
+		{`SELECT COUNT(*) FROM authors`, &st.Authors},
+		{`SELECT COUNT(*) FROM authors WHERE orcid IS NOT NULL AND orcid != ''`, &st.AuthorsWithORCID},
+		{`SELECT COUNT(DISTINCT ` + institutionKey + `) FROM author_affiliations`, &st.Institutions},
+		{`SELECT COUNT(*) FROM venues`, &st.Venues},
+	}
+	for _, c := range counts {
+		if err := s.db.QueryRowContext(ctx, c.query).Scan(c.dest); err != nil {
+			return st, fmt.Errorf("computing statistics: %w", err)
+		}
+	}
+
+	groups := []struct {
+		query string
+		dest  map[string]int
+	}{
+		{`SELECT type, COUNT(*) FROM items GROUP BY type`, st.ItemsByType},
+		{`SELECT COALESCE(NULLIF(v.type, ''), 'other'), COUNT(*)
+			FROM papers p JOIN venues v ON v.id = p.venue_id GROUP BY 1`, st.PapersByVenueType},
+		{`SELECT paper_id, COUNT(*) FROM items GROUP BY paper_id`, st.ItemsByPaper},
+		{`SELECT t.value, COUNT(*) FROM items i, json_each(i.tags) t GROUP BY t.value`, st.ItemsByTag},
+		{`SELECT COALESCE(NULLIF(section, ''), '` + noSection + `'), COUNT(*) FROM items GROUP BY 1`, st.ItemsBySection},
+	}
+	for _, g := range groups {
+		if err := countGroups(ctx, s.db, g.query, g.dest); err != nil {
+			return st, fmt.Errorf("computing statistics: %w", err)
+		}
+	}
+	st.TopTags = TopCounts(st.ItemsByTag, topTags)
+
+	var err error
+	if st.Confidence, err = s.confidenceHistogram(ctx); err != nil {
+		return st, fmt.Errorf("computing statistics: %w", err)
+	}
+	return st, nil
+}
+
+// countGroups runs a two-column key/count query into dest.
+func countGroups(ctx context.Context, db *sql.DB, query string, dest map[string]int) error {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		var n int
+		if err := rows.Scan(&key, &n); err != nil {
+			return err
+		}
+		dest[key] = n
+	}
+	return rows.Err()
+}
+
+// institutionKey identifies an institution by ROR when known, else by its
+// lowercased name.
+const institutionKey = `COALESCE(NULLIF(ror, ''), LOWER(institution))`
+
+// InstitutionStats returns the papers and distinct authors per
+// institution, most papers first, then most authors.
+func (s *Store) InstitutionStats(ctx context.Context) ([]InstitutionStat, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT MIN(a.institution), MAX(COALESCE(a.ror, '')), MAX(COALESCE(a.country, '')),
+			COUNT(DISTINCT a.paper_id), COUNT(DISTINCT pa.author_id)
+		FROM author_affiliations a
+		JOIN paper_authors pa ON pa.paper_id = a.paper_id AND pa.position = a.position
+		GROUP BY `+institutionKey+`
+		ORDER BY COUNT(DISTINCT a.paper_id) DESC, COUNT(DISTINCT pa.author_id) DESC, MIN(a.institution)`)
+	if err != nil {
+		return nil, fmt.Errorf("counting institutions: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []InstitutionStat
+	for rows.Next() {
+		var st InstitutionStat
+		if err := rows.Scan(&st.Institution, &st.ROR, &st.Country, &st.Papers, &st.Authors); err != nil {
+			return nil, err
+		}
+		stats = append(stats, st)
+	}
+	return stats, rows.Err()
+}
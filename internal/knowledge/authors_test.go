@@ -0,0 +1,133 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestNormalizeAuthorName(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Ada Lovelace", "ada lovelace"},
+		{"  A.  Vaswani ", "a vaswani"},
+		{"Jean-Luc Picard", "jean luc picard"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeAuthorName(tt.in); got != tt.want {
+			t.Errorf("normalizeAuthorName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIngestAuthorsAndAffiliations(t *testing.T) {
+	store, tmpDir := testSetup(t)
+
+	// Paper IDs sort so the name-only record is indexed first and then
+	// merged into the ORCID record.
+	plain := samplePaper("1111.00001")
+	plain.Authors = []string{"Ada Lovelace", "Grace Hopper"}
+	detailed := samplePaper("2222.00002")
+	detailed.Authors = []string{"Ada Lovelace", "Alan Turing"}
+	detailed.AuthorDetails = []types.PaperAuthor{
+		{Name: "Ada Lovelace", ORCID: "0000-0002-1825-0097", Affiliations: []types.Affiliation{
+			{Name: "University of London", ROR: "https://ror.org/04cw6st05", Country: "GB"},
+		}},
+		{Name: "Alan Turing", Affiliations: []types.Affiliation{
+			{Name: "University of Cambridge", ROR: "https://ror.org/013meh722", Country: "GB"},
+			{Name: "University of London", ROR: "https://ror.org/04cw6st05", Country: "GB"},
+		}},
+	}
+	for _, p := range []types.Paper{plain, detailed} {
+		writeExtraction(t, tmpDir, p.ID, sampleItems(p.ID))
+		writePaperMeta(t, tmpDir, p)
+	}
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var adaPapers int
+	if err := store.db.QueryRow(
+		`SELECT COUNT(*) FROM paper_authors WHERE author_id = 'orcid:0000-0002-1825-0097'`,
+	).Scan(&adaPapers); err != nil {
+		t.Fatal(err)
+	}
+	if adaPapers != 2 {
+		t.Errorf("ORCID author linked to %d papers, want 2 after merging", adaPapers)
+	}
+
+	st, err := store.Stats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Papers != 2 || st.Authors != 3 || st.AuthorsWithORCID != 1 || st.Institutions != 2 || st.Items != 8 {
+		t.Errorf("Stats = %+v", st)
+	}
+
+	inst, err := store.InstitutionStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inst) != 2 {
+		t.Fatalf("InstitutionStats = %+v, want 2 institutions", inst)
+	}
+	if inst[0].Institution != "University of London" || inst[0].Papers != 1 || inst[0].Authors != 2 {
+		t.Errorf("first institution = %+v", inst[0])
+	}
+
+	results, err := store.Retrieve(context.Background(), QueryOptions{Institution: "cambridge"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("institution filter returned %d items, want 4", len(results))
+	}
+	for _, r := range results {
+		if r.PaperID != detailed.ID {
+			t.Errorf("institution filter returned item of %s", r.PaperID)
+		}
+	}
+	if results, _ := store.Retrieve(context.Background(), QueryOptions{Institution: "https://ror.org/04cw6st05"}); len(results) != 4 {
+		t.Errorf("ROR filter returned %d items, want 4", len(results))
+	}
+}
+
+func TestReingestDropsOrphanedAuthors(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	paper := samplePaper("2301.07041")
+	paper.Authors = []string{"First Author"}
+	writeExtraction(t, tmpDir, paper.ID, sampleItems(paper.ID))
+	writePaperMeta(t, tmpDir, paper)
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	paper.Authors = []string{"Second Author"}
+	writePaperMeta(t, tmpDir, paper)
+	// Touch the extraction so the paper is re-indexed.
+	writeExtraction(t, tmpDir, paper.ID, sampleItems(paper.ID)[:1])
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	rows, err := store.db.Query(`SELECT name FROM authors ORDER BY name`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var n string
+		rows.Scan(&n)
+		names = append(names, n)
+	}
+	if strings.Join(names, ",") != "Second Author" {
+		t.Errorf("authors = %v, want only Second Author", names)
+	}
+}
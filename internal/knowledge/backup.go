@@ -0,0 +1,301 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// previousSuffix names the copy of the database a restore replaces.
+const previousSuffix = ".bak"
+
+// Backup writes a consistent copy of the database to path (R1.9), which
+// must not exist. The copy is compacted and holds every change committed
+// so far, including those still in the write-ahead log.
+func (s *Store) Backup(ctx context.Context, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("backup %s already exists", path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("checking backup path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating backup directory: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `VACUUM INTO ?`, path); err != nil {
+		return fmt.Errorf("backing up database: %w", err)
+	}
+	return nil
+}
+
+// Restore replaces the database under knowledgeDir with the backup at
+// path (R1.9). The backup must pass an integrity check and have a schema
+// this build knows; an older schema is migrated when the database is next
+// opened. The replaced database is kept beside it as research.db.bak,
+// whose path Restore returns, or "" when there was none. No store may
+// have the database open.
+func Restore(knowledgeDir, path string) (string, error) {
+	if err := checkBackup(path); err != nil {
+		return "", err
+	}
+
+	dbDir := filepath.Join(knowledgeDir, indexDir)
+	if err := os.MkdirAll(dbDir, 0o755); err != nil {
+		return "", fmt.Errorf("creating index directory: %w", err)
+	}
+	dbPath := filepath.Join(dbDir, dbFile)
+
+	// Copy first, so a failed copy leaves the current database alone.
+	tmp, err := os.CreateTemp(dbDir, dbFile+".restore-*")
+	if err != nil {
+		return "", fmt.Errorf("creating restore file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	src, err := os.Open(path)
+	if err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("opening backup: %w", err)
+	}
+	_, err = io.Copy(tmp, src)
+	src.Close()
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", fmt.Errorf("copying backup: %w", err)
+	}
+
+	return replaceDatabase(dbPath, tmp.Name())
+}
+
+// replaceDatabase moves the database at newPath to dbPath, keeping the
+// database it replaces as dbPath.bak, whose path it returns, or "" when
+// there was none.
+func replaceDatabase(dbPath, newPath string) (string, error) {
+	// Keep the current database with its write-ahead log, which SQLite
+	// finds by the same name with -wal appended.
+	var previous string
+	if _, err := os.Stat(dbPath); err == nil {
+		previous = dbPath + previousSuffix
+		for _, ext := range []string{"", "-wal", "-shm"} {
+			os.Remove(previous + ext)
+		}
+		for _, ext := range []string{"", "-wal"} {
+			if err := os.Rename(dbPath+ext, previous+ext); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return "", fmt.Errorf("keeping current database: %w", err)
+			}
+		}
+		os.Remove(dbPath + "-shm")
+	}
+	if err := os.Rename(newPath, dbPath); err != nil {
+		return "", fmt.Errorf("replacing database: %w", err)
+	}
+	return previous, nil
+}
+
+// checkBackup rejects a file that is not an intact knowledge base.
+func checkBackup(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("reading backup: %w", err)
+	}
+	db, err := sql.Open(sqliteDriver, sqliteDSN(path, true))
+	if err != nil {
+		return fmt.Errorf("opening backup: %w", err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+
+	problems, err := integrityCheck(ctx, db)
+	if err != nil {
+		return fmt.Errorf("backup %s is not a SQLite database: %w", path, err)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("backup %s fails the integrity check: %s", path, problems[0])
+	}
+	var items int
+	if err := db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'items'`,
+	).Scan(&items); err != nil || items == 0 {
+		return fmt.Errorf("backup %s is not a knowledge base: it has no items table", path)
+	}
+	version, err := schemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	if version > SchemaVersion() {
+		return fmt.Errorf("backup schema version %d is newer than this build supports (%d): upgrade research-engine", version, SchemaVersion())
+	}
+	return nil
+}
+
+// integrityCheck runs SQLite's integrity check and returns the problems it
+// reports, none for an intact database.
+func integrityCheck(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `PRAGMA integrity_check`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var problems []string
+	for rows.Next() {
+		var msg string
+		if err := rows.Scan(&msg); err != nil {
+			return nil, err
+		}
+		if msg != "ok" {
+			problems = append(problems, msg)
+		}
+	}
+	return problems, rows.Err()
+}
+
+// VerifyReport is the result of checking the database and its agreement
+// with the extraction files (R1.10).
+type VerifyReport struct {
+	// Integrity lists the problems SQLite's integrity check found.
+	Integrity []string `json:"integrity,omitempty"`
+
+	// FullText is the error of the full-text index's integrity check.
+	FullText string `json:"full_text,omitempty"`
+
+	// Papers and Items count the extraction files and their items.
+	Papers int `json:"papers"`
+	Items  int `json:"items"`
+
+	// Unreadable lists extraction files that do not parse.
+	Unreadable []string `json:"unreadable,omitempty"`
+
+	// NotIndexed lists papers with an extraction file that were never
+	// stored, and Orphaned papers stored from an extraction file that is
+	// gone.
+	NotIndexed []string `json:"not_indexed,omitempty"`
+	Orphaned   []string `json:"orphaned,omitempty"`
+
+	// MissingItems are extracted items absent from the database,
+	// ExtraItems stored items no extraction file holds, and ChangedItems
+	// items whose stored type or content differs from the extraction.
+	MissingItems []string `json:"missing_items,omitempty"`
+	ExtraItems   []string `json:"extra_items,omitempty"`
+	ChangedItems []string `json:"changed_items,omitempty"`
+}
+
+// Problems returns the number of problems found.
+func (r VerifyReport) Problems() int {
+	n := len(r.Integrity) + len(r.Unreadable) + len(r.NotIndexed) + len(r.Orphaned) +
+		len(r.MissingItems) + len(r.ExtraItems) + len(r.ChangedItems)
+	if r.FullText != "" {
+		n++
+	}
+	return n
+}
+
+// Verify checks the database's integrity, including its full-text index,
+// and compares its papers and items with the extraction files in
+// knowledge/extracted/ as curated by the files in knowledge/curated/
+// (R1.10, R17.1).
+func (s *Store) Verify(ctx context.Context) (VerifyReport, error) {
+	var report VerifyReport
+	var err error
+	if report.Integrity, err = integrityCheck(ctx, s.db); err != nil {
+		return report, fmt.Errorf("checking integrity: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `INSERT INTO items_fts(items_fts) VALUES('integrity-check')`); err != nil {
+		report.FullText = err.Error()
+	}
+
+	type extracted struct {
+		itemType string
+		content  string
+	}
+	items := make(map[string]extracted)
+	papers := make(map[string]bool)
+	paperIDs, err := s.paperSources()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return report, err
+	}
+	for _, paperID := range paperIDs {
+		papers[paperID] = true
+		result, err := s.readExtraction(paperID)
+		if err != nil {
+			report.Unreadable = append(report.Unreadable, filepath.Base(s.extractionPath(paperID)))
+			continue
+		}
+		c, err := s.readCuration(paperID)
+		if err != nil {
+			report.Unreadable = append(report.Unreadable, filepath.Base(s.curationPath(paperID)))
+			continue
+		}
+		applyCuration(result, c)
+		for _, it := range result.Items {
+			items[it.ID] = extracted{string(it.Type), it.Content}
+		}
+	}
+	report.Papers, report.Items = len(papers), len(items)
+
+	indexed := make(map[string]bool)
+	rows, err := s.db.QueryContext(ctx, `SELECT paper_id FROM indexing_status`)
+	if err != nil {
+		return report, fmt.Errorf("reading indexing status: %w", err)
+	}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return report, err
+		}
+		indexed[id] = true
+		if !papers[id] {
+			report.Orphaned = append(report.Orphaned, id)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return report, err
+	}
+	for id := range papers {
+		if !indexed[id] {
+			report.NotIndexed = append(report.NotIndexed, id)
+		}
+	}
+
+	stored := make(map[string]bool)
+	rows, err = s.db.QueryContext(ctx, `SELECT id, type, content FROM items`)
+	if err != nil {
+		return report, fmt.Errorf("reading items: %w", err)
+	}
+	for rows.Next() {
+		var id, itemType, content string
+		if err := rows.Scan(&id, &itemType, &content); err != nil {
+			rows.Close()
+			return report, err
+		}
+		stored[id] = true
+		want, ok := items[id]
+		switch {
+		case !ok:
+			report.ExtraItems = append(report.ExtraItems, id)
+		case want.itemType != itemType || want.content != content:
+			report.ChangedItems = append(report.ChangedItems, id)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return report, err
+	}
+	for id := range items {
+		if !stored[id] {
+			report.MissingItems = append(report.MissingItems, id)
+		}
+	}
+
+	for _, list := range [][]string{report.Orphaned, report.NotIndexed, report.ExtraItems, report.ChangedItems, report.MissingItems} {
+		sort.Strings(list)
+	}
+	return report, nil
+}
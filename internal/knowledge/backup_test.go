@@ -0,0 +1,136 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestBackupRestore(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestHelper(t, store, tmpDir, "first-paper")
+	ctx := context.Background()
+
+	backup := filepath.Join(tmpDir, "backups", "kb.db")
+	if err := store.Backup(ctx, backup); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Backup(ctx, backup); err == nil {
+		t.Error("backup over an existing file succeeded")
+	}
+
+	ingestHelper(t, store, tmpDir, "second-paper")
+	store.Close()
+
+	knowledgeDir := filepath.Join(tmpDir, "knowledge")
+	previous, err := Restore(knowledgeDir, backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(knowledgeDir, indexDir, dbFile+previousSuffix); previous != want {
+		t.Errorf("previous = %q, want %q", previous, want)
+	}
+
+	store, err = NewStore(types.KnowledgeBaseConfig{KnowledgeDir: knowledgeDir}, filepath.Join(tmpDir, "papers"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	results, err := store.Retrieve(ctx, QueryOptions{Query: "attention"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.PaperID != "first-paper" {
+			t.Errorf("restored database holds %s from after the backup", r.ID)
+		}
+	}
+	if len(results) == 0 {
+		t.Error("restored database has no items")
+	}
+
+	// The replaced database is kept with the second paper.
+	if err := checkBackup(previous); err != nil {
+		t.Fatalf("replaced database: %v", err)
+	}
+	db, err := sql.Open(sqliteDriver, sqliteDSN(previous, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM items WHERE paper_id = 'second-paper'`).Scan(&n); err != nil || n != 4 {
+		t.Errorf("replaced database has %d second-paper items (%v), want 4", n, err)
+	}
+}
+
+func TestRestoreRejectsInvalidBackup(t *testing.T) {
+	tmpDir := t.TempDir()
+	bad := filepath.Join(tmpDir, "notes.db")
+	if err := os.WriteFile(bad, []byte("not a database"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Restore(filepath.Join(tmpDir, "knowledge"), bad); err == nil {
+		t.Error("restore of a non-database succeeded")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "knowledge", indexDir, dbFile)); !os.IsNotExist(err) {
+		t.Error("failed restore created a database")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestHelper(t, store, tmpDir, "kept-paper")
+	ingestHelper(t, store, tmpDir, "gone-paper")
+	ctx := context.Background()
+
+	report, err := store.Verify(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Problems() != 0 || report.Papers != 2 || report.Items != 8 {
+		t.Fatalf("fresh store report = %+v, want 2 papers, 8 items, no problems", report)
+	}
+
+	// Edit an item and drop another without storing, remove a paper's
+	// extraction, and add an unstored paper.
+	items := sampleItems("kept-paper")
+	items[0].Content = "Edited claim"
+	writeExtraction(t, tmpDir, "kept-paper", items[:3])
+	os.Remove(filepath.Join(tmpDir, "knowledge", extractedDir, "gone-paper-items.yaml"))
+	writeExtraction(t, tmpDir, "new-paper", sampleItems("new-paper")[:1])
+
+	report, err = store.Verify(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checks := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"not indexed", report.NotIndexed, []string{"new-paper"}},
+		{"orphaned", report.Orphaned, []string{"gone-paper"}},
+		{"missing", report.MissingItems, []string{"new-paper-claim1"}},
+		{"changed", report.ChangedItems, []string{"kept-paper-claim1"}},
+	}
+	for _, c := range checks {
+		if !slices.Equal(c.got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if len(report.ExtraItems) != 5 || !strings.HasPrefix(report.ExtraItems[0], "gone-paper") {
+		t.Errorf("extra items = %v, want gone-paper's four and kept-paper-result1", report.ExtraItems)
+	}
+	if len(report.Integrity) != 0 || report.FullText != "" {
+		t.Errorf("integrity = %v, full text = %q", report.Integrity, report.FullText)
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Member kinds of a collection.
+const (
+	MemberPaper = "paper"
+	MemberItem  = "item"
+)
+
+// createCollections adds the tables of named collections of papers and
+// items (R16.1). Collections are the researcher's own data, not derived
+// from the extraction files, so Rebuild carries them over.
+func createCollections(tx *sql.Tx) error {
+	for _, stmt := range []string{
+		`CREATE TABLE IF NOT EXISTS collections (
+			name TEXT PRIMARY KEY,
+			description TEXT,
+			created_at TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS collection_members (
+			collection TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			member_id TEXT NOT NULL,
+			added_at TEXT NOT NULL,
+			PRIMARY KEY (collection, kind, member_id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_collection_members_member ON collection_members(member_id)`,
+	} {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("creating collection tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// Collection is a named set of papers and items, such as the reading list
+// of one research project (R16.1).
+type Collection struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	Papers      int    `json:"papers"`
+	Items       int    `json:"items"`
+}
+
+// CollectionMember is a paper or item in a collection.
+type CollectionMember struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+
+	// Label is a paper's title or an item's content; it is empty, and
+	// Missing set, when the paper or item is no longer in the knowledge
+	// base.
+	Label   string `json:"label,omitempty"`
+	Missing bool   `json:"missing,omitempty"`
+	AddedAt string `json:"added_at"`
+}
+
+// CreateCollection creates an empty collection.
+func (s *Store) CreateCollection(ctx context.Context, name, description string) error {
+	if name == "" || strings.TrimSpace(name) != name {
+		return fmt.Errorf("invalid collection name %q: it must be non-empty without surrounding spaces", name)
+	}
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO collections (name, description, created_at) VALUES (?, ?, ?) ON CONFLICT(name) DO NOTHING`,
+		name, description, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("creating collection: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("collection %q already exists", name)
+	}
+	return nil
+}
+
+// DeleteCollection deletes a collection and its membership records; the
+// papers and items stay in the knowledge base.
+func (s *Store) DeleteCollection(ctx context.Context, name string) error {
+	if err := s.checkCollection(ctx, name); err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+	for _, stmt := range []string{
+		`DELETE FROM collection_members WHERE collection = ?`,
+		`DELETE FROM collections WHERE name = ?`,
+	} {
+		if _, err := tx.ExecContext(ctx, stmt, name); err != nil {
+			return fmt.Errorf("deleting collection: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// AddToCollection adds papers and items, named by ID, to a collection and
+// returns how many were not members yet. An ID is looked up as a paper
+// first, then as an item; an ID that is neither is an error and nothing is
+// added.
+func (s *Store) AddToCollection(ctx context.Context, name string, ids []string) (int, error) {
+	if err := s.checkCollection(ctx, name); err != nil {
+		return 0, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	added := 0
+	for _, id := range ids {
+		kind, err := memberKind(ctx, tx, id)
+		if err != nil {
+			return 0, err
+		}
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO collection_members (collection, kind, member_id, added_at) VALUES (?, ?, ?, ?)
+			 ON CONFLICT DO NOTHING`, name, kind, id, now)
+		if err != nil {
+			return 0, fmt.Errorf("adding %s to collection: %w", id, err)
+		}
+		n, _ := res.RowsAffected()
+		added += int(n)
+	}
+	return added, tx.Commit()
+}
+
+// memberKind returns whether id names a paper or an item.
+func memberKind(ctx context.Context, tx *sql.Tx, id string) (string, error) {
+	var n int
+	for _, k := range []struct{ kind, query string }{
+		{MemberPaper, `SELECT COUNT(*) FROM papers WHERE id = ?`},
+		{MemberItem, `SELECT COUNT(*) FROM items WHERE id = ?`},
+	} {
+		if err := tx.QueryRowContext(ctx, k.query, id).Scan(&n); err != nil {
+			return "", fmt.Errorf("looking up %s: %w", id, err)
+		}
+		if n > 0 {
+			return k.kind, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not a paper or item ID in the knowledge base", id)
+}
+
+// RemoveFromCollection removes papers and items, named by ID, from a
+// collection and returns how many were members. Removing a paper leaves
+// its items that were added one by one.
+func (s *Store) RemoveFromCollection(ctx context.Context, name string, ids []string) (int, error) {
+	if err := s.checkCollection(ctx, name); err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, id := range ids {
+		res, err := s.db.ExecContext(ctx,
+			`DELETE FROM collection_members WHERE collection = ? AND member_id = ?`, name, id)
+		if err != nil {
+			return removed, fmt.Errorf("removing %s from collection: %w", id, err)
+		}
+		n, _ := res.RowsAffected()
+		removed += int(n)
+	}
+	return removed, nil
+}
+
+// Collections lists the collections by name with their member counts.
+func (s *Store) Collections(ctx context.Context) ([]Collection, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT c.name, COALESCE(c.description, ''), c.created_at,
+			(SELECT COUNT(*) FROM collection_members m WHERE m.collection = c.name AND m.kind = 'paper'),
+			(SELECT COUNT(*) FROM collection_members m WHERE m.collection = c.name AND m.kind = 'item')
+		FROM collections c ORDER BY c.name`)
+	if err != nil {
+		return nil, fmt.Errorf("listing collections: %w", err)
+	}
+	defer rows.Close()
+	var collections []Collection
+	for rows.Next() {
+		var c Collection
+		if err := rows.Scan(&c.Name, &c.Description, &c.CreatedAt, &c.Papers, &c.Items); err != nil {
+			return nil, fmt.Errorf("scanning collection: %w", err)
+		}
+		collections = append(collections, c)
+	}
+	return collections, rows.Err()
+}
+
+// CollectionMembers lists the members of a collection, papers first, in
+// the order they were added.
+func (s *Store) CollectionMembers(ctx context.Context, name string) ([]CollectionMember, error) {
+	if err := s.checkCollection(ctx, name); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT m.kind, m.member_id, m.added_at,
+			CASE m.kind WHEN 'paper' THEN (SELECT COALESCE(title, '') FROM papers WHERE id = m.member_id)
+				ELSE (SELECT content FROM items WHERE id = m.member_id) END
+		FROM collection_members m WHERE m.collection = ?
+		ORDER BY m.kind = 'item', m.added_at, m.member_id`, name)
+	if err != nil {
+		return nil, fmt.Errorf("listing collection: %w", err)
+	}
+	defer rows.Close()
+	var members []CollectionMember
+	for rows.Next() {
+		var (
+			m     CollectionMember
+			label sql.NullString
+		)
+		if err := rows.Scan(&m.Kind, &m.ID, &m.AddedAt, &label); err != nil {
+			return nil, fmt.Errorf("scanning collection member: %w", err)
+		}
+		m.Label, m.Missing = label.String, !label.Valid
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// checkCollection returns an error when the collection does not exist.
+func (s *Store) checkCollection(ctx context.Context, name string) error {
+	var n int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM collections WHERE name = ?`, name).Scan(&n); err != nil {
+		return fmt.Errorf("looking up collection: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("collection %q not found", name)
+	}
+	return nil
+}
+
+// copyCollections copies the collections of the database at path into the
+// store and returns how many it copied. A database from before collections
+// has none.
+func (s *Store) copyCollections(ctx context.Context, path string) (int, error) {
+	copied, err := s.copyTables(ctx, path, []tableCopy{
+		{"collections", `SELECT name, COALESCE(description, ''), created_at FROM collections`,
+			`INSERT OR IGNORE INTO collections (name, description, created_at) VALUES (?, ?, ?)`},
+		{"collection_members", `SELECT collection, kind, member_id, added_at FROM collection_members`,
+			`INSERT OR IGNORE INTO collection_members (collection, kind, member_id, added_at) VALUES (?, ?, ?, ?)`},
+	})
+	if err != nil || copied == nil {
+		return 0, err
+	}
+	return copied[0], nil
+}
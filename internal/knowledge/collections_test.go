@@ -0,0 +1,154 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestCollections(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ctx := context.Background()
+	ingestHelper(t, store, tmpDir, "paper-a")
+	ingestHelper(t, store, tmpDir, "paper-b")
+
+	if err := store.CreateCollection(ctx, "thesis", "Chapter 2 reading"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateCollection(ctx, "thesis", ""); err == nil {
+		t.Error("duplicate collection created")
+	}
+	if err := store.CreateCollection(ctx, " padded", ""); err == nil {
+		t.Error("name with surrounding spaces accepted")
+	}
+
+	added, err := store.AddToCollection(ctx, "thesis", []string{"paper-a", "paper-b-claim1", "paper-a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added != 2 {
+		t.Errorf("added = %d, want 2", added)
+	}
+	if _, err := store.AddToCollection(ctx, "thesis", []string{"paper-b-def1", "no-such-id"}); err == nil {
+		t.Error("unknown ID accepted")
+	}
+	if _, err := store.AddToCollection(ctx, "missing", []string{"paper-a"}); err == nil {
+		t.Error("added to a missing collection")
+	}
+
+	collections, err := store.Collections(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(collections) != 1 || collections[0].Papers != 1 || collections[0].Items != 1 || collections[0].Description != "Chapter 2 reading" {
+		t.Errorf("collections = %+v, want thesis with 1 paper and 1 item", collections)
+	}
+
+	members, err := store.CollectionMembers(ctx, "thesis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 2 || members[0].Kind != MemberPaper || members[1].ID != "paper-b-claim1" || members[1].Label == "" {
+		t.Errorf("members = %+v, want the paper then the item", members)
+	}
+
+	// The paper contributes all its items; the item adds one more.
+	results, err := store.Retrieve(ctx, QueryOptions{Collection: "thesis"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 5 {
+		t.Errorf("got %d results, want paper-a's 4 items and paper-b-claim1", len(results))
+	}
+	results, err = store.Retrieve(ctx, QueryOptions{Collection: "thesis", Query: "GLUE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].ID != "paper-a-result1" {
+		t.Errorf("full-text results = %v, want paper-a-result1", results)
+	}
+	if _, err := store.Retrieve(ctx, QueryOptions{Collection: "missing"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("err = %v, want collection not found", err)
+	}
+
+	removed, err := store.RemoveFromCollection(ctx, "thesis", []string{"paper-a", "paper-b-def1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Errorf("removed = %d, want 1", removed)
+	}
+	if err := store.DeleteCollection(ctx, "thesis"); err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	store.db.QueryRow(`SELECT COUNT(*) FROM collection_members`).Scan(&n)
+	if n != 0 {
+		t.Errorf("%d membership rows left after delete", n)
+	}
+}
+
+func TestCollectionMembersMissing(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ctx := context.Background()
+	writeExtraction(t, tmpDir, "gone-paper", []types.KnowledgeItem{{
+		ID: "gone-claim", Type: types.ItemClaim, PaperID: "gone-paper", Content: "A claim", Confidence: 0.9,
+	}})
+	writePaperMeta(t, tmpDir, samplePaper("gone-paper"))
+	var buf strings.Builder
+	if _, err := store.Ingest(ctx, &buf); err != nil {
+		t.Fatal(err)
+	}
+	store.CreateCollection(ctx, "list", "")
+	if _, err := store.AddToCollection(ctx, "list", []string{"gone-claim"}); err != nil {
+		t.Fatal(err)
+	}
+	store.db.Exec(`DELETE FROM items WHERE id = 'gone-claim'`)
+
+	members, err := store.CollectionMembers(ctx, "list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || !members[0].Missing {
+		t.Errorf("members = %+v, want the deleted item marked missing", members)
+	}
+}
+
+func TestRebuildKeepsCollections(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ctx := context.Background()
+	ingestHelper(t, store, tmpDir, "kept-paper")
+	store.CreateCollection(ctx, "review", "")
+	if _, err := store.AddToCollection(ctx, "review", []string{"kept-paper", "kept-paper-claim1"}); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	cfg := types.KnowledgeBaseConfig{KnowledgeDir: filepath.Join(tmpDir, "knowledge")}
+	var buf strings.Builder
+	summary, err := Rebuild(ctx, cfg, filepath.Join(tmpDir, "papers"), &buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Collections != 1 {
+		t.Errorf("carried %d collections, want 1; output:\n%s", summary.Collections, buf.String())
+	}
+
+	store, err = NewStore(cfg, filepath.Join(tmpDir, "papers"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	members, err := store.CollectionMembers(ctx, "review")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 2 {
+		t.Errorf("members after rebuild = %+v, want 2", members)
+	}
+}
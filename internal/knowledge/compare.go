@@ -0,0 +1,291 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// Relations between two compared items (R13.2).
+const (
+	RelationAgree      = "agree"
+	RelationContradict = "contradict"
+	RelationOrthogonal = "orthogonal"
+)
+
+const (
+	comparePrefix = "compare-"
+
+	// DefaultCompareItems is the number of claim and result items on a
+	// topic that are compared pairwise.
+	DefaultCompareItems = 12
+
+	// comparePairsPerCall is the number of pairs judged in one AI call.
+	comparePairsPerCall = 10
+
+	compareMaxTokens = 4096
+)
+
+// Completer sends a prompt to an AI model and returns its reply. The
+// extraction backends implement it.
+type Completer interface {
+	Complete(ctx context.Context, prompt string, maxTokens int) (string, error)
+}
+
+// Comparison is the result of comparing the claim and result items on a
+// topic pairwise across papers (R13).
+type Comparison struct {
+	Topic    string         `json:"topic"`
+	Compared time.Time      `json:"compared"`
+	Items    []QueryResult  `json:"items"`
+	Pairs    []ComparedPair `json:"pairs"`
+}
+
+// ComparedPair is the relation the AI backend found between two items
+// from different papers (R13.2).
+type ComparedPair struct {
+	A           string `json:"a"`
+	B           string `json:"b"`
+	Relation    string `json:"relation"`
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// Count returns the number of pairs with relation.
+func (c Comparison) Count(relation string) int {
+	n := 0
+	for _, p := range c.Pairs {
+		if p.Relation == relation {
+			n++
+		}
+	}
+	return n
+}
+
+// ComparisonPath returns the report file of the comparison on topic:
+// knowledgeDir/notes/compare-<slug>.md.
+func ComparisonPath(knowledgeDir, topic string) (string, error) {
+	slug := topicSlug(topic)
+	if slug == "" {
+		return "", fmt.Errorf("topic %q has no letters or digits", topic)
+	}
+	return filepath.Join(knowledgeDir, notesDir, comparePrefix+slug+".md"), nil
+}
+
+// CompareCandidates returns the claim and result items on topic that
+// pass the filters in opts, at most maxItems, best match first (R13.1).
+// Items are found by a full-text search of the topic's words or, with an
+// embedder, by a hybrid semantic and full-text search.
+func (s *Store) CompareCandidates(ctx context.Context, e Embedder, topic string, opts QueryOptions, maxItems int) ([]QueryResult, error) {
+	if maxItems <= 0 {
+		maxItems = DefaultCompareItems
+	}
+	opts.Query = FullTextTerms(topic)
+	if opts.Query == "" {
+		return nil, fmt.Errorf("topic %q has no searchable terms", topic)
+	}
+	// Other item types are dropped below, so ask for more.
+	opts.MaxResults = maxItems * 4
+
+	var results []QueryResult
+	var err error
+	if e != nil {
+		results, err = s.RetrieveSemantic(ctx, e, topic, opts)
+	} else {
+		results, err = s.Retrieve(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var items []QueryResult
+	for _, r := range results {
+		if r.Type != types.ItemClaim && r.Type != types.ItemResult {
+			continue
+		}
+		items = append(items, r)
+		if len(items) == maxItems {
+			break
+		}
+	}
+	return items, nil
+}
+
+// Compare asks c how each pair of items from different papers relates:
+// agreeing, contradicting, or orthogonal (R13.2). Pairs are judged in
+// batches, one AI call each. Items from versions of the same paper are
+// not compared.
+func Compare(ctx context.Context, c Completer, topic string, items []QueryResult) (Comparison, error) {
+	cmp := Comparison{Topic: topic, Compared: time.Now().UTC(), Items: items}
+	var pairs [][2]int
+	for i := range items {
+		for j := i + 1; j < len(items); j++ {
+			if work(items[i]) != work(items[j]) {
+				pairs = append(pairs, [2]int{i, j})
+			}
+		}
+	}
+	for start := 0; start < len(pairs); start += comparePairsPerCall {
+		batch := pairs[start:min(start+comparePairsPerCall, len(pairs))]
+		text, err := c.Complete(ctx, comparePrompt(topic, items, batch), compareMaxTokens)
+		if err != nil {
+			return cmp, fmt.Errorf("comparing items: %w", err)
+		}
+		judged, err := parseComparison(text, len(batch))
+		if err != nil {
+			return cmp, err
+		}
+		for k, p := range batch {
+			judged[k].A, judged[k].B = items[p[0]].ID, items[p[1]].ID
+			cmp.Pairs = append(cmp.Pairs, judged[k])
+		}
+	}
+	return cmp, nil
+}
+
+// work identifies the paper an item comes from, counting versions of a
+// paper as one.
+func work(r QueryResult) string {
+	if r.CanonicalID != "" {
+		return r.CanonicalID
+	}
+	return r.PaperID
+}
+
+// comparePrompt asks for the relation of each pair in batch.
+func comparePrompt(topic string, items []QueryResult, batch [][2]int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `You are comparing findings from different research papers on the topic %q.
+
+For each numbered pair of statements below, decide whether they:
+- "agree": support the same conclusion, or report consistent results;
+- "contradict": make incompatible claims, or report results that cannot both hold under the stated conditions;
+- "orthogonal": address different questions, so neither supports nor contradicts the other.
+
+Judge only what the statements say. Differences in setting (dataset, model size, metric) that explain different numbers make a pair orthogonal, not contradictory.
+
+Reply with only a JSON array, one object per pair in order:
+[{"pair": 1, "relation": "agree", "explanation": "one sentence"}]
+
+`, topic)
+	for n, p := range batch {
+		a, bi := items[p[0]], items[p[1]]
+		fmt.Fprintf(&b, "Pair %d:\nA (%s, %s): %s\nB (%s, %s): %s\n\n",
+			n+1, a.PaperID, a.Type, a.Content, bi.PaperID, bi.Type, bi.Content)
+	}
+	return b.String()
+}
+
+// parseComparison parses the judged pairs from a reply, which may wrap
+// the JSON array in prose or a code fence.
+func parseComparison(text string, want int) ([]ComparedPair, error) {
+	start, end := strings.Index(text, "["), strings.LastIndex(text, "]")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("parsing comparison: no JSON array in reply")
+	}
+	var reply []struct {
+		Pair        int    `json:"pair"`
+		Relation    string `json:"relation"`
+		Explanation string `json:"explanation"`
+	}
+	if err := json.Unmarshal([]byte(text[start:end+1]), &reply); err != nil {
+		return nil, fmt.Errorf("parsing comparison: %w", err)
+	}
+	pairs := make([]ComparedPair, want)
+	seen := make([]bool, want)
+	for _, r := range reply {
+		if r.Pair < 1 || r.Pair > want {
+			return nil, fmt.Errorf("parsing comparison: reply judges pair %d of %d", r.Pair, want)
+		}
+		relation := strings.ToLower(strings.TrimSpace(r.Relation))
+		switch relation {
+		case "agrees", "agreeing":
+			relation = RelationAgree
+		case "contradicts", "contradicting", "contradiction":
+			relation = RelationContradict
+		}
+		if relation != RelationAgree && relation != RelationContradict && relation != RelationOrthogonal {
+			return nil, fmt.Errorf("parsing comparison: pair %d has unknown relation %q", r.Pair, r.Relation)
+		}
+		pairs[r.Pair-1] = ComparedPair{Relation: relation, Explanation: strings.TrimSpace(r.Explanation)}
+		seen[r.Pair-1] = true
+	}
+	for i, ok := range seen {
+		if !ok {
+			return nil, fmt.Errorf("parsing comparison: reply does not judge pair %d", i+1)
+		}
+	}
+	return pairs, nil
+}
+
+// WriteComparisonReport renders cmp as Markdown: the contradictions, then
+// the agreements, each pair with both statements and their provenance,
+// and the number of orthogonal pairs (R13.3).
+func WriteComparisonReport(w io.Writer, cmp Comparison) error {
+	byID := make(map[string]QueryResult, len(cmp.Items))
+	papers := make(map[string]bool)
+	for _, r := range cmp.Items {
+		byID[r.ID] = r
+		papers[work(r)] = true
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Comparison: %s\n\n", cmp.Topic)
+	fmt.Fprintf(&b, "%d claim and result items from %d papers, %d pairs compared on %s: %d contradict, %d agree, %d orthogonal.\n",
+		len(cmp.Items), len(papers), len(cmp.Pairs), cmp.Compared.Format(time.DateOnly),
+		cmp.Count(RelationContradict), cmp.Count(RelationAgree), cmp.Count(RelationOrthogonal))
+
+	sections := []struct {
+		title    string
+		relation string
+	}{
+		{"Contradictions", RelationContradict},
+		{"Agreements", RelationAgree},
+	}
+	for _, sec := range sections {
+		if cmp.Count(sec.relation) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n", sec.title)
+		for _, p := range cmp.Pairs {
+			if p.Relation != sec.relation {
+				continue
+			}
+			b.WriteString("\n")
+			for _, id := range []string{p.A, p.B} {
+				r := byID[id]
+				fmt.Fprintf(&b, "- %s — %s (`%s`)\n", r.Content, compareSource(r), r.ID)
+			}
+			if p.Explanation != "" {
+				fmt.Fprintf(&b, "\n  %s\n", p.Explanation)
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// compareSource cites the paper, section, and page of an item.
+func compareSource(r QueryResult) string {
+	title := r.PaperTitle
+	if title == "" {
+		title = r.PaperID
+	}
+	parts := []string{"*" + title + "*"}
+	if r.Section != "" {
+		parts = append(parts, r.Section)
+	}
+	if r.Page > 0 {
+		parts = append(parts, fmt.Sprintf("p. %d", r.Page))
+	}
+	if r.Confidence > 0 && r.Confidence < 1 {
+		parts = append(parts, fmt.Sprintf("confidence %.2f", r.Confidence))
+	}
+	return strings.Join(parts, ", ")
+}
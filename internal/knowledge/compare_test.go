@@ -0,0 +1,139 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// judgeCompleter labels a pair as contradicting when exactly one of its
+// statements says "not", and agreeing otherwise.
+type judgeCompleter struct {
+	calls int
+}
+
+var pairRe = regexp.MustCompile(`Pair (\d+):\nA \([^)]*\): (.*)\nB \([^)]*\): (.*)\n`)
+
+func (j *judgeCompleter) Complete(_ context.Context, prompt string, _ int) (string, error) {
+	j.calls++
+	var out []string
+	for _, m := range pairRe.FindAllStringSubmatch(prompt, -1) {
+		relation := RelationAgree
+		if strings.Contains(m[2], " not ") != strings.Contains(m[3], " not ") {
+			relation = RelationContradict
+		}
+		out = append(out, fmt.Sprintf(`{"pair": %s, "relation": %q, "explanation": "Judged."}`, m[1], relation))
+	}
+	return "```json\n[" + strings.Join(out, ",") + "]\n```", nil
+}
+
+func compareSetup(t *testing.T) *Store {
+	t.Helper()
+	store, tmpDir := testSetup(t)
+	papers := map[string][]types.KnowledgeItem{
+		"p1": {
+			{ID: "p1-a", Type: types.ItemClaim, Content: "Pruning does improve robustness", Confidence: 0.9},
+			{ID: "p1-b", Type: types.ItemResult, Content: "Pruning half the weights keeps accuracy and robustness", Confidence: 0.9},
+			{ID: "p1-m", Type: types.ItemMethod, Content: "We prune by magnitude for robustness", Confidence: 0.9},
+		},
+		"p2": {{ID: "p2-a", Type: types.ItemClaim, Content: "Pruning does not improve robustness", Confidence: 0.8}},
+		"p3": {{ID: "p3-a", Type: types.ItemResult, Content: "Pruned models match dense robustness", Confidence: 0.7}},
+	}
+	for id, items := range papers {
+		for i := range items {
+			items[i].PaperID = id
+			items[i].Section = "Results"
+		}
+		writeExtraction(t, tmpDir, id, items)
+		writePaperMeta(t, tmpDir, types.Paper{ID: id, Title: "Paper " + id})
+	}
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestCompare(t *testing.T) {
+	store := compareSetup(t)
+	ctx := context.Background()
+
+	items, err := store.CompareCandidates(ctx, nil, "Does pruning improve robustness?", QueryOptions{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("got %d candidates, want the 4 claim and result items", len(items))
+	}
+
+	judge := &judgeCompleter{}
+	cmp, err := Compare(ctx, judge, "pruning and robustness", items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Items p1-a and p1-b come from the same paper, leaving 5 pairs.
+	if len(cmp.Pairs) != 5 || judge.calls != 1 {
+		t.Fatalf("got %d pairs in %d calls, want 5 in 1", len(cmp.Pairs), judge.calls)
+	}
+	for _, p := range cmp.Pairs {
+		if p.A[:2] == p.B[:2] {
+			t.Errorf("pair %s, %s compares items of one paper", p.A, p.B)
+		}
+	}
+	// p2-a contradicts p1-a, p1-b, and p3-a.
+	if n := cmp.Count(RelationContradict); n != 3 {
+		t.Errorf("%d contradictions, want 3", n)
+	}
+
+	var report strings.Builder
+	if err := WriteComparisonReport(&report, cmp); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# Comparison: pruning and robustness", "## Contradictions", "## Agreements",
+		"Pruning does not improve robustness — *Paper p2*, Results, confidence 0.80 (`p2-a`)", "3 contradict, 2 agree, 0 orthogonal"} {
+		if !strings.Contains(report.String(), want) {
+			t.Errorf("report lacks %q:\n%s", want, report.String())
+		}
+	}
+}
+
+func TestCompareBatches(t *testing.T) {
+	items := make([]QueryResult, 6)
+	for i := range items {
+		items[i] = QueryResult{KnowledgeItem: types.KnowledgeItem{ID: fmt.Sprint(i), PaperID: fmt.Sprint("p", i), Content: "A claim"}}
+	}
+	judge := &judgeCompleter{}
+	cmp, err := Compare(context.Background(), judge, "topic", items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cmp.Pairs) != 15 || judge.calls != 2 {
+		t.Errorf("got %d pairs in %d calls, want 15 in 2", len(cmp.Pairs), judge.calls)
+	}
+}
+
+func TestParseComparison(t *testing.T) {
+	pairs, err := parseComparison(`Here you go: [{"pair": 2, "relation": "Contradicts"}, {"pair": 1, "relation": "orthogonal"}]`, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pairs[0].Relation != RelationOrthogonal || pairs[1].Relation != RelationContradict {
+		t.Errorf("pairs = %+v", pairs)
+	}
+	for _, bad := range []string{
+		`no array`,
+		`[{"pair": 1, "relation": "agree"}]`,
+		`[{"pair": 1, "relation": "agree"}, {"pair": 3, "relation": "agree"}]`,
+		`[{"pair": 1, "relation": "agree"}, {"pair": 2, "relation": "maybe"}]`,
+	} {
+		if _, err := parseComparison(bad, 2); err == nil {
+			t.Errorf("parseComparison(%s) succeeded, want an error", bad)
+		}
+	}
+}
@@ -0,0 +1,427 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+const (
+	curatedDir    = "curated"
+	curatedSuffix = "-curated.yaml"
+)
+
+// addCurated adds the curated column that marks hand-curated items
+// (R17.1).
+func addCurated(tx *sql.Tx) error {
+	return addMissingColumns(tx, "items", map[string]string{
+		"curated": "INTEGER NOT NULL DEFAULT 0",
+	})
+}
+
+// addItemStatus adds the review status columns of items (R17.2).
+func addItemStatus(tx *sql.Tx) error {
+	return addMissingColumns(tx, "items", map[string]string{
+		"status":      "TEXT NOT NULL DEFAULT '" + string(types.StatusUnverified) + "'",
+		"status_note": "TEXT",
+	})
+}
+
+// ItemEdit lists the fields EditItem changes; nil fields are kept.
+type ItemEdit struct {
+	Type       *types.KnowledgeItemType
+	Content    *string
+	Section    *string
+	Page       *int
+	Confidence *float64
+	Tags       *[]string
+}
+
+// paperSources returns the IDs of the papers with an extraction file, a
+// curation file, or both, sorted.
+func (s *Store) paperSources() ([]string, error) {
+	extractDir := filepath.Join(s.knowledgeDir, extractedDir)
+	entries, err := os.ReadDir(extractDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading extraction directory %s: %w", extractDir, err)
+	}
+	curated, err := os.ReadDir(filepath.Join(s.knowledgeDir, curatedDir))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("reading curation directory: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, list := range []struct {
+		entries []os.DirEntry
+		suffix  string
+	}{{entries, "-items.yaml"}, {curated, curatedSuffix}} {
+		for _, entry := range list.entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), list.suffix) {
+				continue
+			}
+			if id := strings.TrimSuffix(entry.Name(), list.suffix); !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// extractionPath and curationPath return a paper's extraction and
+// curation files.
+func (s *Store) extractionPath(paperID string) string {
+	return filepath.Join(s.knowledgeDir, extractedDir, paperID+"-items.yaml")
+}
+
+func (s *Store) curationPath(paperID string) string {
+	return filepath.Join(s.knowledgeDir, curatedDir, paperID+curatedSuffix)
+}
+
+// sourceModTime returns the modification times of a paper's extraction
+// and curation files, so that a change to either one reindexes the paper
+// (R5.1).
+func (s *Store) sourceModTime(paperID string) (string, error) {
+	var parts []string
+	for i, path := range []string{s.extractionPath(paperID), s.curationPath(paperID)} {
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		t := info.ModTime().UTC().Format(time.RFC3339Nano)
+		if i == 1 {
+			t = "curated " + t
+		}
+		parts = append(parts, t)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// readExtraction reads a paper's extraction file; a paper with only a
+// curation file has an empty one.
+func (s *Store) readExtraction(paperID string) (*types.ExtractionResult, error) {
+	var result types.ExtractionResult
+	data, err := os.ReadFile(s.extractionPath(paperID))
+	if errors.Is(err, os.ErrNotExist) {
+		return &result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("parse error: %w", err)
+	}
+	return &result, nil
+}
+
+// readCuration reads a paper's curation file; a paper without one has an
+// empty curation.
+func (s *Store) readCuration(paperID string) (*types.Curation, error) {
+	c := &types.Curation{PaperID: paperID}
+	data, err := os.ReadFile(s.curationPath(paperID))
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("%s: parse error: %w", filepath.Base(s.curationPath(paperID)), err)
+	}
+	return c, nil
+}
+
+// writeCuration writes a paper's curation file, removing it when the
+// curation is empty.
+func (s *Store) writeCuration(c *types.Curation) error {
+	path := s.curationPath(c.PaperID)
+	if len(c.Items) == 0 && len(c.Deleted) == 0 && len(c.Reviews) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("marshaling curation: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadPaperItems reads a paper's extraction with its curation applied.
+func (s *Store) loadPaperItems(paperID string) (*types.ExtractionResult, error) {
+	result, err := s.readExtraction(paperID)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.readCuration(paperID)
+	if err != nil {
+		return nil, err
+	}
+	applyCuration(result, c)
+	return result, nil
+}
+
+// applyCuration applies a curation to an extraction: deleted items are
+// dropped, edited items replace the extracted ones in place, added items
+// follow, and reviewed items get their status.
+func applyCuration(result *types.ExtractionResult, c *types.Curation) {
+	curated := make(map[string]types.KnowledgeItem, len(c.Items))
+	for _, it := range c.Items {
+		it.PaperID, it.Curated = c.PaperID, true
+		curated[it.ID] = it
+	}
+
+	items := result.Items[:0:0]
+	for _, it := range result.Items {
+		if slices.Contains(c.Deleted, it.ID) {
+			continue
+		}
+		if edited, ok := curated[it.ID]; ok {
+			it = edited
+			delete(curated, it.ID)
+		}
+		items = append(items, it)
+	}
+	for _, it := range c.Items {
+		if _, ok := curated[it.ID]; ok {
+			items = append(items, curated[it.ID])
+		}
+	}
+	for i := range items {
+		for _, r := range c.Reviews {
+			if r.ItemID == items[i].ID {
+				items[i].Status, items[i].StatusNote = r.Status, r.Note
+			}
+		}
+	}
+	result.Items = items
+}
+
+// AddItem adds a hand-written item, such as a note from a talk, to a
+// paper and stores it (R17.1). The paper need not have an extraction
+// file. The item gets an ID from its paper, section, and content, as
+// extracted items do, and a confidence of 1 unless one is given. It
+// returns the stored item.
+func (s *Store) AddItem(ctx context.Context, item types.KnowledgeItem) (types.KnowledgeItem, error) {
+	if item.PaperID == "" {
+		return item, fmt.Errorf("an added item needs a paper ID")
+	}
+	if item.Confidence == 0 {
+		item.Confidence = 1
+	}
+	if err := checkItem(item); err != nil {
+		return item, err
+	}
+	if item.ID == "" {
+		h := sha256.Sum256([]byte(item.PaperID + item.Section + item.Content))
+		item.ID = fmt.Sprintf("%x", h)[:12]
+	}
+	if _, err := s.ItemPaperID(ctx, item.ID); err == nil {
+		return item, fmt.Errorf("item %s already exists; edit it instead", item.ID)
+	}
+	item.Curated = true
+
+	c, err := s.readCuration(item.PaperID)
+	if err != nil {
+		return item, err
+	}
+	c.Items = append(c.Items, item)
+	if err := s.writeCuration(c); err != nil {
+		return item, err
+	}
+	return item, s.indexPaper(ctx, item.PaperID)
+}
+
+// EditItem changes the fields of an item set in edit and stores it
+// (R17.1). The item keeps its ID, so collections and citations of it stay
+// valid, and is marked curated. It returns the edited item.
+func (s *Store) EditItem(ctx context.Context, id string, edit ItemEdit) (types.KnowledgeItem, error) {
+	paperID, err := s.ItemPaperID(ctx, id)
+	if err != nil {
+		return types.KnowledgeItem{}, err
+	}
+	result, err := s.loadPaperItems(paperID)
+	if err != nil {
+		return types.KnowledgeItem{}, err
+	}
+	i := slices.IndexFunc(result.Items, func(it types.KnowledgeItem) bool { return it.ID == id })
+	if i < 0 {
+		return types.KnowledgeItem{}, fmt.Errorf("item %s is not in the extraction or curation files of %s; run knowledge store", id, paperID)
+	}
+	item := result.Items[i]
+	if edit.Type != nil {
+		item.Type = *edit.Type
+	}
+	if edit.Content != nil {
+		item.Content = *edit.Content
+		// The resolved text was derived from the old content.
+		item.ResolvedContent = ""
+	}
+	if edit.Section != nil {
+		item.Section = *edit.Section
+	}
+	if edit.Page != nil {
+		item.Page = *edit.Page
+	}
+	if edit.Confidence != nil {
+		item.Confidence = *edit.Confidence
+	}
+	if edit.Tags != nil {
+		item.Tags = *edit.Tags
+	}
+	if err := checkItem(item); err != nil {
+		return item, err
+	}
+	item.Curated = true
+
+	c, err := s.readCuration(paperID)
+	if err != nil {
+		return item, err
+	}
+	if j := slices.IndexFunc(c.Items, func(it types.KnowledgeItem) bool { return it.ID == id }); j >= 0 {
+		c.Items[j] = item
+	} else {
+		c.Items = append(c.Items, item)
+	}
+	if err := s.writeCuration(c); err != nil {
+		return item, err
+	}
+	return item, s.indexPaper(ctx, paperID)
+}
+
+// DeleteItem removes an item from the knowledge base (R17.1). An
+// extracted item is recorded as deleted, so storing its paper again does
+// not bring it back; an added item is dropped from the curation file.
+func (s *Store) DeleteItem(ctx context.Context, id string) error {
+	paperID, err := s.ItemPaperID(ctx, id)
+	if err != nil {
+		return err
+	}
+	result, err := s.readExtraction(paperID)
+	if err != nil {
+		return err
+	}
+	c, err := s.readCuration(paperID)
+	if err != nil {
+		return err
+	}
+	c.Items = slices.DeleteFunc(c.Items, func(it types.KnowledgeItem) bool { return it.ID == id })
+	c.Reviews = slices.DeleteFunc(c.Reviews, func(r types.ItemReview) bool { return r.ItemID == id })
+	extracted := slices.ContainsFunc(result.Items, func(it types.KnowledgeItem) bool { return it.ID == id })
+	if extracted && !slices.Contains(c.Deleted, id) {
+		c.Deleted = append(c.Deleted, id)
+	}
+	if err := s.writeCuration(c); err != nil {
+		return err
+	}
+	return s.indexPaper(ctx, paperID)
+}
+
+// ReviewItems sets the review status of items (R17.2): verified after the
+// researcher checked an item against its paper, disputed with a note on
+// what is wrong, or unverified to clear a review. Reviews are kept in the
+// papers' curation files, so they survive storing the papers again.
+func (s *Store) ReviewItems(ctx context.Context, ids []string, status types.ItemStatus, note string) error {
+	switch status {
+	case types.StatusUnverified, types.StatusVerified, types.StatusDisputed:
+	default:
+		return fmt.Errorf("invalid item status %q: use unverified, verified, or disputed", status)
+	}
+	byPaper := make(map[string][]string)
+	var papers []string
+	for _, id := range ids {
+		paperID, err := s.ItemPaperID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if byPaper[paperID] == nil {
+			papers = append(papers, paperID)
+		}
+		byPaper[paperID] = append(byPaper[paperID], id)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, paperID := range papers {
+		c, err := s.readCuration(paperID)
+		if err != nil {
+			return err
+		}
+		for _, id := range byPaper[paperID] {
+			c.Reviews = slices.DeleteFunc(c.Reviews, func(r types.ItemReview) bool { return r.ItemID == id })
+			if status != types.StatusUnverified {
+				c.Reviews = append(c.Reviews, types.ItemReview{ItemID: id, Status: status, Note: note, Reviewed: now})
+			}
+		}
+		if err := s.writeCuration(c); err != nil {
+			return err
+		}
+		if err := s.indexPaper(ctx, paperID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkItem rejects an item with an unknown type, no content, or a
+// confidence outside 0 to 1.
+func checkItem(item types.KnowledgeItem) error {
+	switch item.Type {
+	case types.ItemClaim, types.ItemMethod, types.ItemDefinition, types.ItemResult:
+	default:
+		return fmt.Errorf("invalid item type %q: use claim, method, definition, or result", item.Type)
+	}
+	if strings.TrimSpace(item.Content) == "" {
+		return fmt.Errorf("item content is empty")
+	}
+	if item.Confidence < 0 || item.Confidence > 1 {
+		return fmt.Errorf("invalid confidence %v: use a value from 0 to 1", item.Confidence)
+	}
+	return nil
+}
+
+// indexPaper stores one paper from its extraction and curation files, as
+// Ingest would, and refreshes the duplicate clusters and export.yaml.
+func (s *Store) indexPaper(ctx context.Context, paperID string) error {
+	modTime, err := s.sourceModTime(paperID)
+	if err != nil {
+		return err
+	}
+	result, err := s.loadPaperItems(paperID)
+	if err != nil {
+		return err
+	}
+	paper := loadPaperMetadata(filepath.Join(s.papersDir, metadataDir), paperID)
+	if err := s.ingestPaper(ctx, paperID, result, paper, modTime, true); err != nil {
+		return err
+	}
+	if _, err := s.DetectDuplicates(ctx, 0); err != nil {
+		return fmt.Errorf("detecting duplicates: %w", err)
+	}
+	return s.ExportYAML(ctx, QueryOptions{})
+}
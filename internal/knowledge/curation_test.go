@@ -0,0 +1,228 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// storedItem returns the stored item with id, or false.
+func storedItem(t *testing.T, store *Store, paperID, id string) (QueryResult, bool) {
+	t.Helper()
+	results, err := store.Retrieve(context.Background(), QueryOptions{PaperID: paperID, MaxResults: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return QueryResult{}, false
+}
+
+// reextract rewrites a paper's extraction file as a new extraction run
+// would, with a later modification time.
+func reextract(t *testing.T, tmpDir, paperID string) {
+	t.Helper()
+	writeExtraction(t, tmpDir, paperID, sampleItems(paperID))
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(filepath.Join(tmpDir, "knowledge", extractedDir, paperID+"-items.yaml"), later, later)
+}
+
+func TestItemCuration(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ctx := context.Background()
+	ingestHelper(t, store, tmpDir, "cur")
+
+	added, err := store.AddItem(ctx, types.KnowledgeItem{
+		PaperID: "cur", Type: types.ItemClaim, Content: "The speaker reported a 2x speedup", Section: "Talk",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added.ID == "" || added.Confidence != 1 || !added.Curated {
+		t.Errorf("added item = %+v, want an ID, confidence 1, and curated", added)
+	}
+	if _, err := store.AddItem(ctx, added); err == nil {
+		t.Error("item added twice")
+	}
+
+	content := "Efficient attention reduces computation by 40% (corrected)"
+	tags := []string{"attention"}
+	if _, err := store.EditItem(ctx, "cur-claim1", ItemEdit{Content: &content, Tags: &tags}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteItem(ctx, "cur-method1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new extraction of the paper keeps the hand edits.
+	reextract(t, tmpDir, "cur")
+	var buf strings.Builder
+	if summary, err := store.Ingest(ctx, &buf); err != nil || summary.Updated != 1 {
+		t.Fatalf("re-ingest = %+v, %v; want one update", summary, err)
+	}
+	if r, ok := storedItem(t, store, "cur", "cur-claim1"); !ok || r.Content != content || len(r.Tags) != 1 || !r.Curated {
+		t.Errorf("edited item = %+v, want the edit kept", r)
+	}
+	if _, ok := storedItem(t, store, "cur", "cur-method1"); ok {
+		t.Error("deleted item came back")
+	}
+	if r, ok := storedItem(t, store, "cur", added.ID); !ok || !r.Curated {
+		t.Error("added item lost")
+	}
+	if r, _ := storedItem(t, store, "cur", "cur-def1"); r.Curated {
+		t.Error("untouched item marked curated")
+	}
+
+	report, err := store.Verify(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Problems() != 0 {
+		t.Errorf("verify after curation: %+v", report)
+	}
+
+	// Deleting the added item empties the curation of everything but
+	// the edit and the deletion.
+	if err := store.DeleteItem(ctx, added.ID); err != nil {
+		t.Fatal(err)
+	}
+	c, err := store.readCuration("cur")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Items) != 1 || len(c.Deleted) != 1 {
+		t.Errorf("curation = %+v, want the edit and the deletion", c)
+	}
+}
+
+func TestAddItemWithoutExtraction(t *testing.T) {
+	store, _ := testSetup(t)
+	ctx := context.Background()
+
+	item, err := store.AddItem(ctx, types.KnowledgeItem{
+		PaperID: "talk-2026", Type: types.ItemMethod, Content: "Distill the router first", Tags: []string{"moe"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := storedItem(t, store, "talk-2026", item.ID); !ok {
+		t.Fatal("added item not stored")
+	}
+
+	// Storing again finds the curation file unchanged.
+	var buf strings.Builder
+	summary, err := store.Ingest(ctx, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Skipped != 1 {
+		t.Errorf("summary = %+v, want the paper skipped", summary)
+	}
+
+	if err := store.DeleteItem(ctx, item.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(store.curationPath("talk-2026")); !os.IsNotExist(err) {
+		t.Errorf("empty curation file left: %v", err)
+	}
+}
+
+func TestItemCurationErrors(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ctx := context.Background()
+	ingestHelper(t, store, tmpDir, "bad")
+
+	for _, item := range []types.KnowledgeItem{
+		{PaperID: "bad", Type: "opinion", Content: "x"},
+		{PaperID: "bad", Type: types.ItemClaim, Content: "  "},
+		{PaperID: "bad", Type: types.ItemClaim, Content: "x", Confidence: 1.5},
+		{Type: types.ItemClaim, Content: "x"},
+	} {
+		if _, err := store.AddItem(ctx, item); err == nil {
+			t.Errorf("AddItem(%+v) accepted", item)
+		}
+	}
+	empty := ""
+	if _, err := store.EditItem(ctx, "bad-claim1", ItemEdit{Content: &empty}); err == nil {
+		t.Error("edit to empty content accepted")
+	}
+	if _, err := store.EditItem(ctx, "no-such-item", ItemEdit{}); err == nil {
+		t.Error("edit of unknown item accepted")
+	}
+	if err := store.DeleteItem(ctx, "no-such-item"); err == nil {
+		t.Error("delete of unknown item accepted")
+	}
+	if _, err := os.Stat(store.curationPath("bad")); !os.IsNotExist(err) {
+		t.Error("failed edits wrote a curation file")
+	}
+}
+
+func TestReviewItems(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ctx := context.Background()
+	ingestHelper(t, store, tmpDir, "rev")
+
+	if err := store.ReviewItems(ctx, []string{"rev-claim1", "rev-def1"}, types.StatusVerified, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ReviewItems(ctx, []string{"rev-result1"}, types.StatusDisputed, "Table 3 reports 91.2, not 92.1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reviews survive a new extraction of the paper.
+	reextract(t, tmpDir, "rev")
+	var buf strings.Builder
+	if _, err := store.Ingest(ctx, &buf); err != nil {
+		t.Fatal(err)
+	}
+	for status, want := range map[types.ItemStatus]int{types.StatusVerified: 2, types.StatusDisputed: 1, types.StatusUnverified: 1} {
+		results, err := store.Retrieve(ctx, QueryOptions{Status: status})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != want {
+			t.Errorf("%s items = %d, want %d", status, len(results), want)
+		}
+	}
+	if r, _ := storedItem(t, store, "rev", "rev-result1"); r.Status != types.StatusDisputed || r.StatusNote == "" {
+		t.Errorf("disputed item = %+v", r.KnowledgeItem)
+	}
+
+	rows, err := store.exportRows(ctx, QueryOptions{PaperID: "rev"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range rows {
+		if r.ID == "rev-result1" && (r.Status != "disputed" || r.StatusNote != "Table 3 reports 91.2, not 92.1") {
+			t.Errorf("exported row = %+v, want the dispute", r)
+		}
+	}
+
+	// Clearing a review makes the item unverified again.
+	if err := store.ReviewItems(ctx, []string{"rev-claim1"}, types.StatusUnverified, ""); err != nil {
+		t.Fatal(err)
+	}
+	if r, _ := storedItem(t, store, "rev", "rev-claim1"); r.Status != types.StatusUnverified {
+		t.Errorf("cleared item status = %q", r.Status)
+	}
+
+	if err := store.ReviewItems(ctx, []string{"rev-claim1"}, "wrong", ""); err == nil {
+		t.Error("unknown status accepted")
+	}
+	if err := store.ReviewItems(ctx, []string{"no-such-item"}, types.StatusVerified, ""); err == nil {
+		t.Error("unknown item accepted")
+	}
+	if _, err := store.Retrieve(ctx, QueryOptions{Status: "wrong"}); err == nil {
+		t.Error("unknown status filter accepted")
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// DefaultDuplicateThreshold is the Jaccard similarity of word-pair
+	// shingles at which two items are near duplicates (R14.1).
+	DefaultDuplicateThreshold = 0.6
+
+	// shingleWords is the number of consecutive words in a shingle.
+	shingleWords = 2
+
+	// maxShinglePostings skips shingles shared by more items than this
+	// when looking for candidate pairs; such common phrases say little
+	// about whether two items state the same fact.
+	maxShinglePostings = 200
+)
+
+// dedupeItem is an item as compared for near duplicates.
+type dedupeItem struct {
+	id         string
+	itemType   string
+	work       string
+	confidence float64
+	shingles   map[string]bool
+}
+
+// DetectDuplicates clusters near-duplicate items across papers (R14.1):
+// items of the same type from different papers (versions of a paper
+// counting as one) whose texts share at least threshold of their word-pair
+// shingles, by Jaccard similarity. Clusters are transitive. Each cluster's
+// canonical item is its highest-confidence member. The clusters replace
+// those in the item_duplicates table; it returns their number.
+func (s *Store) DetectDuplicates(ctx context.Context, threshold float64) (int, error) {
+	if threshold <= 0 {
+		threshold = DefaultDuplicateThreshold
+	}
+	items, err := s.loadDedupeItems(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	postings := make(map[string][]int)
+	for i, it := range items {
+		for sh := range it.shingles {
+			postings[sh] = append(postings[sh], i)
+		}
+	}
+
+	parent := make([]int, len(items))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i, it := range items {
+		candidates := make(map[int]bool)
+		for sh := range it.shingles {
+			if p := postings[sh]; len(p) <= maxShinglePostings {
+				for _, j := range p {
+					if j > i {
+						candidates[j] = true
+					}
+				}
+			}
+		}
+		for j := range candidates {
+			other := items[j]
+			if other.itemType != it.itemType || other.work == it.work {
+				continue
+			}
+			if jaccard(it.shingles, other.shingles) >= threshold {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	clusters := make(map[int][]int)
+	for i := range items {
+		root := find(i)
+		clusters[root] = append(clusters[root], i)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `DELETE FROM item_duplicates`); err != nil {
+		return 0, fmt.Errorf("clearing duplicates: %w", err)
+	}
+	n := 0
+	for _, members := range clusters {
+		if len(members) < 2 {
+			continue
+		}
+		n++
+		sort.Slice(members, func(a, b int) bool {
+			ma, mb := items[members[a]], items[members[b]]
+			if ma.confidence != mb.confidence {
+				return ma.confidence > mb.confidence
+			}
+			return ma.id < mb.id
+		})
+		canonical := items[members[0]]
+		for _, m := range members {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO item_duplicates (item_id, canonical_id, similarity) VALUES (?, ?, ?)`,
+				items[m].id, canonical.id, jaccard(items[m].shingles, canonical.shingles),
+			); err != nil {
+				return 0, fmt.Errorf("recording duplicates: %w", err)
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing duplicates: %w", err)
+	}
+	return n, nil
+}
+
+// loadDedupeItems reads every item with its text's shingles, using the
+// coreference-resolved text when there is one.
+func (s *Store) loadDedupeItems(ctx context.Context) ([]dedupeItem, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT i.id, i.type, COALESCE(NULLIF(p.canonical_id, ''), i.paper_id),
+			COALESCE(i.confidence, 0), COALESCE(NULLIF(i.resolved_content, ''), i.content)
+		FROM items i LEFT JOIN papers p ON p.id = i.paper_id
+		ORDER BY i.id`)
+	if err != nil {
+		return nil, fmt.Errorf("reading items: %w", err)
+	}
+	defer rows.Close()
+	var items []dedupeItem
+	for rows.Next() {
+		var it dedupeItem
+		var text string
+		if err := rows.Scan(&it.id, &it.itemType, &it.work, &it.confidence, &text); err != nil {
+			return nil, fmt.Errorf("scanning item: %w", err)
+		}
+		it.shingles = shingles(text)
+		if len(it.shingles) > 0 {
+			items = append(items, it)
+		}
+	}
+	return items, rows.Err()
+}
+
+// shingles returns the runs of shingleWords consecutive words in text,
+// lowercased and stripped of punctuation. Texts shorter than a shingle
+// have none.
+func shingles(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' && r != '%'
+	})
+	for i, w := range words {
+		words[i] = strings.Trim(w, ".")
+	}
+	set := make(map[string]bool)
+	for i := 0; i+shingleWords <= len(words); i++ {
+		set[strings.Join(words[i:i+shingleWords], " ")] = true
+	}
+	return set
+}
+
+// jaccard returns the Jaccard similarity of two sets.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for k := range a {
+		if b[k] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// duplicateCluster is a cluster of near-duplicate items (R14.2).
+type duplicateCluster struct {
+	items  []string
+	papers []string
+}
+
+// loadDuplicateCluster returns the members and their distinct papers of
+// the cluster with canonical item canonicalID.
+func (s *Store) loadDuplicateCluster(ctx context.Context, canonicalID string) (duplicateCluster, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT d.item_id, i.paper_id FROM item_duplicates d JOIN items i ON i.id = d.item_id
+		WHERE d.canonical_id = ? ORDER BY i.paper_id, d.item_id`, canonicalID)
+	if err != nil {
+		return duplicateCluster{}, fmt.Errorf("reading duplicates: %w", err)
+	}
+	defer rows.Close()
+	var c duplicateCluster
+	for rows.Next() {
+		var id, paperID string
+		if err := rows.Scan(&id, &paperID); err != nil {
+			return duplicateCluster{}, err
+		}
+		c.items = append(c.items, id)
+		if len(c.papers) == 0 || c.papers[len(c.papers)-1] != paperID {
+			c.papers = append(c.papers, paperID)
+		}
+	}
+	return c, rows.Err()
+}
+
+// collapseDuplicates keeps the best-ranked result of each near-duplicate
+// cluster, at most maxResults, and lists on it the cluster's other items
+// and all its supporting papers (R14.2).
+func (s *Store) collapseDuplicates(ctx context.Context, results []QueryResult, maxResults int) ([]QueryResult, error) {
+	seen := make(map[string]bool)
+	var kept []QueryResult
+	for _, r := range results {
+		key := r.cluster
+		if key == "" {
+			kept = append(kept, r)
+		} else if !seen[key] {
+			seen[key] = true
+			c, err := s.loadDuplicateCluster(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range c.items {
+				if id != r.ID {
+					r.Duplicates = append(r.Duplicates, id)
+				}
+			}
+			r.SupportingPapers = c.papers
+			kept = append(kept, r)
+		}
+		if len(kept) == maxResults {
+			break
+		}
+	}
+	return kept, nil
+}
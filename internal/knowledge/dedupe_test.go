@@ -0,0 +1,116 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestDetectDuplicates(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	papers := map[string][]types.KnowledgeItem{
+		"p1": {
+			{ID: "p1-a", Type: types.ItemClaim, Content: "Sparse attention reduces memory use by half on long documents.", Confidence: 0.8},
+			{ID: "p1-b", Type: types.ItemClaim, Content: "Sparse attention reduces memory use by half on long sequences.", Confidence: 0.7},
+		},
+		"p2": {
+			{ID: "p2-a", Type: types.ItemClaim, Content: "Sparse attention reduces memory use by half on long documents", Confidence: 0.9},
+			{ID: "p2-m", Type: types.ItemMethod, Content: "Sparse attention reduces memory use by half on long documents.", Confidence: 0.9},
+		},
+		"p3": {
+			{ID: "p3-a", Type: types.ItemClaim, Content: "As shown, sparse attention reduces memory use by half on long documents.", Confidence: 0.6},
+			{ID: "p3-b", Type: types.ItemClaim, Content: "Dense attention is needed for retrieval tasks.", Confidence: 0.9},
+		},
+	}
+	for id, items := range papers {
+		for i := range items {
+			items[i].PaperID = id
+		}
+		writeExtraction(t, tmpDir, id, items)
+		writePaperMeta(t, tmpDir, types.Paper{ID: id, Title: "Paper " + id})
+	}
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	// p1-a and p1-b share a paper but are linked through p2-a; p2-m is a
+	// method, and p3-b states something else.
+	if !strings.Contains(buf.String(), "clustered 1 near-duplicate item set(s)") {
+		t.Errorf("ingest output lacks the cluster count:\n%s", buf.String())
+	}
+
+	ctx := context.Background()
+	results, err := store.Retrieve(ctx, QueryOptions{Query: "sparse attention memory", CollapseDuplicates: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d collapsed results, want 2: %+v", len(results), results)
+	}
+	var claim QueryResult
+	for _, r := range results {
+		if r.Type == types.ItemClaim {
+			claim = r
+		}
+	}
+	if want := []string{"p1-a", "p1-b", "p2-a", "p3-a"}; !slices.Contains(want, claim.ID) ||
+		len(claim.Duplicates) != 3 || slices.Contains(claim.Duplicates, claim.ID) {
+		t.Errorf("claim %s has duplicates %v, want the other three of %v", claim.ID, claim.Duplicates, want)
+	}
+	if want := []string{"p1", "p2", "p3"}; !slices.Equal(claim.SupportingPapers, want) {
+		t.Errorf("supporting papers = %v, want %v", claim.SupportingPapers, want)
+	}
+
+	all, err := store.Retrieve(ctx, QueryOptions{Query: "sparse attention memory"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 5 {
+		t.Errorf("got %d results without collapsing, want 5", len(all))
+	}
+
+	limited, err := store.Retrieve(ctx, QueryOptions{Type: types.ItemClaim, CollapseDuplicates: true, MaxResults: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limited) != 1 {
+		t.Errorf("got %d results with limit 1, want 1", len(limited))
+	}
+}
+
+func TestDetectDuplicatesCanonical(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	for id, conf := range map[string]float64{"p1": 0.5, "p2": 0.9} {
+		writeExtraction(t, tmpDir, id, []types.KnowledgeItem{
+			{ID: id + "-a", Type: types.ItemResult, PaperID: id, Content: "Accuracy rises to 91.2% with pruning.", Confidence: conf},
+		})
+		writePaperMeta(t, tmpDir, types.Paper{ID: id, Title: "Paper " + id})
+	}
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	var canonical string
+	if err := store.db.QueryRow(`SELECT canonical_id FROM item_duplicates WHERE item_id = 'p1-a'`).Scan(&canonical); err != nil {
+		t.Fatal(err)
+	}
+	if canonical != "p2-a" {
+		t.Errorf("canonical item = %s, want the more confident p2-a", canonical)
+	}
+}
+
+func TestShingles(t *testing.T) {
+	a := shingles("Accuracy rises to 91.2%, with pruning.")
+	b := shingles("accuracy rises to 91.2% with pruning")
+	if jaccard(a, b) != 1 {
+		t.Errorf("jaccard = %v, want 1 for texts differing in case and punctuation", jaccard(a, b))
+	}
+	if len(shingles("Pruning")) != 0 {
+		t.Error("a one-word text has shingles")
+	}
+}
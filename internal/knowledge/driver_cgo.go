@@ -0,0 +1,23 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+//go:build !sqlite_purego
+
+package knowledge
+
+import (
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqliteDriver is the database/sql driver the store uses: mattn/go-sqlite3,
+// which compiles SQLite in with cgo. Build with -tags sqlite_fts5 so it
+// includes FTS5.
+const sqliteDriver = "sqlite3"
+
+// sqliteDSN returns the data source name opening the database at path,
+// read-only or else in WAL mode with foreign keys enforced.
+func sqliteDSN(path string, readOnly bool) string {
+	if readOnly {
+		return "file:" + path + "?mode=ro"
+	}
+	return path + "?_journal_mode=WAL&_foreign_keys=on"
+}
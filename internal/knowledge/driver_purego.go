@@ -0,0 +1,23 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+//go:build sqlite_purego
+
+package knowledge
+
+import (
+	_ "modernc.org/sqlite"
+)
+
+// sqliteDriver is the database/sql driver the store uses: modernc.org/sqlite,
+// SQLite translated to Go, so the binary builds with CGO_ENABLED=0 for any
+// platform. It includes FTS5.
+const sqliteDriver = "sqlite"
+
+// sqliteDSN returns the data source name opening the database at path,
+// read-only or else in WAL mode with foreign keys enforced.
+func sqliteDSN(path string, readOnly bool) string {
+	if readOnly {
+		return "file:" + path + "?mode=ro"
+	}
+	return "file:" + path + "?_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)"
+}
@@ -0,0 +1,462 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// Default embedding models and endpoints (R12.1).
+const (
+	DefaultOpenAIEmbeddingModel = "text-embedding-3-small"
+	DefaultOllamaEmbeddingModel = "nomic-embed-text"
+	DefaultOpenAIEmbeddingURL   = "https://api.openai.com/v1"
+	DefaultOllamaEmbeddingURL   = "http://localhost:11434"
+)
+
+// embedBatchSize is the number of items sent in one embedding request.
+const embedBatchSize = 64
+
+// Embedder computes vector embeddings of text for semantic search (R12.1).
+type Embedder interface {
+	// EmbeddingModel names the model; vectors of different models are
+	// never compared.
+	EmbeddingModel() string
+
+	// Embed returns one vector per text, in order.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// NewEmbedder returns the embedder cfg.EmbeddingBackend selects, sending
+// its requests through client: "openai" for an OpenAI-compatible
+// embeddings API, authenticated with apiKey, or "ollama" for a local
+// Ollama server. An empty backend returns nil, semantic search being off.
+func NewEmbedder(cfg types.KnowledgeBaseConfig, apiKey string, client *http.Client) (Embedder, error) {
+	switch cfg.EmbeddingBackend {
+	case "":
+		return nil, nil
+	case "openai":
+		return &OpenAIEmbedder{BaseURL: cfg.EmbeddingBaseURL, APIKey: apiKey, Model: cfg.EmbeddingModel, Client: client}, nil
+	case "ollama":
+		return &OllamaEmbedder{URL: cfg.EmbeddingBaseURL, Model: cfg.EmbeddingModel, Client: client}, nil
+	}
+	return nil, fmt.Errorf("unsupported embedding backend: %s (available: openai, ollama)", cfg.EmbeddingBackend)
+}
+
+// OpenAIEmbedder calls an OpenAI-compatible /embeddings endpoint: OpenAI
+// or a local server such as vLLM or llama.cpp.
+type OpenAIEmbedder struct {
+	// BaseURL is the API base the /embeddings path is appended to
+	// (default DefaultOpenAIEmbeddingURL).
+	BaseURL string
+	APIKey  string
+	// Model is the embedding model (default DefaultOpenAIEmbeddingModel).
+	Model  string
+	Client *http.Client
+}
+
+// EmbeddingModel returns the model the embedder calls.
+func (o *OpenAIEmbedder) EmbeddingModel() string {
+	if o.Model == "" {
+		return DefaultOpenAIEmbeddingModel
+	}
+	return o.Model
+}
+
+// Embed embeds texts in one request.
+func (o *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	base := o.BaseURL
+	if base == "" {
+		base = DefaultOpenAIEmbeddingURL
+	}
+	var resp struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	header := http.Header{}
+	if o.APIKey != "" {
+		header.Set("Authorization", "Bearer "+o.APIKey)
+	}
+	body := map[string]any{"model": o.EmbeddingModel(), "input": texts}
+	if err := postJSON(ctx, o.Client, strings.TrimSuffix(base, "/")+"/embeddings", header, body, &resp); err != nil {
+		return nil, fmt.Errorf("embedding API: %w", err)
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding API returned index %d for %d inputs", d.Index, len(texts))
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return checkVectors(vectors)
+}
+
+// OllamaEmbedder calls a local Ollama server's /api/embed endpoint, so
+// semantic search runs offline.
+type OllamaEmbedder struct {
+	// URL is the server's base URL (default DefaultOllamaEmbeddingURL).
+	URL string
+	// Model is the embedding model (default DefaultOllamaEmbeddingModel).
+	Model  string
+	Client *http.Client
+}
+
+// EmbeddingModel returns the model the embedder calls.
+func (o *OllamaEmbedder) EmbeddingModel() string {
+	if o.Model == "" {
+		return DefaultOllamaEmbeddingModel
+	}
+	return o.Model
+}
+
+// Embed embeds texts in one request.
+func (o *OllamaEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	base := o.URL
+	if base == "" {
+		base = DefaultOllamaEmbeddingURL
+	}
+	var resp struct {
+		Embeddings [][]float32 `json:"embeddings"`
+	}
+	body := map[string]any{"model": o.EmbeddingModel(), "input": texts}
+	if err := postJSON(ctx, o.Client, strings.TrimSuffix(base, "/")+"/api/embed", nil, body, &resp); err != nil {
+		return nil, fmt.Errorf("calling Ollama (is \"ollama serve\" running?): %w", err)
+	}
+	if len(resp.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("Ollama returned %d embeddings for %d inputs", len(resp.Embeddings), len(texts))
+	}
+	return checkVectors(resp.Embeddings)
+}
+
+// postJSON posts body as JSON to url and decodes the JSON reply into v.
+func postJSON(ctx context.Context, client *http.Client, url string, header http.Header, body, v any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshaling request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+// checkVectors rejects a reply with a missing or empty vector.
+func checkVectors(vectors [][]float32) ([][]float32, error) {
+	for i, v := range vectors {
+		if len(v) == 0 {
+			return nil, fmt.Errorf("no embedding returned for input %d", i)
+		}
+	}
+	return vectors, nil
+}
+
+// EmbedItems embeds the items that have no vector from e's model for their
+// current text and stores the vectors in the item_embeddings table,
+// removing those of deleted items (R12.2). It returns the number of items
+// embedded. The knowledge store command runs it after Ingest.
+func (s *Store) EmbedItems(ctx context.Context, e Embedder, w io.Writer) (int, error) {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM item_embeddings WHERE item_id NOT IN (SELECT id FROM items)`); err != nil {
+		return 0, fmt.Errorf("removing stale embeddings: %w", err)
+	}
+
+	model := e.EmbeddingModel()
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT i.id, i.content, i.resolved_content, e.model, e.content_hash
+		FROM items i LEFT JOIN item_embeddings e ON e.item_id = i.id
+		ORDER BY i.rowid`)
+	if err != nil {
+		return 0, fmt.Errorf("listing items to embed: %w", err)
+	}
+	var ids, texts []string
+	for rows.Next() {
+		var id, content string
+		var resolved, oldModel, oldHash sql.NullString
+		if err := rows.Scan(&id, &content, &resolved, &oldModel, &oldHash); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scanning item: %w", err)
+		}
+		text := embeddingText(content, resolved.String)
+		if oldModel.String == model && oldHash.String == textHash(text) {
+			continue
+		}
+		ids = append(ids, id)
+		texts = append(texts, text)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	fmt.Fprintf(w, "embedding %d item(s) with %s\n", len(ids), model)
+	embedded := 0
+	for start := 0; start < len(ids); start += embedBatchSize {
+		end := min(start+embedBatchSize, len(ids))
+		vectors, err := e.Embed(ctx, texts[start:end])
+		if err != nil {
+			return embedded, fmt.Errorf("embedding items: %w", err)
+		}
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return embedded, fmt.Errorf("beginning transaction: %w", err)
+		}
+		for i, v := range vectors {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT OR REPLACE INTO item_embeddings (item_id, model, content_hash, vector) VALUES (?, ?, ?, ?)`,
+				ids[start+i], model, textHash(texts[start+i]), encodeVector(normalize(v)),
+			); err != nil {
+				tx.Rollback()
+				return embedded, fmt.Errorf("storing embedding of %s: %w", ids[start+i], err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return embedded, fmt.Errorf("committing embeddings: %w", err)
+		}
+		embedded += len(vectors)
+	}
+	return embedded, nil
+}
+
+// embeddingText is the text of an item that is embedded: its content with
+// self-references resolved when extraction resolved them.
+func embeddingText(content, resolved string) string {
+	if resolved != "" {
+		return resolved
+	}
+	return content
+}
+
+// textHash identifies the text a vector was computed from.
+func textHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:8])
+}
+
+// normalize scales v to unit length, so cosine similarity is a dot
+// product.
+func normalize(v []float32) []float32 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return v
+	}
+	norm := float32(math.Sqrt(sum))
+	out := make([]float32, len(v))
+	for i, x := range v {
+		out[i] = x / norm
+	}
+	return out
+}
+
+// encodeVector stores v as little-endian float32s.
+func encodeVector(v []float32) []byte {
+	b := make([]byte, 4*len(v))
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(x))
+	}
+	return b
+}
+
+// decodeVector reads a vector stored by encodeVector.
+func decodeVector(b []byte) []float32 {
+	v := make([]float32, len(b)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return v
+}
+
+// dot returns the dot product of two vectors, or 0 when their dimensions
+// differ.
+func dot(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+// rrfK damps the reciprocal rank fusion of hybrid search, so items ranked
+// highly by both searches beat items ranked first by one.
+const rrfK = 60
+
+// hybridDepth is how many full-text results are fused with the semantic
+// ranking.
+const hybridDepth = 100
+
+// RetrieveSemantic ranks the items matching opts' filters by the cosine
+// similarity of their embedding to the embedding of question (R12.3). When
+// opts.Query is set too, the full-text ranking of opts.Query is fused with
+// the semantic ranking by reciprocal rank fusion (R12.4). Each result's
+// Score is its similarity, or its fused score. Items without a vector from
+// e's model are not ranked.
+func (s *Store) RetrieveSemantic(ctx context.Context, e Embedder, question string, opts QueryOptions) ([]QueryResult, error) {
+	maxResults := opts.MaxResults
+	if maxResults <= 0 {
+		maxResults = s.maxResults
+	}
+
+	vectors, err := s.loadVectors(ctx, e.EmbeddingModel())
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) == 0 {
+		return nil, fmt.Errorf("no items embedded with %s: run \"knowledge store\" with an embedding backend first", e.EmbeddingModel())
+	}
+	qv, err := e.Embed(ctx, []string{question})
+	if err != nil {
+		return nil, fmt.Errorf("embedding question: %w", err)
+	}
+	query := normalize(qv[0])
+
+	// The filters select the candidates; every candidate with a vector
+	// is ranked.
+	filters := opts
+	filters.Query = ""
+	filters.MaxResults = math.MaxInt32
+	// Duplicates are collapsed after ranking.
+	filters.CollapseDuplicates = false
+	candidates, err := s.Retrieve(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	var ranked []QueryResult
+	for _, c := range candidates {
+		if v, ok := vectors[c.ID]; ok {
+			c.Score = dot(query, v)
+			ranked = append(ranked, c)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Score > ranked[j].Score })
+
+	if opts.Query != "" {
+		text := filters
+		text.Query = opts.Query
+		text.MaxResults = hybridDepth
+		textResults, err := s.Retrieve(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		ranked = fuseRankings(ranked, textResults)
+	}
+
+	if opts.CollapseDuplicates {
+		return s.collapseDuplicates(ctx, ranked, maxResults)
+	}
+	if len(ranked) > maxResults {
+		ranked = ranked[:maxResults]
+	}
+	return ranked, nil
+}
+
+// fuseRankings merges a semantic and a full-text ranking by reciprocal
+// rank fusion: each result scores the sum of 1/(rrfK+rank) over the
+// rankings it appears in.
+func fuseRankings(semantic, text []QueryResult) []QueryResult {
+	scores := make(map[string]float64)
+	byID := make(map[string]QueryResult)
+	var order []string
+	for _, ranking := range [][]QueryResult{semantic, text} {
+		for rank, r := range ranking {
+			if prev, ok := byID[r.ID]; !ok {
+				byID[r.ID] = r
+				order = append(order, r.ID)
+			} else if prev.Snippet == "" {
+				// Keep the match context of the full-text ranking.
+				prev.Snippet, prev.Highlight = r.Snippet, r.Highlight
+				byID[r.ID] = prev
+			}
+			scores[r.ID] += 1 / float64(rrfK+rank+1)
+		}
+	}
+	fused := make([]QueryResult, len(order))
+	for i, id := range order {
+		fused[i] = byID[id]
+		fused[i].Score = scores[id]
+	}
+	sort.SliceStable(fused, func(i, j int) bool { return fused[i].Score > fused[j].Score })
+	return fused
+}
+
+// loadVectors returns the stored vectors of model by item ID.
+func (s *Store) loadVectors(ctx context.Context, model string) (map[string][]float32, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT item_id, vector FROM item_embeddings WHERE model = ?`, model)
+	if err != nil {
+		return nil, fmt.Errorf("reading embeddings: %w", err)
+	}
+	defer rows.Close()
+	vectors := make(map[string][]float32)
+	for rows.Next() {
+		var id string
+		var blob []byte
+		if err := rows.Scan(&id, &blob); err != nil {
+			return nil, fmt.Errorf("scanning embedding: %w", err)
+		}
+		vectors[id] = decodeVector(blob)
+	}
+	return vectors, rows.Err()
+}
+
+// FullTextTerms turns a natural-language question into a full-text query
+// matching any of its words, so it can be fused with a semantic search of
+// the same question without FTS5 syntax errors.
+func FullTextTerms(question string) string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(question), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(w) < 3 || seen[w] {
+			continue
+		}
+		seen[w] = true
+		terms = append(terms, `"`+w+`"`)
+	}
+	return strings.Join(terms, " OR ")
+}
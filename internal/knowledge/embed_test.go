@@ -0,0 +1,208 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// conceptEmbedder embeds text as counts of the concepts its words name, so
+// paraphrases share a vector without sharing words.
+type conceptEmbedder struct {
+	model string
+	texts int
+}
+
+var testConcepts = map[string]int{
+	"attention": 0, "softmax": 0, "transformer": 0,
+	"cost": 1, "computation": 1, "cheaper": 1, "efficient": 1, "quadratic": 1,
+	"benchmark": 2, "dataset": 2, "corpus": 2,
+}
+
+func (c *conceptEmbedder) EmbeddingModel() string { return c.model }
+
+func (c *conceptEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	c.texts += len(texts)
+	out := make([][]float32, len(texts))
+	for i, text := range texts {
+		v := make([]float32, 4)
+		v[3] = 0.1
+		for _, w := range strings.Fields(strings.ToLower(text)) {
+			if d, ok := testConcepts[strings.Trim(w, ".,?")]; ok {
+				v[d]++
+			}
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+func embedSetup(t *testing.T) (*Store, string) {
+	t.Helper()
+	store, tmpDir := testSetup(t)
+	writeExtraction(t, tmpDir, "p1", []types.KnowledgeItem{
+		{ID: "a", Type: types.ItemClaim, Content: "Softmax attention is quadratic in cost", PaperID: "p1", Section: "Intro", Confidence: 0.9},
+		{ID: "b", Type: types.ItemMethod, Content: "We collect a new benchmark corpus", PaperID: "p1", Section: "Data", Confidence: 0.9},
+		{ID: "c", Type: types.ItemResult, Content: "Training takes two days", PaperID: "p1", Section: "Results", Confidence: 0.9},
+	})
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	return store, tmpDir
+}
+
+func TestEmbedItemsIncremental(t *testing.T) {
+	store, tmpDir := embedSetup(t)
+	ctx := context.Background()
+	var buf strings.Builder
+
+	e := &conceptEmbedder{model: "concepts-v1"}
+	if n, err := store.EmbedItems(ctx, e, &buf); err != nil || n != 3 {
+		t.Fatalf("EmbedItems = %d, %v; want 3", n, err)
+	}
+	if n, _ := store.EmbedItems(ctx, e, &buf); n != 0 {
+		t.Errorf("second EmbedItems embedded %d, want 0", n)
+	}
+
+	// A changed item is embedded again, and a removed one is dropped.
+	writeExtraction(t, tmpDir, "p1", []types.KnowledgeItem{
+		{ID: "a", Type: types.ItemClaim, Content: "Softmax attention is cheaper than expected", PaperID: "p1", Section: "Intro", Confidence: 0.9},
+		{ID: "c", Type: types.ItemResult, Content: "Training takes two days", PaperID: "p1", Section: "Results", Confidence: 0.9},
+	})
+	future := time.Now().Add(time.Hour)
+	os.Chtimes(filepath.Join(tmpDir, "knowledge", extractedDir, "p1-items.yaml"), future, future)
+	if _, err := store.Ingest(ctx, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := store.EmbedItems(ctx, e, &buf); n != 1 {
+		t.Errorf("after an edit EmbedItems embedded %d, want 1", n)
+	}
+	var rows int
+	store.db.QueryRow(`SELECT COUNT(*) FROM item_embeddings`).Scan(&rows)
+	if rows != 2 {
+		t.Errorf("%d embeddings stored, want 2 after item b was removed", rows)
+	}
+
+	// Another model embeds everything.
+	if n, _ := store.EmbedItems(ctx, &conceptEmbedder{model: "concepts-v2"}, &buf); n != 2 {
+		t.Errorf("new model embedded %d, want 2", n)
+	}
+}
+
+func TestRetrieveSemantic(t *testing.T) {
+	store, _ := embedSetup(t)
+	ctx := context.Background()
+	e := &conceptEmbedder{model: "concepts-v1"}
+
+	if _, err := store.RetrieveSemantic(ctx, e, "question", QueryOptions{}); err == nil {
+		t.Fatal("expected an error before items are embedded")
+	}
+	var buf strings.Builder
+	if _, err := store.EmbedItems(ctx, e, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	// A paraphrase sharing no words with item a finds it first.
+	results, err := store.RetrieveSemantic(ctx, e, "which transformer computation is expensive?", QueryOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 || results[0].ID != "a" || results[0].Score <= results[1].Score {
+		t.Fatalf("results = %+v, want a ranked first with the highest score", results)
+	}
+
+	// Filters restrict the candidates.
+	results, _ = store.RetrieveSemantic(ctx, e, "which dataset", QueryOptions{Type: types.ItemResult})
+	if len(results) != 1 || results[0].ID != "c" {
+		t.Errorf("filtered results = %+v, want only the result item c", results)
+	}
+
+	// Hybrid search keeps the best of each ranking: b by meaning and c by
+	// its words.
+	results, err = store.RetrieveSemantic(ctx, e, "benchmark corpus", QueryOptions{Query: FullTextTerms("two days of training"), MaxResults: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("hybrid results = %+v, want 2", results)
+	}
+	for _, r := range results {
+		if r.ID == "a" {
+			t.Errorf("hybrid results = %+v, want items b and c matched by one ranking each", results)
+		}
+	}
+}
+
+func TestFullTextTerms(t *testing.T) {
+	if got := FullTextTerms(`What does "attention" cost? Attention, I mean.`); got != `"what" OR "does" OR "attention" OR "cost" OR "mean"` {
+		t.Errorf("FullTextTerms = %s", got)
+	}
+}
+
+func TestOpenAIEmbedder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/v1/embeddings" || r.Header.Get("Authorization") != "Bearer key" || req.Model != DefaultOpenAIEmbeddingModel || len(req.Input) != 2 {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		// Out of order, as the API allows.
+		w.Write([]byte(`{"data": [{"index": 1, "embedding": [0, 1]}, {"index": 0, "embedding": [1, 0]}]}`))
+	}))
+	defer srv.Close()
+
+	e, err := NewEmbedder(types.KnowledgeBaseConfig{EmbeddingBackend: "openai", EmbeddingBaseURL: srv.URL + "/v1"}, "key", srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	vectors, err := e.Embed(context.Background(), []string{"first", "second"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vectors[0][0] != 1 || vectors[1][1] != 1 {
+		t.Errorf("vectors = %v, want them in input order", vectors)
+	}
+}
+
+func TestOllamaEmbedder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/embed" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"embeddings": [[0.5, 0.5]]}`))
+	}))
+	defer srv.Close()
+
+	e := &OllamaEmbedder{URL: srv.URL}
+	if e.EmbeddingModel() != DefaultOllamaEmbeddingModel {
+		t.Errorf("model = %s, want the default", e.EmbeddingModel())
+	}
+	if _, err := e.Embed(context.Background(), []string{"one", "two"}); err == nil {
+		t.Error("expected an error when fewer embeddings than inputs are returned")
+	}
+}
+
+func TestNewEmbedderUnknownBackend(t *testing.T) {
+	if e, err := NewEmbedder(types.KnowledgeBaseConfig{}, "", nil); e != nil || err != nil {
+		t.Errorf("no backend: %v, %v; want nil, nil", e, err)
+	}
+	if _, err := NewEmbedder(types.KnowledgeBaseConfig{EmbeddingBackend: "word2vec"}, "", nil); err == nil {
+		t.Error("expected an error for an unknown backend")
+	}
+}
@@ -4,37 +4,69 @@ package knowledge
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 
+	"github.com/parquet-go/parquet-go"
 	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
 )
 
 // ExportEntry holds a knowledge item with paper metadata for export (R6.3).
 type ExportEntry struct {
-	ID         string       `json:"id" yaml:"id"`
-	Type       string       `json:"type" yaml:"type"`
-	Content    string       `json:"content" yaml:"content"`
-	PaperID    string       `json:"paper_id" yaml:"paper_id"`
-	Section    string       `json:"section" yaml:"section"`
-	Page       int          `json:"page" yaml:"page"`
-	Confidence float64      `json:"confidence" yaml:"confidence"`
-	Tags       []string     `json:"tags" yaml:"tags"`
-	Paper      *ExportPaper `json:"paper,omitempty" yaml:"paper,omitempty"`
+	ID         string        `json:"id" yaml:"id"`
+	Type       string        `json:"type" yaml:"type"`
+	Content    string        `json:"content" yaml:"content"`
+	Resolved   string        `json:"resolved_content,omitempty" yaml:"resolved_content,omitempty"`
+	PaperID    string        `json:"paper_id" yaml:"paper_id"`
+	Section    string        `json:"section" yaml:"section"`
+	Page       int           `json:"page" yaml:"page"`
+	Confidence float64       `json:"confidence" yaml:"confidence"`
+	Tags       []string      `json:"tags" yaml:"tags"`
+	Metric     *types.Metric `json:"metric,omitempty" yaml:"metric,omitempty"`
+	Paper      *ExportPaper  `json:"paper,omitempty" yaml:"paper,omitempty"`
+
+	// Curated, Status, and StatusNote record the researcher's curation
+	// and review of the item (R17.1, R17.2).
+	Curated    bool             `json:"curated,omitempty" yaml:"curated,omitempty"`
+	Status     types.ItemStatus `json:"status" yaml:"status"`
+	StatusNote string           `json:"status_note,omitempty" yaml:"status_note,omitempty"`
 }
 
 // ExportPaper holds the paper-level fields included in each export entry.
+// For a linked paper these describe the canonical version, and Versions
+// lists every member of the set so each PDF stays reachable.
 type ExportPaper struct {
-	Title   string   `json:"title" yaml:"title"`
-	Authors []string `json:"authors" yaml:"authors"`
+	Title       string         `json:"title" yaml:"title"`
+	Authors     []string       `json:"authors" yaml:"authors"`
+	DOI         string         `json:"doi,omitempty" yaml:"doi,omitempty"`
+	CanonicalID string         `json:"canonical_id,omitempty" yaml:"canonical_id,omitempty"`
+	Versions    []PaperVersion `json:"versions,omitempty" yaml:"versions,omitempty"`
 }
 
 const exportLimit = 100000
 
+// ExportOrder selects how exported entries are ordered (R6.5).
+type ExportOrder string
+
+const (
+	// OrderDocument sorts entries by paper, section, page, and item ID, so
+	// re-exporting an unchanged knowledge base produces identical files.
+	OrderDocument ExportOrder = "document"
+	// OrderScore keeps full-text relevance order, best match first. It
+	// requires a query.
+	OrderScore ExportOrder = "score"
+)
+
 // ExportYAML writes the knowledge base to knowledge/index/export.yaml (R6.1).
-// It supports the same filters as Retrieve (R6.4).
+// It supports the same filters as Retrieve (R6.4). Entries are in document
+// order unless opts.Order is OrderScore (R6.5).
 func (s *Store) ExportYAML(ctx context.Context, opts QueryOptions) error {
 	entries, err := s.exportEntries(ctx, opts)
 	if err != nil {
@@ -50,7 +82,8 @@ func (s *Store) ExportYAML(ctx context.Context, opts QueryOptions) error {
 }
 
 // ExportJSON writes the knowledge base to knowledge/index/export.json (R6.2).
-// It supports the same filters as Retrieve (R6.4).
+// It supports the same filters as Retrieve (R6.4) and orders entries like
+// ExportYAML.
 func (s *Store) ExportJSON(ctx context.Context, opts QueryOptions) error {
 	entries, err := s.exportEntries(ctx, opts)
 	if err != nil {
@@ -65,31 +98,221 @@ func (s *Store) ExportJSON(ctx context.Context, opts QueryOptions) error {
 	return os.WriteFile(path, data, 0o644)
 }
 
+// ExportRow is an export entry flattened into scalar columns for CSV and
+// Parquet (R6.6). Paper metadata and metric fields get columns of their
+// own, and list fields (tags, authors, versions) are JSON-encoded. Missing
+// numbers are null in Parquet and empty in CSV.
+type ExportRow struct {
+	ID                  string   `parquet:"id"`
+	Type                string   `parquet:"type"`
+	Content             string   `parquet:"content"`
+	ResolvedContent     string   `parquet:"resolved_content"`
+	PaperID             string   `parquet:"paper_id"`
+	Section             string   `parquet:"section"`
+	Page                int64    `parquet:"page"`
+	Confidence          float64  `parquet:"confidence"`
+	Tags                string   `parquet:"tags"`
+	Status              string   `parquet:"status"`
+	StatusNote          string   `parquet:"status_note"`
+	MetricName          string   `parquet:"metric_name"`
+	MetricValue         *float64 `parquet:"metric_value,optional"`
+	MetricUnit          string   `parquet:"metric_unit"`
+	MetricDataset       string   `parquet:"metric_dataset"`
+	MetricBaseline      string   `parquet:"metric_baseline"`
+	MetricBaselineValue *float64 `parquet:"metric_baseline_value,optional"`
+	PaperTitle          string   `parquet:"paper_title"`
+	PaperAuthors        string   `parquet:"paper_authors"`
+	PaperDOI            string   `parquet:"paper_doi"`
+	PaperCanonicalID    string   `parquet:"paper_canonical_id"`
+	PaperVersions       string   `parquet:"paper_versions"`
+}
+
+// exportColumns names the CSV columns, in the order of ExportRow's fields
+// and its Parquet column names.
+var exportColumns = []string{
+	"id", "type", "content", "resolved_content", "paper_id", "section", "page", "confidence", "tags", "status", "status_note",
+	"metric_name", "metric_value", "metric_unit", "metric_dataset", "metric_baseline", "metric_baseline_value",
+	"paper_title", "paper_authors", "paper_doi", "paper_canonical_id", "paper_versions",
+}
+
+// ExportCSV writes the knowledge base to knowledge/index/export.csv (R6.6),
+// one row per item with a header row. It filters and orders entries like
+// ExportYAML.
+func (s *Store) ExportCSV(ctx context.Context, opts QueryOptions) error {
+	rows, err := s.exportRows(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.knowledgeDir, indexDir, "export.csv")
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating CSV export: %w", err)
+	}
+	w := csv.NewWriter(f)
+	w.Write(exportColumns)
+	for _, r := range rows {
+		w.Write([]string{
+			r.ID, r.Type, r.Content, r.ResolvedContent, r.PaperID, r.Section,
+			strconv.FormatInt(r.Page, 10), formatFloat(&r.Confidence), r.Tags, r.Status, r.StatusNote,
+			r.MetricName, formatFloat(r.MetricValue), r.MetricUnit, r.MetricDataset,
+			r.MetricBaseline, formatFloat(r.MetricBaselineValue),
+			r.PaperTitle, r.PaperAuthors, r.PaperDOI, r.PaperCanonicalID, r.PaperVersions,
+		})
+	}
+	w.Flush()
+	err = w.Error()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("writing CSV export: %w", err)
+	}
+	return nil
+}
+
+// ExportParquet writes the knowledge base to knowledge/index/export.parquet
+// (R6.6) with Snappy compression and the columns of ExportRow. It filters
+// and orders entries like ExportYAML.
+func (s *Store) ExportParquet(ctx context.Context, opts QueryOptions) error {
+	rows, err := s.exportRows(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.knowledgeDir, indexDir, "export.parquet")
+	if err := parquet.WriteFile(path, rows, parquet.Compression(&parquet.Snappy)); err != nil {
+		return fmt.Errorf("writing Parquet export: %w", err)
+	}
+	return nil
+}
+
+// exportRows returns the export entries flattened into rows.
+func (s *Store) exportRows(ctx context.Context, opts QueryOptions) ([]ExportRow, error) {
+	entries, err := s.exportEntries(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]ExportRow, len(entries))
+	for i, e := range entries {
+		r := ExportRow{
+			ID:              e.ID,
+			Type:            e.Type,
+			Content:         e.Content,
+			ResolvedContent: e.Resolved,
+			PaperID:         e.PaperID,
+			Section:         e.Section,
+			Page:            int64(e.Page),
+			Confidence:      e.Confidence,
+			Tags:            jsonList(e.Tags),
+			Status:          string(e.Status),
+			StatusNote:      e.StatusNote,
+		}
+		if m := e.Metric; m != nil {
+			value := m.Value
+			r.MetricName, r.MetricValue, r.MetricUnit = m.Name, &value, m.Unit
+			r.MetricDataset, r.MetricBaseline, r.MetricBaselineValue = m.Dataset, m.Baseline, m.BaselineValue
+		}
+		if p := e.Paper; p != nil {
+			r.PaperTitle, r.PaperAuthors, r.PaperDOI, r.PaperCanonicalID = p.Title, jsonList(p.Authors), p.DOI, p.CanonicalID
+			if len(p.Versions) > 0 {
+				data, _ := json.Marshal(p.Versions)
+				r.PaperVersions = string(data)
+			}
+		}
+		rows[i] = r
+	}
+	return rows, nil
+}
+
+// jsonList encodes a list field for a flat export column, "[]" when empty.
+func jsonList(list []string) string {
+	if len(list) == 0 {
+		return "[]"
+	}
+	data, _ := json.Marshal(list)
+	return string(data)
+}
+
+// formatFloat formats a number for a CSV cell, "" when it is missing.
+func formatFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'g', -1, 64)
+}
+
 func (s *Store) exportEntries(ctx context.Context, opts QueryOptions) ([]ExportEntry, error) {
-	opts.MaxResults = exportLimit
+	switch opts.Order {
+	case "", OrderDocument:
+	case OrderScore:
+		if opts.Query == "" {
+			return nil, fmt.Errorf("export order %q requires a query", OrderScore)
+		}
+	default:
+		return nil, fmt.Errorf("unknown export order %q: use %s or %s", opts.Order, OrderDocument, OrderScore)
+	}
+	if opts.MaxResults <= 0 {
+		opts.MaxResults = exportLimit
+	}
 	results, err := s.Retrieve(ctx, opts)
 	if err != nil {
 		return nil, fmt.Errorf("querying for export: %w", err)
 	}
+	if opts.Order != OrderScore {
+		sort.SliceStable(results, func(i, j int) bool {
+			a, b := results[i], results[j]
+			if a.PaperID != b.PaperID {
+				return a.PaperID < b.PaperID
+			}
+			if a.Section != b.Section {
+				return a.Section < b.Section
+			}
+			if a.Page != b.Page {
+				return a.Page < b.Page
+			}
+			return a.ID < b.ID
+		})
+	}
 
+	versions := make(map[string][]PaperVersion)
 	entries := make([]ExportEntry, len(results))
 	for i, r := range results {
 		entries[i] = ExportEntry{
 			ID:         r.ID,
 			Type:       string(r.Type),
 			Content:    r.Content,
+			Resolved:   r.ResolvedContent,
 			PaperID:    r.PaperID,
 			Section:    r.Section,
 			Page:       r.Page,
 			Confidence: r.Confidence,
 			Tags:       r.Tags,
+			Metric:     r.Metric,
+			Curated:    r.Curated,
+			Status:     r.Status,
+			StatusNote: r.StatusNote,
 		}
-		if r.PaperTitle != "" || len(r.PaperAuthors) > 0 {
+		if r.PaperTitle != "" || len(r.PaperAuthors) > 0 || r.CanonicalID != "" {
 			entries[i].Paper = &ExportPaper{
-				Title:   r.PaperTitle,
-				Authors: r.PaperAuthors,
+				Title:       r.PaperTitle,
+				Authors:     r.PaperAuthors,
+				DOI:         r.PaperDOI,
+				CanonicalID: r.CanonicalID,
+			}
+		}
+		if r.CanonicalID == "" {
+			continue
+		}
+		vs, ok := versions[r.CanonicalID]
+		if !ok {
+			vs, err = s.Versions(ctx, r.PaperID)
+			if err != nil {
+				return nil, fmt.Errorf("listing versions of %s: %w", r.PaperID, err)
 			}
+			versions[r.CanonicalID] = vs
 		}
+		entries[i].Paper.Versions = vs
 	}
 
 	return entries, nil
@@ -0,0 +1,103 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// ingestWithMetric ingests the sample items of paperID with a metric on its
+// result item.
+func ingestWithMetric(t *testing.T, store *Store, tmpDir, paperID string) {
+	t.Helper()
+	items := sampleItems(paperID)
+	items[3].Metric = &types.Metric{Name: "accuracy", Value: 89.2, Unit: "%", Dataset: "GLUE"}
+	writeExtraction(t, tmpDir, paperID, items)
+	writePaperMeta(t, tmpDir, samplePaper(paperID))
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestWithMetric(t, store, tmpDir, "csv-paper")
+
+	if err := store.ExportCSV(context.Background(), QueryOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(filepath.Join(tmpDir, "knowledge", indexDir, "export.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 5 {
+		t.Fatalf("got %d records, want a header and 4 rows", len(records))
+	}
+	col := make(map[string]int)
+	for i, name := range records[0] {
+		col[name] = i
+	}
+	if len(col) != len(exportColumns) {
+		t.Fatalf("header = %v", records[0])
+	}
+
+	byID := make(map[string][]string)
+	for _, r := range records[1:] {
+		byID[r[col["id"]]] = r
+	}
+	claim := byID["csv-paper-claim1"]
+	var tags []string
+	if err := json.Unmarshal([]byte(claim[col["tags"]]), &tags); err != nil || len(tags) != 2 {
+		t.Errorf("tags = %q, want a JSON list of 2", claim[col["tags"]])
+	}
+	if claim[col["paper_title"]] != "Efficient Attention Mechanisms for Transformers" ||
+		claim[col["paper_authors"]] != `["Smith, J.","Doe, A."]` {
+		t.Errorf("paper columns = %q, %q", claim[col["paper_title"]], claim[col["paper_authors"]])
+	}
+	if claim[col["metric_value"]] != "" || claim[col["page"]] != "2" || claim[col["confidence"]] != "0.92" {
+		t.Errorf("claim numbers = %v", claim)
+	}
+	result := byID["csv-paper-result1"]
+	if result[col["metric_name"]] != "accuracy" || result[col["metric_value"]] != "89.2" || result[col["metric_dataset"]] != "GLUE" {
+		t.Errorf("metric columns = %v", result)
+	}
+}
+
+func TestExportParquet(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestWithMetric(t, store, tmpDir, "parquet-paper")
+
+	if err := store.ExportParquet(context.Background(), QueryOptions{Type: types.ItemResult}); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := parquet.ReadFile[ExportRow](filepath.Join(tmpDir, "knowledge", indexDir, "export.parquet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want the one result item", len(rows))
+	}
+	r := rows[0]
+	if r.ID != "parquet-paper-result1" || r.Page != 5 || r.PaperTitle == "" {
+		t.Errorf("row = %+v", r)
+	}
+	if r.MetricValue == nil || *r.MetricValue != 89.2 || r.MetricBaselineValue != nil {
+		t.Errorf("metric values = %v, %v, want 89.2 and null", r.MetricValue, r.MetricBaselineValue)
+	}
+}
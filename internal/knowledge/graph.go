@@ -0,0 +1,283 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// citationGraphFile is the corpus citation graph under knowledgeDir/index/.
+const citationGraphFile = "citation-graph.json"
+
+// Node kinds of a citation graph.
+const (
+	// NodePaper is a paper acquired in the corpus.
+	NodePaper = "paper"
+
+	// NodeExternal is a cited work outside the corpus, identified by its
+	// DOI ("doi:10.1038/...") or arXiv ID ("arxiv:2301.07041").
+	NodeExternal = "external"
+)
+
+// CitationGraph is the corpus-level citation graph built from the
+// bibliographies of every extraction result (R11.1).
+type CitationGraph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+
+	// Unresolved counts bibliography entries that matched no corpus paper
+	// and carry no DOI or arXiv ID.
+	Unresolved int `json:"unresolved"`
+}
+
+// GraphNode is a paper in the corpus or a work it cites.
+type GraphNode struct {
+	// ID is the paper ID of a corpus paper, or "doi:..." or "arxiv:..."
+	// for an external work.
+	ID      string `json:"id"`
+	Kind    string `json:"kind"`
+	Title   string `json:"title,omitempty"`
+	Year    string `json:"year,omitempty"`
+	DOI     string `json:"doi,omitempty"`
+	ArxivID string `json:"arxiv_id,omitempty"`
+
+	// CitedBy is the number of corpus papers citing the node.
+	CitedBy int `json:"cited_by"`
+}
+
+// GraphEdge is one corpus paper citing a node.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	// Keys are the citing paper's bibliography keys for the cited work.
+	Keys []string `json:"keys"`
+
+	// Items are the IDs of the citing paper's knowledge items that cite
+	// the work inline.
+	Items []string `json:"items,omitempty"`
+}
+
+// CitationGraphPath returns where BuildCitationGraph's output is written.
+func CitationGraphPath(knowledgeDir string) string {
+	return filepath.Join(knowledgeDir, indexDir, citationGraphFile)
+}
+
+// corpusPapers indexes the papers in papersDir/metadata/ by ID, DOI,
+// arXiv ID, and normalized title.
+type corpusPapers struct {
+	byID    map[string]*types.Paper
+	byDOI   map[string]string
+	byArxiv map[string]string
+	byTitle map[string]string
+}
+
+func loadCorpusPapers(metaDir string) corpusPapers {
+	c := corpusPapers{
+		byID:    make(map[string]*types.Paper),
+		byDOI:   make(map[string]string),
+		byArxiv: make(map[string]string),
+		byTitle: make(map[string]string),
+	}
+	entries, _ := os.ReadDir(metaDir)
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".yaml") {
+			continue
+		}
+		paper := loadPaperMetadata(metaDir, strings.TrimSuffix(e.Name(), ".yaml"))
+		if paper == nil || paper.ID == "" {
+			continue
+		}
+		c.byID[paper.ID] = paper
+		if paper.DOI != "" {
+			c.byDOI[strings.ToLower(paper.DOI)] = paper.ID
+		}
+		if paper.ArxivID != "" {
+			c.byArxiv[paper.ArxivID] = paper.ID
+		}
+		if t := normalizeTitle(paper.Title); t != "" {
+			c.byTitle[t] = paper.ID
+		}
+	}
+	return c
+}
+
+// resolve returns the corpus paper a bibliography entry names: the one
+// recorded at extraction, or one with its DOI, arXiv ID, or title.
+func (c corpusPapers) resolve(e types.BibliographyEntry) string {
+	if e.PaperID != "" {
+		return e.PaperID
+	}
+	if id := c.byDOI[strings.ToLower(e.DOI)]; e.DOI != "" && id != "" {
+		return id
+	}
+	if id := c.byArxiv[e.ArxivID]; e.ArxivID != "" && id != "" {
+		return id
+	}
+	if id := c.byID[e.ArxivID]; e.ArxivID != "" && id != nil {
+		return id.ID
+	}
+	if t := normalizeTitle(e.Title); len(t) >= 20 {
+		return c.byTitle[t]
+	}
+	return ""
+}
+
+// normalizeTitle lowercases a title and keeps only its letters and
+// digits, so punctuation and case differences still match.
+func normalizeTitle(title string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// BuildCitationGraph reads every extraction result in
+// knowledgeDir/extracted/ and links each bibliography entry to the corpus
+// paper it names, matched by the paper ID recorded at extraction, DOI,
+// arXiv ID, or title, or else to an external node for its DOI or arXiv
+// ID (R11.1). Self-citations are dropped.
+func BuildCitationGraph(knowledgeDir, papersDir string) (*CitationGraph, error) {
+	extractDir := filepath.Join(knowledgeDir, extractedDir)
+	entries, err := os.ReadDir(extractDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading extraction directory %s: %w", extractDir, err)
+	}
+	corpus := loadCorpusPapers(filepath.Join(papersDir, metadataDir))
+
+	g := &CitationGraph{}
+	nodes := make(map[string]*GraphNode)
+	edges := make(map[[2]string]*GraphEdge)
+	paperNode := func(id string) *GraphNode {
+		if n, ok := nodes[id]; ok {
+			return n
+		}
+		n := &GraphNode{ID: id, Kind: NodePaper}
+		if p := corpus.byID[id]; p != nil {
+			n.Title, n.DOI, n.ArxivID = p.Title, p.DOI, p.ArxivID
+			if !p.Date.IsZero() {
+				n.Year = p.Date.Format("2006")
+			}
+		}
+		nodes[id] = n
+		return n
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), "-items.yaml") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(extractDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", entry.Name(), err)
+		}
+		var result types.ExtractionResult
+		if err := yaml.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", entry.Name(), err)
+		}
+		citing := strings.TrimSuffix(entry.Name(), "-items.yaml")
+		paperNode(citing)
+
+		// The node each bibliography entry resolves to, by index.
+		targets := make([]string, len(result.Bibliography))
+		for i, bib := range result.Bibliography {
+			var to string
+			switch id := corpus.resolve(bib); {
+			case id != "":
+				to = id
+				paperNode(id)
+			case bib.DOI != "":
+				to = "doi:" + strings.ToLower(bib.DOI)
+			case bib.ArxivID != "":
+				to = "arxiv:" + bib.ArxivID
+			default:
+				g.Unresolved++
+				continue
+			}
+			if to == citing {
+				continue
+			}
+			if _, ok := nodes[to]; !ok {
+				nodes[to] = &GraphNode{ID: to, Kind: NodeExternal, Title: bib.Title, Year: bib.Year, DOI: bib.DOI, ArxivID: bib.ArxivID}
+			}
+			targets[i] = to
+
+			key := [2]string{citing, to}
+			e, ok := edges[key]
+			if !ok {
+				e = &GraphEdge{From: citing, To: to}
+				edges[key] = e
+				nodes[to].CitedBy++
+			}
+			e.Keys = append(e.Keys, bib.Key)
+		}
+
+		for _, item := range result.Items {
+			for _, c := range item.Citations {
+				if c.BibIndex < 0 || c.BibIndex >= len(targets) || targets[c.BibIndex] == "" {
+					continue
+				}
+				e := edges[[2]string{citing, targets[c.BibIndex]}]
+				if len(e.Items) == 0 || e.Items[len(e.Items)-1] != item.ID {
+					e.Items = append(e.Items, item.ID)
+				}
+			}
+		}
+	}
+
+	for _, n := range nodes {
+		g.Nodes = append(g.Nodes, *n)
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+	for _, e := range edges {
+		g.Edges = append(g.Edges, *e)
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+	return g, nil
+}
+
+// Counts returns the number of corpus papers and external works in g.
+func (g *CitationGraph) Counts() (papers, external int) {
+	for _, n := range g.Nodes {
+		if n.Kind == NodePaper {
+			papers++
+		} else {
+			external++
+		}
+	}
+	return papers, external
+}
+
+// WriteCitationGraph writes g as indented JSON to path, creating its
+// directory.
+func WriteCitationGraph(path string, g *CitationGraph) error {
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding citation graph: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestBuildCitationGraph(t *testing.T) {
+	tmpDir := t.TempDir()
+	knowledgeDir := filepath.Join(tmpDir, "knowledge")
+	papersDir := filepath.Join(tmpDir, "papers")
+	os.MkdirAll(filepath.Join(knowledgeDir, extractedDir), 0o755)
+	os.MkdirAll(filepath.Join(papersDir, metadataDir), 0o755)
+
+	papers := []types.Paper{
+		{ID: "vaswani-2017", Title: "Attention Is All You Need", DOI: "10.5555/3295222.3295349", Date: time.Date(2017, 6, 12, 0, 0, 0, 0, time.UTC)},
+		{ID: "devlin-2019", Title: "BERT: Pre-training of Deep Bidirectional Transformers", ArxivID: "1810.04805"},
+		{ID: "survey", Title: "A Survey"},
+	}
+	for _, p := range papers {
+		data, _ := yaml.Marshal(&p)
+		os.WriteFile(filepath.Join(papersDir, metadataDir, p.ID+".yaml"), data, 0o644)
+	}
+
+	results := []types.ExtractionResult{
+		{
+			PaperID: "survey",
+			Bibliography: []types.BibliographyEntry{
+				{Key: "1", Title: "Attention is all you need", DOI: "10.5555/3295222.3295349"},
+				{Key: "2", Title: "BERT", ArxivID: "1810.04805"},
+				{Key: "3", Title: "Deep residual learning", DOI: "10.1109/CVPR.2016.90"},
+				{Key: "4", Title: "An untraceable technical report"},
+				{Key: "5", Title: "A survey", PaperID: "survey"},
+			},
+			Items: []types.KnowledgeItem{
+				{ID: "s1", Citations: []types.Citation{{Key: "1", BibIndex: 0}, {Key: "3", BibIndex: 2}}},
+				{ID: "s2", Citations: []types.Citation{{Key: "1", BibIndex: 0}, {Key: "4", BibIndex: 3}}},
+			},
+		},
+		{
+			PaperID: "devlin-2019",
+			Bibliography: []types.BibliographyEntry{
+				{Key: "Vaswani, 2017", Title: "Attention Is All You Need."},
+				{Key: "He, 2016", Title: "Deep residual learning", DOI: "10.1109/cvpr.2016.90"},
+			},
+		},
+	}
+	for _, r := range results {
+		data, _ := yaml.Marshal(&r)
+		os.WriteFile(filepath.Join(knowledgeDir, extractedDir, r.PaperID+"-items.yaml"), data, 0o644)
+	}
+
+	g, err := BuildCitationGraph(knowledgeDir, papersDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodes := make(map[string]GraphNode)
+	for _, n := range g.Nodes {
+		nodes[n.ID] = n
+	}
+	if len(nodes) != 4 {
+		t.Errorf("got nodes %v, want 3 papers and 1 external work", g.Nodes)
+	}
+	if n := nodes["vaswani-2017"]; n.Kind != NodePaper || n.CitedBy != 2 || n.Year != "2017" {
+		t.Errorf("vaswani-2017 = %+v, want a corpus paper from 2017 cited twice", n)
+	}
+	if n := nodes["doi:10.1109/cvpr.2016.90"]; n.Kind != NodeExternal || n.CitedBy != 2 {
+		t.Errorf("external DOI node = %+v, want cited twice", n)
+	}
+	if papers, external := g.Counts(); papers != 3 || external != 1 {
+		t.Errorf("Counts() = %d, %d; want 3, 1", papers, external)
+	}
+	if g.Unresolved != 1 {
+		t.Errorf("Unresolved = %d, want 1", g.Unresolved)
+	}
+
+	want := map[[2]string][]string{
+		{"devlin-2019", "doi:10.1109/cvpr.2016.90"}: nil,
+		{"devlin-2019", "vaswani-2017"}:             nil,
+		{"survey", "devlin-2019"}:                   nil,
+		{"survey", "doi:10.1109/cvpr.2016.90"}:      {"s1"},
+		{"survey", "vaswani-2017"}:                  {"s1", "s2"},
+	}
+	if len(g.Edges) != len(want) {
+		t.Fatalf("got edges %+v, want %d without the self-citation", g.Edges, len(want))
+	}
+	for _, e := range g.Edges {
+		items, ok := want[[2]string{e.From, e.To}]
+		if !ok {
+			t.Errorf("unexpected edge %s -> %s", e.From, e.To)
+			continue
+		}
+		if len(e.Items) != len(items) || (len(items) > 0 && e.Items[len(items)-1] != items[len(items)-1]) {
+			t.Errorf("edge %s -> %s: items %v, want %v", e.From, e.To, e.Items, items)
+		}
+	}
+
+	path := CitationGraphPath(knowledgeDir)
+	if err := WriteCitationGraph(path, g); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	var back CitationGraph
+	if err := json.Unmarshal(data, &back); err != nil || len(back.Edges) != len(g.Edges) {
+		t.Errorf("written graph does not round-trip: %v", err)
+	}
+}
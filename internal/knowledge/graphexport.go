@@ -0,0 +1,353 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Node kinds of an exported knowledge graph besides NodePaper and
+// NodeExternal.
+const (
+	// NodeItem is a knowledge item.
+	NodeItem = "item"
+
+	// NodeTag is a tag of the exported items.
+	NodeTag = "tag"
+)
+
+// Edge kinds of an exported knowledge graph.
+const (
+	// EdgeFrom links an item to its paper.
+	EdgeFrom = "from"
+
+	// EdgeTagged links an item, or a paper when items are left out, to a
+	// tag; its weight is the number of tagged items.
+	EdgeTagged = "tagged"
+
+	// EdgeCites links a paper, or an item citing inline, to the work it
+	// cites.
+	EdgeCites = "cites"
+)
+
+// graphItemLabel is the length items' content is cut to for their label.
+const graphItemLabel = 60
+
+// GraphExportOptions selects the knowledge graph ExportGraphML and
+// ExportDOT write (R6.8).
+type GraphExportOptions struct {
+	// Query selects the items, and through them the papers and tags, with
+	// the filters of retrieve.
+	Query QueryOptions
+
+	// Nodes lists the node kinds to include: paper, item, tag, and
+	// external. Empty includes them all.
+	Nodes []string
+
+	// MinCited keeps cited works outside the selected papers only when at
+	// least this many selected papers cite them.
+	MinCited int
+}
+
+// exportGraph is the knowledge graph in the order it is written.
+type exportGraph struct {
+	nodes []exportNode
+	edges []exportEdge
+}
+
+type exportNode struct {
+	id       string
+	kind     string
+	label    string
+	itemType string
+	year     string
+	// confidence is set for items, citedBy for cited works.
+	confidence float64
+	citedBy    int
+}
+
+type exportEdge struct {
+	from, to string
+	kind     string
+	weight   int
+}
+
+// checkGraphNodes rejects unknown node kinds in GraphExportOptions.Nodes.
+func checkGraphNodes(kinds []string) error {
+	for _, k := range kinds {
+		switch k {
+		case NodePaper, NodeItem, NodeTag, NodeExternal:
+		default:
+			return fmt.Errorf("unknown graph node kind %q: use %s, %s, %s, or %s", k, NodePaper, NodeItem, NodeTag, NodeExternal)
+		}
+	}
+	return nil
+}
+
+// knowledgeGraph builds the graph of the selected items, their papers and
+// tags, and the papers' citations from the corpus citation graph (R11.1).
+// Node IDs are prefixed by kind ("paper:", "item:", "tag:"); cited works
+// outside the corpus keep their "doi:" or "arxiv:" ID.
+func (s *Store) knowledgeGraph(ctx context.Context, opts GraphExportOptions) (*exportGraph, error) {
+	if err := checkGraphNodes(opts.Nodes); err != nil {
+		return nil, err
+	}
+	include := func(kind string) bool {
+		return len(opts.Nodes) == 0 || slices.Contains(opts.Nodes, kind)
+	}
+	entries, err := s.exportEntries(ctx, opts.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &exportGraph{}
+	// nodes indexes g.nodes by ID.
+	nodes := make(map[string]int)
+	addNode := func(n exportNode) {
+		if _, ok := nodes[n.id]; !ok {
+			nodes[n.id] = len(g.nodes)
+			g.nodes = append(g.nodes, n)
+		}
+	}
+	weights := make(map[[2]string]int)
+	var order [][2]string
+	edgeKind := make(map[[2]string]string)
+	addEdge := func(from, to, kind string) {
+		key := [2]string{from, to}
+		if weights[key] == 0 {
+			order = append(order, key)
+			edgeKind[key] = kind
+		}
+		weights[key]++
+	}
+
+	papers := make(map[string]bool)
+	for _, e := range entries {
+		paperNode := "paper:" + e.PaperID
+		if !papers[e.PaperID] {
+			papers[e.PaperID] = true
+			if include(NodePaper) {
+				label := e.PaperID
+				if e.Paper != nil && e.Paper.Title != "" {
+					label = e.Paper.Title
+				}
+				addNode(exportNode{id: paperNode, kind: NodePaper, label: label})
+			}
+		}
+		from := paperNode
+		if include(NodeItem) {
+			from = "item:" + e.ID
+			label := e.Content
+			if len(label) > graphItemLabel {
+				label = label[:graphItemLabel-3] + "..."
+			}
+			addNode(exportNode{id: from, kind: NodeItem, label: label, itemType: e.Type, confidence: e.Confidence})
+			if include(NodePaper) {
+				addEdge(from, paperNode, EdgeFrom)
+			}
+		} else if !include(NodePaper) {
+			continue
+		}
+		if include(NodeTag) {
+			for _, t := range e.Tags {
+				addNode(exportNode{id: "tag:" + t, kind: NodeTag, label: t})
+				addEdge(from, "tag:"+t, EdgeTagged)
+			}
+		}
+	}
+
+	cg, err := BuildCitationGraph(s.knowledgeDir, s.papersDir)
+	if errors.Is(err, os.ErrNotExist) {
+		cg = &CitationGraph{}
+	} else if err != nil {
+		return nil, err
+	}
+	selectedItems := make(map[string]bool)
+	for _, e := range entries {
+		selectedItems[e.ID] = true
+	}
+	cited := make(map[string]int)
+	for _, e := range cg.Edges {
+		if papers[e.From] {
+			cited[e.To]++
+		}
+	}
+	for _, n := range cg.Nodes {
+		id := n.ID
+		switch {
+		case n.Kind == NodePaper && papers[n.ID]:
+			if !include(NodePaper) {
+				continue
+			}
+			id = "paper:" + n.ID
+		case include(NodeExternal) && cited[n.ID] > 0 && cited[n.ID] >= opts.MinCited:
+			if n.Kind == NodePaper {
+				id = "paper:" + n.ID
+			}
+		default:
+			continue
+		}
+		label := n.Title
+		if label == "" {
+			label = n.ID
+		}
+		if i, ok := nodes[id]; ok {
+			// Selected papers are already in the graph.
+			g.nodes[i].year, g.nodes[i].citedBy = n.Year, cited[n.ID]
+			continue
+		}
+		addNode(exportNode{id: id, kind: n.Kind, label: label, year: n.Year, citedBy: cited[n.ID]})
+	}
+	for _, e := range cg.Edges {
+		if !papers[e.From] {
+			continue
+		}
+		to := e.To
+		if !strings.HasPrefix(to, "doi:") && !strings.HasPrefix(to, "arxiv:") {
+			to = "paper:" + to
+		}
+		if _, ok := nodes[to]; !ok {
+			continue
+		}
+		if from := "paper:" + e.From; include(NodePaper) {
+			addEdge(from, to, EdgeCites)
+		}
+		for _, item := range e.Items {
+			if from := "item:" + item; selectedItems[item] && include(NodeItem) {
+				addEdge(from, to, EdgeCites)
+			}
+		}
+	}
+
+	for _, key := range order {
+		g.edges = append(g.edges, exportEdge{from: key[0], to: key[1], kind: edgeKind[key], weight: weights[key]})
+	}
+	sort.SliceStable(g.nodes, func(i, j int) bool { return g.nodes[i].id < g.nodes[j].id })
+	sort.SliceStable(g.edges, func(i, j int) bool {
+		if g.edges[i].from != g.edges[j].from {
+			return g.edges[i].from < g.edges[j].from
+		}
+		return g.edges[i].to < g.edges[j].to
+	})
+	return g, nil
+}
+
+// ExportGraphML writes the knowledge graph of the selected items to
+// knowledge/index/export.graphml (R6.8), for Gephi, Cytoscape, or yEd.
+// Nodes carry label, kind, item_type, year, confidence, and cited_by
+// attributes; edges carry kind and weight.
+func (s *Store) ExportGraphML(ctx context.Context, opts GraphExportOptions) error {
+	g, err := s.knowledgeGraph(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	for _, k := range []struct{ id, scope, typ string }{
+		{"label", "node", "string"},
+		{"kind", "node", "string"},
+		{"item_type", "node", "string"},
+		{"year", "node", "string"},
+		{"confidence", "node", "double"},
+		{"cited_by", "node", "int"},
+		{"edge_kind", "edge", "string"},
+		{"weight", "edge", "int"},
+	} {
+		name := strings.TrimPrefix(k.id, "edge_")
+		fmt.Fprintf(&b, `  <key id="%s" for="%s" attr.name="%s" attr.type="%s"/>`+"\n", k.id, k.scope, name, k.typ)
+	}
+	b.WriteString(`  <graph id="knowledge" edgedefault="directed">` + "\n")
+	for _, n := range g.nodes {
+		fmt.Fprintf(&b, `    <node id="%s">`, xmlEscape(n.id))
+		data := [][2]string{{"label", n.label}, {"kind", n.kind}, {"item_type", n.itemType}, {"year", n.year}}
+		if n.kind == NodeItem {
+			data = append(data, [2]string{"confidence", strconv.FormatFloat(n.confidence, 'g', -1, 64)})
+		}
+		if n.citedBy > 0 {
+			data = append(data, [2]string{"cited_by", strconv.Itoa(n.citedBy)})
+		}
+		for _, d := range data {
+			if d[1] != "" {
+				fmt.Fprintf(&b, `<data key="%s">%s</data>`, d[0], xmlEscape(d[1]))
+			}
+		}
+		b.WriteString("</node>\n")
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(&b, `    <edge source="%s" target="%s"><data key="edge_kind">%s</data><data key="weight">%d</data></edge>`+"\n",
+			xmlEscape(e.from), xmlEscape(e.to), e.kind, e.weight)
+	}
+	b.WriteString("  </graph>\n</graphml>\n")
+
+	return os.WriteFile(filepath.Join(s.knowledgeDir, indexDir, "export.graphml"), []byte(b.String()), 0o644)
+}
+
+// dotStyles sets the Graphviz node shape of each kind and the edge style
+// of each edge kind.
+var dotStyles = map[string]string{
+	NodePaper:    `shape=box, style=filled, fillcolor="#dbe9f6"`,
+	NodeExternal: `shape=box, style=dashed`,
+	NodeItem:     `shape=ellipse`,
+	NodeTag:      `shape=hexagon, style=filled, fillcolor="#f6e8c3"`,
+	EdgeFrom:     `style=dotted, arrowhead=none`,
+	EdgeTagged:   `style=dashed, color=gray50`,
+	EdgeCites:    `color=black`,
+}
+
+// ExportDOT writes the knowledge graph of the selected items to
+// knowledge/index/export.dot (R6.8), for Graphviz. Node shapes and edge
+// styles tell the kinds apart; tag edges with a weight above one carry it
+// as their label.
+func (s *Store) ExportDOT(ctx context.Context, opts GraphExportOptions) error {
+	g, err := s.knowledgeGraph(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph knowledge {\n  rankdir=LR;\n")
+	for _, n := range g.nodes {
+		label := n.label
+		if n.kind == NodeItem {
+			label = n.itemType + ": " + label
+		} else if n.year != "" {
+			label += " (" + n.year + ")"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, %s];\n", dotQuote(n.id), dotQuote(label), dotStyles[n.kind])
+	}
+	for _, e := range g.edges {
+		attrs := dotStyles[e.kind]
+		if e.weight > 1 {
+			attrs += fmt.Sprintf(", label=%d, weight=%d", e.weight, e.weight)
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(e.from), dotQuote(e.to), attrs)
+	}
+	b.WriteString("}\n")
+
+	return os.WriteFile(filepath.Join(s.knowledgeDir, indexDir, "export.dot"), []byte(b.String()), 0o644)
+}
+
+// xmlEscape escapes text for an XML attribute or element.
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// dotQuote returns s as a quoted Graphviz ID.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", " ") + `"`
+}
@@ -0,0 +1,182 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// graphSetup stores a survey citing a corpus paper and two external works,
+// one of which the corpus paper cites too.
+func graphSetup(t *testing.T) *Store {
+	t.Helper()
+	store, tmpDir := testSetup(t)
+	results := []types.ExtractionResult{
+		{
+			PaperID: "survey",
+			Bibliography: []types.BibliographyEntry{
+				{Key: "1", Title: "Base paper", PaperID: "base"},
+				{Key: "2", Title: "Deep residual learning", DOI: "10.1109/cvpr.2016.90"},
+				{Key: "3", Title: "BERT", ArxivID: "1810.04805"},
+			},
+			Items: []types.KnowledgeItem{
+				{ID: "survey-c1", Type: types.ItemClaim, PaperID: "survey", Content: `Residual "skip" links help`,
+					Section: "Method", Page: 1, Confidence: 0.9, Tags: []string{"residual", "depth"},
+					Citations: []types.Citation{{Key: "2", BibIndex: 1}}},
+				{ID: "survey-c2", Type: types.ItemClaim, PaperID: "survey", Content: "Depth improves accuracy",
+					Section: "Method", Page: 2, Confidence: 0.8, Tags: []string{"depth"}},
+			},
+		},
+		{
+			PaperID: "base",
+			Bibliography: []types.BibliographyEntry{
+				{Key: "1", Title: "Deep residual learning", DOI: "10.1109/cvpr.2016.90"},
+			},
+			Items: []types.KnowledgeItem{
+				{ID: "base-d1", Type: types.ItemDefinition, PaperID: "base", Content: "Depth is the number of layers",
+					Section: "Background", Page: 1, Confidence: 0.95, Tags: []string{"depth"}},
+			},
+		},
+	}
+	for _, r := range results {
+		data, _ := yaml.Marshal(&r)
+		os.WriteFile(filepath.Join(tmpDir, "knowledge", extractedDir, r.PaperID+"-items.yaml"), data, 0o644)
+		writePaperMeta(t, tmpDir, types.Paper{ID: r.PaperID, Title: "Paper " + r.PaperID})
+	}
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func graphEdges(g *exportGraph) map[string]exportEdge {
+	edges := make(map[string]exportEdge)
+	for _, e := range g.edges {
+		edges[e.from+" -> "+e.to] = e
+	}
+	return edges
+}
+
+func TestKnowledgeGraph(t *testing.T) {
+	store := graphSetup(t)
+	ctx := context.Background()
+
+	g, err := store.knowledgeGraph(ctx, GraphExportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.nodes) != 2+3+2+2 {
+		t.Errorf("got %d nodes %v, want 2 papers, 3 items, 2 tags, 2 external works", len(g.nodes), g.nodes)
+	}
+	edges := graphEdges(g)
+	for _, want := range []struct{ edge, kind string }{
+		{"item:survey-c1 -> paper:survey", EdgeFrom},
+		{"item:survey-c1 -> tag:residual", EdgeTagged},
+		{"item:survey-c1 -> doi:10.1109/cvpr.2016.90", EdgeCites},
+		{"paper:survey -> paper:base", EdgeCites},
+		{"paper:base -> doi:10.1109/cvpr.2016.90", EdgeCites},
+		{"paper:survey -> arxiv:1810.04805", EdgeCites},
+	} {
+		if e, ok := edges[want.edge]; !ok || e.kind != want.kind {
+			t.Errorf("edge %s = %+v, want kind %s", want.edge, e, want.kind)
+		}
+	}
+
+	// Without items, papers link to tags weighted by their tagged items,
+	// and only works cited by both papers are kept.
+	g, err = store.knowledgeGraph(ctx, GraphExportOptions{Nodes: []string{NodePaper, NodeTag, NodeExternal}, MinCited: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.nodes) != 2+2+1 {
+		t.Errorf("got nodes %v, want 2 papers, 2 tags, and the work both cite", g.nodes)
+	}
+	edges = graphEdges(g)
+	if e := edges["paper:survey -> tag:depth"]; e.kind != EdgeTagged || e.weight != 2 {
+		t.Errorf("survey depth edge = %+v, want weight 2", e)
+	}
+	if _, ok := edges["paper:survey -> arxiv:1810.04805"]; ok {
+		t.Error("work cited once kept with MinCited 2")
+	}
+
+	// A filter selects items and, through them, papers.
+	g, err = store.knowledgeGraph(ctx, GraphExportOptions{Query: QueryOptions{PaperID: "base"}, Nodes: []string{NodePaper, NodeItem}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.nodes) != 2 || len(g.edges) != 1 {
+		t.Errorf("got %v / %v, want base and its item", g.nodes, g.edges)
+	}
+
+	if _, err := store.knowledgeGraph(ctx, GraphExportOptions{Nodes: []string{"author"}}); err == nil {
+		t.Error("unknown node kind accepted")
+	}
+}
+
+func TestExportGraphMLAndDOT(t *testing.T) {
+	store := graphSetup(t)
+	ctx := context.Background()
+
+	if err := store.ExportGraphML(ctx, GraphExportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(store.knowledgeDir, indexDir, "export.graphml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Graph struct {
+			Nodes []struct {
+				ID   string `xml:"id,attr"`
+				Data []struct {
+					Key   string `xml:"key,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"data"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid GraphML: %v", err)
+	}
+	if len(doc.Graph.Nodes) != 9 || len(doc.Graph.Edges) == 0 {
+		t.Errorf("GraphML has %d nodes and %d edges", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+	for _, n := range doc.Graph.Nodes {
+		if n.ID == "item:survey-c1" && (len(n.Data) == 0 || n.Data[0].Value != `Residual "skip" links help`) {
+			t.Errorf("item node data = %+v", n.Data)
+		}
+	}
+
+	if err := store.ExportDOT(ctx, GraphExportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data, err = os.ReadFile(filepath.Join(store.knowledgeDir, indexDir, "export.dot"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dot := string(data)
+	for _, want := range []string{
+		"digraph knowledge {",
+		`"item:survey-c1" [label="claim: Residual \"skip\" links help", shape=ellipse];`,
+		`"item:survey-c2" -> "tag:depth"`,
+		`"paper:survey" -> "paper:base" [color=black];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT lacks %q:\n%s", want, dot)
+		}
+	}
+}
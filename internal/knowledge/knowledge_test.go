@@ -3,11 +3,15 @@
 package knowledge
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -279,6 +283,29 @@ func TestIngestPopulatesPapersTable(t *testing.T) {
 	}
 }
 
+func TestIngestStoresPaperSummary(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	result := types.ExtractionResult{
+		PaperID: "p1",
+		Items:   sampleItems("p1"),
+		Summary: &types.PaperSummary{Problem: "Attention is quadratic.", Approach: "A linear approximation.", Findings: "Same accuracy."},
+	}
+	data, _ := yaml.Marshal(&result)
+	os.WriteFile(filepath.Join(tmpDir, "knowledge", extractedDir, "p1-items.yaml"), data, 0o644)
+	writePaperMeta(t, tmpDir, samplePaper("p1"))
+	if _, err := store.Ingest(context.Background(), io.Discard); err != nil {
+		t.Fatal(err)
+	}
+
+	var summary string
+	if err := store.db.QueryRow(`SELECT summary FROM papers WHERE id = ?`, "p1").Scan(&summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary != "Attention is quadratic. A linear approximation. Same accuracy." {
+		t.Errorf("summary = %q", summary)
+	}
+}
+
 func TestIngestWritesExportYAML(t *testing.T) {
 	store, tmpDir := testSetup(t)
 	ingestHelper(t, store, tmpDir, "paper-export")
@@ -437,6 +464,44 @@ func TestRetrieveFullTextSearchIncludesPaperMetadata(t *testing.T) {
 	}
 }
 
+func TestRetrieveFullTextScoreAndSnippet(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestHelper(t, store, tmpDir, "score-paper")
+
+	results, err := store.Retrieve(context.Background(), QueryOptions{Query: "softmax"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) < 2 {
+		t.Fatalf("got %d results, want at least 2", len(results))
+	}
+	for i, r := range results {
+		if r.Score <= 0 {
+			t.Errorf("result %s score = %v, want a positive relevance", r.ID, r.Score)
+		}
+		if i > 0 && r.Score > results[i-1].Score {
+			t.Errorf("result %s scores %v above the previous %v", r.ID, r.Score, results[i-1].Score)
+		}
+		if !strings.Contains(strings.ToLower(r.Snippet), "**softmax**") {
+			t.Errorf("snippet %q does not mark the match", r.Snippet)
+		}
+		if strings.ReplaceAll(r.Highlight, "**", "") != r.Content {
+			t.Errorf("highlight %q is not the marked content %q", r.Highlight, r.Content)
+		}
+	}
+
+	// Structured queries have no relevance or match context.
+	results, err = store.Retrieve(context.Background(), QueryOptions{PaperID: "score-paper"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.Score != 0 || r.Snippet != "" || r.Highlight != "" {
+			t.Errorf("structured result %s has score %v, snippet %q", r.ID, r.Score, r.Snippet)
+		}
+	}
+}
+
 func TestRetrieveRespectsMaxResults(t *testing.T) {
 	store, tmpDir := testSetup(t)
 	ingestHelper(t, store, tmpDir, "limit-paper")
@@ -577,6 +642,168 @@ func TestRetrieveCombinedQuery(t *testing.T) {
 	}
 }
 
+func TestRetrieveColumnFilters(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestHelper(t, store, tmpDir, "col-paper")
+	ctx := context.Background()
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"section:method attention", []string{"col-paper-claim1", "col-paper-method1"}},
+		{"section:background", []string{"col-paper-def1"}},
+		{`tags:"linear-approximation"`, []string{"col-paper-method1"}},
+		{"tags:benchmark OR tags:softmax", []string{"col-paper-def1", "col-paper-result1"}},
+		{"content:method", []string{"col-paper-result1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results, err := store.Retrieve(ctx, QueryOptions{Query: tt.query})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, r := range results {
+				got = append(got, r.ID)
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Retrieve(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetrieveRangeFilters(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	for _, p := range []struct {
+		id   string
+		date time.Time
+	}{
+		{"old-paper", time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"new-paper", time.Date(2023, 2, 14, 0, 0, 0, 0, time.UTC)},
+		{"undated-paper", time.Time{}},
+	} {
+		writeExtraction(t, tmpDir, p.id, sampleItems(p.id))
+		paper := samplePaper(p.id)
+		paper.Date = p.date
+		writePaperMeta(t, tmpDir, paper)
+	}
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		opts QueryOptions
+		want []string
+	}{
+		{"min confidence", QueryOptions{PaperID: "new-paper", MinConfidence: 0.95}, []string{"new-paper-method1", "new-paper-result1"}},
+		{"page range", QueryOptions{PaperID: "new-paper", Pages: PageRange{First: 2, Last: 3}}, []string{"new-paper-claim1", "new-paper-method1"}},
+		{"pages from", QueryOptions{PaperID: "new-paper", Pages: PageRange{First: 4}}, []string{"new-paper-result1"}},
+		{"after 2022", QueryOptions{Type: types.ItemResult, DateFrom: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}, []string{"new-paper-result1"}},
+		{"until 2021", QueryOptions{Type: types.ItemResult, DateTo: time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)}, []string{"old-paper-result1"}},
+		{"on the last day", QueryOptions{Type: types.ItemResult, DateTo: time.Date(2023, 2, 14, 0, 0, 0, 0, time.UTC), DateFrom: time.Date(2023, 2, 14, 0, 0, 0, 0, time.UTC)}, []string{"new-paper-result1"}},
+		{"high confidence after 2022", QueryOptions{Query: "attention", MinConfidence: 0.9, DateFrom: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}, []string{"new-paper-claim1", "new-paper-method1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := store.Retrieve(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, r := range results {
+				got = append(got, r.ID)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePageRange(t *testing.T) {
+	tests := []struct {
+		in   string
+		want PageRange
+	}{
+		{"5", PageRange{5, 5}},
+		{"3-7", PageRange{3, 7}},
+		{"3-", PageRange{First: 3}},
+		{"-7", PageRange{Last: 7}},
+	}
+	for _, tt := range tests {
+		if got, err := ParsePageRange(tt.in); err != nil || got != tt.want {
+			t.Errorf("ParsePageRange(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "-", "7-3", "0", "a-b"} {
+		if _, err := ParsePageRange(bad); err == nil {
+			t.Errorf("ParsePageRange(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestRetrieveMatchesResolvedContent(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	items := sampleItems("coref-paper")
+	items[3].ResolvedContent = "Efficient attention achieves 89.2% accuracy on the GLUE benchmark"
+	writeExtraction(t, tmpDir, "coref-paper", items)
+	writePaperMeta(t, tmpDir, samplePaper("coref-paper"))
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := store.Retrieve(context.Background(), QueryOptions{Query: "efficient GLUE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].ID != "coref-paper-result1" {
+		t.Fatalf("results = %+v, want the result item via its resolved content", results)
+	}
+	if results[0].Content != items[3].Content || results[0].ResolvedContent != items[3].ResolvedContent {
+		t.Errorf("Content = %q, ResolvedContent = %q", results[0].Content, results[0].ResolvedContent)
+	}
+}
+
+func TestNewStoreUpgradesContentOnlyFTS(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestHelper(t, store, tmpDir, "old-paper")
+
+	// Recreate the pre-column FTS table, as older databases, created
+	// before schema versioning, have it.
+	for _, stmt := range []string{
+		`DROP TABLE schema_version`,
+		`DROP TRIGGER items_ai`, `DROP TRIGGER items_ad`, `DROP TRIGGER items_au`,
+		`DROP TABLE items_fts`,
+		`CREATE VIRTUAL TABLE items_fts USING fts5(content, content=items, content_rowid=rowid)`,
+		`INSERT INTO items_fts(items_fts) VALUES('rebuild')`,
+	} {
+		if _, err := store.db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	store.Close()
+
+	store, err := NewStore(types.KnowledgeBaseConfig{KnowledgeDir: filepath.Join(tmpDir, "knowledge")}, filepath.Join(tmpDir, "papers"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	results, err := store.Retrieve(context.Background(), QueryOptions{Query: "section:results"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].ID != "old-paper-result1" {
+		t.Errorf("after upgrade got %+v", results)
+	}
+}
+
 func TestRetrieveStructuredQuerySortOrder(t *testing.T) {
 	store, tmpDir := testSetup(t)
 
@@ -602,6 +829,109 @@ func TestRetrieveStructuredQuerySortOrder(t *testing.T) {
 	}
 }
 
+func TestRetrieveByMetric(t *testing.T) {
+	store, tmpDir := testSetup(t)
+
+	result := func(id, metric string, value float64, dataset string) types.KnowledgeItem {
+		return types.KnowledgeItem{
+			ID: id, Type: types.ItemResult, Content: "Result " + id, PaperID: "p1",
+			Section: "Results", Page: 1, Confidence: 0.9,
+			Metric: &types.Metric{Name: metric, Value: value, Unit: "%", Dataset: dataset},
+		}
+	}
+	writeExtraction(t, tmpDir, "p1", []types.KnowledgeItem{
+		result("r1", "accuracy", 88.1, "GLUE"),
+		result("r2", "accuracy", 91.3, "GLUE benchmark"),
+		result("r3", "accuracy", 95.0, "SQuAD"),
+		result("r4", "error rate", 4.2, "GLUE"),
+		result("r5", "error rate", 3.1, "GLUE"),
+		sampleItems("p1")[0],
+	})
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := func(opts QueryOptions) []string {
+		t.Helper()
+		results, err := store.Retrieve(context.Background(), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []string
+		for _, r := range results {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name string
+		opts QueryOptions
+		want string
+	}{
+		{"best accuracy on GLUE", QueryOptions{Metric: "Accuracy", Dataset: "glue"}, "r2 r1"},
+		{"every accuracy", QueryOptions{Metric: "accuracy"}, "r3 r2 r1"},
+		{"error rates rank ascending", QueryOptions{Metric: "error"}, "r5 r4"},
+		{"lower is better", QueryOptions{Metric: "accuracy", Dataset: "glue", LowerIsBetter: true}, "r1 r2"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(ids(tt.opts), " "); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	results, _ := store.Retrieve(context.Background(), QueryOptions{Metric: "accuracy", Dataset: "squad"})
+	if len(results) != 1 || results[0].Metric == nil || *results[0].Metric != *result("r3", "accuracy", 95.0, "SQuAD").Metric {
+		t.Errorf("metric not round-tripped: %+v", results)
+	}
+}
+
+func TestRetrieveByClaim(t *testing.T) {
+	store, tmpDir := testSetup(t)
+
+	claim := func(id string, number, dependsOn int) types.KnowledgeItem {
+		return types.KnowledgeItem{
+			ID: id, Type: types.ItemClaim, Content: "Claim " + id, PaperID: "p1",
+			Section: "Claims", Page: 9, Confidence: 0.95,
+			PatentClaim: &types.PatentClaim{Number: number, DependsOn: dependsOn, Independent: dependsOn == 0},
+		}
+	}
+	writeExtraction(t, tmpDir, "p1", []types.KnowledgeItem{claim("c2", 2, 1), claim("c10", 10, 0), claim("c1", 1, 0)})
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := store.Retrieve(context.Background(), QueryOptions{PaperID: "p1", Claim: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].ID != "c2" || results[0].PatentClaim == nil || *results[0].PatentClaim != *claim("c2", 2, 1).PatentClaim {
+		t.Errorf("claim 2 = %+v, want c2 depending on claim 1", results)
+	}
+
+	results, _ = store.Retrieve(context.Background(), QueryOptions{PaperID: "p1"})
+	var order []string
+	for _, r := range results {
+		order = append(order, r.ID)
+	}
+	if got := strings.Join(order, " "); got != "c1 c2 c10" {
+		t.Errorf("claims listed %q, want claim order c1 c2 c10", got)
+	}
+}
+
+func TestLowerIsBetter(t *testing.T) {
+	for metric, want := range map[string]bool{
+		"accuracy": false, "bleu": false, "top-1 error": true, "perplexity": true,
+		"WER": true, "inference latency": true, "f1": false,
+	} {
+		if got := LowerIsBetter(metric); got != want {
+			t.Errorf("LowerIsBetter(%q) = %v, want %v", metric, got, want)
+		}
+	}
+}
+
 func TestRetrieveEmptyQueryError(t *testing.T) {
 	opts := QueryOptions{}
 	if !opts.IsEmpty() {
@@ -670,6 +1000,19 @@ func TestTraceItemNotFound(t *testing.T) {
 	}
 }
 
+func TestItemPaperID(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestHelper(t, store, tmpDir, "trace-paper")
+
+	ctx := context.Background()
+	if got, err := store.ItemPaperID(ctx, "trace-paper-claim1"); err != nil || got != "trace-paper" {
+		t.Errorf("ItemPaperID = %q, %v", got, err)
+	}
+	if _, err := store.ItemPaperID(ctx, "nonexistent-item"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("error = %v, want 'not found'", err)
+	}
+}
+
 func TestTraceMarkdownMissing(t *testing.T) {
 	store, tmpDir := testSetup(t)
 	ingestHelper(t, store, tmpDir, "missing-md-paper")
@@ -793,6 +1136,56 @@ func TestExportFilteredByTag(t *testing.T) {
 	}
 }
 
+func TestExportOrder(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestHelper(t, store, tmpDir, "b-paper")
+	ingestHelper(t, store, tmpDir, "a-paper")
+	ctx := context.Background()
+	path := filepath.Join(tmpDir, "knowledge", indexDir, "export.yaml")
+
+	export := func(opts QueryOptions) ([]byte, []ExportEntry) {
+		t.Helper()
+		if err := store.ExportYAML(ctx, opts); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var entries []ExportEntry
+		if err := yaml.Unmarshal(data, &entries); err != nil {
+			t.Fatal(err)
+		}
+		return data, entries
+	}
+
+	first, entries := export(QueryOptions{Query: "attention"})
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.ID)
+	}
+	want := []string{"a-paper-def1", "a-paper-claim1", "a-paper-method1", "b-paper-def1", "b-paper-claim1", "b-paper-method1"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("document order = %v, want %v", got, want)
+	}
+	if again, _ := export(QueryOptions{Query: "attention"}); string(again) != string(first) {
+		t.Error("re-exporting an unchanged knowledge base changed the file")
+	}
+
+	ranked, err := store.Retrieve(ctx, QueryOptions{Query: "attention", MaxResults: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, entries = export(QueryOptions{Query: "attention", Order: OrderScore, MaxResults: 2})
+	if len(entries) != 2 || entries[0].ID != ranked[0].ID || entries[1].ID != ranked[1].ID {
+		t.Errorf("score order = %+v, want top matches %s, %s", entries, ranked[0].ID, ranked[1].ID)
+	}
+
+	if err := store.ExportYAML(ctx, QueryOptions{Order: OrderScore}); err == nil {
+		t.Error("score order without a query should fail")
+	}
+}
+
 // --- IngestSummary ---
 
 func TestIngestSummaryTotal(t *testing.T) {
@@ -842,3 +1235,37 @@ We achieve high accuracy.
 		})
 	}
 }
+
+func TestIngestRegistersMetadataOnlyPapers(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestHelper(t, store, tmpDir, "2301.07041")
+
+	paper := types.Paper{
+		ID:        "10.1234-paywalled",
+		Title:     "A Paywalled Study",
+		DOI:       "10.1234/paywalled",
+		SourceURL: "https://doi.org/10.1234/paywalled",
+		Source:    "doi",
+		Status:    types.AcquisitionMetadataOnly,
+	}
+	writePaperMeta(t, tmpDir, paper)
+
+	var buf bytes.Buffer
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "registered 1 metadata-only paper(s)") {
+		t.Errorf("output missing registration line:\n%s", buf.String())
+	}
+
+	var title, sourceURL, source, status string
+	err := store.db.QueryRow(
+		`SELECT title, source_url, source, status FROM papers WHERE id = ?`, paper.ID,
+	).Scan(&title, &sourceURL, &source, &status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title != paper.Title || sourceURL != paper.SourceURL || source != "doi" || status != "metadata_only" {
+		t.Errorf("paper row = (%q, %q, %q, %q)", title, sourceURL, source, status)
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// typeColumnPrefix marks a matrix column that selects an item type rather
+// than a tag, as in "type:method".
+const typeColumnPrefix = "type:"
+
+// defaultCellChars is the cell length when MatrixOptions.MaxChars is zero.
+const defaultCellChars = 80
+
+// MatrixOptions selects the papers and columns of a comparison matrix
+// (R10.1).
+type MatrixOptions struct {
+	// Columns are tags, or "type:<item type>" for an item type.
+	Columns []string
+
+	// PaperIDs restricts the rows. Empty includes every paper with an
+	// item in at least one column.
+	PaperIDs []string
+
+	// CiteKeys maps paper IDs to citation keys, for example those of a
+	// paper project's references.yaml. Papers not listed get an
+	// AuthorYear key from their metadata.
+	CiteKeys map[string]string
+
+	// MaxChars truncates cell text. Zero uses a default of 80; a negative
+	// value keeps the full text.
+	MaxChars int
+}
+
+// MatrixCell is the item a paper contributes to a column.
+type MatrixCell struct {
+	// ItemID is the highest-confidence matching item; empty when the
+	// paper has none.
+	ItemID  string `json:"item_id,omitempty" yaml:"item_id,omitempty"`
+	Content string `json:"content,omitempty" yaml:"content,omitempty"`
+	// Count is the number of matching items.
+	Count int `json:"count" yaml:"count"`
+}
+
+// MatrixRow is one paper of a comparison matrix.
+type MatrixRow struct {
+	PaperID string       `json:"paper_id" yaml:"paper_id"`
+	Title   string       `json:"title" yaml:"title"`
+	CiteKey string       `json:"cite_key" yaml:"cite_key"`
+	Cells   []MatrixCell `json:"cells" yaml:"cells"`
+}
+
+// Matrix compares papers across columns of tags or item types (R10.1).
+type Matrix struct {
+	Columns []string    `json:"columns" yaml:"columns"`
+	Rows    []MatrixRow `json:"rows" yaml:"rows"`
+}
+
+// Matrix builds a comparison matrix: one row per paper, sorted by citation
+// key, and one cell per column holding the paper's highest-confidence
+// matching item (R10.1).
+func (s *Store) Matrix(ctx context.Context, opts MatrixOptions) (Matrix, error) {
+	if len(opts.Columns) == 0 {
+		return Matrix{}, fmt.Errorf("matrix requires at least one column")
+	}
+	maxChars := opts.MaxChars
+	if maxChars == 0 {
+		maxChars = defaultCellChars
+	}
+	wanted := make(map[string]bool, len(opts.PaperIDs))
+	for _, id := range opts.PaperIDs {
+		wanted[id] = true
+	}
+
+	m := Matrix{Columns: opts.Columns}
+	rows := make(map[string]*MatrixRow)
+	rowFor := func(paperID string) *MatrixRow {
+		r, ok := rows[paperID]
+		if !ok {
+			r = &MatrixRow{PaperID: paperID, Cells: make([]MatrixCell, len(opts.Columns))}
+			rows[paperID] = r
+		}
+		return r
+	}
+	for _, id := range opts.PaperIDs {
+		rowFor(id)
+	}
+	// best holds the confidence of each filled cell's item.
+	best := make(map[*MatrixCell]float64)
+
+	for col, column := range opts.Columns {
+		q := QueryOptions{MaxResults: math.MaxInt32}
+		if t, ok := strings.CutPrefix(column, typeColumnPrefix); ok {
+			q.Type = types.KnowledgeItemType(t)
+		} else {
+			q.Tags = []string{column}
+		}
+		results, err := s.Retrieve(ctx, q)
+		if err != nil {
+			return Matrix{}, fmt.Errorf("column %q: %w", column, err)
+		}
+		for _, r := range results {
+			if len(wanted) > 0 && !wanted[r.PaperID] {
+				continue
+			}
+			cell := &rowFor(r.PaperID).Cells[col]
+			cell.Count++
+			if conf, ok := best[cell]; !ok || r.Confidence > conf {
+				cell.ItemID, cell.Content = r.ID, truncateCell(r.Content, maxChars)
+				best[cell] = r.Confidence
+			}
+		}
+	}
+
+	var ids []string
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	keys, err := s.CiteKeys(ctx, ids, opts.CiteKeys)
+	if err != nil {
+		return Matrix{}, err
+	}
+	for _, id := range ids {
+		r := rows[id]
+		title, _, _, err := s.paperCiteInfo(ctx, id)
+		if err != nil {
+			return Matrix{}, err
+		}
+		r.Title, r.CiteKey = title, keys[id]
+		m.Rows = append(m.Rows, *r)
+	}
+	sort.SliceStable(m.Rows, func(i, j int) bool { return m.Rows[i].CiteKey < m.Rows[j].CiteKey })
+	return m, nil
+}
+
+// paperCiteInfo returns the title, authors, and date of a paper, or of its
+// canonical version when it is linked to one.
+func (s *Store) paperCiteInfo(ctx context.Context, paperID string) (string, []string, string, error) {
+	var title, authorsJSON, date sql.NullString
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COALESCE(c.title, p.title), COALESCE(c.authors, p.authors), COALESCE(c.date, p.date)
+		FROM papers p LEFT JOIN papers c ON c.id = p.canonical_id
+		WHERE p.id = ?`, paperID,
+	).Scan(&title, &authorsJSON, &date)
+	if err == sql.ErrNoRows {
+		return "", nil, "", nil
+	}
+	if err != nil {
+		return "", nil, "", fmt.Errorf("reading paper %s: %w", paperID, err)
+	}
+	var authors []string
+	if authorsJSON.Valid {
+		json.Unmarshal([]byte(authorsJSON.String), &authors)
+	}
+	return title.String, authors, date.String, nil
+}
+
+// citeKey derives an AuthorYear citation key, such as "Vaswani2017", from
+// the first author's surname and the year of date. A paper without authors
+// is keyed by its ID.
+func citeKey(paperID string, authors []string, date string) string {
+	name := paperID
+	if len(authors) > 0 {
+		name = authorSurname(authors[0])
+	}
+	var b strings.Builder
+	for _, r := range name {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	if len(authors) > 0 && len(date) >= 4 {
+		b.WriteString(date[:4])
+	}
+	if b.Len() == 0 {
+		return "paper"
+	}
+	return b.String()
+}
+
+// authorSurname returns the surname of "Surname, Given" or "Given Surname".
+func authorSurname(name string) string {
+	if before, _, ok := strings.Cut(name, ","); ok {
+		return strings.TrimSpace(before)
+	}
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
+
+// uniqueCiteKey returns key, or key with the first free suffix a, b, ...
+// when it is taken, and marks the result used.
+func uniqueCiteKey(key string, used map[string]bool) string {
+	candidate := key
+	for i := 0; used[candidate]; i++ {
+		candidate = key + suffixLetters(i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// suffixLetters returns a, b, ..., z, aa, ab, ... for 0, 1, ...
+func suffixLetters(i int) string {
+	s := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		s = string(rune('a'+(i-1)%26)) + s
+	}
+	return s
+}
+
+func truncateCell(s string, maxChars int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if maxChars < 0 {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= maxChars {
+		return s
+	}
+	if maxChars <= 3 {
+		return string(runes[:maxChars])
+	}
+	return strings.TrimSpace(string(runes[:maxChars-3])) + "..."
+}
+
+// RenderMatrixMarkdown writes the matrix as a Markdown table whose cells
+// cite their paper as [Key], the draft citation syntax (R10.2).
+func RenderMatrixMarkdown(w io.Writer, m Matrix) error {
+	var b strings.Builder
+	b.WriteString("| Paper |")
+	for _, c := range m.Columns {
+		fmt.Fprintf(&b, " %s |", markdownCell(c))
+	}
+	b.WriteString("\n|---|")
+	b.WriteString(strings.Repeat("---|", len(m.Columns)))
+	b.WriteString("\n")
+	for _, r := range m.Rows {
+		fmt.Fprintf(&b, "| %s [%s] |", markdownCell(rowLabel(r)), r.CiteKey)
+		for _, c := range r.Cells {
+			if c.ItemID == "" {
+				b.WriteString(" — |")
+				continue
+			}
+			fmt.Fprintf(&b, " %s [%s] |", markdownCell(c.Content), r.CiteKey)
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// RenderMatrixLaTeX writes the matrix as a booktabs tabular whose cells
+// end in \<citeCommand>{key}; citeCommand defaults to "cite" (R10.2). The
+// snippet needs \usepackage{booktabs} and, for a command such as citep, a
+// citation package that defines it.
+func RenderMatrixLaTeX(w io.Writer, m Matrix, citeCommand string) error {
+	if citeCommand == "" {
+		citeCommand = "cite"
+	}
+	citeCommand = strings.TrimPrefix(citeCommand, `\`)
+	width := 0.8
+	if len(m.Columns) > 0 {
+		width /= float64(len(m.Columns))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\\begin{tabular}{@{}l%s@{}}\n", strings.Repeat(fmt.Sprintf("p{%.2f\\linewidth}", width), len(m.Columns)))
+	b.WriteString("\\toprule\nPaper")
+	for _, c := range m.Columns {
+		fmt.Fprintf(&b, " & %s", escapeLaTeX(c))
+	}
+	b.WriteString(" \\\\\n\\midrule\n")
+	for _, r := range m.Rows {
+		fmt.Fprintf(&b, "%s \\%s{%s}", escapeLaTeX(rowLabel(r)), citeCommand, r.CiteKey)
+		for _, c := range r.Cells {
+			if c.ItemID == "" {
+				b.WriteString(" & --")
+				continue
+			}
+			fmt.Fprintf(&b, " & %s \\%s{%s}", escapeLaTeX(c.Content), citeCommand, r.CiteKey)
+		}
+		b.WriteString(" \\\\\n")
+	}
+	b.WriteString("\\bottomrule\n\\end{tabular}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// rowLabel is the paper's title shortened for the first column, or its ID
+// when it has no title.
+func rowLabel(r MatrixRow) string {
+	if r.Title == "" {
+		return r.PaperID
+	}
+	return truncateCell(r.Title, 40)
+}
+
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// latexEscapes replaces the characters LaTeX treats specially in text.
+var latexEscapes = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+func escapeLaTeX(s string) string {
+	return latexEscapes.Replace(s)
+}
@@ -0,0 +1,100 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestMatrix(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	for _, id := range []string{"2301.00001", "2301.00002"} {
+		writeExtraction(t, tmpDir, id, sampleItems(id))
+		paper := samplePaper(id)
+		paper.Date = time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+		writePaperMeta(t, tmpDir, paper)
+	}
+	writeExtraction(t, tmpDir, "2301.00003", []types.KnowledgeItem{{
+		ID: "2301.00003-claim1", Type: types.ItemClaim, Content: "Costs 5% & scales as O(n_k)",
+		PaperID: "2301.00003", Confidence: 0.9, Tags: []string{"efficiency"},
+	}})
+	writePaperMeta(t, tmpDir, types.Paper{ID: "2301.00003", Title: "Sparse Attention", Authors: []string{"Ada Lovelace"}})
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := store.Matrix(context.Background(), MatrixOptions{
+		Columns:  []string{"attention", "type:result", "efficiency"},
+		CiteKeys: map[string]string{"2301.00002": "Smith2023"},
+		MaxChars: 30,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, r := range m.Rows {
+		keys = append(keys, r.CiteKey)
+	}
+	// The references key is kept; the other Smith paper of 2023 gets a
+	// suffix, and a paper without a date is keyed by surname alone.
+	if got := strings.Join(keys, ","); got != "Lovelace,Smith2023,Smith2023a" {
+		t.Fatalf("cite keys = %s", got)
+	}
+	smith := m.Rows[2]
+	if smith.PaperID != "2301.00001" {
+		t.Fatalf("row = %+v", smith)
+	}
+	// The attention column holds the highest-confidence of three items.
+	if c := smith.Cells[0]; c.ItemID != "2301.00001-method1" || c.Count != 3 || c.Content != "We define efficient attenti..." {
+		t.Errorf("attention cell = %+v", c)
+	}
+	if c := m.Rows[0].Cells[1]; c.ItemID != "" || c.Count != 0 {
+		t.Errorf("empty cell = %+v", c)
+	}
+
+	var tex strings.Builder
+	if err := RenderMatrixLaTeX(&tex, m, "citep"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`\begin{tabular}{@{}lp{0.27\linewidth}p{0.27\linewidth}p{0.27\linewidth}@{}}`,
+		"\\toprule\nPaper & attention & type:result & efficiency \\\\\n\\midrule\n",
+		`Sparse Attention \citep{Lovelace} & -- & -- & Costs 5\% \& scales as O(n\_k) \citep{Lovelace} \\`,
+		`& Our method achieves 89.2\% a... \citep{Smith2023a} &`,
+		"\\bottomrule\n\\end{tabular}\n",
+	} {
+		if !strings.Contains(tex.String(), want) {
+			t.Errorf("LaTeX missing %q:\n%s", want, tex.String())
+		}
+	}
+
+	var md strings.Builder
+	if err := RenderMatrixMarkdown(&md, m); err != nil {
+		t.Fatal(err)
+	}
+	if want := "| Sparse Attention [Lovelace] | — | — | Costs 5% & scales as O(n_k) [Lovelace] |"; !strings.Contains(md.String(), want) {
+		t.Errorf("Markdown missing %q:\n%s", want, md.String())
+	}
+
+	only, err := store.Matrix(context.Background(), MatrixOptions{Columns: []string{"softmax"}, PaperIDs: []string{"2301.00003"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(only.Rows) != 1 || only.Rows[0].Cells[0].ItemID != "" {
+		t.Errorf("restricted matrix = %+v", only)
+	}
+}
+
+func TestSuffixLetters(t *testing.T) {
+	for i, want := range map[int]string{0: "a", 25: "z", 26: "aa", 27: "ab"} {
+		if got := suffixLetters(i); got != want {
+			t.Errorf("suffixLetters(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
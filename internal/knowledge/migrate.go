@@ -0,0 +1,155 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Migration is a forward change to the knowledge base schema (R1.7).
+// Migrations are applied in version order, each in its own transaction,
+// and recorded in the schema_version table.
+type Migration struct {
+	Version int
+	Name    string
+	up      func(tx *sql.Tx) error
+}
+
+// migrations lists every schema change, oldest first. A new change is
+// appended with the next version; released migrations are never edited,
+// since databases record that they applied them.
+var migrations = []Migration{
+	// Databases created before versioning have no schema_version table
+	// and start at version 0; the baseline is idempotent, so it brings
+	// them up to date whatever build created them.
+	{Version: 1, Name: "baseline schema", up: createSchema},
+	{Version: 2, Name: "collections", up: createCollections},
+	{Version: 3, Name: "curated items", up: addCurated},
+	{Version: 4, Name: "item status", up: addItemStatus},
+	{Version: 5, Name: "snapshots", up: createSnapshots},
+}
+
+// SchemaVersion returns the schema version this build creates.
+func SchemaVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// SchemaState is a database's schema version and the migrations it lacks.
+type SchemaState struct {
+	// Exists is false when there is no database yet; it is then created
+	// at the latest version.
+	Exists  bool
+	Current int
+	Latest  int
+	Pending []Migration
+}
+
+// CheckSchema reports the schema version of the knowledge base database
+// under knowledgeDir and the migrations opening it would apply (R1.8),
+// without changing it.
+func CheckSchema(knowledgeDir string) (SchemaState, error) {
+	state := SchemaState{Latest: SchemaVersion()}
+	dbPath := filepath.Join(knowledgeDir, indexDir, dbFile)
+	if _, err := os.Stat(dbPath); errors.Is(err, os.ErrNotExist) {
+		state.Pending = migrations
+		return state, nil
+	} else if err != nil {
+		return state, fmt.Errorf("checking database: %w", err)
+	}
+	state.Exists = true
+
+	db, err := sql.Open(sqliteDriver, sqliteDSN(dbPath, true))
+	if err != nil {
+		return state, fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+	if state.Current, err = schemaVersion(context.Background(), db); err != nil {
+		return state, err
+	}
+	state.Pending = pendingMigrations(state.Current)
+	return state, nil
+}
+
+// migrate applies the migrations the database lacks and returns them. It
+// refuses a database from a newer build, whose schema it does not know.
+func (s *Store) migrate(ctx context.Context) ([]Migration, error) {
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TEXT NOT NULL
+	)`); err != nil {
+		return nil, fmt.Errorf("creating schema_version table: %w", err)
+	}
+	current, err := schemaVersion(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	if current > SchemaVersion() {
+		return nil, fmt.Errorf("database schema version %d is newer than this build supports (%d): upgrade research-engine", current, SchemaVersion())
+	}
+
+	pending := pendingMigrations(current)
+	for _, m := range pending {
+		if err := s.applyMigration(ctx, m); err != nil {
+			return nil, err
+		}
+	}
+	return pending, nil
+}
+
+func (s *Store) applyMigration(ctx context.Context, m Migration) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning migration %d: %w", m.Version, err)
+	}
+	defer tx.Rollback()
+	if err := m.up(tx); err != nil {
+		return fmt.Errorf("migration %d (%s): %w", m.Version, m.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schema_version (version, name, applied_at) VALUES (?, ?, ?)`,
+		m.Version, m.Name, time.Now().UTC().Format(time.RFC3339),
+	); err != nil {
+		return fmt.Errorf("recording migration %d: %w", m.Version, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing migration %d: %w", m.Version, err)
+	}
+	return nil
+}
+
+// schemaVersion returns the highest migration applied to db, 0 for a
+// database created before versioning.
+func schemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var exists int
+	if err := db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_version'`,
+	).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("reading schema version: %w", err)
+	}
+	if exists == 0 {
+		return 0, nil
+	}
+	var version int
+	if err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("reading schema version: %w", err)
+	}
+	return version, nil
+}
+
+// pendingMigrations returns the migrations after version current.
+func pendingMigrations(current int) []Migration {
+	var pending []Migration
+	for _, m := range migrations {
+		if m.Version > current {
+			pending = append(pending, m)
+		}
+	}
+	return pending
+}
@@ -0,0 +1,112 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestCheckSchemaNoDatabase(t *testing.T) {
+	state, err := CheckSchema(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Exists || state.Current != 0 || len(state.Pending) != len(migrations) {
+		t.Errorf("state = %+v, want no database with every migration pending", state)
+	}
+}
+
+func TestMigrateNewStore(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	state, err := CheckSchema(filepath.Join(tmpDir, "knowledge"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Exists || state.Current != SchemaVersion() || len(state.Pending) != 0 {
+		t.Errorf("state = %+v, want a current database", state)
+	}
+
+	// Opening it again applies nothing.
+	applied, err := store.migrate(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("reopening applied %d migrations", len(applied))
+	}
+}
+
+func TestMigrateUnversionedDatabase(t *testing.T) {
+	tmpDir := t.TempDir()
+	knowledgeDir := filepath.Join(tmpDir, "knowledge")
+	store, err := NewStore(types.KnowledgeBaseConfig{KnowledgeDir: knowledgeDir}, filepath.Join(tmpDir, "papers"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Reduce the database to the first schema: an items table without
+	// the columns added since, and no version.
+	for _, stmt := range []string{
+		`DROP TABLE schema_version`,
+		`DROP TABLE items_fts`,
+		`DROP TABLE items`,
+		`CREATE TABLE items (
+			rowid INTEGER PRIMARY KEY AUTOINCREMENT,
+			id TEXT NOT NULL UNIQUE,
+			type TEXT NOT NULL,
+			content TEXT NOT NULL,
+			paper_id TEXT NOT NULL REFERENCES papers(id),
+			section TEXT,
+			page INTEGER,
+			confidence REAL,
+			tags TEXT,
+			citations TEXT
+		)`,
+	} {
+		if _, err := store.db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	store.Close()
+
+	state, err := CheckSchema(knowledgeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Current != 0 || len(state.Pending) != len(migrations) {
+		t.Fatalf("state = %+v, want version 0 with every migration pending", state)
+	}
+
+	store, err = NewStore(types.KnowledgeBaseConfig{KnowledgeDir: knowledgeDir}, filepath.Join(tmpDir, "papers"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	var n int
+	if err := store.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('items') WHERE name = 'patent_claim'`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Error("migration did not add items.patent_claim")
+	}
+	if v, err := schemaVersion(context.Background(), store.db); err != nil || v != SchemaVersion() {
+		t.Errorf("schema version = %d, %v; want %d", v, err, SchemaVersion())
+	}
+}
+
+func TestMigrateRefusesNewerDatabase(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	if _, err := store.db.Exec(`INSERT INTO schema_version (version, name, applied_at) VALUES (?, 'future', '')`, SchemaVersion()+1); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	_, err := NewStore(types.KnowledgeBaseConfig{KnowledgeDir: filepath.Join(tmpDir, "knowledge")}, filepath.Join(tmpDir, "papers"))
+	if err == nil || !strings.Contains(err.Error(), "newer than this build supports") {
+		t.Errorf("err = %v, want a newer-schema error", err)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// paperTopTags is the number of tags listed for each paper in a listing.
+const paperTopTags = 5
+
+// PaperListing is one paper of the knowledge base with the counts of its
+// knowledge items (R15.1).
+type PaperListing struct {
+	ID      string   `json:"id"`
+	Title   string   `json:"title"`
+	Authors []string `json:"authors"`
+	Date    string   `json:"date,omitempty"`
+	Status  string   `json:"status,omitempty"`
+
+	// CanonicalID names the canonical version of a paper linked to other
+	// versions.
+	CanonicalID string `json:"canonical_id,omitempty"`
+
+	Items       int            `json:"items"`
+	ItemsByType map[string]int `json:"items_by_type,omitempty"`
+
+	// Tags counts the paper's items per tag, most first; a listing keeps
+	// the top few and the detailed view all of them.
+	Tags []FacetCount `json:"tags,omitempty"`
+
+	// Matches is the number of the paper's items matching the listing's
+	// full-text query.
+	Matches int `json:"matches,omitempty"`
+}
+
+// PaperListOptions selects and limits the papers Papers lists.
+type PaperListOptions struct {
+	// Query keeps papers with items matching the full-text query, in the
+	// syntax of retrieve, and ranks them by their matching items.
+	Query string
+
+	// MaxResults limits the listing. Zero lists every paper.
+	MaxResults int
+}
+
+// PaperDetail is the detailed view of one paper (R15.2): its metadata,
+// venue, institutions, linked versions, and knowledge items.
+type PaperDetail struct {
+	PaperListing
+	Abstract     string         `json:"abstract,omitempty"`
+	DOI          string         `json:"doi,omitempty"`
+	ArxivID      string         `json:"arxiv_id,omitempty"`
+	SourceURL    string         `json:"source_url,omitempty"`
+	PDFPath      string         `json:"pdf_path,omitempty"`
+	Source       string         `json:"source,omitempty"`
+	Venue        string         `json:"venue,omitempty"`
+	VenueRank    string         `json:"venue_rank,omitempty"`
+	Institutions []string       `json:"institutions,omitempty"`
+	Versions     []PaperVersion `json:"versions,omitempty"`
+	Sections     []FacetCount   `json:"sections,omitempty"`
+
+	// KnowledgeItems lists the paper's items by section and page.
+	KnowledgeItems []types.KnowledgeItem `json:"knowledge_items"`
+}
+
+// Papers lists the papers in the knowledge base with their item counts
+// and top tags (R15.1), newest first, or with a query, those with the most
+// matching items first.
+func (s *Store) Papers(ctx context.Context, opts PaperListOptions) ([]PaperListing, error) {
+	var (
+		qb   strings.Builder
+		args []any
+	)
+	qb.WriteString(`SELECT p.id, COALESCE(p.title, ''), COALESCE(p.authors, ''), COALESCE(p.date, ''),
+		COALESCE(p.status, ''), COALESCE(p.canonical_id, '')`)
+	if opts.Query != "" {
+		query, err := ParseQuery(opts.Query)
+		if err != nil {
+			return nil, err
+		}
+		qb.WriteString(`, m.n FROM papers p
+			JOIN (SELECT i.paper_id, COUNT(*) AS n FROM items_fts
+				JOIN items i ON i.rowid = items_fts.rowid
+				WHERE items_fts MATCH ? GROUP BY i.paper_id) m ON m.paper_id = p.id
+			ORDER BY m.n DESC, p.id`)
+		args = append(args, query)
+	} else {
+		qb.WriteString(`, 0 FROM papers p ORDER BY p.date IS NULL OR p.date = '', p.date DESC, p.id`)
+	}
+	if opts.MaxResults > 0 {
+		qb.WriteString(` LIMIT ?`)
+		args = append(args, opts.MaxResults)
+	}
+
+	rows, err := s.db.QueryContext(ctx, qb.String(), args...)
+	if err != nil {
+		if strings.Contains(err.Error(), "fts5:") {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidQuery, opts.Query, err)
+		}
+		return nil, fmt.Errorf("listing papers: %w", err)
+	}
+	var papers []PaperListing
+	for rows.Next() {
+		var (
+			p           PaperListing
+			authorsJSON string
+		)
+		if err := rows.Scan(&p.ID, &p.Title, &authorsJSON, &p.Date, &p.Status, &p.CanonicalID, &p.Matches); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning paper: %w", err)
+		}
+		if authorsJSON != "" {
+			json.Unmarshal([]byte(authorsJSON), &p.Authors)
+		}
+		papers = append(papers, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	byType, err := s.paperFacets(ctx, `SELECT paper_id, type, COUNT(*) FROM items GROUP BY 1, 2`)
+	if err != nil {
+		return nil, err
+	}
+	byTag, err := s.paperFacets(ctx, `SELECT i.paper_id, t.value, COUNT(*) FROM items i, json_each(i.tags) t GROUP BY 1, 2`)
+	if err != nil {
+		return nil, err
+	}
+	for i := range papers {
+		p := &papers[i]
+		p.ItemsByType = byType[p.ID]
+		for _, n := range p.ItemsByType {
+			p.Items += n
+		}
+		p.Tags = TopCounts(byTag[p.ID], paperTopTags)
+	}
+	return papers, nil
+}
+
+// paperFacets runs a paper/value/count query into counts per paper.
+func (s *Store) paperFacets(ctx context.Context, query string, args ...any) (map[string]map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("counting paper items: %w", err)
+	}
+	defer rows.Close()
+	facets := make(map[string]map[string]int)
+	for rows.Next() {
+		var (
+			paperID, value string
+			n              int
+		)
+		if err := rows.Scan(&paperID, &value, &n); err != nil {
+			return nil, fmt.Errorf("counting paper items: %w", err)
+		}
+		if facets[paperID] == nil {
+			facets[paperID] = make(map[string]int)
+		}
+		facets[paperID][value] = n
+	}
+	return facets, rows.Err()
+}
+
+// Paper returns the detailed view of one paper (R15.2).
+func (s *Store) Paper(ctx context.Context, paperID string) (PaperDetail, error) {
+	var (
+		d           PaperDetail
+		authorsJSON string
+	)
+	err := s.db.QueryRowContext(ctx,
+		`SELECT p.id, COALESCE(p.title, ''), COALESCE(p.authors, ''), COALESCE(p.date, ''),
+			COALESCE(p.status, ''), COALESCE(p.canonical_id, ''), COALESCE(p.abstract, ''),
+			COALESCE(p.doi, ''), COALESCE(p.arxiv_id, ''), COALESCE(p.source_url, ''),
+			COALESCE(p.pdf_path, ''), COALESCE(p.source, ''), COALESCE(v.name, ''),
+			COALESCE(v.rank, '') || CASE WHEN COALESCE(v.rank_source, '') != '' THEN ' (' || v.rank_source || ')' ELSE '' END
+		FROM papers p LEFT JOIN venues v ON v.id = p.venue_id
+		WHERE p.id = ?`, paperID,
+	).Scan(&d.ID, &d.Title, &authorsJSON, &d.Date, &d.Status, &d.CanonicalID, &d.Abstract,
+		&d.DOI, &d.ArxivID, &d.SourceURL, &d.PDFPath, &d.Source, &d.Venue, &d.VenueRank)
+	if err == sql.ErrNoRows {
+		return d, fmt.Errorf("paper %s not found", paperID)
+	}
+	if err != nil {
+		return d, fmt.Errorf("looking up paper: %w", err)
+	}
+	if authorsJSON != "" {
+		json.Unmarshal([]byte(authorsJSON), &d.Authors)
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT DISTINCT institution FROM author_affiliations WHERE paper_id = ? ORDER BY institution`, paperID)
+	if err != nil {
+		return d, fmt.Errorf("reading institutions: %w", err)
+	}
+	for rows.Next() {
+		var inst string
+		if err := rows.Scan(&inst); err != nil {
+			rows.Close()
+			return d, err
+		}
+		d.Institutions = append(d.Institutions, inst)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return d, err
+	}
+
+	if d.Versions, err = s.Versions(ctx, paperID); err != nil {
+		return d, err
+	}
+	// A paper that is not linked lists only itself.
+	if len(d.Versions) == 1 {
+		d.Versions = nil
+	}
+
+	byType, err := s.paperFacets(ctx, `SELECT paper_id, type, COUNT(*) FROM items WHERE paper_id = ? GROUP BY 1, 2`, paperID)
+	if err != nil {
+		return d, err
+	}
+	byTag, err := s.paperFacets(ctx,
+		`SELECT i.paper_id, t.value, COUNT(*) FROM items i, json_each(i.tags) t WHERE i.paper_id = ? GROUP BY 1, 2`, paperID)
+	if err != nil {
+		return d, err
+	}
+	bySection, err := s.paperFacets(ctx,
+		`SELECT paper_id, COALESCE(NULLIF(section, ''), '`+noSection+`'), COUNT(*) FROM items WHERE paper_id = ? GROUP BY 1, 2`, paperID)
+	if err != nil {
+		return d, err
+	}
+	d.ItemsByType = byType[paperID]
+	d.Tags = TopCounts(byTag[paperID], 0)
+	d.Sections = TopCounts(bySection[paperID], 0)
+	for _, n := range d.ItemsByType {
+		d.Items += n
+	}
+
+	if d.Items > 0 {
+		results, err := s.Retrieve(ctx, QueryOptions{PaperID: paperID, MaxResults: d.Items})
+		if err != nil {
+			return d, err
+		}
+		for _, r := range results {
+			d.KnowledgeItems = append(d.KnowledgeItems, r.KnowledgeItem)
+		}
+	}
+	return d, nil
+}
@@ -0,0 +1,117 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestPapersListsNewestFirstWithCounts(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ctx := context.Background()
+
+	old := samplePaper("old-paper")
+	old.Date = time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	writePaperMeta(t, tmpDir, old)
+	writeExtraction(t, tmpDir, "old-paper", sampleItems("old-paper"))
+	recent := samplePaper("recent-paper")
+	recent.Date = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	writePaperMeta(t, tmpDir, recent)
+	writeExtraction(t, tmpDir, "recent-paper", sampleItems("recent-paper")[:2])
+	writeExtraction(t, tmpDir, "undated-paper", sampleItems("undated-paper"))
+	writePaperMeta(t, tmpDir, samplePaper("undated-paper"))
+	var buf strings.Builder
+	if _, err := store.Ingest(ctx, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	papers, err := store.Papers(ctx, PaperListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, p := range papers {
+		ids = append(ids, p.ID)
+	}
+	if strings.Join(ids, ",") != "recent-paper,old-paper,undated-paper" {
+		t.Fatalf("order = %v, want newest first and undated last", ids)
+	}
+	p := papers[0]
+	if p.Items != 2 || p.ItemsByType["claim"] != 1 || p.ItemsByType["method"] != 1 {
+		t.Errorf("counts = %d %v, want one claim and one method", p.Items, p.ItemsByType)
+	}
+	if len(p.Tags) == 0 || p.Tags[0] != (FacetCount{Value: "attention", Items: 2}) {
+		t.Errorf("tags = %v, want attention first", p.Tags)
+	}
+	if len(p.Authors) != 2 || p.Title == "" {
+		t.Errorf("metadata = %+v", p)
+	}
+
+	limited, err := store.Papers(ctx, PaperListOptions{MaxResults: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limited) != 1 {
+		t.Errorf("limited listing has %d papers, want 1", len(limited))
+	}
+}
+
+func TestPapersQueryRanksByMatchingItems(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ctx := context.Background()
+	ingestHelper(t, store, tmpDir, "full-paper")
+	writeExtraction(t, tmpDir, "other-paper", []types.KnowledgeItem{{
+		ID: "other-paper-claim1", Type: types.ItemClaim, PaperID: "other-paper",
+		Content: "Convolutions capture local structure", Section: "Method", Page: 1, Confidence: 0.9,
+	}})
+	writePaperMeta(t, tmpDir, samplePaper("other-paper"))
+	var buf strings.Builder
+	if _, err := store.Ingest(ctx, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	papers, err := store.Papers(ctx, PaperListOptions{Query: "attention"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(papers) != 1 || papers[0].ID != "full-paper" || papers[0].Matches != 3 {
+		t.Fatalf("papers = %+v, want full-paper with 3 matches", papers)
+	}
+
+	if _, err := store.Papers(ctx, PaperListOptions{Query: `"unterminated`}); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("err = %v, want ErrInvalidQuery", err)
+	}
+}
+
+func TestPaperDetail(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ctx := context.Background()
+	ingestHelper(t, store, tmpDir, "detail-paper")
+
+	d, err := store.Paper(ctx, "detail-paper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Items != 4 || len(d.KnowledgeItems) != 4 {
+		t.Errorf("items = %d, listed %d, want 4", d.Items, len(d.KnowledgeItems))
+	}
+	if len(d.Sections) != 3 || d.Sections[0] != (FacetCount{Value: "Method", Items: 2}) {
+		t.Errorf("sections = %v", d.Sections)
+	}
+	if len(d.Tags) != 6 {
+		t.Errorf("tags = %v, want all 6", d.Tags)
+	}
+	if d.Versions != nil {
+		t.Errorf("versions = %v, want none for an unlinked paper", d.Versions)
+	}
+
+	if _, err := store.Paper(ctx, "missing-paper"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("err = %v, want not found", err)
+	}
+}
@@ -0,0 +1,421 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidQuery is returned for a full-text query whose syntax is
+// invalid (R2.8).
+var ErrInvalidQuery = errors.New("invalid query")
+
+// ftsColumns are the items_fts columns a query term can be restricted to
+// with a column filter (R2.5).
+var ftsColumns = []string{"content", "section", "tags", "resolved_content"}
+
+// ParseQuery checks a full-text query and rewrites it into FTS5 query
+// syntax that cannot fail to parse (R2.8). It supports quoted phrases,
+// prefix terms (atten*), the AND, OR, and NOT operators (upper case, with
+// AND implied between terms), parentheses, NEAR(a b, N) groups, and column
+// filters (section:methods). Bare words holding punctuation, such as
+// self-attention or O(n^2), are quoted as phrases, and punctuation alone
+// is dropped, since the tokenizer would discard it. Syntax errors are
+// reported as ErrInvalidQuery with the position of the offending text.
+func ParseQuery(query string) (string, error) {
+	p := &queryParser{query: query, runes: []rune(query)}
+	toks, err := p.lex()
+	if err != nil {
+		return "", err
+	}
+	if len(toks) == 0 {
+		return "", p.errorf(0, "no search terms")
+	}
+	p.toks = toks
+	if err := p.parseOr(); err != nil {
+		return "", err
+	}
+	if p.i < len(p.toks) {
+		t := p.toks[p.i]
+		if t.kind == tokRParen {
+			return "", p.errorf(t.pos, "unmatched )")
+		}
+		return "", p.errorf(t.pos, "unexpected %s", t.text)
+	}
+	var b strings.Builder
+	for i, t := range toks {
+		if i > 0 {
+			switch prev := toks[i-1].kind; {
+			case prev == tokLParen || prev == tokColumn || prev == tokNear:
+			case t.kind == tokRParen || t.kind == tokComma:
+			default:
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(t.text)
+	}
+	return b.String(), nil
+}
+
+type tokenKind int
+
+const (
+	tokTerm tokenKind = iota
+	tokColumn
+	tokAnd
+	tokOr
+	tokNot
+	tokNear
+	tokLParen
+	tokRParen
+	tokComma
+	tokNumber
+)
+
+// queryToken is a lexed query token; text is its FTS5 form and pos the
+// rune offset of its source in the query.
+type queryToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+type queryParser struct {
+	query string
+	runes []rune
+	toks  []queryToken
+	i     int
+}
+
+func (p *queryParser) errorf(pos int, format string, args ...any) error {
+	return fmt.Errorf("%w %q: %s at position %d", ErrInvalidQuery, p.query, fmt.Sprintf(format, args...), pos+1)
+}
+
+// lex splits the query into tokens, quoting terms FTS5 would not accept
+// as bare words.
+func (p *queryParser) lex() ([]queryToken, error) {
+	var toks []queryToken
+	r := p.runes
+	// Commas separate a NEAR group's distance; elsewhere they are
+	// punctuation.
+	depth, nearDepth := 0, -1
+	for i := 0; i < len(r); {
+		c := r[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			depth++
+			if len(toks) > 0 && toks[len(toks)-1].kind == tokNear {
+				nearDepth = depth
+			}
+			toks = append(toks, queryToken{tokLParen, "(", i})
+			i++
+		case c == ')':
+			if depth == nearDepth {
+				nearDepth = -1
+			}
+			depth--
+			toks = append(toks, queryToken{tokRParen, ")", i})
+			i++
+		case c == ',':
+			if depth == nearDepth {
+				toks = append(toks, queryToken{tokComma, ",", i})
+			}
+			i++
+		case c == '"':
+			start := i
+			var b strings.Builder
+			for i++; ; i++ {
+				if i >= len(r) {
+					return nil, p.errorf(start, "unterminated quote")
+				}
+				if r[i] == '"' {
+					if i+1 < len(r) && r[i+1] == '"' {
+						b.WriteRune('"')
+						i++
+						continue
+					}
+					break
+				}
+				b.WriteRune(r[i])
+			}
+			i++
+			prefix := i < len(r) && r[i] == '*'
+			if prefix {
+				i++
+			}
+			if text := b.String(); hasSearchable(text) {
+				toks = append(toks, queryToken{tokTerm, quotePhrase(text, prefix), start})
+			}
+		default:
+			start := i
+			local := 0
+			for ; i < len(r); i++ {
+				c := r[i]
+				if unicode.IsSpace(c) || c == '"' || c == ',' {
+					break
+				}
+				if c == '(' {
+					if r[i-1] == ':' || string(r[start:i]) == "NEAR" {
+						break
+					}
+					local++
+				} else if c == ')' {
+					if local == 0 {
+						break
+					}
+					local--
+				}
+			}
+			word := string(r[start:i])
+			if word == "NEAR" && i < len(r) && r[i] == '(' {
+				toks = append(toks, queryToken{tokNear, "NEAR", start})
+				continue
+			}
+			switch word {
+			case "AND":
+				toks = append(toks, queryToken{tokAnd, word, start})
+				continue
+			case "OR":
+				toks = append(toks, queryToken{tokOr, word, start})
+				continue
+			case "NOT":
+				toks = append(toks, queryToken{tokNot, word, start})
+				continue
+			}
+			if n, err := strconv.Atoi(word); err == nil && n >= 0 && len(toks) > 0 && toks[len(toks)-1].kind == tokComma {
+				toks = append(toks, queryToken{tokNumber, word, start})
+				continue
+			}
+			if col, rest, ok := strings.Cut(word, ":"); ok && isIdentifier(col) {
+				col = strings.ToLower(col)
+				if !slices.Contains(ftsColumns, col) {
+					return nil, p.errorf(start, "unknown column %q (columns are %s)", col, strings.Join(ftsColumns, ", "))
+				}
+				toks = append(toks, queryToken{tokColumn, col + ":", start})
+				// The filtered term follows the colon, here or after a
+				// space.
+				if rest == "" {
+					continue
+				}
+				start += len([]rune(col)) + 1
+				word = rest
+			}
+			prefix := strings.HasSuffix(word, "*")
+			word = strings.TrimSuffix(word, "*")
+			if !hasSearchable(word) {
+				continue
+			}
+			if isBareword(word) {
+				if prefix {
+					word += "*"
+				}
+				toks = append(toks, queryToken{tokTerm, word, start})
+			} else {
+				toks = append(toks, queryToken{tokTerm, quotePhrase(word, prefix), start})
+			}
+		}
+	}
+	return toks, nil
+}
+
+func (p *queryParser) peek() (queryToken, bool) {
+	if p.i < len(p.toks) {
+		return p.toks[p.i], true
+	}
+	return queryToken{}, false
+}
+
+// end returns the position just past the query for errors at its end.
+func (p *queryParser) end() int { return len(p.runes) }
+
+func (p *queryParser) parseOr() error {
+	if err := p.parseAnd(); err != nil {
+		return err
+	}
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind != tokOr {
+			return nil
+		}
+		p.i++
+		if err := p.operand(t); err != nil {
+			return err
+		}
+		if err := p.parseAnd(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *queryParser) parseAnd() error {
+	if err := p.parseNot(); err != nil {
+		return err
+	}
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind == tokOr || t.kind == tokRParen {
+			return nil
+		}
+		if t.kind == tokAnd {
+			p.i++
+			if err := p.operand(t); err != nil {
+				return err
+			}
+		}
+		if err := p.parseNot(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *queryParser) parseNot() error {
+	if err := p.parsePrimary(); err != nil {
+		return err
+	}
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind != tokNot {
+			return nil
+		}
+		p.i++
+		if err := p.operand(t); err != nil {
+			return err
+		}
+		if err := p.parsePrimary(); err != nil {
+			return err
+		}
+	}
+}
+
+// operand checks that the operator op is followed by a term.
+func (p *queryParser) operand(op queryToken) error {
+	t, ok := p.peek()
+	if !ok || t.kind == tokRParen || t.kind == tokAnd || t.kind == tokOr || t.kind == tokNot || t.kind == tokComma {
+		return p.errorf(op.pos, "%s needs a term after it", op.text)
+	}
+	return nil
+}
+
+func (p *queryParser) parsePrimary() error {
+	t, ok := p.peek()
+	if !ok {
+		return p.errorf(p.end(), "missing term")
+	}
+	switch t.kind {
+	case tokTerm:
+		p.i++
+		return nil
+	case tokColumn:
+		p.i++
+		next, ok := p.peek()
+		if !ok || next.kind != tokTerm && next.kind != tokLParen && next.kind != tokNear {
+			return p.errorf(t.pos, "column filter %s needs a term after it", t.text)
+		}
+		return p.parsePrimary()
+	case tokLParen:
+		p.i++
+		if next, ok := p.peek(); ok && next.kind == tokRParen {
+			return p.errorf(t.pos, "empty parentheses")
+		}
+		if err := p.parseOr(); err != nil {
+			return err
+		}
+		if next, ok := p.peek(); !ok || next.kind != tokRParen {
+			return p.errorf(t.pos, "unmatched (")
+		}
+		p.i++
+		return nil
+	case tokNear:
+		return p.parseNear()
+	case tokNot:
+		return p.errorf(t.pos, "NOT needs a term before it: write \"a NOT b\"")
+	case tokAnd, tokOr:
+		return p.errorf(t.pos, "%s needs a term before it", t.text)
+	case tokRParen:
+		return p.errorf(t.pos, "unmatched )")
+	default:
+		return p.errorf(t.pos, "unexpected %s", t.text)
+	}
+}
+
+// parseNear checks a NEAR(phrase phrase ..., N) group, whose phrases may
+// not hold operators.
+func (p *queryParser) parseNear() error {
+	near := p.toks[p.i]
+	p.i += 2 // NEAR and (
+	terms := 0
+	for {
+		t, ok := p.peek()
+		if !ok {
+			return p.errorf(near.pos, "unmatched ( in NEAR")
+		}
+		p.i++
+		switch t.kind {
+		case tokTerm:
+			terms++
+			continue
+		case tokComma:
+			n, ok := p.peek()
+			if !ok || n.kind != tokNumber {
+				return p.errorf(t.pos, "NEAR distance must be a number")
+			}
+			p.i++
+			if c, ok := p.peek(); !ok || c.kind != tokRParen {
+				return p.errorf(near.pos, "unmatched ( in NEAR")
+			}
+			p.i++
+		case tokRParen:
+		default:
+			return p.errorf(t.pos, "NEAR takes only terms and phrases, not %s", t.text)
+		}
+		if terms == 0 {
+			return p.errorf(near.pos, "NEAR needs at least one term")
+		}
+		return nil
+	}
+}
+
+// hasSearchable reports whether text holds a letter or digit the
+// tokenizer would index.
+func hasSearchable(text string) bool {
+	return strings.IndexFunc(text, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0
+}
+
+// isBareword reports whether FTS5 accepts word unquoted.
+func isBareword(word string) bool {
+	for _, r := range word {
+		if r < 0x80 && !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// quotePhrase returns text as an FTS5 string, with a trailing * for a
+// prefix phrase.
+func quotePhrase(text string, prefix bool) string {
+	q := `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+	if prefix {
+		q += "*"
+	}
+	return q
+}
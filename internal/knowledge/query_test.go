@@ -0,0 +1,118 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"attention", "attention"},
+		{"efficient attention", "efficient attention"},
+		{`"linear approximation" softmax`, `"linear approximation" softmax`},
+		{"attention AND softmax", "attention AND softmax"},
+		{"attention OR recurrence", "attention OR recurrence"},
+		{"attention NOT softmax", "attention NOT softmax"},
+		{"(attention OR recurrence) NOT softmax", "(attention OR recurrence) NOT softmax"},
+		{"NEAR(attention softmax, 5)", "NEAR(attention softmax, 5)"},
+		{`NEAR("linear approximation" softmax)`, `NEAR("linear approximation" softmax)`},
+		{"atten*", "atten*"},
+		{`"self att"*`, `"self att"*`},
+		{"section:methods attention", "section:methods attention"},
+		{`Section:"related work"`, `section:"related work"`},
+		{"tags:(attention OR softmax)", "tags:(attention OR softmax)"},
+		// Words FTS5 would reject are quoted, and bare punctuation dropped.
+		{"self-attention", `"self-attention"`},
+		{"O(n^2) complexity", `"O(n^2)" complexity`},
+		{"attention, softmax - cost", "attention softmax cost"},
+		{"10:30 run", `"10:30" run`},
+		{`say "hello ""world"""`, `say "hello ""world"""`},
+		// Lower-case operators are ordinary words.
+		{"not and or near", "not and or near"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseQuery(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "no search terms"},
+		{"- ,", "no search terms"},
+		{`"linear approximation`, "unterminated quote at position 1"},
+		{"NOT softmax", "NOT needs a term before it"},
+		{"attention AND", "AND needs a term after it"},
+		{"OR attention", "OR needs a term before it"},
+		{"attention OR OR softmax", "OR needs a term after it"},
+		{"(attention OR softmax", "unmatched ( at position 1"},
+		{"attention) softmax", "unmatched ) at position 10"},
+		{"()", "empty parentheses"},
+		{"author:vaswani", `unknown column "author"`},
+		{"section:", "column filter section: needs a term"},
+		{"NEAR(attention, x)", "NEAR distance must be a number"},
+		{"NEAR(a OR b)", "NEAR takes only terms and phrases"},
+		{"NEAR(attention", "unmatched ( in NEAR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+			if !errors.Is(err, ErrInvalidQuery) {
+				t.Fatalf("err = %v, want ErrInvalidQuery", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetrieveQuerySyntax(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestHelper(t, store, tmpDir, "syntax-paper")
+	ctx := context.Background()
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{`"softmax attention"`, 1},
+		{"attention NOT softmax", 1},
+		{"efficient AND softmax", 1},
+		{"NEAR(attention averages, 3)", 1},
+		{"NEAR(efficient averages, 1)", 0},
+		{"approx*", 1},
+		{"O(n^2) computation", 1},
+	}
+	for _, tt := range tests {
+		results, err := store.Retrieve(ctx, QueryOptions{Query: tt.query})
+		if err != nil {
+			t.Errorf("%s: %v", tt.query, err)
+			continue
+		}
+		if len(results) != tt.want {
+			t.Errorf("%s: got %d results, want %d", tt.query, len(results), tt.want)
+		}
+	}
+
+	if _, err := store.Retrieve(ctx, QueryOptions{Query: "attention AND"}); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("err = %v, want ErrInvalidQuery", err)
+	}
+}
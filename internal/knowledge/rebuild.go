@@ -0,0 +1,162 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// rebuildSuffix names the database a rebuild fills before it replaces
+// the current one.
+const rebuildSuffix = ".rebuild"
+
+// RebuildSummary holds the results of rebuilding the knowledge base.
+type RebuildSummary struct {
+	IngestSummary
+	Papers int
+	Items  int
+	// Collections and Snapshots are the numbers of collections and
+	// snapshots carried over from the replaced database.
+	Collections int
+	Snapshots   int
+	// Previous is the path the replaced database was kept at, or "" when
+	// there was none.
+	Previous string
+}
+
+// Rebuild creates a new database from the extraction files in
+// knowledge/extracted/ and the paper metadata in papers/metadata/, then
+// replaces the current database with it (R1.11). The current database is
+// only read for its collections, snapshots, and ingest history, which the
+// extraction files do not hold (R16.1, R18.1), and a damaged one is
+// rebuilt without them; it is kept as research.db.bak. Ingest output goes
+// to w, and progress, when not nil, is called after each paper. Item
+// embeddings are not rebuilt; EmbedItems recomputes them. No store may
+// have the database open.
+func Rebuild(ctx context.Context, cfg types.KnowledgeBaseConfig, papersDir string, w io.Writer, progress func(done, total int)) (RebuildSummary, error) {
+	dbDir := filepath.Join(cfg.KnowledgeDir, indexDir)
+	if err := os.MkdirAll(dbDir, 0o755); err != nil {
+		return RebuildSummary{}, fmt.Errorf("creating index directory: %w", err)
+	}
+	dbPath := filepath.Join(dbDir, dbFile)
+	newPath := dbPath + rebuildSuffix
+	removeDatabase(newPath)
+
+	store, err := openStore(cfg, papersDir, newPath)
+	if err != nil {
+		removeDatabase(newPath)
+		return RebuildSummary{}, err
+	}
+	store.progress = progress
+	// The papers are stored again, not changed; the history carried over
+	// from the current database stands.
+	store.noHistory = true
+
+	var summary RebuildSummary
+	summary.IngestSummary, err = store.Ingest(ctx, w)
+	if err == nil {
+		err = store.db.QueryRowContext(ctx,
+			`SELECT (SELECT COUNT(*) FROM papers), (SELECT COUNT(*) FROM items)`,
+		).Scan(&summary.Papers, &summary.Items)
+	}
+	if _, serr := os.Stat(dbPath); err == nil && serr == nil {
+		var cerr error
+		if summary.Collections, cerr = store.copyCollections(ctx, dbPath); cerr != nil {
+			fmt.Fprintf(w, "warning: collections not carried over from the current database: %v\n", cerr)
+		}
+		if summary.Snapshots, cerr = store.copySnapshots(ctx, dbPath); cerr != nil {
+			fmt.Fprintf(w, "warning: snapshots and ingest history not carried over from the current database: %v\n", cerr)
+		}
+	}
+	// Closing checkpoints the write-ahead log into the database file.
+	if cerr := store.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		removeDatabase(newPath)
+		return summary, fmt.Errorf("rebuilding knowledge base: %w", err)
+	}
+
+	if summary.Previous, err = replaceDatabase(dbPath, newPath); err != nil {
+		return summary, err
+	}
+	return summary, nil
+}
+
+// removeDatabase deletes the database at path with its write-ahead log
+// and shared-memory files.
+func removeDatabase(path string) {
+	for _, ext := range []string{"", "-wal", "-shm"} {
+		os.Remove(path + ext)
+	}
+}
+
+// tableCopy copies the rows of a table of the database a rebuild
+// replaces into the rebuilt one.
+type tableCopy struct {
+	table, query, insert string
+}
+
+// copyTables runs the copies from the database at path in one
+// transaction and returns the number of rows each copied, or nil when the
+// database predates one of the tables.
+func (s *Store) copyTables(ctx context.Context, path string, copies []tableCopy) ([]int, error) {
+	src, err := sql.Open(sqliteDriver, sqliteDSN(path, true))
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+	for _, c := range copies {
+		var n int
+		if err := src.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, c.table,
+		).Scan(&n); err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return nil, nil
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	copied := make([]int, len(copies))
+	for i, c := range copies {
+		rows, err := src.QueryContext(ctx, c.query)
+		if err != nil {
+			return nil, err
+		}
+		cols, _ := rows.Columns()
+		for rows.Next() {
+			vals := make([]any, len(cols))
+			ptrs := make([]any, len(cols))
+			for j := range vals {
+				ptrs[j] = &vals[j]
+			}
+			if err := rows.Scan(ptrs...); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			if _, err := tx.ExecContext(ctx, c.insert, vals...); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			copied[i]++
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return copied, tx.Commit()
+}
@@ -0,0 +1,62 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestRebuildReplacesDamagedDatabase(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestHelper(t, store, tmpDir, "first-paper")
+	ingestHelper(t, store, tmpDir, "second-paper")
+	store.Close()
+
+	// Damage the database beyond opening.
+	dbPath := filepath.Join(tmpDir, "knowledge", indexDir, dbFile)
+	removeDatabase(dbPath)
+	if err := os.WriteFile(dbPath, []byte(strings.Repeat("garbage ", 1024)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := types.KnowledgeBaseConfig{KnowledgeDir: filepath.Join(tmpDir, "knowledge")}
+	var calls [][2]int
+	var buf strings.Builder
+	summary, err := Rebuild(context.Background(), cfg, filepath.Join(tmpDir, "papers"), &buf, func(done, total int) {
+		calls = append(calls, [2]int{done, total})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Indexed != 2 || summary.Papers != 2 || summary.Items != 8 {
+		t.Errorf("summary = %+v, want 2 papers indexed with 8 items", summary)
+	}
+	if summary.Previous != dbPath+previousSuffix {
+		t.Errorf("previous = %q", summary.Previous)
+	}
+	if len(calls) != 3 || calls[2] != [2]int{2, 2} {
+		t.Errorf("progress calls = %v, want 0/2, 1/2, 2/2", calls)
+	}
+	if _, err := os.Stat(dbPath + rebuildSuffix); !os.IsNotExist(err) {
+		t.Error("rebuild left its working database behind")
+	}
+
+	store, err = NewStore(cfg, filepath.Join(tmpDir, "papers"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	report, err := store.Verify(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Problems() != 0 {
+		t.Errorf("rebuilt database fails verification: %+v", report)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// References returns a references.yaml entry for each paper the listing
+// options select, for seeding a paper project (prd007 R7.2), typed from
+// its source and venue. Papers linked
+// to a canonical version are cited through it, once. Citation keys are
+// AuthorYear keys made unique in ranking order, so the best-matching paper
+// keeps the plain key; entries are sorted by key.
+func (s *Store) References(ctx context.Context, opts PaperListOptions) ([]types.ReferenceEntry, error) {
+	papers, err := s.Papers(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []types.ReferenceEntry
+	seen := make(map[string]bool)
+	used := make(map[string]bool)
+	for _, p := range papers {
+		id := p.ID
+		if p.CanonicalID != "" {
+			id = p.CanonicalID
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		title, authors, date, err := s.paperCiteInfo(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		venue, refType, doi, err := s.paperBibInfo(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		ref := types.ReferenceEntry{
+			CitationKey: uniqueCiteKey(citeKey(id, authors, date), used),
+			PaperID:     id,
+			Title:       title,
+			Authors:     make([]string, 0, len(authors)),
+			Venue:       venue,
+			Type:        refType,
+			DOI:         doi,
+		}
+		for _, a := range authors {
+			ref.Authors = append(ref.Authors, authorSurname(a))
+		}
+		if len(date) >= 4 {
+			ref.Year, _ = strconv.Atoi(date[:4])
+		}
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].CitationKey < refs[j].CitationKey })
+	return refs, nil
+}
+
+// CiteKeys returns a citation key for each of paperIDs: its key in known,
+// such as those of a paper project's references.yaml, or else an AuthorYear
+// key from its metadata, made unique against every other key. Papers are
+// keyed in ID order, so the same papers always get the same keys.
+func (s *Store) CiteKeys(ctx context.Context, paperIDs []string, known map[string]string) (map[string]string, error) {
+	ids := append([]string(nil), paperIDs...)
+	sort.Strings(ids)
+	used := make(map[string]bool)
+	for _, key := range known {
+		used[key] = true
+	}
+	keys := make(map[string]string, len(ids))
+	for _, id := range ids {
+		if _, done := keys[id]; done {
+			continue
+		}
+		if key := known[id]; key != "" {
+			keys[id] = key
+			continue
+		}
+		_, authors, date, err := s.paperCiteInfo(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		keys[id] = uniqueCiteKey(citeKey(id, authors, date), used)
+	}
+	return keys, nil
+}
+
+// paperBibInfo returns a paper's venue name, empty when unknown, its
+// reference type, and its DOI. Patents are patents; papers at a conference
+// or workshop are inproceedings, in a journal or an untyped venue articles,
+// and anything else, such as a preprint, misc (prd007 R5.5).
+func (s *Store) paperBibInfo(ctx context.Context, paperID string) (string, types.ReferenceType, string, error) {
+	var venue, venueType, source, doi string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COALESCE(v.name, ''), COALESCE(v.type, ''), COALESCE(p.source, ''), COALESCE(p.doi, '')
+		FROM papers p LEFT JOIN venues v ON v.id = p.venue_id
+		WHERE p.id = ?`, paperID,
+	).Scan(&venue, &venueType, &source, &doi)
+	if err != nil && err != sql.ErrNoRows {
+		return "", "", "", fmt.Errorf("reading venue of %s: %w", paperID, err)
+	}
+	refType := types.RefMisc
+	switch {
+	case source == "patent" || source == "patentsview":
+		refType = types.RefPatent
+	case venueType == string(types.VenueConference) || venueType == string(types.VenueWorkshop):
+		refType = types.RefInProceedings
+	case venueType == string(types.VenueJournal), venue != "" && venueType == "":
+		refType = types.RefArticle
+	}
+	return venue, refType, doi, nil
+}
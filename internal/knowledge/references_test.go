@@ -0,0 +1,81 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestReferences(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	for _, id := range []string{"2301.00001", "2301.00002"} {
+		writeExtraction(t, tmpDir, id, sampleItems(id))
+		paper := samplePaper(id)
+		paper.Date = time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+		writePaperMeta(t, tmpDir, paper)
+	}
+	writeExtraction(t, tmpDir, "2301.00003", []types.KnowledgeItem{{
+		ID: "2301.00003-claim1", Type: types.ItemClaim, Content: "Analytical engines compute Bernoulli numbers",
+		PaperID: "2301.00003", Confidence: 0.9,
+	}})
+	writePaperMeta(t, tmpDir, types.Paper{ID: "2301.00003", Title: "Notes", Authors: []string{"Ada Lovelace"}})
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	refs, err := store.References(context.Background(), PaperListOptions{Query: "attention"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refs) != 2 {
+		t.Fatalf("got %d references, want the 2 attention papers: %+v", len(refs), refs)
+	}
+	keys := refs[0].CitationKey + "," + refs[1].CitationKey
+	if keys != "Smith2023,Smith2023a" {
+		t.Errorf("cite keys = %s", keys)
+	}
+	r := refs[0]
+	// Without a venue the paper is cited as misc.
+	if r.Year != 2023 || strings.Join(r.Authors, ",") != "Smith,Doe" || r.Title != "Efficient Attention Mechanisms for Transformers" || r.Type != types.RefMisc {
+		t.Errorf("reference = %+v", r)
+	}
+
+	refs, err = store.References(context.Background(), PaperListOptions{Query: "attention", MaxResults: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refs) != 1 || refs[0].CitationKey != "Smith2023" {
+		t.Errorf("limited references = %+v", refs)
+	}
+}
+
+func TestCiteKeys(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	for _, id := range []string{"2301.00001", "2301.00002"} {
+		paper := samplePaper(id)
+		paper.Date = time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+		writeExtraction(t, tmpDir, id, sampleItems(id))
+		writePaperMeta(t, tmpDir, paper)
+	}
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	// A known key is kept and reserved: the other Smith paper of 2023
+	// cannot take it.
+	keys, err := store.CiteKeys(context.Background(), []string{"2301.00002", "2301.00001", "2301.00002"},
+		map[string]string{"2301.00002": "Smith2023"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys["2301.00002"] != "Smith2023" || keys["2301.00001"] != "Smith2023a" {
+		t.Errorf("keys = %v", keys)
+	}
+}
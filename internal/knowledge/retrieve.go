@@ -7,16 +7,19 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/pdiddy/research-engine/pkg/types"
 )
 
 // QueryOptions holds parameters for knowledge base queries (R2, R3).
 type QueryOptions struct {
-	// Query is the FTS5 full-text search string (R2.1).
+	// Query is the full-text search string (R2.1), with phrase, boolean,
+	// NEAR, and column syntax checked by ParseQuery (R2.8).
 	Query string
 
 	// Type filters by KnowledgeItemType (R3.1).
@@ -28,26 +31,159 @@ type QueryOptions struct {
 	// PaperID filters by paper (R3.3).
 	PaperID string
 
+	// Institution keeps items from papers with an author affiliated with
+	// a matching institution: a case-insensitive substring of its name,
+	// or its ROR ID (R7.4).
+	Institution string
+
+	// Metric keeps result items whose metric name contains it, case
+	// insensitively, and ranks them by value, best first (R3.7).
+	Metric string
+
+	// Dataset keeps result items whose metric was measured on a dataset
+	// whose name contains it, case insensitively (R3.7).
+	Dataset string
+
+	// LowerIsBetter ranks Metric values ascending. Metrics such as
+	// perplexity and error rate rank ascending without it.
+	LowerIsBetter bool
+
+	// Claim keeps the patent claim item with this claim number, usually
+	// with PaperID naming the patent (R3.8).
+	Claim int
+
+	// Collection keeps items in the named collection, added one by one
+	// or with their paper (R16.2).
+	Collection string
+
+	// Status keeps items with this review status (R17.2).
+	Status types.ItemStatus
+
+	// MinConfidence keeps items extracted with at least this confidence
+	// (R3.9).
+	MinConfidence float64
+
+	// Pages keeps items from pages in the range (R3.9).
+	Pages PageRange
+
+	// DateFrom and DateTo keep items from papers dated within the range,
+	// both days included; a zero bound is open. Papers without a date are
+	// left out (R3.9).
+	DateFrom time.Time
+	DateTo   time.Time
+
+	// CollapseDuplicates returns one result for each cluster of
+	// near-duplicate items across papers, its best-ranked member, listing
+	// the others and all their papers (R14.2).
+	CollapseDuplicates bool
+
 	// MaxResults limits result count. Zero uses store default (R2.3).
 	MaxResults int
+
+	// Order sets the order of exported entries (R6.5). Retrieve ignores
+	// it and always ranks full-text results by relevance.
+	Order ExportOrder
 }
 
 // IsEmpty reports whether the query has no search terms or filters.
 func (q QueryOptions) IsEmpty() bool {
-	return q.Query == "" && q.Type == "" && len(q.Tags) == 0 && q.PaperID == ""
+	return q.Query == "" && q.Type == "" && len(q.Tags) == 0 && q.PaperID == "" && q.Institution == "" && q.Collection == "" && q.Status == "" &&
+		q.Metric == "" && q.Dataset == "" && q.Claim == 0 && q.MinConfidence == 0 && q.Pages == (PageRange{}) &&
+		q.DateFrom.IsZero() && q.DateTo.IsZero()
+}
+
+// PageRange is an inclusive range of page numbers; a zero bound is open.
+type PageRange struct {
+	First int
+	Last  int
+}
+
+// ParsePageRange parses a page or range of pages: "5", "3-7", "3-" for
+// page 3 onward, or "-7" for up to page 7.
+func ParsePageRange(s string) (PageRange, error) {
+	first, last, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	var r PageRange
+	var err error
+	if first != "" {
+		if r.First, err = strconv.Atoi(first); err != nil || r.First < 1 {
+			return PageRange{}, fmt.Errorf("invalid page range %q: use N, N-M, N-, or -M", s)
+		}
+	}
+	if !isRange {
+		r.Last = r.First
+	} else if last != "" {
+		if r.Last, err = strconv.Atoi(last); err != nil || r.Last < 1 {
+			return PageRange{}, fmt.Errorf("invalid page range %q: use N, N-M, N-, or -M", s)
+		}
+	}
+	if r == (PageRange{}) || r.Last != 0 && r.First > r.Last {
+		return PageRange{}, fmt.Errorf("invalid page range %q: use N, N-M, N-, or -M", s)
+	}
+	return r, nil
 }
 
 // QueryResult is a KnowledgeItem with associated Paper metadata (R2.4).
+// When the item's paper is linked to other versions, the paper fields
+// describe the canonical version and CanonicalID names it.
 type QueryResult struct {
 	types.KnowledgeItem
 	PaperTitle   string   `json:"paper_title" yaml:"paper_title"`
 	PaperAuthors []string `json:"paper_authors" yaml:"paper_authors"`
+	PaperDOI     string   `json:"paper_doi,omitempty" yaml:"paper_doi,omitempty"`
+	CanonicalID  string   `json:"canonical_id,omitempty" yaml:"canonical_id,omitempty"`
+
+	// Score is the relevance of a full-text result, its negated BM25
+	// score (R2.6); of a semantic result, its cosine similarity to the
+	// question; or of a hybrid result, its fused rank score (R12). Higher
+	// is more relevant.
+	Score float64 `json:"score,omitempty" yaml:"score,omitempty"`
+
+	// Snippet is the passage of a full-text result around its matching
+	// terms, and Highlight its content, with the matching terms marked
+	// **like this** (R2.7).
+	Snippet   string `json:"snippet,omitempty" yaml:"snippet,omitempty"`
+	Highlight string `json:"highlight,omitempty" yaml:"highlight,omitempty"`
+
+	// Duplicates are the IDs of the other items in a collapsed result's
+	// near-duplicate cluster, and SupportingPapers the papers of all its
+	// items (R14.2).
+	Duplicates       []string `json:"duplicates,omitempty" yaml:"duplicates,omitempty"`
+	SupportingPapers []string `json:"supporting_papers,omitempty" yaml:"supporting_papers,omitempty"`
+
+	// cluster is the canonical item of the item's near-duplicate
+	// cluster, if it has one.
+	cluster string
+}
+
+// Match marks and snippet length of full-text results (R2.7).
+const (
+	matchOpen       = "**"
+	matchClose      = "**"
+	snippetEllipsis = "..."
+	snippetTokens   = 16
+)
+
+// lowerIsBetterMetrics are words in metric names where a smaller value is
+// the better result.
+var lowerIsBetterMetrics = []string{"error", "loss", "perplexity", "latency", "wer", "cer", "fid", "mae", "mse", "rmse", "time"}
+
+// LowerIsBetter reports whether a smaller value of the named metric is a
+// better result, such as for perplexity, error rates, and latency.
+func LowerIsBetter(metric string) bool {
+	for _, w := range strings.FieldsFunc(strings.ToLower(metric), func(r rune) bool { return !unicode.IsLetter(r) }) {
+		if slices.Contains(lowerIsBetterMetrics, w) {
+			return true
+		}
+	}
+	return false
 }
 
 // Retrieve queries the knowledge base with optional full-text search
-// and structured filters (R2, R3). Results are ranked by relevance for
-// full-text queries or sorted by paper_id, section, page for
-// structured-only queries (R3.6).
+// and structured filters (R2, R3). Results are ranked by BM25 relevance
+// for full-text queries (R2.6), with a snippet and highlight of the match
+// (R2.7), by metric value for metric queries (R3.7), or
+// sorted by paper_id, section, page for structured-only queries (R3.6).
+// With CollapseDuplicates, near-duplicate items are collapsed (R14.2).
 func (s *Store) Retrieve(ctx context.Context, opts QueryOptions) ([]QueryResult, error) {
 	maxResults := opts.MaxResults
 	if maxResults <= 0 {
@@ -61,22 +197,37 @@ func (s *Store) Retrieve(ctx context.Context, opts QueryOptions) ([]QueryResult,
 	)
 
 	if useFTS {
+		query, err := ParseQuery(opts.Query)
+		if err != nil {
+			return nil, err
+		}
 		qb.WriteString(
 			`SELECT i.id, i.type, i.content, i.paper_id, i.section, i.page,
-				i.confidence, i.tags, i.citations,
-				p.title, p.authors, items_fts.rank
+				i.confidence, i.tags, i.citations, i.resolved_content, i.metric, i.patent_claim, i.curated,
+				i.status, COALESCE(i.status_note, ''),
+				COALESCE(c.title, p.title), COALESCE(c.authors, p.authors),
+				p.canonical_id, COALESCE(c.doi, p.doi), bm25(items_fts, ` + bm25Weights + `) AS rank,
+				snippet(items_fts, -1, ?, ?, ?, ?), highlight(items_fts, 0, ?, ?),
+				COALESCE(d.canonical_id, '')
 			FROM items_fts
 			JOIN items i ON i.rowid = items_fts.rowid
 			LEFT JOIN papers p ON i.paper_id = p.id
+			LEFT JOIN papers c ON c.id = p.canonical_id
+			LEFT JOIN item_duplicates d ON d.item_id = i.id
 			WHERE items_fts MATCH ?`)
-		args = append(args, opts.Query)
+		args = append(args, matchOpen, matchClose, snippetEllipsis, snippetTokens, matchOpen, matchClose, query)
 	} else {
 		qb.WriteString(
 			`SELECT i.id, i.type, i.content, i.paper_id, i.section, i.page,
-				i.confidence, i.tags, i.citations,
-				p.title, p.authors, 0 AS rank
+				i.confidence, i.tags, i.citations, i.resolved_content, i.metric, i.patent_claim, i.curated,
+				i.status, COALESCE(i.status_note, ''),
+				COALESCE(c.title, p.title), COALESCE(c.authors, p.authors),
+				p.canonical_id, COALESCE(c.doi, p.doi), 0 AS rank, '', '',
+				COALESCE(d.canonical_id, '')
 			FROM items i
 			LEFT JOIN papers p ON i.paper_id = p.id
+			LEFT JOIN papers c ON c.id = p.canonical_id
+			LEFT JOIN item_duplicates d ON d.item_id = i.id
 			WHERE 1=1`)
 	}
 
@@ -95,17 +246,97 @@ func (s *Store) Retrieve(ctx context.Context, opts QueryOptions) ([]QueryResult,
 		args = append(args, tag)
 	}
 
-	if useFTS {
-		qb.WriteString(` ORDER BY items_fts.rank`)
-	} else {
-		qb.WriteString(` ORDER BY i.paper_id, i.section, i.page`)
+	if opts.Institution != "" {
+		qb.WriteString(` AND EXISTS (SELECT 1 FROM author_affiliations a WHERE a.paper_id = i.paper_id
+			AND (INSTR(LOWER(a.institution), ?) > 0 OR a.ror = ?))`)
+		args = append(args, strings.ToLower(opts.Institution), opts.Institution)
+	}
+
+	if opts.Collection != "" {
+		if err := s.checkCollection(ctx, opts.Collection); err != nil {
+			return nil, err
+		}
+		qb.WriteString(` AND EXISTS (SELECT 1 FROM collection_members m WHERE m.collection = ?
+			AND (m.kind = 'item' AND m.member_id = i.id OR m.kind = 'paper' AND m.member_id = i.paper_id))`)
+		args = append(args, opts.Collection)
+	}
+
+	if opts.Status != "" {
+		switch opts.Status {
+		case types.StatusUnverified, types.StatusVerified, types.StatusDisputed:
+		default:
+			return nil, fmt.Errorf("invalid item status %q: use unverified, verified, or disputed", opts.Status)
+		}
+		qb.WriteString(` AND i.status = ?`)
+		args = append(args, string(opts.Status))
+	}
+
+	if opts.Metric != "" {
+		qb.WriteString(` AND INSTR(LOWER(json_extract(i.metric, '$.name')), ?) > 0`)
+		args = append(args, strings.ToLower(opts.Metric))
+	}
+
+	if opts.Dataset != "" {
+		qb.WriteString(` AND INSTR(LOWER(json_extract(i.metric, '$.dataset')), ?) > 0`)
+		args = append(args, strings.ToLower(opts.Dataset))
+	}
+
+	if opts.Claim > 0 {
+		qb.WriteString(` AND json_extract(i.patent_claim, '$.number') = ?`)
+		args = append(args, opts.Claim)
+	}
+
+	if opts.MinConfidence > 0 {
+		qb.WriteString(` AND i.confidence >= ?`)
+		args = append(args, opts.MinConfidence)
+	}
+
+	if opts.Pages.First > 0 {
+		qb.WriteString(` AND i.page >= ?`)
+		args = append(args, opts.Pages.First)
+	}
+	if opts.Pages.Last > 0 {
+		qb.WriteString(` AND i.page BETWEEN 1 AND ?`)
+		args = append(args, opts.Pages.Last)
+	}
+
+	// Paper dates are stored as RFC 3339 timestamps; their first ten
+	// characters compare as YYYY-MM-DD.
+	if !opts.DateFrom.IsZero() {
+		qb.WriteString(` AND SUBSTR(p.date, 1, 10) >= ?`)
+		args = append(args, opts.DateFrom.Format(time.DateOnly))
+	}
+	if !opts.DateTo.IsZero() {
+		qb.WriteString(` AND p.date != '' AND SUBSTR(p.date, 1, 10) <= ?`)
+		args = append(args, opts.DateTo.Format(time.DateOnly))
 	}
 
+	switch {
+	case useFTS:
+		qb.WriteString(` ORDER BY rank, i.id`)
+	case opts.Metric != "":
+		dir := "DESC"
+		if opts.LowerIsBetter || LowerIsBetter(opts.Metric) {
+			dir = "ASC"
+		}
+		qb.WriteString(` ORDER BY json_extract(i.metric, '$.value') ` + dir + `, i.confidence DESC, i.id`)
+	default:
+		qb.WriteString(` ORDER BY i.paper_id, i.section, i.page, json_extract(i.patent_claim, '$.number'), i.id`)
+	}
+
+	// Collapsing drops results after the query, so it limits them itself.
 	qb.WriteString(` LIMIT ?`)
-	args = append(args, maxResults)
+	if opts.CollapseDuplicates {
+		args = append(args, -1)
+	} else {
+		args = append(args, maxResults)
+	}
 
 	rows, err := s.db.QueryContext(ctx, qb.String(), args...)
 	if err != nil {
+		if strings.Contains(err.Error(), "fts5:") {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidQuery, opts.Query, err)
+		}
 		return nil, fmt.Errorf("querying knowledge base: %w", err)
 	}
 	defer rows.Close()
@@ -117,20 +348,30 @@ func (s *Store) Retrieve(ctx context.Context, opts QueryOptions) ([]QueryResult,
 			itemType    string
 			tagsJSON    sql.NullString
 			citJSON     sql.NullString
+			resolved    sql.NullString
+			metricJSON  sql.NullString
+			claimJSON   sql.NullString
 			paperTitle  sql.NullString
 			authorsJSON sql.NullString
+			canonicalID sql.NullString
+			paperDOI    sql.NullString
 			rank        float64
 		)
 
 		if err := rows.Scan(
 			&qr.ID, &itemType, &qr.Content, &qr.PaperID, &qr.Section, &qr.Page,
-			&qr.Confidence, &tagsJSON, &citJSON,
-			&paperTitle, &authorsJSON, &rank,
+			&qr.Confidence, &tagsJSON, &citJSON, &resolved, &metricJSON, &claimJSON, &qr.Curated,
+			&qr.Status, &qr.StatusNote,
+			&paperTitle, &authorsJSON, &canonicalID, &paperDOI, &rank,
+			&qr.Snippet, &qr.Highlight, &qr.cluster,
 		); err != nil {
 			return nil, fmt.Errorf("scanning row: %w", err)
 		}
 
 		qr.Type = types.KnowledgeItemType(itemType)
+		qr.ResolvedContent = resolved.String
+		// FTS5 BM25 scores are negative, more so for better matches.
+		qr.Score = -rank
 
 		if tagsJSON.Valid {
 			json.Unmarshal([]byte(tagsJSON.String), &qr.Tags)
@@ -138,73 +379,46 @@ func (s *Store) Retrieve(ctx context.Context, opts QueryOptions) ([]QueryResult,
 		if citJSON.Valid {
 			json.Unmarshal([]byte(citJSON.String), &qr.Citations)
 		}
+		if metricJSON.Valid {
+			json.Unmarshal([]byte(metricJSON.String), &qr.Metric)
+		}
+		if claimJSON.Valid {
+			json.Unmarshal([]byte(claimJSON.String), &qr.PatentClaim)
+		}
 		if paperTitle.Valid {
 			qr.PaperTitle = paperTitle.String
 		}
 		if authorsJSON.Valid {
 			json.Unmarshal([]byte(authorsJSON.String), &qr.PaperAuthors)
 		}
+		if canonicalID.Valid {
+			qr.CanonicalID = canonicalID.String
+		}
+		if paperDOI.Valid {
+			qr.PaperDOI = paperDOI.String
+		}
 
 		results = append(results, qr)
 	}
-
-	return results, rows.Err()
-}
-
-// Trace returns the surrounding context from the source Markdown for a
-// given item ID (R4.2, R4.3). It reads from papers/markdown/ using the
-// item's paper_id and section to locate the source passage.
-func (s *Store) Trace(ctx context.Context, itemID string) (string, error) {
-	var paperID, section string
-	var page int
-
-	err := s.db.QueryRowContext(ctx,
-		`SELECT paper_id, section, page FROM items WHERE id = ?`, itemID,
-	).Scan(&paperID, &section, &page)
-
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return "", fmt.Errorf("item %s not found", itemID)
-		}
-		return "", fmt.Errorf("looking up item: %w", err)
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	mdPath := filepath.Join(s.papersDir, markdownDir, paperID+".md")
-	content, err := os.ReadFile(mdPath)
-	if err != nil {
-		return "", fmt.Errorf("reading %s: %w", mdPath, err)
+	if opts.CollapseDuplicates {
+		return s.collapseDuplicates(ctx, results, maxResults)
 	}
-
-	return extractSectionContext(string(content), section), nil
+	return results, nil
 }
 
-// extractSectionContext finds the named section in Markdown and returns
-// its body text, stripping page markers.
-func extractSectionContext(content, targetSection string) string {
-	lines := strings.Split(content, "\n")
-	var capturing bool
-	var result []string
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		if strings.HasPrefix(trimmed, "## ") || strings.HasPrefix(trimmed, "### ") {
-			heading := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
-			if heading == targetSection {
-				capturing = true
-				continue
-			} else if capturing {
-				break
-			}
-		}
-
-		if capturing {
-			if strings.HasPrefix(trimmed, "<!-- page") {
-				continue
-			}
-			result = append(result, line)
-		}
+// ItemPaperID returns the ID of the paper the item was extracted from.
+func (s *Store) ItemPaperID(ctx context.Context, itemID string) (string, error) {
+	var paperID string
+	err := s.db.QueryRowContext(ctx, `SELECT paper_id FROM items WHERE id = ?`, itemID).Scan(&paperID)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("item %s not found", itemID)
 	}
-
-	return strings.TrimSpace(strings.Join(result, "\n"))
+	if err != nil {
+		return "", fmt.Errorf("looking up item: %w", err)
+	}
+	return paperID, nil
 }
@@ -0,0 +1,367 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Ingest history actions.
+const (
+	HistoryIndexed = "indexed"
+	HistoryUpdated = "updated"
+)
+
+// createSnapshots adds the ingest history and the tables of named
+// snapshots of the knowledge base (R18.1, R18.2). Like collections, they
+// are the researcher's record of the corpus over time, not derived from
+// the extraction files, so Rebuild carries them over.
+func createSnapshots(tx *sql.Tx) error {
+	for _, stmt := range []string{
+		`CREATE TABLE IF NOT EXISTS ingest_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			paper_id TEXT NOT NULL,
+			action TEXT NOT NULL,
+			items INTEGER NOT NULL,
+			ingested_at TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS snapshots (
+			name TEXT PRIMARY KEY,
+			description TEXT,
+			created_at TEXT NOT NULL,
+			history_id INTEGER NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS snapshot_items (
+			snapshot TEXT NOT NULL,
+			item_id TEXT NOT NULL,
+			paper_id TEXT NOT NULL,
+			type TEXT NOT NULL,
+			content TEXT NOT NULL,
+			status TEXT NOT NULL,
+			PRIMARY KEY (snapshot, item_id)
+		)`,
+	} {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("creating snapshot tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// recordIngest adds a paper's indexing to the ingest history (R18.1).
+func recordIngest(ctx context.Context, tx *sql.Tx, paperID, action string, items int) error {
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO ingest_history (paper_id, action, items, ingested_at) VALUES (?, ?, ?, ?)`,
+		paperID, action, items, time.Now().UTC().Format(time.RFC3339),
+	); err != nil {
+		return fmt.Errorf("recording ingest history: %w", err)
+	}
+	return nil
+}
+
+// Snapshot is a named copy of the knowledge base's items at one point in
+// time (R18.2).
+type Snapshot struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	Papers      int    `json:"papers"`
+	Items       int    `json:"items"`
+}
+
+// SnapshotItem is an item as a snapshot recorded it.
+type SnapshotItem struct {
+	ID      string `json:"id"`
+	PaperID string `json:"paper_id"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	Status  string `json:"status"`
+}
+
+// ItemChange is an item whose type, content, or review status differs
+// between two snapshots.
+type ItemChange struct {
+	Before SnapshotItem `json:"before"`
+	After  SnapshotItem `json:"after"`
+}
+
+// IngestEvent is one entry of the ingest history: a paper stored for the
+// first time or again.
+type IngestEvent struct {
+	PaperID    string `json:"paper_id"`
+	Action     string `json:"action"`
+	Items      int    `json:"items"`
+	IngestedAt string `json:"ingested_at"`
+}
+
+// SnapshotDiff is what changed in the corpus between two snapshots
+// (R18.3).
+type SnapshotDiff struct {
+	// From and To name the snapshots compared; To is empty when the
+	// comparison is with the current knowledge base.
+	From string `json:"from"`
+	To   string `json:"to,omitempty"`
+
+	AddedPapers   []string `json:"added_papers,omitempty"`
+	RemovedPapers []string `json:"removed_papers,omitempty"`
+
+	// AddedItems and RemovedItems include the items of added and removed
+	// papers.
+	AddedItems   []SnapshotItem `json:"added_items,omitempty"`
+	RemovedItems []SnapshotItem `json:"removed_items,omitempty"`
+	ChangedItems []ItemChange   `json:"changed_items,omitempty"`
+
+	// Ingests are the papers stored between the two snapshots, oldest
+	// first.
+	Ingests []IngestEvent `json:"ingests,omitempty"`
+}
+
+// Empty reports whether the items are the same in both snapshots.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.AddedItems) == 0 && len(d.RemovedItems) == 0 && len(d.ChangedItems) == 0
+}
+
+// CreateSnapshot records the current items under name (R18.2).
+func (s *Store) CreateSnapshot(ctx context.Context, name, description string) (Snapshot, error) {
+	if name == "" || strings.TrimSpace(name) != name {
+		return Snapshot{}, fmt.Errorf("invalid snapshot name %q: it must be non-empty without surrounding spaces", name)
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var historyID int64
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM ingest_history`).Scan(&historyID); err != nil {
+		return Snapshot{}, fmt.Errorf("reading ingest history: %w", err)
+	}
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO snapshots (name, description, created_at, history_id) VALUES (?, ?, ?, ?) ON CONFLICT(name) DO NOTHING`,
+		name, description, time.Now().UTC().Format(time.RFC3339), historyID)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("creating snapshot: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return Snapshot{}, fmt.Errorf("snapshot %q already exists", name)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO snapshot_items (snapshot, item_id, paper_id, type, content, status)
+		 SELECT ?, id, paper_id, type, content, COALESCE(status, 'unverified') FROM items`, name,
+	); err != nil {
+		return Snapshot{}, fmt.Errorf("recording snapshot items: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return Snapshot{}, fmt.Errorf("committing snapshot: %w", err)
+	}
+	return s.snapshot(ctx, name)
+}
+
+// Snapshots lists the snapshots, oldest first.
+func (s *Store) Snapshots(ctx context.Context) ([]Snapshot, error) {
+	rows, err := s.db.QueryContext(ctx, snapshotQuery+` ORDER BY s.history_id, s.created_at, s.name`)
+	if err != nil {
+		return nil, fmt.Errorf("listing snapshots: %w", err)
+	}
+	defer rows.Close()
+	var snapshots []Snapshot
+	for rows.Next() {
+		var sn Snapshot
+		if err := rows.Scan(&sn.Name, &sn.Description, &sn.CreatedAt, &sn.Papers, &sn.Items); err != nil {
+			return nil, fmt.Errorf("scanning snapshot: %w", err)
+		}
+		snapshots = append(snapshots, sn)
+	}
+	return snapshots, rows.Err()
+}
+
+// snapshotQuery selects snapshots with their paper and item counts.
+const snapshotQuery = `SELECT s.name, COALESCE(s.description, ''), s.created_at,
+	(SELECT COUNT(DISTINCT paper_id) FROM snapshot_items i WHERE i.snapshot = s.name),
+	(SELECT COUNT(*) FROM snapshot_items i WHERE i.snapshot = s.name)
+	FROM snapshots s`
+
+// snapshot returns the named snapshot.
+func (s *Store) snapshot(ctx context.Context, name string) (Snapshot, error) {
+	var sn Snapshot
+	err := s.db.QueryRowContext(ctx, snapshotQuery+` WHERE s.name = ?`, name).
+		Scan(&sn.Name, &sn.Description, &sn.CreatedAt, &sn.Papers, &sn.Items)
+	if err == sql.ErrNoRows {
+		return sn, fmt.Errorf("snapshot %q not found", name)
+	}
+	if err != nil {
+		return sn, fmt.Errorf("looking up snapshot: %w", err)
+	}
+	return sn, nil
+}
+
+// DeleteSnapshot deletes a snapshot; the ingest history stays.
+func (s *Store) DeleteSnapshot(ctx context.Context, name string) error {
+	if _, err := s.snapshot(ctx, name); err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+	for _, stmt := range []string{
+		`DELETE FROM snapshot_items WHERE snapshot = ?`,
+		`DELETE FROM snapshots WHERE name = ?`,
+	} {
+		if _, err := tx.ExecContext(ctx, stmt, name); err != nil {
+			return fmt.Errorf("deleting snapshot: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// DiffSnapshots compares snapshot from with snapshot to, or with the
+// current knowledge base when to is empty (R18.3).
+func (s *Store) DiffSnapshots(ctx context.Context, from, to string) (SnapshotDiff, error) {
+	diff := SnapshotDiff{From: from, To: to}
+	before, fromHistory, err := s.snapshotItems(ctx, from)
+	if err != nil {
+		return diff, err
+	}
+	after, toHistory, err := s.snapshotItems(ctx, to)
+	if err != nil {
+		return diff, err
+	}
+
+	beforePapers, afterPapers := make(map[string]bool), make(map[string]bool)
+	for id, it := range before {
+		beforePapers[it.PaperID] = true
+		if now, ok := after[id]; !ok {
+			diff.RemovedItems = append(diff.RemovedItems, it)
+		} else if now.Type != it.Type || now.Content != it.Content || now.Status != it.Status {
+			diff.ChangedItems = append(diff.ChangedItems, ItemChange{Before: it, After: now})
+		}
+	}
+	for id, it := range after {
+		afterPapers[it.PaperID] = true
+		if _, ok := before[id]; !ok {
+			diff.AddedItems = append(diff.AddedItems, it)
+		}
+	}
+	for p := range afterPapers {
+		if !beforePapers[p] {
+			diff.AddedPapers = append(diff.AddedPapers, p)
+		}
+	}
+	for p := range beforePapers {
+		if !afterPapers[p] {
+			diff.RemovedPapers = append(diff.RemovedPapers, p)
+		}
+	}
+	sort.Strings(diff.AddedPapers)
+	sort.Strings(diff.RemovedPapers)
+	for _, list := range [][]SnapshotItem{diff.AddedItems, diff.RemovedItems} {
+		sort.Slice(list, func(i, j int) bool { return itemLess(list[i], list[j]) })
+	}
+	sort.Slice(diff.ChangedItems, func(i, j int) bool {
+		return itemLess(diff.ChangedItems[i].After, diff.ChangedItems[j].After)
+	})
+
+	// The history between the snapshots, in either order.
+	lo, hi := fromHistory, toHistory
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	if diff.Ingests, err = s.ingestHistory(ctx, lo, hi); err != nil {
+		return diff, err
+	}
+	return diff, nil
+}
+
+// itemLess orders snapshot items by paper, then ID.
+func itemLess(a, b SnapshotItem) bool {
+	if a.PaperID != b.PaperID {
+		return a.PaperID < b.PaperID
+	}
+	return a.ID < b.ID
+}
+
+// snapshotItems returns the items of the named snapshot, or of the
+// current knowledge base when name is empty, by ID, with the last ingest
+// history entry they include.
+func (s *Store) snapshotItems(ctx context.Context, name string) (map[string]SnapshotItem, int64, error) {
+	var (
+		historyID int64
+		rows      *sql.Rows
+		err       error
+	)
+	if name == "" {
+		err = s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM ingest_history`).Scan(&historyID)
+		if err == nil {
+			rows, err = s.db.QueryContext(ctx,
+				`SELECT id, paper_id, type, content, COALESCE(status, 'unverified') FROM items`)
+		}
+	} else {
+		err = s.db.QueryRowContext(ctx, `SELECT history_id FROM snapshots WHERE name = ?`, name).Scan(&historyID)
+		if err == sql.ErrNoRows {
+			return nil, 0, fmt.Errorf("snapshot %q not found", name)
+		}
+		if err == nil {
+			rows, err = s.db.QueryContext(ctx,
+				`SELECT item_id, paper_id, type, content, status FROM snapshot_items WHERE snapshot = ?`, name)
+		}
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading snapshot items: %w", err)
+	}
+	defer rows.Close()
+	items := make(map[string]SnapshotItem)
+	for rows.Next() {
+		var it SnapshotItem
+		if err := rows.Scan(&it.ID, &it.PaperID, &it.Type, &it.Content, &it.Status); err != nil {
+			return nil, 0, fmt.Errorf("scanning snapshot item: %w", err)
+		}
+		items[it.ID] = it
+	}
+	return items, historyID, rows.Err()
+}
+
+// ingestHistory returns the ingest history entries after entry from up to
+// and including entry to, oldest first.
+func (s *Store) ingestHistory(ctx context.Context, from, to int64) ([]IngestEvent, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT paper_id, action, items, ingested_at FROM ingest_history WHERE id > ? AND id <= ? ORDER BY id`,
+		from, to)
+	if err != nil {
+		return nil, fmt.Errorf("reading ingest history: %w", err)
+	}
+	defer rows.Close()
+	var events []IngestEvent
+	for rows.Next() {
+		var e IngestEvent
+		if err := rows.Scan(&e.PaperID, &e.Action, &e.Items, &e.IngestedAt); err != nil {
+			return nil, fmt.Errorf("scanning ingest history: %w", err)
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// copySnapshots copies the ingest history and snapshots of the database
+// at path into the store and returns how many snapshots it copied. A
+// database from before snapshots has none.
+func (s *Store) copySnapshots(ctx context.Context, path string) (int, error) {
+	copied, err := s.copyTables(ctx, path, []tableCopy{
+		{"ingest_history", `SELECT id, paper_id, action, items, ingested_at FROM ingest_history`,
+			`INSERT OR IGNORE INTO ingest_history (id, paper_id, action, items, ingested_at) VALUES (?, ?, ?, ?, ?)`},
+		{"snapshots", `SELECT name, COALESCE(description, ''), created_at, history_id FROM snapshots`,
+			`INSERT OR IGNORE INTO snapshots (name, description, created_at, history_id) VALUES (?, ?, ?, ?)`},
+		{"snapshot_items", `SELECT snapshot, item_id, paper_id, type, content, status FROM snapshot_items`,
+			`INSERT OR IGNORE INTO snapshot_items (snapshot, item_id, paper_id, type, content, status) VALUES (?, ?, ?, ?, ?, ?)`},
+	})
+	if err != nil || copied == nil {
+		return 0, err
+	}
+	return copied[1], nil
+}
@@ -0,0 +1,127 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestSnapshotDiff(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ctx := context.Background()
+	ingestHelper(t, store, tmpDir, "old")
+
+	before, err := store.CreateSnapshot(ctx, "before", "start of review")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.Papers != 1 || before.Items != 4 {
+		t.Errorf("snapshot = %+v, want 1 paper and 4 items", before)
+	}
+	if _, err := store.CreateSnapshot(ctx, "before", ""); err == nil {
+		t.Error("duplicate snapshot created")
+	}
+
+	ingestHelper(t, store, tmpDir, "new")
+	content := "Efficient attention halves computation"
+	if _, err := store.EditItem(ctx, "old-claim1", ItemEdit{Content: &content}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ReviewItems(ctx, []string{"old-def1"}, types.StatusVerified, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteItem(ctx, "old-method1"); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := store.DiffSnapshots(ctx, "before", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.AddedPapers) != 1 || diff.AddedPapers[0] != "new" || len(diff.RemovedPapers) != 0 {
+		t.Errorf("papers added %v, removed %v; want new added", diff.AddedPapers, diff.RemovedPapers)
+	}
+	if len(diff.AddedItems) != 4 || len(diff.RemovedItems) != 1 || diff.RemovedItems[0].ID != "old-method1" {
+		t.Errorf("items added %d, removed %v; want 4 added and old-method1 removed", len(diff.AddedItems), diff.RemovedItems)
+	}
+	if len(diff.ChangedItems) != 2 || diff.ChangedItems[0].After.Content != content || diff.ChangedItems[1].After.Status != "verified" {
+		t.Errorf("changed = %+v, want the edit and the review", diff.ChangedItems)
+	}
+	// The new paper, then the old one stored again for each of the three
+	// hand changes.
+	if len(diff.Ingests) != 4 || diff.Ingests[0].PaperID != "new" || diff.Ingests[0].Action != HistoryIndexed {
+		t.Errorf("ingests = %+v, want new indexed then three updates", diff.Ingests)
+	}
+
+	if _, err := store.CreateSnapshot(ctx, "after", ""); err != nil {
+		t.Fatal(err)
+	}
+	back, err := store.DiffSnapshots(ctx, "after", "before")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(back.RemovedPapers) != 1 || len(back.RemovedItems) != 4 || len(back.AddedItems) != 1 || len(back.Ingests) != 4 {
+		t.Errorf("reverse diff = %+v", back)
+	}
+	if same, err := store.DiffSnapshots(ctx, "after", ""); err != nil || !same.Empty() {
+		t.Errorf("diff with current = %+v, %v; want empty", same, err)
+	}
+
+	snapshots, err := store.Snapshots(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 || snapshots[0].Name != "before" || snapshots[1].Papers != 2 {
+		t.Errorf("snapshots = %+v, want before then after", snapshots)
+	}
+	if err := store.DeleteSnapshot(ctx, "before"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.DiffSnapshots(ctx, "before", "after"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("err = %v, want deleted snapshot not found", err)
+	}
+}
+
+func TestRebuildKeepsSnapshots(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ctx := context.Background()
+	ingestHelper(t, store, tmpDir, "kept")
+	if _, err := store.CreateSnapshot(ctx, "v1", ""); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	cfg := types.KnowledgeBaseConfig{KnowledgeDir: filepath.Join(tmpDir, "knowledge")}
+	var buf strings.Builder
+	summary, err := Rebuild(ctx, cfg, filepath.Join(tmpDir, "papers"), &buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Snapshots != 1 {
+		t.Errorf("carried %d snapshots, want 1; output:\n%s", summary.Snapshots, buf.String())
+	}
+
+	store, err = NewStore(cfg, filepath.Join(tmpDir, "papers"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	diff, err := store.DiffSnapshots(ctx, "v1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Rebuilding stores the papers again without changing them.
+	if !diff.Empty() || len(diff.Ingests) != 0 {
+		t.Errorf("diff after rebuild = %+v, want no changes", diff)
+	}
+	var history int
+	store.db.QueryRow(`SELECT COUNT(*) FROM ingest_history`).Scan(&history)
+	if history != 1 {
+		t.Errorf("ingest history has %d entries after rebuild, want the original 1", history)
+	}
+}
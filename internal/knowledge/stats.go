@@ -0,0 +1,78 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"sort"
+)
+
+// noSection labels items without a section in the section counts.
+const noSection = "(none)"
+
+// topTags is the number of tags listed in CorpusStats.TopTags.
+const topTags = 20
+
+// confidenceBuckets is the number of equal-width confidence histogram
+// buckets between 0 and 1.
+const confidenceBuckets = 10
+
+// FacetCount is the number of items with one value of a facet, such as a
+// tag (R7.5).
+type FacetCount struct {
+	Value string `json:"value"`
+	Items int    `json:"items"`
+}
+
+// ConfidenceBucket counts the items whose extraction confidence is at
+// least Min and below Max; the last bucket includes 1 (R7.5).
+type ConfidenceBucket struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Items int     `json:"items"`
+}
+
+// TopCounts returns the n values of counts with the most items, most
+// first and then by value; n <= 0 returns them all.
+func TopCounts(counts map[string]int, n int) []FacetCount {
+	top := make([]FacetCount, 0, len(counts))
+	for v, c := range counts {
+		top = append(top, FacetCount{Value: v, Items: c})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Items != top[j].Items {
+			return top[i].Items > top[j].Items
+		}
+		return top[i].Value < top[j].Value
+	})
+	if n > 0 && len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// confidenceHistogram counts items per confidence bucket.
+func (s *Store) confidenceHistogram(ctx context.Context) ([]ConfidenceBucket, error) {
+	buckets := make([]ConfidenceBucket, confidenceBuckets)
+	for i := range buckets {
+		buckets[i].Min = float64(i) / confidenceBuckets
+		buckets[i].Max = float64(i+1) / confidenceBuckets
+	}
+	// The small offset keeps confidences such as 0.7, stored as
+	// 0.6999..., in their bucket.
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT MIN(MAX(CAST(COALESCE(confidence, 0) * ? + 1e-9 AS INTEGER), 0), ? - 1), COUNT(*)
+		FROM items GROUP BY 1`, confidenceBuckets, confidenceBuckets)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var bucket, n int
+		if err := rows.Scan(&bucket, &n); err != nil {
+			return nil, err
+		}
+		buckets[bucket].Items = n
+	}
+	return buckets, rows.Err()
+}
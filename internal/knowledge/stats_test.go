@@ -0,0 +1,67 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestStatsFacets(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestHelper(t, store, tmpDir, "p1")
+	writeExtraction(t, tmpDir, "p2", []types.KnowledgeItem{
+		{ID: "p2-a", Type: types.ItemClaim, Content: "Attention is all you need", PaperID: "p2", Confidence: 0.7, Tags: []string{"attention"}},
+		{ID: "p2-b", Type: types.ItemClaim, Content: "Recurrence is not needed", PaperID: "p2", Section: "Method", Confidence: 1},
+	})
+	ingestHelper(t, store, tmpDir, "p1")
+
+	st, err := store.Stats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.ItemsByPaper["p1"] != 4 || st.ItemsByPaper["p2"] != 2 {
+		t.Errorf("ItemsByPaper = %v", st.ItemsByPaper)
+	}
+	if st.ItemsBySection["Method"] != 3 || st.ItemsBySection[noSection] != 1 {
+		t.Errorf("ItemsBySection = %v", st.ItemsBySection)
+	}
+	if len(st.TopTags) == 0 || st.TopTags[0] != (FacetCount{Value: "attention", Items: 4}) {
+		t.Errorf("TopTags = %v, want attention first with 4 items", st.TopTags)
+	}
+	if st.ItemsByTag["softmax"] != 1 {
+		t.Errorf("ItemsByTag = %v", st.ItemsByTag)
+	}
+
+	if len(st.Confidence) != confidenceBuckets {
+		t.Fatalf("got %d confidence buckets, want %d", len(st.Confidence), confidenceBuckets)
+	}
+	// 0.7 falls in its own bucket, 0.88 in 0.8-0.9, and 0.92, 0.95, 0.97,
+	// and 1 in the last.
+	want := map[int]int{7: 1, 8: 1, 9: 4}
+	for i, b := range st.Confidence {
+		if b.Items != want[i] {
+			t.Errorf("bucket %.1f-%.1f has %d items, want %d", b.Min, b.Max, b.Items, want[i])
+		}
+	}
+}
+
+func TestTopCounts(t *testing.T) {
+	counts := map[string]int{"b": 2, "a": 2, "c": 5, "d": 1}
+	got := TopCounts(counts, 3)
+	want := []FacetCount{{"c", 5}, {"a", 2}, {"b", 2}}
+	if len(got) != len(want) {
+		t.Fatalf("TopCounts = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("TopCounts = %v, want %v", got, want)
+			break
+		}
+	}
+	if all := TopCounts(counts, 0); len(all) != 4 {
+		t.Errorf("TopCounts(0) returned %d, want all 4", len(all))
+	}
+}
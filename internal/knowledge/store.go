@@ -14,15 +14,19 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
 	"go.yaml.in/yaml/v3"
 
 	"github.com/pdiddy/research-engine/pkg/types"
 )
 
+// bm25Weights weights the BM25 relevance of matches in the items_fts
+// columns: content, section, tags, and resolved_content.
+const bm25Weights = "10.0, 2.0, 2.0, 5.0"
+
 const (
 	extractedDir = "extracted"
 	indexDir     = "index"
@@ -37,19 +41,30 @@ type Store struct {
 	knowledgeDir string
 	papersDir    string
 	maxResults   int
+	policy       VersionPolicy
+	// venueRankings are the ranking files applied to venues on ingest.
+	venueRankings []string
+	// progress, when set, is called after Ingest processes each paper.
+	progress func(done, total int)
+	// noHistory keeps ingests out of the ingest history.
+	noHistory bool
 }
 
 // NewStore opens or creates the knowledge base SQLite database at
 // knowledgeDir/index/research.db. It creates the schema if it does not
-// exist (R1.2, R1.3).
+// exist and applies any pending schema migrations (R1.2, R1.3, R1.7).
 func NewStore(cfg types.KnowledgeBaseConfig, papersDir string) (*Store, error) {
 	dbDir := filepath.Join(cfg.KnowledgeDir, indexDir)
 	if err := os.MkdirAll(dbDir, 0o755); err != nil {
 		return nil, fmt.Errorf("creating index directory: %w", err)
 	}
 
-	dbPath := filepath.Join(dbDir, dbFile)
-	db, err := sql.Open("sqlite3", dbPath+"?_journal_mode=WAL&_foreign_keys=on")
+	return openStore(cfg, papersDir, filepath.Join(dbDir, dbFile))
+}
+
+// openStore opens or creates the knowledge base database at dbPath.
+func openStore(cfg types.KnowledgeBaseConfig, papersDir, dbPath string) (*Store, error) {
+	db, err := sql.Open(sqliteDriver, sqliteDSN(dbPath, false))
 	if err != nil {
 		return nil, fmt.Errorf("opening database: %w", err)
 	}
@@ -59,16 +74,25 @@ func NewStore(cfg types.KnowledgeBaseConfig, papersDir string) (*Store, error) {
 		maxResults = 20
 	}
 
+	policy, err := ParseVersionPolicy(cfg.VersionPolicy)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
 	s := &Store{
 		db:           db,
 		knowledgeDir: cfg.KnowledgeDir,
 		papersDir:    papersDir,
 		maxResults:   maxResults,
+		policy:       policy,
+
+		venueRankings: cfg.VenueRankings,
 	}
 
-	if err := s.createSchema(); err != nil {
+	if _, err := s.migrate(context.Background()); err != nil {
 		db.Close()
-		return nil, fmt.Errorf("creating schema: %w", err)
+		return nil, err
 	}
 
 	return s, nil
@@ -79,7 +103,9 @@ func (s *Store) Close() error {
 	return s.db.Close()
 }
 
-func (s *Store) createSchema() error {
+// createSchema creates the schema as it stood before versioning, and
+// brings a database created by any earlier build up to it.
+func createSchema(tx *sql.Tx) error {
 	statements := []string{
 		`CREATE TABLE IF NOT EXISTS papers (
 			id TEXT PRIMARY KEY,
@@ -89,7 +115,14 @@ func (s *Store) createSchema() error {
 			abstract TEXT,
 			source_url TEXT,
 			pdf_path TEXT,
-			conversion_status TEXT
+			conversion_status TEXT,
+			doi TEXT,
+			arxiv_id TEXT,
+			canonical_id TEXT,
+			source TEXT,
+			status TEXT,
+			venue_id TEXT,
+			summary TEXT
 		)`,
 		`CREATE TABLE IF NOT EXISTS items (
 			rowid INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -101,51 +134,145 @@ func (s *Store) createSchema() error {
 			page INTEGER,
 			confidence REAL,
 			tags TEXT,
-			citations TEXT
+			citations TEXT,
+			resolved_content TEXT,
+			metric TEXT,
+			patent_claim TEXT
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_items_paper_id ON items(paper_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_items_type ON items(type)`,
+		`CREATE TABLE IF NOT EXISTS item_embeddings (
+			item_id TEXT PRIMARY KEY,
+			model TEXT NOT NULL,
+			content_hash TEXT NOT NULL,
+			vector BLOB NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS item_duplicates (
+			item_id TEXT PRIMARY KEY,
+			canonical_id TEXT NOT NULL,
+			similarity REAL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_item_duplicates_canonical ON item_duplicates(canonical_id)`,
 		`CREATE TABLE IF NOT EXISTS indexing_status (
 			paper_id TEXT PRIMARY KEY,
 			file_mod_time TEXT
 		)`,
 	}
 
+	statements = append(statements, authorSchema...)
+	statements = append(statements, venueSchema...)
+
 	for _, stmt := range statements {
-		if _, err := s.db.Exec(stmt); err != nil {
+		if _, err := tx.Exec(stmt); err != nil {
 			return fmt.Errorf("executing schema statement: %w", err)
 		}
 	}
 
-	// FTS5 virtual table with triggers for sync.
-	var ftsExists int
-	if err := s.db.QueryRow(
-		`SELECT count(*) FROM sqlite_master WHERE type='table' AND name='items_fts'`,
-	).Scan(&ftsExists); err != nil {
+	// Databases created before version linking lack the identifier columns,
+	// and those created before paper summaries lack summary.
+	if err := addMissingColumns(tx, "papers", map[string]string{
+		"doi":          "TEXT",
+		"arxiv_id":     "TEXT",
+		"canonical_id": "TEXT",
+		"source":       "TEXT",
+		"status":       "TEXT",
+		"venue_id":     "TEXT",
+		"summary":      "TEXT",
+	}); err != nil {
+		return err
+	}
+	// Databases created before reference resolution lack resolved_content,
+	// those created before structured results lack metric, and those
+	// created before patent claims lack patent_claim.
+	if err := addMissingColumns(tx, "items", map[string]string{
+		"resolved_content": "TEXT",
+		"metric":           "TEXT",
+		"patent_claim":     "TEXT",
+	}); err != nil {
+		return err
+	}
+
+	// FTS5 virtual table with triggers for sync. Section and tags are
+	// indexed as their own columns so queries can filter on them with
+	// column syntax (section:methods), and resolved_content so a method
+	// name matches items that only say "our method" (prd003 R7.3).
+	// Databases created with an older column set are rebuilt.
+	var ftsSQL string
+	err := tx.QueryRow(
+		`SELECT sql FROM sqlite_master WHERE type='table' AND name='items_fts'`,
+	).Scan(&ftsSQL)
+	if err != nil && err != sql.ErrNoRows {
 		return fmt.Errorf("checking FTS table: %w", err)
 	}
+	if ftsSQL != "" && strings.Contains(ftsSQL, "resolved_content") {
+		return nil
+	}
 
-	if ftsExists == 0 {
-		ftsStatements := []string{
-			`CREATE VIRTUAL TABLE items_fts USING fts5(content, content=items, content_rowid=rowid)`,
-			`CREATE TRIGGER items_ai AFTER INSERT ON items BEGIN
-				INSERT INTO items_fts(rowid, content) VALUES (new.rowid, new.content);
-			END`,
-			`CREATE TRIGGER items_ad AFTER DELETE ON items BEGIN
-				INSERT INTO items_fts(items_fts, rowid, content) VALUES('delete', old.rowid, old.content);
-			END`,
-			`CREATE TRIGGER items_au AFTER UPDATE ON items BEGIN
-				INSERT INTO items_fts(items_fts, rowid, content) VALUES('delete', old.rowid, old.content);
-				INSERT INTO items_fts(rowid, content) VALUES (new.rowid, new.content);
-			END`,
+	ftsStatements := []string{
+		`DROP TRIGGER IF EXISTS items_ai`,
+		`DROP TRIGGER IF EXISTS items_ad`,
+		`DROP TRIGGER IF EXISTS items_au`,
+		`DROP TABLE IF EXISTS items_fts`,
+		`CREATE VIRTUAL TABLE items_fts USING fts5(content, section, tags, resolved_content, content=items, content_rowid=rowid)`,
+		`CREATE TRIGGER items_ai AFTER INSERT ON items BEGIN
+			INSERT INTO items_fts(rowid, content, section, tags, resolved_content) VALUES (new.rowid, new.content, new.section, new.tags, new.resolved_content);
+		END`,
+		`CREATE TRIGGER items_ad AFTER DELETE ON items BEGIN
+			INSERT INTO items_fts(items_fts, rowid, content, section, tags, resolved_content) VALUES('delete', old.rowid, old.content, old.section, old.tags, old.resolved_content);
+		END`,
+		`CREATE TRIGGER items_au AFTER UPDATE ON items BEGIN
+			INSERT INTO items_fts(items_fts, rowid, content, section, tags, resolved_content) VALUES('delete', old.rowid, old.content, old.section, old.tags, old.resolved_content);
+			INSERT INTO items_fts(rowid, content, section, tags, resolved_content) VALUES (new.rowid, new.content, new.section, new.tags, new.resolved_content);
+		END`,
+		// Unqualified terms match every column; weight content matches
+		// above section and tag matches when ranking. Resolved content
+		// repeats most of content, so it weighs less to avoid counting a
+		// match twice.
+		`INSERT INTO items_fts(items_fts, rank) VALUES('rank', 'bm25(` + bm25Weights + `)')`,
+		`INSERT INTO items_fts(items_fts) VALUES('rebuild')`,
+	}
+	for _, stmt := range ftsStatements {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("creating FTS infrastructure: %w", err)
 		}
-		for _, stmt := range ftsStatements {
-			if _, err := s.db.Exec(stmt); err != nil {
-				return fmt.Errorf("creating FTS infrastructure: %w", err)
-			}
+	}
+
+	return nil
+}
+
+// addMissingColumns adds each column in cols that table does not have yet.
+func addMissingColumns(tx *sql.Tx, table string, cols map[string]string) error {
+	rows, err := tx.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return fmt.Errorf("reading %s columns: %w", table, err)
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return fmt.Errorf("scanning %s columns: %w", table, err)
 		}
+		existing[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("reading %s columns: %w", table, err)
 	}
 
+	names := make([]string, 0, len(cols))
+	for name := range cols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if existing[name] {
+			continue
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, name, cols[name])); err != nil {
+			return fmt.Errorf("adding column %s.%s: %w", table, name, err)
+		}
+	}
 	return nil
 }
 
@@ -162,24 +289,24 @@ func (s IngestSummary) Total() int {
 	return s.Indexed + s.Updated + s.Skipped + s.Failed
 }
 
-// Ingest reads extraction YAML files from knowledgeDir/extracted/ and
-// populates the database. It detects new, changed, and unchanged files
-// for incremental updates (R1.1, R5.1-R5.5). On success it writes
-// export.yaml (R1.6).
+// Ingest reads extraction YAML files from knowledgeDir/extracted/, with
+// the hand edits in knowledgeDir/curated/ applied (R17.1), and populates
+// the database. It detects new, changed, and unchanged files for
+// incremental updates (R1.1, R5.1-R5.5). On success it writes export.yaml
+// (R1.6).
 func (s *Store) Ingest(ctx context.Context, w io.Writer) (IngestSummary, error) {
-	extractDir := filepath.Join(s.knowledgeDir, extractedDir)
 	metaDir := filepath.Join(s.papersDir, metadataDir)
 
-	entries, err := os.ReadDir(extractDir)
+	paperIDs, err := s.paperSources()
 	if err != nil {
-		return IngestSummary{}, fmt.Errorf("reading extraction directory %s: %w", extractDir, err)
+		return IngestSummary{}, err
 	}
 
 	var summary IngestSummary
 
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), "-items.yaml") {
-			continue
+	for i, paperID := range paperIDs {
+		if s.progress != nil {
+			s.progress(i, len(paperIDs))
 		}
 
 		select {
@@ -188,16 +315,12 @@ func (s *Store) Ingest(ctx context.Context, w io.Writer) (IngestSummary, error)
 		default:
 		}
 
-		paperID := strings.TrimSuffix(entry.Name(), "-items.yaml")
-		filePath := filepath.Join(extractDir, entry.Name())
-
-		info, err := entry.Info()
+		modTime, err := s.sourceModTime(paperID)
 		if err != nil {
 			fmt.Fprintf(w, "failed  %s: %v\n", paperID, err)
 			summary.Failed++
 			continue
 		}
-		modTime := info.ModTime().UTC().Format(time.RFC3339Nano)
 
 		// Check whether the file has changed since last indexing (R5.1, R5.3).
 		var storedModTime string
@@ -213,23 +336,16 @@ func (s *Store) Ingest(ctx context.Context, w io.Writer) (IngestSummary, error)
 
 		isUpdate := err == nil
 
-		data, err := os.ReadFile(filePath)
+		result, err := s.loadPaperItems(paperID)
 		if err != nil {
 			fmt.Fprintf(w, "failed  %s: %v\n", paperID, err)
 			summary.Failed++
 			continue
 		}
 
-		var result types.ExtractionResult
-		if err := yaml.Unmarshal(data, &result); err != nil {
-			fmt.Fprintf(w, "failed  %s: parse error: %v\n", paperID, err)
-			summary.Failed++
-			continue
-		}
-
 		paper := loadPaperMetadata(metaDir, paperID)
 
-		if err := s.ingestPaper(ctx, paperID, &result, paper, modTime, isUpdate); err != nil {
+		if err := s.ingestPaper(ctx, paperID, result, paper, modTime, isUpdate); err != nil {
 			fmt.Fprintf(w, "failed  %s: %v\n", paperID, err)
 			summary.Failed++
 			continue
@@ -244,9 +360,48 @@ func (s *Store) Ingest(ctx context.Context, w io.Writer) (IngestSummary, error)
 		}
 	}
 
+	if s.progress != nil {
+		s.progress(len(paperIDs), len(paperIDs))
+	}
+
 	fmt.Fprintf(w, "\nindexed: %d, updated: %d, skipped: %d, failed: %d\n",
 		summary.Indexed, summary.Updated, summary.Skipped, summary.Failed)
 
+	referenced, err := s.registerReferencedPapers(ctx, metaDir)
+	if err != nil {
+		fmt.Fprintf(w, "warning: registering metadata-only papers: %v\n", err)
+	}
+	if referenced > 0 {
+		fmt.Fprintf(w, "registered %d metadata-only paper(s) without knowledge items\n", referenced)
+	}
+	if err := s.backfillAuthors(ctx, metaDir); err != nil {
+		fmt.Fprintf(w, "warning: indexing authors: %v\n", err)
+	}
+	if err := s.backfillVenues(ctx, metaDir); err != nil {
+		fmt.Fprintf(w, "warning: indexing venues: %v\n", err)
+	}
+	if len(s.venueRankings) > 0 {
+		s.rankVenues(ctx, w)
+	}
+
+	if summary.Indexed > 0 || summary.Updated > 0 || referenced > 0 {
+		linked, err := s.LinkVersions(ctx)
+		if err != nil {
+			return summary, err
+		}
+		if linked > 0 {
+			fmt.Fprintf(w, "linked %d preprint/published version set(s)\n", linked)
+		}
+		// Duplicates are found after linking so that versions of a paper
+		// count as one (R14.1).
+		clustered, err := s.DetectDuplicates(ctx, 0)
+		if err != nil {
+			fmt.Fprintf(w, "warning: detecting duplicates: %v\n", err)
+		} else if clustered > 0 {
+			fmt.Fprintf(w, "clustered %d near-duplicate item set(s)\n", clustered)
+		}
+	}
+
 	// Write export.yaml after successful ingestion (R1.6).
 	if summary.Indexed > 0 || summary.Updated > 0 {
 		if err := s.ExportYAML(ctx, QueryOptions{}); err != nil {
@@ -273,23 +428,8 @@ func (s *Store) ingestPaper(ctx context.Context, paperID string, result *types.E
 
 	// Upsert paper record (R1.5).
 	if paper != nil {
-		authorsJSON, _ := json.Marshal(paper.Authors)
-		dateStr := ""
-		if !paper.Date.IsZero() {
-			dateStr = paper.Date.Format(time.RFC3339)
-		}
-		_, err := tx.ExecContext(ctx,
-			`INSERT INTO papers (id, title, authors, date, abstract, source_url, pdf_path, conversion_status)
-			 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-			 ON CONFLICT(id) DO UPDATE SET
-				title=excluded.title, authors=excluded.authors, date=excluded.date,
-				abstract=excluded.abstract, source_url=excluded.source_url,
-				pdf_path=excluded.pdf_path, conversion_status=excluded.conversion_status`,
-			paper.ID, paper.Title, string(authorsJSON), dateStr,
-			paper.Abstract, paper.SourceURL, paper.PDFPath, string(paper.ConversionStatus),
-		)
-		if err != nil {
-			return fmt.Errorf("upserting paper: %w", err)
+		if err := upsertPaper(ctx, tx, paper); err != nil {
+			return err
 		}
 	} else {
 		_, err := tx.ExecContext(ctx,
@@ -300,28 +440,63 @@ func (s *Store) ingestPaper(ctx context.Context, paperID string, result *types.E
 		}
 	}
 
+	// Record the paper summary (prd003 R9.2), or clear one from an earlier
+	// extraction.
+	var summary sql.NullString
+	if text := result.Summary.Text(); text != "" {
+		summary = sql.NullString{String: text, Valid: true}
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE papers SET summary = ? WHERE id = ?`, summary, paperID); err != nil {
+		return fmt.Errorf("recording paper summary: %w", err)
+	}
+
 	// Insert items (R1.4).
 	stmt, err := tx.PrepareContext(ctx,
-		`INSERT OR REPLACE INTO items (id, type, content, paper_id, section, page, confidence, tags, citations)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		`INSERT OR REPLACE INTO items (id, type, content, paper_id, section, page, confidence, tags, citations, resolved_content, metric, patent_claim, curated, status, status_note)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return fmt.Errorf("preparing insert: %w", err)
 	}
 	defer stmt.Close()
 
 	for _, item := range result.Items {
+		status := item.Status
+		if status == "" {
+			status = types.StatusUnverified
+		}
 		tagsJSON, _ := json.Marshal(item.Tags)
 		citationsJSON, _ := json.Marshal(item.Citations)
+		var metricJSON sql.NullString
+		if item.Metric != nil {
+			b, _ := json.Marshal(item.Metric)
+			metricJSON = sql.NullString{String: string(b), Valid: true}
+		}
+		var claimJSON sql.NullString
+		if item.PatentClaim != nil {
+			b, _ := json.Marshal(item.PatentClaim)
+			claimJSON = sql.NullString{String: string(b), Valid: true}
+		}
 		_, err := stmt.ExecContext(ctx,
 			item.ID, string(item.Type), item.Content, item.PaperID,
 			item.Section, item.Page, item.Confidence,
-			string(tagsJSON), string(citationsJSON),
+			string(tagsJSON), string(citationsJSON), item.ResolvedContent, metricJSON, claimJSON, item.Curated,
+			string(status), item.StatusNote,
 		)
 		if err != nil {
 			return fmt.Errorf("inserting item %s: %w", item.ID, err)
 		}
 	}
 
+	if !s.noHistory {
+		action := HistoryIndexed
+		if isUpdate {
+			action = HistoryUpdated
+		}
+		if err := recordIngest(ctx, tx, paperID, action, len(result.Items)); err != nil {
+			return err
+		}
+	}
+
 	// Update indexing status (R5.1).
 	_, err = tx.ExecContext(ctx,
 		`INSERT INTO indexing_status (paper_id, file_mod_time) VALUES (?, ?)
@@ -335,6 +510,117 @@ func (s *Store) ingestPaper(ctx context.Context, paperID string, result *types.E
 	return tx.Commit()
 }
 
+// upsertPaper inserts or replaces the papers row for paper (R1.5), its
+// authorship rows (R7.1), and its venue (R8.1).
+func upsertPaper(ctx context.Context, tx *sql.Tx, paper *types.Paper) error {
+	authorsJSON, _ := json.Marshal(paper.Authors)
+	dateStr := ""
+	if !paper.Date.IsZero() {
+		dateStr = paper.Date.Format(time.RFC3339)
+	}
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO papers (id, title, authors, date, abstract, source_url, pdf_path, conversion_status, doi, arxiv_id, source, status)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET
+			title=excluded.title, authors=excluded.authors, date=excluded.date,
+			abstract=excluded.abstract, source_url=excluded.source_url,
+			pdf_path=excluded.pdf_path, conversion_status=excluded.conversion_status,
+			doi=excluded.doi, arxiv_id=excluded.arxiv_id,
+			source=excluded.source, status=excluded.status`,
+		paper.ID, paper.Title, string(authorsJSON), dateStr,
+		paper.Abstract, paper.SourceURL, paper.PDFPath, string(paper.ConversionStatus),
+		strings.ToLower(strings.TrimSpace(paper.DOI)), paper.ArxivID,
+		paper.Source, string(paper.Status),
+	)
+	if err != nil {
+		return fmt.Errorf("upserting paper: %w", err)
+	}
+	if err := replacePaperAuthors(ctx, tx, paper); err != nil {
+		return err
+	}
+	return setPaperVenue(ctx, tx, paper)
+}
+
+// registerReferencedPapers adds the papers recorded without a PDF (status
+// no_pdf or metadata_only) to the papers table, so works that were never
+// downloaded are known to the knowledge base with their provenance
+// (prd001 R1.8). They have no items. It returns the number registered.
+func (s *Store) registerReferencedPapers(ctx context.Context, metaDir string) (int, error) {
+	entries, err := os.ReadDir(metaDir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading metadata directory %s: %w", metaDir, err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	registered := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml") {
+			continue
+		}
+		paper := loadPaperMetadata(metaDir, strings.TrimSuffix(entry.Name(), ".yaml"))
+		if paper == nil || paper.Status == "" || paper.DuplicateOf != "" {
+			continue
+		}
+		if err := upsertPaper(ctx, tx, paper); err != nil {
+			return 0, fmt.Errorf("%s: %w", paper.ID, err)
+		}
+		registered++
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+	return registered, nil
+}
+
+// backfillAuthors indexes the authors of papers that have none yet, such
+// as papers indexed before the author tables existed whose extractions
+// are unchanged (R7.1).
+func (s *Store) backfillAuthors(ctx context.Context, metaDir string) error {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id FROM papers WHERE id NOT IN (SELECT paper_id FROM paper_authors)`)
+	if err != nil {
+		return err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+	for _, id := range ids {
+		paper := loadPaperMetadata(metaDir, id)
+		if paper == nil || (len(paper.Authors) == 0 && len(paper.AuthorDetails) == 0) {
+			continue
+		}
+		paper.ID = id
+		if err := replacePaperAuthors(ctx, tx, paper); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 // loadPaperMetadata reads a Paper record from metaDir/[paperID].yaml.
 // Returns nil if the file does not exist or cannot be parsed.
 func loadPaperMetadata(metaDir, paperID string) *types.Paper {
@@ -0,0 +1,232 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// minTraceSimilarity is the share of an item's word-pair shingles a
+// Markdown paragraph must contain for Trace to take it as the item's
+// source when the item's section is not found (R4.4).
+const minTraceSimilarity = 0.5
+
+// TraceMatch says how Trace located an item's source passage.
+type TraceMatch string
+
+const (
+	// TraceSection is a section whose heading equals the item's section.
+	TraceSection TraceMatch = "section"
+
+	// TraceHeading is a section whose heading equals the item's section
+	// once numbering, case, and spacing are ignored.
+	TraceHeading TraceMatch = "heading"
+
+	// TraceContent is the paragraph whose text best matches the item's
+	// content.
+	TraceContent TraceMatch = "content"
+)
+
+// TraceResult is the source passage Trace found for an item.
+type TraceResult struct {
+	// Context is the passage: the section's body for a section or
+	// heading match, the matching paragraph for a content match.
+	Context string `json:"context"`
+
+	// Section is the item's stored section, and Heading the heading the
+	// passage was found under ("" before the first heading).
+	Section string `json:"section"`
+	Heading string `json:"heading"`
+
+	// Match says how the passage was found, and Similarity, for a
+	// content match, the share of the item's shingles it contains.
+	Match      TraceMatch `json:"match"`
+	Similarity float64    `json:"similarity,omitempty"`
+}
+
+// Trace returns the surrounding context from the source Markdown for a
+// given item ID (R4.2, R4.3). It is TraceItem's passage.
+func (s *Store) Trace(ctx context.Context, itemID string) (string, error) {
+	result, err := s.TraceItem(ctx, itemID)
+	return result.Context, err
+}
+
+// TraceItem locates an item's source passage in papers/markdown/ (R4.2,
+// R4.3). It looks for the item's section heading, then for a heading
+// that differs only in numbering, case, or spacing, and then, when the
+// sections were renamed after extraction, for the paragraph most like the
+// item's content (R4.4). It fails when none of these finds the item.
+func (s *Store) TraceItem(ctx context.Context, itemID string) (TraceResult, error) {
+	var paperID, section, content string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT paper_id, section, content FROM items WHERE id = ?`, itemID,
+	).Scan(&paperID, &section, &content)
+	if err == sql.ErrNoRows {
+		return TraceResult{}, fmt.Errorf("item %s not found", itemID)
+	}
+	if err != nil {
+		return TraceResult{}, fmt.Errorf("looking up item: %w", err)
+	}
+
+	mdPath := filepath.Join(s.papersDir, markdownDir, paperID+".md")
+	md, err := os.ReadFile(mdPath)
+	if err != nil {
+		return TraceResult{}, fmt.Errorf("reading %s: %w", mdPath, err)
+	}
+
+	result, ok := locateSource(string(md), section, content)
+	if !ok {
+		return result, fmt.Errorf("item %s not found in %s: no section %q and no paragraph matching its content", itemID, mdPath, section)
+	}
+	return result, nil
+}
+
+// locateSource finds the passage in Markdown an item with the given
+// section and content came from.
+func locateSource(md, section, content string) (TraceResult, bool) {
+	result := TraceResult{Section: section}
+	if section != "" {
+		if text := extractSectionContext(md, section); text != "" {
+			result.Context, result.Heading, result.Match = text, section, TraceSection
+			return result, true
+		}
+	}
+
+	sections := markdownSections(md)
+	if want := normalizeHeading(section); want != "" {
+		for _, sec := range sections {
+			if sec.heading != "" && normalizeHeading(sec.heading) == want {
+				if text := strings.TrimSpace(strings.Join(sec.lines, "\n")); text != "" {
+					result.Context, result.Heading, result.Match = text, sec.heading, TraceHeading
+					return result, true
+				}
+			}
+		}
+	}
+
+	item := shingles(content)
+	if len(item) == 0 {
+		return result, false
+	}
+	for _, sec := range sections {
+		for _, para := range paragraphs(sec.lines) {
+			if sim := containment(item, shingles(para)); sim > result.Similarity {
+				result.Context, result.Heading, result.Similarity = para, sec.heading, sim
+			}
+		}
+	}
+	if result.Similarity < minTraceSimilarity {
+		return TraceResult{Section: section}, false
+	}
+	result.Match = TraceContent
+	return result, true
+}
+
+// extractSectionContext finds the named section in Markdown and returns
+// its body text, stripping page markers.
+func extractSectionContext(content, targetSection string) string {
+	lines := strings.Split(content, "\n")
+	var capturing bool
+	var result []string
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "## ") || strings.HasPrefix(trimmed, "### ") {
+			heading := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+			if heading == targetSection {
+				capturing = true
+				continue
+			} else if capturing {
+				break
+			}
+		}
+
+		if capturing {
+			if strings.HasPrefix(trimmed, "<!-- page") {
+				continue
+			}
+			result = append(result, line)
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
+
+// markdownSection is the text under one "##" or "###" heading, without
+// page markers.
+type markdownSection struct {
+	heading string
+	lines   []string
+}
+
+// markdownSections splits Markdown at its "##" and "###" headings, as
+// extractSectionContext reads them. Text before the first heading is a
+// section with an empty heading.
+func markdownSections(md string) []markdownSection {
+	sections := []markdownSection{{}}
+	for _, line := range strings.Split(md, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "## ") || strings.HasPrefix(trimmed, "### "):
+			sections = append(sections, markdownSection{heading: strings.TrimSpace(strings.TrimLeft(trimmed, "#"))})
+		case strings.HasPrefix(trimmed, "<!-- page"):
+		default:
+			last := &sections[len(sections)-1]
+			last.lines = append(last.lines, line)
+		}
+	}
+	return sections
+}
+
+// paragraphs returns the blank-line separated paragraphs of lines.
+func paragraphs(lines []string) []string {
+	var paras, current []string
+	flush := func() {
+		if len(current) > 0 {
+			paras = append(paras, strings.Join(current, "\n"))
+			current = nil
+		}
+	}
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+	return paras
+}
+
+// headingNumber matches the numbering before a heading's title: "3",
+// "3.2.", "IV.", or "A.".
+var headingNumber = regexp.MustCompile(`^(?:\d+(?:\.\d+)*\.?|[ivxlc]+\.|[a-z]\.)\s+`)
+
+// normalizeHeading reduces a heading to its lower-case title words, so
+// "3.2 Training Setup" and "Training setup" compare equal.
+func normalizeHeading(heading string) string {
+	h := strings.Join(strings.Fields(strings.ToLower(heading)), " ")
+	h = headingNumber.ReplaceAllString(h, "")
+	return strings.TrimRight(h, ":.")
+}
+
+// containment returns the share of the item's shingles found in text.
+func containment(item, text map[string]bool) float64 {
+	if len(item) == 0 {
+		return 0
+	}
+	shared := 0
+	for k := range item {
+		if text[k] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(item))
+}
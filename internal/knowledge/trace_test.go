@@ -0,0 +1,73 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTraceItemFallbacks(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ctx := context.Background()
+	ingestHelper(t, store, tmpDir, "moved")
+
+	// Re-conversion numbered the headings and renamed "Background" and
+	// "Results".
+	writeMarkdown(t, tmpDir, "moved", `# Efficient Attention
+
+## 2 Preliminaries
+<!-- page 1 -->
+Transformers stack layers of attention and feed-forward blocks.
+
+## 3. METHOD
+<!-- page 2 -->
+Efficient attention reduces computation from O(n^2) to O(n log n).
+
+## 5 Experiments
+<!-- page 5 -->
+We evaluate on GLUE.
+
+Overall, our method achieves 89.2% accuracy on the GLUE benchmark,
+ahead of every baseline.
+`)
+
+	got, err := store.TraceItem(ctx, "moved-claim1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Match != TraceHeading || got.Heading != "3. METHOD" || !strings.Contains(got.Context, "O(n log n)") {
+		t.Errorf("claim trace = %+v, want the numbered Method heading", got)
+	}
+
+	got, err = store.TraceItem(ctx, "moved-result1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Match != TraceContent || got.Heading != "5 Experiments" || got.Similarity < minTraceSimilarity {
+		t.Errorf("result trace = %+v, want a content match under Experiments", got)
+	}
+	if !strings.Contains(got.Context, "89.2% accuracy") || strings.Contains(got.Context, "We evaluate") {
+		t.Errorf("result context = %q, want only the matching paragraph", got.Context)
+	}
+
+	// Neither a heading nor a paragraph resembles the definition.
+	if _, err := store.TraceItem(ctx, "moved-def1"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("err = %v, want the item not found in the Markdown", err)
+	}
+}
+
+func TestNormalizeHeading(t *testing.T) {
+	for _, tt := range []struct{ heading, want string }{
+		{"Method", "method"},
+		{"3 Method", "method"},
+		{"3.2. Training  Setup:", "training setup"},
+		{"IV. Results", "results"},
+		{"A. Proofs", "proofs"},
+	} {
+		if got := normalizeHeading(tt.heading); got != tt.want {
+			t.Errorf("normalizeHeading(%q) = %q, want %q", tt.heading, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,386 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// venueSchema creates the venues table (R8.1). A venue is identified by
+// its ISSN when known ("issn:1532-4435") and otherwise by its normalized
+// name ("name:neural information processing systems"). Rank and
+// rank_source come from the user's ranking files (R8.2).
+var venueSchema = []string{
+	`CREATE TABLE IF NOT EXISTS venues (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		name_key TEXT NOT NULL,
+		type TEXT,
+		issn TEXT,
+		rank TEXT,
+		rank_source TEXT
+	)`,
+	`CREATE INDEX IF NOT EXISTS venues_name_key ON venues(name_key)`,
+}
+
+// normalizeISSN strips hyphens and spaces so "1532-4435" and "15324435"
+// match.
+func normalizeISSN(issn string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(issn))
+}
+
+// setPaperVenue records the venue of paper, creating or merging its venues
+// row, and removes venues left without papers (R8.1).
+func setPaperVenue(ctx context.Context, tx *sql.Tx, paper *types.Paper) error {
+	var venueID any
+	if v := paper.Venue; v != nil && normalizeAuthorName(v.Name) != "" {
+		id, err := resolveVenue(ctx, tx, v)
+		if err != nil {
+			return err
+		}
+		venueID = id
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE papers SET venue_id = ? WHERE id = ?`, venueID, paper.ID); err != nil {
+		return fmt.Errorf("setting venue of %s: %w", paper.ID, err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM venues WHERE id NOT IN (SELECT venue_id FROM papers WHERE venue_id IS NOT NULL)`,
+	); err != nil {
+		return fmt.Errorf("removing orphaned venues: %w", err)
+	}
+	return nil
+}
+
+// resolveVenue returns the venues row for v: the venue with the same ISSN,
+// else the venue with the same normalized name, else a new one. A venue
+// first seen without an ISSN gains it, and one typed "other" gains a more
+// specific type.
+func resolveVenue(ctx context.Context, tx *sql.Tx, v *types.Venue) (string, error) {
+	nameKey := normalizeAuthorName(v.Name)
+	issn := normalizeISSN(v.ISSN)
+
+	var id string
+	err := sql.ErrNoRows
+	if issn != "" {
+		err = tx.QueryRowContext(ctx,
+			`SELECT id FROM venues WHERE REPLACE(issn, '-', '') = ?`, issn).Scan(&id)
+	}
+	if err == sql.ErrNoRows {
+		err = tx.QueryRowContext(ctx,
+			`SELECT id FROM venues WHERE name_key = ? ORDER BY id LIMIT 1`, nameKey).Scan(&id)
+	}
+	switch {
+	case err == sql.ErrNoRows:
+		id = "name:" + nameKey
+		if v.ISSN != "" {
+			id = "issn:" + v.ISSN
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO venues (id, name, name_key, type, issn) VALUES (?, ?, ?, ?, ?)`,
+			id, v.Name, nameKey, string(v.Type), v.ISSN,
+		); err != nil {
+			return "", fmt.Errorf("inserting venue %s: %w", v.Name, err)
+		}
+		return id, nil
+	case err != nil:
+		return "", fmt.Errorf("looking up venue %s: %w", v.Name, err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE venues SET
+			issn = CASE WHEN COALESCE(issn, '') = '' THEN ? ELSE issn END,
+			type = CASE WHEN COALESCE(type, '') IN ('', 'other') AND ? != '' THEN ? ELSE type END
+		WHERE id = ?`,
+		v.ISSN, string(v.Type), string(v.Type), id,
+	); err != nil {
+		return "", fmt.Errorf("updating venue %s: %w", v.Name, err)
+	}
+	return id, nil
+}
+
+// backfillVenues records the venues of papers that have none yet, such as
+// papers indexed before the venues table existed (R8.1).
+func (s *Store) backfillVenues(ctx context.Context, metaDir string) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT id FROM papers WHERE venue_id IS NULL`)
+	if err != nil {
+		return err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+	for _, id := range ids {
+		paper := loadPaperMetadata(metaDir, id)
+		if paper == nil || paper.Venue == nil {
+			continue
+		}
+		paper.ID = id
+		if err := setPaperVenue(ctx, tx, paper); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// VenueRanking is one entry of a venue ranking list (R8.2).
+type VenueRanking struct {
+	Title   string
+	Acronym string
+	ISSNs   []string
+	Rank    string
+	// Source names the ranking, such as "CORE2023" or "Scimago".
+	Source string
+}
+
+// LoadVenueRankings reads a ranking file in one of two formats: the CSV
+// export of the CORE conference portal (no header; title, acronym, source,
+// and rank in columns 2 to 5) or the semicolon-separated journal list
+// from Scimago (header row; Title, Issn, and SJR Best Quartile columns).
+func LoadVenueRankings(path string) ([]VenueRanking, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening venue rankings: %w", err)
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	first, err := br.Peek(512)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	head, _, _ := strings.Cut(string(first), "\n")
+	head = strings.TrimPrefix(head, "\ufeff")
+
+	var rankings []VenueRanking
+	if strings.Contains(head, ";") && strings.Contains(head, "Sourceid") {
+		rankings, err = parseScimago(br)
+	} else {
+		rankings, err = parseCORE(br)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return rankings, nil
+}
+
+func parseCORE(r io.Reader) ([]VenueRanking, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	var rankings []VenueRanking
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return rankings, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rec) < 5 {
+			line, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("line %d: expected CORE columns id, title, acronym, source, rank", line)
+		}
+		rankings = append(rankings, VenueRanking{
+			Title:   strings.TrimSpace(rec[1]),
+			Acronym: strings.TrimSpace(rec[2]),
+			Source:  strings.TrimSpace(rec[3]),
+			Rank:    strings.TrimSpace(rec[4]),
+		})
+	}
+}
+
+func parseScimago(r io.Reader) ([]VenueRanking, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = ';'
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	col := make(map[string]int)
+	for i, name := range header {
+		col[strings.TrimPrefix(strings.TrimSpace(name), "\ufeff")] = i
+	}
+	titleCol, ok1 := col["Title"]
+	issnCol, ok2 := col["Issn"]
+	quartileCol, ok3 := col["SJR Best Quartile"]
+	if !ok1 || !ok2 || !ok3 {
+		return nil, fmt.Errorf("header lacks the Scimago Title, Issn, or SJR Best Quartile column")
+	}
+
+	var rankings []VenueRanking
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return rankings, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rec) <= max(titleCol, issnCol, quartileCol) {
+			continue
+		}
+		rank := strings.TrimSpace(rec[quartileCol])
+		if rank == "" || rank == "-" {
+			continue
+		}
+		var issns []string
+		for _, issn := range strings.Split(rec[issnCol], ",") {
+			if issn = strings.TrimSpace(issn); issn != "" && issn != "-" {
+				issns = append(issns, issn)
+			}
+		}
+		rankings = append(rankings, VenueRanking{
+			Title:  strings.TrimSpace(rec[titleCol]),
+			ISSNs:  issns,
+			Rank:   rank,
+			Source: "Scimago",
+		})
+	}
+}
+
+// ApplyVenueRankings sets the rank of every venue that matches an entry
+// of rankings by ISSN, by normalized title, or by acronym, and clears the
+// rank of the rest (R8.2). Earlier entries win. It returns the number of
+// venues ranked.
+func (s *Store) ApplyVenueRankings(ctx context.Context, rankings []VenueRanking) (int, error) {
+	byISSN := make(map[string]*VenueRanking)
+	byName := make(map[string]*VenueRanking)
+	for i := range rankings {
+		r := &rankings[i]
+		for _, issn := range r.ISSNs {
+			if key := normalizeISSN(issn); byISSN[key] == nil {
+				byISSN[key] = r
+			}
+		}
+		for _, name := range []string{r.Title, r.Acronym} {
+			if key := normalizeAuthorName(name); key != "" && byName[key] == nil {
+				byName[key] = r
+			}
+		}
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name_key, COALESCE(issn, '') FROM venues`)
+	if err != nil {
+		return 0, fmt.Errorf("reading venues: %w", err)
+	}
+	type venueRow struct{ id, nameKey, issn string }
+	var venues []venueRow
+	for rows.Next() {
+		var v venueRow
+		if err := rows.Scan(&v.id, &v.nameKey, &v.issn); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		venues = append(venues, v)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+	ranked := 0
+	for _, v := range venues {
+		r := byISSN[normalizeISSN(v.issn)]
+		if v.issn == "" || r == nil {
+			r = byName[v.nameKey]
+		}
+		var rank, source any
+		if r != nil {
+			rank, source = r.Rank, r.Source
+			ranked++
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE venues SET rank = ?, rank_source = ? WHERE id = ?`, rank, source, v.id,
+		); err != nil {
+			return 0, fmt.Errorf("ranking venue %s: %w", v.id, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+	return ranked, nil
+}
+
+// rankVenues applies the store's ranking files and reports how many
+// venues they ranked. A file that cannot be read is a warning.
+func (s *Store) rankVenues(ctx context.Context, w io.Writer) {
+	var rankings []VenueRanking
+	for _, path := range s.venueRankings {
+		r, err := LoadVenueRankings(path)
+		if err != nil {
+			fmt.Fprintf(w, "warning: %v\n", err)
+			continue
+		}
+		rankings = append(rankings, r...)
+	}
+	ranked, err := s.ApplyVenueRankings(ctx, rankings)
+	if err != nil {
+		fmt.Fprintf(w, "warning: ranking venues: %v\n", err)
+		return
+	}
+	fmt.Fprintf(w, "ranked %d venue(s) from %d ranking entries\n", ranked, len(rankings))
+}
+
+// VenueStat counts the papers published in one venue (R8.3).
+type VenueStat struct {
+	Venue      string `json:"venue"`
+	Type       string `json:"type,omitempty"`
+	ISSN       string `json:"issn,omitempty"`
+	Rank       string `json:"rank,omitempty"`
+	RankSource string `json:"rank_source,omitempty"`
+	Papers     int    `json:"papers"`
+}
+
+// VenueStats returns the number of papers per venue, most papers first.
+func (s *Store) VenueStats(ctx context.Context) ([]VenueStat, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT v.name, COALESCE(v.type, ''), COALESCE(v.issn, ''), COALESCE(v.rank, ''),
+			COALESCE(v.rank_source, ''), COUNT(p.id)
+		FROM venues v JOIN papers p ON p.venue_id = v.id
+		GROUP BY v.id
+		ORDER BY COUNT(p.id) DESC, v.name`)
+	if err != nil {
+		return nil, fmt.Errorf("counting venues: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []VenueStat
+	for rows.Next() {
+		var st VenueStat
+		if err := rows.Scan(&st.Venue, &st.Type, &st.ISSN, &st.Rank, &st.RankSource, &st.Papers); err != nil {
+			return nil, err
+		}
+		stats = append(stats, st)
+	}
+	return stats, rows.Err()
+}
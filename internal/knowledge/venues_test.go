@@ -0,0 +1,125 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestLoadVenueRankings(t *testing.T) {
+	dir := t.TempDir()
+	core := filepath.Join(dir, "core.csv")
+	coreCSV := `"1","Advances in Neural Information Processing Systems","NeurIPS","CORE2023","A*","4611","Yes","No","No"
+"2","Workshop on Machine Learning Systems","MLSys-W","CORE2023","C","4612","No","No","No"
+`
+	scimago := filepath.Join(dir, "scimago.csv")
+	scimagoCSV := "\ufeffRank;Sourceid;Title;Type;Issn;SJR;SJR Best Quartile\n" +
+		`1;21100;"Journal of Machine Learning Research";journal;"15337928, 15324435";"5,1";Q1` + "\n" +
+		`2;21101;"Unranked Letters";journal;"12345678";"0,1";-` + "\n"
+	for path, content := range map[string]string{core: coreCSV, scimago: scimagoCSV} {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	coreRanks, err := LoadVenueRankings(core)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coreRanks) != 2 || coreRanks[0].Acronym != "NeurIPS" || coreRanks[0].Rank != "A*" || coreRanks[0].Source != "CORE2023" {
+		t.Errorf("CORE rankings = %+v", coreRanks)
+	}
+
+	journalRanks, err := LoadVenueRankings(scimago)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(journalRanks) != 1 {
+		t.Fatalf("Scimago rankings = %+v, want the one ranked journal", journalRanks)
+	}
+	jr := journalRanks[0]
+	if jr.Title != "Journal of Machine Learning Research" || jr.Rank != "Q1" || jr.Source != "Scimago" || len(jr.ISSNs) != 2 {
+		t.Errorf("Scimago ranking = %+v", jr)
+	}
+}
+
+func TestIngestVenuesAndStats(t *testing.T) {
+	store, tmpDir := testSetup(t)
+
+	jmlr := &types.Venue{Name: "Journal of Machine Learning Research", Type: types.VenueJournal, ISSN: "1532-4435"}
+	papers := []types.Paper{samplePaper("1111.00001"), samplePaper("2222.00002"), samplePaper("3333.00003"), samplePaper("4444.00004")}
+	papers[0].Venue = jmlr
+	// The same journal without its ISSN merges by name.
+	papers[1].Venue = &types.Venue{Name: "Journal of Machine Learning Research.", Type: types.VenueOther}
+	papers[2].Venue = &types.Venue{Name: "NeurIPS", Type: types.VenueConference}
+	papers[3].Venue = &types.Venue{Name: "arXiv", Type: types.VenuePreprint}
+	for _, p := range papers {
+		writeExtraction(t, tmpDir, p.ID, sampleItems(p.ID))
+		writePaperMeta(t, tmpDir, p)
+	}
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	ranked, err := store.ApplyVenueRankings(context.Background(), []VenueRanking{
+		{Title: "Advances in Neural Information Processing Systems", Acronym: "NeurIPS", Rank: "A*", Source: "CORE2023"},
+		{Title: "J. Mach. Learn. Res.", ISSNs: []string{"15324435"}, Rank: "Q1", Source: "Scimago"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ranked != 2 {
+		t.Errorf("ranked %d venues, want 2", ranked)
+	}
+
+	stats, err := store.VenueStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 3 {
+		t.Fatalf("VenueStats = %+v, want 3 venues", stats)
+	}
+	first := stats[0]
+	if first.Venue != "Journal of Machine Learning Research" || first.Papers != 2 || first.Type != "journal" || first.Rank != "Q1" {
+		t.Errorf("first venue = %+v", first)
+	}
+	for _, st := range stats[1:] {
+		if st.Venue == "NeurIPS" && (st.Rank != "A*" || st.RankSource != "CORE2023") {
+			t.Errorf("NeurIPS = %+v, want CORE A*", st)
+		}
+		if st.Venue == "arXiv" && st.Rank != "" {
+			t.Errorf("arXiv should be unranked, got %+v", st)
+		}
+	}
+
+	corpus, err := store.Stats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if corpus.Venues != 3 || corpus.PapersByVenueType["journal"] != 2 || corpus.PapersByVenueType["preprint"] != 1 {
+		t.Errorf("Stats venues = %d, by type %v", corpus.Venues, corpus.PapersByVenueType)
+	}
+
+	// Removing a paper's venue drops the venue once no paper uses it.
+	papers[3].Venue = nil
+	tx, err := store.db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := setPaperVenue(context.Background(), tx, &papers[3]); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if stats, _ := store.VenueStats(context.Background()); len(stats) != 2 {
+		t.Errorf("after removing arXiv, VenueStats = %+v", stats)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// VersionPolicy selects which member of a linked preprint/published set is
+// the canonical version reported by retrieval and export.
+type VersionPolicy string
+
+const (
+	// PolicyPublished prefers the published (DOI) version.
+	PolicyPublished VersionPolicy = "published"
+
+	// PolicyPreprint prefers the arXiv preprint.
+	PolicyPreprint VersionPolicy = "preprint"
+)
+
+// ParseVersionPolicy validates a version policy name. The empty string
+// selects PolicyPublished.
+func ParseVersionPolicy(name string) (VersionPolicy, error) {
+	switch VersionPolicy(strings.ToLower(strings.TrimSpace(name))) {
+	case "", PolicyPublished:
+		return PolicyPublished, nil
+	case PolicyPreprint:
+		return PolicyPreprint, nil
+	default:
+		return "", fmt.Errorf("unknown version policy %q: use published or preprint", name)
+	}
+}
+
+// PaperVersion is one paper record in a linked version set.
+type PaperVersion struct {
+	ID        string `json:"id" yaml:"id"`
+	DOI       string `json:"doi,omitempty" yaml:"doi,omitempty"`
+	ArxivID   string `json:"arxiv_id,omitempty" yaml:"arxiv_id,omitempty"`
+	PDFPath   string `json:"pdf_path,omitempty" yaml:"pdf_path,omitempty"`
+	Preprint  bool   `json:"preprint" yaml:"preprint"`
+	Canonical bool   `json:"canonical" yaml:"canonical"`
+}
+
+// isPreprint reports whether a paper record is the arXiv copy of its work:
+// arXiv acquisitions use the arXiv ID (possibly versioned) as paper ID,
+// with the slash of pre-2007 IDs ("hep-th/9901001") replaced by a hyphen.
+func isPreprint(id, arxivID string) bool {
+	return arxivID != "" && strings.HasPrefix(id, strings.ReplaceAll(arxivID, "/", "-"))
+}
+
+// LinkVersions groups papers that share an arXiv ID or DOI into version
+// sets and records the canonical member of each set in papers.canonical_id
+// according to the store's version policy. Papers that are not linked have
+// canonical_id cleared. It returns the number of sets with two or more
+// members. Items from every member stay indexed, so both PDFs remain
+// reachable through their own paper IDs.
+func (s *Store) LinkVersions(ctx context.Context) (int, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, COALESCE(doi, ''), COALESCE(arxiv_id, '') FROM papers ORDER BY id`)
+	if err != nil {
+		return 0, fmt.Errorf("reading papers for version linking: %w", err)
+	}
+	var papers []PaperVersion
+	for rows.Next() {
+		var v PaperVersion
+		if err := rows.Scan(&v.ID, &v.DOI, &v.ArxivID); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scanning paper: %w", err)
+		}
+		v.Preprint = isPreprint(v.ID, v.ArxivID)
+		papers = append(papers, v)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("reading papers for version linking: %w", err)
+	}
+
+	// Union papers that share a normalized identifier.
+	parent := make([]int, len(papers))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	owner := make(map[string]int)
+	for i, p := range papers {
+		var keys []string
+		if p.DOI != "" {
+			keys = append(keys, "doi:"+strings.ToLower(p.DOI))
+		}
+		if p.ArxivID != "" {
+			keys = append(keys, "arxiv:"+p.ArxivID)
+		}
+		for _, key := range keys {
+			if j, ok := owner[key]; ok {
+				parent[find(i)] = find(j)
+			} else {
+				owner[key] = i
+			}
+		}
+	}
+
+	sets := make(map[int][]int)
+	for i := range papers {
+		root := find(i)
+		sets[root] = append(sets[root], i)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	linked := 0
+	for _, members := range sets {
+		canonical := sql.NullString{}
+		if len(members) > 1 {
+			linked++
+			canonical = sql.NullString{String: papers[s.pickCanonical(papers, members)].ID, Valid: true}
+		}
+		for _, i := range members {
+			if _, err := tx.ExecContext(ctx,
+				`UPDATE papers SET canonical_id = ? WHERE id = ?`, canonical, papers[i].ID,
+			); err != nil {
+				return 0, fmt.Errorf("linking %s: %w", papers[i].ID, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing version links: %w", err)
+	}
+	return linked, nil
+}
+
+// pickCanonical returns the index of the member the policy prefers. Ties
+// go to the lowest paper ID so the choice is stable across runs.
+func (s *Store) pickCanonical(papers []PaperVersion, members []int) int {
+	wantPreprint := s.policy == PolicyPreprint
+	best := -1
+	for _, i := range members {
+		if best < 0 {
+			best = i
+			continue
+		}
+		bestMatch := papers[best].Preprint == wantPreprint
+		match := papers[i].Preprint == wantPreprint
+		if match && !bestMatch || match == bestMatch && papers[i].ID < papers[best].ID {
+			best = i
+		}
+	}
+	return best
+}
+
+// Versions returns every paper record in the version set containing
+// paperID, canonical first. A paper that is not linked returns itself.
+func (s *Store) Versions(ctx context.Context, paperID string) ([]PaperVersion, error) {
+	var canonical sql.NullString
+	err := s.db.QueryRowContext(ctx,
+		`SELECT canonical_id FROM papers WHERE id = ?`, paperID,
+	).Scan(&canonical)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("paper %s not found", paperID)
+		}
+		return nil, fmt.Errorf("looking up paper: %w", err)
+	}
+
+	query := `SELECT id, COALESCE(doi, ''), COALESCE(arxiv_id, ''), COALESCE(pdf_path, ''),
+		COALESCE(canonical_id, '') FROM papers WHERE id = ?`
+	key := paperID
+	if canonical.Valid {
+		query = `SELECT id, COALESCE(doi, ''), COALESCE(arxiv_id, ''), COALESCE(pdf_path, ''),
+			COALESCE(canonical_id, '') FROM papers WHERE canonical_id = ?`
+		key = canonical.String
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, key)
+	if err != nil {
+		return nil, fmt.Errorf("querying versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []PaperVersion
+	for rows.Next() {
+		var (
+			v           PaperVersion
+			canonicalID string
+		)
+		if err := rows.Scan(&v.ID, &v.DOI, &v.ArxivID, &v.PDFPath, &canonicalID); err != nil {
+			return nil, fmt.Errorf("scanning version: %w", err)
+		}
+		v.Preprint = isPreprint(v.ID, v.ArxivID)
+		v.Canonical = canonicalID == "" || canonicalID == v.ID
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		if versions[i].Canonical != versions[j].Canonical {
+			return versions[i].Canonical
+		}
+		return versions[i].ID < versions[j].ID
+	})
+	return versions, nil
+}
@@ -0,0 +1,171 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package knowledge
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// ingestVersionPair indexes an arXiv preprint and its published DOI version.
+func ingestVersionPair(t *testing.T, store *Store, tmpDir string) {
+	t.Helper()
+	preprint := types.Paper{
+		ID: "2301.07041v2", Title: "Efficient Attention (preprint)",
+		ArxivID: "2301.07041", DOI: "10.1145/1234567.1234568",
+		PDFPath: "papers/raw/2301.07041v2.pdf",
+	}
+	published := types.Paper{
+		ID: "10.1145-1234567.1234568", Title: "Efficient Attention",
+		Authors: []string{"Smith, J."}, DOI: "10.1145/1234567.1234568",
+		PDFPath: "papers/raw/10.1145-1234567.1234568.pdf",
+	}
+	for _, p := range []types.Paper{preprint, published} {
+		writeExtraction(t, tmpDir, p.ID, sampleItems(p.ID))
+		writePaperMeta(t, tmpDir, p)
+	}
+	writePaperMeta(t, tmpDir, samplePaper("unrelated"))
+	writeExtraction(t, tmpDir, "unrelated", sampleItems("unrelated"))
+
+	var buf strings.Builder
+	if _, err := store.Ingest(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "linked 1 preprint/published version set(s)") {
+		t.Errorf("ingest output missing link summary:\n%s", buf.String())
+	}
+}
+
+func TestParseVersionPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    VersionPolicy
+		wantErr bool
+	}{
+		{"", PolicyPublished, false},
+		{"published", PolicyPublished, false},
+		{" Preprint ", PolicyPreprint, false},
+		{"newest", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseVersionPolicy(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseVersionPolicy(%q) = %q, %v; want %q, err=%v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestIsPreprint(t *testing.T) {
+	tests := []struct {
+		id, arxivID string
+		want        bool
+	}{
+		{"2301.07041v2", "2301.07041", true},
+		{"hep-th-9901001", "hep-th/9901001", true},
+		{"10.1145-abc", "2301.07041", false},
+		{"2301.07041", "", false},
+	}
+	for _, tt := range tests {
+		if got := isPreprint(tt.id, tt.arxivID); got != tt.want {
+			t.Errorf("isPreprint(%q, %q) = %v, want %v", tt.id, tt.arxivID, got, tt.want)
+		}
+	}
+}
+
+func TestLinkVersionsPublishedPolicy(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestVersionPair(t, store, tmpDir)
+
+	results, err := store.Retrieve(context.Background(), QueryOptions{PaperID: "2301.07041v2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) == 0 {
+		t.Fatal("expected results for preprint")
+	}
+	r := results[0]
+	if r.CanonicalID != "10.1145-1234567.1234568" {
+		t.Errorf("CanonicalID = %q, want published version", r.CanonicalID)
+	}
+	if r.PaperTitle != "Efficient Attention" {
+		t.Errorf("PaperTitle = %q, want canonical title", r.PaperTitle)
+	}
+	if r.PaperDOI != "10.1145/1234567.1234568" {
+		t.Errorf("PaperDOI = %q", r.PaperDOI)
+	}
+
+	unrelated, err := store.Retrieve(context.Background(), QueryOptions{PaperID: "unrelated"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unrelated) == 0 || unrelated[0].CanonicalID != "" {
+		t.Errorf("unlinked paper should have no canonical ID: %+v", unrelated)
+	}
+}
+
+func TestLinkVersionsPreprintPolicy(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	store.policy = PolicyPreprint
+	ingestVersionPair(t, store, tmpDir)
+
+	versions, err := store.Versions(context.Background(), "10.1145-1234567.1234568")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("got %d versions, want 2", len(versions))
+	}
+	if versions[0].ID != "2301.07041v2" || !versions[0].Canonical || !versions[0].Preprint {
+		t.Errorf("first version = %+v, want canonical preprint", versions[0])
+	}
+	if versions[1].Canonical || versions[1].PDFPath == "" {
+		t.Errorf("second version = %+v, want non-canonical with PDF path", versions[1])
+	}
+}
+
+func TestVersionsUnlinkedPaper(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestHelper(t, store, tmpDir, "paper-a")
+
+	versions, err := store.Versions(context.Background(), "paper-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 1 || !versions[0].Canonical {
+		t.Errorf("versions = %+v, want the paper itself", versions)
+	}
+
+	if _, err := store.Versions(context.Background(), "missing"); err == nil {
+		t.Error("expected error for unknown paper")
+	}
+}
+
+func TestExportListsVersions(t *testing.T) {
+	store, tmpDir := testSetup(t)
+	ingestVersionPair(t, store, tmpDir)
+
+	if err := store.ExportJSON(context.Background(), QueryOptions{PaperID: "2301.07041v2"}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, "knowledge", indexDir, "export.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []ExportEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) == 0 || entries[0].Paper == nil {
+		t.Fatal("expected entries with paper metadata")
+	}
+	p := entries[0].Paper
+	if p.CanonicalID != "10.1145-1234567.1234568" || len(p.Versions) != 2 {
+		t.Errorf("paper = %+v, want canonical published with two versions", p)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+// Package review supports systematic reviews. It derives the PRISMA flow
+// (identification, screening, eligibility, inclusion) from the artifacts
+// the pipeline already writes: saved query files with their triage
+// decisions, acquired papers, and extraction results.
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pdiddy/research-engine/internal/acquire"
+	"github.com/pdiddy/research-engine/internal/search"
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// Flow holds the PRISMA 2020 flow counts for a review.
+type Flow struct {
+	// Identified counts search records before deduplication, per query
+	// file and across all of them.
+	Identified int `json:"identified"`
+	// BySource counts identified records per search backend. A record
+	// several backends returned counts once for each.
+	BySource map[string]int `json:"by_source"`
+	// DuplicatesRemoved counts records merged within a search run or found
+	// by more than one query file.
+	DuplicatesRemoved int `json:"duplicates_removed"`
+
+	// Screened counts unique records on title and abstract.
+	Screened int `json:"screened"`
+	// ExcludedAtScreening counts records with a reject decision.
+	ExcludedAtScreening int `json:"excluded_at_screening"`
+	// AwaitingScreening counts records without a decision yet.
+	AwaitingScreening int `json:"awaiting_screening"`
+
+	// SoughtForRetrieval counts records kept at screening.
+	SoughtForRetrieval int `json:"sought_for_retrieval"`
+	// NotRetrieved counts kept records without an acquired full text.
+	NotRetrieved int `json:"not_retrieved"`
+	// AssessedForEligibility counts kept records whose full text was
+	// acquired.
+	AssessedForEligibility int `json:"assessed_for_eligibility"`
+
+	// NotIncluded counts acquired papers not (yet) extracted into the
+	// knowledge base.
+	NotIncluded int `json:"not_included"`
+	// Included counts acquired papers with knowledge items extracted.
+	Included int `json:"included"`
+}
+
+// record is one deduplicated search result across query files.
+type record struct {
+	id     string
+	status string
+}
+
+// ComputeFlow derives the PRISMA flow from query files and the papers and
+// knowledge directories. Records are deduplicated across files by their
+// acquisition ID; a record kept in any file counts as kept.
+func ComputeFlow(queryFiles []*search.QueryFile, papersDir, knowledgeDir string) Flow {
+	f := Flow{BySource: make(map[string]int)}
+
+	var records []*record
+	byKey := make(map[string]*record)
+	for _, qf := range queryFiles {
+		f.Identified += len(qf.Results) + qf.Summary.DuplicatesRemoved
+		f.DuplicatesRemoved += qf.Summary.DuplicatesRemoved
+		for _, r := range qf.Results {
+			for _, src := range strings.Split(r.Source, ",") {
+				if src = strings.TrimSpace(src); src != "" {
+					f.BySource[src]++
+				}
+			}
+			id := r.PreferredAcquisitionID
+			if id == "" {
+				id = r.Identifier
+			}
+			key := strings.ToLower(strings.TrimSpace(id))
+			if key == "" {
+				key = strings.ToLower(r.Title)
+			}
+			rec := byKey[key]
+			if rec == nil {
+				rec = &record{id: id}
+				byKey[key] = rec
+				records = append(records, rec)
+			} else {
+				f.DuplicatesRemoved++
+			}
+			rec.status = mergeStatus(rec.status, r.Triage)
+		}
+	}
+
+	f.Screened = len(records)
+	for _, rec := range records {
+		switch rec.status {
+		case search.TriageReject:
+			f.ExcludedAtScreening++
+			continue
+		case search.TriageKeep:
+		default:
+			f.AwaitingScreening++
+			continue
+		}
+		f.SoughtForRetrieval++
+		paperID, ok := acquiredPaper(papersDir, rec.id)
+		if !ok {
+			f.NotRetrieved++
+			continue
+		}
+		f.AssessedForEligibility++
+		if extracted(knowledgeDir, paperID) {
+			f.Included++
+		} else {
+			f.NotIncluded++
+		}
+	}
+	return f
+}
+
+// mergeStatus combines the decisions on copies of a record: keep wins over
+// reject, and any decision over none.
+func mergeStatus(current string, t *types.Triage) string {
+	if t == nil || t.Status == "" || current == search.TriageKeep {
+		return current
+	}
+	return t.Status
+}
+
+// acquiredPaper returns the ID of the paper acquired for an identifier,
+// following duplicate links, and whether its PDF is on disk.
+func acquiredPaper(papersDir, identifier string) (string, bool) {
+	idType, normalized := acquire.Classify(identifier)
+	if idType == acquire.TypeUnknown {
+		return "", false
+	}
+	paperID := acquire.Slug(idType, normalized)
+	if p, err := acquire.LoadPaper(papersDir, paperID); err == nil && p.DuplicateOf != "" {
+		paperID = p.DuplicateOf
+	}
+	_, err := os.Stat(filepath.Join(papersDir, "raw", paperID+".pdf"))
+	return paperID, err == nil
+}
+
+// extracted reports whether knowledge items were extracted for paperID.
+func extracted(knowledgeDir, paperID string) bool {
+	_, err := os.Stat(filepath.Join(knowledgeDir, "extracted", paperID+"-items.yaml"))
+	return err == nil
+}
+
+// FormatText writes the flow as an indented PRISMA summary.
+func FormatText(f Flow, w io.Writer) {
+	fmt.Fprintln(w, "Identification")
+	fmt.Fprintf(w, "  Records identified:              %d\n", f.Identified)
+	for _, src := range sortedKeys(f.BySource) {
+		fmt.Fprintf(w, "    %-30s %d\n", src, f.BySource[src])
+	}
+	fmt.Fprintf(w, "  Duplicates removed:              %d\n", f.DuplicatesRemoved)
+	fmt.Fprintln(w, "Screening")
+	fmt.Fprintf(w, "  Records screened:                %d\n", f.Screened)
+	fmt.Fprintf(w, "  Records excluded:                %d\n", f.ExcludedAtScreening)
+	fmt.Fprintf(w, "  Awaiting screening:              %d\n", f.AwaitingScreening)
+	fmt.Fprintln(w, "Eligibility")
+	fmt.Fprintf(w, "  Reports sought for retrieval:    %d\n", f.SoughtForRetrieval)
+	fmt.Fprintf(w, "  Reports not retrieved:           %d\n", f.NotRetrieved)
+	fmt.Fprintf(w, "  Reports assessed for eligibility: %d\n", f.AssessedForEligibility)
+	fmt.Fprintf(w, "  Reports not yet included:        %d\n", f.NotIncluded)
+	fmt.Fprintln(w, "Included")
+	fmt.Fprintf(w, "  Studies included:                %d\n", f.Included)
+}
+
+// FormatJSON writes the flow as indented JSON.
+func FormatJSON(f Flow, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(f)
+}
+
+// FormatMermaid writes the flow as a Mermaid flowchart in the PRISMA 2020
+// layout, ready to paste into Markdown.
+func FormatMermaid(f Flow, w io.Writer) {
+	var sources []string
+	for _, src := range sortedKeys(f.BySource) {
+		sources = append(sources, fmt.Sprintf("%s: %d", src, f.BySource[src]))
+	}
+	identified := fmt.Sprintf("Records identified: %d", f.Identified)
+	if len(sources) > 0 {
+		identified += "<br/>" + strings.Join(sources, "<br/>")
+	}
+
+	fmt.Fprintln(w, "flowchart TD")
+	fmt.Fprintf(w, "    id[\"%s\"] --> dup[\"Duplicates removed: %d\"]\n", identified, f.DuplicatesRemoved)
+	fmt.Fprintf(w, "    id --> scr[\"Records screened: %d\"]\n", f.Screened)
+	fmt.Fprintf(w, "    scr --> exc[\"Records excluded: %d\"]\n", f.ExcludedAtScreening)
+	fmt.Fprintf(w, "    scr --> ret[\"Reports sought for retrieval: %d\"]\n", f.SoughtForRetrieval)
+	fmt.Fprintf(w, "    ret --> nret[\"Reports not retrieved: %d\"]\n", f.NotRetrieved)
+	fmt.Fprintf(w, "    ret --> elig[\"Reports assessed for eligibility: %d\"]\n", f.AssessedForEligibility)
+	fmt.Fprintf(w, "    elig --> ninc[\"Reports not yet included: %d\"]\n", f.NotIncluded)
+	fmt.Fprintf(w, "    elig --> inc[\"Studies included: %d\"]\n", f.Included)
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
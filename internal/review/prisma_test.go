@@ -0,0 +1,91 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package review
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/internal/search"
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func result(id, source, status string) types.SearchResult {
+	r := types.SearchResult{Identifier: id, PreferredAcquisitionID: id, Title: "Paper " + id, Source: source}
+	if status != "" {
+		r.Triage = &types.Triage{Status: status}
+	}
+	return r
+}
+
+func TestComputeFlow(t *testing.T) {
+	dir := t.TempDir()
+	papersDir := filepath.Join(dir, "papers")
+	knowledgeDir := filepath.Join(dir, "knowledge")
+	for _, f := range []string{
+		"papers/raw/2301.00001.pdf",
+		"papers/raw/10.1234-kept.pdf",
+		"knowledge/extracted/2301.00001-items.yaml",
+	} {
+		p := filepath.Join(dir, f)
+		os.MkdirAll(filepath.Dir(p), 0o755)
+		if err := os.WriteFile(p, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	first := &search.QueryFile{
+		Results: []types.SearchResult{
+			result("2301.00001", "arxiv,openalex", search.TriageKeep),
+			result("10.1234/kept", "openalex", search.TriageKeep),
+			result("10.1234/missing", "semantic_scholar", search.TriageKeep),
+			result("10.1234/rejected", "openalex", search.TriageReject),
+			result("10.1234/open", "arxiv", ""),
+		},
+		Summary: search.QuerySummary{DuplicatesRemoved: 2},
+	}
+	second := &search.QueryFile{
+		Results: []types.SearchResult{
+			result("10.1234/REJECTED", "semantic_scholar", ""),
+			result("10.1234/open", "openalex", search.TriageReject),
+		},
+	}
+
+	got := ComputeFlow([]*search.QueryFile{first, second}, papersDir, knowledgeDir)
+	want := Flow{
+		Identified:             9,
+		BySource:               map[string]int{"arxiv": 2, "openalex": 4, "semantic_scholar": 2},
+		DuplicatesRemoved:      4,
+		Screened:               5,
+		ExcludedAtScreening:    2,
+		AwaitingScreening:      0,
+		SoughtForRetrieval:     3,
+		NotRetrieved:           1,
+		AssessedForEligibility: 2,
+		NotIncluded:            1,
+		Included:               1,
+	}
+	if got.Identified != want.Identified || got.DuplicatesRemoved != want.DuplicatesRemoved ||
+		got.Screened != want.Screened || got.ExcludedAtScreening != want.ExcludedAtScreening ||
+		got.AwaitingScreening != want.AwaitingScreening || got.SoughtForRetrieval != want.SoughtForRetrieval ||
+		got.NotRetrieved != want.NotRetrieved || got.AssessedForEligibility != want.AssessedForEligibility ||
+		got.NotIncluded != want.NotIncluded || got.Included != want.Included {
+		t.Errorf("ComputeFlow =\n%+v\nwant\n%+v", got, want)
+	}
+	for src, n := range want.BySource {
+		if got.BySource[src] != n {
+			t.Errorf("BySource[%s] = %d, want %d", src, got.BySource[src], n)
+		}
+	}
+
+	var buf bytes.Buffer
+	FormatMermaid(got, &buf)
+	for _, s := range []string{"flowchart TD", "Records identified: 9", "Studies included: 1"} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("mermaid output missing %q:\n%s", s, buf.String())
+		}
+	}
+}
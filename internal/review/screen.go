@@ -0,0 +1,345 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package review
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pdiddy/research-engine/internal/search"
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// Screening decisions. Include maps to the keep triage status and exclude
+// to reject, so consensus decisions feed the acquisition queue and the
+// PRISMA flow.
+const (
+	DecisionInclude = "include"
+	DecisionExclude = "exclude"
+)
+
+// ParseDecision validates a screening decision.
+func ParseDecision(decision string) (string, error) {
+	d := strings.ToLower(strings.TrimSpace(decision))
+	if d != DecisionInclude && d != DecisionExclude {
+		return "", fmt.Errorf("invalid decision %q: use include or exclude", decision)
+	}
+	return d, nil
+}
+
+// triageStatusFor maps a screening decision to its triage status.
+func triageStatusFor(decision string) string {
+	if decision == DecisionInclude {
+		return search.TriageKeep
+	}
+	return search.TriageReject
+}
+
+// Pending returns the results reviewer has not screened yet, in rank order.
+// Results whose conflict was resolved are not pending.
+func Pending(qf *search.QueryFile, reviewer string) []search.RankedResult {
+	var out []search.RankedResult
+	for i, r := range qf.Results {
+		if r.Triage != nil && r.Triage.Resolved {
+			continue
+		}
+		if decisionBy(r.Triage, reviewer) != "" {
+			continue
+		}
+		out = append(out, search.RankedResult{Rank: i + 1, Result: r})
+	}
+	return out
+}
+
+// Decide records reviewer's decision on the result identified by id,
+// replacing the reviewer's earlier decision, and updates the result's
+// triage status: keep or reject once required reviewers agree, empty while
+// fewer have screened it or they disagree. A resolved status is kept.
+func Decide(qf *search.QueryFile, id, reviewer, decision, note string, required int, now time.Time) (*types.SearchResult, error) {
+	reviewer = strings.TrimSpace(reviewer)
+	if reviewer == "" {
+		return nil, fmt.Errorf("reviewer name is required")
+	}
+	r, err := qf.Result(id)
+	if err != nil {
+		return nil, err
+	}
+	if r.Triage == nil {
+		r.Triage = &types.Triage{}
+	}
+	t := r.Triage
+	d := types.ScreeningDecision{Reviewer: reviewer, Decision: decision, Note: note, Updated: now}
+	replaced := false
+	for i := range t.Screening {
+		if strings.EqualFold(t.Screening[i].Reviewer, reviewer) {
+			t.Screening[i] = d
+			replaced = true
+		}
+	}
+	if !replaced {
+		t.Screening = append(t.Screening, d)
+	}
+	if !t.Resolved {
+		t.Status = consensus(t.Screening, required)
+		t.Updated = now
+	}
+	return r, nil
+}
+
+// consensus returns the triage status the screening decisions agree on
+// when at least required reviewers decided, else "".
+func consensus(decisions []types.ScreeningDecision, required int) string {
+	if len(decisions) == 0 || len(decisions) < required {
+		return ""
+	}
+	first := decisions[0].Decision
+	for _, d := range decisions[1:] {
+		if d.Decision != first {
+			return ""
+		}
+	}
+	return triageStatusFor(first)
+}
+
+// Resolve sets the final decision on a result, typically one the
+// reviewers disagreed on.
+func Resolve(qf *search.QueryFile, id, decision, note string, now time.Time) (*types.SearchResult, error) {
+	r, err := qf.Result(id)
+	if err != nil {
+		return nil, err
+	}
+	if r.Triage == nil {
+		r.Triage = &types.Triage{}
+	}
+	r.Triage.Status = triageStatusFor(decision)
+	r.Triage.Note = note
+	r.Triage.Resolved = true
+	r.Triage.Updated = now
+	return r, nil
+}
+
+// Conflicts returns the results whose reviewers disagree and that have not
+// been resolved, in rank order.
+func Conflicts(qf *search.QueryFile) []search.RankedResult {
+	var out []search.RankedResult
+	for i, r := range qf.Results {
+		if InConflict(r.Triage) {
+			out = append(out, search.RankedResult{Rank: i + 1, Result: r})
+		}
+	}
+	return out
+}
+
+// InConflict reports whether reviewers disagree on a result that has not
+// been resolved.
+func InConflict(t *types.Triage) bool {
+	if t == nil || t.Resolved {
+		return false
+	}
+	for _, d := range t.Screening {
+		if d.Decision != t.Screening[0].Decision {
+			return true
+		}
+	}
+	return false
+}
+
+// decisionBy returns reviewer's decision on a result, or "".
+func decisionBy(t *types.Triage, reviewer string) string {
+	if t == nil {
+		return ""
+	}
+	for _, d := range t.Screening {
+		if strings.EqualFold(d.Reviewer, reviewer) {
+			return d.Decision
+		}
+	}
+	return ""
+}
+
+// Reviewers returns the names of everyone who screened a result in qf,
+// sorted.
+func Reviewers(qf *search.QueryFile) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, r := range qf.Results {
+		if r.Triage == nil {
+			continue
+		}
+		for _, d := range r.Triage.Screening {
+			if key := strings.ToLower(d.Reviewer); !seen[key] {
+				seen[key] = true
+				names = append(names, d.Reviewer)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Agreement summarizes how two reviewers' decisions compare on the results
+// both screened.
+type Agreement struct {
+	ReviewerA string `json:"reviewer_a"`
+	ReviewerB string `json:"reviewer_b"`
+	// Both counts results screened by both reviewers.
+	Both        int `json:"both"`
+	BothInclude int `json:"both_include"`
+	BothExclude int `json:"both_exclude"`
+	// OnlyAInclude counts results A included and B excluded.
+	OnlyAInclude int `json:"only_a_include"`
+	// OnlyBInclude counts results B included and A excluded.
+	OnlyBInclude int `json:"only_b_include"`
+	// Observed is the proportion of results the reviewers agree on.
+	Observed float64 `json:"observed_agreement"`
+	// Kappa is Cohen's kappa: agreement corrected for chance. It is 1 when
+	// the reviewers agree on every result and NaN when neither screened
+	// any result in common.
+	Kappa float64 `json:"kappa"`
+}
+
+// ComputeAgreement compares reviewers a and b across the query files.
+func ComputeAgreement(queryFiles []*search.QueryFile, a, b string) Agreement {
+	ag := Agreement{ReviewerA: a, ReviewerB: b}
+	for _, qf := range queryFiles {
+		for _, r := range qf.Results {
+			da, db := decisionBy(r.Triage, a), decisionBy(r.Triage, b)
+			if da == "" || db == "" {
+				continue
+			}
+			ag.Both++
+			switch {
+			case da == DecisionInclude && db == DecisionInclude:
+				ag.BothInclude++
+			case da == DecisionExclude && db == DecisionExclude:
+				ag.BothExclude++
+			case da == DecisionInclude:
+				ag.OnlyAInclude++
+			default:
+				ag.OnlyBInclude++
+			}
+		}
+	}
+	if ag.Both == 0 {
+		ag.Kappa = math.NaN()
+		return ag
+	}
+
+	n := float64(ag.Both)
+	ag.Observed = float64(ag.BothInclude+ag.BothExclude) / n
+	aInclude := float64(ag.BothInclude+ag.OnlyAInclude) / n
+	bInclude := float64(ag.BothInclude+ag.OnlyBInclude) / n
+	expected := aInclude*bInclude + (1-aInclude)*(1-bInclude)
+	if expected == 1 {
+		ag.Kappa = 1
+		return ag
+	}
+	ag.Kappa = (ag.Observed - expected) / (1 - expected)
+	return ag
+}
+
+// FormatAgreement writes the two-by-two agreement table with observed
+// agreement and Cohen's kappa.
+func FormatAgreement(ag Agreement, w io.Writer) {
+	if ag.Both == 0 {
+		fmt.Fprintf(w, "%s and %s have not screened any result in common.\n", ag.ReviewerA, ag.ReviewerB)
+		return
+	}
+	rowA := [2]string{ag.ReviewerA + " include", ag.ReviewerA + " exclude"}
+	colB := [2]string{ag.ReviewerB + " include", ag.ReviewerB + " exclude"}
+	width := max(len(rowA[0]), len(rowA[1]))
+	fmt.Fprintf(w, "%-*s  %*s  %*s\n", width, "", len(colB[0]), colB[0], len(colB[1]), colB[1])
+	fmt.Fprintf(w, "%-*s  %*d  %*d\n", width, rowA[0], len(colB[0]), ag.BothInclude, len(colB[1]), ag.OnlyAInclude)
+	fmt.Fprintf(w, "%-*s  %*d  %*d\n", width, rowA[1], len(colB[0]), ag.OnlyBInclude, len(colB[1]), ag.BothExclude)
+	fmt.Fprintf(w, "\nScreened by both:    %d\n", ag.Both)
+	fmt.Fprintf(w, "Observed agreement:  %.1f%%\n", 100*ag.Observed)
+	fmt.Fprintf(w, "Cohen's kappa:       %.3f (%s)\n", ag.Kappa, kappaLabel(ag.Kappa))
+}
+
+// kappaLabel returns the Landis and Koch description of a kappa value.
+func kappaLabel(k float64) string {
+	switch {
+	case k < 0:
+		return "poor"
+	case k <= 0.20:
+		return "slight"
+	case k <= 0.40:
+		return "fair"
+	case k <= 0.60:
+		return "moderate"
+	case k <= 0.80:
+		return "substantial"
+	default:
+		return "almost perfect"
+	}
+}
+
+// FormatScreening writes results for title and abstract screening: rank,
+// identifier, title, authors and year, and the abstract.
+func FormatScreening(results []search.RankedResult, w io.Writer) {
+	if len(results) == 0 {
+		fmt.Fprintln(w, "Nothing left to screen.")
+		return
+	}
+	for _, rr := range results {
+		r := rr.Result
+		fmt.Fprintf(w, "[%d] %s\n", rr.Rank, acquisitionID(r))
+		fmt.Fprintf(w, "    %s\n", r.Title)
+		if byline := byline(r); byline != "" {
+			fmt.Fprintf(w, "    %s\n", byline)
+		}
+		abstract := strings.TrimSpace(r.Abstract)
+		if abstract == "" {
+			abstract = "(no abstract)"
+		}
+		fmt.Fprintf(w, "\n    %s\n\n", abstract)
+	}
+}
+
+// FormatConflicts writes each unresolved disagreement with every
+// reviewer's decision and note.
+func FormatConflicts(results []search.RankedResult, w io.Writer) {
+	if len(results) == 0 {
+		fmt.Fprintln(w, "No unresolved disagreements.")
+		return
+	}
+	for _, rr := range results {
+		r := rr.Result
+		fmt.Fprintf(w, "[%d] %s  %s\n", rr.Rank, acquisitionID(r), r.Title)
+		for _, d := range r.Triage.Screening {
+			line := fmt.Sprintf("    %-8s %s", d.Decision, d.Reviewer)
+			if d.Note != "" {
+				line += ": " + d.Note
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+	fmt.Fprintf(w, "\n%d unresolved disagreements\n", len(results))
+}
+
+func acquisitionID(r types.SearchResult) string {
+	if r.PreferredAcquisitionID != "" {
+		return r.PreferredAcquisitionID
+	}
+	return r.Identifier
+}
+
+// byline returns "First Author et al. (2023)" for a result.
+func byline(r types.SearchResult) string {
+	var s string
+	switch len(r.Authors) {
+	case 0:
+	case 1:
+		s = r.Authors[0]
+	default:
+		s = r.Authors[0] + " et al."
+	}
+	if !r.Date.IsZero() {
+		s = strings.TrimSpace(fmt.Sprintf("%s (%d)", s, r.Date.Year()))
+	}
+	return s
+}
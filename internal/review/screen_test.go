@@ -0,0 +1,161 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package review
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pdiddy/research-engine/internal/search"
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func screeningQueryFile() *search.QueryFile {
+	var results []types.SearchResult
+	for _, id := range []string{"2301.00001", "2301.00002", "2301.00003", "2301.00004"} {
+		results = append(results, types.SearchResult{Identifier: id, PreferredAcquisitionID: id, Title: "Paper " + id})
+	}
+	return &search.QueryFile{Results: results}
+}
+
+func mustDecide(t *testing.T, qf *search.QueryFile, id, reviewer, decision string, required int) *types.SearchResult {
+	t.Helper()
+	r, err := Decide(qf, id, reviewer, decision, "", required, time.Now())
+	if err != nil {
+		t.Fatalf("Decide(%s, %s): %v", id, reviewer, err)
+	}
+	return r
+}
+
+func TestDecideSingleReviewer(t *testing.T) {
+	qf := screeningQueryFile()
+	r := mustDecide(t, qf, "2301.00001", "alice", DecisionInclude, 1)
+	if r.Triage.Status != search.TriageKeep {
+		t.Errorf("Status = %q, want keep", r.Triage.Status)
+	}
+	r = mustDecide(t, qf, "2301.00001", "Alice", DecisionExclude, 1)
+	if r.Triage.Status != search.TriageReject || len(r.Triage.Screening) != 1 {
+		t.Errorf("changed decision: Status = %q, %d decisions", r.Triage.Status, len(r.Triage.Screening))
+	}
+	if _, err := Decide(qf, "2301.00001", " ", DecisionInclude, "", 1, time.Now()); err == nil {
+		t.Error("expected error without a reviewer")
+	}
+	if _, err := Decide(qf, "9999.99999", "alice", DecisionInclude, "", 1, time.Now()); err == nil {
+		t.Error("expected error for unknown identifier")
+	}
+}
+
+func TestDecideDualReviewers(t *testing.T) {
+	qf := screeningQueryFile()
+
+	r := mustDecide(t, qf, "2301.00001", "alice", DecisionInclude, 2)
+	if r.Triage.Status != "" {
+		t.Errorf("one of two reviewers: Status = %q, want empty", r.Triage.Status)
+	}
+	r = mustDecide(t, qf, "2301.00001", "bob", DecisionInclude, 2)
+	if r.Triage.Status != search.TriageKeep {
+		t.Errorf("agreement: Status = %q, want keep", r.Triage.Status)
+	}
+
+	mustDecide(t, qf, "2301.00002", "alice", DecisionInclude, 2)
+	r = mustDecide(t, qf, "2301.00002", "bob", DecisionExclude, 2)
+	if r.Triage.Status != "" {
+		t.Errorf("disagreement: Status = %q, want empty", r.Triage.Status)
+	}
+
+	conflicts := Conflicts(qf)
+	if len(conflicts) != 1 || conflicts[0].Rank != 2 {
+		t.Fatalf("Conflicts = %+v", conflicts)
+	}
+	var buf bytes.Buffer
+	FormatConflicts(conflicts, &buf)
+	if !strings.Contains(buf.String(), "include  alice") || !strings.Contains(buf.String(), "exclude  bob") {
+		t.Errorf("conflict report:\n%s", buf.String())
+	}
+
+	if pending := Pending(qf, "bob"); len(pending) != 2 || pending[0].Rank != 3 {
+		t.Errorf("Pending(bob) = %+v", pending)
+	}
+
+	r, err := Resolve(qf, "2301.00002", DecisionInclude, "in scope after discussion", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Triage.Status != search.TriageKeep || !r.Triage.Resolved {
+		t.Errorf("resolved: %+v", r.Triage)
+	}
+	if len(Conflicts(qf)) != 0 {
+		t.Error("resolved conflict still reported")
+	}
+	// A later decision does not undo the resolution.
+	r = mustDecide(t, qf, "2301.00002", "carol", DecisionExclude, 2)
+	if r.Triage.Status != search.TriageKeep {
+		t.Errorf("after resolution: Status = %q, want keep", r.Triage.Status)
+	}
+
+	if got := qf.AcquisitionIDs(search.TriageKeep, 0); len(got) != 2 {
+		t.Errorf("acquisition queue = %v, want 2 kept results", got)
+	}
+	if got := Reviewers(qf); strings.Join(got, ",") != "alice,bob,carol" {
+		t.Errorf("Reviewers = %v", got)
+	}
+}
+
+func TestComputeAgreement(t *testing.T) {
+	qf := screeningQueryFile()
+	// alice: I I E E; bob: I E E E.
+	for i, d := range []string{DecisionInclude, DecisionInclude, DecisionExclude, DecisionExclude} {
+		mustDecide(t, qf, qf.Results[i].Identifier, "alice", d, 2)
+	}
+	for i, d := range []string{DecisionInclude, DecisionExclude, DecisionExclude, DecisionExclude} {
+		mustDecide(t, qf, qf.Results[i].Identifier, "bob", d, 2)
+	}
+
+	ag := ComputeAgreement([]*search.QueryFile{qf}, "alice", "bob")
+	if ag.Both != 4 || ag.BothInclude != 1 || ag.BothExclude != 2 || ag.OnlyAInclude != 1 || ag.OnlyBInclude != 0 {
+		t.Errorf("Agreement counts = %+v", ag)
+	}
+	// po = 0.75; pe = 0.5*0.25 + 0.5*0.75 = 0.5; kappa = 0.5.
+	if math.Abs(ag.Observed-0.75) > 1e-9 || math.Abs(ag.Kappa-0.5) > 1e-9 {
+		t.Errorf("Observed = %v, Kappa = %v; want 0.75, 0.5", ag.Observed, ag.Kappa)
+	}
+
+	var buf bytes.Buffer
+	FormatAgreement(ag, &buf)
+	for _, s := range []string{"bob include", "alice exclude", "75.0%", "0.500 (moderate)"} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("agreement output missing %q:\n%s", s, buf.String())
+		}
+	}
+
+	if ag := ComputeAgreement([]*search.QueryFile{qf}, "alice", "carol"); ag.Both != 0 || !math.IsNaN(ag.Kappa) {
+		t.Errorf("no overlap: %+v", ag)
+	}
+}
+
+func TestComputeAgreementPerfect(t *testing.T) {
+	qf := screeningQueryFile()
+	for _, r := range qf.Results[:2] {
+		mustDecide(t, qf, r.Identifier, "alice", DecisionExclude, 2)
+		mustDecide(t, qf, r.Identifier, "bob", DecisionExclude, 2)
+	}
+	if ag := ComputeAgreement([]*search.QueryFile{qf}, "alice", "bob"); ag.Kappa != 1 {
+		t.Errorf("Kappa = %v, want 1", ag.Kappa)
+	}
+}
+
+func TestComputeFlowCountsScreeningConsensus(t *testing.T) {
+	qf := screeningQueryFile()
+	mustDecide(t, qf, "2301.00001", "alice", DecisionExclude, 2)
+	mustDecide(t, qf, "2301.00001", "bob", DecisionExclude, 2)
+	mustDecide(t, qf, "2301.00002", "alice", DecisionInclude, 2)
+	mustDecide(t, qf, "2301.00002", "bob", DecisionExclude, 2)
+
+	f := ComputeFlow([]*search.QueryFile{qf}, t.TempDir(), t.TempDir())
+	if f.ExcludedAtScreening != 1 || f.AwaitingScreening != 3 || f.SoughtForRetrieval != 0 {
+		t.Errorf("flow = %+v", f)
+	}
+}
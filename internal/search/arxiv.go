@@ -88,10 +88,10 @@ func (b *ArxivBackend) Search(ctx context.Context, query Query, cfg types.Search
 		}
 
 		// Position-based relevance score (R3.5).
-		if total > 1 {
-			r.RelevanceScore = 1.0 - float64(i)/float64(total-1)*0.9
-		} else {
-			r.RelevanceScore = 1.0
+		r.RelevanceScore = positionScore(i, total)
+
+		if cfg.KeepRaw {
+			attachRaw(&r, b.Name(), "<entry>"+entry.Raw+"</entry>")
 		}
 
 		results = append(results, r)
@@ -130,6 +130,7 @@ type arxivEntry struct {
 	Summary   string        `xml:"summary"`
 	Published string        `xml:"published"`
 	Authors   []arxivAuthor `xml:"author"`
+	Raw       string        `xml:",innerxml"`
 }
 
 type arxivAuthor struct {
@@ -0,0 +1,247 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package search
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// DefaultClusterThreshold is the minimum average cosine similarity between
+// two clusters for them to be merged.
+const DefaultClusterThreshold = 0.15
+
+// clusterLabelTerms is the number of top-weighted terms used as a label.
+const clusterLabelTerms = 3
+
+// minClusterTermLen drops short tokens, which are mostly stopwords.
+const minClusterTermLen = 3
+
+// clusterStopwords lists frequent words in titles and abstracts that carry
+// no topical signal.
+var clusterStopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true,
+	"that": true, "this": true, "these": true, "are": true, "was": true,
+	"were": true, "our": true, "its": true, "into": true, "over": true,
+	"using": true, "based": true, "via": true, "can": true, "which": true,
+	"their": true, "has": true, "have": true, "been": true, "also": true,
+	"than": true, "such": true, "both": true, "show": true, "paper": true,
+	"propose": true, "proposed": true, "approach": true, "method": true,
+	"methods": true, "results": true, "new": true, "not": true, "but": true,
+	"between": true, "two": true, "more": true, "most": true, "while": true,
+}
+
+// Cluster is a group of topically similar results. Members are indexes
+// into SearchOutput.Results in rank order; Label holds the terms with the
+// highest TF-IDF weight across the members.
+type Cluster struct {
+	Label   []string `json:"label" yaml:"label"`
+	Members []int    `json:"members" yaml:"members"`
+}
+
+// ClusterResults groups results by title and abstract similarity. Each
+// result becomes a TF-IDF vector; average-linkage agglomerative clustering
+// merges the most similar pair of clusters until no pair reaches
+// threshold. The pairwise similarities are computed once and updated after
+// each merge with the Lance-Williams formula, so a few hundred results
+// cluster in well under a second. Multi-member clusters come first,
+// ordered by their best-ranked member; results that joined no cluster are
+// collected in a final unlabeled cluster. A threshold of zero uses
+// DefaultClusterThreshold.
+func ClusterResults(results []types.SearchResult, threshold float64) []Cluster {
+	if len(results) == 0 {
+		return nil
+	}
+	if threshold <= 0 {
+		threshold = DefaultClusterThreshold
+	}
+
+	vecs := tfidfVectors(results)
+	sim := similarityMatrix(vecs)
+
+	// groups[i] is the cluster in slot i; a slot is emptied when its
+	// cluster merges into a lower slot.
+	groups := make([][]int, len(results))
+	for i := range groups {
+		groups[i] = []int{i}
+	}
+
+	for {
+		bestI, bestJ, bestSim := -1, -1, threshold
+		for i := range groups {
+			if groups[i] == nil {
+				continue
+			}
+			for j := i + 1; j < len(groups); j++ {
+				if groups[j] != nil && sim[i][j] >= bestSim {
+					bestI, bestJ, bestSim = i, j, sim[i][j]
+				}
+			}
+		}
+		if bestI < 0 {
+			break
+		}
+
+		// Lance-Williams update for average linkage: the merged cluster's
+		// similarity to k is the size-weighted mean of its parts'.
+		ni, nj := float64(len(groups[bestI])), float64(len(groups[bestJ]))
+		for k := range groups {
+			if groups[k] == nil || k == bestI || k == bestJ {
+				continue
+			}
+			s := (ni*sim[bestI][k] + nj*sim[bestJ][k]) / (ni + nj)
+			sim[bestI][k], sim[k][bestI] = s, s
+		}
+		groups[bestI] = append(groups[bestI], groups[bestJ]...)
+		groups[bestJ] = nil
+	}
+
+	var clusters []Cluster
+	var other []int
+	for _, g := range groups {
+		if g == nil {
+			continue
+		}
+		sort.Ints(g)
+		if len(g) == 1 {
+			other = append(other, g[0])
+			continue
+		}
+		clusters = append(clusters, Cluster{Label: clusterLabel(vecs, g), Members: g})
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Members[0] < clusters[j].Members[0]
+	})
+	if len(other) > 0 {
+		sort.Ints(other)
+		clusters = append(clusters, Cluster{Members: other})
+	}
+	return clusters
+}
+
+// tfidfVectors builds one L2-normalized TF-IDF vector per result from its
+// title and abstract.
+func tfidfVectors(results []types.SearchResult) []map[string]float64 {
+	tfs := make([]map[string]float64, len(results))
+	df := make(map[string]int)
+	for i, r := range results {
+		tf := make(map[string]float64)
+		for _, term := range clusterTerms(r.Title + " " + r.Abstract) {
+			tf[term]++
+		}
+		for term := range tf {
+			df[term]++
+		}
+		tfs[i] = tf
+	}
+
+	n := float64(len(results))
+	for _, tf := range tfs {
+		var norm float64
+		for term, count := range tf {
+			w := count * math.Log(1+n/float64(df[term]))
+			tf[term] = w
+			norm += w * w
+		}
+		norm = math.Sqrt(norm)
+		for term := range tf {
+			if norm > 0 {
+				tf[term] /= norm
+			}
+		}
+	}
+	return tfs
+}
+
+// clusterTerms lowercases text and splits it into content words.
+func clusterTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+	terms := words[:0]
+	for _, w := range words {
+		w = strings.Trim(w, "-")
+		if len(w) < minClusterTermLen || clusterStopwords[w] {
+			continue
+		}
+		terms = append(terms, w)
+	}
+	return terms
+}
+
+// similarityMatrix returns the cosine similarity of every pair of vectors.
+func similarityMatrix(vecs []map[string]float64) [][]float64 {
+	sim := make([][]float64, len(vecs))
+	for i := range sim {
+		sim[i] = make([]float64, len(vecs))
+	}
+	for i := range vecs {
+		for j := i + 1; j < len(vecs); j++ {
+			s := cosine(vecs[i], vecs[j])
+			sim[i][j], sim[j][i] = s, s
+		}
+	}
+	return sim
+}
+
+// cosine returns the dot product of two normalized vectors.
+func cosine(a, b map[string]float64) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var dot float64
+	for term, w := range a {
+		dot += w * b[term]
+	}
+	return dot
+}
+
+// clusterLabel returns the highest-weighted terms summed over members.
+func clusterLabel(vecs []map[string]float64, members []int) []string {
+	weights := make(map[string]float64)
+	for _, i := range members {
+		for term, w := range vecs[i] {
+			weights[term] += w
+		}
+	}
+	terms := make([]string, 0, len(weights))
+	for term := range weights {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if weights[terms[i]] != weights[terms[j]] {
+			return weights[terms[i]] > weights[terms[j]]
+		}
+		return terms[i] < terms[j]
+	})
+	if len(terms) > clusterLabelTerms {
+		terms = terms[:clusterLabelTerms]
+	}
+	return terms
+}
+
+// formatClusteredTable writes one table section per cluster. Rank numbers
+// keep the overall ranking so clustered output can be compared with the
+// flat table.
+func formatClusteredTable(out SearchOutput, w io.Writer) {
+	for n, c := range out.Clusters {
+		label := "other"
+		if len(c.Label) > 0 {
+			label = strings.Join(c.Label, ", ")
+		}
+		if n > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "Cluster %d: %s (%d results)\n", n+1, label, len(c.Members))
+		fmt.Fprintln(w, strings.Repeat("-", 110))
+		for _, i := range c.Members {
+			writeTableRow(w, i+1, out.Results[i])
+		}
+	}
+}
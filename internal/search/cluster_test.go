@@ -0,0 +1,185 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package search
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func clusterSample() []types.SearchResult {
+	return []types.SearchResult{
+		{Title: "Sparse attention for long transformer sequences", Abstract: "Sparse attention patterns reduce transformer cost on long sequences."},
+		{Title: "Protein folding with deep networks", Abstract: "Predicting protein structure from amino acid sequences."},
+		{Title: "Linear attention transformers", Abstract: "Kernelized attention makes transformer layers linear in sequence length."},
+		{Title: "Protein structure prediction benchmarks", Abstract: "Evaluating protein folding predictors on structure benchmarks."},
+		{Title: "Bayesian optimization of hyperparameters", Abstract: "Gaussian process surrogates guide hyperparameter search."},
+	}
+}
+
+func TestClusterResults(t *testing.T) {
+	clusters := ClusterResults(clusterSample(), 0)
+	if len(clusters) != 3 {
+		t.Fatalf("got %d clusters, want 3: %+v", len(clusters), clusters)
+	}
+
+	wantMembers := [][]int{{0, 2}, {1, 3}, {4}}
+	for i, want := range wantMembers {
+		got := clusters[i].Members
+		if len(got) != len(want) {
+			t.Fatalf("cluster %d members = %v, want %v", i, got, want)
+		}
+		for j := range want {
+			if got[j] != want[j] {
+				t.Errorf("cluster %d members = %v, want %v", i, got, want)
+			}
+		}
+	}
+
+	if !containsString(clusters[0].Label, "attention") {
+		t.Errorf("cluster 0 label = %v, want it to contain attention", clusters[0].Label)
+	}
+	if !containsString(clusters[1].Label, "protein") {
+		t.Errorf("cluster 1 label = %v, want it to contain protein", clusters[1].Label)
+	}
+	if len(clusters[2].Label) != 0 {
+		t.Errorf("unclustered group should be unlabeled, got %v", clusters[2].Label)
+	}
+}
+
+func TestClusterResultsHighThresholdKeepsSingletons(t *testing.T) {
+	clusters := ClusterResults(clusterSample(), 0.99)
+	if len(clusters) != 1 || len(clusters[0].Members) != 5 {
+		t.Errorf("want a single unlabeled group of 5, got %+v", clusters)
+	}
+}
+
+// topicalResults returns n results spread round-robin over the given
+// number of topics. Each topic has its own vocabulary, and each result adds
+// one word of its own.
+func topicalResults(n, topics int) []types.SearchResult {
+	results := make([]types.SearchResult, n)
+	for i := range results {
+		t := i % topics
+		results[i] = types.SearchResult{
+			Title:    fmt.Sprintf("topic%03d study%03d variant%04d", t, t, i),
+			Abstract: fmt.Sprintf("findings on field%03d and domain%03d", t, t),
+		}
+	}
+	return results
+}
+
+func TestClusterResultsManyResults(t *testing.T) {
+	const n, topics = 400, 40
+	clusters := ClusterResults(topicalResults(n, topics), 0)
+	if len(clusters) != topics {
+		t.Fatalf("got %d clusters, want %d", len(clusters), topics)
+	}
+	seen := make(map[int]bool)
+	for _, c := range clusters {
+		if len(c.Members) != n/topics {
+			t.Errorf("cluster %v has %d members, want %d", c.Label, len(c.Members), n/topics)
+		}
+		for _, m := range c.Members {
+			if m%topics != c.Members[0]%topics {
+				t.Errorf("cluster %v mixes topics: %v", c.Label, c.Members)
+				break
+			}
+			seen[m] = true
+		}
+	}
+	if len(seen) != n {
+		t.Errorf("%d results clustered, want %d", len(seen), n)
+	}
+}
+
+func TestClusterResultsMatchesAverageLinkage(t *testing.T) {
+	results := append(clusterSample(), topicalResults(30, 6)...)
+	vecs := tfidfVectors(results)
+
+	// Reference: recompute average linkage from the vectors at every step.
+	groups := make([][]int, len(results))
+	for i := range groups {
+		groups[i] = []int{i}
+	}
+	for {
+		bestI, bestJ, bestSim := -1, -1, DefaultClusterThreshold
+		for i := range groups {
+			for j := i + 1; j < len(groups); j++ {
+				var sum float64
+				for _, a := range groups[i] {
+					for _, b := range groups[j] {
+						sum += cosine(vecs[a], vecs[b])
+					}
+				}
+				if sim := sum / float64(len(groups[i])*len(groups[j])); sim >= bestSim {
+					bestI, bestJ, bestSim = i, j, sim
+				}
+			}
+		}
+		if bestI < 0 {
+			break
+		}
+		groups[bestI] = append(groups[bestI], groups[bestJ]...)
+		groups = append(groups[:bestJ], groups[bestJ+1:]...)
+	}
+	want := make(map[string]bool)
+	for _, g := range groups {
+		if len(g) > 1 {
+			sort.Ints(g)
+			want[fmt.Sprint(g)] = true
+		}
+	}
+
+	got := make(map[string]bool)
+	for _, c := range ClusterResults(results, 0) {
+		if len(c.Label) > 0 {
+			got[fmt.Sprint(c.Members)] = true
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("clusters = %v, want %v", got, want)
+	}
+}
+
+func TestClusterResultsEmpty(t *testing.T) {
+	if got := ClusterResults(nil, 0); got != nil {
+		t.Errorf("ClusterResults(nil) = %v, want nil", got)
+	}
+}
+
+func TestFormatTableClustered(t *testing.T) {
+	out := SearchOutput{Results: clusterSample()}
+	out.Clusters = ClusterResults(out.Results, 0)
+
+	var buf bytes.Buffer
+	FormatTable(out, &buf)
+	text := buf.String()
+
+	for _, want := range []string{"Cluster 1: ", "Cluster 3: other (1 results)", "5 results in 3 clusters"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("output missing %q:\n%s", want, text)
+		}
+	}
+	// Ranks follow the overall ranking, so result 3 appears in cluster 1.
+	first := strings.Index(text, "Cluster 1")
+	second := strings.Index(text, "Cluster 2")
+	if rank3 := strings.Index(text, "\n3   "); rank3 < first || rank3 > second {
+		t.Errorf("rank 3 should be listed in cluster 1:\n%s", text)
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,264 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pdiddy/research-engine/internal/httputil"
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// openAlexAutocompleteBase is the OpenAlex concept autocomplete endpoint.
+// Declared as a var so tests can substitute an httptest server.
+var openAlexAutocompleteBase = "https://api.openalex.org/autocomplete/concepts"
+
+// OriginalExpansion labels results found by the query as the user wrote it.
+const OriginalExpansion = "original"
+
+// maxConceptExpansions caps the OpenAlex concept variants. Every variant
+// runs against all backends, so each one adds a full round of API calls.
+const maxConceptExpansions = 2
+
+// expansionScoreFactor scales the relevance of results found only through
+// an expansion so that, at equal rank, the original query wins.
+const expansionScoreFactor = 0.9
+
+// acronyms maps common research acronyms to their long forms. Expansion
+// works in both directions.
+var acronyms = map[string]string{
+	"ai":   "artificial intelligence",
+	"cnn":  "convolutional neural network",
+	"gan":  "generative adversarial network",
+	"gnn":  "graph neural network",
+	"kg":   "knowledge graph",
+	"llm":  "large language model",
+	"ml":   "machine learning",
+	"nlp":  "natural language processing",
+	"rag":  "retrieval augmented generation",
+	"rl":   "reinforcement learning",
+	"rlhf": "reinforcement learning from human feedback",
+	"rnn":  "recurrent neural network",
+	"svm":  "support vector machine",
+	"vit":  "vision transformer",
+	"vae":  "variational autoencoder",
+}
+
+// Expansion is one query variant and the label recorded on the results it
+// finds.
+type Expansion struct {
+	Label string
+	Query Query
+}
+
+// ExpandQuery generates variants of the query's free text: one with
+// acronyms and their long forms swapped, and up to maxConceptExpansions
+// OpenAlex concepts matching the text. Author, keyword, and date filters
+// carry over to every variant. An OpenAlex failure is reported on w and
+// the acronym variant is still returned.
+func ExpandQuery(ctx context.Context, client *http.Client, q Query, email, userAgent string, w io.Writer) []Expansion {
+	text := strings.TrimSpace(q.FreeText)
+	if text == "" {
+		return nil
+	}
+
+	var out []Expansion
+	seen := map[string]bool{strings.ToLower(text): true}
+	add := func(label, freeText string) {
+		key := strings.ToLower(strings.TrimSpace(freeText))
+		if key == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		v := q
+		v.FreeText = freeText
+		out = append(out, Expansion{Label: label, Query: v})
+	}
+
+	if swapped, ok := swapAcronyms(text); ok {
+		add("acronym", swapped)
+	}
+
+	concepts, err := openAlexConcepts(ctx, client, text, email, userAgent)
+	if err != nil {
+		fmt.Fprintf(w, "warning: concept expansion failed: %v\n", err)
+	}
+	added := 0
+	for _, c := range concepts {
+		if added == maxConceptExpansions {
+			break
+		}
+		before := len(out)
+		add("concept: "+c, c)
+		if len(out) > before {
+			added++
+		}
+	}
+	return out
+}
+
+// wordPattern matches one word; ReplaceAllStringFunc leaves the text
+// between words untouched.
+var wordPattern = regexp.MustCompile(`[A-Za-z0-9]+`)
+
+// swapAcronyms replaces each known acronym with its long form and each
+// known long form with its acronym. It reports whether anything changed.
+func swapAcronyms(text string) (string, bool) {
+	result := text
+	changed := false
+
+	// Long forms first, longest first, so "reinforcement learning from
+	// human feedback" is not consumed by "reinforcement learning".
+	longForms := make([]string, 0, len(acronyms))
+	for _, long := range acronyms {
+		longForms = append(longForms, long)
+	}
+	sort.Slice(longForms, func(i, j int) bool { return len(longForms[i]) > len(longForms[j]) })
+	short := make(map[string]string, len(acronyms))
+	for a, long := range acronyms {
+		short[long] = a
+	}
+	var placeholders []string
+	for _, long := range longForms {
+		re := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(long) + `s?\b`)
+		result = re.ReplaceAllStringFunc(result, func(match string) string {
+			acronym := strings.ToUpper(short[long])
+			if len(match) > len(long) {
+				acronym += "s"
+			}
+			placeholders = append(placeholders, acronym)
+			changed = true
+			return fmt.Sprintf("\x00%d\x00", len(placeholders)-1)
+		})
+	}
+
+	result = wordPattern.ReplaceAllStringFunc(result, func(word string) string {
+		stem, plural := word, ""
+		if _, ok := acronyms[strings.ToLower(strings.TrimSuffix(word, "s"))]; ok && strings.HasSuffix(word, "s") {
+			stem, plural = strings.TrimSuffix(word, "s"), "s"
+		}
+		// Only all-caps words count as acronyms, so "ai" or "ml" in
+		// lowercase prose is left alone.
+		if stem != strings.ToUpper(stem) {
+			return word
+		}
+		if long, ok := acronyms[strings.ToLower(stem)]; ok {
+			changed = true
+			return long + plural
+		}
+		return word
+	})
+
+	for i, p := range placeholders {
+		result = strings.ReplaceAll(result, fmt.Sprintf("\x00%d\x00", i), p)
+	}
+	return result, changed
+}
+
+// openAlexConceptsResponse captures the autocomplete results.
+type openAlexConceptsResponse struct {
+	Results []struct {
+		DisplayName string `json:"display_name"`
+	} `json:"results"`
+}
+
+// openAlexConcepts returns the display names of OpenAlex concepts that
+// autocomplete the text, best match first.
+func openAlexConcepts(ctx context.Context, client *http.Client, text, email, userAgent string) ([]string, error) {
+	params := url.Values{"q": {text}}
+	if email != "" {
+		params.Set("mailto", email)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, openAlexAutocompleteBase+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := httputil.DoWithRetry(ctx, client, req, 0)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAlex autocomplete request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OpenAlex autocomplete returned HTTP %d", resp.StatusCode)
+	}
+
+	var ac openAlexConceptsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ac); err != nil {
+		return nil, fmt.Errorf("parsing OpenAlex autocomplete response: %w", err)
+	}
+
+	var names []string
+	for _, r := range ac.Results {
+		if r.DisplayName != "" {
+			names = append(names, r.DisplayName)
+		}
+	}
+	return names, nil
+}
+
+// SearchExpanded runs the original query and every expansion through
+// Search, labels each result with the variant that found it, and merges
+// the result sets. A result found by several variants carries all their
+// labels; results found only through an expansion have their relevance
+// scaled by expansionScoreFactor. The merged list is ranked and truncated
+// to cfg.MaxResults like a single search.
+func SearchExpanded(ctx context.Context, query Query, expansions []Expansion, backends []Backend, cfg types.SearchConfig, recencyBias bool, w io.Writer) (SearchOutput, error) {
+	variants := append([]Expansion{{Label: OriginalExpansion, Query: query}}, expansions...)
+
+	var (
+		all     []types.SearchResult
+		removed int
+		errs    []string
+	)
+	for _, v := range variants {
+		out, err := Search(ctx, v.Query, backends, cfg, recencyBias, w)
+		if err != nil {
+			if v.Label == OriginalExpansion {
+				return SearchOutput{}, err
+			}
+			fmt.Fprintf(w, "warning: expansion %q failed: %v\n", v.Label, err)
+			errs = append(errs, fmt.Sprintf("%s: %v", v.Label, err))
+			continue
+		}
+		for i := range out.Results {
+			out.Results[i].Expansions = []string{v.Label}
+			if v.Label != OriginalExpansion {
+				out.Results[i].RelevanceScore *= expansionScoreFactor
+			}
+		}
+		for _, e := range out.BackendErrors {
+			if v.Label == OriginalExpansion {
+				errs = append(errs, e)
+			} else {
+				errs = append(errs, v.Label+": "+e)
+			}
+		}
+		removed += out.DupsRemoved
+		all = append(all, out.Results...)
+	}
+
+	merged, crossRemoved := deduplicate(all)
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].RelevanceScore > merged[j].RelevanceScore
+	})
+	if cfg.MaxResults > 0 && len(merged) > cfg.MaxResults {
+		merged = merged[:cfg.MaxResults]
+	}
+
+	return SearchOutput{
+		Results:       merged,
+		DupsRemoved:   removed + crossRemoved,
+		BackendErrors: errs,
+	}, nil
+}
@@ -0,0 +1,156 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package search
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// queryBackend returns canned results keyed by the query's free text.
+type queryBackend struct {
+	results map[string][]types.SearchResult
+}
+
+func (b *queryBackend) Name() string { return "query" }
+
+func (b *queryBackend) Search(_ context.Context, q Query, _ types.SearchConfig) ([]types.SearchResult, error) {
+	return b.results[q.FreeText], nil
+}
+
+func TestSwapAcronyms(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		changed bool
+	}{
+		{"LLM agents", "large language model agents", true},
+		{"LLMs for code", "large language models for code", true},
+		{"large language models for code", "LLMs for code", true},
+		{"reinforcement learning from human feedback", "RLHF", true},
+		{"GNN and reinforcement learning", "graph neural network and RL", true},
+		{"ai in lowercase prose", "ai in lowercase prose", false},
+		{"protein folding", "protein folding", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, changed := swapAcronyms(tt.in)
+			if got != tt.want || changed != tt.changed {
+				t.Errorf("swapAcronyms(%q) = %q, %v; want %q, %v", tt.in, got, changed, tt.want, tt.changed)
+			}
+		})
+	}
+}
+
+func TestExpandQuery(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") != "LLM agents" {
+			t.Errorf("autocomplete q = %q", r.URL.Query().Get("q"))
+		}
+		fmt.Fprint(w, `{"results":[
+			{"display_name":"llm agents"},
+			{"display_name":"Multi-agent system"},
+			{"display_name":"Language model"},
+			{"display_name":"Intelligent agent"}
+		]}`)
+	}))
+	defer ts.Close()
+
+	old := openAlexAutocompleteBase
+	openAlexAutocompleteBase = ts.URL
+	defer func() { openAlexAutocompleteBase = old }()
+
+	var buf bytes.Buffer
+	q := Query{FreeText: "LLM agents", Author: "Smith"}
+	got := ExpandQuery(context.Background(), ts.Client(), q, "", "test/0.1", &buf)
+
+	want := []Expansion{
+		{Label: "acronym", Query: Query{FreeText: "large language model agents", Author: "Smith"}},
+		{Label: "concept: Multi-agent system", Query: Query{FreeText: "Multi-agent system", Author: "Smith"}},
+		{Label: "concept: Language model", Query: Query{FreeText: "Language model", Author: "Smith"}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d expansions, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].Label != want[i].Label || got[i].Query.FreeText != want[i].Query.FreeText || got[i].Query.Author != want[i].Query.Author {
+			t.Errorf("expansion %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestExpandQueryAutocompleteFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	old := openAlexAutocompleteBase
+	openAlexAutocompleteBase = ts.URL
+	defer func() { openAlexAutocompleteBase = old }()
+
+	var buf bytes.Buffer
+	got := ExpandQuery(context.Background(), ts.Client(), Query{FreeText: "GNN"}, "", "test/0.1", &buf)
+	if len(got) != 1 || got[0].Label != "acronym" {
+		t.Errorf("expansions = %+v, want only the acronym variant", got)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("concept expansion failed")) {
+		t.Errorf("expected warning, got %q", buf.String())
+	}
+}
+
+func TestSearchExpanded(t *testing.T) {
+	backend := &queryBackend{results: map[string][]types.SearchResult{
+		"LLM agents": {
+			{Identifier: "2301.00001", Title: "Agents with LLMs", Source: "query", RelevanceScore: 1.0},
+			{Identifier: "2301.00002", Title: "Shared paper", Source: "query", RelevanceScore: 0.5},
+		},
+		"large language model agents": {
+			{Identifier: "2301.00002", Title: "Shared paper", Source: "query", RelevanceScore: 1.0},
+			{Identifier: "2301.00003", Title: "Only via acronym", Source: "query", RelevanceScore: 1.0},
+		},
+	}}
+	expansions := []Expansion{{Label: "acronym", Query: Query{FreeText: "large language model agents"}}}
+
+	var buf bytes.Buffer
+	out, err := SearchExpanded(context.Background(), Query{FreeText: "LLM agents"}, expansions,
+		[]Backend{backend}, testCfg(), false, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Results) != 3 {
+		t.Fatalf("got %d results, want 3", len(out.Results))
+	}
+	if out.DupsRemoved != 1 {
+		t.Errorf("DupsRemoved = %d, want 1", out.DupsRemoved)
+	}
+
+	byID := make(map[string]types.SearchResult)
+	for _, r := range out.Results {
+		byID[r.Identifier] = r
+	}
+	if got := byID["2301.00002"].Expansions; !slices.Equal(got, []string{OriginalExpansion, "acronym"}) {
+		t.Errorf("shared result expansions = %v", got)
+	}
+	only := byID["2301.00003"]
+	if !slices.Equal(only.Expansions, []string{"acronym"}) {
+		t.Errorf("acronym-only expansions = %v", only.Expansions)
+	}
+	if only.RelevanceScore >= byID["2301.00001"].RelevanceScore {
+		t.Errorf("expansion-only score %.2f should be below original %.2f at equal rank",
+			only.RelevanceScore, byID["2301.00001"].RelevanceScore)
+	}
+
+	var table bytes.Buffer
+	FormatTable(out, &table)
+	if !bytes.Contains(table.Bytes(), []byte("query (via acronym)")) {
+		t.Errorf("table should mark expansion-only result:\n%s", table.String())
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+// Implements: prd006-search (R2.6, R4.1, R4.4), prd008-patent-search (R3.2);
+//
+//	docs/ARCHITECTURE § Search.
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pdiddy/research-engine/internal/httputil"
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// Lens.org search endpoints. Declared as vars so tests can substitute an
+// httptest server.
+var (
+	lensScholarlyBase = "https://api.lens.org/scholarly/search"
+	lensPatentBase    = "https://api.lens.org/patent/search"
+)
+
+// lensMaxSize is the largest page size the Lens.org search API accepts.
+const lensMaxSize = 1000
+
+// LensBackend queries the Lens.org API for scholarly works and patents in
+// one search. Scholarly records map to DOIs or arXiv IDs when Lens links
+// them; patent records map to jurisdiction-prefixed patent numbers so they
+// flow through the same acquisition path as PatentsView results.
+type LensBackend struct {
+	Client *http.Client
+	APIKey string
+}
+
+// Name returns the backend identifier.
+func (b *LensBackend) Name() string { return "lens" }
+
+// Search queries the Lens.org scholarly and patent endpoints and returns
+// the combined results. A failure on one endpoint is tolerated when the
+// other succeeds; the backend fails only when both do.
+func (b *LensBackend) Search(ctx context.Context, query Query, cfg types.SearchConfig) ([]types.SearchResult, error) {
+	body, err := buildLensRequest(query, cfg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	scholarly, scholarlyErr := b.searchScholarly(ctx, body, cfg)
+	patents, patentErr := b.searchPatents(ctx, body, cfg)
+
+	if scholarlyErr != nil && patentErr != nil {
+		return nil, fmt.Errorf("Lens scholarly: %v; Lens patents: %w", scholarlyErr, patentErr)
+	}
+	return append(scholarly, patents...), nil
+}
+
+// searchScholarly posts the request to the scholarly endpoint and maps
+// each work to a SearchResult.
+func (b *LensBackend) searchScholarly(ctx context.Context, reqBody []byte, cfg types.SearchConfig) ([]types.SearchResult, error) {
+	body, err := b.post(ctx, lensScholarlyBase, reqBody, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp lensScholarlyResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing Lens scholarly response: %w", err)
+	}
+	var raws []json.RawMessage
+	if cfg.KeepRaw {
+		raws = rawJSONItems(body, "data")
+	}
+
+	total := len(resp.Data)
+	results := make([]types.SearchResult, 0, total)
+	for i, work := range resp.Data {
+		r := work.toResult()
+		r.Source = b.Name()
+		r.RelevanceScore = positionScore(i, total)
+		if i < len(raws) {
+			attachRaw(&r, b.Name(), string(raws[i]))
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// searchPatents posts the request to the patent endpoint and maps each
+// patent record to a SearchResult.
+func (b *LensBackend) searchPatents(ctx context.Context, reqBody []byte, cfg types.SearchConfig) ([]types.SearchResult, error) {
+	body, err := b.post(ctx, lensPatentBase, reqBody, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp lensPatentResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing Lens patent response: %w", err)
+	}
+	var raws []json.RawMessage
+	if cfg.KeepRaw {
+		raws = rawJSONItems(body, "data")
+	}
+
+	total := len(resp.Data)
+	results := make([]types.SearchResult, 0, total)
+	for i, patent := range resp.Data {
+		r := patent.toResult()
+		r.Source = b.Name()
+		r.RelevanceScore = positionScore(i, total)
+		if i < len(raws) {
+			attachRaw(&r, b.Name(), string(raws[i]))
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// post sends a JSON search request to a Lens.org endpoint and returns the
+// response body.
+func (b *LensBackend) post(ctx context.Context, endpoint string, reqBody []byte, cfg types.SearchConfig) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", cfg.UserAgent)
+	req.Header.Set("Content-Type", "application/json")
+	if b.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+b.APIKey)
+	}
+
+	resp, err := httputil.DoWithRetry(ctx, b.Client, req, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Lens API request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Lens API returned HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading Lens response: %w", err)
+	}
+	return body, nil
+}
+
+// buildLensRequest builds the JSON request body shared by both endpoints:
+// a query_string over the combined query fields plus an optional
+// date_published range filter.
+func buildLensRequest(q Query, maxResults int) ([]byte, error) {
+	text := plainTextQuery(q)
+	if text == "" {
+		return nil, fmt.Errorf("empty Lens query")
+	}
+
+	if maxResults <= 0 {
+		maxResults = 20
+	}
+	if maxResults > lensMaxSize {
+		maxResults = lensMaxSize
+	}
+
+	boolQuery := map[string]any{
+		"must": []any{map[string]any{"query_string": map[string]any{"query": text}}},
+	}
+	if dateRange := lensDateRange(q.DateFrom, q.DateTo); dateRange != nil {
+		boolQuery["filter"] = []any{map[string]any{
+			"range": map[string]any{"date_published": dateRange},
+		}}
+	}
+
+	body := map[string]any{
+		"query": map[string]any{"bool": boolQuery},
+		"size":  maxResults,
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling Lens request: %w", err)
+	}
+	return data, nil
+}
+
+// lensDateRange returns the gte/lte bounds for a date_published range
+// filter, or nil when neither bound is set.
+func lensDateRange(from, to time.Time) map[string]string {
+	if from.IsZero() && to.IsZero() {
+		return nil
+	}
+	r := make(map[string]string)
+	if !from.IsZero() {
+		r["gte"] = from.Format("2006-01-02")
+	}
+	if !to.IsZero() {
+		r["lte"] = to.Format("2006-01-02")
+	}
+	return r
+}
+
+// Lens.org scholarly API JSON structures.
+type lensScholarlyResponse struct {
+	Total int                 `json:"total"`
+	Data  []lensScholarlyWork `json:"data"`
+}
+
+type lensScholarlyWork struct {
+	LensID        string           `json:"lens_id"`
+	Title         string           `json:"title"`
+	Abstract      string           `json:"abstract"`
+	DatePublished string           `json:"date_published"`
+	YearPublished int              `json:"year_published"`
+	Authors       []lensAuthor     `json:"authors"`
+	ExternalIDs   []lensExternalID `json:"external_ids"`
+}
+
+type lensAuthor struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+type lensExternalID struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// lensIDPrefix marks an identifier that is only a Lens ID. No acquisition
+// source resolves it, so such results carry no acquisition ID.
+const lensIDPrefix = "lens:"
+
+// toResult maps a scholarly work to a SearchResult. Identifiers prefer
+// arXiv ID, then DOI, then the Lens ID (R4.4).
+func (w lensScholarlyWork) toResult() types.SearchResult {
+	r := types.SearchResult{
+		Title:    w.Title,
+		Abstract: w.Abstract,
+	}
+	for _, a := range w.Authors {
+		if name := strings.TrimSpace(a.FirstName + " " + a.LastName); name != "" {
+			r.Authors = append(r.Authors, name)
+		}
+	}
+	r.Date = lensDate(w.DatePublished, w.YearPublished)
+
+	var doi, arxivID string
+	for _, id := range w.ExternalIDs {
+		switch strings.ToLower(id.Type) {
+		case "doi":
+			doi = id.Value
+		case "arxiv":
+			arxivID = strings.TrimPrefix(id.Value, "arXiv:")
+		}
+	}
+	switch {
+	case arxivID != "":
+		r.Identifier = arxivID
+	case doi != "":
+		r.Identifier = doi
+	default:
+		r.Identifier = lensIDPrefix + w.LensID
+		return r
+	}
+	r.PreferredAcquisitionID = r.Identifier
+	return r
+}
+
+// Lens.org patent API JSON structures.
+type lensPatentResponse struct {
+	Total int                `json:"total"`
+	Data  []lensPatentRecord `json:"data"`
+}
+
+type lensPatentRecord struct {
+	LensID        string           `json:"lens_id"`
+	Jurisdiction  string           `json:"jurisdiction"`
+	DocNumber     string           `json:"doc_number"`
+	Kind          string           `json:"kind"`
+	DatePublished string           `json:"date_published"`
+	Abstract      []lensLangText   `json:"abstract"`
+	Biblio        lensPatentBiblio `json:"biblio"`
+}
+
+type lensPatentBiblio struct {
+	InventionTitle []lensLangText    `json:"invention_title"`
+	Parties        lensPatentParties `json:"parties"`
+}
+
+type lensPatentParties struct {
+	Inventors []lensInventor `json:"inventors"`
+}
+
+type lensInventor struct {
+	ExtractedName struct {
+		Value string `json:"value"`
+	} `json:"extracted_name"`
+}
+
+type lensLangText struct {
+	Text string `json:"text"`
+	Lang string `json:"lang"`
+}
+
+// toResult maps a patent record to a SearchResult. The identifier joins
+// jurisdiction, document number, and kind code (e.g. "US7654321B2") so
+// US patents classify the same way as PatentsView results (prd008 R3.2).
+func (p lensPatentRecord) toResult() types.SearchResult {
+	r := types.SearchResult{
+		Title:    englishText(p.Biblio.InventionTitle),
+		Abstract: englishText(p.Abstract),
+		Date:     lensDate(p.DatePublished, 0),
+	}
+	for _, inv := range p.Biblio.Parties.Inventors {
+		if name := strings.TrimSpace(inv.ExtractedName.Value); name != "" {
+			r.Authors = append(r.Authors, name)
+		}
+	}
+
+	if p.Jurisdiction == "" || p.DocNumber == "" {
+		r.Identifier = lensIDPrefix + p.LensID
+		return r
+	}
+	r.Identifier = p.Jurisdiction + p.DocNumber + p.Kind
+	r.PreferredAcquisitionID = r.Identifier
+	return r
+}
+
+// englishText returns the English entry from a list of language-tagged
+// texts, or the first entry when no English text exists.
+func englishText(texts []lensLangText) string {
+	for _, t := range texts {
+		if strings.EqualFold(t.Lang, "en") {
+			return strings.TrimSpace(t.Text)
+		}
+	}
+	if len(texts) > 0 {
+		return strings.TrimSpace(texts[0].Text)
+	}
+	return ""
+}
+
+// lensDate parses a Lens date_published value, falling back to January 1
+// of the publication year when only the year is known.
+func lensDate(date string, year int) time.Time {
+	if date != "" {
+		if t, err := time.Parse("2006-01-02", date); err == nil {
+			return t
+		}
+	}
+	if year > 0 {
+		return time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Time{}
+}
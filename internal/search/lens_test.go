@@ -0,0 +1,215 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+const sampleLensScholarlyJSON = `{
+  "total": 2,
+  "data": [
+    {
+      "lens_id": "000-111-222-333-444",
+      "title": "Attention Is All You Need",
+      "abstract": "We propose the Transformer.",
+      "date_published": "2017-06-12",
+      "authors": [{"first_name": "Ashish", "last_name": "Vaswani"}],
+      "external_ids": [{"type": "doi", "value": "10.5555/3295222.3295349"}, {"type": "arxiv", "value": "1706.03762"}]
+    },
+    {
+      "lens_id": "555-666-777-888-999",
+      "title": "An Unlinked Work",
+      "year_published": 2019,
+      "authors": []
+    }
+  ]
+}`
+
+const sampleLensPatentJSON = `{
+  "total": 1,
+  "data": [
+    {
+      "lens_id": "123-456-789-000-111",
+      "jurisdiction": "US",
+      "doc_number": "7654321",
+      "kind": "B2",
+      "date_published": "2010-02-02",
+      "abstract": [{"text": "Resume", "lang": "fr"}, {"text": "A neural network system.", "lang": "en"}],
+      "biblio": {
+        "invention_title": [{"text": "Neural Network System", "lang": "en"}],
+        "parties": {"inventors": [{"extracted_name": {"value": "SMITH JOHN"}}]}
+      }
+    }
+  ]
+}`
+
+// lensTestServer serves the scholarly and patent endpoints with the given
+// status codes and records the request bodies it receives.
+func lensTestServer(t *testing.T, scholarlyStatus, patentStatus int, bodies *[]string) func() {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Authorization = %q, want bearer token", got)
+		}
+		b, _ := io.ReadAll(r.Body)
+		*bodies = append(*bodies, string(b))
+		switch r.URL.Path {
+		case "/scholarly":
+			w.WriteHeader(scholarlyStatus)
+			fmt.Fprint(w, sampleLensScholarlyJSON)
+		case "/patent":
+			w.WriteHeader(patentStatus)
+			fmt.Fprint(w, sampleLensPatentJSON)
+		}
+	}))
+	oldScholarly, oldPatent := lensScholarlyBase, lensPatentBase
+	lensScholarlyBase = ts.URL + "/scholarly"
+	lensPatentBase = ts.URL + "/patent"
+	return func() {
+		lensScholarlyBase, lensPatentBase = oldScholarly, oldPatent
+		ts.Close()
+	}
+}
+
+func TestLensBackendSearch(t *testing.T) {
+	var bodies []string
+	defer lensTestServer(t, http.StatusOK, http.StatusOK, &bodies)()
+
+	b := &LensBackend{Client: http.DefaultClient, APIKey: "test-key"}
+	results, err := b.Search(context.Background(), Query{FreeText: "attention"}, testCfg())
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("len(results) = %d, want 3", len(results))
+	}
+
+	work := results[0]
+	if work.Identifier != "1706.03762" || work.PreferredAcquisitionID != "1706.03762" {
+		t.Errorf("Identifier = %q, want arXiv ID preferred over DOI", work.Identifier)
+	}
+	if work.Source != "lens" {
+		t.Errorf("Source = %q, want lens", work.Source)
+	}
+	if len(work.Authors) != 1 || work.Authors[0] != "Ashish Vaswani" {
+		t.Errorf("Authors = %v", work.Authors)
+	}
+
+	unlinked := results[1]
+	if unlinked.Identifier != "lens:555-666-777-888-999" {
+		t.Errorf("Identifier = %q, want Lens ID fallback", unlinked.Identifier)
+	}
+	if unlinked.PreferredAcquisitionID != "" {
+		t.Errorf("PreferredAcquisitionID = %q, want empty for a Lens-only ID", unlinked.PreferredAcquisitionID)
+	}
+	if unlinked.Date.Year() != 2019 {
+		t.Errorf("Date = %v, want year-only fallback 2019", unlinked.Date)
+	}
+
+	patent := results[2]
+	if patent.Identifier != "US7654321B2" {
+		t.Errorf("patent Identifier = %q, want US7654321B2", patent.Identifier)
+	}
+	if !isPatentResult(patent) {
+		t.Error("Lens US patent should classify as a patent result")
+	}
+	if patent.Title != "Neural Network System" || patent.Abstract != "A neural network system." {
+		t.Errorf("patent title/abstract = %q / %q, want English text", patent.Title, patent.Abstract)
+	}
+}
+
+func TestLensResultsWithoutAcquirableID(t *testing.T) {
+	work := lensScholarlyWork{LensID: "111-222", Title: "Unlinked"}.toResult()
+	patent := lensPatentRecord{LensID: "333-444", DocNumber: "7654321"}.toResult()
+	for _, r := range []types.SearchResult{work, patent} {
+		if !strings.HasPrefix(r.Identifier, "lens:") {
+			t.Errorf("Identifier = %q, want Lens ID fallback", r.Identifier)
+		}
+		if r.PreferredAcquisitionID != "" {
+			t.Errorf("PreferredAcquisitionID = %q, want empty", r.PreferredAcquisitionID)
+		}
+	}
+
+	qf := &QueryFile{Results: []types.SearchResult{
+		{Identifier: "1706.03762", PreferredAcquisitionID: "1706.03762"},
+		work,
+		patent,
+	}}
+	if got := qf.AcquisitionIDs("", 0); len(got) != 1 || got[0] != "1706.03762" {
+		t.Errorf("AcquisitionIDs = %v, want only the arXiv ID", got)
+	}
+}
+
+func TestLensBackendPartialFailure(t *testing.T) {
+	var bodies []string
+	defer lensTestServer(t, http.StatusInternalServerError, http.StatusOK, &bodies)()
+
+	b := &LensBackend{Client: http.DefaultClient, APIKey: "test-key"}
+	results, err := b.Search(context.Background(), Query{FreeText: "attention"}, testCfg())
+	if err != nil {
+		t.Fatalf("Search should tolerate one failed endpoint: %v", err)
+	}
+	if len(results) != 1 || results[0].Identifier != "US7654321B2" {
+		t.Errorf("results = %+v, want only the patent", results)
+	}
+}
+
+func TestLensBackendBothFail(t *testing.T) {
+	var bodies []string
+	defer lensTestServer(t, http.StatusInternalServerError, http.StatusForbidden, &bodies)()
+
+	b := &LensBackend{Client: http.DefaultClient, APIKey: "test-key"}
+	if _, err := b.Search(context.Background(), Query{FreeText: "attention"}, testCfg()); err == nil {
+		t.Error("expected error when both endpoints fail")
+	}
+}
+
+func TestBuildLensRequest(t *testing.T) {
+	q := Query{
+		FreeText: "attention",
+		Author:   "Vaswani",
+		DateFrom: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	data, err := buildLensRequest(q, 5000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var body struct {
+		Size  int `json:"size"`
+		Query struct {
+			Bool struct {
+				Must   []map[string]map[string]string            `json:"must"`
+				Filter []map[string]map[string]map[string]string `json:"filter"`
+			} `json:"bool"`
+		} `json:"query"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Size != lensMaxSize {
+		t.Errorf("size = %d, want clamp to %d", body.Size, lensMaxSize)
+	}
+	if got := body.Query.Bool.Must[0]["query_string"]["query"]; got != "attention Vaswani" {
+		t.Errorf("query_string = %q", got)
+	}
+	rng := body.Query.Bool.Filter[0]["range"]["date_published"]
+	if rng["gte"] != "2017-01-01" || rng["lte"] != "" {
+		t.Errorf("date range = %v, want only gte", rng)
+	}
+
+	if _, err := buildLensRequest(Query{}, 10); err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Errorf("expected empty query error, got %v", err)
+	}
+}
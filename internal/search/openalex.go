@@ -6,8 +6,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"path"
 	"sort"
 	"strings"
 	"time"
@@ -85,11 +87,21 @@ func (b *OpenAlexBackend) Search(ctx context.Context, query Query, cfg types.Sea
 		return nil, fmt.Errorf("OpenAlex API returned HTTP %d", resp.StatusCode)
 	}
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading OpenAlex response: %w", err)
+	}
+
 	var oar openAlexResponse
-	if err := json.NewDecoder(resp.Body).Decode(&oar); err != nil {
+	if err := json.Unmarshal(body, &oar); err != nil {
 		return nil, fmt.Errorf("parsing OpenAlex response: %w", err)
 	}
 
+	var raws []json.RawMessage
+	if cfg.KeepRaw {
+		raws = rawJSONItems(body, "results")
+	}
+
 	total := len(oar.Results)
 	var results []types.SearchResult
 	for i, work := range oar.Results {
@@ -119,6 +131,9 @@ func (b *OpenAlexBackend) Search(ctx context.Context, query Query, cfg types.Sea
 			doi := strings.TrimPrefix(work.DOI, "https://doi.org/")
 			r.Identifier = doi
 			r.PreferredAcquisitionID = doi
+		} else if id := work.IDs.acquisitionID(); id != "" {
+			r.Identifier = id
+			r.PreferredAcquisitionID = id
 		} else if work.ID != "" {
 			r.Identifier = work.ID
 			r.PreferredAcquisitionID = work.ID
@@ -126,10 +141,10 @@ func (b *OpenAlexBackend) Search(ctx context.Context, query Query, cfg types.Sea
 
 		// Position-based relevance score. OpenAlex returns results
 		// sorted by relevance by default.
-		if total > 1 {
-			r.RelevanceScore = 1.0 - float64(i)/float64(total-1)*0.9
-		} else {
-			r.RelevanceScore = 1.0
+		r.RelevanceScore = positionScore(i, total)
+
+		if i < len(raws) {
+			attachRaw(&r, b.Name(), string(raws[i]))
 		}
 
 		results = append(results, r)
@@ -139,17 +154,7 @@ func (b *OpenAlexBackend) Search(ctx context.Context, query Query, cfg types.Sea
 
 // buildOpenAlexQuery combines query fields into a search string.
 func buildOpenAlexQuery(q Query) string {
-	var parts []string
-	if q.FreeText != "" {
-		parts = append(parts, q.FreeText)
-	}
-	if q.Author != "" {
-		parts = append(parts, q.Author)
-	}
-	for _, kw := range q.Keywords {
-		parts = append(parts, kw)
-	}
-	return strings.Join(parts, " ")
+	return plainTextQuery(q)
 }
 
 // reconstructAbstract converts OpenAlex's abstract_inverted_index back to
@@ -204,6 +209,25 @@ type openAlexWork struct {
 	Authorships           []openAlexAuthorship   `json:"authorships"`
 	AbstractInvertedIndex map[string][]int       `json:"abstract_inverted_index"`
 	OpenAccess            openAlexOpenAccess     `json:"open_access"`
+	IDs                   openAlexIDs            `json:"ids"`
+}
+
+// openAlexIDs holds the external identifiers of a work as URLs.
+type openAlexIDs struct {
+	PMID  string `json:"pmid"`
+	PMCID string `json:"pmcid"`
+}
+
+// acquisitionID returns the PMCID, or failing that the PMID, in the form
+// acquire.Classify recognizes, or "" when the work has neither.
+func (ids openAlexIDs) acquisitionID() string {
+	if ids.PMCID != "" {
+		return path.Base(strings.TrimSuffix(ids.PMCID, "/"))
+	}
+	if ids.PMID != "" {
+		return "PMID:" + path.Base(strings.TrimSuffix(ids.PMID, "/"))
+	}
+	return ""
 }
 
 type openAlexAuthorship struct {
@@ -276,6 +276,36 @@ func TestOpenAlexBackendNoDOIFallsBackToID(t *testing.T) {
 	}
 }
 
+func TestOpenAlexBackendNoDOIUsesPubMedIDs(t *testing.T) {
+	tests := []struct {
+		ids  string
+		want string
+	}{
+		{`{"pmid":"https://pubmed.ncbi.nlm.nih.gov/23193287","pmcid":"https://www.ncbi.nlm.nih.gov/pmc/articles/PMC3531190"}`, "PMC3531190"},
+		{`{"pmid":"https://pubmed.ncbi.nlm.nih.gov/23193287"}`, "PMID:23193287"},
+	}
+	for _, tt := range tests {
+		body := fmt.Sprintf(`{
+			"meta": {"count": 1, "per_page": 20, "page": 1},
+			"results": [{"id": "https://openalex.org/W1", "title": "P", "doi": "", "ids": %s}]
+		}`, tt.ids)
+		ts := openAlexTestServer(http.StatusOK, body)
+
+		old := openAlexSearchBase
+		openAlexSearchBase = ts.URL
+		b := &OpenAlexBackend{Client: ts.Client()}
+		results, err := b.Search(context.Background(), Query{FreeText: "test"}, testCfg())
+		openAlexSearchBase = old
+		ts.Close()
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		if results[0].PreferredAcquisitionID != tt.want {
+			t.Errorf("PreferredAcquisitionID = %q, want %q", results[0].PreferredAcquisitionID, tt.want)
+		}
+	}
+}
+
 // --- Position-based scoring ---
 
 func TestOpenAlexBackendPositionScoring(t *testing.T) {
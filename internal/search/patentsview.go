@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -76,11 +77,21 @@ func (b *PatentsViewBackend) Search(ctx context.Context, query Query, cfg types.
 		return nil, fmt.Errorf("PatentsView API returned HTTP %d", resp.StatusCode)
 	}
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading PatentsView response: %w", err)
+	}
+
 	var pvr patentsViewResponse
-	if err := json.NewDecoder(resp.Body).Decode(&pvr); err != nil {
+	if err := json.Unmarshal(body, &pvr); err != nil {
 		return nil, fmt.Errorf("parsing PatentsView response: %w", err)
 	}
 
+	var raws []json.RawMessage
+	if cfg.KeepRaw {
+		raws = rawJSONItems(body, "patents")
+	}
+
 	total := len(pvr.Patents)
 	var results []types.SearchResult
 	for i, patent := range pvr.Patents {
@@ -110,10 +121,10 @@ func (b *PatentsViewBackend) Search(ctx context.Context, query Query, cfg types.
 		}
 
 		// Position-based relevance score (R3.5).
-		if total > 1 {
-			r.RelevanceScore = 1.0 - float64(i)/float64(total-1)*0.9
-		} else {
-			r.RelevanceScore = 1.0
+		r.RelevanceScore = positionScore(i, total)
+
+		if i < len(raws) {
+			attachRaw(&r, b.Name(), string(raws[i]))
 		}
 
 		results = append(results, r)
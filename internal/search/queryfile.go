@@ -36,6 +36,7 @@ type QueryParams struct {
 type QueryFileConfig struct {
 	MaxResults  int  `yaml:"max_results"`
 	RecencyBias bool `yaml:"recency_bias"`
+	KeepRaw     bool `yaml:"keep_raw,omitempty"`
 }
 
 // QuerySummary stores result statistics and a timestamp.
@@ -48,7 +49,9 @@ type QuerySummary struct {
 
 const dateFmt = "2006-01-02"
 
-// WriteQueryFile saves query parameters and results to a YAML file.
+// WriteQueryFile saves query parameters and results to a YAML file. When
+// the file already exists, triage decisions on results that are still
+// present are kept.
 func WriteQueryFile(path string, query Query, cfg types.SearchConfig, recencyBias bool, out SearchOutput) error {
 	qf := QueryFile{
 		Query: QueryParams{
@@ -59,6 +62,7 @@ func WriteQueryFile(path string, query Query, cfg types.SearchConfig, recencyBia
 		Config: QueryFileConfig{
 			MaxResults:  cfg.MaxResults,
 			RecencyBias: recencyBias,
+			KeepRaw:     cfg.KeepRaw,
 		},
 		Results: out.Results,
 		Summary: QuerySummary{
@@ -76,11 +80,10 @@ func WriteQueryFile(path string, query Query, cfg types.SearchConfig, recencyBia
 		qf.Query.DateTo = query.DateTo.Format(dateFmt)
 	}
 
-	data, err := yaml.Marshal(&qf)
-	if err != nil {
-		return fmt.Errorf("marshaling query file: %w", err)
+	if prev, err := ReadQueryFile(path); err == nil {
+		carryTriage(prev.Results, qf.Results)
 	}
-	return os.WriteFile(path, data, 0o644)
+	return qf.Save(path)
 }
 
 // ReadQueryFile loads a previously saved query file from disk.
@@ -0,0 +1,35 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package search
+
+import (
+	"time"
+
+	"github.com/pdiddy/research-engine/internal/httputil"
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// RateLimits returns the built-in per-host request budget for the search
+// backends (R5.1-R5.3). Semantic Scholar allows one request per second
+// without an API key.
+func RateLimits(cfg types.SearchConfig) map[string]httputil.HostLimit {
+	limits := map[string]httputil.HostLimit{
+		"export.arxiv.org":        httputil.Every(3 * time.Second),
+		"api.semanticscholar.org": {Rate: 1, Burst: 1},
+		"api.openalex.org":        {Rate: 10, Burst: 10},
+		"search.patentsview.org":  {Rate: 45.0 / 60, Burst: 1},
+		"api.lens.org":            {Rate: 50.0 / 60, Burst: 1},
+	}
+	if cfg.SemanticScholarAPIKey != "" {
+		limits["api.semanticscholar.org"] = httputil.HostLimit{Rate: 10, Burst: 1}
+	}
+	return limits
+}
+
+// NewRateLimiter returns the limiter shared by every backend in a search
+// run. Its host budgets are the process-wide ones, shared with
+// acquisition in the same process; cfg.RateLimits overrides any host.
+// Hosts without a budget are not limited.
+func NewRateLimiter(cfg types.SearchConfig) *httputil.RateLimiter {
+	return httputil.SharedRateLimiter(httputil.HostLimit{}, RateLimits(cfg), httputil.PerSecond(cfg.RateLimits))
+}
@@ -0,0 +1,36 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package search
+
+import (
+	"testing"
+
+	"github.com/pdiddy/research-engine/internal/httputil"
+)
+
+func TestRateLimits(t *testing.T) {
+	const s2 = "api.semanticscholar.org"
+
+	cfg := testCfg()
+	if got := RateLimits(cfg)[s2]; got.Rate != 1 {
+		t.Errorf("S2 without key: rate = %v, want 1", got.Rate)
+	}
+
+	cfg.SemanticScholarAPIKey = "key"
+	if got := RateLimits(cfg)[s2]; got.Rate <= 1 {
+		t.Errorf("S2 with key: rate = %v, want > 1", got.Rate)
+	}
+
+	cfg.RateLimits = map[string]float64{s2: 0.5, "example.org": 2}
+	limiter := NewRateLimiter(cfg)
+	if got, want := limiter.Limit(s2), (httputil.HostLimit{Rate: 0.5, Burst: 1}); got != want {
+		t.Errorf("S2 override = %+v, want %+v", got, want)
+	}
+	if got, want := limiter.Limit("example.org"), (httputil.HostLimit{Rate: 2, Burst: 1}); got != want {
+		t.Errorf("added host = %+v, want %+v", got, want)
+	}
+
+	if got := limiter.Limit("unknown.example"); got != (httputil.HostLimit{}) {
+		t.Errorf("unknown host limit = %+v, want unlimited", got)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package search
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// rawJSONItems returns each element of the array stored under key in a JSON
+// response body, byte for byte. It returns nil when the body or the key does
+// not decode; raw retention is best effort and never fails a search.
+func rawJSONItems(body []byte, key string) []json.RawMessage {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(envelope[key], &items); err != nil {
+		return nil
+	}
+	return items
+}
+
+// attachRaw records payload as the raw record backend returned for r.
+func attachRaw(r *types.SearchResult, backend, payload string) {
+	payload = strings.TrimSpace(payload)
+	if payload == "" {
+		return
+	}
+	if r.Raw == nil {
+		r.Raw = make(map[string]string)
+	}
+	r.Raw[backend] = payload
+}
+
+// mergeRaw copies raw payloads from src into dst without overwriting
+// entries dst already holds.
+func mergeRaw(dst *types.SearchResult, src types.SearchResult) {
+	for backend, payload := range src.Raw {
+		if _, ok := dst.Raw[backend]; ok {
+			continue
+		}
+		attachRaw(dst, backend, payload)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func TestRawJSONItems(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		key  string
+		want []string
+	}{
+		{"array of objects", `{"results":[{"a":1},{"b":2}]}`, "results", []string{`{"a":1}`, `{"b":2}`}},
+		{"missing key", `{"results":[{"a":1}]}`, "data", nil},
+		{"not an array", `{"results":{"a":1}}`, "results", nil},
+		{"invalid json", `not json`, "results", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rawJSONItems([]byte(tt.body), tt.key)
+			if len(got) != len(tt.want) {
+				t.Fatalf("len = %d, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if string(got[i]) != tt.want[i] {
+					t.Errorf("item %d = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMergeRawKeepsBothBackends(t *testing.T) {
+	dst := types.SearchResult{Identifier: "1706.03762", Source: "arxiv"}
+	attachRaw(&dst, "arxiv", "<entry>a</entry>")
+	src := types.SearchResult{Identifier: "1706.03762", Source: "semantic_scholar"}
+	attachRaw(&src, "semantic_scholar", `{"paperId":"x"}`)
+	attachRaw(&src, "arxiv", "<entry>other</entry>")
+
+	mergeInto(&dst, src)
+
+	if dst.Raw["arxiv"] != "<entry>a</entry>" {
+		t.Errorf("arxiv raw overwritten: %q", dst.Raw["arxiv"])
+	}
+	if dst.Raw["semantic_scholar"] != `{"paperId":"x"}` {
+		t.Errorf("semantic_scholar raw = %q", dst.Raw["semantic_scholar"])
+	}
+}
+
+func TestKeepRawBackends(t *testing.T) {
+	cfg := testCfg()
+	cfg.KeepRaw = true
+
+	t.Run("openalex", func(t *testing.T) {
+		ts := openAlexTestServer(http.StatusOK, sampleOpenAlexJSON)
+		defer ts.Close()
+		old := openAlexSearchBase
+		openAlexSearchBase = ts.URL
+		defer func() { openAlexSearchBase = old }()
+
+		b := &OpenAlexBackend{Client: ts.Client()}
+		results, err := b.Search(context.Background(), Query{FreeText: "attention"}, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(results[0].Raw["openalex"], `"open_access"`) {
+			t.Errorf("raw payload missing open_access: %q", results[0].Raw["openalex"])
+		}
+	})
+
+	t.Run("arxiv", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, sampleArxivSearchXML)
+		}))
+		defer ts.Close()
+		old := arxivAPIBase
+		arxivAPIBase = ts.URL
+		defer func() { arxivAPIBase = old }()
+
+		b := &ArxivBackend{Client: ts.Client()}
+		results, err := b.Search(context.Background(), Query{FreeText: "attention"}, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw := results[0].Raw["arxiv"]
+		if !strings.HasPrefix(raw, "<entry>") || !strings.Contains(raw, "1706.03762v1") {
+			t.Errorf("raw entry = %q", raw)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		ts := openAlexTestServer(http.StatusOK, sampleOpenAlexJSON)
+		defer ts.Close()
+		old := openAlexSearchBase
+		openAlexSearchBase = ts.URL
+		defer func() { openAlexSearchBase = old }()
+
+		b := &OpenAlexBackend{Client: ts.Client()}
+		results, err := b.Search(context.Background(), Query{FreeText: "attention"}, testCfg())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if results[0].Raw != nil {
+			t.Errorf("Raw = %v, want nil without KeepRaw", results[0].Raw)
+		}
+	})
+}
@@ -12,6 +12,8 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -42,15 +44,32 @@ func (q Query) IsEmpty() bool {
 	return q.FreeText == "" && q.Author == "" && len(q.Keywords) == 0
 }
 
-// SearchOutput holds the results and dedup statistics.
+// plainTextQuery joins free text, author, and keywords into one
+// space-separated string for backends that take a single search box.
+func plainTextQuery(q Query) string {
+	var parts []string
+	if q.FreeText != "" {
+		parts = append(parts, q.FreeText)
+	}
+	if q.Author != "" {
+		parts = append(parts, q.Author)
+	}
+	parts = append(parts, q.Keywords...)
+	return strings.Join(parts, " ")
+}
+
+// SearchOutput holds the results and dedup statistics. Clusters is set
+// only when the caller groups results with ClusterResults.
 type SearchOutput struct {
 	Results        []types.SearchResult
 	DupsRemoved    int
 	BackendErrors  []string
+	Clusters       []Cluster
 }
 
 // Search fans out the query to all backends concurrently, deduplicates
-// results, ranks them, and returns the top N (R1-R4).
+// results, ranks them, and returns the top N (R1-R4). Request pacing is
+// left to the backends' HTTP clients; see NewRateLimiter.
 func Search(ctx context.Context, query Query, backends []Backend, cfg types.SearchConfig, recencyBias bool, w io.Writer) (SearchOutput, error) {
 	if query.IsEmpty() {
 		return SearchOutput{}, fmt.Errorf("query is empty: provide a research question or structured parameters")
@@ -68,10 +87,7 @@ func Search(ctx context.Context, query Query, backends []Backend, cfg types.Sear
 	ch := make(chan backendResult, len(backends))
 	var wg sync.WaitGroup
 
-	for i, b := range backends {
-		if i > 0 && cfg.InterBackendDelay > 0 {
-			time.Sleep(cfg.InterBackendDelay)
-		}
+	for _, b := range backends {
 		wg.Add(1)
 		go func(b Backend) {
 			defer wg.Done()
@@ -163,7 +179,8 @@ func deduplicate(results []types.SearchResult) ([]types.SearchResult, int) {
 // Identifier field (arXiv ID or DOI set by backends). Patent identifiers
 // are normalized by stripping kind codes so US7654321B2 and US7654321
 // produce the same key. Patent keys use a "patent:" prefix to prevent
-// cross-type matching with academic papers.
+// cross-type matching with academic papers. DOIs are case-insensitive, so
+// DOI keys are lowercased.
 func dedupKey(r types.SearchResult) string {
 	if r.Identifier == "" {
 		return ""
@@ -171,9 +188,18 @@ func dedupKey(r types.SearchResult) string {
 	if isPatentResult(r) {
 		return "patent:" + stripKindCode(r.Identifier)
 	}
-	return "id:" + r.Identifier
+	id := strings.TrimSpace(r.Identifier)
+	if strings.HasPrefix(id, "10.") {
+		id = strings.ToLower(id)
+	}
+	id = arxivSubjectClass.ReplaceAllString(id, "$1/")
+	return "id:" + id
 }
 
+// arxivSubjectClass matches the subject class some sources include in
+// pre-2007 arXiv IDs ("math.GT/0309136"); the canonical ID omits it.
+var arxivSubjectClass = regexp.MustCompile(`^([a-z]+(?:-[a-z]+)?)\.[A-Z]{2}/`)
+
 // stripKindCode removes the trailing kind code from a US patent identifier.
 // US7654321B2 → US7654321, US20230012345A1 → US20230012345.
 func stripKindCode(id string) string {
@@ -212,17 +238,24 @@ func mergeInto(dst *types.SearchResult, src types.SearchResult) {
 	if isArxivID(src.PreferredAcquisitionID) && !isArxivID(dst.PreferredAcquisitionID) {
 		dst.PreferredAcquisitionID = src.PreferredAcquisitionID
 	}
+	mergeRaw(dst, src)
+	for _, e := range src.Expansions {
+		if !slices.Contains(dst.Expansions, e) {
+			dst.Expansions = append(dst.Expansions, e)
+		}
+	}
 	if dst.Source != src.Source && !strings.Contains(dst.Source, src.Source) {
 		dst.Source = dst.Source + "," + src.Source
 	}
 }
 
-// isArxivID returns true if the string looks like an arXiv ID (e.g. "2301.07041").
+// arxivIDPattern matches arXiv IDs in the current scheme ("2301.07041")
+// and the pre-2007 scheme ("hep-th/9901001", "math.GT/0309136").
+var arxivIDPattern = regexp.MustCompile(`^(?:\d{4}\.\d{4,5}|[a-z]+(?:-[a-z]+)?(?:\.[A-Z]{2})?/\d{7})(?:v\d+)?$`)
+
+// isArxivID returns true if the string looks like an arXiv ID.
 func isArxivID(s string) bool {
-	if len(s) < 9 {
-		return false
-	}
-	return s[4] == '.' && s[0] >= '0' && s[0] <= '9'
+	return arxivIDPattern.MatchString(s)
 }
 
 // normalizeTitle returns a lowercased, punctuation-stripped version of the title (R3.1).
@@ -236,6 +269,15 @@ func normalizeTitle(title string) string {
 	return strings.Join(strings.Fields(b.String()), " ")
 }
 
+// positionScore converts a backend's rank order into a relevance score
+// between 1.0 (first) and 0.1 (last) (R3.5).
+func positionScore(i, total int) float64 {
+	if total <= 1 {
+		return 1.0
+	}
+	return 1.0 - float64(i)/float64(total-1)*0.9
+}
+
 // applyRecencyBias boosts scores for papers published within the window (R3.4).
 func applyRecencyBias(results []types.SearchResult, window time.Duration) {
 	now := time.Now()
@@ -252,6 +294,7 @@ func applyRecencyBias(results []types.SearchResult, window time.Duration) {
 }
 
 // FormatTable writes results as a human-readable table to w (R4.2, R4.5).
+// When the output carries clusters, results are grouped by cluster.
 func FormatTable(out SearchOutput, w io.Writer) {
 	if len(out.Results) == 0 {
 		fmt.Fprintln(w, "No results found.")
@@ -260,33 +303,49 @@ func FormatTable(out SearchOutput, w io.Writer) {
 
 	fmt.Fprintf(w, "%-4s  %-60s  %-20s  %-4s  %-6s  %s\n",
 		"Rank", "Title", "Authors", "Year", "Score", "Source")
-	fmt.Fprintln(w, strings.Repeat("-", 110))
 
-	for i, r := range out.Results {
-		title := r.Title
-		if len(title) > 60 {
-			title = title[:57] + "..."
-		}
-		authors := formatAuthors(r.Authors)
-		year := ""
-		if !r.Date.IsZero() {
-			year = fmt.Sprintf("%d", r.Date.Year())
-		}
-		source := r.Source
-		if isPatentResult(r) {
-			source = "patent"
+	if len(out.Clusters) > 0 {
+		fmt.Fprintln(w)
+		formatClusteredTable(out, w)
+	} else {
+		fmt.Fprintln(w, strings.Repeat("-", 110))
+		for i, r := range out.Results {
+			writeTableRow(w, i+1, r)
 		}
-		fmt.Fprintf(w, "%-4d  %-60s  %-20s  %-4s  %-6.2f  %s\n",
-			i+1, title, authors, year, r.RelevanceScore, source)
 	}
 
 	fmt.Fprintf(w, "\n%d results", len(out.Results))
+	if len(out.Clusters) > 0 {
+		fmt.Fprintf(w, " in %d clusters", len(out.Clusters))
+	}
 	if out.DupsRemoved > 0 {
 		fmt.Fprintf(w, " (%d duplicates removed)", out.DupsRemoved)
 	}
 	fmt.Fprintln(w)
 }
 
+// writeTableRow writes one result line of the table.
+func writeTableRow(w io.Writer, rank int, r types.SearchResult) {
+	title := r.Title
+	if len(title) > 60 {
+		title = title[:57] + "..."
+	}
+	authors := formatAuthors(r.Authors)
+	year := ""
+	if !r.Date.IsZero() {
+		year = fmt.Sprintf("%d", r.Date.Year())
+	}
+	source := r.Source
+	if isPatentResult(r) {
+		source = "patent"
+	}
+	if len(r.Expansions) > 0 && !slices.Contains(r.Expansions, OriginalExpansion) {
+		source += " (via " + strings.Join(r.Expansions, "; ") + ")"
+	}
+	fmt.Fprintf(w, "%-4d  %-60s  %-20s  %-4s  %-6.2f  %s\n",
+		rank, title, authors, year, r.RelevanceScore, source)
+}
+
 // FormatJSON writes results as indented JSON to w (R4.3).
 func FormatJSON(out SearchOutput, w io.Writer) error {
 	enc := json.NewEncoder(w)
@@ -44,7 +44,6 @@ func testCfg() types.SearchConfig {
 			UserAgent: "test/0.1",
 		},
 		MaxResults:        20,
-		InterBackendDelay: 0,
 		RecencyBiasWindow: 2 * 365 * 24 * time.Hour,
 	}
 }
@@ -232,6 +231,9 @@ func TestDedupKey(t *testing.T) {
 	}{
 		{"arxiv paper", types.SearchResult{Identifier: "2301.07041", Source: "arxiv"}, "id:2301.07041"},
 		{"doi paper", types.SearchResult{Identifier: "10.1234/test", Source: "semantic_scholar"}, "id:10.1234/test"},
+		{"doi mixed case", types.SearchResult{Identifier: " 10.1234/TeSt", Source: "openalex"}, "id:10.1234/test"},
+		{"arxiv old style", types.SearchResult{Identifier: "hep-th/9901001", Source: "arxiv"}, "id:hep-th/9901001"},
+		{"arxiv old style subject class", types.SearchResult{Identifier: "math.GT/0309136", Source: "semantic_scholar"}, "id:math/0309136"},
 		{"patent with kind code", types.SearchResult{Identifier: "US7654321B2", Source: "patentsview"}, "patent:US7654321"},
 		{"patent without kind code", types.SearchResult{Identifier: "US7654321", Source: "patentsview"}, "patent:US7654321"},
 		{"empty identifier", types.SearchResult{}, ""},
@@ -445,6 +447,8 @@ func TestExtractArxivID(t *testing.T) {
 		{"http://arxiv.org/abs/1706.03762v5", "1706.03762"},
 		{"http://arxiv.org/abs/2301.12345", "2301.12345"},
 		{"https://arxiv.org/abs/2301.07041v2", "2301.07041"},
+		{"http://arxiv.org/abs/hep-th/9901001v2", "hep-th/9901001"},
+		{"http://arxiv.org/abs/solv-int/9901001", "solv-int/9901001"},
 		{"not a url", ""},
 	}
 	for _, tt := range tests {
@@ -677,6 +681,10 @@ func TestIsArxivID(t *testing.T) {
 	}{
 		{"2301.07041", true},
 		{"1706.03762", true},
+		{"2301.07041v2", true},
+		{"hep-th/9901001", true},
+		{"math.GT/0309136v1", true},
+		{"hep-th/99", false},
 		{"10.1234/foo", false},
 		{"short", false},
 		{"", false},
@@ -6,9 +6,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 
 	"github.com/pdiddy/research-engine/internal/httputil"
@@ -77,11 +77,21 @@ func (b *SemanticScholarBackend) Search(ctx context.Context, query Query, cfg ty
 		return nil, fmt.Errorf("Semantic Scholar API returned HTTP %d", resp.StatusCode)
 	}
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading Semantic Scholar response: %w", err)
+	}
+
 	var sr semanticResponse
-	if err := json.NewDecoder(resp.Body).Decode(&sr); err != nil {
+	if err := json.Unmarshal(body, &sr); err != nil {
 		return nil, fmt.Errorf("parsing Semantic Scholar response: %w", err)
 	}
 
+	var raws []json.RawMessage
+	if cfg.KeepRaw {
+		raws = rawJSONItems(body, "data")
+	}
+
 	total := len(sr.Data)
 	var results []types.SearchResult
 	for i, paper := range sr.Data {
@@ -103,23 +113,30 @@ func (b *SemanticScholarBackend) Search(ctx context.Context, query Query, cfg ty
 			r.Date = time.Date(paper.Year, 1, 1, 0, 0, 0, 0, time.UTC)
 		}
 
-		// Set identifiers: prefer arXiv ID, then DOI (R4.4).
+		// Set identifiers: prefer arXiv ID, then DOI (R4.4), then the
+		// PubMed Central and PubMed IDs of biomedical papers.
 		if paper.ExternalIDs.ArXiv != "" {
 			r.Identifier = paper.ExternalIDs.ArXiv
 			r.PreferredAcquisitionID = paper.ExternalIDs.ArXiv
 		} else if paper.ExternalIDs.DOI != "" {
 			r.Identifier = paper.ExternalIDs.DOI
 			r.PreferredAcquisitionID = paper.ExternalIDs.DOI
+		} else if paper.ExternalIDs.PubMedCentral != "" {
+			r.Identifier = "PMC" + paper.ExternalIDs.PubMedCentral
+			r.PreferredAcquisitionID = r.Identifier
+		} else if paper.ExternalIDs.PubMed != "" {
+			r.Identifier = "PMID:" + paper.ExternalIDs.PubMed
+			r.PreferredAcquisitionID = r.Identifier
 		} else {
 			r.Identifier = paper.PaperID
 			r.PreferredAcquisitionID = paper.PaperID
 		}
 
 		// Position-based relevance score (R3.5).
-		if total > 1 {
-			r.RelevanceScore = 1.0 - float64(i)/float64(total-1)*0.9
-		} else {
-			r.RelevanceScore = 1.0
+		r.RelevanceScore = positionScore(i, total)
+
+		if i < len(raws) {
+			attachRaw(&r, b.Name(), string(raws[i]))
 		}
 
 		results = append(results, r)
@@ -129,17 +146,7 @@ func (b *SemanticScholarBackend) Search(ctx context.Context, query Query, cfg ty
 
 // buildSemanticQuery combines query fields into a search string.
 func buildSemanticQuery(q Query) string {
-	var parts []string
-	if q.FreeText != "" {
-		parts = append(parts, q.FreeText)
-	}
-	if q.Author != "" {
-		parts = append(parts, q.Author)
-	}
-	for _, kw := range q.Keywords {
-		parts = append(parts, kw)
-	}
-	return strings.Join(parts, " ")
+	return plainTextQuery(q)
 }
 
 // buildYearRange returns a Semantic Scholar year filter string (e.g. "2020-2023").
@@ -179,7 +186,9 @@ type semanticAuthor struct {
 }
 
 type semanticExternalIDs struct {
-	DOI      string `json:"DOI"`
-	ArXiv    string `json:"ArXiv"`
-	CorpusID int    `json:"CorpusId"`
+	DOI           string `json:"DOI"`
+	ArXiv         string `json:"ArXiv"`
+	PubMed        string `json:"PubMed"`
+	PubMedCentral string `json:"PubMedCentral"`
+	CorpusID      int    `json:"CorpusId"`
 }
@@ -155,6 +155,18 @@ func TestSemanticSearchIdentifierPreference(t *testing.T) {
 			"10.555/test",
 			"10.555/test",
 		},
+		{
+			"PMCID when no arXiv or DOI",
+			`{"paperId":"jkl","title":"P","authors":[],"externalIds":{"PubMedCentral":"3531190","PubMed":"23193287"}}`,
+			"PMC3531190",
+			"PMC3531190",
+		},
+		{
+			"PMID when no PMCID",
+			`{"paperId":"mno","title":"P","authors":[],"externalIds":{"PubMed":"23193287"}}`,
+			"PMID:23193287",
+			"PMID:23193287",
+		},
 		{
 			"PaperID when no arXiv or DOI",
 			`{"paperId":"ghi789","title":"P","authors":[],"externalIds":{}}`,
@@ -0,0 +1,177 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package search
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+// Triage statuses recorded on query file results.
+const (
+	TriageKeep   = "keep"
+	TriageReject = "reject"
+
+	// TriageUntriaged selects results without a decision when filtering.
+	TriageUntriaged = "untriaged"
+)
+
+// ParseTriageStatus validates a status given to annotate. With filter set
+// it also accepts TriageUntriaged and the empty string (every result).
+func ParseTriageStatus(status string, filter bool) (string, error) {
+	s := strings.ToLower(strings.TrimSpace(status))
+	switch {
+	case s == TriageKeep || s == TriageReject:
+		return s, nil
+	case filter && (s == TriageUntriaged || s == ""):
+		return s, nil
+	case filter:
+		return "", fmt.Errorf("invalid status %q: use keep, reject, or untriaged", status)
+	default:
+		return "", fmt.Errorf("invalid status %q: use keep or reject", status)
+	}
+}
+
+// RankedResult is a query file result with its 1-based rank in the file.
+type RankedResult struct {
+	Rank   int
+	Result types.SearchResult
+}
+
+// Result returns the result whose identifier or preferred acquisition ID
+// matches id (case-insensitively, so DOIs match in any case).
+func (qf *QueryFile) Result(id string) (*types.SearchResult, error) {
+	i := findResult(qf.Results, id)
+	if i < 0 {
+		return nil, fmt.Errorf("no result with identifier %q in query file", id)
+	}
+	return &qf.Results[i], nil
+}
+
+// Annotate records a triage decision on the result identified by id (see
+// Result). An existing decision is replaced; reviewers' screening
+// decisions are kept.
+func (qf *QueryFile) Annotate(id, status, note string, now time.Time) (*types.SearchResult, error) {
+	r, err := qf.Result(id)
+	if err != nil {
+		return nil, err
+	}
+	t := &types.Triage{Status: status, Note: note, Updated: now}
+	if r.Triage != nil {
+		t.Screening = r.Triage.Screening
+	}
+	r.Triage = t
+	return r, nil
+}
+
+// Filter returns the results with the given triage status in rank order.
+// An empty status returns every result.
+func (qf *QueryFile) Filter(status string) []RankedResult {
+	var out []RankedResult
+	for i, r := range qf.Results {
+		if status != "" && triageStatus(r) != status {
+			continue
+		}
+		out = append(out, RankedResult{Rank: i + 1, Result: r})
+	}
+	return out
+}
+
+// AcquisitionIDs returns the preferred acquisition ID of the results
+// acquire --from-query downloads: those with the given triage status (all
+// results when status is empty), in rank order, at most top of them when
+// top is positive. Results without an ID fall back to their identifier,
+// except Lens-only identifiers, which no source can download.
+func (qf *QueryFile) AcquisitionIDs(status string, top int) []string {
+	var ids []string
+	for _, rr := range qf.Filter(status) {
+		if top > 0 && len(ids) == top {
+			break
+		}
+		id := rr.Result.PreferredAcquisitionID
+		if id == "" {
+			id = rr.Result.Identifier
+		}
+		if id == "" || strings.HasPrefix(id, lensIDPrefix) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Save writes the query file back to path.
+func (qf *QueryFile) Save(path string) error {
+	data, err := yaml.Marshal(qf)
+	if err != nil {
+		return fmt.Errorf("marshaling query file: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// FormatTriageTable writes results with their rank, triage status, and
+// note to w.
+func FormatTriageTable(results []RankedResult, w io.Writer) {
+	if len(results) == 0 {
+		fmt.Fprintln(w, "No matching results.")
+		return
+	}
+	fmt.Fprintf(w, "%-4s  %-9s  %-24s  %-50s  %s\n", "Rank", "Status", "ID", "Title", "Note")
+	fmt.Fprintln(w, strings.Repeat("-", 110))
+	for _, rr := range results {
+		r := rr.Result
+		id := r.PreferredAcquisitionID
+		if id == "" {
+			id = r.Identifier
+		}
+		title := r.Title
+		if len(title) > 50 {
+			title = title[:47] + "..."
+		}
+		note := ""
+		if r.Triage != nil {
+			note = r.Triage.Note
+		}
+		fmt.Fprintf(w, "%-4d  %-9s  %-24s  %-50s  %s\n", rr.Rank, triageStatus(r), id, title, note)
+	}
+	fmt.Fprintf(w, "\n%d results\n", len(results))
+}
+
+// triageStatus returns the result's status, or TriageUntriaged.
+func triageStatus(r types.SearchResult) string {
+	if r.Triage == nil || r.Triage.Status == "" {
+		return TriageUntriaged
+	}
+	return r.Triage.Status
+}
+
+// findResult returns the index of the result identified by id, or -1.
+func findResult(results []types.SearchResult, id string) int {
+	id = strings.TrimSpace(id)
+	for i, r := range results {
+		if strings.EqualFold(r.Identifier, id) || strings.EqualFold(r.PreferredAcquisitionID, id) {
+			return i
+		}
+	}
+	return -1
+}
+
+// carryTriage copies decisions from a previous run's results onto matching
+// results of a new run, so re-running a saved search keeps the shortlist.
+func carryTriage(prev, next []types.SearchResult) {
+	for i := range next {
+		if next[i].Triage != nil {
+			continue
+		}
+		if j := findResult(prev, next[i].Identifier); j >= 0 && prev[j].Triage != nil {
+			next[i].Triage = prev[j].Triage
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package search
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pdiddy/research-engine/pkg/types"
+)
+
+func triageQueryFile() *QueryFile {
+	return &QueryFile{Results: []types.SearchResult{
+		{Identifier: "1706.03762", PreferredAcquisitionID: "1706.03762", Title: "Attention Is All You Need"},
+		{Identifier: "10.1145/abc", PreferredAcquisitionID: "10.1145/abc", Title: "A Published Paper"},
+		{Identifier: "https://example.org/p", Title: "Web Only"},
+	}}
+}
+
+func TestParseTriageStatus(t *testing.T) {
+	if s, err := ParseTriageStatus(" Keep ", false); err != nil || s != TriageKeep {
+		t.Errorf("ParseTriageStatus(Keep) = %q, %v", s, err)
+	}
+	if _, err := ParseTriageStatus("untriaged", false); err == nil {
+		t.Error("untriaged should not be accepted for annotate")
+	}
+	if s, err := ParseTriageStatus("untriaged", true); err != nil || s != TriageUntriaged {
+		t.Errorf("ParseTriageStatus(untriaged, filter) = %q, %v", s, err)
+	}
+	if _, err := ParseTriageStatus("maybe", true); err == nil {
+		t.Error("expected error for unknown status")
+	}
+}
+
+func TestAnnotateAndFilter(t *testing.T) {
+	qf := triageQueryFile()
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := qf.Annotate("10.1145/ABC", TriageKeep, "core method", now); err != nil {
+		t.Fatalf("Annotate DOI: %v", err)
+	}
+	if _, err := qf.Annotate("https://example.org/p", TriageReject, "", now); err != nil {
+		t.Fatalf("Annotate URL: %v", err)
+	}
+	if _, err := qf.Annotate("9999.99999", TriageKeep, "", now); err == nil {
+		t.Error("expected error for unknown identifier")
+	}
+
+	kept := qf.Filter(TriageKeep)
+	if len(kept) != 1 || kept[0].Rank != 2 || kept[0].Result.Triage.Note != "core method" {
+		t.Errorf("Filter(keep) = %+v", kept)
+	}
+	if got := qf.Filter(TriageUntriaged); len(got) != 1 || got[0].Rank != 1 {
+		t.Errorf("Filter(untriaged) = %+v", got)
+	}
+	if got := qf.Filter(""); len(got) != 3 {
+		t.Errorf("Filter(\"\") returned %d results, want 3", len(got))
+	}
+
+	// Re-annotating replaces the decision.
+	if _, err := qf.Annotate("10.1145/abc", TriageReject, "", now); err != nil {
+		t.Fatal(err)
+	}
+	if got := qf.AcquisitionIDs(TriageKeep, 0); len(got) != 0 {
+		t.Errorf("AcquisitionIDs(keep) after reject = %v", got)
+	}
+}
+
+func TestAcquisitionIDs(t *testing.T) {
+	qf := triageQueryFile()
+	now := time.Now()
+	qf.Annotate("1706.03762", TriageKeep, "", now)
+	qf.Annotate("https://example.org/p", TriageKeep, "", now)
+
+	tests := []struct {
+		name   string
+		status string
+		top    int
+		want   []string
+	}{
+		{"kept", TriageKeep, 0, []string{"1706.03762", "https://example.org/p"}},
+		{"all", "", 0, []string{"1706.03762", "10.1145/abc", "https://example.org/p"}},
+		{"top two", "", 2, []string{"1706.03762", "10.1145/abc"}},
+		{"top one kept", TriageKeep, 1, []string{"1706.03762"}},
+		{"untriaged", TriageUntriaged, 0, []string{"10.1145/abc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := qf.AcquisitionIDs(tt.status, tt.top); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AcquisitionIDs(%q, %d) = %v, want %v", tt.status, tt.top, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteQueryFileKeepsTriage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "query.yaml")
+	out := SearchOutput{Results: triageQueryFile().Results}
+	if err := WriteQueryFile(path, Query{FreeText: "q"}, types.SearchConfig{}, false, out); err != nil {
+		t.Fatal(err)
+	}
+
+	qf, err := ReadQueryFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := qf.Annotate("1706.03762", TriageKeep, "baseline", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := qf.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// A re-run returns the kept paper at a different rank.
+	rerun := SearchOutput{Results: []types.SearchResult{
+		{Identifier: "2401.00001", Title: "New Paper"},
+		{Identifier: "1706.03762", Title: "Attention Is All You Need"},
+	}}
+	if err := WriteQueryFile(path, Query{FreeText: "q"}, types.SearchConfig{}, false, rerun); err != nil {
+		t.Fatal(err)
+	}
+	qf, err = ReadQueryFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qf.Results[0].Triage != nil {
+		t.Errorf("new result should be untriaged, got %+v", qf.Results[0].Triage)
+	}
+	if tr := qf.Results[1].Triage; tr == nil || tr.Status != TriageKeep || tr.Note != "baseline" {
+		t.Errorf("carried triage = %+v", tr)
+	}
+}
+
+func TestFormatTriageTable(t *testing.T) {
+	qf := triageQueryFile()
+	qf.Annotate("1706.03762", TriageKeep, "baseline", time.Now())
+
+	var buf bytes.Buffer
+	FormatTriageTable(qf.Filter(""), &buf)
+	out := buf.String()
+	for _, want := range []string{"keep", "baseline", "untriaged", "10.1145/abc", "3 results"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	FormatTriageTable(nil, &buf)
+	if !strings.Contains(buf.String(), "No matching results") {
+		t.Errorf("empty table = %q", buf.String())
+	}
+}
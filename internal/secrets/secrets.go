@@ -4,7 +4,8 @@
 // Each file in the directory represents one secret: the filename is the key name and the
 // file contents (trimmed) are the value.
 //
-// Supported key files: patentsview-api-key, semantic-scholar-api-key, anthropic-api-key, openalex-email.
+// Supported key files: patentsview-api-key, semantic-scholar-api-key, anthropic-api-key, openalex-email,
+// lens-api-key, zotero-api-key, epo-ops-key, epo-ops-secret.
 package secrets
 
 import (
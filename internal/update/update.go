@@ -0,0 +1,263 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+// Package update replaces the running research-engine binary with the
+// latest release. Releases publish one binary per platform, a SHA256SUMS
+// file, and SHA256SUMS.sig, a base64 Ed25519 signature of SHA256SUMS made with
+// the key whose public half is embedded in the binary at build time.
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// DefaultFeedURL is the release feed checked by Check.
+const DefaultFeedURL = "https://api.github.com/repos/pdiddy/research-engine/releases/latest"
+
+const (
+	binName       = "research-engine"
+	checksumsFile = "SHA256SUMS"
+	signatureFile = "SHA256SUMS.sig"
+
+	// maxAssetSize bounds downloads so a bad feed cannot fill the disk.
+	maxAssetSize = 256 << 20
+)
+
+// Release is the latest release advertised by the feed.
+type Release struct {
+	Version string
+	// Assets maps asset file names to download URLs.
+	Assets map[string]string
+}
+
+// feedRelease is the subset of the GitHub release JSON the feed returns.
+type feedRelease struct {
+	TagName string `json:"tag_name"`
+	Assets  []struct {
+		Name string `json:"name"`
+		URL  string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// Check fetches the latest release from feedURL.
+func Check(ctx context.Context, client *http.Client, feedURL string) (*Release, error) {
+	data, err := fetch(ctx, client, feedURL)
+	if err != nil {
+		return nil, fmt.Errorf("checking release feed: %w", err)
+	}
+	var fr feedRelease
+	if err := json.Unmarshal(data, &fr); err != nil {
+		return nil, fmt.Errorf("parsing release feed: %w", err)
+	}
+	if fr.TagName == "" {
+		return nil, errors.New("release feed has no tag_name")
+	}
+	rel := &Release{Version: fr.TagName, Assets: make(map[string]string, len(fr.Assets))}
+	for _, a := range fr.Assets {
+		rel.Assets[a.Name] = a.URL
+	}
+	return rel, nil
+}
+
+// AssetName returns the release file name of the binary for a platform,
+// matching what mage release produces.
+func AssetName(version, goos, goarch string) string {
+	name := fmt.Sprintf("%s_%s_%s_%s", binName, version, goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Newer reports whether release is a later version than current. Versions
+// are compared as dotted numbers with an optional leading "v" and
+// "-suffix"; a current version that does not parse (such as "dev") is
+// older than any release.
+func Newer(release, current string) bool {
+	r, ok := parseVersion(release)
+	if !ok {
+		return false
+	}
+	c, ok := parseVersion(current)
+	if !ok {
+		return true
+	}
+	for i := 0; i < len(r) || i < len(c); i++ {
+		var rv, cv int
+		if i < len(r) {
+			rv = r[i]
+		}
+		if i < len(c) {
+			cv = c[i]
+		}
+		if rv != cv {
+			return rv > cv
+		}
+	}
+	return false
+}
+
+func parseVersion(v string) ([]int, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	if v == "" {
+		return nil, false
+	}
+	var parts []int
+	for _, p := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
+
+// ParsePublicKey decodes a base64 Ed25519 public key as embedded by
+// mage release.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	if s == "" {
+		return nil, errors.New("this build has no release signing key; update is only available in release binaries")
+	}
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("embedded release signing key is invalid")
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// Download fetches the release binary for goos/goarch, verifies the
+// signature on SHA256SUMS with key, and checks the binary against its
+// listed checksum. It returns the verified binary.
+func Download(ctx context.Context, client *http.Client, rel *Release, key ed25519.PublicKey, goos, goarch string) ([]byte, error) {
+	name := AssetName(rel.Version, goos, goarch)
+	for _, asset := range []string{name, checksumsFile, signatureFile} {
+		if rel.Assets[asset] == "" {
+			return nil, fmt.Errorf("release %s has no %s", rel.Version, asset)
+		}
+	}
+
+	sums, err := fetch(ctx, client, rel.Assets[checksumsFile])
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", checksumsFile, err)
+	}
+	sig, err := fetch(ctx, client, rel.Assets[signatureFile])
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", signatureFile, err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(sig)))
+	if err != nil || !ed25519.Verify(key, sums, raw) {
+		return nil, fmt.Errorf("%s signature does not verify: refusing to update", checksumsFile)
+	}
+	want, err := ChecksumFor(sums, name)
+	if err != nil {
+		return nil, err
+	}
+
+	bin, err := fetch(ctx, client, rel.Assets[name])
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", name, err)
+	}
+	got := sha256.Sum256(bin)
+	if hex.EncodeToString(got[:]) != want {
+		return nil, fmt.Errorf("%s checksum mismatch: refusing to update", name)
+	}
+	return bin, nil
+}
+
+// ChecksumFor returns the hex SHA-256 listed for name in a sha256sum-style
+// checksum file.
+func ChecksumFor(sums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s does not list %s", checksumsFile, name)
+}
+
+// Replace swaps the binary at exePath for bin. The new binary is written
+// next to the old one and renamed into place, so a failure leaves the old
+// binary working. The old binary is renamed aside first because Windows
+// cannot overwrite a running executable; the leftover ".old" file is
+// removed where the platform allows.
+func Replace(exePath string, bin []byte) error {
+	dir := filepath.Dir(exePath)
+	tmp, err := os.CreateTemp(dir, "."+binName+"-*.new")
+	if err != nil {
+		return fmt.Errorf("writing new binary next to %s: %w", exePath, err)
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(bin); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0o755); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("making new binary executable: %w", err)
+	}
+
+	oldPath := exePath + ".old"
+	os.Remove(oldPath)
+	if err := os.Rename(exePath, oldPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("moving current binary aside: %w", err)
+	}
+	if err := os.Rename(tmpPath, exePath); err != nil {
+		os.Rename(oldPath, exePath)
+		os.Remove(tmpPath)
+		return fmt.Errorf("installing new binary: %w", err)
+	}
+	if runtime.GOOS != "windows" {
+		os.Remove(oldPath)
+	}
+	return nil
+}
+
+func fetch(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAssetSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxAssetSize {
+		return nil, fmt.Errorf("GET %s: response larger than %d bytes", url, maxAssetSize)
+	}
+	return data, nil
+}
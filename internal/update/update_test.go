@@ -0,0 +1,145 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package update
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		release, current string
+		want             bool
+	}{
+		{"v0.3.0", "v0.2.9", true},
+		{"v0.2.0", "v0.2.0", false},
+		{"v0.2.0", "v0.10.0", false},
+		{"v1.0", "v0.9.9", true},
+		{"v1.0.1", "1.0", true},
+		{"v0.2.0", "dev", true},
+		{"v0.2.0", "v0.1.0-3-gabc123", true},
+		{"nightly", "v0.1.0", false},
+	}
+	for _, tt := range tests {
+		if got := Newer(tt.release, tt.current); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.release, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	if _, err := ParsePublicKey(""); err == nil || !strings.Contains(err.Error(), "no release signing key") {
+		t.Errorf("empty key error = %v", err)
+	}
+	if _, err := ParsePublicKey("c2hvcnQ="); err == nil {
+		t.Error("expected error for short key")
+	}
+}
+
+// releaseServer serves a feed and assets for version v0.2.0 signed with
+// priv. tamper modifies the served binary after the checksums are made.
+func releaseServer(t *testing.T, priv ed25519.PrivateKey, tamper bool) *httptest.Server {
+	t.Helper()
+	name := AssetName("v0.2.0", "linux", "amd64")
+	bin := []byte("new binary")
+	sum := sha256.Sum256(bin)
+	sums := fmt.Sprintf("%x  %s\n", sum, name)
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(sums)))
+	if tamper {
+		bin = []byte("evil binary")
+	}
+
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	mux.HandleFunc("/feed", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"tag_name":"v0.2.0","assets":[
+			{"name":%q,"browser_download_url":"%s/bin"},
+			{"name":"SHA256SUMS","browser_download_url":"%s/sums"},
+			{"name":"SHA256SUMS.sig","browser_download_url":"%s/sig"}]}`,
+			name, srv.URL, srv.URL, srv.URL)
+	})
+	mux.HandleFunc("/bin", func(w http.ResponseWriter, r *http.Request) { w.Write(bin) })
+	mux.HandleFunc("/sums", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(sums)) })
+	mux.HandleFunc("/sig", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(sig + "\n")) })
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDownload(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	ctx := context.Background()
+
+	t.Run("verified", func(t *testing.T) {
+		srv := releaseServer(t, priv, false)
+		rel, err := Check(ctx, srv.Client(), srv.URL+"/feed")
+		if err != nil {
+			t.Fatal(err)
+		}
+		bin, err := Download(ctx, srv.Client(), rel, pub, "linux", "amd64")
+		if err != nil {
+			t.Fatalf("Download: %v", err)
+		}
+		if string(bin) != "new binary" {
+			t.Errorf("binary = %q", bin)
+		}
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		srv := releaseServer(t, priv, false)
+		rel, _ := Check(ctx, srv.Client(), srv.URL+"/feed")
+		if _, err := Download(ctx, srv.Client(), rel, otherPub, "linux", "amd64"); err == nil || !strings.Contains(err.Error(), "signature") {
+			t.Errorf("error = %v, want signature failure", err)
+		}
+	})
+
+	t.Run("tampered binary", func(t *testing.T) {
+		srv := releaseServer(t, priv, true)
+		rel, _ := Check(ctx, srv.Client(), srv.URL+"/feed")
+		if _, err := Download(ctx, srv.Client(), rel, pub, "linux", "amd64"); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+			t.Errorf("error = %v, want checksum mismatch", err)
+		}
+	})
+
+	t.Run("missing platform", func(t *testing.T) {
+		srv := releaseServer(t, priv, false)
+		rel, _ := Check(ctx, srv.Client(), srv.URL+"/feed")
+		if _, err := Download(ctx, srv.Client(), rel, pub, "plan9", "386"); err == nil {
+			t.Error("expected error for platform without a binary")
+		}
+	})
+}
+
+func TestReplace(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "research-engine")
+	if err := os.WriteFile(exe, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Replace(exe, []byte("new")); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	data, err := os.ReadFile(exe)
+	if err != nil || string(data) != "new" {
+		t.Errorf("binary = %q, %v", data, err)
+	}
+	info, err := os.Stat(exe)
+	if err != nil || info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("new binary not executable: %v, %v", info.Mode(), err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(exe))
+	if len(entries) != 1 {
+		t.Errorf("leftover files after replace: %v", entries)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+// Package usage keeps a local log of CLI runs for the researcher's own
+// methodology reporting: which commands ran, how long they took, and how
+// the corpus grew. The log is a JSON Lines file in the project directory
+// and is never sent anywhere.
+package usage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultPath is the usage log location relative to the project directory.
+const DefaultPath = ".research-engine/usage.jsonl"
+
+// Corpus counts the files at each pipeline stage.
+type Corpus struct {
+	PDFs      int `json:"pdfs"`
+	Markdown  int `json:"markdown"`
+	Extracted int `json:"extracted"`
+}
+
+// Entry is one logged command run.
+type Entry struct {
+	Time     time.Time     `json:"time"`
+	Command  string        `json:"command"`
+	Duration time.Duration `json:"duration_ns"`
+	OK       bool          `json:"ok"`
+	Corpus   Corpus        `json:"corpus"`
+}
+
+// CountCorpus counts PDFs in papersDir/raw, Markdown files in
+// papersDir/markdown, and extraction results in knowledgeDir/extracted.
+// Missing directories count as empty.
+func CountCorpus(papersDir, knowledgeDir string) Corpus {
+	return Corpus{
+		PDFs:      countFiles(filepath.Join(papersDir, "raw"), ".pdf"),
+		Markdown:  countFiles(filepath.Join(papersDir, "markdown"), ".md"),
+		Extracted: countFiles(filepath.Join(knowledgeDir, "extracted"), "-items.yaml"),
+	}
+}
+
+func countFiles(dir, suffix string) int {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), suffix) {
+			n++
+		}
+	}
+	return n
+}
+
+// Append adds e to the log at path, creating the file and its directory.
+func Append(path string, e Entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshaling usage entry: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// Read returns the entries logged at path in file order. A missing log
+// has no entries; malformed lines are skipped.
+func Read(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// CommandStats aggregates the runs of one command.
+type CommandStats struct {
+	Command  string        `json:"command"`
+	Runs     int           `json:"runs"`
+	Failed   int           `json:"failed"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// Snapshot is the corpus size at the end of a day.
+type Snapshot struct {
+	Date   string `json:"date"`
+	Corpus Corpus `json:"corpus"`
+}
+
+// Report summarizes a usage log.
+type Report struct {
+	First    time.Time      `json:"first"`
+	Last     time.Time      `json:"last"`
+	Runs     int            `json:"runs"`
+	Duration time.Duration  `json:"duration_ns"`
+	Commands []CommandStats `json:"commands"`
+	Growth   []Snapshot     `json:"growth"`
+}
+
+// Summarize aggregates entries at or after since (all entries when since
+// is zero). Commands are ordered by total time, most first; corpus growth
+// has one snapshot per day with runs, taken from the day's last run.
+func Summarize(entries []Entry, since time.Time) Report {
+	var r Report
+	byCommand := make(map[string]*CommandStats)
+	for _, e := range entries {
+		if e.Time.Before(since) {
+			continue
+		}
+		if r.Runs == 0 || e.Time.Before(r.First) {
+			r.First = e.Time
+		}
+		if e.Time.After(r.Last) {
+			r.Last = e.Time
+		}
+		r.Runs++
+		r.Duration += e.Duration
+
+		cs := byCommand[e.Command]
+		if cs == nil {
+			cs = &CommandStats{Command: e.Command}
+			byCommand[e.Command] = cs
+		}
+		cs.Runs++
+		cs.Duration += e.Duration
+		if !e.OK {
+			cs.Failed++
+		}
+
+		date := e.Time.Local().Format("2006-01-02")
+		if n := len(r.Growth); n > 0 && r.Growth[n-1].Date == date {
+			r.Growth[n-1].Corpus = e.Corpus
+		} else {
+			r.Growth = append(r.Growth, Snapshot{Date: date, Corpus: e.Corpus})
+		}
+	}
+
+	for _, cs := range byCommand {
+		r.Commands = append(r.Commands, *cs)
+	}
+	sort.Slice(r.Commands, func(i, j int) bool {
+		if r.Commands[i].Duration != r.Commands[j].Duration {
+			return r.Commands[i].Duration > r.Commands[j].Duration
+		}
+		return r.Commands[i].Command < r.Commands[j].Command
+	})
+	return r
+}
+
+// FormatReport writes r as text tables.
+func FormatReport(r Report, w io.Writer) {
+	if r.Runs == 0 {
+		fmt.Fprintln(w, "No usage recorded.")
+		return
+	}
+	fmt.Fprintf(w, "%d runs from %s to %s, %s total\n\n",
+		r.Runs, r.First.Local().Format("2006-01-02"), r.Last.Local().Format("2006-01-02"), r.Duration.Round(time.Second))
+
+	fmt.Fprintf(w, "%-28s  %6s  %6s  %12s\n", "Command", "Runs", "Failed", "Time")
+	fmt.Fprintln(w, strings.Repeat("-", 58))
+	for _, cs := range r.Commands {
+		fmt.Fprintf(w, "%-28s  %6d  %6d  %12s\n", cs.Command, cs.Runs, cs.Failed, cs.Duration.Round(time.Second))
+	}
+
+	fmt.Fprintf(w, "\n%-10s  %6s  %8s  %9s\n", "Date", "PDFs", "Markdown", "Extracted")
+	fmt.Fprintln(w, strings.Repeat("-", 39))
+	for _, s := range r.Growth {
+		fmt.Fprintf(w, "%-10s  %6d  %8d  %9d\n", s.Date, s.Corpus.PDFs, s.Corpus.Markdown, s.Corpus.Extracted)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package usage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "usage.jsonl")
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	for i, cmd := range []string{"search", "acquire"} {
+		e := Entry{Time: now.Add(time.Duration(i) * time.Minute), Command: cmd, Duration: time.Second, OK: true}
+		if err := Append(path, e); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	entries, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[1].Command != "acquire" || entries[1].Duration != time.Second {
+		t.Errorf("entries = %+v", entries)
+	}
+
+	if entries, err := Read(filepath.Join(t.TempDir(), "missing.jsonl")); err != nil || entries != nil {
+		t.Errorf("missing log = %v, %v", entries, err)
+	}
+}
+
+func TestCountCorpus(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{"papers/raw/a.pdf", "papers/raw/b.pdf", "papers/markdown/a.md", "knowledge/extracted/a-items.yaml", "papers/raw/notes.txt"}
+	for _, f := range files {
+		p := filepath.Join(dir, f)
+		os.MkdirAll(filepath.Dir(p), 0o755)
+		if err := os.WriteFile(p, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := CountCorpus(filepath.Join(dir, "papers"), filepath.Join(dir, "knowledge"))
+	if got != (Corpus{PDFs: 2, Markdown: 1, Extracted: 1}) {
+		t.Errorf("CountCorpus = %+v", got)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	day1 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local)
+	day2 := day1.AddDate(0, 0, 1)
+	entries := []Entry{
+		{Time: day1, Command: "search", Duration: 2 * time.Second, OK: true, Corpus: Corpus{PDFs: 0}},
+		{Time: day1.Add(time.Hour), Command: "acquire", Duration: 30 * time.Second, OK: false, Corpus: Corpus{PDFs: 4}},
+		{Time: day2, Command: "acquire", Duration: 10 * time.Second, OK: true, Corpus: Corpus{PDFs: 9, Markdown: 2}},
+	}
+
+	r := Summarize(entries, time.Time{})
+	if r.Runs != 3 || r.Duration != 42*time.Second {
+		t.Errorf("Runs = %d, Duration = %v", r.Runs, r.Duration)
+	}
+	if len(r.Commands) != 2 || r.Commands[0].Command != "acquire" || r.Commands[0].Runs != 2 || r.Commands[0].Failed != 1 {
+		t.Errorf("Commands = %+v", r.Commands)
+	}
+	if len(r.Growth) != 2 || r.Growth[0].Corpus.PDFs != 4 || r.Growth[1].Corpus.Markdown != 2 {
+		t.Errorf("Growth = %+v", r.Growth)
+	}
+
+	if r := Summarize(entries, day2.Add(-time.Minute)); r.Runs != 1 || !r.First.Equal(day2) {
+		t.Errorf("since day2: Runs = %d, First = %v", r.Runs, r.First)
+	}
+
+	var buf bytes.Buffer
+	FormatReport(r, &buf)
+	for _, want := range []string{"3 runs", "acquire", "2026-03-02"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("report missing %q:\n%s", want, buf.String())
+		}
+	}
+}
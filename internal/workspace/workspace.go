@@ -0,0 +1,136 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+// Package workspace keeps the registry of named research workspaces. A
+// workspace is a project directory with its own papers/, knowledge/, and
+// output/ directories, config file, and secrets; the registry lets one
+// machine switch between unrelated projects by name instead of by
+// changing directory. The registry is a YAML file in the user's
+// configuration directory.
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// FileName is the registry's file name in ~/.config/research-engine/.
+const FileName = "workspaces.yaml"
+
+// Workspace is a registered project directory.
+type Workspace struct {
+	Name        string `yaml:"name" json:"name"`
+	Path        string `yaml:"path" json:"path"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// Registry is the set of registered workspaces, ordered by name.
+type Registry struct {
+	Workspaces []Workspace `yaml:"workspaces"`
+
+	path string
+}
+
+// validName matches workspace names: letters, digits, '.', '_', and '-',
+// starting with a letter or digit.
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// DefaultPath returns the registry's location,
+// ~/.config/research-engine/workspaces.yaml.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("locating home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "research-engine", FileName), nil
+}
+
+// Load reads the registry at path. A missing file is an empty registry.
+func Load(path string) (*Registry, error) {
+	r := &Registry{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading workspace registry: %w", err)
+	}
+	if err := yaml.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("parsing workspace registry %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Save writes the registry back to the file it was loaded from.
+func (r *Registry) Save() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("creating registry directory: %w", err)
+	}
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("encoding workspace registry: %w", err)
+	}
+	if err := os.WriteFile(r.path, data, 0o644); err != nil {
+		return fmt.Errorf("writing workspace registry: %w", err)
+	}
+	return nil
+}
+
+// Add registers dir as the workspace name and returns it. The directory
+// is recorded as an absolute path and created if it does not exist. A
+// name or directory already registered is an error.
+func (r *Registry) Add(name, dir, description string) (Workspace, error) {
+	if !validName.MatchString(name) {
+		return Workspace{}, fmt.Errorf("invalid workspace name %q: use letters, digits, '.', '_', and '-'", name)
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return Workspace{}, fmt.Errorf("resolving %s: %w", dir, err)
+	}
+	for _, w := range r.Workspaces {
+		if w.Name == name {
+			return Workspace{}, fmt.Errorf("workspace %q already exists at %s", name, w.Path)
+		}
+		if w.Path == abs {
+			return Workspace{}, fmt.Errorf("%s is already registered as workspace %q", abs, w.Name)
+		}
+	}
+	if err := os.MkdirAll(abs, 0o755); err != nil {
+		return Workspace{}, fmt.Errorf("creating workspace directory: %w", err)
+	}
+
+	w := Workspace{Name: name, Path: abs, Description: description}
+	r.Workspaces = append(r.Workspaces, w)
+	sort.Slice(r.Workspaces, func(i, j int) bool { return r.Workspaces[i].Name < r.Workspaces[j].Name })
+	return w, nil
+}
+
+// Remove unregisters a workspace; its directory is left alone.
+func (r *Registry) Remove(name string) error {
+	for i, w := range r.Workspaces {
+		if w.Name == name {
+			r.Workspaces = append(r.Workspaces[:i], r.Workspaces[i+1:]...)
+			return nil
+		}
+	}
+	return r.notFound(name)
+}
+
+// Lookup returns the workspace registered as name.
+func (r *Registry) Lookup(name string) (Workspace, error) {
+	for _, w := range r.Workspaces {
+		if w.Name == name {
+			return w, nil
+		}
+	}
+	return Workspace{}, r.notFound(name)
+}
+
+func (r *Registry) notFound(name string) error {
+	return fmt.Errorf("workspace %q is not registered in %s (see workspace list)", name, r.path)
+}
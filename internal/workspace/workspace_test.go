@@ -0,0 +1,92 @@
+// Copyright Mesh Intelligence Inc., 2026. All rights reserved.
+
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config", FileName)
+
+	r, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load of a missing registry: %v", err)
+	}
+	if len(r.Workspaces) != 0 {
+		t.Fatalf("missing registry has %d workspaces", len(r.Workspaces))
+	}
+
+	survey := filepath.Join(dir, "projects", "survey")
+	if _, err := r.Add("survey", survey, "LLM survey"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(survey); err != nil {
+		t.Errorf("workspace directory not created: %v", err)
+	}
+	if _, err := r.Add("thesis", filepath.Join(dir, "thesis"), ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Workspaces) != 2 || r.Workspaces[0].Name != "survey" || r.Workspaces[0].Description != "LLM survey" {
+		t.Errorf("reloaded workspaces = %+v", r.Workspaces)
+	}
+	w, err := r.Lookup("thesis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !filepath.IsAbs(w.Path) {
+		t.Errorf("path %q is not absolute", w.Path)
+	}
+
+	if err := r.Remove("survey"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Lookup("survey"); err == nil || !strings.Contains(err.Error(), "not registered") {
+		t.Errorf("err = %v, want survey not registered", err)
+	}
+	if _, err := os.Stat(survey); err != nil {
+		t.Errorf("removing the workspace deleted its directory: %v", err)
+	}
+}
+
+func TestAddRejects(t *testing.T) {
+	dir := t.TempDir()
+	r, _ := Load(filepath.Join(dir, FileName))
+	if _, err := r.Add("a", filepath.Join(dir, "a"), ""); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct{ name, dir string }{
+		{"a", filepath.Join(dir, "other")},
+		{"b", filepath.Join(dir, "a")},
+		{"", filepath.Join(dir, "c")},
+		{"has space", filepath.Join(dir, "d")},
+		{"-flag", filepath.Join(dir, "e")},
+	} {
+		if _, err := r.Add(tt.name, tt.dir, ""); err == nil {
+			t.Errorf("Add(%q, %q) accepted", tt.name, tt.dir)
+		}
+	}
+	if err := r.Remove("missing"); err == nil {
+		t.Error("removed an unregistered workspace")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	os.WriteFile(path, []byte("workspaces: [unclosed"), 0o644)
+	if _, err := Load(path); err == nil {
+		t.Error("invalid registry loaded")
+	}
+}
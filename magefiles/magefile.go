@@ -5,10 +5,17 @@
 package main
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/pdiddy/research-engine/internal/draft"
 )
@@ -41,13 +48,36 @@ const (
 	cmdPkg  = "./cmd/research-engine"
 )
 
-// Build compiles the CLI binary into bin/.
+// sqliteBuild returns the build tags and cgo setting for the SQLite driver
+// SQLITE_DRIVER selects: "cgo" (the default) for mattn/go-sqlite3 with
+// FTS5 compiled in, or "purego" for modernc.org/sqlite, which needs no C
+// compiler and cross-compiles to static binaries.
+func sqliteBuild() (tags string, cgo bool, err error) {
+	switch d := os.Getenv("SQLITE_DRIVER"); d {
+	case "", "cgo":
+		return "sqlite_fts5", true, nil
+	case "purego":
+		return "sqlite_purego", false, nil
+	default:
+		return "", false, fmt.Errorf("unknown SQLITE_DRIVER %q (use cgo or purego)", d)
+	}
+}
+
+// Build compiles the CLI binary into bin/. Set SQLITE_DRIVER=purego to
+// build without cgo.
 func Build() error {
+	tags, cgo, err := sqliteBuild()
+	if err != nil {
+		return err
+	}
 	if err := os.MkdirAll(binDir, 0o755); err != nil {
 		return fmt.Errorf("creating %s: %w", binDir, err)
 	}
 	out := filepath.Join(binDir, binName)
-	cmd := exec.Command("go", "build", "-tags", "sqlite_fts5", "-o", out, cmdPkg)
+	cmd := exec.Command("go", "build", "-tags", tags, "-o", out, cmdPkg)
+	if !cgo {
+		cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
+	}
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
@@ -57,9 +87,220 @@ func Build() error {
 	return nil
 }
 
-// Test runs all Go tests with the sqlite_fts5 build tag.
+const distDir = "dist"
+
+// releaseTarget is one platform of the release matrix. zigTarget is the
+// triple passed to "zig cc", which serves as the cgo C compiler when
+// cross-compiling the SQLite driver.
+type releaseTarget struct {
+	goos, goarch, zigTarget string
+}
+
+// releaseTargets lists the platforms Release builds. Linux binaries link
+// musl statically so they run on any distribution.
+var releaseTargets = []releaseTarget{
+	{"linux", "amd64", "x86_64-linux-musl"},
+	{"linux", "arm64", "aarch64-linux-musl"},
+	{"darwin", "amd64", "x86_64-macos"},
+	{"darwin", "arm64", "aarch64-macos"},
+	{"windows", "amd64", "x86_64-windows-gnu"},
+	{"windows", "arm64", "aarch64-windows-gnu"},
+}
+
+// Release cross-compiles a self-contained binary per platform into dist/,
+// with SQLite and FTS5 compiled in, and writes dist/SHA256SUMS. The version
+// is embedded in the binary (research-engine --version).
+//
+// RELEASE_SIGNING_KEY holds the base64 Ed25519 seed made by mage keygen.
+// When set, SHA256SUMS is signed into dist/SHA256SUMS.sig and the public
+// key is embedded so research-engine update can verify later releases.
+// Without it the binaries cannot self-update.
+//
+// The default SQLite driver needs cgo, so platforms other than the host
+// are built with zig as the C cross-compiler; zig must be on PATH. With
+// SQLITE_DRIVER=purego every platform is built without cgo or zig. Set
+// RELEASE_TARGETS to a comma-separated subset (e.g. "linux/amd64,windows/arm64")
+// to build fewer platforms.
+//
+// Usage: mage release v0.2.0
+func Release(version string) error {
+	targets, err := selectReleaseTargets(os.Getenv("RELEASE_TARGETS"))
+	if err != nil {
+		return err
+	}
+	signingKey, err := releaseSigningKey()
+	if err != nil {
+		return err
+	}
+	var publicKey string
+	if signingKey != nil {
+		publicKey = base64.StdEncoding.EncodeToString(signingKey.Public().(ed25519.PublicKey))
+	} else {
+		fmt.Println("RELEASE_SIGNING_KEY not set: building unsigned binaries without self-update")
+	}
+	if err := os.RemoveAll(distDir); err != nil {
+		return fmt.Errorf("removing %s: %w", distDir, err)
+	}
+	if err := os.MkdirAll(distDir, 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", distDir, err)
+	}
+
+	var artifacts []string
+	for _, t := range targets {
+		out, err := buildRelease(t, version, publicKey)
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, out)
+		fmt.Printf("Built %s\n", out)
+	}
+
+	sums, err := writeChecksums(artifacts)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %s\n", sums)
+
+	if signingKey != nil {
+		data, err := os.ReadFile(sums)
+		if err != nil {
+			return err
+		}
+		sig := base64.StdEncoding.EncodeToString(ed25519.Sign(signingKey, data))
+		sigPath := sums + ".sig"
+		if err := os.WriteFile(sigPath, []byte(sig+"\n"), 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", sigPath, err)
+		}
+		fmt.Printf("Wrote %s\n", sigPath)
+	}
+	return nil
+}
+
+// Keygen prints a new Ed25519 release signing key: the private seed for
+// RELEASE_SIGNING_KEY and the public key release binaries embed. Keep the
+// seed secret; losing it means existing binaries cannot verify updates.
+func Keygen() error {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("RELEASE_SIGNING_KEY=%s\n", base64.StdEncoding.EncodeToString(priv.Seed()))
+	fmt.Printf("public key: %s\n", base64.StdEncoding.EncodeToString(pub))
+	return nil
+}
+
+// releaseSigningKey decodes RELEASE_SIGNING_KEY, or returns nil when unset.
+func releaseSigningKey() (ed25519.PrivateKey, error) {
+	v := strings.TrimSpace(os.Getenv("RELEASE_SIGNING_KEY"))
+	if v == "" {
+		return nil, nil
+	}
+	seed, err := base64.StdEncoding.DecodeString(v)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("RELEASE_SIGNING_KEY must be a base64 %d-byte Ed25519 seed (see mage keygen)", ed25519.SeedSize)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// selectReleaseTargets filters releaseTargets by a "goos/goarch,..." list.
+// An empty list selects every target.
+func selectReleaseTargets(list string) ([]releaseTarget, error) {
+	if strings.TrimSpace(list) == "" {
+		return releaseTargets, nil
+	}
+	var out []releaseTarget
+	for _, want := range strings.Split(list, ",") {
+		want = strings.TrimSpace(want)
+		found := false
+		for _, t := range releaseTargets {
+			if t.goos+"/"+t.goarch == want {
+				out = append(out, t)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown release target %q", want)
+		}
+	}
+	return out, nil
+}
+
+// buildRelease compiles the CLI for one target and returns the binary path.
+// A non-empty publicKey is embedded as the update signing key.
+func buildRelease(t releaseTarget, version, publicKey string) (string, error) {
+	name := fmt.Sprintf("%s_%s_%s_%s", binName, version, t.goos, t.goarch)
+	if t.goos == "windows" {
+		name += ".exe"
+	}
+	out := filepath.Join(distDir, name)
+
+	ldflags := "-s -w -X main.version=" + version
+	if publicKey != "" {
+		ldflags += " -X main.updatePublicKey=" + publicKey
+	}
+	tags, cgo, err := sqliteBuild()
+	if err != nil {
+		return "", err
+	}
+	env := append(os.Environ(), "GOOS="+t.goos, "GOARCH="+t.goarch)
+	if !cgo {
+		// Without cgo the binary is static on every platform.
+		env = append(env, "CGO_ENABLED=0")
+	} else if env = append(env, "CGO_ENABLED=1"); t.goos != runtime.GOOS || t.goarch != runtime.GOARCH || t.goos == "linux" {
+		if _, err := exec.LookPath("zig"); err != nil {
+			return "", fmt.Errorf("building %s/%s needs zig on PATH as the cgo cross-compiler (https://ziglang.org/download/)", t.goos, t.goarch)
+		}
+		env = append(env, "CC=zig cc -target "+t.zigTarget, "CXX=zig c++ -target "+t.zigTarget)
+	}
+	if cgo && t.goos == "linux" {
+		ldflags += " -linkmode external -extldflags -static"
+	}
+
+	cmd := exec.Command("go", "build", "-trimpath", "-tags", tags, "-ldflags", ldflags, "-o", out, cmdPkg)
+	cmd.Env = env
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("go build %s/%s: %w", t.goos, t.goarch, err)
+	}
+	return out, nil
+}
+
+// writeChecksums writes SHA256SUMS in the format sha256sum -c accepts.
+func writeChecksums(paths []string) (string, error) {
+	var b strings.Builder
+	for _, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			return "", err
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", fmt.Errorf("hashing %s: %w", p, err)
+		}
+		fmt.Fprintf(&b, "%x  %s\n", h.Sum(nil), filepath.Base(p))
+	}
+	out := filepath.Join(distDir, "SHA256SUMS")
+	if err := os.WriteFile(out, []byte(b.String()), 0o644); err != nil {
+		return "", fmt.Errorf("writing %s: %w", out, err)
+	}
+	return out, nil
+}
+
+// Test runs all Go tests with the build tags of the SQLite driver
+// SQLITE_DRIVER selects (sqlite_fts5 by default).
 func Test() error {
-	cmd := exec.Command("go", "test", "-tags", "sqlite_fts5", "./...")
+	tags, cgo, err := sqliteBuild()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("go", "test", "-tags", tags, "./...")
+	if !cgo {
+		cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
+	}
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
@@ -68,10 +309,12 @@ func Test() error {
 	return nil
 }
 
-// Clean removes build artifacts (bin/ directory).
+// Clean removes build artifacts (bin/ and dist/ directories).
 func Clean() error {
-	if err := os.RemoveAll(binDir); err != nil {
-		return fmt.Errorf("removing %s: %w", binDir, err)
+	for _, dir := range []string{binDir, distDir} {
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("removing %s: %w", dir, err)
+		}
 	}
 	fmt.Println("Cleaned build artifacts.")
 	return nil
@@ -206,60 +449,26 @@ func countWords(data []byte) int {
 	return count
 }
 
-const binPandoc = "pandoc"
-
 // Compile produces a PDF from a paper project directory using pandoc.
 // The project directory must contain numbered Markdown section files and
-// optionally a references.yaml for citation support.
-// Implements: prd007-paper-writing R6.4.
+// optionally a references.yaml for citation support. Set CSL to a
+// Citation Style Language file to format citations in a venue's style,
+// FORMAT to a comma-separated list of pdf, tex, docx, and html for other
+// outputs, and TEMPLATE to a LaTeX template such as ieee, acm, or arxiv.
+// Implements: prd007-paper-writing R6.4, R6.5, R6.6, R6.7.
 //
 // Usage: mage compile output/papers/my-survey
 func Compile(projectDir string) error {
 	if projectDir == "" {
 		return fmt.Errorf("project directory required: mage compile output/papers/my-survey")
 	}
-
-	// Collect numbered section files in order.
-	inputPaths, err := draft.SectionFiles(projectDir)
-	if err != nil {
-		return err
-	}
-	if len(inputPaths) == 0 {
-		return fmt.Errorf("no numbered section files (NN-*.md) found in %s", projectDir)
+	opts := draft.CompileOptions{CSL: os.Getenv("CSL"), Template: os.Getenv("TEMPLATE")}
+	if formats := os.Getenv("FORMAT"); formats != "" {
+		opts.Formats = strings.Split(formats, ",")
 	}
-
-	// Determine output PDF path.
-	slug := filepath.Base(projectDir)
-	outPDF := filepath.Join(projectDir, slug+".pdf")
-
-	// Build pandoc arguments.
-	args := []string{
-		"--from=markdown",
-		"--to=pdf",
-		"-o", outPDF,
+	outputs, err := draft.Compile(projectDir, opts)
+	for _, out := range outputs {
+		fmt.Printf("Compiled %s\n", out)
 	}
-
-	// Generate BibTeX from references.yaml if it exists.
-	bibPath := filepath.Join(projectDir, slug+".bib")
-	refs, err := draft.LoadReferences(projectDir)
-	if err == nil && len(refs.Papers) > 0 {
-		bibContent := draft.GenerateBibTeX(refs)
-		if err := os.WriteFile(bibPath, []byte(bibContent), 0o644); err != nil {
-			return fmt.Errorf("writing BibTeX: %w", err)
-		}
-		args = append(args, "--citeproc", "--bibliography="+bibPath)
-		fmt.Printf("Generated %s from references.yaml\n", bibPath)
-	}
-
-	args = append(args, inputPaths...)
-
-	cmd := exec.Command(binPandoc, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("pandoc: %w", err)
-	}
-
-	fmt.Printf("Compiled %s\n", outPDF)
-	return nil
+	return err
 }
@@ -12,6 +12,11 @@ type HTTPConfig struct {
 	// UserAgent is the User-Agent header sent with HTTP requests
 	// (e.g. "research-engine/0.1"). Per prd001-acquisition R5.2, prd006-search R5.4.
 	UserAgent string `json:"user_agent" yaml:"user_agent"`
+
+	// RateLimits overrides the built-in request rate for a host, in
+	// requests per second (e.g. "api.semanticscholar.org": 1). A rate of 0
+	// removes the limit for that host.
+	RateLimits map[string]float64 `json:"rate_limits,omitempty" yaml:"rate_limits,omitempty"`
 }
 
 // SearchConfig holds settings for the search stage.
@@ -45,11 +50,17 @@ type SearchConfig struct {
 	// Per prd008-patent-search R1.3, R1.4.
 	PatentsViewAPIKey string `json:"patentsview_api_key,omitempty" yaml:"patentsview_api_key,omitempty"`
 
-	// InterBackendDelay is the delay between API calls to different backends (default 1s).
-	InterBackendDelay time.Duration `json:"inter_backend_delay" yaml:"inter_backend_delay"`
+	// EnableLens controls whether the Lens.org scholarly and patent backend is used.
+	EnableLens bool `json:"enable_lens" yaml:"enable_lens"`
+
+	// LensAPIKey is the bearer token for the Lens.org API.
+	LensAPIKey string `json:"lens_api_key,omitempty" yaml:"lens_api_key,omitempty"`
 
 	// RecencyBiasWindow is the time window for boosting recent papers (default 2 years).
 	RecencyBiasWindow time.Duration `json:"recency_bias_window" yaml:"recency_bias_window"`
+
+	// KeepRaw retains each backend's raw per-result payload in SearchResult.Raw.
+	KeepRaw bool `json:"keep_raw,omitempty" yaml:"keep_raw,omitempty"`
 }
 
 // AcquisitionConfig holds settings for the acquisition stage.
@@ -57,11 +68,55 @@ type SearchConfig struct {
 type AcquisitionConfig struct {
 	HTTPConfig `yaml:",inline"`
 
-	// DownloadDelay is the delay between consecutive downloads (default 1s).
+	// DownloadDelay is the minimum interval between requests to a host
+	// that has no built-in rate limit (default 1s).
 	DownloadDelay time.Duration `json:"download_delay" yaml:"download_delay"`
 
 	// PapersDir is the base directory for papers (contains raw/, metadata/, markdown/).
 	PapersDir string `json:"papers_dir" yaml:"papers_dir"`
+
+	// VerifyXref additionally checks that each downloaded PDF's startxref
+	// trailer points at its cross-reference table, rejecting truncated files.
+	VerifyXref bool `json:"verify_xref,omitempty" yaml:"verify_xref,omitempty"`
+
+	// MetadataOnly records each paper's metadata without downloading its
+	// PDF, for works that cannot be downloaded (R1.8).
+	MetadataOnly bool `json:"metadata_only,omitempty" yaml:"metadata_only,omitempty"`
+
+	// Force re-acquires papers that already exist, replacing the PDF and
+	// metadata (R2.9). The existing PDF is kept if the download fails.
+	Force bool `json:"force,omitempty" yaml:"force,omitempty"`
+
+	// ProxyPrefix is an institutional proxy prefix, such as an EZproxy
+	// login URL ending in "?url=", prepended to publisher download URLs
+	// (R2.10). Open-access sources are downloaded directly.
+	ProxyPrefix string `json:"proxy_prefix,omitempty" yaml:"proxy_prefix,omitempty"`
+
+	// Headers are extra HTTP headers sent with PDF downloads, for example
+	// an access token issued by a publisher.
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+
+	// CookieFile is a cookies.txt file (Netscape format) with the session
+	// cookies of a library or publisher login.
+	CookieFile string `json:"cookie_file,omitempty" yaml:"cookie_file,omitempty"`
+
+	// OPSKey and OPSSecret are the EPO Open Patent Services consumer key
+	// and secret used for non-US patent metadata (prd008 R4.7).
+	OPSKey    string `json:"ops_key,omitempty" yaml:"ops_key,omitempty"`
+	OPSSecret string `json:"ops_secret,omitempty" yaml:"ops_secret,omitempty"`
+
+	// Email is the contact address Unpaywall requires with each request
+	// (R2.12). Without it, open-access re-checks use OpenAlex only.
+	Email string `json:"email,omitempty" yaml:"email,omitempty"`
+
+	// MaxFileSize aborts a download larger than this many bytes; zero
+	// means no limit (R2.13).
+	MaxFileSize int64 `json:"max_file_size,omitempty" yaml:"max_file_size,omitempty"`
+
+	// MaxTotalBytes is a quota on the papers directory: a download that
+	// would take it past this many bytes is aborted and the batch stops.
+	// Zero means no quota (R2.13).
+	MaxTotalBytes int64 `json:"max_total_bytes,omitempty" yaml:"max_total_bytes,omitempty"`
 }
 
 // ConversionBackend identifies the PDF conversion tool.
@@ -72,12 +127,14 @@ const (
 	BackendGROBID     ConversionBackend = "grobid"
 	BackendPdftotext  ConversionBackend = "pdftotext"
 	BackendMarkitdown ConversionBackend = "markitdown"
+	BackendNative     ConversionBackend = "native"
 )
 
 // ConversionConfig holds settings for the conversion stage.
 // Per prd002-conversion R5.1-R5.2.
 type ConversionConfig struct {
-	// Backend selects the conversion tool: grobid, pdftotext, or markitdown.
+	// Backend selects the conversion tool: grobid, pdftotext, markitdown,
+	// or native.
 	Backend ConversionBackend `json:"backend" yaml:"backend"`
 
 	// PapersDir is the base directory for papers (contains raw/, metadata/, markdown/).
@@ -86,6 +143,15 @@ type ConversionConfig struct {
 
 // AIConfig holds shared settings for stages that call a Generative AI API.
 type AIConfig struct {
+	// Backend names the API protocol: "claude" (default) or "openai" for
+	// an OpenAI-compatible chat-completions API. Extraction also accepts
+	// "ollama", and "replay" to answer only from recorded responses.
+	Backend string `json:"backend,omitempty" yaml:"backend,omitempty"`
+
+	// BaseURL is the API base URL for the openai backend (default
+	// https://api.openai.com/v1).
+	BaseURL string `json:"base_url,omitempty" yaml:"base_url,omitempty"`
+
 	// Model is the AI model identifier (e.g. "claude-sonnet-4-5-20250929").
 	Model string `json:"model" yaml:"model"`
 
@@ -97,7 +163,7 @@ type AIConfig struct {
 }
 
 // ExtractionConfig holds settings for the extraction stage.
-// Per prd003-extraction R5.2-R5.5.
+// Per prd003-extraction R5.2-R5.5, R5.8, R5.11, R6.6, R6.7.
 type ExtractionConfig struct {
 	AIConfig `yaml:",inline"`
 
@@ -106,6 +172,54 @@ type ExtractionConfig struct {
 
 	// KnowledgeDir is the base directory for knowledge output (contains extracted/).
 	KnowledgeDir string `json:"knowledge_dir" yaml:"knowledge_dir"`
+
+	// MinQuality skips papers whose conversion quality score is below it
+	// (0 extracts every paper).
+	MinQuality float64 `json:"min_quality,omitempty" yaml:"min_quality,omitempty"`
+
+	// MaxConcurrentCalls bounds the AI calls in flight across all papers
+	// and sections of a run (default 1, serial).
+	MaxConcurrentCalls int `json:"max_concurrent_calls,omitempty" yaml:"max_concurrent_calls,omitempty"`
+
+	// Prompt selects the extraction prompt template: a file path, or the
+	// name of a template in knowledge/prompts/. Empty uses the built-in
+	// prompt.
+	Prompt string `json:"prompt,omitempty" yaml:"prompt,omitempty"`
+
+	// PatentPrompt selects the prompt template for converted patents in
+	// the same way. Empty uses the built-in patent prompt.
+	PatentPrompt string `json:"patent_prompt,omitempty" yaml:"patent_prompt,omitempty"`
+
+	// Sections restricts extraction to sections whose heading, or the
+	// heading they fall under, contains one of these names (case
+	// insensitive). Empty extracts every section except back matter.
+	Sections []string `json:"sections,omitempty" yaml:"sections,omitempty"`
+
+	// SkipSections excludes sections whose heading, or the heading they
+	// fall under, contains one of these names (case insensitive).
+	SkipSections []string `json:"skip_sections,omitempty" yaml:"skip_sections,omitempty"`
+
+	// MaxSectionTokens is the estimated size above which a section is split
+	// into overlapping parts before extraction (default 6000).
+	MaxSectionTokens int `json:"max_section_tokens,omitempty" yaml:"max_section_tokens,omitempty"`
+
+	// AllowPartial keeps the items of a paper's other sections when some
+	// of its sections fail, recording the failures in the result instead
+	// of failing the paper.
+	AllowPartial bool `json:"allow_partial,omitempty" yaml:"allow_partial,omitempty"`
+
+	// NoCache disables the per-section response cache in
+	// knowledge/cache/, so every section is sent to the AI backend.
+	NoCache bool `json:"no_cache,omitempty" yaml:"no_cache,omitempty"`
+
+	// Summarize asks the AI backend for a structured summary of each
+	// paper after its items are extracted.
+	Summarize bool `json:"summarize,omitempty" yaml:"summarize,omitempty"`
+
+	// ReplayDir holds recorded AI responses: calls with a recording are
+	// replayed from it and the others recorded to it. With the replay
+	// backend every call must have a recording.
+	ReplayDir string `json:"replay_dir,omitempty" yaml:"replay_dir,omitempty"`
 }
 
 // KnowledgeBaseConfig holds settings for the knowledge base stage.
@@ -116,6 +230,27 @@ type KnowledgeBaseConfig struct {
 
 	// MaxResults is the default maximum number of query results (default 20).
 	MaxResults int `json:"max_results" yaml:"max_results"`
+
+	// VersionPolicy picks the canonical member of a preprint/published pair:
+	// "published" (default) or "preprint".
+	VersionPolicy string `json:"version_policy,omitempty" yaml:"version_policy,omitempty"`
+
+	// VenueRankings lists venue ranking files (CORE conference CSV exports
+	// or Scimago journal lists) applied to venues on ingest.
+	VenueRankings []string `json:"venue_rankings,omitempty" yaml:"venue_rankings,omitempty"`
+
+	// EmbeddingBackend selects the embedding API for semantic search:
+	// "openai" for an OpenAI-compatible embeddings API or "ollama" for a
+	// local Ollama server. Empty leaves items unembedded. Per
+	// prd004-knowledge-base R12.1.
+	EmbeddingBackend string `json:"embedding_backend,omitempty" yaml:"embedding_backend,omitempty"`
+
+	// EmbeddingModel is the embedding model (default text-embedding-3-small
+	// for openai, nomic-embed-text for ollama).
+	EmbeddingModel string `json:"embedding_model,omitempty" yaml:"embedding_model,omitempty"`
+
+	// EmbeddingBaseURL is the API base URL of the embedding backend.
+	EmbeddingBaseURL string `json:"embedding_base_url,omitempty" yaml:"embedding_base_url,omitempty"`
 }
 
 // PipelineConfig groups all stage configurations for the pipeline.
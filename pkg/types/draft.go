@@ -9,10 +9,15 @@ type Author struct {
 
 	// Affiliation is the author's institutional affiliation.
 	Affiliation string `json:"affiliation,omitempty" yaml:"affiliation,omitempty"`
+
+	// Email is the author's contact address, printed by venue templates
+	// (R2.4).
+	Email string `json:"email,omitempty" yaml:"email,omitempty"`
 }
 
-// TitlePageMeta holds the YAML frontmatter from 00-title-page.md.
-// Per prd007-paper-writing R2.1-R2.3.
+// TitlePageMeta holds the YAML frontmatter from 00-title-page.md, or the
+// project's paper.yaml when it has one.
+// Per prd007-paper-writing R2.1-R2.4.
 type TitlePageMeta struct {
 	// Title is the paper title.
 	Title string `json:"title" yaml:"title"`
@@ -70,6 +75,18 @@ type Outline struct {
 	Sections []OutlineSection `json:"sections" yaml:"sections"`
 }
 
+// ReferenceType classifies a cited work for its bibliography entry.
+// Per prd007-paper-writing R5.5.
+type ReferenceType string
+
+const (
+	// RefArticle is a journal article, the type of an entry without one.
+	RefArticle       ReferenceType = "article"
+	RefInProceedings ReferenceType = "inproceedings"
+	RefPatent        ReferenceType = "patent"
+	RefMisc          ReferenceType = "misc"
+)
+
 // ReferenceEntry records a cited paper in references.yaml.
 // Per prd007-paper-writing R5.1.
 type ReferenceEntry struct {
@@ -90,6 +107,13 @@ type ReferenceEntry struct {
 
 	// Venue is the journal or conference (optional).
 	Venue string `json:"venue,omitempty" yaml:"venue,omitempty"`
+
+	// Type is the kind of work: article, inproceedings, patent, or misc.
+	// Empty is article (R5.5).
+	Type ReferenceType `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// DOI is the work's DOI (optional).
+	DOI string `json:"doi,omitempty" yaml:"doi,omitempty"`
 }
 
 // ReferencesFile holds all cited papers from references.yaml.
@@ -2,6 +2,11 @@
 
 package types
 
+import (
+	"strings"
+	"time"
+)
+
 // KnowledgeItemType categorizes a knowledge item extracted from a paper.
 // Per prd003-extraction R1.1.
 type KnowledgeItemType string
@@ -30,6 +35,16 @@ type BibliographyEntry struct {
 
 	// Venue is the journal, conference, or publisher.
 	Venue string `json:"venue" yaml:"venue"`
+
+	// DOI, ArxivID, and URL are the identifiers written in the entry; the
+	// arXiv ID is versionless. Per prd003-extraction R3.6.
+	DOI     string `json:"doi,omitempty" yaml:"doi,omitempty"`
+	ArxivID string `json:"arxiv_id,omitempty" yaml:"arxiv_id,omitempty"`
+	URL     string `json:"url,omitempty" yaml:"url,omitempty"`
+
+	// PaperID is the ID of the acquired paper in the corpus the entry
+	// names, matched by DOI or arXiv ID; empty when it is not acquired.
+	PaperID string `json:"paper_id,omitempty" yaml:"paper_id,omitempty"`
 }
 
 // Citation represents an inline reference within a KnowledgeItem's content,
@@ -46,10 +61,49 @@ type Citation struct {
 
 	// Context is the surrounding text where the citation appears.
 	Context string `json:"context" yaml:"context"`
+
+	// PaperID is the acquired paper the linked bibliography entry names,
+	// if any. Per prd003-extraction R3.6.
+	PaperID string `json:"paper_id,omitempty" yaml:"paper_id,omitempty"`
+}
+
+// Metric is the measurement a result item reports, so results can be
+// compared across papers. Per prd003-extraction R1.5.
+type Metric struct {
+	// Name is the lowercase metric name (e.g. "accuracy", "bleu", "f1").
+	Name string `json:"name" yaml:"name"`
+
+	// Value is the reported number, as written in the paper.
+	Value float64 `json:"value" yaml:"value"`
+
+	// Unit is the unit of Value, such as "%" or "ms"; empty when unitless.
+	Unit string `json:"unit,omitempty" yaml:"unit,omitempty"`
+
+	// Dataset is the benchmark or dataset the value was measured on.
+	Dataset string `json:"dataset,omitempty" yaml:"dataset,omitempty"`
+
+	// Baseline is the method the result is compared against, if any.
+	Baseline string `json:"baseline,omitempty" yaml:"baseline,omitempty"`
+
+	// BaselineValue is the baseline's value of the same metric, if given.
+	BaselineValue *float64 `json:"baseline_value,omitempty" yaml:"baseline_value,omitempty"`
+}
+
+// SectionError records a section that could not be extracted in a partial
+// ExtractionResult. Per prd003-extraction R6.9.
+type SectionError struct {
+	// Section is the heading of the section.
+	Section string `json:"section" yaml:"section"`
+
+	// Page is the page the section begins on.
+	Page int `json:"page" yaml:"page"`
+
+	// Error is the failure message.
+	Error string `json:"error" yaml:"error"`
 }
 
 // KnowledgeItem is a typed extraction from a paper with provenance.
-// Per prd003-extraction R1.1-R1.4, R2.1-R2.5, R3.1, R3.3-R3.4, R4.1-R4.4.
+// Per prd003-extraction R1.1-R1.5, R2.1-R2.5, R3.1, R3.3-R3.4, R4.1-R4.4, R5.15.
 type KnowledgeItem struct {
 	// ID is a stable identifier for this item, consistent across re-extractions
 	// of unchanged content. Per R2.5.
@@ -61,6 +115,11 @@ type KnowledgeItem struct {
 	// Content preserves the original language from the source paper. Per R1.3.
 	Content string `json:"content" yaml:"content"`
 
+	// ResolvedContent is Content with self-references such as "our method"
+	// replaced by the paper's method name. Empty when nothing was replaced.
+	// Per R7.2.
+	ResolvedContent string `json:"resolved_content,omitempty" yaml:"resolved_content,omitempty"`
+
 	// PaperID matches the Paper record from acquisition. Per R2.1.
 	PaperID string `json:"paper_id" yaml:"paper_id"`
 
@@ -78,10 +137,55 @@ type KnowledgeItem struct {
 
 	// Citations lists inline references cited within this item's content. Per R3.1, R3.3, R3.4.
 	Citations []Citation `json:"citations,omitempty" yaml:"citations,omitempty"`
+
+	// Metric is the structured measurement of a result item; nil for other
+	// types and for results without a single number. Per R1.5.
+	Metric *Metric `json:"metric,omitempty" yaml:"metric,omitempty"`
+
+	// PatentClaim numbers a claim item extracted from a patent's claims;
+	// nil for other items. Per R5.15.
+	PatentClaim *PatentClaim `json:"patent_claim,omitempty" yaml:"patent_claim,omitempty"`
+
+	// Curated marks an item the researcher added or edited by hand; it
+	// outlives re-extraction of its paper. Per prd004-knowledge-base R17.1.
+	Curated bool `json:"curated,omitempty" yaml:"curated,omitempty"`
+
+	// Status is the researcher's review of the item; empty in extraction
+	// files, where every item is unverified. Per prd004-knowledge-base
+	// R17.2.
+	Status ItemStatus `json:"status,omitempty" yaml:"status,omitempty"`
+
+	// StatusNote is the researcher's reason for the status, such as why a
+	// claim is disputed.
+	StatusNote string `json:"status_note,omitempty" yaml:"status_note,omitempty"`
+}
+
+// ItemStatus is the review status of a knowledge item. Per
+// prd004-knowledge-base R17.2.
+type ItemStatus string
+
+const (
+	StatusUnverified ItemStatus = "unverified"
+	StatusVerified   ItemStatus = "verified"
+	StatusDisputed   ItemStatus = "disputed"
+)
+
+// PatentClaim identifies one claim of a patent. Per prd003-extraction
+// R5.15.
+type PatentClaim struct {
+	// Number is the claim number.
+	Number int `json:"number" yaml:"number"`
+
+	// DependsOn is the number of the claim a dependent claim refers to;
+	// zero for an independent claim.
+	DependsOn int `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+
+	// Independent marks a claim that depends on no other claim.
+	Independent bool `json:"independent" yaml:"independent"`
 }
 
 // ExtractionResult holds the output of extracting knowledge from a single paper.
-// Per prd003-extraction R5.6, R3.2, R4.3.
+// Per prd003-extraction R5.6, R3.2, R4.3, R9.1.
 type ExtractionResult struct {
 	// PaperID identifies the source paper.
 	PaperID string `json:"paper_id" yaml:"paper_id"`
@@ -95,6 +199,143 @@ type ExtractionResult struct {
 	// PaperTags are paper-level topic tags summarizing the overall topics. Per R4.3.
 	PaperTags []string `json:"paper_tags" yaml:"paper_tags"`
 
+	// Summary is the paper's structured summary, generated when
+	// extraction runs with summaries enabled. Per R9.1.
+	Summary *PaperSummary `json:"summary,omitempty" yaml:"summary,omitempty"`
+
+	// Funding is the funding statement from the paper's acknowledgments or
+	// funding section. Per R8.1.
+	Funding string `json:"funding,omitempty" yaml:"funding,omitempty"`
+
+	// Grants lists the grant numbers named in the funding statement. Per R8.2.
+	Grants []string `json:"grants,omitempty" yaml:"grants,omitempty"`
+
+	// ConflictOfInterest is the paper's conflict-of-interest or competing
+	// interests disclosure. Per R8.3.
+	ConflictOfInterest string `json:"conflict_of_interest,omitempty" yaml:"conflict_of_interest,omitempty"`
+
+	// PromptVersion names the extraction prompt the items were extracted
+	// with; a result whose version differs from the selected prompt is
+	// re-extracted. Per R6.8.
+	PromptVersion string `json:"prompt_version,omitempty" yaml:"prompt_version,omitempty"`
+
+	// Partial marks a result extracted with some sections failed; their
+	// errors are in Errors, and the next batch extraction retries the
+	// paper. Per R6.9.
+	Partial bool `json:"partial,omitempty" yaml:"partial,omitempty"`
+
+	// Errors lists the sections whose AI call failed or whose response
+	// was invalid in a partial result. Per R6.9.
+	Errors []SectionError `json:"errors,omitempty" yaml:"errors,omitempty"`
+
 	// Error records an extraction failure message. Empty on success.
 	Error string `json:"error,omitempty" yaml:"error,omitempty"`
 }
+
+// PaperSummary is a 3-5 sentence summary of a paper in three parts.
+// Per prd003-extraction R9.1.
+type PaperSummary struct {
+	// Problem is the question or gap the paper addresses.
+	Problem string `json:"problem" yaml:"problem"`
+
+	// Approach is how the paper addresses it.
+	Approach string `json:"approach" yaml:"approach"`
+
+	// Findings are the paper's main results.
+	Findings string `json:"findings" yaml:"findings"`
+}
+
+// Text returns the summary as one paragraph: problem, approach, then
+// findings.
+func (s *PaperSummary) Text() string {
+	if s == nil {
+		return ""
+	}
+	var parts []string
+	for _, p := range []string{s.Problem, s.Approach, s.Findings} {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Curation records the researcher's hand edits to one paper's knowledge
+// items. It is kept apart from the extraction file and applied over it
+// whenever the paper is stored, so the edits survive re-extraction. Per
+// prd004-knowledge-base R17.1.
+type Curation struct {
+	// PaperID is the paper the items belong to.
+	PaperID string `json:"paper_id" yaml:"paper_id"`
+
+	// Items are items added by hand and extracted items as edited; an item
+	// here replaces the extracted item with the same ID.
+	Items []KnowledgeItem `json:"items,omitempty" yaml:"items,omitempty"`
+
+	// Deleted lists the IDs of extracted items removed by hand.
+	Deleted []string `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+
+	// Reviews records the items the researcher verified or disputed. Per
+	// prd004-knowledge-base R17.2.
+	Reviews []ItemReview `json:"reviews,omitempty" yaml:"reviews,omitempty"`
+}
+
+// ItemReview is the researcher's review of one knowledge item.
+type ItemReview struct {
+	// ItemID is the reviewed item.
+	ItemID string `json:"item_id" yaml:"item_id"`
+
+	// Status is verified or disputed.
+	Status ItemStatus `json:"status" yaml:"status"`
+
+	// Note is the reason for the status.
+	Note string `json:"note,omitempty" yaml:"note,omitempty"`
+
+	// Reviewed is when the status was set.
+	Reviewed time.Time `json:"reviewed" yaml:"reviewed"`
+}
+
+// AbsenceNote records a negative finding: a planned survey topic that the
+// listed searches and retrievals found nothing for, so the survey can state
+// what was searched. Per prd004-knowledge-base R9.1.
+type AbsenceNote struct {
+	// Topic is the survey topic that was looked for.
+	Topic string `json:"topic" yaml:"topic"`
+
+	// Note is the researcher's comment on the finding.
+	Note string `json:"note,omitempty" yaml:"note,omitempty"`
+
+	// Recorded is when the note was last updated.
+	Recorded time.Time `json:"recorded" yaml:"recorded"`
+
+	// Queries lists the searches and retrievals that found nothing, with
+	// their provenance.
+	Queries []AbsenceQuery `json:"queries" yaml:"queries"`
+}
+
+// AbsenceQuery is one search or retrieval behind an AbsenceNote.
+type AbsenceQuery struct {
+	// Kind is "search" for a saved search query file or "retrieve" for a
+	// knowledge-base retrieval.
+	Kind string `json:"kind" yaml:"kind"`
+
+	// Query is the query text, or for a query file its free text,
+	// keywords, author, and date range.
+	Query string `json:"query" yaml:"query"`
+
+	// File is the search query file, for searches.
+	File string `json:"file,omitempty" yaml:"file,omitempty"`
+
+	// Run is when the search ran or the retrieval was checked.
+	Run time.Time `json:"run" yaml:"run"`
+
+	// Results is the number of results returned.
+	Results int `json:"results" yaml:"results"`
+
+	// Kept is the number of search results triaged as keep.
+	Kept int `json:"kept,omitempty" yaml:"kept,omitempty"`
+
+	// BackendErrors lists the search backends that failed, since their
+	// silence is not evidence of absence.
+	BackendErrors []string `json:"backend_errors,omitempty" yaml:"backend_errors,omitempty"`
+}
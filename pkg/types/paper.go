@@ -15,6 +15,74 @@ const (
 	ConversionFailed  ConversionStatus = "failed"
 )
 
+// QualityLevel grades a conversion's quality score.
+// Per prd002-conversion R4.2.
+type QualityLevel string
+
+const (
+	QualityGood QualityLevel = "good"
+	QualityFair QualityLevel = "fair"
+	QualityPoor QualityLevel = "poor"
+)
+
+// ConversionQuality records how usable a paper's converted Markdown is,
+// so extraction can warn on or skip poor conversions.
+// Per prd002-conversion R4.1.
+type ConversionQuality struct {
+	// Score weighs the measures below into a value from 0 to 1.
+	Score float64      `json:"score" yaml:"score"`
+	Level QualityLevel `json:"level" yaml:"level"`
+
+	// WordRatio is the share of Words found in an English dictionary;
+	// garbled text, merged words, and lost ligatures lower it.
+	WordRatio float64 `json:"word_ratio" yaml:"word_ratio"`
+	Words     int     `json:"words" yaml:"words"`
+
+	// Headings counts Markdown headings; References reports whether a
+	// reference section heading was found.
+	Headings   int  `json:"headings" yaml:"headings"`
+	References bool `json:"references" yaml:"references"`
+
+	// Pages counts <!-- page N --> markers and PageCoverage is the share
+	// of those pages with text. Both are zero for output without markers.
+	Pages        int     `json:"pages,omitempty" yaml:"pages,omitempty"`
+	PageCoverage float64 `json:"page_coverage,omitempty" yaml:"page_coverage,omitempty"`
+}
+
+// AcquisitionStatus marks a paper recorded without its PDF.
+// Per prd001-acquisition R1.7.
+type AcquisitionStatus string
+
+const (
+	// AcquisitionNoPDF marks a book or chapter with no open-access PDF;
+	// only its metadata was recorded.
+	AcquisitionNoPDF AcquisitionStatus = "no_pdf"
+
+	// AcquisitionMetadataOnly marks a paper acquired with --metadata-only:
+	// its metadata was recorded and its PDF deliberately not downloaded.
+	// Per prd001-acquisition R1.8.
+	AcquisitionMetadataOnly AcquisitionStatus = "metadata_only"
+
+	// AcquisitionPaywalled marks a paper whose download returned an HTML
+	// page, typically a publisher paywall, instead of a PDF. Acquiring it
+	// again re-checks for an open-access copy.
+	// Per prd001-acquisition R2.11.
+	AcquisitionPaywalled AcquisitionStatus = "paywalled"
+)
+
+// VenueType classifies where a paper was published.
+// Per prd001-acquisition R3.9.
+type VenueType string
+
+const (
+	VenueJournal    VenueType = "journal"
+	VenueConference VenueType = "conference"
+	VenueWorkshop   VenueType = "workshop"
+	VenuePreprint   VenueType = "preprint"
+	VenueBook       VenueType = "book"
+	VenueOther      VenueType = "other"
+)
+
 // Paper holds metadata and file paths for an acquired paper.
 // Per prd001-acquisition R3.2: source URL, local PDF path, title, authors,
 // date, abstract, and conversion status.
@@ -40,9 +108,142 @@ type Paper struct {
 	// Abstract is the paper abstract.
 	Abstract string `json:"abstract" yaml:"abstract"`
 
-	// Source identifies which backend provided the PDF (e.g. "arxiv", "doi", "openalex", "url").
+	// DOI is the paper's DOI when known, including the published DOI that
+	// arXiv records for a preprint.
+	DOI string `json:"doi,omitempty" yaml:"doi,omitempty"`
+
+	// ArxivID is the versionless arXiv identifier when the paper has a
+	// preprint, including one OpenAlex links to a published DOI.
+	ArxivID string `json:"arxiv_id,omitempty" yaml:"arxiv_id,omitempty"`
+
+	// PMID and PMCID are the PubMed and PubMed Central identifiers of a
+	// biomedical paper, when known.
+	PMID  string `json:"pmid,omitempty" yaml:"pmid,omitempty"`
+	PMCID string `json:"pmcid,omitempty" yaml:"pmcid,omitempty"`
+
+	// ISBN is the ISBN-13 of a book, or of the book a chapter belongs to.
+	ISBN string `json:"isbn,omitempty" yaml:"isbn,omitempty"`
+
+	// Source identifies which backend provided the PDF (e.g. "arxiv", "doi", "openalex", "pmc", "url", "zotero").
 	Source string `json:"source,omitempty" yaml:"source,omitempty"`
 
+	// Status is AcquisitionNoPDF, AcquisitionMetadataOnly, or
+	// AcquisitionPaywalled for a record without a PDF and empty when the
+	// PDF was downloaded.
+	Status AcquisitionStatus `json:"status,omitempty" yaml:"status,omitempty"`
+
 	// ConversionStatus tracks whether the PDF has been converted to Markdown.
 	ConversionStatus ConversionStatus `json:"conversion_status" yaml:"conversion_status"`
+
+	// ConversionQuality scores the converted Markdown; nil until the paper
+	// is converted. Per prd002-conversion R4.1.
+	ConversionQuality *ConversionQuality `json:"conversion_quality,omitempty" yaml:"conversion_quality,omitempty"`
+
+	// Language is the ISO 639-1 code of the language conversion detected
+	// the paper to be written in (e.g. "en", "de"). Per
+	// prd002-conversion R5.1.
+	Language string `json:"language,omitempty" yaml:"language,omitempty"`
+
+	// SHA256 is the hex SHA-256 checksum of the downloaded PDF.
+	SHA256 string `json:"sha256,omitempty" yaml:"sha256,omitempty"`
+
+	// DuplicateOf names the paper whose PDF this identifier also resolved
+	// to. Such a record has no PDF of its own.
+	DuplicateOf string `json:"duplicate_of,omitempty" yaml:"duplicate_of,omitempty"`
+
+	// Aliases lists the IDs of duplicate records that point at this paper.
+	Aliases []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+
+	// Funders lists the funding bodies Crossref records for the paper.
+	// Per prd001-acquisition R3.7.
+	Funders []Funder `json:"funders,omitempty" yaml:"funders,omitempty"`
+
+	// AuthorDetails lists the authors with the ORCID and affiliations
+	// OpenAlex records for each authorship, in author order. Empty when
+	// OpenAlex has no record of the paper. Per prd001-acquisition R3.8.
+	AuthorDetails []PaperAuthor `json:"author_details,omitempty" yaml:"author_details,omitempty"`
+
+	// Venue is the journal, conference, or preprint server the paper
+	// appeared in, from OpenAlex, Crossref, or arXiv.
+	// Per prd001-acquisition R3.9.
+	Venue *Venue `json:"venue,omitempty" yaml:"venue,omitempty"`
+
+	// ZoteroKey is the key of the Zotero item the paper was imported from.
+	// Per prd001-acquisition R1.9.
+	ZoteroKey string `json:"zotero_key,omitempty" yaml:"zotero_key,omitempty"`
+
+	// Provenance lists the HTTP requests of the paper's latest
+	// acquisition in order: the resolvers and metadata APIs asked, each
+	// download and redirect, and which response was saved as the PDF.
+	// Per prd001-acquisition R3.10.
+	Provenance []ProvenanceStep `json:"provenance,omitempty" yaml:"provenance,omitempty"`
+}
+
+// ProvenanceStep is one HTTP request made while acquiring a paper.
+type ProvenanceStep struct {
+	// Time is when the request was sent.
+	Time time.Time `json:"time" yaml:"time"`
+
+	// Method and URL identify the request. Contact emails and API keys in
+	// the query are redacted.
+	Method string `json:"method" yaml:"method"`
+	URL    string `json:"url" yaml:"url"`
+
+	// Status is the HTTP status code, or zero when no response arrived.
+	Status int `json:"status,omitempty" yaml:"status,omitempty"`
+
+	// Error is the transport error when no response arrived.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// Saved marks the response written to the paper's PDF file.
+	Saved bool `json:"saved,omitempty" yaml:"saved,omitempty"`
+}
+
+// Venue is where a paper was published.
+type Venue struct {
+	// Name is the venue's display name, such as a journal title or
+	// "Proceedings of ...".
+	Name string `json:"name" yaml:"name"`
+
+	// Type is journal, conference, workshop, preprint, book, or other.
+	Type VenueType `json:"type" yaml:"type"`
+
+	// ISSN is the venue's linking ISSN, when it has one.
+	ISSN string `json:"issn,omitempty" yaml:"issn,omitempty"`
+}
+
+// PaperAuthor is one authorship of a paper.
+type PaperAuthor struct {
+	// Name is the author's display name.
+	Name string `json:"name" yaml:"name"`
+
+	// ORCID is the author's bare ORCID iD (0000-0002-1825-0097), when known.
+	ORCID string `json:"orcid,omitempty" yaml:"orcid,omitempty"`
+
+	// Affiliations lists the institutions the author gave on this paper.
+	Affiliations []Affiliation `json:"affiliations,omitempty" yaml:"affiliations,omitempty"`
+}
+
+// Affiliation is an institution an author was affiliated with on a paper.
+type Affiliation struct {
+	// Name is the institution's display name.
+	Name string `json:"name" yaml:"name"`
+
+	// ROR is the institution's Research Organization Registry ID URL.
+	ROR string `json:"ror,omitempty" yaml:"ror,omitempty"`
+
+	// Country is the ISO 3166-1 alpha-2 country code.
+	Country string `json:"country,omitempty" yaml:"country,omitempty"`
+}
+
+// Funder is a funding body credited by a paper, with its award numbers.
+type Funder struct {
+	// Name is the funder's name as registered with Crossref.
+	Name string `json:"name" yaml:"name"`
+
+	// DOI is the funder's Open Funder Registry DOI, when known.
+	DOI string `json:"doi,omitempty" yaml:"doi,omitempty"`
+
+	// Awards lists the grant or award numbers credited to this funder.
+	Awards []string `json:"awards,omitempty" yaml:"awards,omitempty"`
 }
@@ -41,4 +41,53 @@ type SearchResult struct {
 	// PreferredAcquisitionID is the identifier the acquisition stage should use
 	// to download this paper: arXiv ID if available, then DOI, then URL.
 	PreferredAcquisitionID string `json:"preferred_acquisition_id" yaml:"preferred_acquisition_id"`
+
+	// Raw maps backend name to the verbatim JSON or XML record that backend
+	// returned for this result. Populated only when SearchConfig.KeepRaw is
+	// set, so tooling can inspect fields the unified result drops.
+	Raw map[string]string `json:"raw,omitempty" yaml:"raw,omitempty"`
+
+	// Expansions lists the query variants that found this result when the
+	// search ran with query expansion ("original", "acronym", "concept: X").
+	Expansions []string `json:"expansions,omitempty" yaml:"expansions,omitempty"`
+
+	// Triage is the researcher's keep/reject decision on this result,
+	// recorded in the query file. Nil until the result is annotated.
+	Triage *Triage `json:"triage,omitempty" yaml:"triage,omitempty"`
+}
+
+// Triage is a shortlisting decision on a search result.
+type Triage struct {
+	// Status is "keep" or "reject".
+	Status string `json:"status" yaml:"status"`
+
+	// Note is a free-text reason for the decision.
+	Note string `json:"note,omitempty" yaml:"note,omitempty"`
+
+	// Updated is when the decision was last recorded.
+	Updated time.Time `json:"updated" yaml:"updated"`
+
+	// Screening holds the reviewers' independent include/exclude
+	// decisions (screen decide). Status follows their consensus and stays
+	// empty while reviewers disagree.
+	Screening []ScreeningDecision `json:"screening,omitempty" yaml:"screening,omitempty"`
+
+	// Resolved is set when Status was decided by screen resolve after the
+	// reviewers disagreed.
+	Resolved bool `json:"resolved,omitempty" yaml:"resolved,omitempty"`
+}
+
+// ScreeningDecision is one reviewer's title/abstract screening decision.
+type ScreeningDecision struct {
+	// Reviewer names the person who screened the result.
+	Reviewer string `json:"reviewer" yaml:"reviewer"`
+
+	// Decision is "include" or "exclude".
+	Decision string `json:"decision" yaml:"decision"`
+
+	// Note is a free-text reason for the decision.
+	Note string `json:"note,omitempty" yaml:"note,omitempty"`
+
+	// Updated is when the decision was recorded.
+	Updated time.Time `json:"updated" yaml:"updated"`
 }